@@ -0,0 +1,12 @@
+-- +migrate Up
+
+-- the account is deactivated when the deletion is requested, and only hard deleted after the grace period has passed
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_requested_at TIMESTAMPTZ DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_deletion_requested_at ON users(deletion_requested_at) WHERE deletion_requested_at IS NOT NULL;
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_users_deletion_requested_at;
+
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_requested_at;
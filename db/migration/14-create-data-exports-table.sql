@@ -0,0 +1,26 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    archive BYTEA DEFAULT NULL,
+    download_token_hash TEXT DEFAULT NULL,
+    expires_at TIMESTAMPTZ DEFAULT NULL,
+    completed_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+
+    CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_id ON data_exports(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_data_exports_download_token_hash ON data_exports(download_token_hash);
+
+-- each user can only have one export being prepared at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_data_exports_user_id_in_progress ON data_exports(user_id)
+    WHERE status IN ('pending', 'processing');
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS data_exports;
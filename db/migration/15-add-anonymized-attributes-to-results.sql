@@ -0,0 +1,11 @@
+-- +migrate Up
+
+-- when the account is deleted, the results can be kept for package analysis by detaching them from the child and
+-- the creator. Only these coarse attributes of the child are kept, so the deleted person is no longer identifiable
+ALTER TABLE results ADD COLUMN IF NOT EXISTS child_age_band TEXT DEFAULT NULL;
+ALTER TABLE results ADD COLUMN IF NOT EXISTS child_gender BOOLEAN DEFAULT NULL;
+
+-- +migrate Down
+
+ALTER TABLE results DROP COLUMN IF EXISTS child_gender;
+ALTER TABLE results DROP COLUMN IF EXISTS child_age_band;
@@ -0,0 +1,23 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS care_relationships (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    child_id UUID NOT NULL,
+    therapist_id UUID NOT NULL,
+    granted_by UUID NOT NULL,
+    scope TEXT NOT NULL DEFAULT 'read',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+
+    CONSTRAINT fk_child_id FOREIGN KEY (child_id) REFERENCES children(id) ON DELETE CASCADE,
+    CONSTRAINT fk_therapist_id FOREIGN KEY (therapist_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_granted_by FOREIGN KEY (granted_by) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- each therapist can only be assigned once to the same child
+CREATE UNIQUE INDEX IF NOT EXISTS idx_care_relationships_child_id_therapist_id ON care_relationships(child_id, therapist_id);
+CREATE INDEX IF NOT EXISTS idx_care_relationships_therapist_id ON care_relationships(therapist_id);
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS care_relationships;
@@ -0,0 +1,31 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organizations_name ON organizations(name);
+
+-- therapists belong to at most one organization, and the children are linked to the organization
+-- through the care relationships granted to its therapists
+ALTER TABLE users ADD COLUMN IF NOT EXISTS organization_id UUID DEFAULT NULL REFERENCES organizations(id) ON DELETE SET NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_organization_admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_users_organization_id ON users(organization_id) WHERE organization_id IS NOT NULL;
+
+-- package without organization is a global package usable by everyone
+ALTER TABLE packages ADD COLUMN IF NOT EXISTS organization_id UUID DEFAULT NULL REFERENCES organizations(id) ON DELETE CASCADE;
+
+-- +migrate Down
+
+ALTER TABLE packages DROP COLUMN IF EXISTS organization_id;
+
+DROP INDEX IF EXISTS idx_users_organization_id;
+
+ALTER TABLE users DROP COLUMN IF EXISTS is_organization_admin;
+ALTER TABLE users DROP COLUMN IF EXISTS organization_id;
+
+DROP TABLE IF EXISTS organizations;
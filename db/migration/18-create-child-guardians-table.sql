@@ -0,0 +1,27 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS child_guardians (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    child_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    role TEXT NOT NULL DEFAULT 'guardian',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+
+    CONSTRAINT fk_child_id FOREIGN KEY (child_id) REFERENCES children(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- each user can only be the guardian of the same child once, and each child only has one owner
+CREATE UNIQUE INDEX IF NOT EXISTS idx_child_guardians_child_id_user_id ON child_guardians(child_id, user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_child_guardians_child_id_owner ON child_guardians(child_id) WHERE role = 'owner';
+CREATE INDEX IF NOT EXISTS idx_child_guardians_user_id ON child_guardians(user_id);
+
+-- the parent who registered the child stays as children.parent_user_id and becomes the owner
+INSERT INTO child_guardians (child_id, user_id, role)
+SELECT id, parent_user_id, 'owner' FROM children
+ON CONFLICT DO NOTHING;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS child_guardians;
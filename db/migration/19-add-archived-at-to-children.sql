@@ -0,0 +1,8 @@
+-- +migrate Up
+
+-- the archived children are hidden from the children listing, while their results are kept
+ALTER TABLE children ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ DEFAULT NULL;
+
+-- +migrate Down
+
+ALTER TABLE children DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,12 @@
+-- +migrate Up
+
+-- marks the results anonymized when the account or the child was deleted, so they are only used for package analysis
+-- and can't be downloaded by anyone. Only the month is kept, the same way as the created_at of the anonymized results
+ALTER TABLE results ADD COLUMN IF NOT EXISTS anonymized_at TIMESTAMPTZ DEFAULT NULL;
+
+UPDATE results SET anonymized_at = DATE_TRUNC('month', updated_at)
+WHERE anonymized_at IS NULL AND (child_age_band IS NOT NULL OR child_gender IS NOT NULL);
+
+-- +migrate Down
+
+ALTER TABLE results DROP COLUMN IF EXISTS anonymized_at;
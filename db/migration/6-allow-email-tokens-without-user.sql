@@ -0,0 +1,9 @@
+-- +migrate Up
+
+-- tokens such as therapist invitation are issued before the account exists
+ALTER TABLE email_tokens ALTER COLUMN user_id DROP NOT NULL;
+
+-- +migrate Down
+
+DELETE FROM email_tokens WHERE user_id IS NULL;
+ALTER TABLE email_tokens ALTER COLUMN user_id SET NOT NULL;
@@ -0,0 +1,13 @@
+-- +migrate Up
+
+-- the email is no longer encrypted deterministically, so the lookup and the uniqueness rely on the blind index.
+-- the existing users are backfilled by running the reencrypt command
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_blind_index TEXT DEFAULT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_blind_index ON users(email_blind_index);
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_users_email_blind_index;
+
+ALTER TABLE users DROP COLUMN IF EXISTS email_blind_index;
@@ -15,14 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/atec/packages": {
-            "post": {
+        "/v1/admin/audit-logs": {
+            "get": {
                 "security": [
                     {
                         "AdministratorLevelAuth": []
                     }
                 ],
-                "description": "Create new ATEC questionaire package",
+                "description": "Allow administrator to search and paginate the audit trail of sensitive actions, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,9 +30,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ATEC Package"
+                    "Admin"
                 ],
-                "summary": "Create new ATEC questionaire package",
+                "summary": "Search audit logs",
                 "parameters": [
                     {
                         "type": "string",
@@ -42,13 +42,120 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "ATEC questionnarie package details",
-                        "name": "create_package_input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.CreatePackageInput"
-                        }
+                        "enum": [
+                            "child.search",
+                            "result.search",
+                            "result.download",
+                            "package.create",
+                            "package.update",
+                            "package.change_active_status",
+                            "package.delete",
+                            "user.delete_account",
+                            "user.cancel_delete_account",
+                            "user.purge_account",
+                            "user.change_role",
+                            "data_export.request",
+                            "data_export.download",
+                            "child.grant_care_access",
+                            "child.revoke_care_access",
+                            "child.invite_guardian",
+                            "child.accept_guardianship",
+                            "child.remove_guardian",
+                            "child.initiate_transfer",
+                            "child.transfer",
+                            "child.archive",
+                            "child.restore",
+                            "child.delete",
+                            "organization.create",
+                            "user.set_organization"
+                        ],
+                        "type": "string",
+                        "example": "child.search",
+                        "x-enum-varnames": [
+                            "AuditActionSearchChild",
+                            "AuditActionSearchResult",
+                            "AuditActionDownloadResult",
+                            "AuditActionCreatePackage",
+                            "AuditActionUpdatePackage",
+                            "AuditActionChangePackageStatus",
+                            "AuditActionDeletePackage",
+                            "AuditActionDeleteAccount",
+                            "AuditActionCancelDeleteAccount",
+                            "AuditActionPurgeAccount",
+                            "AuditActionChangeUserRole",
+                            "AuditActionRequestDataExport",
+                            "AuditActionDownloadDataExport",
+                            "AuditActionGrantCareAccess",
+                            "AuditActionRevokeCareAccess",
+                            "AuditActionInviteGuardian",
+                            "AuditActionAcceptGuardianship",
+                            "AuditActionRemoveGuardian",
+                            "AuditActionInitiateTransfer",
+                            "AuditActionTransferChild",
+                            "AuditActionArchiveChild",
+                            "AuditActionRestoreChild",
+                            "AuditActionDeleteChild",
+                            "AuditActionCreateOrganization",
+                            "AuditActionSetUserOrganization"
+                        ],
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "actorID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-01-01T00:00:00Z",
+                        "name": "createdAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-02-01T00:00:00Z",
+                        "name": "createdBefore",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 10,
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "targetID",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "child",
+                            "result",
+                            "package",
+                            "user",
+                            "data_export",
+                            "organization"
+                        ],
+                        "type": "string",
+                        "x-enum-varnames": [
+                            "AuditTargetChild",
+                            "AuditTargetResult",
+                            "AuditTargetPackage",
+                            "AuditTargetUser",
+                            "AuditTargetExport",
+                            "AuditTargetOrganization"
+                        ],
+                        "name": "targetType",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -63,7 +170,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.CreatePackageOutput"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/rest.AuditLogOutput"
+                                            }
                                         }
                                     }
                                 }
@@ -71,7 +181,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -85,19 +213,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/atec/packages/active": {
+        "/v1/admin/organizations": {
             "get": {
-                "description": "Get all active packages",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "AdministratorLevelAuth": []
+                    }
                 ],
+                "description": "Allow administrator to list all the organizations",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ATEC Package"
+                    "Admin"
+                ],
+                "summary": "List organizations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all active packages",
                 "responses": {
                     "200": {
                         "description": "Successful response",
@@ -112,7 +251,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/rest.SearchActivePackageOutput"
+                                                "$ref": "#/definitions/rest.OrganizationOutput"
                                             }
                                         }
                                     }
@@ -120,8 +259,14 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -133,16 +278,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/atec/packages/{package_id}": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
                         "AdministratorLevelAuth": []
                     }
                 ],
-                "description": "Update existing ATEC questionnarie package",
+                "description": "Allow administrator to register a new organization. The organization name must be unique",
                 "consumes": [
                     "application/json"
                 ],
@@ -150,9 +293,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ATEC Package"
+                    "Admin"
                 ],
-                "summary": "Update existing ATEC questionnarie package",
+                "summary": "Create organization",
                 "parameters": [
                     {
                         "type": "string",
@@ -162,19 +305,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "package id (UUID v4)",
-                        "name": "package_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "ATEC questionnarie package details",
-                        "name": "update_package_input",
+                        "description": "organization detail",
+                        "name": "admin_create_organization_input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.UpdatePackageInput"
+                            "$ref": "#/definitions/rest.AdminCreateOrganizationInput"
                         }
                     }
                 ],
@@ -190,7 +326,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.UpdatePackageOutput"
+                                            "$ref": "#/definitions/rest.OrganizationOutput"
                                         }
                                     }
                                 }
@@ -198,7 +334,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -210,14 +358,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/admin/therapists/invitations": {
+            "post": {
                 "security": [
                     {
                         "AdministratorLevelAuth": []
                     }
                 ],
-                "description": "Delete ATEC questionnaire package",
+                "description": "Send a single use invitation to the given email. The invitation can be redeemed to create a therapist account",
                 "consumes": [
                     "application/json"
                 ],
@@ -225,9 +375,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ATEC Package"
+                    "Admin"
                 ],
-                "summary": "Delete ATEC questionnaire package",
+                "summary": "Invite a therapist by email",
                 "parameters": [
                     {
                         "type": "string",
@@ -237,19 +387,48 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "package ID to be deleted (UUID v4)",
-                        "name": "package_id",
-                        "in": "path",
-                        "required": true
+                        "description": "therapist email",
+                        "name": "invite_therapist_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.InviteTherapistInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "No Content"
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.InviteTherapistOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad request / validation error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -261,14 +440,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/v1/admin/users": {
+            "get": {
                 "security": [
                     {
                         "AdministratorLevelAuth": []
                     }
                 ],
-                "description": "Update existing ATEC questionnarie package activation status",
+                "description": "Allow administrator to search and paginate all registered users",
                 "consumes": [
                     "application/json"
                 ],
@@ -276,9 +457,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ATEC Package"
+                    "Admin"
                 ],
-                "summary": "Update package activation status",
+                "summary": "Search users",
                 "parameters": [
                     {
                         "type": "string",
@@ -289,19 +470,51 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "package ID to be activated/deactivated (UUID v4)",
-                        "name": "package_id",
-                        "in": "path",
-                        "required": true
+                        "name": "email",
+                        "in": "query"
                     },
                     {
-                        "description": "activation status",
-                        "name": "activation_package_input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.ActivationPackageInput"
-                        }
+                        "type": "boolean",
+                        "name": "isActive",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 10,
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "organizationID",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "administrator",
+                            "parent",
+                            "therapist"
+                        ],
+                        "type": "string",
+                        "x-enum-varnames": [
+                            "RolesAdministrator",
+                            "RolesParent",
+                            "RolesTherapist"
+                        ],
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "username",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -316,7 +529,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.ActivationPackageOutput"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/rest.AdminUserOutput"
+                                            }
                                         }
                                     }
                                 }
@@ -324,7 +540,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -338,9 +572,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/atec/questionnaires": {
-            "get": {
-                "description": "Used when a user wants to get an active ATEC questionnaire to be filled later",
+        "/v1/admin/users/{user_id}/activation": {
+            "patch": {
+                "security": [
+                    {
+                        "AdministratorLevelAuth": []
+                    }
+                ],
+                "description": "Allow administrator to change other user's active status. Deactivating will revoke all the user's sessions",
                 "consumes": [
                     "application/json"
                 ],
@@ -348,20 +587,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Questionnaire"
+                    "Admin"
                 ],
-                "summary": "Initialize ATEC questionnaire or get one",
+                "summary": "Activate or deactivate user account",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "optional to get questionnaire from its package id. if empty, a default one will be returned",
-                        "name": "package_id",
-                        "in": "query"
-                    }
-                ],
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "user ID (UUID v4)",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "active status",
+                        "name": "admin_change_user_activation_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.AdminChangeUserActivationInput"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "success response",
+                        "description": "Successful response",
                         "schema": {
                             "allOf": [
                                 {
@@ -371,7 +627,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.GetATECQuestionnaireOutput"
+                                            "$ref": "#/definitions/rest.AdminUpdateUserOutput"
                                         }
                                     }
                                 }
@@ -379,7 +635,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -391,9 +665,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/admin/users/{user_id}/deletion/cancel": {
             "post": {
-                "description": "When submiting questionnaire for a child, ensure using the parent's account or Admin level account. otherwise will be blocked.",
+                "security": [
+                    {
+                        "AdministratorLevelAuth": []
+                    }
+                ],
+                "description": "Allow administrator to cancel the scheduled deletion of other user's account, restoring the account along with its children and results",
                 "consumes": [
                     "application/json"
                 ],
@@ -401,24 +682,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Questionnaire"
+                    "Admin"
                 ],
-                "summary": "Submit questionnaire result",
+                "summary": "Cancel account deletion",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Optional jwt auth token to be used if want to fill questionnaire based on a registered child",
+                        "description": "JWT Token",
                         "name": "Authorization",
-                        "in": "header"
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "description": "full questionnaire answer",
-                        "name": "submit_questionnaire_input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.SubmitQuestionnaireInput"
-                        }
+                        "type": "string",
+                        "description": "user ID (UUID v4)",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -433,7 +713,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.SubmitQuestionnaireOutput"
+                                            "$ref": "#/definitions/rest.AdminUpdateUserOutput"
                                         }
                                     }
                                 }
@@ -441,7 +721,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request or the account is not scheduled for deletion",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -455,14 +753,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/atec/questionnaires/results": {
-            "get": {
+        "/v1/admin/users/{user_id}/mfa": {
+            "patch": {
                 "security": [
                     {
-                        "TherapistLevelAuth": []
+                        "AdministratorLevelAuth": []
                     }
                 ],
-                "description": "Search through all the submitted ATEC questionnaires to the systems",
+                "description": "Allow administrator to require therapist or administrator account to use two-factor authentication.\nWhen required but not yet enrolled, all the user's sessions are revoked and the enrollment is enforced on next login",
                 "consumes": [
                     "application/json"
                 ],
@@ -470,54 +768,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Questionnaire"
+                    "Admin"
                 ],
-                "summary": "Search questionnaire result",
+                "summary": "Require or unrequire two-factor authentication",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "JWT token to prove that you're admin",
+                        "description": "JWT Token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "name": "child_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "created_by_id",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 0,
-                        "type": "integer",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "package_id",
-                        "in": "query"
+                        "description": "user ID (UUID v4)",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "name": "result_id",
-                        "in": "query"
+                        "description": "mfa requirement",
+                        "name": "admin_set_mfa_requirement_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.AdminSetMFARequirementInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success response",
+                        "description": "Successful response",
                         "schema": {
                             "allOf": [
                                 {
@@ -527,7 +808,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.SearchQUestionnaireResultsOutput"
+                                            "$ref": "#/definitions/rest.AdminUpdateUserOutput"
                                         }
                                     }
                                 }
@@ -535,7 +816,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -549,14 +848,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/atec/questionnaires/results/my": {
-            "get": {
+        "/v1/admin/users/{user_id}/organization": {
+            "put": {
                 "security": [
                     {
-                        "ParentLevelAuth": []
+                        "AdministratorLevelAuth": []
                     }
                 ],
-                "description": "Get all questionnaires result submitted by the requester account",
+                "description": "Allow administrator to move a therapist into an organization, optionally as the organization admin,\nor remove the therapist from the organization by leaving the organization_id empty. All the user's sessions will be revoked",
                 "consumes": [
                     "application/json"
                 ],
@@ -564,34 +863,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Questionnaire"
+                    "Admin"
                 ],
-                "summary": "Get my quesionnaires results",
+                "summary": "Set user organization",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "JWT token from auth process",
+                        "description": "JWT Token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "user ID (UUID v4)",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "minimum": 0,
-                        "type": "integer",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "organization membership",
+                        "name": "admin_set_user_organization_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.AdminSetUserOrganizationInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success response",
+                        "description": "Successful response",
                         "schema": {
                             "allOf": [
                                 {
@@ -601,7 +903,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.SearchQUestionnaireResultsOutput"
+                                            "$ref": "#/definitions/rest.AdminUpdateUserOutput"
                                         }
                                     }
                                 }
@@ -609,7 +911,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -623,37 +943,79 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/atec/questionnaires/results/{result_id}": {
-            "get": {
-                "description": "Download quesionnaire result as image",
+        "/v1/admin/users/{user_id}/password/reset": {
+            "post": {
+                "security": [
+                    {
+                        "AdministratorLevelAuth": []
+                    }
+                ],
+                "description": "Allow administrator to invalidate other user's password and sessions, then send a reset password email to the user",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "image/jpeg"
+                    "application/json"
                 ],
                 "tags": [
-                    "Questionnaire"
+                    "Admin"
                 ],
-                "summary": "Download quesionnaire result as image",
+                "summary": "Force user password reset",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Optional jwt auth token to be used if want to download result owned by a specific owner",
+                        "description": "JWT Token",
                         "name": "Authorization",
-                        "in": "header"
+                        "in": "header",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "ID of the submitted questionnaire (UUID v4)",
-                        "name": "result_id",
+                        "description": "user ID (UUID v4)",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.AdminUpdateUserOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -667,14 +1029,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/accounts": {
-            "delete": {
+        "/v1/admin/users/{user_id}/role": {
+            "patch": {
                 "security": [
                     {
-                        "ParentLevelAuth": []
+                        "AdministratorLevelAuth": []
                     }
                 ],
-                "description": "Delete all user's related data from the system",
+                "description": "Allow administrator to change other user's role. All the user's sessions will be revoked",
                 "consumes": [
                     "application/json"
                 ],
@@ -682,9 +1044,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "Delete user's account",
+                "summary": "Change user role",
                 "parameters": [
                     {
                         "type": "string",
@@ -694,58 +1056,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "the email of the account which password will be reset",
-                        "name": "change_password_input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.DeleteAccountInput"
-                        }
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "Successful response",
-                        "schema": {
-                            "$ref": "#/definitions/rest.StandardSuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request\"\t\"validation error",
-                        "schema": {
-                            "$ref": "#/definitions/rest.StandardErrorResponse"
-                        }
+                        "type": "string",
+                        "description": "user ID (UUID v4)",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
                     },
-                    "500": {
-                        "description": "Internal Error",
-                        "schema": {
-                            "$ref": "#/definitions/rest.StandardErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/v1/auth/login": {
-            "post": {
-                "description": "Use this endpoint to login with your username and password",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "Gain access to the system by authenticating using a registered account",
-                "parameters": [
                     {
-                        "description": "account detail such as email and password to log in",
-                        "name": "login_input",
+                        "description": "new role",
+                        "name": "admin_change_user_role_input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.LoginInput"
+                            "$ref": "#/definitions/rest.AdminChangeUserRoleInput"
                         }
                     }
                 ],
@@ -761,7 +1084,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.LoginOutput"
+                                            "$ref": "#/definitions/rest.AdminUpdateUserOutput"
                                         }
                                     }
                                 }
@@ -769,48 +1092,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Authentication Failed",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/v1/auth/password": {
-            "get": {
-                "description": "After user click the link from email for reset password, he will be redirected here to change the password. This might not work in this page\nsince the returned value in this API is HTML",
-                "consumes": [
-                    "application/x-www-form-urlencoded"
-                ],
-                "produces": [
-                    "text/html"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "Webpage's form to change user's account password",
-                "responses": {
-                    "200": {
-                        "description": "Successful response",
-                        "schema": {
-                            "$ref": "#/definitions/rest.StandardSuccessResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Bad request\"\t\"validation error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -822,9 +1122,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/admin/users/{user_id}/unlock": {
             "post": {
-                "description": "After using init reset password method, the resulting JWT token can be used here\nto authorize the password changes.",
+                "security": [
+                    {
+                        "AdministratorLevelAuth": []
+                    }
+                ],
+                "description": "Allow administrator to unlock account locked due to too many failed login attempts and reset the failed login counter",
                 "consumes": [
                     "application/json"
                 ],
@@ -832,18 +1139,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "Change user's account password",
+                "summary": "Unlock user account",
                 "parameters": [
                     {
-                        "description": "the email of the account which password will be reset",
-                        "name": "change_password_input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.ResetPasswordInput"
-                        }
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "user ID (UUID v4)",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -858,7 +1170,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.ResetPasswordOutput"
+                                            "$ref": "#/definitions/rest.AdminUpdateUserOutput"
                                         }
                                     }
                                 }
@@ -866,7 +1178,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request\"\t\"validation error",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -878,9 +1208,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "If the user wants to change their password, use this API.\nwhen the request succeed, an email containing confirmation link will\nbe sent to the account email.",
+            }
+        },
+        "/v1/atec/packages": {
+            "post": {
+                "security": [
+                    {
+                        "AdministratorLevelAuth": []
+                    }
+                ],
+                "description": "Create new ATEC questionaire package. The package created by the organization admin is only usable within the organization",
                 "consumes": [
                     "application/json"
                 ],
@@ -888,17 +1225,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "ATEC Package"
                 ],
-                "summary": "Initiate change password process for an active account",
+                "summary": "Create new ATEC questionaire package",
                 "parameters": [
                     {
-                        "description": "the email of the account which password will be reset",
-                        "name": "init_change_password_input",
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "ATEC questionnarie package details",
+                        "name": "create_package_input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.InitResetPasswordInput"
+                            "$ref": "#/definitions/rest.CreatePackageInput"
                         }
                     }
                 ],
@@ -914,7 +1258,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.InitResetPasswordOutput"
+                                            "$ref": "#/definitions/rest.CreatePackageOutput"
                                         }
                                     }
                                 }
@@ -922,7 +1266,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request\"\t\"validation error",
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -936,9 +1280,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/signup": {
-            "post": {
-                "description": "Use this API endpoint to create a new account",
+        "/v1/atec/packages/active": {
+            "get": {
+                "description": "Get all active packages usable by the requester. The organization scoped packages are only listed for the organization members",
                 "consumes": [
                     "application/json"
                 ],
@@ -946,20 +1290,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
-                ],
-                "summary": "Create a new account to access user only resources within this system",
-                "parameters": [
-                    {
-                        "description": "Login Credentials",
-                        "name": "signup_input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.SignupInput"
-                        }
-                    }
+                    "ATEC Package"
                 ],
+                "summary": "Get all active packages",
                 "responses": {
                     "200": {
                         "description": "Successful response",
@@ -972,7 +1305,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.SignupOutput"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/rest.SearchActivePackageOutput"
+                                            }
                                         }
                                     }
                                 }
@@ -980,7 +1316,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request / validation error",
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -988,9 +1330,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/signup/resend": {
-            "post": {
-                "description": "If something happen during account verification that cause the email not received\nor the email is lost, use this API to resend the verification email",
+        "/v1/atec/packages/{package_id}": {
+            "put": {
+                "security": [
+                    {
+                        "AdministratorLevelAuth": []
+                    }
+                ],
+                "description": "Update existing ATEC questionnarie package",
                 "consumes": [
                     "application/json"
                 ],
@@ -998,17 +1345,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "ATEC Package"
                 ],
-                "summary": "Resend email for account verification",
+                "summary": "Update existing ATEC questionnarie package",
                 "parameters": [
                     {
-                        "description": "resend signup input",
-                        "name": "resend_signup_input",
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "package id (UUID v4)",
+                        "name": "package_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ATEC questionnarie package details",
+                        "name": "update_package_input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.ResendVerificationInput"
+                            "$ref": "#/definitions/rest.UpdatePackageInput"
                         }
                     }
                 ],
@@ -1024,7 +1385,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.ResendVerificationOutput"
+                                            "$ref": "#/definitions/rest.UpdatePackageOutput"
                                         }
                                     }
                                 }
@@ -1032,13 +1393,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request / validation error",
-                        "schema": {
-                            "$ref": "#/definitions/rest.StandardErrorResponse"
-                        }
-                    },
-                    "429": {
-                        "description": "Too many requests",
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -1050,11 +1405,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/auth/verify": {
-            "get": {
-                "description": "Confirmation method to ensure the email used when signup is active and owned by requester.\nIf the confirmation token is valid, the account will be activated\nand will be able to be used on login. Otherwise, the opposite will happen.",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdministratorLevelAuth": []
+                    }
+                ],
+                "description": "Delete ATEC questionnaire package",
                 "consumes": [
                     "application/json"
                 ],
@@ -1062,16 +1420,83 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "ATEC Package"
                 ],
-                "summary": "Validate account after signup",
+                "summary": "Delete ATEC questionnaire package",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "validation token received from email after signup in form of a jwt token",
-                        "name": "validation_token",
-                        "in": "query",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "package ID to be deleted (UUID v4)",
+                        "name": "package_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "AdministratorLevelAuth": []
+                    }
+                ],
+                "description": "Update existing ATEC questionnarie package activation status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ATEC Package"
+                ],
+                "summary": "Update package activation status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "package ID to be activated/deactivated (UUID v4)",
+                        "name": "package_id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "activation status",
+                        "name": "activation_package_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.ActivationPackageInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -1086,7 +1511,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.VerifyAccountOutput"
+                                            "$ref": "#/definitions/rest.ActivationPackageOutput"
                                         }
                                     }
                                 }
@@ -1094,13 +1519,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request / validation error",
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "invalid or expired verification token. details will be given in the error message",
+                    "500": {
+                        "description": "Internal Error",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -1108,14 +1533,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/childern": {
+        "/v1/atec/questionnaires": {
             "get": {
-                "security": [
-                    {
-                        "ParentLevelAuth": []
-                    }
-                ],
-                "description": "Get all childern registered under this account",
+                "description": "Used when a user wants to get an active ATEC questionnaire to be filled later",
                 "consumes": [
                     "application/json"
                 ],
@@ -1123,35 +1543,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Childern"
+                    "Questionnaire"
                 ],
-                "summary": "Get all childern registered under this account",
+                "summary": "Initialize ATEC questionnaire or get one",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "JWT Token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "limit searching param",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "offset searching param",
-                        "name": "offset",
-                        "in": "query",
-                        "required": true
+                        "description": "optional to get questionnaire from its package id. if empty, a default one will be returned",
+                        "name": "package_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "success response",
                         "schema": {
                             "allOf": [
                                 {
@@ -1161,10 +1566,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/rest.GetMyChildernOutput"
-                                            }
+                                            "$ref": "#/definitions/rest.GetATECQuestionnaireOutput"
                                         }
                                     }
                                 }
@@ -1186,12 +1588,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "security": [
-                    {
-                        "ParentLevelAuth": []
-                    }
-                ],
-                "description": "Register a new child",
+                "description": "When submiting questionnaire for a child, ensure using the parent's account or Admin level account. otherwise will be blocked.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1199,24 +1596,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Childern"
+                    "Questionnaire"
                 ],
-                "summary": "Register a new child",
+                "summary": "Submit questionnaire result",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "JWT Token",
+                        "description": "Optional jwt auth token to be used if want to fill questionnaire based on a registered child",
                         "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "in": "header"
                     },
                     {
-                        "description": "child details",
-                        "name": "register_child_input",
+                        "description": "full questionnaire answer",
+                        "name": "submit_questionnaire_input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.RegisterChildInput"
+                            "$ref": "#/definitions/rest.SubmitQuestionnaireInput"
                         }
                     }
                 ],
@@ -1232,7 +1628,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.RegisterChildOutput"
+                                            "$ref": "#/definitions/rest.SubmitQuestionnaireOutput"
                                         }
                                     }
                                 }
@@ -1254,14 +1650,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/childern/search": {
+        "/v1/atec/questionnaires/results": {
             "get": {
                 "security": [
                     {
                         "TherapistLevelAuth": []
                     }
                 ],
-                "description": "Search childern data",
+                "description": "Search through all the submitted ATEC questionnaires to the systems. Therapist only finds the results of the children\nassigned to them, while the organization admin finds the results of the organization. Only the administrator can search across the organizations",
                 "consumes": [
                     "application/json"
                 ],
@@ -1269,49 +1665,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Childern"
+                    "Questionnaire"
                 ],
-                "summary": "Search childern data",
+                "summary": "Search questionnaire result",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "JWT Token",
+                        "description": "JWT token to prove that you're admin",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "type": "boolean",
-                        "name": "gender",
+                        "type": "string",
+                        "name": "child_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "created_by_id",
                         "in": "query"
                     },
                     {
+                        "maximum": 100,
                         "minimum": 1,
                         "type": "integer",
-                        "example": 1,
                         "name": "limit",
                         "in": "query"
                     },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "offset",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "name": "name",
+                        "name": "organization_id",
                         "in": "query"
                     },
                     {
-                        "minimum": 0,
-                        "type": "integer",
-                        "name": "offset",
+                        "type": "string",
+                        "name": "package_id",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "name": "parent_user_id",
+                        "name": "result_id",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "success response",
                         "schema": {
                             "allOf": [
                                 {
@@ -1321,7 +1727,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.SearchChildernOutput"
+                                            "$ref": "#/definitions/rest.SearchQUestionnaireResultsOutput"
                                         }
                                     }
                                 }
@@ -1343,14 +1749,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/childern/{child_id}": {
-            "put": {
+        "/v1/atec/questionnaires/results/my": {
+            "get": {
                 "security": [
                     {
                         "ParentLevelAuth": []
                     }
                 ],
-                "description": "Update child data",
+                "description": "Get all questionnaires result submitted by the requester account",
                 "consumes": [
                     "application/json"
                 ],
@@ -1358,37 +1764,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Childern"
+                    "Questionnaire"
                 ],
-                "summary": "Update child data",
+                "summary": "Get my quesionnaires results",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "JWT Token",
+                        "description": "JWT token from auth process",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Child ID to be updated (UUID v4)",
-                        "name": "child_id",
-                        "in": "path",
-                        "required": true
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "description": "new child details",
-                        "name": "update_child_input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.UpdateChildernInput"
-                        }
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "success response",
                         "schema": {
                             "allOf": [
                                 {
@@ -1398,7 +1801,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.UpdateChildernOutput"
+                                            "$ref": "#/definitions/rest.SearchQUestionnaireResultsOutput"
                                         }
                                     }
                                 }
@@ -1420,64 +1823,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/childern/{child_id}/stats": {
+        "/v1/atec/questionnaires/results/{result_id}": {
             "get": {
-                "security": [
-                    {
-                        "ParentLevelAuth": []
-                    }
-                ],
-                "description": "The returned data will be JSON but contains sufficient data to be drawn as graph on frontend",
+                "description": "Download quesionnaire result as image",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "image/jpeg"
                 ],
                 "tags": [
-                    "Childern"
+                    "Questionnaire"
                 ],
-                "summary": "Get child ATEC score history",
+                "summary": "Download quesionnaire result as image",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "JWT Token",
+                        "description": "Optional jwt auth token to be used if want to download result owned by a specific owner",
                         "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "in": "header"
                     },
                     {
                         "type": "string",
-                        "description": "Child ID (UUID v4)",
-                        "name": "child_id",
+                        "description": "ID of the submitted questionnaire (UUID v4)",
+                        "name": "result_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successful response",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/usecase.StatisticComponent"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -1491,14 +1867,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/users/me": {
-            "get": {
+        "/v1/auth/accounts": {
+            "delete": {
                 "security": [
                     {
                         "ParentLevelAuth": []
                     }
                 ],
-                "description": "Return the profile of the currently authenticated user",
+                "description": "Schedule the deletion of the account and all user's related data. The account is deactivated immediately\nand permanently deleted after the grace period, unless restored using the link sent to the account email",
                 "consumes": [
                     "application/json"
                 ],
@@ -1506,9 +1882,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Authentication"
                 ],
-                "summary": "Get my profile data",
+                "summary": "Delete user's account",
                 "parameters": [
                     {
                         "type": "string",
@@ -1516,6 +1892,61 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "the email of the account which password will be reset",
+                        "name": "change_password_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.DeleteAccountInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request\"\t\"validation error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/accounts/restore": {
+            "post": {
+                "description": "Use the restore token sent to the account email to cancel the deletion, restoring the account along with its children and results",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Restore account scheduled for deletion",
+                "parameters": [
+                    {
+                        "description": "restore token from the email",
+                        "name": "restore_account_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.RestoreAccountInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -1530,21 +1961,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.GetMyProfileOutput"
+                                            "$ref": "#/definitions/rest.RestoreAccountOutput"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad request or the account is not scheduled for deletion",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Invalid, expired or used token",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Account not found",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -1556,14 +1993,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "ParentLevelAuth": []
-                    }
-                ],
-                "description": "Allow authenticated user to update their username, phone number, and address",
+            }
+        },
+        "/v1/auth/email/confirm": {
+            "post": {
+                "description": "Use the change email token sent to the new email address to replace the account email",
                 "consumes": [
                     "application/json"
                 ],
@@ -1571,24 +2005,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Authentication"
                 ],
-                "summary": "Update my profile data",
+                "summary": "Confirm the email change",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "JWT Token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "Update profile input",
-                        "name": "update_profile_input",
+                        "description": "change email token from the email",
+                        "name": "confirm_change_email_input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.UpdateMyProfileInput"
+                            "$ref": "#/definitions/rest.ConfirmChangeEmailInput"
                         }
                     }
                 ],
@@ -1604,7 +2031,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/rest.UpdateMyProfileOutput"
+                                            "$ref": "#/definitions/rest.ConfirmChangeEmailOutput"
                                         }
                                     }
                                 }
@@ -1612,13 +2039,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Bad request or the email has been used by another account",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Invalid, expired or used token",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -1632,14 +2065,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/users/therapists": {
-            "get": {
-                "security": [
-                    {
-                        "ParentLevelAuth": []
-                    }
-                ],
-                "description": "Return the list of all users with therapist role",
+        "/v1/auth/login": {
+            "post": {
+                "description": "Use this endpoint to login with your username and password\nFor account with two-factor authentication enabled, mfa_required will be true and\nthe mfa_token must be exchanged with the login token via /v1/auth/login/mfa.\nIf mfa_enrollment_required is true, the account must enroll first via /v1/auth/mfa/enroll\nRepeated failed login will be delayed progressively, and the account will be temporarily locked\nonce the failure threshold is reached. An email containing the unlock link will be sent to the account owner\nUnknown email, wrong password and locked account all result in the same 401 response",
                 "consumes": [
                     "application/json"
                 ],
@@ -1647,16 +2075,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Authentication"
                 ],
-                "summary": "Get all therapists",
+                "summary": "Gain access to the system by authenticating using a registered account",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "JWT Token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "account detail such as email and password to log in",
+                        "name": "login_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.LoginInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -1671,24 +2101,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/rest.GetTherapistOutput"
-                                            }
+                                            "$ref": "#/definitions/rest.LoginOutput"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Authentication Failed",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too many login attempts",
                         "schema": {
                             "$ref": "#/definitions/rest.StandardErrorResponse"
                         }
@@ -1701,162 +2134,4395 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.AnswerDetail": {
-            "type": "object",
-            "additionalProperties": {
-                "type": "object",
-                "additionalProperties": {
-                    "type": "integer"
-                }
-            }
         },
-        "model.AnswerOption": {
-            "type": "object",
-            "required": [
-                "description",
-                "id"
-            ],
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "score": {
-                    "type": "integer",
-                    "minimum": 0
+        "/v1/auth/login/magic-link": {
+            "post": {
+                "description": "Exchange the magic link token from /v1/auth/login/passwordless with the login token.\nExpired or used magic link result in 401 response",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Login using the magic link sent to the email",
+                "parameters": [
+                    {
+                        "description": "magic link token from the email",
+                        "name": "magic_link_login_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MagicLinkLoginInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.LoginOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication Failed",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "model.ChecklistGroup": {
-            "type": "object",
-            "required": [
-                "custom_name",
-                "options",
-                "questions"
-            ],
-            "properties": {
-                "custom_name": {
-                    "description": "CustomName can be used to be displayed to user. if empty, the one from\nthe template will be used",
-                    "type": "string"
-                },
-                "options": {
-                    "type": "array",
-                    "minItems": 1,
-                    "uniqueItems": true,
-                    "items": {
-                        "$ref": "#/definitions/model.AnswerOption"
-                    }
-                },
-                "questions": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
+        "/v1/auth/login/mfa": {
+            "post": {
+                "description": "Exchange the mfa_token returned from login with the login token by supplying either\nthe TOTP code from the authenticator app or one of the unused recovery codes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Complete login using the second factor",
+                "parameters": [
+                    {
+                        "description": "mfa token and the second factor",
+                        "name": "mfa_verify_login_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MFAVerifyLoginInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.LoginOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication Failed",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many attempts",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/login/passwordless": {
+            "post": {
+                "description": "Only available for parent accounts. A 6 digit code and a magic link will be sent to the email,\nboth only valid for a short time and can only be used once. Requesting again will invalidate the previous ones.\nThe response is the same whether or not the email can be used to login without password,\nonly the email owner will be notified about it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Request a one time code and a magic link to login without password",
+                "parameters": [
+                    {
+                        "description": "the email of the account to login to",
+                        "name": "init_passwordless_login_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.InitPasswordlessLoginInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.InitPasswordlessLoginOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/login/passwordless/verify": {
+            "post": {
+                "description": "Exchange the code from /v1/auth/login/passwordless with the login token.\nThe number of attempts is limited, and wrong, expired or used code all result in the same 401 response",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Login using the one time code sent to the email",
+                "parameters": [
+                    {
+                        "description": "the email and the code",
+                        "name": "verify_passwordless_login_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.VerifyPasswordlessLoginInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.LoginOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication Failed",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many login attempts",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Revoke the session tied to the supplied access token. The access token and its refresh token\nwill no longer be accepted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout from the current session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication Failed",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/logout/all": {
+            "post": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Revoke every active session owned by the requester, logging them out from all devices.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout from all sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication Failed",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/mfa": {
+            "delete": {
+                "security": [
+                    {
+                        "TherapistLevelAuth": []
+                    }
+                ],
+                "description": "Disable two-factor authentication for the logged in account. Not allowed when required by administrator",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Disable two-factor authentication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "TOTP code",
+                        "name": "mfa_disable_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MFADisableInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.MFADisableOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication Failed",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/mfa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "TherapistLevelAuth": []
+                    }
+                ],
+                "description": "Generate a new TOTP secret to be added to the authenticator app. Only available for therapist and administrator.\nUse the Authorization header when logged in, or the mfa_token from login when the enrollment is required by administrator",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Start two-factor authentication enrollment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "mfa token when not logged in",
+                        "name": "mfa_enroll_input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/rest.MFAEnrollInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.MFAEnrollOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication Failed",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/mfa/enroll/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "TherapistLevelAuth": []
+                    }
+                ],
+                "description": "Enable two-factor authentication by supplying the TOTP code generated from the new secret.\nThe recovery codes are only shown once. When enrolling using mfa_token, the login token is also returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Confirm two-factor authentication enrollment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "TOTP code",
+                        "name": "mfa_confirm_enrollment_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MFAConfirmEnrollmentInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.MFAConfirmEnrollmentOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication Failed",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/password": {
+            "get": {
+                "description": "After user click the link from email for reset password, he will be redirected here to change the password. This might not work in this page\nsince the returned value in this API is HTML",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Webpage's form to change user's account password",
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request\"\t\"validation error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "After using init reset password method, the resulting JWT token can be used here\nto authorize the password changes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Change user's account password",
+                "parameters": [
+                    {
+                        "description": "the email of the account which password will be reset",
+                        "name": "change_password_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.ResetPasswordInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.ResetPasswordOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request\"\t\"validation error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "If the user wants to change their password, use this API.\nwhen the request succeed, an email containing confirmation link will\nbe sent to the account email. The response is the same whether or not an active account\nis registered using the email, only the email owner will be notified about it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Initiate change password process for an active account",
+                "parameters": [
+                    {
+                        "description": "the email of the account which password will be reset",
+                        "name": "init_change_password_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.InitResetPasswordInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.InitResetPasswordOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request\"\t\"validation error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/refresh": {
+            "post": {
+                "description": "Use this endpoint to get a new access token when the previous one has expired.\nThe refresh token is rotated on every use, so always store the newly returned refresh token.\nReusing an already used refresh token will revoke the whole session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Exchange refresh token for a new access token",
+                "parameters": [
+                    {
+                        "description": "refresh token obtained from login or previous refresh",
+                        "name": "refresh_token_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.RefreshTokenInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.RefreshTokenOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication Failed",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/signup": {
+            "post": {
+                "description": "Use this API endpoint to create a new account\nThe response is the same whether or not the email is already registered,\nthe email owner will be notified instead if the email is already registered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Create a new account to access user only resources within this system",
+                "parameters": [
+                    {
+                        "description": "Login Credentials",
+                        "name": "signup_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.SignupInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.SignupOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request / validation error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/signup/resend": {
+            "post": {
+                "description": "If something happen during account verification that cause the email not received\nor the email is lost, use this API to resend the verification email\nThe response is the same for unregistered and already activated email, only the email owner will be notified",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Resend email for account verification",
+                "parameters": [
+                    {
+                        "description": "resend signup input",
+                        "name": "resend_signup_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.ResendVerificationInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.ResendVerificationOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request / validation error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/signup/therapist": {
+            "post": {
+                "description": "Redeem the invitation token sent to the therapist email and create the therapist account with the chosen password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Create a therapist account from an invitation",
+                "parameters": [
+                    {
+                        "description": "invitation token and account data",
+                        "name": "redeem_therapist_invitation_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.RedeemTherapistInvitationInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.RedeemTherapistInvitationOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request / validation error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or already used invitation",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/unlock": {
+            "post": {
+                "description": "Use the unlock token sent to the account email when the account was locked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Unlock account locked due to too many failed login attempts",
+                "parameters": [
+                    {
+                        "description": "unlock token from the email",
+                        "name": "unlock_account_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.UnlockAccountInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.UnlockAccountOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or used token",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/verify": {
+            "get": {
+                "description": "Confirmation method to ensure the email used when signup is active and owned by requester.\nIf the confirmation token is valid, the account will be activated\nand will be able to be used on login. Otherwise, the opposite will happen.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Validate account after signup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "validation token received from email after signup in form of a jwt token",
+                        "name": "validation_token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.VerifyAccountOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request / validation error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid or expired verification token. details will be given in the error message",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/childern": {
+            "get": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Get all childern registered under this account, including the childern shared with this account as a guardian",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Childern"
+                ],
+                "summary": "Get all childern registered under this account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "list the archived childern instead of the active ones",
+                        "name": "archived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit searching param",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset searching param",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/rest.GetMyChildernOutput"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Register a new child",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Childern"
+                ],
+                "summary": "Register a new child",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "child details",
+                        "name": "register_child_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.RegisterChildInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.RegisterChildOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/childern/guardians/invitations/accept": {
+            "post": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Redeem the guardian invitation sent to the email of this account and join the child as a guardian",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Childern"
+                ],
+                "summary": "Accept the invitation to become a child's guardian",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "invitation token",
+                        "name": "accept_guardian_invitation_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.AcceptGuardianInvitationInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.GuardianOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request / already a guardian",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized / invalid invitation",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The invitation was sent to another email",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/childern/search": {
+            "get": {
+                "security": [
+                    {
+                        "TherapistLevelAuth": []
+                    }
+                ],
+                "description": "Search childern data. Therapist only finds the children assigned to them, while the organization admin finds\nthe children of the organization. Only the administrator can search across the organizations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Childern"
+                ],
+                "summary": "Search childern data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "organization_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "parent_user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.SearchChildernOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/childern/transfers/accept": {
+            "post": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Redeem the transfer request sent to the email of this account. The child and the results the previous parent\ncreated for the child are moved to this account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Childern"
+                ],
+                "summary": "Accept the transfer of a child to my account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "transfer token",
+                        "name": "accept_child_transfer_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.AcceptChildTransferInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.AcceptChildTransferOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request / the child has moved elsewhere",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized / invalid transfer token",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The transfer was sent to another email",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/childern/{child_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Update child data",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Childern"
+                ],
+                "summary": "Update child data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Child ID to be updated (UUID v4)",
+                        "name": "child_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new child details",
+                        "name": "update_child_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.UpdateChildernInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.UpdateChildernOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Permanently delete the child together with the child's results. Set anonymize_results to keep the results\nas anonymous statistic data instead of deleting them. This action can't be undone",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Childern"
+                ],
+                "summary": "Permanently delete my child",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Child ID (UUID v4)",
+                        "name": "child_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "anonymize the child's results instead of deleting them",
+                        "name": "anonymize_results",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.DeleteChildOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/childern/{child_id}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Hide the child from the childern list and search while keeping the child data and results.\nThe archived child can be listed using the archived query on the childern list and restored at any time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Childern"
+                ],
+                "summary": "Archive my child",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Child ID (UUID v4)",
+                        "name": "child_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.ArchiveChildOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request / already archived",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/childern/{child_id}/guardians": {
+            "get": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "List the owner and every guardian of the child. Only available to the child's owner and guardians",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Childern"
+                ],
+                "summary": "List the child's guardians",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Child ID (UUID v4)",
+                        "name": "child_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/rest.GuardianOutput"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/childern/{child_id}/guardians/invitations": {
+            "post": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Send a single use invitation to the given email. The account registered with the email can accept the invitation\nto become the child's guardian, sharing the access to the child's statistic, results and questionnaire submission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Childern"
+                ],
+                "summary": "Invite a guardian to share my child",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Child ID (UUID v4)",
+                        "name": "child_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "guardian email",
+                        "name": "invite_guardian_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.InviteGuardianInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.InviteGuardianOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request / validation error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/childern/{child_id}/guardians/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "The child's owner can remove any guardian, while a guardian can only remove themselves to leave the child.\nThe owner can't be removed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Childern"
+                ],
+                "summary": "Remove a guardian from the child",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Child ID (UUID v4)",
+                        "name": "child_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "guardian user ID (UUID v4)",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.RemoveGuardianOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/childern/{child_id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Bring back the archived child to the childern list and search",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Childern"
+                ],
+                "summary": "Restore my archived child",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Child ID (UUID v4)",
+                        "name": "child_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.RestoreChildOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request / not archived",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/childern/{child_id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "The returned data will be JSON but contains sufficient data to be drawn as graph on frontend",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Childern"
+                ],
+                "summary": "Get child ATEC score history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Child ID (UUID v4)",
+                        "name": "child_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/usecase.StatisticComponent"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/childern/{child_id}/transfers": {
+            "post": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Send a single use transfer request to the given email. Once the account registered with the email accepts it,\nthe child and the results created for the child are moved to that account and this account loses the access to the child",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Childern"
+                ],
+                "summary": "Transfer my child to another account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Child ID (UUID v4)",
+                        "name": "child_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "recipient email",
+                        "name": "initiate_child_transfer_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.InitiateChildTransferInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.InitiateChildTransferOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request / validation error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/exports/download": {
+            "get": {
+                "description": "Download the data export archive using the token from the link sent to the account email",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Download data export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "download token from the email",
+                        "name": "export_token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "400": {
+                        "description": "Bad request or the link has expired",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Data export not found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Return the profile of the currently authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get my profile data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.GetMyProfileOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Allow authenticated user to update their username, phone number, and address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update my profile data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Update profile input",
+                        "name": "update_profile_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.UpdateMyProfileInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.UpdateMyProfileOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/email": {
+            "put": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Allow authenticated user to change their email by supplying the current password. A confirmation link is sent to the new email\nand a notice is sent to the current email. The email is only changed once the link is confirmed before it expires.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change my email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Change email input",
+                        "name": "change_email_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.InitChangeEmailInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.InitChangeEmailOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request or the email has been used by another account",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/exports": {
+            "get": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "List all the data exports requested by the user along with their status, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List my data exports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/rest.DataExportOutput"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Start preparing a ZIP archive of everything stored about the user: the profile, children, results and the result images.\nThe archive is prepared in the background, and the download link is sent to the account email once ready.\nOnly one export can be prepared at a time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Request data export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.DataExportOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Previous export is still being prepared",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/password": {
+            "put": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Allow authenticated user to change their password by supplying the current one. The new password must satisfy the password policy.\nOnce changed, all the user's other sessions and outstanding reset password links are revoked, and a confirmation email is sent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change my password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Change password input",
+                        "name": "change_password_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.ChangePasswordInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.ChangePasswordOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "List every device currently logged in to the user's account, along with the ip address and user agent used to login",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List my active sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/rest.SessionOutput"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/sessions/{session_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Logout the device using the session. Both the access token and refresh token from that session will no longer be usable",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Revoke one of my sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "session ID (UUID v4)",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.RevokeMySessionOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/tokens": {
+            "get": {
+                "security": [
+                    {
+                        "TherapistLevelAuth": []
+                    }
+                ],
+                "description": "List all the personal access tokens owned by the user, including the revoked and expired ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List my personal access tokens",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/rest.PersonalAccessTokenOutput"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "TherapistLevelAuth": []
+                    }
+                ],
+                "description": "Create a named, scoped and expiring token to be used by integrations in place of the login token.\nOnly available for therapist and administrator, and the admin scope can only be granted by administrator.\nThe token is only shown once in this response",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create personal access token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "token name, scopes and expiry",
+                        "name": "create_personal_access_token_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.CreatePersonalAccessTokenInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.CreatePersonalAccessTokenOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/tokens/{token_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "TherapistLevelAuth": []
+                    }
+                ],
+                "description": "Revoke one of the user's personal access tokens. The token can no longer be used afterward",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Revoke personal access token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "personal access token ID (UUID v4)",
+                        "name": "token_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.RevokePersonalAccessTokenOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/therapists": {
+            "get": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Return the list of all users with therapist role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get all therapists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/rest.GetTherapistOutput"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/therapists/access": {
+            "get": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "List every therapist assigned to the children registered under this account along with the granted scope",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List therapist access granted to my children",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/rest.TherapistAccessOutput"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/therapists/{therapist_id}/access": {
+            "put": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Assign the therapist to the child. The read scope allows the therapist to read the child data, statistic and results,\nwhile the submit scope also allows submitting questionnaire for the child. Granting again will replace the scope",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Grant a therapist access to my child",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "therapist ID (UUID v4)",
+                        "name": "therapist_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "child and scope",
+                        "name": "grant_therapist_access_input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.GrantTherapistAccessInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.TherapistAccessOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/therapists/{therapist_id}/access/{child_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ParentLevelAuth": []
+                    }
+                ],
+                "description": "Remove the therapist from the child. The therapist will no longer be able to access the child data",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Revoke a therapist access to my child",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "therapist ID (UUID v4)",
+                        "name": "therapist_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "child ID (UUID v4)",
+                        "name": "child_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/rest.StandardSuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/rest.RevokeTherapistAccessOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.AnswerDetail": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "object",
+                "additionalProperties": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AnswerOption": {
+            "type": "object",
+            "required": [
+                "description",
+                "id"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "model.AuditAction": {
+            "type": "string",
+            "enum": [
+                "child.search",
+                "result.search",
+                "result.download",
+                "package.create",
+                "package.update",
+                "package.change_active_status",
+                "package.delete",
+                "user.delete_account",
+                "user.cancel_delete_account",
+                "user.purge_account",
+                "user.change_role",
+                "data_export.request",
+                "data_export.download",
+                "child.grant_care_access",
+                "child.revoke_care_access",
+                "child.invite_guardian",
+                "child.accept_guardianship",
+                "child.remove_guardian",
+                "child.initiate_transfer",
+                "child.transfer",
+                "child.archive",
+                "child.restore",
+                "child.delete",
+                "organization.create",
+                "user.set_organization"
+            ],
+            "x-enum-varnames": [
+                "AuditActionSearchChild",
+                "AuditActionSearchResult",
+                "AuditActionDownloadResult",
+                "AuditActionCreatePackage",
+                "AuditActionUpdatePackage",
+                "AuditActionChangePackageStatus",
+                "AuditActionDeletePackage",
+                "AuditActionDeleteAccount",
+                "AuditActionCancelDeleteAccount",
+                "AuditActionPurgeAccount",
+                "AuditActionChangeUserRole",
+                "AuditActionRequestDataExport",
+                "AuditActionDownloadDataExport",
+                "AuditActionGrantCareAccess",
+                "AuditActionRevokeCareAccess",
+                "AuditActionInviteGuardian",
+                "AuditActionAcceptGuardianship",
+                "AuditActionRemoveGuardian",
+                "AuditActionInitiateTransfer",
+                "AuditActionTransferChild",
+                "AuditActionArchiveChild",
+                "AuditActionRestoreChild",
+                "AuditActionDeleteChild",
+                "AuditActionCreateOrganization",
+                "AuditActionSetUserOrganization"
+            ]
+        },
+        "model.AuditMetadata": {
+            "type": "object",
+            "additionalProperties": {}
+        },
+        "model.AuditTargetType": {
+            "type": "string",
+            "enum": [
+                "child",
+                "result",
+                "package",
+                "user",
+                "data_export",
+                "organization"
+            ],
+            "x-enum-varnames": [
+                "AuditTargetChild",
+                "AuditTargetResult",
+                "AuditTargetPackage",
+                "AuditTargetUser",
+                "AuditTargetExport",
+                "AuditTargetOrganization"
+            ]
+        },
+        "model.CareScope": {
+            "type": "string",
+            "enum": [
+                "read",
+                "submit"
+            ],
+            "x-enum-varnames": [
+                "CareScopeRead",
+                "CareScopeSubmit"
+            ]
+        },
+        "model.ChecklistGroup": {
+            "type": "object",
+            "required": [
+                "custom_name",
+                "options",
+                "questions"
+            ],
+            "properties": {
+                "custom_name": {
+                    "description": "CustomName can be used to be displayed to user. if empty, the one from\nthe template will be used",
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/model.AnswerOption"
+                    }
+                },
+                "questions": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
                         "type": "string"
                     }
                 }
             }
         },
-        "model.ImageResultAttributeKey": {
+        "model.DataExportStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "processing",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "DataExportStatusPending",
+                "DataExportStatusProcessing",
+                "DataExportStatusCompleted",
+                "DataExportStatusFailed"
+            ]
+        },
+        "model.GuardianRole": {
+            "type": "string",
+            "enum": [
+                "owner",
+                "guardian"
+            ],
+            "x-enum-varnames": [
+                "GuardianRoleOwner",
+                "GuardianRoleGuardian"
+            ]
+        },
+        "model.ImageResultAttributeKey": {
+            "type": "object",
+            "required": [
+                "indication",
+                "result_id",
+                "submitted_at",
+                "title",
+                "total"
+            ],
+            "properties": {
+                "indication": {
+                    "type": "string"
+                },
+                "result_id": {
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.IndicationCategory": {
+            "type": "object",
+            "required": [
+                "detail",
+                "maximum_score",
+                "name"
+            ],
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "maximum_score": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "minimum_score": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PersonalAccessTokenScope": {
+            "type": "string",
+            "enum": [
+                "packages",
+                "children",
+                "questionnaires",
+                "users",
+                "admin"
+            ],
+            "x-enum-varnames": [
+                "PersonalAccessTokenScopePackages",
+                "PersonalAccessTokenScopeChildren",
+                "PersonalAccessTokenScopeQuestionnaires",
+                "PersonalAccessTokenScopeUsers",
+                "PersonalAccessTokenScopeAdmin"
+            ]
+        },
+        "model.Questionnaire": {
+            "type": "object",
+            "additionalProperties": {
+                "$ref": "#/definitions/model.ChecklistGroup"
+            }
+        },
+        "model.ResultDetail": {
+            "type": "object",
+            "additionalProperties": {
+                "$ref": "#/definitions/model.SubtestGrade"
+            }
+        },
+        "model.Roles": {
+            "type": "string",
+            "enum": [
+                "administrator",
+                "parent",
+                "therapist"
+            ],
+            "x-enum-varnames": [
+                "RolesAdministrator",
+                "RolesParent",
+                "RolesTherapist"
+            ]
+        },
+        "model.SubtestGrade": {
+            "type": "object",
+            "properties": {
+                "grade": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.AcceptChildTransferInput": {
+            "type": "object",
+            "required": [
+                "transfer_token"
+            ],
+            "properties": {
+                "transfer_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.AcceptChildTransferOutput": {
+            "type": "object",
+            "properties": {
+                "child_id": {
+                    "type": "string"
+                },
+                "parent_user_id": {
+                    "type": "string"
+                },
+                "previous_parent_user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.AcceptGuardianInvitationInput": {
+            "type": "object",
+            "required": [
+                "invitation_token"
+            ],
+            "properties": {
+                "invitation_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.ActivationPackageInput": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "rest.ActivationPackageOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.AdminChangeUserActivationInput": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "rest.AdminChangeUserRoleInput": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "enum": [
+                        "administrator",
+                        "therapist",
+                        "parent"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Roles"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.AdminCreateOrganizationInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Klinik Tumbuh Kembang"
+                }
+            }
+        },
+        "rest.AdminSetMFARequirementInput": {
+            "type": "object",
+            "properties": {
+                "required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "rest.AdminSetUserOrganizationInput": {
+            "type": "object",
+            "properties": {
+                "is_organization_admin": {
+                    "type": "boolean"
+                },
+                "organization_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.AdminUpdateUserOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.AdminUserOutput": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "failed_login_attempts": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_organization_admin": {
+                    "type": "boolean"
+                },
+                "locked_until": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "roles": {
+                    "$ref": "#/definitions/model.Roles"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.ArchiveChildOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "child archived"
+                }
+            }
+        },
+        "rest.AuditLogOutput": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.AuditAction"
+                        }
+                    ],
+                    "example": "child.search"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "actor_role": {
+                    "$ref": "#/definitions/model.Roles"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string",
+                    "example": "203.0.113.10"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/model.AuditMetadata"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.AuditTargetType"
+                        }
+                    ],
+                    "example": "child"
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0"
+                }
+            }
+        },
+        "rest.ChangePasswordInput": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.ChangePasswordOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "your password has been changed"
+                }
+            }
+        },
+        "rest.ConfirmChangeEmailInput": {
+            "type": "object",
+            "required": [
+                "change_email_token"
+            ],
+            "properties": {
+                "change_email_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.ConfirmChangeEmailOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "your email has been changed"
+                }
+            }
+        },
+        "rest.CreatePackageInput": {
+            "type": "object",
+            "required": [
+                "image_result_attribute_key",
+                "indication_categories",
+                "package_name",
+                "questionnaire"
+            ],
+            "properties": {
+                "image_result_attribute_key": {
+                    "$ref": "#/definitions/model.ImageResultAttributeKey"
+                },
+                "indication_categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.IndicationCategory"
+                    }
+                },
+                "package_name": {
+                    "type": "string"
+                },
+                "questionnaire": {
+                    "$ref": "#/definitions/model.Questionnaire"
+                }
+            }
+        },
+        "rest.CreatePackageOutput": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.CreatePersonalAccessTokenInput": {
+            "type": "object",
+            "required": [
+                "expires_in_days",
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_in_days": {
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1,
+                    "example": 90
+                },
+                "name": {
+                    "type": "string",
+                    "example": "monthly report script"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "enum": [
+                            "packages",
+                            "children",
+                            "questionnaires",
+                            "users",
+                            "admin"
+                        ],
+                        "$ref": "#/definitions/model.PersonalAccessTokenScope"
+                    }
+                }
+            }
+        },
+        "rest.CreatePersonalAccessTokenOutput": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PersonalAccessTokenScope"
+                    }
+                },
+                "token": {
+                    "description": "Token is only shown once, store it securely",
+                    "type": "string",
+                    "example": "atec_pat_xxxxxxxx"
+                }
+            }
+        },
+        "rest.DataExportOutput": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt is when the download link sent to the email expires, only set once the export is completed",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "enum": [
+                        "pending",
+                        "processing",
+                        "completed",
+                        "failed"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.DataExportStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.DeleteAccountInput": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "rest.DeleteChildOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "child deleted"
+                }
+            }
+        },
+        "rest.GetATECQuestionnaireOutput": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "questionnaire": {
+                    "$ref": "#/definitions/model.Questionnaire"
+                }
+            }
+        },
+        "rest.GetMyChildernOutput": {
+            "type": "object",
+            "properties": {
+                "archived_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "boolean"
+                },
+                "guardian_name": {
+                    "type": "string"
+                },
+                "guardian_role": {
+                    "enum": [
+                        "owner",
+                        "guardian"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.GuardianRole"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_user_id": {
+                    "type": "string"
+                },
+                "parent_user_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.GetMyProfileOutput": {
             "type": "object",
-            "required": [
-                "indication",
-                "result_id",
-                "submitted_at",
-                "title",
-                "total"
-            ],
             "properties": {
-                "indication": {
+                "address": {
                     "type": "string"
                 },
-                "result_id": {
+                "created_at": {
                     "type": "string"
                 },
-                "submitted_at": {
+                "email": {
                     "type": "string"
                 },
-                "title": {
+                "id": {
                     "type": "string"
                 },
-                "total": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "roles": {
+                    "$ref": "#/definitions/model.Roles"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "model.IndicationCategory": {
+        "rest.GetTherapistOutput": {
             "type": "object",
-            "required": [
-                "detail",
-                "maximum_score",
-                "name"
-            ],
             "properties": {
-                "detail": {
+                "created_at": {
                     "type": "string"
                 },
-                "maximum_score": {
-                    "type": "integer",
-                    "minimum": 1
+                "id": {
+                    "type": "string"
                 },
-                "minimum_score": {
-                    "type": "integer",
-                    "minimum": 0
+                "is_active": {
+                    "type": "boolean"
                 },
-                "name": {
+                "roles": {
+                    "$ref": "#/definitions/model.Roles"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "model.Questionnaire": {
+        "rest.GrantTherapistAccessInput": {
             "type": "object",
-            "additionalProperties": {
-                "$ref": "#/definitions/model.ChecklistGroup"
+            "properties": {
+                "child_id": {
+                    "type": "string"
+                },
+                "scope": {
+                    "enum": [
+                        "read",
+                        "submit"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.CareScope"
+                        }
+                    ]
+                }
             }
         },
-        "model.ResultDetail": {
+        "rest.GuardianOutput": {
             "type": "object",
-            "additionalProperties": {
-                "$ref": "#/definitions/model.SubtestGrade"
+            "properties": {
+                "child_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "enum": [
+                        "owner",
+                        "guardian"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.GuardianRole"
+                        }
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
             }
         },
-        "model.Roles": {
-            "type": "string",
-            "enum": [
-                "administrator",
-                "parent",
-                "therapist"
+        "rest.InitChangeEmailInput": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_email"
             ],
-            "x-enum-varnames": [
-                "RolesAdministrator",
-                "RolesParent",
-                "RolesTherapist"
-            ]
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_email": {
+                    "type": "string"
+                }
+            }
         },
-        "model.SubtestGrade": {
+        "rest.InitChangeEmailOutput": {
             "type": "object",
             "properties": {
-                "grade": {
-                    "type": "integer"
-                },
-                "name": {
+                "message": {
+                    "type": "string",
+                    "example": "a confirmation link has been sent to the new email"
+                }
+            }
+        },
+        "rest.InitPasswordlessLoginInput": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
                     "type": "string"
                 }
             }
         },
-        "rest.ActivationPackageInput": {
+        "rest.InitPasswordlessLoginOutput": {
             "type": "object",
             "properties": {
-                "status": {
-                    "type": "boolean"
+                "message": {
+                    "type": "string",
+                    "example": "ok"
                 }
             }
         },
-        "rest.ActivationPackageOutput": {
+        "rest.InitResetPasswordInput": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.InitResetPasswordOutput": {
             "type": "object",
             "properties": {
                 "message": {
@@ -1864,211 +6530,326 @@ const docTemplate = `{
                 }
             }
         },
-        "rest.CreatePackageInput": {
+        "rest.InitiateChildTransferInput": {
             "type": "object",
             "required": [
-                "image_result_attribute_key",
-                "indication_categories",
-                "package_name",
-                "questionnaire"
+                "email"
             ],
             "properties": {
-                "image_result_attribute_key": {
-                    "$ref": "#/definitions/model.ImageResultAttributeKey"
+                "email": {
+                    "type": "string",
+                    "example": "recipient@string.com"
+                }
+            }
+        },
+        "rest.InitiateChildTransferOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "transfer request sent"
+                }
+            }
+        },
+        "rest.InviteGuardianInput": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "guardian@string.com"
+                }
+            }
+        },
+        "rest.InviteGuardianOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "invitation sent"
+                }
+            }
+        },
+        "rest.InviteTherapistInput": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "therapist@string.com"
+                }
+            }
+        },
+        "rest.InviteTherapistOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "invitation sent"
+                }
+            }
+        },
+        "rest.LoginInput": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
                 },
-                "indication_categories": {
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "rest.LoginOutput": {
+            "type": "object",
+            "properties": {
+                "mfa_enrollment_required": {
+                    "type": "boolean"
+                },
+                "mfa_required": {
+                    "type": "boolean"
+                },
+                "mfa_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.MFAConfirmEnrollmentInput": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "mfa_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.MFAConfirmEnrollmentOutput": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.IndicationCategory"
+                        "type": "string"
                     }
                 },
-                "package_name": {
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.MFADisableInput": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "rest.MFADisableOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "two-factor authentication disabled"
+                }
+            }
+        },
+        "rest.MFAEnrollInput": {
+            "type": "object",
+            "properties": {
+                "mfa_token": {
                     "type": "string"
-                },
-                "questionnaire": {
-                    "$ref": "#/definitions/model.Questionnaire"
                 }
             }
         },
-        "rest.CreatePackageOutput": {
+        "rest.MFAEnrollOutput": {
             "type": "object",
             "properties": {
-                "id": {
+                "provisioning_uri": {
+                    "type": "string",
+                    "example": "otpauth://totp/ATEC:user@mail.com?secret=SECRET\u0026issuer=ATEC"
+                },
+                "secret": {
                     "type": "string"
                 }
             }
         },
-        "rest.DeleteAccountInput": {
+        "rest.MFAVerifyLoginInput": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "mfa_token"
             ],
             "properties": {
-                "email": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "mfa_token": {
                     "type": "string"
                 },
-                "password": {
+                "recovery_code": {
                     "type": "string",
-                    "minLength": 8
+                    "example": "a1b2c-3d4e5"
                 }
             }
         },
-        "rest.GetATECQuestionnaireOutput": {
+        "rest.MagicLinkLoginInput": {
             "type": "object",
+            "required": [
+                "magic_link_token"
+            ],
             "properties": {
-                "id": {
-                    "type": "string"
-                },
-                "name": {
+                "magic_link_token": {
                     "type": "string"
-                },
-                "questionnaire": {
-                    "$ref": "#/definitions/model.Questionnaire"
                 }
             }
         },
-        "rest.GetMyChildernOutput": {
+        "rest.OrganizationOutput": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "date_of_birth": {
-                    "type": "string"
-                },
-                "gender": {
-                    "type": "boolean"
-                },
-                "guardian_name": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "parent_user_id": {
-                    "type": "string"
-                },
-                "parent_user_name": {
-                    "type": "string"
-                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "rest.GetMyProfileOutput": {
+        "rest.PersonalAccessTokenOutput": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "email": {
+                "expires_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "phone_number": {
+                "last_used_at": {
                     "type": "string"
                 },
-                "roles": {
-                    "$ref": "#/definitions/model.Roles"
-                },
-                "updated_at": {
+                "name": {
                     "type": "string"
                 },
-                "username": {
+                "revoked_at": {
                     "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PersonalAccessTokenScope"
+                    }
                 }
             }
         },
-        "rest.GetTherapistOutput": {
+        "rest.QuestionnaireGrade": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "roles": {
-                    "$ref": "#/definitions/model.Roles"
+                "detail": {
+                    "$ref": "#/definitions/model.ResultDetail"
                 },
-                "updated_at": {
-                    "type": "string"
+                "indication": {
+                    "$ref": "#/definitions/model.IndicationCategory"
                 },
-                "username": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "rest.InitResetPasswordInput": {
+        "rest.RedeemTherapistInvitationInput": {
             "type": "object",
             "required": [
-                "email"
+                "invitation_token",
+                "password",
+                "username"
             ],
             "properties": {
-                "email": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Example No. 123, Jakarta"
+                },
+                "invitation_token": {
                     "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "password123"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "example": "+628123456789"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "username"
                 }
             }
         },
-        "rest.InitResetPasswordOutput": {
+        "rest.RedeemTherapistInvitationOutput": {
             "type": "object",
             "properties": {
                 "message": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "your therapist account has been created"
                 }
             }
         },
-        "rest.LoginInput": {
+        "rest.RefreshTokenInput": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "refresh_token"
             ],
             "properties": {
-                "email": {
+                "refresh_token": {
                     "type": "string"
-                },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
                 }
             }
         },
-        "rest.LoginOutput": {
+        "rest.RefreshTokenOutput": {
             "type": "object",
             "properties": {
-                "token": {
+                "refresh_token": {
                     "type": "string"
-                }
-            }
-        },
-        "rest.QuestionnaireGrade": {
-            "type": "object",
-            "properties": {
-                "detail": {
-                    "$ref": "#/definitions/model.ResultDetail"
                 },
-                "indication": {
-                    "$ref": "#/definitions/model.IndicationCategory"
-                },
-                "total": {
-                    "type": "integer"
+                "token": {
+                    "type": "string"
                 }
             }
         },
@@ -2103,6 +6884,15 @@ const docTemplate = `{
                 }
             }
         },
+        "rest.RemoveGuardianOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "guardian removed"
+                }
+            }
+        },
         "rest.ResendVerificationInput": {
             "type": "object",
             "required": [
@@ -2146,6 +6936,62 @@ const docTemplate = `{
                 }
             }
         },
+        "rest.RestoreAccountInput": {
+            "type": "object",
+            "required": [
+                "restore_token"
+            ],
+            "properties": {
+                "restore_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.RestoreAccountOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "your account has been restored"
+                }
+            }
+        },
+        "rest.RestoreChildOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "child restored"
+                }
+            }
+        },
+        "rest.RevokeMySessionOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "session revoked"
+                }
+            }
+        },
+        "rest.RevokePersonalAccessTokenOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "personal access token revoked"
+                }
+            }
+        },
+        "rest.RevokeTherapistAccessOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "therapist access revoked"
+                }
+            }
+        },
         "rest.SearchActivePackageOutput": {
             "type": "object",
             "properties": {
@@ -2227,6 +7073,35 @@ const docTemplate = `{
                 }
             }
         },
+        "rest.SessionOutput": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "description": "Current is true for the session used to make the request",
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string",
+                    "example": "203.0.113.10"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0"
+                }
+            }
+        },
         "rest.SignupInput": {
             "type": "object",
             "required": [
@@ -2335,6 +7210,57 @@ const docTemplate = `{
                 }
             }
         },
+        "rest.TherapistAccessOutput": {
+            "type": "object",
+            "properties": {
+                "child_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "scope": {
+                    "enum": [
+                        "read",
+                        "submit"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.CareScope"
+                        }
+                    ]
+                },
+                "therapist_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.UnlockAccountInput": {
+            "type": "object",
+            "required": [
+                "unlock_token"
+            ],
+            "properties": {
+                "unlock_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.UnlockAccountOutput": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "your account has been unlocked"
+                }
+            }
+        },
         "rest.UpdateChildernInput": {
             "type": "object",
             "required": [
@@ -2436,6 +7362,22 @@ const docTemplate = `{
                 }
             }
         },
+        "rest.VerifyPasswordlessLoginInput": {
+            "type": "object",
+            "required": [
+                "code",
+                "email"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
         "usecase.StatisticComponent": {
             "type": "object",
             "properties": {
@@ -6,10 +6,17 @@ import (
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/sweet-go/stdlib/encryption"
@@ -19,53 +26,170 @@ import (
 // DefaultBlockSize is the default block size used for encryption/decryption
 const DefaultBlockSize int = 16
 
+// LegacyEncryptionKeyVersion is the version of the key set as CreateCryptorOpts.EncryptionKey. The AES-CBC ciphertexts
+// encrypted using it are not tagged with the version, to stay compatible with the ones created before keys were versioned
+const LegacyEncryptionKeyVersion = 1
+
+const (
+	cbcCipherTextPrefix = "v"
+	gcmCipherTextPrefix = "g"
+)
+
 // SharedCryptor instance contains common functionality relates to cryptograpic functions
 // and is reusable throughout the entire codebase
 type SharedCryptor struct {
-	encryptionKey []byte
-	iv            string
-	blockSize     int
-	hashCost      int
+	encryptionKey    []byte
+	encryptionKeys   map[int]versionedKey
+	activeKeyVersion int
+	blindIndexKey    []byte
+	blockSize        int
+	hashCost         int
+	jwtKeySet        *JWTKeySet
+	acceptHS256      bool
+}
+
+type versionedKey struct {
+	key []byte
+	iv  string
 }
 
 // SharedCryptorIface interface for SharedCryptor. Provided to ease the mocking process later
 type SharedCryptorIface interface {
 	Encrypt(plainText string) (string, error)
+	LegacyEncrypt(plainText string) ([]string, error)
 	Decrypt(cipherText string) (string, error)
+	BlindIndex(value string) string
 	Hash(data []byte) (string, error)
 	CreateJWT(claims jwt.Claims) (string, error)
 	ValidateJWT(token string, opts ValidateJWTOpts) (*jwt.Token, error)
 	CompareHash(hashed []byte, plain []byte) error
 }
 
+// VersionedEncryptionKey is an encryption key identified by its version. The version must be greater than
+// LegacyEncryptionKeyVersion and must never be reused for another key
+type VersionedEncryptionKey struct {
+	Version int
+	Key     []byte
+	IV      string
+}
+
 // CreateCryptorOpts is the options used to create a new cryptor instance.
+// JWTKeySet is used to sign and verify the jwt. Set AcceptLegacyHS256JWT to keep accepting the jwt signed
+// using the encryption key, only intended while the tokens issued before the key set was introduced are still valid.
+// RotatedEncryptionKeys are the keys introduced after EncryptionKey, the one with the highest version will be used to encrypt.
+// BlindIndexKey is used to create the blind index, and unlike the encryption keys, can't be rotated without recreating every index
 type CreateCryptorOpts struct {
-	HashCost      int
-	EncryptionKey []byte
-	IV            string
-	BlockSize     int
+	HashCost              int
+	EncryptionKey         []byte
+	IV                    string
+	RotatedEncryptionKeys []VersionedEncryptionKey
+	BlindIndexKey         []byte
+	BlockSize             int
+	JWTKeySet             *JWTKeySet
+	AcceptLegacyHS256JWT  bool
 }
 
 // NewSharedCryptor create a new instance of SharedCryptor
 func NewSharedCryptor(opts *CreateCryptorOpts) *SharedCryptor {
+	encryptionKey := encryption.SHA256Hash(opts.EncryptionKey) // better implement hkdf
+
+	keys := map[int]versionedKey{
+		LegacyEncryptionKeyVersion: {key: encryptionKey, iv: opts.IV},
+	}
+	activeKeyVersion := LegacyEncryptionKeyVersion
+
+	for _, k := range opts.RotatedEncryptionKeys {
+		keys[k.Version] = versionedKey{key: encryption.SHA256Hash(k.Key), iv: k.IV}
+
+		if k.Version > activeKeyVersion {
+			activeKeyVersion = k.Version
+		}
+	}
+
 	return &SharedCryptor{
-		encryptionKey: encryption.SHA256Hash(opts.EncryptionKey), // better implement hkdf
-		iv:            opts.IV,
-		blockSize:     opts.BlockSize,
-		hashCost:      opts.HashCost,
+		encryptionKey:    encryptionKey,
+		encryptionKeys:   keys,
+		activeKeyVersion: activeKeyVersion,
+		blindIndexKey:    opts.BlindIndexKey,
+		blockSize:        opts.BlockSize,
+		hashCost:         opts.HashCost,
+		jwtKeySet:        opts.JWTKeySet,
+		acceptHS256:      opts.AcceptLegacyHS256JWT,
 	}
 }
 
-// Encrypt takes plainText and returning the encrypted form of it
+// ActiveEncryptionKeyVersion return the version of the key used to encrypt
+func (s *SharedCryptor) ActiveEncryptionKeyVersion() int {
+	return s.activeKeyVersion
+}
+
+// NeedsReencryption report whether the cipherText is not yet encrypted using AES-GCM with the active key
+func (s *SharedCryptor) NeedsReencryption(cipherText string) (bool, error) {
+	version, isGCM, _, err := parseCipherText(cipherText)
+	if err != nil {
+		return false, err
+	}
+
+	return !isGCM || version != s.activeKeyVersion, nil
+}
+
+// Encrypt takes plainText and returning the encrypted form of it using AES-GCM with the active key.
+// A random nonce is used on every call, so the same plainText results in a different cipherText each time.
+// Use BlindIndex instead when the value needs to be looked up
 func (s *SharedCryptor) Encrypt(plainText string) (string, error) {
-	ivKey, err := hex.DecodeString(s.iv)
+	aead, err := newGCM(s.encryptionKeys[s.activeKeyVersion].key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	// the nonce is prepended to the sealed value, because it is needed to open it
+	sealed := aead.Seal(nonce, nonce, []byte(plainText), nil)
+
+	return fmt.Sprintf("%s%d:%s", gcmCipherTextPrefix, s.activeKeyVersion, hex.EncodeToString(sealed)), nil
+}
+
+// LegacyEncrypt takes plainText and returning the encrypted forms of it the way Encrypt did before AES-GCM was used,
+// which is AES-CBC with the static iv of the key. One cipherText is returned for every configured key, ordered by the key
+// version, because the values stored before the blind index was introduced may be encrypted using any of them until
+// reencrypted. The same plainText always results in the same cipherTexts, thus only intended to look up those values
+func (s *SharedCryptor) LegacyEncrypt(plainText string) ([]string, error) {
+	versions := make([]int, 0, len(s.encryptionKeys))
+	for version := range s.encryptionKeys {
+		versions = append(versions, version)
+	}
+
+	sort.Ints(versions)
+
+	cipherTexts := make([]string, 0, len(versions))
+
+	for _, version := range versions {
+		cipherText, err := s.legacyEncryptWithKey(version, plainText)
+		if err != nil {
+			return nil, err
+		}
+
+		cipherTexts = append(cipherTexts, cipherText)
+	}
+
+	return cipherTexts, nil
+}
+
+func (s *SharedCryptor) legacyEncryptWithKey(version int, plainText string) (string, error) {
+	key := s.encryptionKeys[version]
+
+	ivKey, err := hex.DecodeString(key.iv)
 	if err != nil {
 		return "", err
 	}
 
 	bPlaintext := s.pkcs5Padding([]byte(plainText), s.blockSize, len(plainText))
 
-	block, err := aes.NewCipher(s.encryptionKey)
+	block, err := aes.NewCipher(key.key)
 	if err != nil {
 		return "", err
 	}
@@ -75,22 +199,41 @@ func (s *SharedCryptor) Encrypt(plainText string) (string, error) {
 	mode := cipher.NewCBCEncrypter(block, ivKey)
 	mode.CryptBlocks(ciphertext, bPlaintext)
 
-	return hex.EncodeToString(ciphertext), nil
+	if version == LegacyEncryptionKeyVersion {
+		return hex.EncodeToString(ciphertext), nil
+	}
+
+	return fmt.Sprintf("%s%d:%s", cbcCipherTextPrefix, version, hex.EncodeToString(ciphertext)), nil
 }
 
-// Decrypt takes the cipherText and returning the decrypted value of it
+// Decrypt takes the cipherText and returning the decrypted value of it, using the key the cipherText was encrypted with.
+// Both the AES-GCM and the legacy AES-CBC cipherText are supported
 func (s *SharedCryptor) Decrypt(cipherText string) (string, error) {
-	ivKey, err := s.generateIVKey(s.iv)
+	version, isGCM, payload, err := parseCipherText(cipherText)
+	if err != nil {
+		return "", err
+	}
+
+	key, found := s.encryptionKeys[version]
+	if !found {
+		return "", fmt.Errorf("unknown encryption key version: %d", version)
+	}
+
+	cipherTextDecoded, err := hex.DecodeString(payload)
 	if err != nil {
 		return "", err
 	}
 
-	cipherTextDecoded, err := hex.DecodeString(cipherText)
+	if isGCM {
+		return decryptGCM(key.key, cipherTextDecoded)
+	}
+
+	ivKey, err := s.generateIVKey(key.iv)
 	if err != nil {
 		return "", err
 	}
 
-	block, err := aes.NewCipher(s.encryptionKey)
+	block, err := aes.NewCipher(key.key)
 	if err != nil {
 		return "", err
 	}
@@ -101,6 +244,15 @@ func (s *SharedCryptor) Decrypt(cipherText string) (string, error) {
 	return string(s.pkcs5Unpadding(cipherTextDecoded)), nil
 }
 
+// BlindIndex return the hex encoded HMAC-SHA256 of the value using the blind index key. The result is deterministic
+// and reveals nothing about the value, so it can be stored next to the encrypted value to look it up
+func (s *SharedCryptor) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, s.blindIndexKey)
+	mac.Write([]byte(value))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 // Hash generates hashed value utilizing bcrypt of data in form of base64 encoded string
 func (s *SharedCryptor) Hash(data []byte) (string, error) {
 	hashed, err := bcrypt.GenerateFromPassword(data, s.hashCost)
@@ -116,11 +268,13 @@ func (s *SharedCryptor) CompareHash(hashed []byte, plain []byte) error {
 	return bcrypt.CompareHashAndPassword(hashed, plain)
 }
 
-// CreateJWT create a jwt token
+// CreateJWT create a jwt token signed using the current signing key of the jwt key set.
+// The kid header is set, so the verifier can pick the right key while the keys are being rotated
 func (s *SharedCryptor) CreateJWT(claims jwt.Claims) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = s.jwtKeySet.SigningKeyID()
 
-	signed, err := token.SignedString(s.encryptionKey)
+	signed, err := token.SignedString(s.jwtKeySet.signingKey)
 	if err != nil {
 		return "", err
 	}
@@ -135,23 +289,21 @@ type ValidateJWTOpts struct {
 }
 
 // ValidateJWT validate JWT with several extra checks provided by the jwt module, such as:
-// WithExpirationRequired, WithIssuer, WithSubject, WithValidMethods
+// WithExpirationRequired, WithIssuer, WithSubject, WithValidMethods.
+// The token must be signed by one of the verification keys identified by the kid header
 func (s *SharedCryptor) ValidateJWT(token string, opts ValidateJWTOpts) (*jwt.Token, error) {
-	parsedToken, err := jwt.Parse(token,
-		func(t *jwt.Token) (interface{}, error) {
-			if t.Method.Alg() != jwt.SigningMethodHS256.Name {
-				return nil, errors.New("invalid token alg")
-			}
+	validMethods := []string{jwt.SigningMethodEdDSA.Alg()}
+	if s.acceptHS256 {
+		validMethods = append(validMethods, jwt.SigningMethodHS256.Name)
+	}
 
-			return s.encryptionKey, nil
-		},
+	parsedToken, err := jwt.Parse(token,
+		s.jwtVerificationKey,
 		jwt.WithExpirationRequired(),
 		jwt.WithIssuer(opts.Issuer),
 		jwt.WithSubject(opts.Subject),
 		jwt.WithIssuedAt(),
-		jwt.WithValidMethods([]string{
-			jwt.SigningMethodHS256.Name,
-		}),
+		jwt.WithValidMethods(validMethods),
 	)
 
 	if err != nil {
@@ -166,6 +318,30 @@ func (s *SharedCryptor) ValidateJWT(token string, opts ValidateJWTOpts) (*jwt.To
 	return parsedToken, nil
 }
 
+func (s *SharedCryptor) jwtVerificationKey(t *jwt.Token) (interface{}, error) {
+	switch t.Method.Alg() {
+	default:
+		return nil, errors.New("invalid token alg")
+	case jwt.SigningMethodHS256.Name:
+		// TODO: remove the HS256 fallback, along with the jwt.accept_legacy_hs256 config, once the longest token
+		// expiry has passed since the Ed25519 signing key was deployed
+		if !s.acceptHS256 {
+			return nil, errors.New("invalid token alg")
+		}
+
+		return s.encryptionKey, nil
+	case jwt.SigningMethodEdDSA.Alg():
+		kid, _ := t.Header["kid"].(string)
+
+		key, found := s.jwtKeySet.VerificationKey(kid)
+		if !found {
+			return nil, errors.New("unknown token kid")
+		}
+
+		return key, nil
+	}
+}
+
 func (s *SharedCryptor) pkcs5Unpadding(src []byte) []byte {
 	if len(src) == 0 {
 		return nil
@@ -207,6 +383,57 @@ func (s *SharedCryptor) generateIVKey(iv string) ([]byte, error) {
 	return hex.DecodeString(ivKey)
 }
 
+// parseCipherText split the cipherText into the key version, whether it is encrypted using AES-GCM, and the hex encoded
+// payload. The cipherText is formatted as g<version>:<hex> for AES-GCM, v<version>:<hex> for the versioned AES-CBC,
+// or just the hex for the AES-CBC using LegacyEncryptionKeyVersion
+func parseCipherText(cipherText string) (int, bool, string, error) {
+	isGCM := strings.HasPrefix(cipherText, gcmCipherTextPrefix)
+	if !isGCM && !strings.HasPrefix(cipherText, cbcCipherTextPrefix) {
+		return LegacyEncryptionKeyVersion, false, cipherText, nil
+	}
+
+	versionStr, payload, found := strings.Cut(cipherText[1:], ":")
+	if !found {
+		return 0, false, "", errors.New("malformed versioned ciphertext")
+	}
+
+	version, err := strconv.Atoi(versionStr)
+	if err != nil {
+		return 0, false, "", fmt.Errorf("invalid ciphertext key version: %w", err)
+	}
+
+	return version, isGCM, payload, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func decryptGCM(key []byte, sealed []byte) (string, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("ciphertext is too short")
+	}
+
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+	plainText, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plainText), nil
+}
+
 // GenerateRandomIVKey generate random IV value
 func generateRandomIVKey(blockSize int) (string, error) {
 	bytes := make([]byte, blockSize)
@@ -216,3 +443,36 @@ func generateRandomIVKey(blockSize int) (string, error) {
 
 	return hex.EncodeToString(bytes), nil
 }
+
+// GenerateSecureToken generate a random token from n bytes read from crypto/rand.
+// The result is encoded using base64 raw url encoding, thus safe to be used in url or headers
+func GenerateSecureToken(n int) (string, error) {
+	bytes := make([]byte, n)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// GenerateNumericCode generate a random numeric code with the given number of digits from crypto/rand.
+// The code is zero padded, so it always has exactly the given number of digits
+func GenerateNumericCode(digits int) (string, error) {
+	upperBound := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+
+	n, err := rand.Int(rand.Reader, upperBound)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", digits, n), nil
+}
+
+// HashToken hash a high entropy token, such as the one generated by GenerateSecureToken, using sha256
+// and returning the hex encoded value. Unlike Hash, the result is deterministic and can be used
+// to lookup or compare the token. Must not be used to hash passwords
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
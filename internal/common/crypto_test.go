@@ -0,0 +1,102 @@
+package common_test
+
+import (
+	"crypto/ed25519"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sweet-go/stdlib/encryption"
+)
+
+func TestSharedCryptor_LegacyEncrypt(t *testing.T) {
+	plainText := "user@example.com"
+	legacyOpts := &common.CreateCryptorOpts{
+		EncryptionKey: []byte("legacy-encryption-key"),
+		IV:            "000102030405060708090a0b0c0d0e0f",
+		BlockSize:     common.DefaultBlockSize,
+	}
+
+	t.Run("only the legacy key is configured", func(t *testing.T) {
+		cryptor := common.NewSharedCryptor(legacyOpts)
+
+		cipherTexts, err := cryptor.LegacyEncrypt(plainText)
+		require.NoError(t, err)
+		require.Len(t, cipherTexts, 1)
+
+		decrypted, err := cryptor.Decrypt(cipherTexts[0])
+		require.NoError(t, err)
+		assert.Equal(t, plainText, decrypted)
+	})
+
+	t.Run("the values encrypted before the rotation are still matched", func(t *testing.T) {
+		storedBeforeRotation, err := common.NewSharedCryptor(legacyOpts).LegacyEncrypt(plainText)
+		require.NoError(t, err)
+
+		rotatedOpts := *legacyOpts
+		rotatedOpts.RotatedEncryptionKeys = []common.VersionedEncryptionKey{
+			{Version: 2, Key: []byte("rotated-encryption-key"), IV: "0f0e0d0c0b0a09080706050403020100"},
+		}
+		cryptor := common.NewSharedCryptor(&rotatedOpts)
+
+		cipherTexts, err := cryptor.LegacyEncrypt(plainText)
+		require.NoError(t, err)
+		require.Len(t, cipherTexts, 2)
+		assert.Equal(t, storedBeforeRotation[0], cipherTexts[0])
+		assert.True(t, strings.HasPrefix(cipherTexts[1], "v2:"))
+
+		for _, cipherText := range cipherTexts {
+			decrypted, err := cryptor.Decrypt(cipherText)
+			require.NoError(t, err)
+			assert.Equal(t, plainText, decrypted)
+		}
+	})
+
+	t.Run("invalid iv", func(t *testing.T) {
+		invalidOpts := *legacyOpts
+		invalidOpts.IV = "not-a-hex"
+
+		_, err := common.NewSharedCryptor(&invalidOpts).LegacyEncrypt(plainText)
+		assert.Error(t, err)
+	})
+}
+
+func TestSharedCryptor_ValidateJWT_LegacyHS256(t *testing.T) {
+	encryptionKey := []byte("legacy-encryption-key")
+	_, signingKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	opts := common.ValidateJWTOpts{Issuer: "system", Subject: "login"}
+	legacyToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": opts.Issuer,
+		"sub": opts.Subject,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString(encryption.SHA256Hash(encryptionKey))
+	require.NoError(t, err)
+
+	newCryptor := func(acceptLegacyHS256JWT bool) *common.SharedCryptor {
+		return common.NewSharedCryptor(&common.CreateCryptorOpts{
+			EncryptionKey:        encryptionKey,
+			BlockSize:            common.DefaultBlockSize,
+			JWTKeySet:            common.NewJWTKeySet(signingKey),
+			AcceptLegacyHS256JWT: acceptLegacyHS256JWT,
+		})
+	}
+
+	t.Run("rejected by default", func(t *testing.T) {
+		_, err := newCryptor(config.JWTAcceptLegacyHS256()).ValidateJWT(legacyToken, opts)
+		assert.Error(t, err)
+	})
+
+	t.Run("accepted when the legacy HS256 jwt is still accepted", func(t *testing.T) {
+		token, err := newCryptor(true).ValidateJWT(legacyToken, opts)
+		require.NoError(t, err)
+		assert.True(t, token.Valid)
+	})
+}
@@ -0,0 +1,155 @@
+package common
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// JSONWebKey is the public part of a jwt signing key in JWK format (RFC 7517)
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+}
+
+// JSONWebKeySet is the list of keys accepted to verify the jwt, published as the jwks document
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWTKeySet hold the Ed25519 key used to sign new jwt and every public key accepted to verify them.
+// Each key is identified by its kid, which is the RFC 7638 thumbprint of the public key.
+// To rotate the key, use the new key to sign while keeping the old public key as a verification key
+// until every token signed by it has expired
+type JWTKeySet struct {
+	signingKeyID     string
+	signingKey       ed25519.PrivateKey
+	verificationKeys map[string]ed25519.PublicKey
+	keyIDs           []string
+}
+
+// NewJWTKeySet create a new JWTKeySet. The public key of the signing key is always accepted for verification
+func NewJWTKeySet(signingKey ed25519.PrivateKey, verificationKeys ...ed25519.PublicKey) *JWTKeySet {
+	signingPublicKey, _ := signingKey.Public().(ed25519.PublicKey)
+
+	ks := &JWTKeySet{
+		signingKeyID:     JWTKeyID(signingPublicKey),
+		signingKey:       signingKey,
+		verificationKeys: make(map[string]ed25519.PublicKey),
+	}
+
+	for _, key := range append([]ed25519.PublicKey{signingPublicKey}, verificationKeys...) {
+		kid := JWTKeyID(key)
+		if _, found := ks.verificationKeys[kid]; found {
+			continue
+		}
+
+		ks.verificationKeys[kid] = key
+		ks.keyIDs = append(ks.keyIDs, kid)
+	}
+
+	return ks
+}
+
+// SigningKeyID return the kid of the key currently used to sign the jwt
+func (ks *JWTKeySet) SigningKeyID() string {
+	return ks.signingKeyID
+}
+
+// VerificationKey return the public key identified by the kid, if accepted
+func (ks *JWTKeySet) VerificationKey(kid string) (ed25519.PublicKey, bool) {
+	key, found := ks.verificationKeys[kid]
+
+	return key, found
+}
+
+// JWKS return every accepted verification key in JWK format, starting with the current signing key
+func (ks *JWTKeySet) JWKS() JSONWebKeySet {
+	keys := make([]JSONWebKey, 0, len(ks.keyIDs))
+	for _, kid := range ks.keyIDs {
+		keys = append(keys, JSONWebKey{
+			KeyType:   "OKP",
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(ks.verificationKeys[kid]),
+			KeyID:     kid,
+			Use:       "sig",
+			Algorithm: "EdDSA",
+		})
+	}
+
+	return JSONWebKeySet{
+		Keys: keys,
+	}
+}
+
+// JWTKeyID return the RFC 7638 thumbprint of the Ed25519 public key, used as the kid
+func JWTKeyID(key ed25519.PublicKey) string {
+	// the members must be ordered lexicographically without any whitespace
+	thumbprintInput := fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":"%s"}`, base64.RawURLEncoding.EncodeToString(key))
+	sum := sha256.Sum256([]byte(thumbprintInput))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// LoadJWTSigningKey read the PEM encoded PKCS #8 Ed25519 private key from the file
+func LoadJWTSigningKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEMFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an Ed25519 private key", path)
+	}
+
+	return privateKey, nil
+}
+
+// LoadJWTVerificationKey read the PEM encoded PKIX Ed25519 public key from the file
+func LoadJWTVerificationKey(path string) (ed25519.PublicKey, error) {
+	block, err := readPEMFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an Ed25519 public key", path)
+	}
+
+	return publicKey, nil
+}
+
+func readPEMFile(path string) (*pem.Block, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM block from " + path)
+	}
+
+	return block, nil
+}
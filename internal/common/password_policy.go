@@ -0,0 +1,82 @@
+package common
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sweet-go/stdlib/helper"
+)
+
+// PasswordMaxLength is the maximum password length in bytes, since bcrypt will not hash anything beyond it
+const PasswordMaxLength = 72
+
+// ErrPasswordBreached returned when the password is listed on the breached password list
+var ErrPasswordBreached = errors.New("this password has appeared in a data breach and can't be used, please choose another password")
+
+// PasswordPolicy hold the rules every new password must satisfy
+type PasswordPolicy struct {
+	minLength int
+	breached  map[string]struct{}
+}
+
+// NewPasswordPolicy create a new PasswordPolicy. The breached passwords are compared case insensitively
+func NewPasswordPolicy(minLength int, breachedPasswords []string) *PasswordPolicy {
+	breached := make(map[string]struct{}, len(breachedPasswords))
+	for _, password := range breachedPasswords {
+		breached[strings.ToLower(password)] = struct{}{}
+	}
+
+	return &PasswordPolicy{
+		minLength: minLength,
+		breached:  breached,
+	}
+}
+
+// Validate return a human readable error describing the first rule violated by the password
+func (p *PasswordPolicy) Validate(password string) error {
+	if len(password) < p.minLength {
+		return fmt.Errorf("password must be at least %d characters long", p.minLength)
+	}
+
+	if len(password) > PasswordMaxLength {
+		return fmt.Errorf("password must not be longer than %d characters", PasswordMaxLength)
+	}
+
+	if _, found := p.breached[strings.ToLower(password)]; found {
+		return ErrPasswordBreached
+	}
+
+	return nil
+}
+
+// LoadBreachedPasswordList read the breached password list from the file, one password per line.
+// Empty lines and lines starting with # are ignored
+func LoadBreachedPasswordList(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer helper.WrapCloser(file.Close)
+
+	passwords := []string{}
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		passwords = append(passwords, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return passwords, nil
+}
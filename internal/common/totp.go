@@ -0,0 +1,132 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // RFC 6238 default algorithm, supported by all authenticator apps
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP parameters as recommended by RFC 6238 and widely supported by authenticator apps
+const (
+	TOTPDigits       = 6
+	TOTPPeriod       = 30 * time.Second
+	totpSecretLength = 20
+
+	// totpAllowedSkew is the number of time steps before and after the current one
+	// still accepted, to tolerate clock drift between the server and the user's device
+	totpAllowedSkew = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret generate a new random TOTP secret, encoded as base32 without padding
+// which is the format expected by authenticator apps
+func GenerateTOTPSecret() (string, error) {
+	secret := make([]byte, totpSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// GenerateTOTPCode generate the TOTP code of the base32 encoded secret at the given time
+func GenerateTOTPCode(secret string, t time.Time) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	return hotp(key, uint64(t.Unix()/int64(TOTPPeriod.Seconds()))), nil
+}
+
+// ValidateTOTPCode report whether the code is valid for the base32 encoded secret at the given time.
+// Codes from the adjacent time steps are also accepted to tolerate clock drift
+func ValidateTOTPCode(secret, code string, t time.Time) bool {
+	if len(code) != TOTPDigits {
+		return false
+	}
+
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return false
+	}
+
+	counter := t.Unix() / int64(TOTPPeriod.Seconds())
+	valid := false
+
+	for i := -totpAllowedSkew; i <= totpAllowedSkew; i++ {
+		expected := hotp(key, uint64(counter+int64(i)))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			valid = true
+		}
+	}
+
+	return valid
+}
+
+// TOTPProvisioningURI build the otpauth uri which can be rendered as a QR code
+// and scanned by authenticator apps
+func TOTPProvisioningURI(issuer, accountName, secret string) string {
+	label := url.PathEscape(issuer + ":" + accountName)
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(TOTPDigits))
+	params.Set("period", fmt.Sprint(int(TOTPPeriod.Seconds())))
+
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
+
+// mfaRecoveryCodeLength is the number of random bytes used for each recovery code
+const mfaRecoveryCodeLength = 5
+
+// GenerateMFARecoveryCode generate a random recovery code formatted as xxxxx-xxxxx to ease typing
+func GenerateMFARecoveryCode() (string, error) {
+	code := make([]byte, mfaRecoveryCodeLength)
+	if _, err := rand.Read(code); err != nil {
+		return "", err
+	}
+
+	encoded := hex.EncodeToString(code)
+
+	return encoded[:len(encoded)/2] + "-" + encoded[len(encoded)/2:], nil
+}
+
+// NormalizeMFARecoveryCode remove the formatting from the user supplied recovery code,
+// so it can be hashed and compared with the generated one
+func NormalizeMFARecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// hotp implement RFC 4226 HOTP algorithm with dynamic truncation
+func hotp(key []byte, counter uint64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", TOTPDigits, value%mod)
+}
@@ -23,6 +23,8 @@ func init() {
 	viper.AddConfigPath("../../")
 	viper.AddConfigPath("../../../")
 
+	viper.SetDefault("jwt.accept_legacy_hs256", false)
+
 	err := viper.ReadInConfig()
 	if err != nil {
 		logrus.WithError(err).Error("failed to read config file")
@@ -62,6 +64,12 @@ func ServerPort() string {
 	return ":" + viper.GetString("server.port")
 }
 
+// ServerTrustedProxies the CIDR ranges of the reverse proxies in front of the server. The client ip address is only taken
+// from the X-Forwarded-For header set by those proxies. If left empty, the ip address of the connection itself is used
+func ServerTrustedProxies() []string {
+	return viper.GetStringSlice("server.trusted_proxies")
+}
+
 // PrivateKeyFilePath private key path
 func PrivateKeyFilePath() string {
 	return viper.GetString("private_key_path")
@@ -72,6 +80,50 @@ func IVKey() string {
 	return viper.GetString("iv_key")
 }
 
+// EncryptionKeyConfig configuration of an encryption key introduced after the one from PrivateKeyFilePath
+type EncryptionKeyConfig struct {
+	Version        int    `mapstructure:"version"`
+	PrivateKeyPath string `mapstructure:"private_key_path"`
+	IVKey          string `mapstructure:"iv_key"`
+}
+
+// RotatedEncryptionKeys the encryption keys introduced after the one from PrivateKeyFilePath, which is always version 1.
+// The key with the highest version will be used to encrypt, the others are kept to decrypt the data not yet re-encrypted
+func RotatedEncryptionKeys() ([]EncryptionKeyConfig, error) {
+	keys := []EncryptionKeyConfig{}
+	if err := viper.UnmarshalKey("encryption.rotated_keys", &keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// BlindIndexKeyPath path to the key used to create the blind index of the encrypted values that need to be looked up,
+// such as the user's email. Must be different from the encryption keys and must not be changed once the index is created
+func BlindIndexKeyPath() string {
+	return viper.GetString("encryption.blind_index_key_path")
+}
+
+// JWTSigningKeyPath path to the PEM encoded PKCS #8 Ed25519 private key used to sign every new jwt
+func JWTSigningKeyPath() string {
+	return viper.GetString("jwt.signing_key_path")
+}
+
+// JWTVerificationKeyPaths paths to the PEM encoded PKIX Ed25519 public keys of the previous signing keys.
+// The jwt signed using those keys are still accepted, keep them until all of those tokens have expired
+func JWTVerificationKeyPaths() []string {
+	return viper.GetStringSlice("jwt.verification_key_paths")
+}
+
+// JWTAcceptLegacyHS256 whether the jwt signed using HS256 with the encryption key are still accepted,
+// configured by jwt.accept_legacy_hs256 and disabled unless explicitly set to true.
+// Only enable while the tokens issued before the Ed25519 signing key was introduced are still valid.
+// This will be removed together with the HS256 fallback once the longest token expiry has passed
+// since the Ed25519 signing key was deployed
+func JWTAcceptLegacyHS256() bool {
+	return viper.GetBool("jwt.accept_legacy_hs256")
+}
+
 // SignupTokenExpiry expiry time in time.Duration
 func SignupTokenExpiry() time.Duration {
 	return viper.GetDuration("signup_token_expiry")
@@ -95,9 +147,43 @@ func SendInBlueIsActivated() bool {
 	return viper.GetBool("sendinblue.is_activated")
 }
 
-// LoginTokenExpiry expiry time for login token in time.Duration
+// LoginTokenExpiry expiry time for login token in time.Duration. Because the login token can be renewed
+// using the refresh token, this value should be kept short. If left unset, will return 15 minutes.
 func LoginTokenExpiry() time.Duration {
-	return viper.GetDuration("login_token_expiry")
+	const defaultExpiry = 15 * time.Minute
+
+	cfg := viper.GetDuration("login_token_expiry")
+	if cfg == 0 {
+		return defaultExpiry
+	}
+
+	return cfg
+}
+
+// RefreshTokenExpiry expiry time for refresh token in time.Duration. Each time the refresh token
+// is used, the session lifetime will be extended by this value. If left unset, will return 30 days.
+func RefreshTokenExpiry() time.Duration {
+	const defaultExpiry = 30 * 24 * time.Hour
+
+	cfg := viper.GetDuration("refresh_token_expiry")
+	if cfg == 0 {
+		return defaultExpiry
+	}
+
+	return cfg
+}
+
+// SessionLastSeenUpdateInterval minimum interval between updates of the session's last seen time,
+// to avoid writing to the database on every authenticated request. If left unset, will return 1 minute.
+func SessionLastSeenUpdateInterval() time.Duration {
+	const defaultInterval = time.Minute
+
+	cfg := viper.GetDuration("session_last_seen_update_interval")
+	if cfg == 0 {
+		return defaultInterval
+	}
+
+	return cfg
 }
 
 // ChangePasswordTokenExpiry change password token expiry in time.Duration
@@ -110,6 +196,221 @@ func ServerResetPasswordBaseURL() string {
 	return viper.GetString("server.reset_password_base_url")
 }
 
+// TherapistInvitationTokenExpiry therapist invitation token expiry in time.Duration.
+// If left unset, will return 7 days.
+func TherapistInvitationTokenExpiry() time.Duration {
+	const defaultExpiry = 7 * 24 * time.Hour
+
+	cfg := viper.GetDuration("therapist_invitation_token_expiry")
+	if cfg == 0 {
+		return defaultExpiry
+	}
+
+	return cfg
+}
+
+// ServerTherapistInvitationBaseURL contains the url for invited therapist when clicking the button
+// on the invitation email. Could be used to point to the front end page to complete the registration
+func ServerTherapistInvitationBaseURL() string {
+	return viper.GetString("server.therapist_invitation_base_url")
+}
+
+// GuardianInvitationTokenExpiry how long the invitation to become the child's guardian can be accepted, in time.Duration.
+// If left unset, will return 7 days.
+func GuardianInvitationTokenExpiry() time.Duration {
+	const defaultExpiry = 7 * 24 * time.Hour
+
+	cfg := viper.GetDuration("guardian_invitation_token_expiry")
+	if cfg == 0 {
+		return defaultExpiry
+	}
+
+	return cfg
+}
+
+// ServerGuardianInvitationBaseURL contains the url for invited guardian when clicking the button
+// on the invitation email. Could be used to point to the front end page to accept the invitation
+func ServerGuardianInvitationBaseURL() string {
+	return viper.GetString("server.guardian_invitation_base_url")
+}
+
+// ChildTransferTokenExpiry how long the transfer of a child to another account can be accepted, in time.Duration.
+// If left unset, will return 3 days.
+func ChildTransferTokenExpiry() time.Duration {
+	const defaultExpiry = 3 * 24 * time.Hour
+
+	cfg := viper.GetDuration("child_transfer_token_expiry")
+	if cfg == 0 {
+		return defaultExpiry
+	}
+
+	return cfg
+}
+
+// ServerChildTransferBaseURL contains the url for the transfer recipient when clicking the button
+// on the transfer email. Could be used to point to the front end page to accept the transfer
+func ServerChildTransferBaseURL() string {
+	return viper.GetString("server.child_transfer_base_url")
+}
+
+// MFAPendingTokenExpiry expiry time of the token given after a successful password check for account
+// with two-factor authentication, in time.Duration. If left unset, will return 5 minutes.
+func MFAPendingTokenExpiry() time.Duration {
+	const defaultExpiry = 5 * time.Minute
+
+	cfg := viper.GetDuration("mfa_pending_token_expiry")
+	if cfg == 0 {
+		return defaultExpiry
+	}
+
+	return cfg
+}
+
+// MFAIssuer the issuer name shown on the user's authenticator app. If left unset, will return ATEC.
+func MFAIssuer() string {
+	const defaultIssuer = "ATEC"
+
+	cfg := viper.GetString("mfa_issuer")
+	if cfg == "" {
+		return defaultIssuer
+	}
+
+	return cfg
+}
+
+// LoginIPAttemptLimit maximum number of login attempts allowed from a single IP address
+// within LoginAttemptLimiterWindow. If left unset, will return 30.
+func LoginIPAttemptLimit() int {
+	const defaultLimit = 30
+
+	cfg := viper.GetInt("login_protection.ip_attempt_limit")
+	if cfg == 0 {
+		return defaultLimit
+	}
+
+	return cfg
+}
+
+// LoginAccountAttemptLimit maximum number of login attempts allowed for a single account
+// within LoginAttemptLimiterWindow. If left unset, will return 10.
+func LoginAccountAttemptLimit() int {
+	const defaultLimit = 10
+
+	cfg := viper.GetInt("login_protection.account_attempt_limit")
+	if cfg == 0 {
+		return defaultLimit
+	}
+
+	return cfg
+}
+
+// LoginAttemptLimiterWindow the window used by the login attempt limiters. If left unset, will return 15 minutes.
+func LoginAttemptLimiterWindow() time.Duration {
+	const defaultWindow = 15 * time.Minute
+
+	cfg := viper.GetDuration("login_protection.attempt_limiter_window")
+	if cfg == 0 {
+		return defaultWindow
+	}
+
+	return cfg
+}
+
+// LoginLockoutThreshold number of consecutive failed login before the account is temporarily locked.
+// If left unset, will return 5.
+func LoginLockoutThreshold() int {
+	const defaultThreshold = 5
+
+	cfg := viper.GetInt("login_protection.lockout_threshold")
+	if cfg == 0 {
+		return defaultThreshold
+	}
+
+	return cfg
+}
+
+// LoginLockoutDuration how long the account is locked after reaching LoginLockoutThreshold.
+// If left unset, will return 30 minutes.
+func LoginLockoutDuration() time.Duration {
+	const defaultDuration = 30 * time.Minute
+
+	cfg := viper.GetDuration("login_protection.lockout_duration")
+	if cfg == 0 {
+		return defaultDuration
+	}
+
+	return cfg
+}
+
+// AccountUnlockTokenExpiry expiry of the token sent to unlock a locked account. If left unset, will return 24 hours.
+func AccountUnlockTokenExpiry() time.Duration {
+	const defaultExpiry = 24 * time.Hour
+
+	cfg := viper.GetDuration("account_unlock_token_expiry")
+	if cfg == 0 {
+		return defaultExpiry
+	}
+
+	return cfg
+}
+
+// ServerAccountUnlockBaseURL contains the url for user when clicking the unlock button on the
+// account locked email. Could be used to point to the front end page along with the unlock token
+func ServerAccountUnlockBaseURL() string {
+	return viper.GetString("server.account_unlock_base_url")
+}
+
+// ChangeEmailTokenExpiry expiry of the token sent to the new email address to confirm the email change.
+// Once expired, the pending email change is discarded. If left unset, will return 1 hour.
+func ChangeEmailTokenExpiry() time.Duration {
+	const defaultExpiry = time.Hour
+
+	cfg := viper.GetDuration("change_email_token_expiry")
+	if cfg == 0 {
+		return defaultExpiry
+	}
+
+	return cfg
+}
+
+// ServerChangeEmailBaseURL contains the url for user when clicking the confirm button on the
+// change email confirmation email. Could be used to point to the front end page along with the change email token
+func ServerChangeEmailBaseURL() string {
+	return viper.GetString("server.change_email_base_url")
+}
+
+// PasswordlessLoginCodeExpiry expiry of the one time code and the magic link sent for passwordless login.
+// Kept short because both can be used to login without password. If left unset, will return 10 minutes.
+func PasswordlessLoginCodeExpiry() time.Duration {
+	const defaultExpiry = 10 * time.Minute
+
+	cfg := viper.GetDuration("passwordless_login.code_expiry")
+	if cfg == 0 {
+		return defaultExpiry
+	}
+
+	return cfg
+}
+
+// PasswordlessLoginVerifyAttemptLimit maximum number of attempts to verify the one time code of a single account
+// within PasswordlessLoginCodeExpiry. If left unset, will return 5.
+func PasswordlessLoginVerifyAttemptLimit() int {
+	const defaultLimit = 5
+
+	cfg := viper.GetInt("passwordless_login.verify_attempt_limit")
+	if cfg == 0 {
+		return defaultLimit
+	}
+
+	return cfg
+}
+
+// ServerPasswordlessLoginBaseURL contains the url for user when clicking the login button on the
+// passwordless login email. Could be used to point to the front end page along with the magic link token
+func ServerPasswordlessLoginBaseURL() string {
+	return viper.GetString("server.passwordless_login_base_url")
+}
+
 // RedisAddr get redis address
 func RedisAddr() string {
 	return viper.GetString("caching.redis.host")
@@ -195,6 +496,82 @@ func ResendSignupVerificationLimiterDuration() time.Duration {
 	return cfg
 }
 
+// InitResetPasswordLimiterDuration init reset password limiter duration, limiting how often the reset password
+// email can be requested for the same email. If left unset or below 1 minutes, will return the default duration of 5 minutes.
+func InitResetPasswordLimiterDuration() time.Duration {
+	const defaultDurationMinutes = 5
+
+	const minimumDurationMinutes = 1
+
+	defaultDuration := defaultDurationMinutes * time.Minute
+	minimumDuration := minimumDurationMinutes * time.Minute
+
+	cfg := viper.GetDuration("init_reset_password_limiter_duration")
+	if cfg == 0 || cfg < minimumDuration {
+		return defaultDuration
+	}
+
+	return cfg
+}
+
+// PasswordMinLength minimum length of a new password. If left unset or below 8, will return 8.
+func PasswordMinLength() int {
+	const minimumLength = 8
+
+	cfg := viper.GetInt("password_policy.min_length")
+	if cfg < minimumLength {
+		return minimumLength
+	}
+
+	return cfg
+}
+
+// BreachedPasswordListPath path to the file containing passwords known from data breaches, which must not be used
+// as a new password. If left unset, will return ./assets/breached-passwords.txt
+func BreachedPasswordListPath() string {
+	const defaultPath = "./assets/breached-passwords.txt"
+
+	cfg := viper.GetString("password_policy.breached_password_list_path")
+	if cfg == "" {
+		return defaultPath
+	}
+
+	return cfg
+}
+
+// ChangeEmailLimiterDuration change email limiter duration, limiting how often the user can request an email change.
+// If left unset or below 1 minutes, will return the default duration of 5 minutes.
+func ChangeEmailLimiterDuration() time.Duration {
+	const defaultDurationMinutes = 5
+
+	const minimumDurationMinutes = 1
+
+	defaultDuration := defaultDurationMinutes * time.Minute
+	minimumDuration := minimumDurationMinutes * time.Minute
+
+	cfg := viper.GetDuration("change_email_limiter_duration")
+	if cfg == 0 || cfg < minimumDuration {
+		return defaultDuration
+	}
+
+	return cfg
+}
+
+// PasswordlessLoginLimiterDuration passwordless login limiter duration, limiting how often the login code
+// can be requested for the same email. If left unset or below 30 seconds, will return the default duration of 1 minute.
+func PasswordlessLoginLimiterDuration() time.Duration {
+	const defaultDuration = time.Minute
+
+	const minimumDuration = 30 * time.Second
+
+	cfg := viper.GetDuration("passwordless_login.limiter_duration")
+	if cfg == 0 || cfg < minimumDuration {
+		return defaultDuration
+	}
+
+	return cfg
+}
+
 // DBMaxIdleConn max idle conn
 func DBMaxIdleConn() int {
 	const defaultMaxIdleConn = 30
@@ -230,3 +607,85 @@ func DBConnMaxLifetime() time.Duration {
 
 	return time.Duration(cfg) * time.Second
 }
+
+// AuditLogRetentionDays how many days the audit logs are kept before being purged. If left unset, will return 365 days.
+func AuditLogRetentionDays() int {
+	const defaultRetentionDays = 365
+
+	cfg := viper.GetInt("audit_log.retention_days")
+	if cfg <= 0 {
+		return defaultRetentionDays
+	}
+
+	return cfg
+}
+
+// AccountDeletionGracePeriod how long the account deletion can still be cancelled before the account and all of its data
+// are permanently deleted. The restore link sent to the user's email is also valid for this long. If left unset, will return 30 days.
+func AccountDeletionGracePeriod() time.Duration {
+	const defaultGracePeriod = 30 * 24 * time.Hour
+
+	cfg := viper.GetDuration("account_deletion.grace_period")
+	if cfg == 0 {
+		return defaultGracePeriod
+	}
+
+	return cfg
+}
+
+// AccountDeletionAnonymizeResults whether the results of the purged accounts are anonymized and kept for package analysis,
+// instead of being deleted along with the account. The anonymized results only keep the child's age band and gender
+func AccountDeletionAnonymizeResults() bool {
+	return viper.GetBool("account_deletion.anonymize_results")
+}
+
+// ServerAccountRestoreBaseURL contains the url for user when clicking the restore button on the
+// account deletion email. Could be used to point to the front end page along with the restore token
+func ServerAccountRestoreBaseURL() string {
+	return viper.GetString("server.account_restore_base_url")
+}
+
+// DataExportLinkExpiry how long the data export download link sent to the user's email can be used.
+// If left unset, will return 7 days.
+func DataExportLinkExpiry() time.Duration {
+	const defaultExpiry = 7 * 24 * time.Hour
+
+	cfg := viper.GetDuration("data_export.link_expiry")
+	if cfg == 0 {
+		return defaultExpiry
+	}
+
+	return cfg
+}
+
+// DataExportProcessingTimeout how long a data export may stay pending or processing before it is considered
+// abandoned, e.g. because the server was restarted while preparing it, and marked as failed. If left unset, will return 1 hour.
+func DataExportProcessingTimeout() time.Duration {
+	const defaultTimeout = time.Hour
+
+	cfg := viper.GetDuration("data_export.processing_timeout")
+	if cfg == 0 {
+		return defaultTimeout
+	}
+
+	return cfg
+}
+
+// DataExportMaxArchiveSize the maximum size in bytes of the data export archive, which is stored on the database
+// until it expires. The export is marked as failed once its archive grows past the limit. If left unset, will return 50 MiB.
+func DataExportMaxArchiveSize() int {
+	const defaultMaxSize = 50 << 20
+
+	cfg := viper.GetInt("data_export.max_archive_size")
+	if cfg <= 0 {
+		return defaultMaxSize
+	}
+
+	return cfg
+}
+
+// ServerDataExportDownloadBaseURL contains the url for user when clicking the download button on the
+// data export email. Could point directly to the download endpoint, or to the front end page along with the export token
+func ServerDataExportDownloadBaseURL() string {
+	return viper.GetString("server.data_export_download_base_url")
+}
@@ -0,0 +1,47 @@
+package console
+
+import (
+	"context"
+
+	"github.com/luckyAkbar/atec/internal/config"
+	"github.com/luckyAkbar/atec/internal/db"
+	"github.com/luckyAkbar/atec/internal/repository"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var purgeDeletedAccountsCMD = &cobra.Command{
+	Use: "purge-deleted-accounts",
+	Long: "permanently delete the accounts, along with all of their data, whose deletion was requested longer than " +
+		"the grace period configured as account_deletion.grace_period. Meant to be run periodically, e.g. daily using cron. " +
+		"Set account_deletion.anonymize_results to keep the anonymized results for package analysis instead of deleting them",
+	Run: purgeDeletedAccountsFn,
+}
+
+//nolint:gochecknoinits
+func init() {
+	rootCMD.AddCommand(purgeDeletedAccountsCMD)
+}
+
+func purgeDeletedAccountsFn(_ *cobra.Command, _ []string) {
+	db.InitializePostgresConn()
+
+	logger := logrus.WithField("grace-period", config.AccountDeletionGracePeriod())
+
+	accountPurgeUsecase := usecase.NewAccountPurgeUsecase(
+		repository.NewUserRepositoryUCAdapter(repository.NewUserRepository(db.PostgresDB)),
+		repository.NewResultRepositoryUCAdapter(repository.NewResultRepository(db.PostgresDB)),
+		repository.NewChildRepositoryUCAdapter(repository.NewChildRepository(db.PostgresDB)),
+		repository.NewChildGuardianRepositoryUCAdapter(repository.NewChildGuardianRepository(db.PostgresDB)),
+		repository.NewTransactionControllerFactory(db.PostgresDB),
+		repository.NewAuditLogRepositoryUCAdapter(repository.NewAuditLogRepository(db.PostgresDB)),
+	)
+
+	output, err := accountPurgeUsecase.HandlePurgeScheduledAccountDeletions(context.Background())
+	if err != nil {
+		logger.Fatal("failed to purge deleted accounts: ", err)
+	}
+
+	logger.WithField("purged", output.Purged).WithField("failed", output.Failed).Info("deleted accounts purged")
+}
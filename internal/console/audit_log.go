@@ -0,0 +1,39 @@
+package console
+
+import (
+	"context"
+	"time"
+
+	"github.com/luckyAkbar/atec/internal/config"
+	"github.com/luckyAkbar/atec/internal/db"
+	"github.com/luckyAkbar/atec/internal/repository"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var purgeAuditLogsCMD = &cobra.Command{
+	Use: "purge-audit-logs",
+	Long: "permanently delete the audit logs older than the retention period configured as audit_log.retention_days. " +
+		"Meant to be run periodically, e.g. daily using cron",
+	Run: purgeAuditLogsFn,
+}
+
+//nolint:gochecknoinits
+func init() {
+	rootCMD.AddCommand(purgeAuditLogsCMD)
+}
+
+func purgeAuditLogsFn(_ *cobra.Command, _ []string) {
+	db.InitializePostgresConn()
+
+	retentionDays := config.AuditLogRetentionDays()
+	before := time.Now().AddDate(0, 0, -retentionDays)
+	logger := logrus.WithField("retention-days", retentionDays).WithField("before", before)
+
+	deleted, err := repository.NewAuditLogRepository(db.PostgresDB).DeleteCreatedBefore(context.Background(), before)
+	if err != nil {
+		logger.Fatal("failed to purge audit logs: ", err)
+	}
+
+	logger.WithField("deleted", deleted).Info("audit logs purged")
+}
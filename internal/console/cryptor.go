@@ -0,0 +1,62 @@
+package console
+
+import (
+	"fmt"
+
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/config"
+	"github.com/sweet-go/stdlib/encryption"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newSharedCryptor create the shared cryptor using every configured encryption key. Will panic if any key can't be loaded.
+// jwtKeySet can be left nil when the jwt is not going to be created nor validated
+func newSharedCryptor(jwtKeySet *common.JWTKeySet) *common.SharedCryptor {
+	key, err := encryption.ReadKeyFromFile(config.PrivateKeyFilePath())
+	if err != nil {
+		panic(err)
+	}
+
+	rotatedKeysConfig, err := config.RotatedEncryptionKeys()
+	if err != nil {
+		panic(err)
+	}
+
+	rotatedKeys := []common.VersionedEncryptionKey{}
+	versions := map[int]bool{common.LegacyEncryptionKeyVersion: true}
+
+	for _, cfg := range rotatedKeysConfig {
+		if versions[cfg.Version] || cfg.Version < common.LegacyEncryptionKeyVersion {
+			panic(fmt.Sprintf("invalid or duplicated encryption key version: %d", cfg.Version))
+		}
+
+		versions[cfg.Version] = true
+
+		rotatedKey, err := encryption.ReadKeyFromFile(cfg.PrivateKeyPath)
+		if err != nil {
+			panic(err)
+		}
+
+		rotatedKeys = append(rotatedKeys, common.VersionedEncryptionKey{
+			Version: cfg.Version,
+			Key:     rotatedKey.Bytes,
+			IV:      cfg.IVKey,
+		})
+	}
+
+	blindIndexKey, err := encryption.ReadKeyFromFile(config.BlindIndexKeyPath())
+	if err != nil {
+		panic(err)
+	}
+
+	return common.NewSharedCryptor(&common.CreateCryptorOpts{
+		HashCost:              bcrypt.DefaultCost,
+		EncryptionKey:         key.Bytes,
+		IV:                    config.IVKey(),
+		RotatedEncryptionKeys: rotatedKeys,
+		BlindIndexKey:         blindIndexKey.Bytes,
+		BlockSize:             common.DefaultBlockSize,
+		JWTKeySet:             jwtKeySet,
+		AcceptLegacyHS256JWT:  config.JWTAcceptLegacyHS256(),
+	})
+}
@@ -0,0 +1,38 @@
+package console
+
+import (
+	"context"
+	"time"
+
+	"github.com/luckyAkbar/atec/internal/config"
+	"github.com/luckyAkbar/atec/internal/db"
+	"github.com/luckyAkbar/atec/internal/repository"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var purgeDataExportsCMD = &cobra.Command{
+	Use: "purge-data-exports",
+	Long: "permanently delete the data exports whose download link has expired, and the failed ones older than " +
+		"data_export.link_expiry, so their archives don't pile up on the database. Meant to be run periodically, e.g. daily using cron",
+	Run: purgeDataExportsFn,
+}
+
+//nolint:gochecknoinits
+func init() {
+	rootCMD.AddCommand(purgeDataExportsCMD)
+}
+
+func purgeDataExportsFn(_ *cobra.Command, _ []string) {
+	db.InitializePostgresConn()
+
+	failedBefore := time.Now().Add(-config.DataExportLinkExpiry())
+	logger := logrus.WithField("failed-before", failedBefore)
+
+	deleted, err := repository.NewDataExportRepository(db.PostgresDB).DeleteExpired(context.Background(), failedBefore)
+	if err != nil {
+		logger.Fatal("failed to purge data exports: ", err)
+	}
+
+	logger.WithField("deleted", deleted).Info("data exports purged")
+}
@@ -0,0 +1,59 @@
+package console
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var generateJWTKeyCMD = &cobra.Command{
+	Use:  "generate-jwt-key",
+	Long: "generate a new Ed25519 key pair to sign the jwt. Use the private key as jwt.signing_key_path, and when rotating, move the public key of the previous signing key to jwt.verification_key_paths",
+	Run:  generateJWTKeyFn,
+}
+
+//nolint:gochecknoinits
+func init() {
+	generateJWTKeyCMD.Flags().String("private-key-out", "jwt_signing_key.pem", "output path of the PEM encoded private key")
+	generateJWTKeyCMD.Flags().String("public-key-out", "jwt_verification_key.pem", "output path of the PEM encoded public key")
+
+	rootCMD.AddCommand(generateJWTKeyCMD)
+}
+
+func generateJWTKeyFn(cmd *cobra.Command, _ []string) {
+	privateKeyOut := cmd.Flag("private-key-out").Value.String()
+	publicKeyOut := cmd.Flag("public-key-out").Value.String()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		logrus.Fatal("failed to generate key: ", err)
+	}
+
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		logrus.Fatal("failed to marshal private key: ", err)
+	}
+
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		logrus.Fatal("failed to marshal public key: ", err)
+	}
+
+	err = os.WriteFile(privateKeyOut, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyBytes}), 0o600)
+	if err != nil {
+		logrus.Fatal("failed to write private key: ", err)
+	}
+
+	err = os.WriteFile(publicKeyOut, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}), 0o600)
+	if err != nil {
+		logrus.Fatal("failed to write public key: ", err)
+	}
+
+	logrus.WithField("kid", common.JWTKeyID(publicKey)).Info("jwt key generated")
+}
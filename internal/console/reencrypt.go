@@ -0,0 +1,193 @@
+package console
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/db"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/repository"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var reencryptCMD = &cobra.Command{
+	Use: "reencrypt",
+	Long: "re-encrypt the users' email, phone number, address and mfa secret using AES-GCM with the newest encryption key, " +
+		"and create the missing email blind index. Must be run after upgrading from the deterministic AES-CBC encryption. " +
+		"Users already encrypted using the newest key are skipped, so the command can simply be re-run if interrupted. " +
+		"Use --start-after with the last reported user id to skip the users already processed",
+	Run: reencryptFn,
+}
+
+//nolint:gochecknoinits
+func init() {
+	reencryptCMD.Flags().Int("batch-size", 100, "number of users processed per batch")
+	reencryptCMD.Flags().String("start-after", "", "only process users with id greater than this id, used to resume")
+
+	rootCMD.AddCommand(reencryptCMD)
+}
+
+func reencryptFn(cmd *cobra.Command, _ []string) {
+	batchSize, err := cmd.Flags().GetInt("batch-size")
+	if err != nil || batchSize <= 0 {
+		logrus.Fatal("invalid batch size")
+	}
+
+	lastID := uuid.Nil
+
+	if startAfter := cmd.Flag("start-after").Value.String(); startAfter != "" {
+		lastID, err = uuid.Parse(startAfter)
+		if err != nil {
+			logrus.Fatal("invalid start after user id: ", err)
+		}
+	}
+
+	sharedCryptor := newSharedCryptor(nil)
+
+	db.InitializePostgresConn()
+
+	userRepo := repository.NewUserRepository(db.PostgresDB)
+	ctx := context.Background()
+	logger := logrus.WithField("key-version", sharedCryptor.ActiveEncryptionKeyVersion())
+
+	reencrypted, skipped := 0, 0
+
+	for {
+		users, err := userRepo.FindBatchAfterID(ctx, lastID, batchSize)
+		if err != nil {
+			logger.WithField("last-user-id", lastID).Fatal("failed to find users: ", err)
+		}
+
+		if len(users) == 0 {
+			break
+		}
+
+		for i := range users {
+			user := &users[i]
+
+			done, err := reencryptUser(ctx, userRepo, sharedCryptor, user)
+			if err != nil {
+				logger.WithField("last-user-id", lastID).WithField("user-id", user.ID).Fatal("failed to re-encrypt user: ", err)
+			}
+
+			if done {
+				reencrypted++
+			} else {
+				skipped++
+			}
+
+			lastID = user.ID
+		}
+
+		logger.WithField("last-user-id", lastID).Infof("%d users re-encrypted, %d users skipped", reencrypted, skipped)
+	}
+
+	logger.Infof("finished, %d users re-encrypted, %d users skipped", reencrypted, skipped)
+}
+
+// reencryptUser re-encrypt the user's encrypted fields using the active key, and create the email blind index if missing.
+// Return false when nothing is changed, because the fields are already up to date or were changed after the user was read
+func reencryptUser(
+	ctx context.Context, userRepo *repository.UserRepository, sc *common.SharedCryptor, user *model.User,
+) (bool, error) {
+	current := usecase.RepoUserEncryptedFields{
+		Email:           user.Email,
+		EmailBlindIndex: user.EmailBlindIndex,
+		PhoneNumber:     user.PhoneNumber,
+		Address:         user.Address,
+		MFASecret:       user.MFASecret,
+	}
+
+	reencrypted := usecase.RepoUserEncryptedFields{
+		EmailBlindIndex: current.EmailBlindIndex,
+	}
+	changed := false
+
+	if !current.EmailBlindIndex.Valid {
+		email, err := sc.Decrypt(current.Email)
+		if err != nil {
+			return false, err
+		}
+
+		reencrypted.EmailBlindIndex = sql.NullString{String: sc.BlindIndex(email), Valid: true}
+		changed = true
+	}
+
+	fields := []struct {
+		current     string
+		valid       bool
+		reencrypted *string
+	}{
+		{current.Email, true, &reencrypted.Email},
+		{current.PhoneNumber.String, current.PhoneNumber.Valid, &reencrypted.PhoneNumber.String},
+		{current.Address.String, current.Address.Valid, &reencrypted.Address.String},
+		{current.MFASecret.String, current.MFASecret.Valid, &reencrypted.MFASecret.String},
+	}
+
+	for _, field := range fields {
+		if !field.valid {
+			continue
+		}
+
+		value, valueChanged, err := reencryptValue(sc, field.current)
+		if err != nil {
+			return false, err
+		}
+
+		*field.reencrypted = value
+		changed = changed || valueChanged
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	reencrypted.PhoneNumber.Valid = current.PhoneNumber.Valid
+	reencrypted.Address.Valid = current.Address.Valid
+	reencrypted.MFASecret.Valid = current.MFASecret.Valid
+
+	err := userRepo.Reencrypt(ctx, usecase.RepoReencryptUserInput{
+		UserID:      user.ID,
+		Current:     current,
+		Reencrypted: reencrypted,
+	})
+
+	switch {
+	default:
+		return false, err
+	case errors.Is(err, repository.ErrNotFound):
+		// changed after it was read, the new value is already encrypted using the active key
+		return false, nil
+	case err == nil:
+		return true, nil
+	}
+}
+
+// reencryptValue return the cipherText encrypted using the active key, and whether it is different from the given one
+func reencryptValue(sc *common.SharedCryptor, cipherText string) (string, bool, error) {
+	needsReencryption, err := sc.NeedsReencryption(cipherText)
+	if err != nil {
+		return "", false, err
+	}
+
+	if !needsReencryption {
+		return cipherText, false, nil
+	}
+
+	plainText, err := sc.Decrypt(cipherText)
+	if err != nil {
+		return "", false, err
+	}
+
+	reencrypted, err := sc.Encrypt(plainText)
+	if err != nil {
+		return "", false, err
+	}
+
+	return reencrypted, true, nil
+}
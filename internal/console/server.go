@@ -2,6 +2,7 @@ package console
 
 import (
 	"context"
+	"crypto/ed25519"
 	"net/http"
 	"os"
 	"os/signal"
@@ -21,9 +22,7 @@ import (
 	"github.com/luckyAkbar/atec/internal/usecase"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"github.com/sweet-go/stdlib/encryption"
 	"github.com/sweet-go/stdlib/helper"
-	"golang.org/x/crypto/bcrypt"
 )
 
 var serverCMD = &cobra.Command{
@@ -53,27 +52,41 @@ func serverFn(cmd *cobra.Command, _ []string) {
 		logrus.SetLevel(logrus.DebugLevel)
 	}
 
-	key, err := encryption.ReadKeyFromFile(config.PrivateKeyFilePath())
+	fontBytes, err := os.ReadFile("./assets/font.ttf")
 	if err != nil {
 		panic(err)
 	}
 
-	fontBytes, err := os.ReadFile("./assets/font.ttf")
+	font, err := truetype.Parse(fontBytes)
 	if err != nil {
 		panic(err)
 	}
 
-	font, err := truetype.Parse(fontBytes)
+	breachedPasswords, err := common.LoadBreachedPasswordList(config.BreachedPasswordListPath())
 	if err != nil {
 		panic(err)
 	}
 
-	sharedCryptor := common.NewSharedCryptor(&common.CreateCryptorOpts{
-		HashCost:      bcrypt.DefaultCost,
-		EncryptionKey: key.Bytes,
-		IV:            config.IVKey(),
-		BlockSize:     common.DefaultBlockSize,
-	})
+	passwordPolicy := common.NewPasswordPolicy(config.PasswordMinLength(), breachedPasswords)
+
+	jwtSigningKey, err := common.LoadJWTSigningKey(config.JWTSigningKeyPath())
+	if err != nil {
+		panic(err)
+	}
+
+	jwtVerificationKeys := []ed25519.PublicKey{}
+	for _, path := range config.JWTVerificationKeyPaths() {
+		verificationKey, err := common.LoadJWTVerificationKey(path)
+		if err != nil {
+			panic(err)
+		}
+
+		jwtVerificationKeys = append(jwtVerificationKeys, verificationKey)
+	}
+
+	jwtKeySet := common.NewJWTKeySet(jwtSigningKey, jwtVerificationKeys...)
+
+	sharedCryptor := newSharedCryptor(jwtKeySet)
 
 	brevoClient := common.NewBrevoClient(config.SendinblueAPIKey())
 
@@ -107,6 +120,16 @@ func serverFn(cmd *cobra.Command, _ []string) {
 	packageRepo := repository.NewPackageRepo(db.PostgresDB, cacheKeeper)
 	childRepo := repository.NewChildRepository(db.PostgresDB)
 	resultRepo := repository.NewResultRepository(db.PostgresDB)
+	sessionRepo := repository.NewSessionRepository(db.PostgresDB)
+	emailTokenRepo := repository.NewEmailTokenRepository(db.PostgresDB)
+	mfaRecoveryCodeRepo := repository.NewMFARecoveryCodeRepository(db.PostgresDB)
+	passwordlessLoginRepo := repository.NewPasswordlessLoginRepository(cacheKeeper)
+	personalAccessTokenRepo := repository.NewPersonalAccessTokenRepository(db.PostgresDB)
+	auditLogRepo := repository.NewAuditLogRepository(db.PostgresDB)
+	dataExportRepo := repository.NewDataExportRepository(db.PostgresDB)
+	careRelationshipRepo := repository.NewCareRelationshipRepository(db.PostgresDB)
+	organizationRepo := repository.NewOrganizationRepository(db.PostgresDB)
+	childGuardianRepo := repository.NewChildGuardianRepository(db.PostgresDB)
 
 	transactionControllerFactory := repository.NewTransactionControllerFactory(db.PostgresDB)
 
@@ -114,6 +137,16 @@ func serverFn(cmd *cobra.Command, _ []string) {
 	packageRepoUCAdapter := repository.NewPackageRepositoryUCAdapter(packageRepo)
 	childRepoUCAdapter := repository.NewChildRepositoryUCAdapter(childRepo)
 	resultRepoUCAdapter := repository.NewResultRepositoryUCAdapter(resultRepo)
+	sessionRepoUCAdapter := repository.NewSessionRepositoryUCAdapter(sessionRepo)
+	emailTokenRepoUCAdapter := repository.NewEmailTokenRepositoryUCAdapter(emailTokenRepo)
+	mfaRecoveryCodeRepoUCAdapter := repository.NewMFARecoveryCodeRepositoryUCAdapter(mfaRecoveryCodeRepo)
+	passwordlessLoginRepoUCAdapter := repository.NewPasswordlessLoginRepositoryUCAdapter(passwordlessLoginRepo)
+	personalAccessTokenRepoUCAdapter := repository.NewPersonalAccessTokenRepositoryUCAdapter(personalAccessTokenRepo)
+	auditLogRepoUCAdapter := repository.NewAuditLogRepositoryUCAdapter(auditLogRepo)
+	dataExportRepoUCAdapter := repository.NewDataExportRepositoryUCAdapter(dataExportRepo)
+	careRelationshipRepoUCAdapter := repository.NewCareRelationshipRepositoryUCAdapter(careRelationshipRepo)
+	organizationRepoUCAdapter := repository.NewOrganizationRepositoryUCAdapter(organizationRepo)
+	childGuardianRepoUCAdapter := repository.NewChildGuardianRepositoryUCAdapter(childGuardianRepo)
 
 	authUsecase := usecase.NewAuthUsecase(
 		sharedCryptor,
@@ -123,11 +156,37 @@ func serverFn(cmd *cobra.Command, _ []string) {
 		transactionControllerFactory,
 		mailer,
 		rateLimiter,
+		sessionRepoUCAdapter,
+		emailTokenRepoUCAdapter,
+		mfaRecoveryCodeRepoUCAdapter,
+		passwordPolicy,
+		passwordlessLoginRepoUCAdapter,
+		personalAccessTokenRepoUCAdapter,
+		auditLogRepoUCAdapter,
+	)
+	packageUsecase := usecase.NewPackageUsecase(packageRepoUCAdapter, auditLogRepoUCAdapter)
+	childUsecase := usecase.NewChildUsecase(
+		childRepoUCAdapter, resultRepoUCAdapter, userRepoUCAdapter, auditLogRepoUCAdapter, careRelationshipRepoUCAdapter,
+		childGuardianRepoUCAdapter, emailTokenRepoUCAdapter, transactionControllerFactory, sharedCryptor, mailer,
+	)
+	questionnaireUsecase := usecase.NewQuestionnaireUsecase(
+		packageRepoUCAdapter, childRepoUCAdapter, resultRepoUCAdapter, auditLogRepoUCAdapter, careRelationshipRepoUCAdapter,
+		childGuardianRepoUCAdapter, font,
+	)
+	usersUsecase := usecase.NewUsersUsecase(
+		userRepoUCAdapter,
+		sharedCryptor,
+		sessionRepoUCAdapter,
+		auditLogRepoUCAdapter,
+		childRepoUCAdapter,
+		resultRepoUCAdapter,
+		packageRepoUCAdapter,
+		dataExportRepoUCAdapter,
+		careRelationshipRepoUCAdapter,
+		organizationRepoUCAdapter,
+		mailer,
+		font,
 	)
-	packageUsecase := usecase.NewPackageUsecase(packageRepoUCAdapter)
-	childUsecase := usecase.NewChildUsecase(childRepoUCAdapter, resultRepoUCAdapter, userRepoUCAdapter)
-	questionnaireUsecase := usecase.NewQuestionnaireUsecase(packageRepoUCAdapter, childRepoUCAdapter, resultRepoUCAdapter, font)
-	usersUsecase := usecase.NewUsersUsecase(userRepoUCAdapter, sharedCryptor)
 
 	initAdmin, err := cmd.Flags().GetBool("init-admin-account")
 	if err != nil {
@@ -147,7 +206,13 @@ func serverFn(cmd *cobra.Command, _ []string) {
 		initPackage(packageRepo, userRepo)
 	}
 
+	ipExtractor, err := rest.NewIPExtractor(config.ServerTrustedProxies())
+	if err != nil {
+		panic(err)
+	}
+
 	httpServer := echo.New()
+	httpServer.IPExtractor = ipExtractor
 
 	httpServer.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
 		Skipper: func(c echo.Context) bool {
@@ -170,6 +235,10 @@ func serverFn(cmd *cobra.Command, _ []string) {
 			Message: "pong",
 		})
 	})
+	// the public keys allowing other services to verify the jwt issued by this server
+	rootGroup.GET("/.well-known/jwks.json", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, jwtKeySet.JWKS())
+	})
 
 	v1Group := httpServer.Group("v1")
 
@@ -259,11 +328,12 @@ func initAdminAccount(userRepo *repository.UserRepository, sc *common.SharedCryp
 	}
 
 	adminUser, err := userRepo.Create(context.Background(), usecase.RepoCreateUserInput{
-		Email:    emailEncrypted,
-		Password: hashedPassword,
-		Username: adminUsername,
-		IsActive: true,
-		Roles:    model.RolesAdministrator,
+		Email:           emailEncrypted,
+		EmailBlindIndex: sc.BlindIndex(adminEmail),
+		Password:        hashedPassword,
+		Username:        adminUsername,
+		IsActive:        true,
+		Roles:           model.RolesAdministrator,
 	})
 
 	if err != nil {
@@ -0,0 +1,72 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/usecase"
+)
+
+// @Summary		Search audit logs
+// @Description	Allow administrator to search and paginate the audit trail of sensitive actions, newest first
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		AdministratorLevelAuth
+// @Param			Authorization					header		string											true	"JWT Token"
+// @Param			admin_search_audit_logs_input	query		AdminSearchAuditLogsInput						true	"search parameters"
+// @Success		200								{object}	StandardSuccessResponse{data=[]AuditLogOutput}	"Successful response"
+// @Failure		400								{object}	StandardErrorResponse							"Bad Request"
+// @Failure		401								{object}	StandardErrorResponse							"Unauthorized"
+// @Failure		403								{object}	StandardErrorResponse							"Forbidden"
+// @Failure		404								{object}	StandardErrorResponse							"Not Found"
+// @Failure		500								{object}	StandardErrorResponse							"Internal Error"
+// @Router			/v1/admin/audit-logs [get]
+func (s *Service) HandleAdminSearchAuditLogs() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &AdminSearchAuditLogsInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		auditLogs, err := s.usersUsecase.AdminSearchAuditLogs(c.Request().Context(), usecase.AdminSearchAuditLogsInput{
+			ActorID:       input.ActorID,
+			Action:        input.Action,
+			TargetType:    input.TargetType,
+			TargetID:      input.TargetID,
+			CreatedAfter:  input.CreatedAfter,
+			CreatedBefore: input.CreatedBefore,
+			Limit:         input.Limit,
+			Offset:        input.Offset,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		resp := make([]AuditLogOutput, 0, len(auditLogs))
+		for _, auditLog := range auditLogs {
+			resp = append(resp, AuditLogOutput{
+				ID:         auditLog.ID,
+				ActorID:    auditLog.ActorID,
+				ActorRole:  auditLog.ActorRole,
+				Action:     auditLog.Action,
+				TargetType: auditLog.TargetType,
+				TargetID:   auditLog.TargetID,
+				Metadata:   auditLog.Metadata,
+				IPAddress:  auditLog.IPAddress,
+				UserAgent:  auditLog.UserAgent,
+				CreatedAt:  auditLog.CreatedAt,
+			})
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data:       resp,
+		})
+	}
+}
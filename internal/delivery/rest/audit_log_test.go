@@ -0,0 +1,100 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/delivery/rest"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	usecase_mock "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersService_HandleAdminSearchAuditLogs(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockUsersUsecase := usecase_mock.NewUsersUsecaseIface(t)
+	service := rest.NewService(group, nil, nil, nil, nil, mockUsersUsecase)
+
+	actorID := uuid.New()
+	auditLogID := uuid.New()
+	createdAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name   string
+		query  string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name:  "invalid created after",
+			query: "?limit=10&created_after=yesterday",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(_ echo.Context) {},
+		},
+		{
+			name:  "forbidden",
+			query: "?limit=10",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusForbidden, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockUsersUsecase.EXPECT().AdminSearchAuditLogs(ectx.Request().Context(), usecase.AdminSearchAuditLogsInput{
+					Limit: 10,
+				}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrForbidden}).Once()
+			},
+		},
+		{
+			name:  "success",
+			query: "?actor_id=" + actorID.String() + "&action=result.download&target_type=result&created_after=2025-01-01T00:00:00Z&limit=10&offset=5",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), auditLogID.String())
+				assert.Contains(t, rec.Body.String(), `"action":"result.download"`)
+				assert.Contains(t, rec.Body.String(), `"metadata":{"child_id":"abc"}`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockUsersUsecase.EXPECT().AdminSearchAuditLogs(ectx.Request().Context(), usecase.AdminSearchAuditLogsInput{
+					ActorID:      actorID,
+					Action:       model.AuditActionDownloadResult,
+					TargetType:   model.AuditTargetResult,
+					CreatedAfter: createdAfter,
+					Limit:        10,
+					Offset:       5,
+				}).Return([]usecase.AuditLogOutput{{
+					ID:         auditLogID,
+					ActorID:    actorID,
+					ActorRole:  model.RolesTherapist,
+					Action:     model.AuditActionDownloadResult,
+					TargetType: model.AuditTargetResult,
+					TargetID:   uuid.NewString(),
+					Metadata:   model.AuditMetadata{"child_id": "abc"},
+					CreatedAt:  time.Now(),
+				}}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/admin/audit-logs"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleAdminSearchAuditLogs()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
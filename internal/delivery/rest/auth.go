@@ -12,6 +12,8 @@ import (
 
 // @Summary		Create a new account to access user only resources within this system
 // @Description	Use this API endpoint to create a new account
+// @Description	The response is the same whether or not the email is already registered,
+// @Description	the email owner will be notified instead if the email is already registered
 // @Tags			Authentication
 // @Accept			json
 // @Param			signup_input	body	SignupInput	true	"Login Credentials"
@@ -55,6 +57,7 @@ func (s *Service) HandleSignUp() echo.HandlerFunc {
 // @Summary		Resend email for account verification
 // @Description	If something happen during account verification that cause the email not received
 // @Description	or the email is lost, use this API to resend the verification email
+// @Description	The response is the same for unregistered and already activated email, only the email owner will be notified
 // @Tags			Authentication
 // @Accept			json
 // @Param			resend_signup_input	body	ResendVerificationInput	true	"resend signup input"
@@ -130,6 +133,12 @@ func (s *Service) HandleVerifyAccount() echo.HandlerFunc {
 
 // @Summary		Gain access to the system by authenticating using a registered account
 // @Description	Use this endpoint to login with your username and password
+// @Description	For account with two-factor authentication enabled, mfa_required will be true and
+// @Description	the mfa_token must be exchanged with the login token via /v1/auth/login/mfa.
+// @Description	If mfa_enrollment_required is true, the account must enroll first via /v1/auth/mfa/enroll
+// @Description	Repeated failed login will be delayed progressively, and the account will be temporarily locked
+// @Description	once the failure threshold is reached. An email containing the unlock link will be sent to the account owner
+// @Description	Unknown email, wrong password and locked account all result in the same 401 response
 // @Tags			Authentication
 // @Accept			json
 // @Produce		json
@@ -137,6 +146,7 @@ func (s *Service) HandleVerifyAccount() echo.HandlerFunc {
 // @Success		200			{object}	StandardSuccessResponse{data=LoginOutput}	"Successful response"
 // @Failure		400			{object}	StandardErrorResponse						"Bad request"
 // @Failure		401			{object}	StandardErrorResponse						"Authentication Failed"
+// @Failure		429			{object}	StandardErrorResponse						"Too many login attempts"
 // @Failure		500			{object}	StandardErrorResponse						"Internal Error"
 // @Router			/v1/auth/login [post]
 func (s *Service) HandleLogin() echo.HandlerFunc {
@@ -151,8 +161,9 @@ func (s *Service) HandleLogin() echo.HandlerFunc {
 		}
 
 		output, err := s.authUsecase.HandleLogin(c.Request().Context(), usecase.LoginInput{
-			Email:    input.Email,
-			Password: input.Password,
+			Email:     input.Email,
+			Password:  input.Password,
+			IPAddress: c.RealIP(),
 		})
 
 		if err != nil {
@@ -163,7 +174,91 @@ func (s *Service) HandleLogin() echo.HandlerFunc {
 			StatusCode: http.StatusOK,
 			Message:    http.StatusText(http.StatusOK),
 			Data: LoginOutput{
-				Token: output.Token,
+				Token:                 output.Token,
+				RefreshToken:          output.RefreshToken,
+				MFARequired:           output.MFARequired,
+				MFAEnrollmentRequired: output.MFAEnrollmentRequired,
+				MFAToken:              output.MFAToken,
+			},
+		})
+	}
+}
+
+// @Summary		Unlock account locked due to too many failed login attempts
+// @Description	Use the unlock token sent to the account email when the account was locked
+// @Tags			Authentication
+// @Accept			json
+// @Produce		json
+// @Param			unlock_account_input	body		UnlockAccountInput									true	"unlock token from the email"
+// @Success		200						{object}	StandardSuccessResponse{data=UnlockAccountOutput}	"Successful response"
+// @Failure		400						{object}	StandardErrorResponse								"Bad request"
+// @Failure		401						{object}	StandardErrorResponse								"Invalid or used token"
+// @Failure		404						{object}	StandardErrorResponse								"Account not found"
+// @Failure		500						{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/auth/unlock [post]
+func (s *Service) HandleUnlockAccount() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &UnlockAccountInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleUnlockAccount(c.Request().Context(), usecase.UnlockAccountInput{
+			UnlockToken: input.UnlockToken,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: UnlockAccountOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
+
+// @Summary		Confirm the email change
+// @Description	Use the change email token sent to the new email address to replace the account email
+// @Tags			Authentication
+// @Accept			json
+// @Produce		json
+// @Param			confirm_change_email_input	body		ConfirmChangeEmailInput									true	"change email token from the email"
+// @Success		200							{object}	StandardSuccessResponse{data=ConfirmChangeEmailOutput}	"Successful response"
+// @Failure		400							{object}	StandardErrorResponse									"Bad request or the email has been used by another account"
+// @Failure		401							{object}	StandardErrorResponse									"Invalid, expired or used token"
+// @Failure		404							{object}	StandardErrorResponse									"Account not found"
+// @Failure		500							{object}	StandardErrorResponse									"Internal Error"
+// @Router			/v1/auth/email/confirm [post]
+func (s *Service) HandleConfirmChangeEmail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &ConfirmChangeEmailInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleConfirmChangeEmail(c.Request().Context(), usecase.ConfirmChangeEmailInput{
+			ChangeEmailToken: input.ChangeEmailToken,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: ConfirmChangeEmailOutput{
+				Message: output.Message,
 			},
 		})
 	}
@@ -172,13 +267,15 @@ func (s *Service) HandleLogin() echo.HandlerFunc {
 // @Summary		Initiate change password process for an active account
 // @Description	If the user wants to change their password, use this API.
 // @Description	when the request succeed, an email containing confirmation link will
-// @Description	be sent to the account email.
+// @Description	be sent to the account email. The response is the same whether or not an active account
+// @Description	is registered using the email, only the email owner will be notified about it
 // @Tags			Authentication
 // @Accept			json
 // @Produce		json
 // @Param			init_change_password_input	body		InitResetPasswordInput									true	"the email of the account which password will be reset"
 // @Success		200							{object}	StandardSuccessResponse{data=InitResetPasswordOutput}	"Successful response"
 // @Failure		400							{object}	StandardErrorResponse									"Bad request"	"validation error"
+// @Failure		429							{object}	StandardErrorResponse									"Too many requests"
 // @Failure		500							{object}	StandardErrorResponse									"Internal Error"
 // @Router			/v1/auth/password [patch]
 func (s *Service) HandleInitResetPassword() echo.HandlerFunc {
@@ -277,7 +374,8 @@ func (s *Service) HandleRenderChangePasswordPage() echo.HandlerFunc {
 }
 
 // @Summary		Delete user's account
-// @Description	Delete all user's related data from the system
+// @Description	Schedule the deletion of the account and all user's related data. The account is deactivated immediately
+// @Description	and permanently deleted after the grace period, unless restored using the link sent to the account email
 // @Tags			Authentication
 // @Accept			application/json
 // @Security		ParentLevelAuth
@@ -313,6 +411,130 @@ func (s *Service) HandleDeleteAccount() echo.HandlerFunc {
 	}
 }
 
+// @Summary		Restore account scheduled for deletion
+// @Description	Use the restore token sent to the account email to cancel the deletion, restoring the account along with its children and results
+// @Tags			Authentication
+// @Accept			json
+// @Produce		json
+// @Param			restore_account_input	body		RestoreAccountInput									true	"restore token from the email"
+// @Success		200						{object}	StandardSuccessResponse{data=RestoreAccountOutput}	"Successful response"
+// @Failure		400						{object}	StandardErrorResponse								"Bad request or the account is not scheduled for deletion"
+// @Failure		401						{object}	StandardErrorResponse								"Invalid, expired or used token"
+// @Failure		404						{object}	StandardErrorResponse								"Account not found"
+// @Failure		500						{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/auth/accounts/restore [post]
+func (s *Service) HandleRestoreAccount() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &RestoreAccountInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleRestoreAccount(c.Request().Context(), usecase.RestoreAccountInput{
+			RestoreToken: input.RestoreToken,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: RestoreAccountOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
+
+// @Summary		Exchange refresh token for a new access token
+// @Description	Use this endpoint to get a new access token when the previous one has expired.
+// @Description	The refresh token is rotated on every use, so always store the newly returned refresh token.
+// @Description	Reusing an already used refresh token will revoke the whole session.
+// @Tags			Authentication
+// @Accept			json
+// @Produce		json
+// @Param			refresh_token_input	body		RefreshTokenInput									true	"refresh token obtained from login or previous refresh"
+// @Success		200					{object}	StandardSuccessResponse{data=RefreshTokenOutput}	"Successful response"
+// @Failure		400					{object}	StandardErrorResponse								"Bad request"
+// @Failure		401					{object}	StandardErrorResponse								"Authentication Failed"
+// @Failure		500					{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/auth/refresh [post]
+func (s *Service) HandleRefreshToken() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &RefreshTokenInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleRefreshToken(c.Request().Context(), usecase.RefreshTokenInput{
+			RefreshToken: input.RefreshToken,
+		})
+
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: RefreshTokenOutput{
+				Token:        output.Token,
+				RefreshToken: output.RefreshToken,
+			},
+		})
+	}
+}
+
+// @Summary		Logout from the current session
+// @Description	Revoke the session tied to the supplied access token. The access token and its refresh token
+// @Description	will no longer be accepted.
+// @Tags			Authentication
+// @Security		ParentLevelAuth
+// @Param			Authorization	header	string	true	"JWT Token"
+// @Produce		json
+// @Success		204	{object}	StandardSuccessResponse	"Successful response"
+// @Failure		401	{object}	StandardErrorResponse	"Authentication Failed"
+// @Failure		500	{object}	StandardErrorResponse	"Internal Error"
+// @Router			/v1/auth/logout [post]
+func (s *Service) HandleLogout() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := s.authUsecase.HandleLogout(c.Request().Context()); err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// @Summary		Logout from all sessions
+// @Description	Revoke every active session owned by the requester, logging them out from all devices.
+// @Tags			Authentication
+// @Security		ParentLevelAuth
+// @Param			Authorization	header	string	true	"JWT Token"
+// @Produce		json
+// @Success		204	{object}	StandardSuccessResponse	"Successful response"
+// @Failure		401	{object}	StandardErrorResponse	"Authentication Failed"
+// @Failure		500	{object}	StandardErrorResponse	"Internal Error"
+// @Router			/v1/auth/logout/all [post]
+func (s *Service) HandleLogoutAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := s.authUsecase.HandleLogoutAll(c.Request().Context()); err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
 //nolint:funlen
 func changePasswordWebpage(token string) string {
 	return fmt.Sprintf(`
@@ -676,3 +898,90 @@ func accountVerifiedWebpage() string {
 			</body>
 			</html>`
 }
+
+// @Summary		Invite a therapist by email
+// @Description	Send a single use invitation to the given email. The invitation can be redeemed to create a therapist account
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		AdministratorLevelAuth
+// @Param			Authorization			header		string												true	"JWT Token"
+// @Param			invite_therapist_input	body		InviteTherapistInput								true	"therapist email"
+// @Success		200						{object}	StandardSuccessResponse{data=InviteTherapistOutput}	"Successful response"
+// @Failure		400						{object}	StandardErrorResponse								"Bad request / validation error"
+// @Failure		401						{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		403						{object}	StandardErrorResponse								"Forbidden"
+// @Failure		500						{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/admin/therapists/invitations [post]
+func (s *Service) HandleInviteTherapist() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &InviteTherapistInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleInviteTherapist(c.Request().Context(), usecase.InviteTherapistInput{
+			Email: input.Email,
+		})
+
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: InviteTherapistOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
+
+// @Summary		Create a therapist account from an invitation
+// @Description	Redeem the invitation token sent to the therapist email and create the therapist account with the chosen password
+// @Tags			Authentication
+// @Accept			json
+// @Param			redeem_therapist_invitation_input	body	RedeemTherapistInvitationInput	true	"invitation token and account data"
+// @Produce		json
+// @Success		200	{object}	StandardSuccessResponse{data=RedeemTherapistInvitationOutput}	"Successful response"
+// @Failure		400	{object}	StandardErrorResponse											"Bad request / validation error"
+// @Failure		401	{object}	StandardErrorResponse											"Invalid or already used invitation"
+// @Failure		500	{object}	StandardErrorResponse											"Internal Error"
+// @Router			/v1/auth/signup/therapist [post]
+func (s *Service) HandleRedeemTherapistInvitation() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &RedeemTherapistInvitationInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleRedeemTherapistInvitation(c.Request().Context(), usecase.RedeemTherapistInvitationInput{
+			InvitationToken: input.InvitationToken,
+			Password:        input.Password,
+			Username:        input.Username,
+			PhoneNumber:     input.PhoneNumber,
+			Address:         input.Address,
+		})
+
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: RedeemTherapistInvitationOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
@@ -345,8 +345,9 @@ func TestAuthService_HandleLogin(t *testing.T) {
 			},
 			mockFn: func(ectx echo.Context) {
 				mockAuthUsecase.EXPECT().HandleLogin(ectx.Request().Context(), usecase.LoginInput{
-					Email:    "invalid-email",
-					Password: "password12345",
+					Email:     "invalid-email",
+					Password:  "password12345",
+					IPAddress: "192.0.2.1",
 				}).Return(nil, usecase.UsecaseError{
 					ErrType: usecase.ErrBadRequest,
 				}).Once()
@@ -373,8 +374,9 @@ func TestAuthService_HandleLogin(t *testing.T) {
 			},
 			mockFn: func(ectx echo.Context) {
 				mockAuthUsecase.EXPECT().HandleLogin(ectx.Request().Context(), usecase.LoginInput{
-					Email:    "valid@email.test",
-					Password: "password12345",
+					Email:     "valid@email.test",
+					Password:  "password12345",
+					IPAddress: "192.0.2.1",
 				}).Return(&usecase.LoginOutput{}, nil).Once()
 			},
 		},
@@ -758,3 +760,532 @@ func TestAuthService_HandleDeleteAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthService_HandleRefreshToken(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	testCases := []struct {
+		name   string
+		reqCtx func() (*httptest.ResponseRecorder, echo.Context)
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "invalid input body",
+			reqCtx: func() (*httptest.ResponseRecorder, echo.Context) {
+				req := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(`{,}`))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				return rec, ectx
+			},
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+				assert.Equal(t, "application/json", rec.Header().Get(echo.HeaderContentType))
+			},
+		},
+		{
+			name: "usecase returning error",
+			reqCtx: func() (*httptest.ResponseRecorder, echo.Context) {
+				req := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(`{
+					"refresh_token": "invalid"
+				}`))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				return rec, ectx
+			},
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusUnauthorized, rec.Code)
+				assert.Equal(t, "application/json", rec.Header().Get(echo.HeaderContentType))
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleRefreshToken(ectx.Request().Context(), usecase.RefreshTokenInput{
+					RefreshToken: "invalid",
+				}).Return(nil, usecase.UsecaseError{
+					ErrType: usecase.ErrUnauthorized,
+				}).Once()
+			},
+		},
+		{
+			name: "ok",
+			reqCtx: func() (*httptest.ResponseRecorder, echo.Context) {
+				req := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(`{
+					"refresh_token": "valid"
+				}`))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				return rec, ectx
+			},
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, "application/json", rec.Header().Get(echo.HeaderContentType))
+				assert.Contains(t, rec.Body.String(), `"refresh_token":"newRefreshToken"`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleRefreshToken(ectx.Request().Context(), usecase.RefreshTokenInput{
+					RefreshToken: "valid",
+				}).Return(&usecase.RefreshTokenOutput{
+					Token:        "newToken",
+					RefreshToken: "newRefreshToken",
+				}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec, ectx := tc.reqCtx()
+
+			if tc.mockFn != nil {
+				tc.mockFn(ectx)
+			}
+
+			err := service.HandleRefreshToken()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestAuthService_HandleLogout(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	testCases := []struct {
+		name   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "usecase returning error",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+				assert.Equal(t, "application/json", rec.Header().Get(echo.HeaderContentType))
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleLogout(ectx.Request().Context()).Return(usecase.UsecaseError{
+					ErrType: usecase.ErrInternal,
+				}).Once()
+			},
+		},
+		{
+			name: "success",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNoContent, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleLogout(ectx.Request().Context()).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleLogout()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestAuthService_HandleLogoutAll(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	testCases := []struct {
+		name   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "usecase returning error",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+				assert.Equal(t, "application/json", rec.Header().Get(echo.HeaderContentType))
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleLogoutAll(ectx.Request().Context()).Return(usecase.UsecaseError{
+					ErrType: usecase.ErrInternal,
+				}).Once()
+			},
+		},
+		{
+			name: "success",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNoContent, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleLogoutAll(ectx.Request().Context()).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/auth/logout/all", nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleLogoutAll()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestAuthService_HandleInviteTherapist(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	testCases := []struct {
+		name   string
+		body   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "invalid input",
+			body: `{,}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(_ echo.Context) {},
+		},
+		{
+			name: "usecase returning forbidden",
+			body: `{"email":"therapist@example.com"}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusForbidden, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleInviteTherapist(ectx.Request().Context(), usecase.InviteTherapistInput{
+					Email: "therapist@example.com",
+				}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrForbidden}).Once()
+			},
+		},
+		{
+			name: "success",
+			body: `{"email":"therapist@example.com"}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), "invitation sent")
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleInviteTherapist(ectx.Request().Context(), usecase.InviteTherapistInput{
+					Email: "therapist@example.com",
+				}).Return(&usecase.InviteTherapistOutput{Message: "invitation sent"}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/therapists/invitations", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleInviteTherapist()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestAuthService_HandleRedeemTherapistInvitation(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	validBody := `{"invitation_token":"token","password":"password123","username":"therapist"}`
+	expectedInput := usecase.RedeemTherapistInvitationInput{
+		InvitationToken: "token",
+		Password:        "password123",
+		Username:        "therapist",
+	}
+
+	testCases := []struct {
+		name   string
+		body   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "invalid input",
+			body: `{,}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(_ echo.Context) {},
+		},
+		{
+			name: "invitation already used",
+			body: validBody,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleRedeemTherapistInvitation(ectx.Request().Context(), expectedInput).
+					Return(nil, usecase.UsecaseError{ErrType: usecase.ErrUnauthorized}).Once()
+			},
+		},
+		{
+			name: "success",
+			body: validBody,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleRedeemTherapistInvitation(ectx.Request().Context(), expectedInput).
+					Return(&usecase.RedeemTherapistInvitationOutput{Message: "ok"}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/auth/signup/therapist", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleRedeemTherapistInvitation()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestAuthService_HandleUnlockAccount(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	validBody := `{"unlock_token":"token"}`
+	expectedInput := usecase.UnlockAccountInput{
+		UnlockToken: "token",
+	}
+
+	testCases := []struct {
+		name   string
+		body   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "invalid input",
+			body: `{,}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(_ echo.Context) {},
+		},
+		{
+			name: "unlock token already used",
+			body: validBody,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleUnlockAccount(ectx.Request().Context(), expectedInput).
+					Return(nil, usecase.UsecaseError{ErrType: usecase.ErrUnauthorized}).Once()
+			},
+		},
+		{
+			name: "success",
+			body: validBody,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleUnlockAccount(ectx.Request().Context(), expectedInput).
+					Return(&usecase.UnlockAccountOutput{Message: "ok"}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/auth/unlock", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleUnlockAccount()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestAuthService_HandleRestoreAccount(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	validBody := `{"restore_token":"token"}`
+	expectedInput := usecase.RestoreAccountInput{
+		RestoreToken: "token",
+	}
+
+	testCases := []struct {
+		name   string
+		body   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "invalid input",
+			body: `{,}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(_ echo.Context) {},
+		},
+		{
+			name: "account is not scheduled for deletion",
+			body: validBody,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleRestoreAccount(ectx.Request().Context(), expectedInput).
+					Return(nil, usecase.UsecaseError{ErrType: usecase.ErrBadRequest}).Once()
+			},
+		},
+		{
+			name: "success",
+			body: validBody,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), "your account has been restored")
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleRestoreAccount(ectx.Request().Context(), expectedInput).
+					Return(&usecase.RestoreAccountOutput{Message: "your account has been restored"}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/auth/accounts/restore", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleRestoreAccount()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestAuthService_HandleConfirmChangeEmail(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	validBody := `{"change_email_token":"token"}`
+	expectedInput := usecase.ConfirmChangeEmailInput{
+		ChangeEmailToken: "token",
+	}
+
+	testCases := []struct {
+		name   string
+		body   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "invalid input",
+			body: `{,}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(_ echo.Context) {},
+		},
+		{
+			name: "email has been used by another account",
+			body: validBody,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleConfirmChangeEmail(ectx.Request().Context(), expectedInput).
+					Return(nil, usecase.UsecaseError{ErrType: usecase.ErrBadRequest}).Once()
+			},
+		},
+		{
+			name: "success",
+			body: validBody,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleConfirmChangeEmail(ectx.Request().Context(), expectedInput).
+					Return(&usecase.ConfirmChangeEmailOutput{Message: "ok"}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/auth/email/confirm", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleConfirmChangeEmail()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/usecase"
+)
+
+// @Summary		Grant a therapist access to my child
+// @Description	Assign the therapist to the child. The read scope allows the therapist to read the child data, statistic and results,
+// @Description	while the submit scope also allows submitting questionnaire for the child. Granting again will replace the scope
+// @Tags			Users
+// @Accept			json
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization					header		string												true	"JWT Token"
+// @Param			therapist_id					path		string												true	"therapist ID (UUID v4)"
+// @Param			grant_therapist_access_input	body		GrantTherapistAccessInput							true	"child and scope"
+// @Success		200								{object}	StandardSuccessResponse{data=TherapistAccessOutput}	"Successful response"
+// @Failure		400								{object}	StandardErrorResponse								"Bad Request"
+// @Failure		401								{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		403								{object}	StandardErrorResponse								"Forbidden"
+// @Failure		404								{object}	StandardErrorResponse								"Not Found"
+// @Failure		500								{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/users/therapists/{therapist_id}/access [put]
+func (s *Service) HandleGrantTherapistAccess() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &GrantTherapistAccessInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.usersUsecase.HandleGrantTherapistAccess(c.Request().Context(), usecase.GrantTherapistAccessInput{
+			TherapistID: input.TherapistID,
+			ChildID:     input.ChildID,
+			Scope:       input.Scope,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data:       newTherapistAccessOutput(*output),
+		})
+	}
+}
+
+// @Summary		Revoke a therapist access to my child
+// @Description	Remove the therapist from the child. The therapist will no longer be able to access the child data
+// @Tags			Users
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization	header		string														true	"JWT Token"
+// @Param			therapist_id	path		string														true	"therapist ID (UUID v4)"
+// @Param			child_id		path		string														true	"child ID (UUID v4)"
+// @Success		200				{object}	StandardSuccessResponse{data=RevokeTherapistAccessOutput}	"Successful response"
+// @Failure		400				{object}	StandardErrorResponse										"Bad Request"
+// @Failure		401				{object}	StandardErrorResponse										"Unauthorized"
+// @Failure		403				{object}	StandardErrorResponse										"Forbidden"
+// @Failure		404				{object}	StandardErrorResponse										"Not Found"
+// @Failure		500				{object}	StandardErrorResponse										"Internal Error"
+// @Router			/v1/users/therapists/{therapist_id}/access/{child_id} [delete]
+func (s *Service) HandleRevokeTherapistAccess() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &RevokeTherapistAccessInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		err := s.usersUsecase.HandleRevokeTherapistAccess(c.Request().Context(), usecase.RevokeTherapistAccessInput{
+			TherapistID: input.TherapistID,
+			ChildID:     input.ChildID,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: RevokeTherapistAccessOutput{
+				Message: "therapist access revoked",
+			},
+		})
+	}
+}
+
+// @Summary		List therapist access granted to my children
+// @Description	List every therapist assigned to the children registered under this account along with the granted scope
+// @Tags			Users
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization	header		string													true	"JWT Token"
+// @Success		200				{object}	StandardSuccessResponse{data=[]TherapistAccessOutput}	"Successful response"
+// @Failure		401				{object}	StandardErrorResponse									"Unauthorized"
+// @Failure		500				{object}	StandardErrorResponse									"Internal Error"
+// @Router			/v1/users/therapists/access [get]
+func (s *Service) HandleListGrantedTherapistAccess() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		output, err := s.usersUsecase.HandleListGrantedTherapistAccess(c.Request().Context())
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		resp := make([]TherapistAccessOutput, 0, len(output))
+		for _, access := range output {
+			resp = append(resp, newTherapistAccessOutput(access))
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data:       resp,
+		})
+	}
+}
+
+func newTherapistAccessOutput(access usecase.TherapistAccessOutput) TherapistAccessOutput {
+	return TherapistAccessOutput{
+		ID:          access.ID,
+		ChildID:     access.ChildID,
+		TherapistID: access.TherapistID,
+		Scope:       access.Scope,
+		CreatedAt:   access.CreatedAt,
+		UpdatedAt:   access.UpdatedAt,
+	}
+}
@@ -0,0 +1,189 @@
+package rest_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/delivery/rest"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	usecase_mock "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersService_HandleGrantTherapistAccess(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockUsersUC := usecase_mock.NewUsersUsecaseIface(t)
+
+	svc := rest.NewService(group, nil, nil, nil, nil, mockUsersUC)
+
+	therapistID := uuid.New()
+	childID := uuid.New()
+	path := "/v1/users/therapists/" + therapistID.String() + "/access"
+
+	t.Run("invalid body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(`{,}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("therapist_id")
+		ctx.SetParamValues(therapistID.String())
+
+		err := svc.HandleGrantTherapistAccess()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("forbidden mapped from usecase", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(fmt.Sprintf(`{"child_id":"%s","scope":"read"}`, childID)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("therapist_id")
+		ctx.SetParamValues(therapistID.String())
+
+		mockUsersUC.EXPECT().HandleGrantTherapistAccess(ctx.Request().Context(), usecase.GrantTherapistAccessInput{
+			TherapistID: therapistID,
+			ChildID:     childID,
+			Scope:       model.CareScopeRead,
+		}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrForbidden}).Once()
+
+		err := svc.HandleGrantTherapistAccess()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(fmt.Sprintf(`{"child_id":"%s","scope":"submit"}`, childID)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("therapist_id")
+		ctx.SetParamValues(therapistID.String())
+
+		mockUsersUC.EXPECT().HandleGrantTherapistAccess(ctx.Request().Context(), usecase.GrantTherapistAccessInput{
+			TherapistID: therapistID,
+			ChildID:     childID,
+			Scope:       model.CareScopeSubmit,
+		}).Return(&usecase.TherapistAccessOutput{
+			ID:          uuid.New(),
+			ChildID:     childID,
+			TherapistID: therapistID,
+			Scope:       model.CareScopeSubmit,
+		}, nil).Once()
+
+		err := svc.HandleGrantTherapistAccess()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"scope":"submit"`)
+	})
+}
+
+func TestUsersService_HandleRevokeTherapistAccess(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockUsersUC := usecase_mock.NewUsersUsecaseIface(t)
+
+	svc := rest.NewService(group, nil, nil, nil, nil, mockUsersUC)
+
+	therapistID := uuid.New()
+	childID := uuid.New()
+
+	testCases := []struct {
+		name   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "the therapist has no access to the child",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockUsersUC.EXPECT().HandleRevokeTherapistAccess(ectx.Request().Context(), usecase.RevokeTherapistAccessInput{
+					TherapistID: therapistID,
+					ChildID:     childID,
+				}).Return(usecase.UsecaseError{ErrType: usecase.ErrNotFound}).Once()
+			},
+		},
+		{
+			name: "success",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), "therapist access revoked")
+			},
+			mockFn: func(ectx echo.Context) {
+				mockUsersUC.EXPECT().HandleRevokeTherapistAccess(ectx.Request().Context(), usecase.RevokeTherapistAccessInput{
+					TherapistID: therapistID,
+					ChildID:     childID,
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/v1/users/therapists/"+therapistID.String()+"/access/"+childID.String(), nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+			ectx.SetPath("/v1/users/therapists/:therapist_id/access/:child_id")
+			ectx.SetParamNames("therapist_id", "child_id")
+			ectx.SetParamValues(therapistID.String(), childID.String())
+
+			tc.mockFn(ectx)
+
+			err := svc.HandleRevokeTherapistAccess()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestUsersService_HandleListGrantedTherapistAccess(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockUsersUC := usecase_mock.NewUsersUsecaseIface(t)
+
+	svc := rest.NewService(group, nil, nil, nil, nil, mockUsersUC)
+
+	therapistID := uuid.New()
+
+	t.Run("usecase error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/v1/users/therapists/access", nil), rec)
+
+		mockUsersUC.EXPECT().HandleListGrantedTherapistAccess(ctx.Request().Context()).
+			Return(nil, usecase.UsecaseError{ErrType: usecase.ErrInternal}).Once()
+
+		err := svc.HandleListGrantedTherapistAccess()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/v1/users/therapists/access", nil), rec)
+
+		mockUsersUC.EXPECT().HandleListGrantedTherapistAccess(ctx.Request().Context()).Return([]usecase.TherapistAccessOutput{{
+			ID:          uuid.New(),
+			ChildID:     uuid.New(),
+			TherapistID: therapistID,
+			Scope:       model.CareScopeRead,
+		}}, nil).Once()
+
+		err := svc.HandleListGrantedTherapistAccess()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), therapistID.String())
+	})
+}
@@ -121,12 +121,13 @@ func (s *Service) HandleUpdateChildern() echo.HandlerFunc {
 }
 
 // @Summary		Get all childern registered under this account
-// @Description	Get all childern registered under this account
+// @Description	Get all childern registered under this account, including the childern shared with this account as a guardian
 // @Tags			Childern
 // @Accept			json
 // @Produce		json
 // @Security		ParentLevelAuth
 // @Param			Authorization	header		string												true	"JWT Token"
+// @Param			archived		query		bool												false	"list the archived childern instead of the active ones"
 // @Param			limit			query		int													true	"limit searching param"
 // @Param			offset			query		int													true	"offset searching param"
 // @Success		200				{object}	StandardSuccessResponse{data=[]GetMyChildernOutput}	"Successful response"
@@ -145,8 +146,9 @@ func (s *Service) HandleGetMyChildern() echo.HandlerFunc {
 		}
 
 		children, err := s.childUsecase.GetRegisteredChildren(c.Request().Context(), usecase.GetRegisteredChildrenInput{
-			Limit:  input.Limit,
-			Offset: input.Offset,
+			Archived: input.Archived,
+			Limit:    input.Limit,
+			Offset:   input.Offset,
 		})
 
 		if err != nil {
@@ -160,10 +162,12 @@ func (s *Service) HandleGetMyChildern() echo.HandlerFunc {
 				ID:             child.ID,
 				ParentUserID:   child.ParentUserID,
 				ParentUserName: child.ParentUsername,
+				GuardianRole:   child.GuardianRole,
 				DateOfBirth:    child.DateOfBirth,
 				Gender:         child.Gender,
 				Name:           child.Name,
 				GuardianName:   null.NewString(child.GuardianName.String, child.GuardianName.Valid),
+				ArchivedAt:     null.NewTime(child.ArchivedAt.Time, child.ArchivedAt.Valid),
 				CreatedAt:      child.CreatedAt,
 				UpdatedAt:      child.UpdatedAt,
 			})
@@ -178,7 +182,8 @@ func (s *Service) HandleGetMyChildern() echo.HandlerFunc {
 }
 
 // @Summary		Search childern data
-// @Description	Search childern data
+// @Description	Search childern data. Therapist only finds the children assigned to them, while the organization admin finds
+// @Description	the children of the organization. Only the administrator can search across the organizations
 // @Tags			Childern
 // @Accept			json
 // @Produce		json
@@ -201,11 +206,12 @@ func (s *Service) HandleSearchChildern() echo.HandlerFunc {
 		}
 
 		children, err := s.childUsecase.Search(c.Request().Context(), usecase.SearchChildInput{
-			ParentUserID: input.ParentUserID,
-			Name:         input.Name,
-			Gender:       input.Gender,
-			Limit:        input.Limit,
-			Offset:       input.Offset,
+			ParentUserID:   input.ParentUserID,
+			OrganizationID: input.OrganizationID,
+			Name:           input.Name,
+			Gender:         input.Gender,
+			Limit:          input.Limit,
+			Offset:         input.Offset,
 		})
 
 		if err != nil {
@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/usecase"
+)
+
+// @Summary		Archive my child
+// @Description	Hide the child from the childern list and search while keeping the child data and results.
+// @Description	The archived child can be listed using the archived query on the childern list and restored at any time
+// @Tags			Childern
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization	header		string												true	"JWT Token"
+// @Param			child_id		path		string												true	"Child ID (UUID v4)"
+// @Success		200				{object}	StandardSuccessResponse{data=ArchiveChildOutput}	"Successful response"
+// @Failure		400				{object}	StandardErrorResponse								"Bad request / already archived"
+// @Failure		401				{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		403				{object}	StandardErrorResponse								"Forbidden"
+// @Failure		404				{object}	StandardErrorResponse								"Not Found"
+// @Failure		500				{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/childern/{child_id}/archive [post]
+func (s *Service) HandleArchiveChild() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &ArchiveChildInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		err := s.childUsecase.HandleArchiveChild(c.Request().Context(), usecase.ArchiveChildInput{
+			ChildID: input.ChildID,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: ArchiveChildOutput{
+				Message: "child archived",
+			},
+		})
+	}
+}
+
+// @Summary		Restore my archived child
+// @Description	Bring back the archived child to the childern list and search
+// @Tags			Childern
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization	header		string												true	"JWT Token"
+// @Param			child_id		path		string												true	"Child ID (UUID v4)"
+// @Success		200				{object}	StandardSuccessResponse{data=RestoreChildOutput}	"Successful response"
+// @Failure		400				{object}	StandardErrorResponse								"Bad request / not archived"
+// @Failure		401				{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		403				{object}	StandardErrorResponse								"Forbidden"
+// @Failure		404				{object}	StandardErrorResponse								"Not Found"
+// @Failure		500				{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/childern/{child_id}/restore [post]
+func (s *Service) HandleRestoreChild() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &RestoreChildInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		err := s.childUsecase.HandleRestoreChild(c.Request().Context(), usecase.RestoreChildInput{
+			ChildID: input.ChildID,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: RestoreChildOutput{
+				Message: "child restored",
+			},
+		})
+	}
+}
+
+// @Summary		Permanently delete my child
+// @Description	Permanently delete the child together with the child's results. Set anonymize_results to keep the results
+// @Description	as anonymous statistic data instead of deleting them. This action can't be undone
+// @Tags			Childern
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization		header		string											true	"JWT Token"
+// @Param			child_id			path		string											true	"Child ID (UUID v4)"
+// @Param			anonymize_results	query		bool											false	"anonymize the child's results instead of deleting them"
+// @Success		200					{object}	StandardSuccessResponse{data=DeleteChildOutput}	"Successful response"
+// @Failure		400					{object}	StandardErrorResponse							"Bad request"
+// @Failure		401					{object}	StandardErrorResponse							"Unauthorized"
+// @Failure		403					{object}	StandardErrorResponse							"Forbidden"
+// @Failure		404					{object}	StandardErrorResponse							"Not Found"
+// @Failure		500					{object}	StandardErrorResponse							"Internal Error"
+// @Router			/v1/childern/{child_id} [delete]
+func (s *Service) HandleDeleteChild() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &DeleteChildInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		err := s.childUsecase.HandleDeleteChild(c.Request().Context(), usecase.DeleteChildInput{
+			ChildID:          input.ChildID,
+			AnonymizeResults: input.AnonymizeResults,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: DeleteChildOutput{
+				Message: "child deleted",
+			},
+		})
+	}
+}
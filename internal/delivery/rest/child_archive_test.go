@@ -0,0 +1,198 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/delivery/rest"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	usecase_mock "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChildService_HandleArchiveChild(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockChildUsecase := usecase_mock.NewChildUsecaseIface(t)
+	svc := rest.NewService(group, nil, nil, mockChildUsecase, nil, nil)
+
+	childID := uuid.New()
+
+	testCases := []struct {
+		name   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "child is already archived",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockChildUsecase.EXPECT().HandleArchiveChild(ectx.Request().Context(), usecase.ArchiveChildInput{
+					ChildID: childID,
+				}).Return(usecase.UsecaseError{ErrType: usecase.ErrBadRequest}).Once()
+			},
+		},
+		{
+			name: "success",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), "child archived")
+			},
+			mockFn: func(ectx echo.Context) {
+				mockChildUsecase.EXPECT().HandleArchiveChild(ectx.Request().Context(), usecase.ArchiveChildInput{
+					ChildID: childID,
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/childern/"+childID.String()+"/archive", nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+			ectx.SetPath("/v1/childern/:child_id/archive")
+			ectx.SetParamNames("child_id")
+			ectx.SetParamValues(childID.String())
+
+			tc.mockFn(ectx)
+
+			err := svc.HandleArchiveChild()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestChildService_HandleRestoreChild(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockChildUsecase := usecase_mock.NewChildUsecaseIface(t)
+	svc := rest.NewService(group, nil, nil, mockChildUsecase, nil, nil)
+
+	childID := uuid.New()
+
+	testCases := []struct {
+		name   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "forbidden mapped from usecase",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusForbidden, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockChildUsecase.EXPECT().HandleRestoreChild(ectx.Request().Context(), usecase.RestoreChildInput{
+					ChildID: childID,
+				}).Return(usecase.UsecaseError{ErrType: usecase.ErrForbidden}).Once()
+			},
+		},
+		{
+			name: "success",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), "child restored")
+			},
+			mockFn: func(ectx echo.Context) {
+				mockChildUsecase.EXPECT().HandleRestoreChild(ectx.Request().Context(), usecase.RestoreChildInput{
+					ChildID: childID,
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/childern/"+childID.String()+"/restore", nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+			ectx.SetPath("/v1/childern/:child_id/restore")
+			ectx.SetParamNames("child_id")
+			ectx.SetParamValues(childID.String())
+
+			tc.mockFn(ectx)
+
+			err := svc.HandleRestoreChild()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestChildService_HandleDeleteChild(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockChildUsecase := usecase_mock.NewChildUsecaseIface(t)
+	svc := rest.NewService(group, nil, nil, mockChildUsecase, nil, nil)
+
+	childID := uuid.New()
+
+	testCases := []struct {
+		name   string
+		query  string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name:  "invalid anonymize_results query",
+			query: "?anonymize_results=maybe",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name: "child not found",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockChildUsecase.EXPECT().HandleDeleteChild(ectx.Request().Context(), usecase.DeleteChildInput{
+					ChildID: childID,
+				}).Return(usecase.UsecaseError{ErrType: usecase.ErrNotFound}).Once()
+			},
+		},
+		{
+			name:  "success with anonymized results",
+			query: "?anonymize_results=true",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), "child deleted")
+			},
+			mockFn: func(ectx echo.Context) {
+				mockChildUsecase.EXPECT().HandleDeleteChild(ectx.Request().Context(), usecase.DeleteChildInput{
+					ChildID:          childID,
+					AnonymizeResults: true,
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/v1/childern/"+childID.String()+tc.query, nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+			ectx.SetPath("/v1/childern/:child_id")
+			ectx.SetParamNames("child_id")
+			ectx.SetParamValues(childID.String())
+
+			if tc.mockFn != nil {
+				tc.mockFn(ectx)
+			}
+
+			err := svc.HandleDeleteChild()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/usecase"
+)
+
+// @Summary		Invite a guardian to share my child
+// @Description	Send a single use invitation to the given email. The account registered with the email can accept the invitation
+// @Description	to become the child's guardian, sharing the access to the child's statistic, results and questionnaire submission
+// @Tags			Childern
+// @Accept			json
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization			header		string												true	"JWT Token"
+// @Param			child_id				path		string												true	"Child ID (UUID v4)"
+// @Param			invite_guardian_input	body		InviteGuardianInput									true	"guardian email"
+// @Success		200						{object}	StandardSuccessResponse{data=InviteGuardianOutput}	"Successful response"
+// @Failure		400						{object}	StandardErrorResponse								"Bad request / validation error"
+// @Failure		401						{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		403						{object}	StandardErrorResponse								"Forbidden"
+// @Failure		404						{object}	StandardErrorResponse								"Not Found"
+// @Failure		500						{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/childern/{child_id}/guardians/invitations [post]
+func (s *Service) HandleInviteGuardian() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &InviteGuardianInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.childUsecase.HandleInviteGuardian(c.Request().Context(), usecase.InviteGuardianInput{
+			ChildID: input.ChildID,
+			Email:   input.Email,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: InviteGuardianOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
+
+// @Summary		Accept the invitation to become a child's guardian
+// @Description	Redeem the guardian invitation sent to the email of this account and join the child as a guardian
+// @Tags			Childern
+// @Accept			json
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization						header		string										true	"JWT Token"
+// @Param			accept_guardian_invitation_input	body		AcceptGuardianInvitationInput				true	"invitation token"
+// @Success		200									{object}	StandardSuccessResponse{data=GuardianOutput}	"Successful response"
+// @Failure		400									{object}	StandardErrorResponse						"Bad request / already a guardian"
+// @Failure		401									{object}	StandardErrorResponse						"Unauthorized / invalid invitation"
+// @Failure		403									{object}	StandardErrorResponse						"The invitation was sent to another email"
+// @Failure		404									{object}	StandardErrorResponse						"Not Found"
+// @Failure		500									{object}	StandardErrorResponse						"Internal Error"
+// @Router			/v1/childern/guardians/invitations/accept [post]
+func (s *Service) HandleAcceptGuardianInvitation() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &AcceptGuardianInvitationInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.childUsecase.HandleAcceptGuardianInvitation(c.Request().Context(), usecase.AcceptGuardianInvitationInput{
+			InvitationToken: input.InvitationToken,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data:       newGuardianOutput(*output),
+		})
+	}
+}
+
+// @Summary		List the child's guardians
+// @Description	List the owner and every guardian of the child. Only available to the child's owner and guardians
+// @Tags			Childern
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization	header		string											true	"JWT Token"
+// @Param			child_id		path		string											true	"Child ID (UUID v4)"
+// @Success		200				{object}	StandardSuccessResponse{data=[]GuardianOutput}	"Successful response"
+// @Failure		400				{object}	StandardErrorResponse							"Bad request"
+// @Failure		401				{object}	StandardErrorResponse							"Unauthorized"
+// @Failure		403				{object}	StandardErrorResponse							"Forbidden"
+// @Failure		404				{object}	StandardErrorResponse							"Not Found"
+// @Failure		500				{object}	StandardErrorResponse							"Internal Error"
+// @Router			/v1/childern/{child_id}/guardians [get]
+func (s *Service) HandleListGuardians() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &ListGuardiansInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.childUsecase.HandleListGuardians(c.Request().Context(), usecase.ListGuardiansInput{
+			ChildID: input.ChildID,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		resp := make([]GuardianOutput, 0, len(output))
+		for _, guardian := range output {
+			resp = append(resp, newGuardianOutput(guardian))
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data:       resp,
+		})
+	}
+}
+
+// @Summary		Remove a guardian from the child
+// @Description	The child's owner can remove any guardian, while a guardian can only remove themselves to leave the child.
+// @Description	The owner can't be removed
+// @Tags			Childern
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization	header		string												true	"JWT Token"
+// @Param			child_id		path		string												true	"Child ID (UUID v4)"
+// @Param			user_id			path		string												true	"guardian user ID (UUID v4)"
+// @Success		200				{object}	StandardSuccessResponse{data=RemoveGuardianOutput}	"Successful response"
+// @Failure		400				{object}	StandardErrorResponse								"Bad request"
+// @Failure		401				{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		403				{object}	StandardErrorResponse								"Forbidden"
+// @Failure		404				{object}	StandardErrorResponse								"Not Found"
+// @Failure		500				{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/childern/{child_id}/guardians/{user_id} [delete]
+func (s *Service) HandleRemoveGuardian() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &RemoveGuardianInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		err := s.childUsecase.HandleRemoveGuardian(c.Request().Context(), usecase.RemoveGuardianInput{
+			ChildID: input.ChildID,
+			UserID:  input.UserID,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: RemoveGuardianOutput{
+				Message: "guardian removed",
+			},
+		})
+	}
+}
+
+func newGuardianOutput(guardian usecase.GuardianOutput) GuardianOutput {
+	return GuardianOutput{
+		ChildID:   guardian.ChildID,
+		UserID:    guardian.UserID,
+		Username:  guardian.Username,
+		Role:      guardian.Role,
+		CreatedAt: guardian.CreatedAt,
+	}
+}
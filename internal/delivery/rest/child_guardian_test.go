@@ -0,0 +1,249 @@
+package rest_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/delivery/rest"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	usecase_mock "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChildService_HandleInviteGuardian(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockChildUsecase := usecase_mock.NewChildUsecaseIface(t)
+	svc := rest.NewService(group, nil, nil, mockChildUsecase, nil, nil)
+
+	childID := uuid.New()
+	path := "/v1/childern/" + childID.String() + "/guardians/invitations"
+
+	t.Run("invalid body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{,}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("child_id")
+		ctx.SetParamValues(childID.String())
+
+		err := svc.HandleInviteGuardian()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("forbidden mapped from usecase", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"email":"guardian@example.com"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("child_id")
+		ctx.SetParamValues(childID.String())
+
+		mockChildUsecase.EXPECT().HandleInviteGuardian(ctx.Request().Context(), usecase.InviteGuardianInput{
+			ChildID: childID,
+			Email:   "guardian@example.com",
+		}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrForbidden}).Once()
+
+		err := svc.HandleInviteGuardian()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"email":"guardian@example.com"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("child_id")
+		ctx.SetParamValues(childID.String())
+
+		mockChildUsecase.EXPECT().HandleInviteGuardian(ctx.Request().Context(), usecase.InviteGuardianInput{
+			ChildID: childID,
+			Email:   "guardian@example.com",
+		}).Return(&usecase.InviteGuardianOutput{Message: "invitation sent"}, nil).Once()
+
+		err := svc.HandleInviteGuardian()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invitation sent")
+	})
+}
+
+func TestChildService_HandleAcceptGuardianInvitation(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockChildUsecase := usecase_mock.NewChildUsecaseIface(t)
+	svc := rest.NewService(group, nil, nil, mockChildUsecase, nil, nil)
+
+	childID := uuid.New()
+	userID := uuid.New()
+	path := "/v1/childern/guardians/invitations/accept"
+
+	t.Run("invitation sent to another email", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"invitation_token":"token"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+
+		mockChildUsecase.EXPECT().HandleAcceptGuardianInvitation(ctx.Request().Context(), usecase.AcceptGuardianInvitationInput{
+			InvitationToken: "token",
+		}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrForbidden}).Once()
+
+		err := svc.HandleAcceptGuardianInvitation()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"invitation_token":"token"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+
+		mockChildUsecase.EXPECT().HandleAcceptGuardianInvitation(ctx.Request().Context(), usecase.AcceptGuardianInvitationInput{
+			InvitationToken: "token",
+		}).Return(&usecase.GuardianOutput{
+			ChildID:  childID,
+			UserID:   userID,
+			Username: "guardian",
+			Role:     model.GuardianRoleGuardian,
+		}, nil).Once()
+
+		err := svc.HandleAcceptGuardianInvitation()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), fmt.Sprintf(`"child_id":"%s"`, childID))
+		assert.Contains(t, rec.Body.String(), `"role":"guardian"`)
+	})
+}
+
+func TestChildService_HandleListGuardians(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockChildUsecase := usecase_mock.NewChildUsecaseIface(t)
+	svc := rest.NewService(group, nil, nil, mockChildUsecase, nil, nil)
+
+	childID := uuid.New()
+
+	testCases := []struct {
+		name   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "child not found",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockChildUsecase.EXPECT().HandleListGuardians(ectx.Request().Context(), usecase.ListGuardiansInput{
+					ChildID: childID,
+				}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrNotFound}).Once()
+			},
+		},
+		{
+			name: "success",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"role":"owner"`)
+				assert.Contains(t, rec.Body.String(), `"role":"guardian"`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockChildUsecase.EXPECT().HandleListGuardians(ectx.Request().Context(), usecase.ListGuardiansInput{
+					ChildID: childID,
+				}).Return([]usecase.GuardianOutput{
+					{ChildID: childID, UserID: uuid.New(), Username: "owner", Role: model.GuardianRoleOwner},
+					{ChildID: childID, UserID: uuid.New(), Username: "guardian", Role: model.GuardianRoleGuardian},
+				}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/childern/"+childID.String()+"/guardians", nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+			ectx.SetPath("/v1/childern/:child_id/guardians")
+			ectx.SetParamNames("child_id")
+			ectx.SetParamValues(childID.String())
+
+			tc.mockFn(ectx)
+
+			err := svc.HandleListGuardians()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestChildService_HandleRemoveGuardian(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockChildUsecase := usecase_mock.NewChildUsecaseIface(t)
+	svc := rest.NewService(group, nil, nil, mockChildUsecase, nil, nil)
+
+	childID := uuid.New()
+	userID := uuid.New()
+
+	testCases := []struct {
+		name   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "the user is not a guardian of the child",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockChildUsecase.EXPECT().HandleRemoveGuardian(ectx.Request().Context(), usecase.RemoveGuardianInput{
+					ChildID: childID,
+					UserID:  userID,
+				}).Return(usecase.UsecaseError{ErrType: usecase.ErrNotFound}).Once()
+			},
+		},
+		{
+			name: "success",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), "guardian removed")
+			},
+			mockFn: func(ectx echo.Context) {
+				mockChildUsecase.EXPECT().HandleRemoveGuardian(ectx.Request().Context(), usecase.RemoveGuardianInput{
+					ChildID: childID,
+					UserID:  userID,
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/v1/childern/"+childID.String()+"/guardians/"+userID.String(), nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+			ectx.SetPath("/v1/childern/:child_id/guardians/:user_id")
+			ectx.SetParamNames("child_id", "user_id")
+			ectx.SetParamValues(childID.String(), userID.String())
+
+			tc.mockFn(ectx)
+
+			err := svc.HandleRemoveGuardian()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package rest_test
 
 import (
+	"database/sql"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -337,6 +338,40 @@ func TestChildService_HandleGetMyChildren(t *testing.T) {
 				}, nil).Once()
 			},
 		},
+		{
+			name: "ok, only the archived children",
+			reqCtx: func() (*httptest.ResponseRecorder, echo.Context) {
+				req := httptest.NewRequest(http.MethodGet, "/v1/children", nil)
+				req.Header.Set("Content-Type", "application/json")
+
+				q := req.URL.Query()
+				q.Add("archived", "true")
+				q.Add("limit", "100")
+				q.Add("offset", "0")
+				req.URL.RawQuery = q.Encode()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				return rec, ectx
+			},
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"archived_at":"2024-01-02T00:00:00Z"`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockChildUsecase.EXPECT().GetRegisteredChildren(ectx.Request().Context(), usecase.GetRegisteredChildrenInput{
+					Archived: true,
+					Limit:    100,
+					Offset:   0,
+				}).Return([]usecase.GetRegisteredChildrenOutput{
+					{
+						ID:         uuid.New(),
+						ArchivedAt: sql.NullTime{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Valid: true},
+					},
+				}, nil).Once()
+			},
+		},
 	}
 
 	for _, tc := range testCases {
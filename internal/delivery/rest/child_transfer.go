@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/usecase"
+)
+
+// @Summary		Transfer my child to another account
+// @Description	Send a single use transfer request to the given email. Once the account registered with the email accepts it,
+// @Description	the child and the results created for the child are moved to that account and this account loses the access to the child
+// @Tags			Childern
+// @Accept			json
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization					header		string														true	"JWT Token"
+// @Param			child_id						path		string														true	"Child ID (UUID v4)"
+// @Param			initiate_child_transfer_input	body		InitiateChildTransferInput									true	"recipient email"
+// @Success		200								{object}	StandardSuccessResponse{data=InitiateChildTransferOutput}	"Successful response"
+// @Failure		400								{object}	StandardErrorResponse										"Bad request / validation error"
+// @Failure		401								{object}	StandardErrorResponse										"Unauthorized"
+// @Failure		403								{object}	StandardErrorResponse										"Forbidden"
+// @Failure		404								{object}	StandardErrorResponse										"Not Found"
+// @Failure		500								{object}	StandardErrorResponse										"Internal Error"
+// @Router			/v1/childern/{child_id}/transfers [post]
+func (s *Service) HandleInitiateChildTransfer() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &InitiateChildTransferInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.childUsecase.HandleInitiateChildTransfer(c.Request().Context(), usecase.InitiateChildTransferInput{
+			ChildID: input.ChildID,
+			Email:   input.Email,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: InitiateChildTransferOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
+
+// @Summary		Accept the transfer of a child to my account
+// @Description	Redeem the transfer request sent to the email of this account. The child and the results the previous parent
+// @Description	created for the child are moved to this account
+// @Tags			Childern
+// @Accept			json
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization				header		string													true	"JWT Token"
+// @Param			accept_child_transfer_input	body		AcceptChildTransferInput								true	"transfer token"
+// @Success		200							{object}	StandardSuccessResponse{data=AcceptChildTransferOutput}	"Successful response"
+// @Failure		400							{object}	StandardErrorResponse									"Bad request / the child has moved elsewhere"
+// @Failure		401							{object}	StandardErrorResponse									"Unauthorized / invalid transfer token"
+// @Failure		403							{object}	StandardErrorResponse									"The transfer was sent to another email"
+// @Failure		404							{object}	StandardErrorResponse									"Not Found"
+// @Failure		500							{object}	StandardErrorResponse									"Internal Error"
+// @Router			/v1/childern/transfers/accept [post]
+func (s *Service) HandleAcceptChildTransfer() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &AcceptChildTransferInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.childUsecase.HandleAcceptChildTransfer(c.Request().Context(), usecase.AcceptChildTransferInput{
+			TransferToken: input.TransferToken,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: AcceptChildTransferOutput{
+				ChildID:              output.ChildID,
+				PreviousParentUserID: output.PreviousParentUserID,
+				ParentUserID:         output.ParentUserID,
+			},
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package rest_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/delivery/rest"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	usecase_mock "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChildService_HandleInitiateChildTransfer(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockChildUsecase := usecase_mock.NewChildUsecaseIface(t)
+	svc := rest.NewService(group, nil, nil, mockChildUsecase, nil, nil)
+
+	childID := uuid.New()
+	path := "/v1/childern/" + childID.String() + "/transfers"
+
+	t.Run("invalid body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{,}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("child_id")
+		ctx.SetParamValues(childID.String())
+
+		err := svc.HandleInitiateChildTransfer()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("forbidden mapped from usecase", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"email":"parent@example.com"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("child_id")
+		ctx.SetParamValues(childID.String())
+
+		mockChildUsecase.EXPECT().HandleInitiateChildTransfer(ctx.Request().Context(), usecase.InitiateChildTransferInput{
+			ChildID: childID,
+			Email:   "parent@example.com",
+		}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrForbidden}).Once()
+
+		err := svc.HandleInitiateChildTransfer()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"email":"parent@example.com"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("child_id")
+		ctx.SetParamValues(childID.String())
+
+		mockChildUsecase.EXPECT().HandleInitiateChildTransfer(ctx.Request().Context(), usecase.InitiateChildTransferInput{
+			ChildID: childID,
+			Email:   "parent@example.com",
+		}).Return(&usecase.InitiateChildTransferOutput{Message: "transfer request sent"}, nil).Once()
+
+		err := svc.HandleInitiateChildTransfer()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "transfer request sent")
+	})
+}
+
+func TestChildService_HandleAcceptChildTransfer(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockChildUsecase := usecase_mock.NewChildUsecaseIface(t)
+	svc := rest.NewService(group, nil, nil, mockChildUsecase, nil, nil)
+
+	childID := uuid.New()
+	previousParentID := uuid.New()
+	parentID := uuid.New()
+	path := "/v1/childern/transfers/accept"
+
+	t.Run("child has moved elsewhere", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"transfer_token":"token"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+
+		mockChildUsecase.EXPECT().HandleAcceptChildTransfer(ctx.Request().Context(), usecase.AcceptChildTransferInput{
+			TransferToken: "token",
+		}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrBadRequest}).Once()
+
+		err := svc.HandleAcceptChildTransfer()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"transfer_token":"token"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+
+		mockChildUsecase.EXPECT().HandleAcceptChildTransfer(ctx.Request().Context(), usecase.AcceptChildTransferInput{
+			TransferToken: "token",
+		}).Return(&usecase.AcceptChildTransferOutput{
+			ChildID:              childID,
+			PreviousParentUserID: previousParentID,
+			ParentUserID:         parentID,
+		}, nil).Once()
+
+		err := svc.HandleAcceptChildTransfer()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), fmt.Sprintf(`"previous_parent_user_id":"%s"`, previousParentID))
+		assert.Contains(t, rec.Body.String(), fmt.Sprintf(`"parent_user_id":"%s"`, parentID))
+	})
+}
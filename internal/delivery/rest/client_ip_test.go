@@ -0,0 +1,115 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/delivery/rest"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	usecase_mock "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIPExtractor(t *testing.T) {
+	testCases := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   string
+		realIP         string
+		expectedIP     string
+	}{
+		{
+			name:         "no trusted proxy, the forwarded headers are ignored",
+			remoteAddr:   "203.0.113.10:54321",
+			forwardedFor: "198.51.100.7",
+			realIP:       "198.51.100.8",
+			expectedIP:   "203.0.113.10",
+		},
+		{
+			name:           "the forwarded header set by a trusted proxy is used",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.2:54321",
+			forwardedFor:   "198.51.100.7",
+			expectedIP:     "198.51.100.7",
+		},
+		{
+			name:           "the forwarded header set by an untrusted client is ignored",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "203.0.113.10:54321",
+			forwardedFor:   "198.51.100.7",
+			expectedIP:     "203.0.113.10",
+		},
+		{
+			name:           "the address prepended by the client is ignored",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.2:54321",
+			forwardedFor:   "198.51.100.7, 203.0.113.10",
+			expectedIP:     "203.0.113.10",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extractor, err := rest.NewIPExtractor(tc.trustedProxies)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			if tc.forwardedFor != "" {
+				req.Header.Set(echo.HeaderXForwardedFor, tc.forwardedFor)
+			}
+			if tc.realIP != "" {
+				req.Header.Set(echo.HeaderXRealIP, tc.realIP)
+			}
+
+			assert.Equal(t, tc.expectedIP, extractor(req))
+		})
+	}
+
+	t.Run("invalid trusted proxy range", func(t *testing.T) {
+		_, err := rest.NewIPExtractor([]string{"not-a-cidr"})
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthService_HandleLogin_SpoofedForwardedHeader(t *testing.T) {
+	extractor, err := rest.NewIPExtractor(nil)
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.IPExtractor = extractor
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	// rotating the forwarded headers must not change the ip address used to rate limit the login attempts
+	for _, spoofedIP := range []string{"198.51.100.7", "198.51.100.8", "198.51.100.9"} {
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(`{
+			"email": "valid@email.test",
+			"password": "password12345"
+		}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(echo.HeaderXForwardedFor, spoofedIP)
+		req.Header.Set(echo.HeaderXRealIP, spoofedIP)
+		rec := httptest.NewRecorder()
+		ectx := e.NewContext(req, rec)
+
+		mockAuthUsecase.EXPECT().HandleLogin(ectx.Request().Context(), usecase.LoginInput{
+			Email:     "valid@email.test",
+			Password:  "password12345",
+			IPAddress: "192.0.2.1",
+		}).Return(nil, usecase.UsecaseError{
+			ErrType: usecase.ErrTooManyRequests,
+		}).Once()
+
+		err := service.HandleLogin()(ectx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	}
+}
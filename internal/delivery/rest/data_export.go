@@ -0,0 +1,112 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/usecase"
+)
+
+// @Summary		Request data export
+// @Description	Start preparing a ZIP archive of everything stored about the user: the profile, children, results and the result images.
+// @Description	The archive is prepared in the background, and the download link is sent to the account email once ready.
+// @Description	Only one export can be prepared at a time
+// @Tags			Users
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization	header		string											true	"JWT Token"
+// @Success		202				{object}	StandardSuccessResponse{data=DataExportOutput}	"Successful response"
+// @Failure		400				{object}	StandardErrorResponse							"Previous export is still being prepared"
+// @Failure		401				{object}	StandardErrorResponse							"Unauthorized"
+// @Failure		403				{object}	StandardErrorResponse							"Forbidden"
+// @Failure		500				{object}	StandardErrorResponse							"Internal Error"
+// @Router			/v1/users/me/exports [post]
+func (s *Service) HandleRequestDataExport() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		output, err := s.usersUsecase.HandleRequestDataExport(c.Request().Context())
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusAccepted, StandardSuccessResponse{
+			StatusCode: http.StatusAccepted,
+			Message:    http.StatusText(http.StatusAccepted),
+			Data:       newDataExportOutput(*output),
+		})
+	}
+}
+
+// @Summary		List my data exports
+// @Description	List all the data exports requested by the user along with their status, newest first
+// @Tags			Users
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization	header		string											true	"JWT Token"
+// @Success		200				{object}	StandardSuccessResponse{data=[]DataExportOutput}	"Successful response"
+// @Failure		401				{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		403				{object}	StandardErrorResponse								"Forbidden"
+// @Failure		500				{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/users/me/exports [get]
+func (s *Service) HandleListMyDataExports() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		exports, err := s.usersUsecase.HandleListMyDataExports(c.Request().Context())
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		resp := make([]DataExportOutput, 0, len(exports))
+		for _, export := range exports {
+			resp = append(resp, newDataExportOutput(export))
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data:       resp,
+		})
+	}
+}
+
+// @Summary		Download data export
+// @Description	Download the data export archive using the token from the link sent to the account email
+// @Tags			Users
+// @Produce		application/zip
+// @Param			export_token	query		string					true	"download token from the email"
+// @Failure		400				{object}	StandardErrorResponse	"Bad request or the link has expired"
+// @Failure		404				{object}	StandardErrorResponse	"Data export not found"
+// @Failure		500				{object}	StandardErrorResponse	"Internal Error"
+// @Router			/v1/users/exports/download [get]
+func (s *Service) HandleDownloadDataExport() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &DownloadDataExportInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.usersUsecase.HandleDownloadDataExport(c.Request().Context(), usecase.DownloadDataExportInput{
+			Token: input.ExportToken,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", output.FileName))
+
+		return c.Blob(http.StatusOK, output.ContentType, output.Archive)
+	}
+}
+
+func newDataExportOutput(export usecase.DataExportOutput) DataExportOutput {
+	return DataExportOutput{
+		ID:          export.ID,
+		Status:      export.Status,
+		ExpiresAt:   export.ExpiresAt,
+		CompletedAt: export.CompletedAt,
+		CreatedAt:   export.CreatedAt,
+	}
+}
@@ -0,0 +1,194 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/delivery/rest"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	usecase_mock "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersService_HandleRequestDataExport(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockUsersUsecase := usecase_mock.NewUsersUsecaseIface(t)
+	service := rest.NewService(group, nil, nil, nil, nil, mockUsersUsecase)
+
+	exportID := uuid.New()
+
+	testCases := []struct {
+		name   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "previous export still in progress",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockUsersUsecase.EXPECT().HandleRequestDataExport(ectx.Request().Context()).
+					Return(nil, usecase.UsecaseError{ErrType: usecase.ErrBadRequest}).Once()
+			},
+		},
+		{
+			name: "success",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusAccepted, rec.Code)
+				assert.Contains(t, rec.Body.String(), exportID.String())
+				assert.Contains(t, rec.Body.String(), `"status":"pending"`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockUsersUsecase.EXPECT().HandleRequestDataExport(ectx.Request().Context()).Return(&usecase.DataExportOutput{
+					ID:        exportID,
+					Status:    model.DataExportStatusPending,
+					CreatedAt: time.Now(),
+				}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/users/me/exports", nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleRequestDataExport()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestUsersService_HandleListMyDataExports(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockUsersUsecase := usecase_mock.NewUsersUsecaseIface(t)
+	service := rest.NewService(group, nil, nil, nil, nil, mockUsersUsecase)
+
+	exportID := uuid.New()
+
+	testCases := []struct {
+		name   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "usecase error",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockUsersUsecase.EXPECT().HandleListMyDataExports(ectx.Request().Context()).
+					Return(nil, usecase.UsecaseError{ErrType: usecase.ErrInternal}).Once()
+			},
+		},
+		{
+			name: "success",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), exportID.String())
+				assert.Contains(t, rec.Body.String(), `"status":"completed"`)
+			},
+			mockFn: func(ectx echo.Context) {
+				now := time.Now()
+
+				mockUsersUsecase.EXPECT().HandleListMyDataExports(ectx.Request().Context()).Return([]usecase.DataExportOutput{{
+					ID:          exportID,
+					Status:      model.DataExportStatusCompleted,
+					ExpiresAt:   &now,
+					CompletedAt: &now,
+					CreatedAt:   now,
+				}}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/users/me/exports", nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleListMyDataExports()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestUsersService_HandleDownloadDataExport(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockUsersUsecase := usecase_mock.NewUsersUsecaseIface(t)
+	service := rest.NewService(group, nil, nil, nil, nil, mockUsersUsecase)
+
+	testCases := []struct {
+		name   string
+		query  string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name:  "link expired",
+			query: "?export_token=expired",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockUsersUsecase.EXPECT().HandleDownloadDataExport(ectx.Request().Context(), usecase.DownloadDataExportInput{
+					Token: "expired",
+				}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrBadRequest}).Once()
+			},
+		},
+		{
+			name:  "success",
+			query: "?export_token=valid",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, "application/zip", rec.Header().Get(echo.HeaderContentType))
+				assert.Equal(t, `attachment; filename="atec-data-export-20240517.zip"`, rec.Header().Get(echo.HeaderContentDisposition))
+				assert.Equal(t, "zip", rec.Body.String())
+			},
+			mockFn: func(ectx echo.Context) {
+				mockUsersUsecase.EXPECT().HandleDownloadDataExport(ectx.Request().Context(), usecase.DownloadDataExportInput{
+					Token: "valid",
+				}).Return(&usecase.DownloadDataExportOutput{
+					FileName:    "atec-data-export-20240517.zip",
+					ContentType: "application/zip",
+					Archive:     []byte("zip"),
+				}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/users/exports/download"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleDownloadDataExport()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package rest
 import (
 	"database/sql"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/luckyAkbar/atec/internal/model"
@@ -15,14 +16,16 @@ type SubmitQuestionnaireInput struct {
 	Answers   model.AnswerDetail `validate:"required" json:"answers"`
 }
 
-// SearchQUestionnaireResultsInput input
+// SearchQUestionnaireResultsInput input. OrganizationID is only used by the administrator
+// to narrow down the search to the organization
 type SearchQUestionnaireResultsInput struct {
-	ResultID    uuid.UUID `json:"result_id" query:"result_id"`
-	PackageID   uuid.UUID `json:"package_id" query:"package_id"`
-	ChildID     uuid.UUID `json:"child_id" query:"child_id"`
-	CreatedByID uuid.UUID `json:"created_by_id" query:"created_by_id"`
-	Limit       int       `json:"limit" query:"limit" validate:"min=1,max=100"`
-	Offset      int       `json:"offset" query:"offset" validate:"min=0"`
+	ResultID       uuid.UUID `json:"result_id" query:"result_id"`
+	PackageID      uuid.UUID `json:"package_id" query:"package_id"`
+	ChildID        uuid.UUID `json:"child_id" query:"child_id"`
+	CreatedByID    uuid.UUID `json:"created_by_id" query:"created_by_id"`
+	OrganizationID uuid.UUID `json:"organization_id" query:"organization_id"`
+	Limit          int       `json:"limit" query:"limit" validate:"min=1,max=100"`
+	Offset         int       `json:"offset" query:"offset" validate:"min=0"`
 }
 
 // GetMyQUestionnaireResultsInput input
@@ -62,6 +65,49 @@ type LoginInput struct {
 	Password string `json:"password" validate:"required,min=8"`
 }
 
+// UnlockAccountInput input
+type UnlockAccountInput struct {
+	UnlockToken string `json:"unlock_token" validate:"required"`
+}
+
+// RestoreAccountInput input
+type RestoreAccountInput struct {
+	RestoreToken string `json:"restore_token" validate:"required"`
+}
+
+// ConfirmChangeEmailInput input
+type ConfirmChangeEmailInput struct {
+	ChangeEmailToken string `json:"change_email_token" validate:"required"`
+}
+
+// MFAVerifyLoginInput input. Fill either code or recovery_code
+type MFAVerifyLoginInput struct {
+	MFAToken     string `json:"mfa_token" validate:"required"`
+	Code         string `json:"code" example:"123456"`
+	RecoveryCode string `json:"recovery_code" example:"a1b2c-3d4e5"`
+}
+
+// MFAEnrollInput input. mfa_token is only required when enrolling without being logged in
+type MFAEnrollInput struct {
+	MFAToken string `json:"mfa_token"`
+}
+
+// MFAConfirmEnrollmentInput input. mfa_token is only required when enrolling without being logged in
+type MFAConfirmEnrollmentInput struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code" validate:"required" example:"123456"`
+}
+
+// MFADisableInput input
+type MFADisableInput struct {
+	Code string `json:"code" validate:"required" example:"123456"`
+}
+
+// RefreshTokenInput input
+type RefreshTokenInput struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
 // RegisterChildInput input
 type RegisterChildInput struct {
 	Name         string  `json:"name" validate:"required"`
@@ -144,19 +190,22 @@ type DeletePackageInput struct {
 	PackageID uuid.UUID `param:"package_id"`
 }
 
-// GetMyChildrenInput input
+// GetMyChildrenInput input. Set Archived to list the archived children instead of the active ones
 type GetMyChildrenInput struct {
-	Limit  int `query:"limit" validate:"min=1"`
-	Offset int `query:"offset" validate:"min=0"`
+	Archived bool `query:"archived"`
+	Limit    int  `query:"limit" validate:"min=1"`
+	Offset   int  `query:"offset" validate:"min=0"`
 }
 
-// SearchChildrenInput input
+// SearchChildrenInput input. OrganizationID is only used by the administrator
+// to narrow down the search to the organization
 type SearchChildrenInput struct {
-	ParentUserID *uuid.UUID `json:"parent_user_id" query:"parent_user_id"`
-	Name         *string    `query:"name"`
-	Gender       *bool      `query:"gender"`
-	Limit        int        `query:"limit" validate:"min=1" example:"1"`
-	Offset       int        `query:"offset" validate:"min=0"`
+	ParentUserID   *uuid.UUID `json:"parent_user_id" query:"parent_user_id"`
+	OrganizationID *uuid.UUID `json:"organization_id" query:"organization_id"`
+	Name           *string    `query:"name"`
+	Gender         *bool      `query:"gender"`
+	Limit          int        `query:"limit" validate:"min=1" example:"1"`
+	Offset         int        `query:"offset" validate:"min=0"`
 }
 
 // DownloadQuestionnaireResultInput input
@@ -186,3 +235,197 @@ type UpdateMyProfileInput struct {
 	PhoneNumber *string `json:"phone_number" validate:"required"`
 	Address     *string `json:"address" validate:"required"`
 }
+
+// ChangePasswordInput input
+type ChangePasswordInput struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+// InitChangeEmailInput input
+type InitChangeEmailInput struct {
+	NewEmail        string `json:"new_email" validate:"required,email"`
+	CurrentPassword string `json:"current_password" validate:"required"`
+}
+
+// AdminSearchUsersInput input
+type AdminSearchUsersInput struct {
+	Email          string      `query:"email"`
+	Username       string      `query:"username"`
+	Role           model.Roles `query:"role" enums:"administrator,therapist,parent"`
+	IsActive       *bool       `query:"is_active"`
+	OrganizationID uuid.UUID   `query:"organization_id"`
+	Limit          int         `query:"limit" validate:"min=1" example:"10"`
+	Offset         int         `query:"offset" validate:"min=0"`
+}
+
+// AdminSearchAuditLogsInput input. Created after and created before must be formatted as RFC3339
+type AdminSearchAuditLogsInput struct {
+	ActorID       uuid.UUID             `query:"actor_id"`
+	Action        model.AuditAction     `query:"action" example:"child.search"`
+	TargetType    model.AuditTargetType `query:"target_type" enums:"child,result,package,user"`
+	TargetID      string                `query:"target_id"`
+	CreatedAfter  time.Time             `query:"created_after" example:"2025-01-01T00:00:00Z"`
+	CreatedBefore time.Time             `query:"created_before" example:"2025-02-01T00:00:00Z"`
+	Limit         int                   `query:"limit" validate:"min=1" example:"10"`
+	Offset        int                   `query:"offset" validate:"min=0"`
+}
+
+// AdminChangeUserRoleInput input
+type AdminChangeUserRoleInput struct {
+	UserID uuid.UUID   `json:"-" param:"user_id"`
+	Role   model.Roles `json:"role" enums:"administrator,therapist,parent"`
+}
+
+// AdminChangeUserActivationInput input
+type AdminChangeUserActivationInput struct {
+	UserID   uuid.UUID `json:"-" param:"user_id"`
+	IsActive *bool     `json:"is_active"`
+}
+
+// AdminSetMFARequirementInput input
+type AdminSetMFARequirementInput struct {
+	UserID   uuid.UUID `json:"-" param:"user_id"`
+	Required *bool     `json:"required"`
+}
+
+// AdminUnlockUserInput input
+type AdminUnlockUserInput struct {
+	UserID uuid.UUID `param:"user_id"`
+}
+
+// AdminCancelAccountDeletionInput input
+type AdminCancelAccountDeletionInput struct {
+	UserID uuid.UUID `param:"user_id"`
+}
+
+// AdminForceResetPasswordInput input
+type AdminForceResetPasswordInput struct {
+	UserID uuid.UUID `param:"user_id"`
+}
+
+// InviteTherapistInput input
+type InviteTherapistInput struct {
+	Email string `json:"email" validate:"required,email" example:"therapist@string.com"`
+}
+
+// RedeemTherapistInvitationInput input
+type RedeemTherapistInvitationInput struct {
+	InvitationToken string  `json:"invitation_token" validate:"required"`
+	Password        string  `json:"password" validate:"required,min=8" example:"password123"`
+	Username        string  `json:"username" validate:"required" example:"username"`
+	PhoneNumber     *string `json:"phone_number" example:"+628123456789"`
+	Address         *string `json:"address" example:"Jl. Example No. 123, Jakarta"`
+}
+
+// InitPasswordlessLoginInput input
+type InitPasswordlessLoginInput struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// VerifyPasswordlessLoginInput input
+type VerifyPasswordlessLoginInput struct {
+	Email string `json:"email" validate:"required,email"`
+	Code  string `json:"code" validate:"required" example:"123456"`
+}
+
+// MagicLinkLoginInput input
+type MagicLinkLoginInput struct {
+	MagicLinkToken string `json:"magic_link_token" validate:"required"`
+}
+
+// CreatePersonalAccessTokenInput input
+type CreatePersonalAccessTokenInput struct {
+	Name          string                           `json:"name" validate:"required" example:"monthly report script"`
+	Scopes        []model.PersonalAccessTokenScope `json:"scopes" validate:"required,min=1" enums:"packages,children,questionnaires,users,admin"`
+	ExpiresInDays int                              `json:"expires_in_days" validate:"required,min=1,max=365" example:"90"`
+}
+
+// RevokePersonalAccessTokenInput input
+type RevokePersonalAccessTokenInput struct {
+	TokenID uuid.UUID `param:"token_id"`
+}
+
+// RevokeMySessionInput input
+type RevokeMySessionInput struct {
+	SessionID uuid.UUID `param:"session_id"`
+}
+
+// DownloadDataExportInput input
+type DownloadDataExportInput struct {
+	ExportToken string `query:"export_token" validate:"required"`
+}
+
+// GrantTherapistAccessInput input
+type GrantTherapistAccessInput struct {
+	TherapistID uuid.UUID       `json:"-" param:"therapist_id"`
+	ChildID     uuid.UUID       `json:"child_id"`
+	Scope       model.CareScope `json:"scope" enums:"read,submit"`
+}
+
+// RevokeTherapistAccessInput input
+type RevokeTherapistAccessInput struct {
+	TherapistID uuid.UUID `param:"therapist_id"`
+	ChildID     uuid.UUID `param:"child_id"`
+}
+
+// AdminCreateOrganizationInput input
+type AdminCreateOrganizationInput struct {
+	Name string `json:"name" validate:"required,max=255" example:"Klinik Tumbuh Kembang"`
+}
+
+// AdminSetUserOrganizationInput input. Leave organization_id empty to remove the user from the organization
+type AdminSetUserOrganizationInput struct {
+	UserID              uuid.UUID `json:"-" param:"user_id"`
+	OrganizationID      uuid.UUID `json:"organization_id"`
+	IsOrganizationAdmin bool      `json:"is_organization_admin"`
+}
+
+// InviteGuardianInput input
+type InviteGuardianInput struct {
+	ChildID uuid.UUID `json:"-" param:"child_id"`
+	Email   string    `json:"email" validate:"required,email" example:"guardian@string.com"`
+}
+
+// AcceptGuardianInvitationInput input
+type AcceptGuardianInvitationInput struct {
+	InvitationToken string `json:"invitation_token" validate:"required"`
+}
+
+// ListGuardiansInput input
+type ListGuardiansInput struct {
+	ChildID uuid.UUID `param:"child_id"`
+}
+
+// RemoveGuardianInput input
+type RemoveGuardianInput struct {
+	ChildID uuid.UUID `param:"child_id"`
+	UserID  uuid.UUID `param:"user_id"`
+}
+
+// InitiateChildTransferInput input
+type InitiateChildTransferInput struct {
+	ChildID uuid.UUID `json:"-" param:"child_id"`
+	Email   string    `json:"email" validate:"required,email" example:"recipient@string.com"`
+}
+
+// ArchiveChildInput input
+type ArchiveChildInput struct {
+	ChildID uuid.UUID `param:"child_id"`
+}
+
+// RestoreChildInput input
+type RestoreChildInput struct {
+	ChildID uuid.UUID `param:"child_id"`
+}
+
+// DeleteChildInput input. Set AnonymizeResults to keep the child's results as anonymous statistic data
+type DeleteChildInput struct {
+	ChildID          uuid.UUID `param:"child_id"`
+	AnonymizeResults bool      `query:"anonymize_results"`
+}
+
+// AcceptChildTransferInput input
+type AcceptChildTransferInput struct {
+	TransferToken string `json:"transfer_token" validate:"required"`
+}
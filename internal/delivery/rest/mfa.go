@@ -0,0 +1,188 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/usecase"
+)
+
+// @Summary		Complete login using the second factor
+// @Description	Exchange the mfa_token returned from login with the login token by supplying either
+// @Description	the TOTP code from the authenticator app or one of the unused recovery codes
+// @Tags			Authentication
+// @Accept			json
+// @Produce		json
+// @Param			mfa_verify_login_input	body		MFAVerifyLoginInput							true	"mfa token and the second factor"
+// @Success		200						{object}	StandardSuccessResponse{data=LoginOutput}	"Successful response"
+// @Failure		400						{object}	StandardErrorResponse						"Bad request"
+// @Failure		401						{object}	StandardErrorResponse						"Authentication Failed"
+// @Failure		429						{object}	StandardErrorResponse						"Too many attempts"
+// @Failure		500						{object}	StandardErrorResponse						"Internal Error"
+// @Router			/v1/auth/login/mfa [post]
+func (s *Service) HandleMFAVerifyLogin() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &MFAVerifyLoginInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleMFAVerifyLogin(c.Request().Context(), usecase.MFAVerifyLoginInput{
+			MFAToken:     input.MFAToken,
+			Code:         input.Code,
+			RecoveryCode: input.RecoveryCode,
+		})
+
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: LoginOutput{
+				Token:        output.Token,
+				RefreshToken: output.RefreshToken,
+			},
+		})
+	}
+}
+
+// @Summary		Start two-factor authentication enrollment
+// @Description	Generate a new TOTP secret to be added to the authenticator app. Only available for therapist and administrator.
+// @Description	Use the Authorization header when logged in, or the mfa_token from login when the enrollment is required by administrator
+// @Tags			Authentication
+// @Accept			json
+// @Produce		json
+// @Security		TherapistLevelAuth
+// @Param			Authorization		header		string											false	"JWT Token"
+// @Param			mfa_enroll_input	body		MFAEnrollInput									false	"mfa token when not logged in"
+// @Success		200					{object}	StandardSuccessResponse{data=MFAEnrollOutput}	"Successful response"
+// @Failure		400					{object}	StandardErrorResponse							"Bad request"
+// @Failure		401					{object}	StandardErrorResponse							"Authentication Failed"
+// @Failure		403					{object}	StandardErrorResponse							"Forbidden"
+// @Failure		500					{object}	StandardErrorResponse							"Internal Error"
+// @Router			/v1/auth/mfa/enroll [post]
+func (s *Service) HandleMFAEnroll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &MFAEnrollInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleMFAEnroll(c.Request().Context(), usecase.MFAEnrollInput{
+			MFAToken: input.MFAToken,
+		})
+
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: MFAEnrollOutput{
+				Secret:          output.Secret,
+				ProvisioningURI: output.ProvisioningURI,
+			},
+		})
+	}
+}
+
+// @Summary		Confirm two-factor authentication enrollment
+// @Description	Enable two-factor authentication by supplying the TOTP code generated from the new secret.
+// @Description	The recovery codes are only shown once. When enrolling using mfa_token, the login token is also returned
+// @Tags			Authentication
+// @Accept			json
+// @Produce		json
+// @Security		TherapistLevelAuth
+// @Param			Authorization					header		string														false	"JWT Token"
+// @Param			mfa_confirm_enrollment_input	body		MFAConfirmEnrollmentInput									true	"TOTP code"
+// @Success		200								{object}	StandardSuccessResponse{data=MFAConfirmEnrollmentOutput}	"Successful response"
+// @Failure		400								{object}	StandardErrorResponse										"Bad request"
+// @Failure		401								{object}	StandardErrorResponse										"Authentication Failed"
+// @Failure		403								{object}	StandardErrorResponse										"Forbidden"
+// @Failure		500								{object}	StandardErrorResponse										"Internal Error"
+// @Router			/v1/auth/mfa/enroll/confirm [post]
+func (s *Service) HandleMFAConfirmEnrollment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &MFAConfirmEnrollmentInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleMFAConfirmEnrollment(c.Request().Context(), usecase.MFAConfirmEnrollmentInput{
+			MFAToken: input.MFAToken,
+			Code:     input.Code,
+		})
+
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: MFAConfirmEnrollmentOutput{
+				RecoveryCodes: output.RecoveryCodes,
+				Token:         output.Token,
+				RefreshToken:  output.RefreshToken,
+			},
+		})
+	}
+}
+
+// @Summary		Disable two-factor authentication
+// @Description	Disable two-factor authentication for the logged in account. Not allowed when required by administrator
+// @Tags			Authentication
+// @Accept			json
+// @Produce		json
+// @Security		TherapistLevelAuth
+// @Param			Authorization		header		string											true	"JWT Token"
+// @Param			mfa_disable_input	body		MFADisableInput									true	"TOTP code"
+// @Success		200					{object}	StandardSuccessResponse{data=MFADisableOutput}	"Successful response"
+// @Failure		400					{object}	StandardErrorResponse							"Bad request"
+// @Failure		401					{object}	StandardErrorResponse							"Authentication Failed"
+// @Failure		403					{object}	StandardErrorResponse							"Forbidden"
+// @Failure		500					{object}	StandardErrorResponse							"Internal Error"
+// @Router			/v1/auth/mfa [delete]
+func (s *Service) HandleMFADisable() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &MFADisableInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleMFADisable(c.Request().Context(), usecase.MFADisableInput{
+			Code: input.Code,
+		})
+
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: MFADisableOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
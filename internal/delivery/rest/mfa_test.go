@@ -0,0 +1,256 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/delivery/rest"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	usecase_mock "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthService_HandleMFAVerifyLogin(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	testCases := []struct {
+		name   string
+		body   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "invalid input",
+			body: `{,}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(_ echo.Context) {},
+		},
+		{
+			name: "too many attempts",
+			body: `{"mfa_token":"token","code":"123456"}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleMFAVerifyLogin(ectx.Request().Context(), usecase.MFAVerifyLoginInput{
+					MFAToken: "token",
+					Code:     "123456",
+				}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrTooManyRequests}).Once()
+			},
+		},
+		{
+			name: "success using recovery code",
+			body: `{"mfa_token":"token","recovery_code":"abcde-12345"}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"token":"loginToken"`)
+				assert.Contains(t, rec.Body.String(), `"refresh_token":"refreshToken"`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleMFAVerifyLogin(ectx.Request().Context(), usecase.MFAVerifyLoginInput{
+					MFAToken:     "token",
+					RecoveryCode: "abcde-12345",
+				}).Return(&usecase.LoginOutput{Token: "loginToken", RefreshToken: "refreshToken"}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/auth/login/mfa", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleMFAVerifyLogin()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestAuthService_HandleMFAEnroll(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	testCases := []struct {
+		name   string
+		body   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "forbidden for parent",
+			body: `{}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusForbidden, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleMFAEnroll(ectx.Request().Context(), usecase.MFAEnrollInput{}).
+					Return(nil, usecase.UsecaseError{ErrType: usecase.ErrForbidden}).Once()
+			},
+		},
+		{
+			name: "success using mfa token",
+			body: `{"mfa_token":"token"}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"secret":"SECRET"`)
+				assert.Contains(t, rec.Body.String(), `"provisioning_uri":"otpauth://totp/ATEC"`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleMFAEnroll(ectx.Request().Context(), usecase.MFAEnrollInput{MFAToken: "token"}).
+					Return(&usecase.MFAEnrollOutput{Secret: "SECRET", ProvisioningURI: "otpauth://totp/ATEC"}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/auth/mfa/enroll", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleMFAEnroll()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestAuthService_HandleMFAConfirmEnrollment(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	testCases := []struct {
+		name   string
+		body   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "invalid code",
+			body: `{"code":"123456"}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleMFAConfirmEnrollment(ectx.Request().Context(), usecase.MFAConfirmEnrollmentInput{
+					Code: "123456",
+				}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrUnauthorized}).Once()
+			},
+		},
+		{
+			name: "success",
+			body: `{"code":"123456"}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"recovery_codes":["abcde-12345"]`)
+				assert.NotContains(t, rec.Body.String(), `"token"`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleMFAConfirmEnrollment(ectx.Request().Context(), usecase.MFAConfirmEnrollmentInput{
+					Code: "123456",
+				}).Return(&usecase.MFAConfirmEnrollmentOutput{RecoveryCodes: []string{"abcde-12345"}}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/auth/mfa/enroll/confirm", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleMFAConfirmEnrollment()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestAuthService_HandleMFADisable(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	testCases := []struct {
+		name   string
+		body   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "invalid input",
+			body: `{,}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(_ echo.Context) {},
+		},
+		{
+			name: "required by administrator",
+			body: `{"code":"123456"}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusForbidden, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleMFADisable(ectx.Request().Context(), usecase.MFADisableInput{Code: "123456"}).
+					Return(nil, usecase.UsecaseError{ErrType: usecase.ErrForbidden}).Once()
+			},
+		},
+		{
+			name: "success",
+			body: `{"code":"123456"}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), "two-factor authentication disabled")
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleMFADisable(ectx.Request().Context(), usecase.MFADisableInput{Code: "123456"}).
+					Return(&usecase.MFADisableOutput{Message: "two-factor authentication disabled"}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/v1/auth/mfa", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleMFADisable()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
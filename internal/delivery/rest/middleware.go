@@ -13,7 +13,14 @@ import (
 // If the token is valid, it will set the user information in the context and call the next handler.
 // If the token is invalid or missing, it will return an error response when allowUnauthorized is false.
 // Otherwise, it will call the next handler without authentication check.
+// Only the login token is accepted, use ScopedAuthMiddleware to also accept the personal access token.
 func (s *Service) AuthMiddleware(allowUnauthorized bool) echo.MiddlewareFunc {
+	return s.ScopedAuthMiddleware(allowUnauthorized, "")
+}
+
+// ScopedAuthMiddleware behave like AuthMiddleware, but will also accept the personal access token
+// granted with the given scope.
+func (s *Service) ScopedAuthMiddleware(allowUnauthorized bool, scope model.PersonalAccessTokenScope) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			token := getAccessToken(c.Request())
@@ -31,6 +38,7 @@ func (s *Service) AuthMiddleware(allowUnauthorized bool) echo.MiddlewareFunc {
 
 			output, err := s.authUsecase.AuthenticateAccessToken(c.Request().Context(), usecase.AuthenticateAccessTokenInput{
 				Token: token,
+				Scope: scope,
 			})
 
 			if err != nil {
@@ -38,8 +46,12 @@ func (s *Service) AuthMiddleware(allowUnauthorized bool) echo.MiddlewareFunc {
 			}
 
 			authUser := model.AuthUser{
-				ID:   output.UserID,
-				Role: output.UserRole,
+				ID:                    output.UserID,
+				Role:                  output.UserRole,
+				SessionID:             output.SessionID,
+				PersonalAccessTokenID: output.PersonalAccessTokenID,
+				OrganizationID:        output.OrganizationID,
+				IsOrganizationAdmin:   output.IsOrganizationAdmin,
 			}
 
 			ctx := c.Request().Context()
@@ -52,6 +64,32 @@ func (s *Service) AuthMiddleware(allowUnauthorized bool) echo.MiddlewareFunc {
 	}
 }
 
+// ClientInfoMiddleware will set the ip address and user agent of the client making the request in the context,
+// to be recorded when the user login and in the audit log. The ip address is found using the echo.IPExtractor
+// created by NewIPExtractor, so the client can't forge it using the forwarded headers.
+func (s *Service) ClientInfoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := model.SetClientInfoToCtx(c.Request().Context(), model.ClientInfo{
+				IPAddress: c.RealIP(),
+				UserAgent: c.Request().UserAgent(),
+			})
+
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// scopedAuthMiddlewareFactory return a ScopedAuthMiddleware constructor bound to the scope,
+// to help registering the routes belonging to the same scope
+func (s *Service) scopedAuthMiddlewareFactory(scope model.PersonalAccessTokenScope) func(allowUnauthorized bool) echo.MiddlewareFunc {
+	return func(allowUnauthorized bool) echo.MiddlewareFunc {
+		return s.ScopedAuthMiddleware(allowUnauthorized, scope)
+	}
+}
+
 func getAccessToken(req *http.Request) string {
 	authHeaders := strings.Split(req.Header.Get("Authorization"), " ")
 
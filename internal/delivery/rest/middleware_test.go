@@ -142,8 +142,9 @@ func TestRESTMiddleware_AuthMiddleware(t *testing.T) {
 				mockAuthUsecase.EXPECT().AuthenticateAccessToken(ectx.Request().Context(), usecase.AuthenticateAccessTokenInput{
 					Token: "secretboss",
 				}).Return(&usecase.AuthenticateAccessTokenOutput{
-					UserID:   uuid.New(),
-					UserRole: model.RolesAdministrator,
+					UserID:    uuid.New(),
+					UserRole:  model.RolesAdministrator,
+					SessionID: uuid.New(),
 				}, nil).Times(1)
 			},
 		},
@@ -164,3 +165,122 @@ func TestRESTMiddleware_AuthMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestRESTMiddleware_ScopedAuthMiddleware(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	userID := uuid.New()
+	tokenID := uuid.New()
+
+	next := func(c echo.Context) error {
+		user := model.GetUserFromCtx(c.Request().Context())
+		require.NotNil(t, user)
+		assert.Equal(t, userID, user.ID)
+		assert.Equal(t, tokenID, user.PersonalAccessTokenID)
+
+		return c.String(http.StatusOK, "ok")
+	}
+
+	testCases := []struct {
+		name   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "token is missing the required scope",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusForbidden, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().AuthenticateAccessToken(ectx.Request().Context(), usecase.AuthenticateAccessTokenInput{
+					Token: "atec_pat_secret",
+					Scope: model.PersonalAccessTokenScopeChildren,
+				}).Return(nil, usecase.UsecaseError{
+					ErrType: usecase.ErrForbidden,
+				}).Once()
+			},
+		},
+		{
+			name: "ok",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().AuthenticateAccessToken(ectx.Request().Context(), usecase.AuthenticateAccessTokenInput{
+					Token: "atec_pat_secret",
+					Scope: model.PersonalAccessTokenScopeChildren,
+				}).Return(&usecase.AuthenticateAccessTokenOutput{
+					UserID:                userID,
+					UserRole:              model.RolesTherapist,
+					PersonalAccessTokenID: tokenID,
+				}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "atec_pat_secret")
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.ScopedAuthMiddleware(false, model.PersonalAccessTokenScopeChildren)(next)(ectx)
+
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestRESTMiddleware_ClientInfoMiddleware(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	service := rest.NewService(group, nil, nil, nil, nil, nil)
+
+	var client model.ClientInfo
+	next := func(c echo.Context) error {
+		client = model.GetClientInfoFromCtx(c.Request().Context())
+
+		return c.String(http.StatusOK, "ok")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "203.0.113.10:54321"
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+	rec := httptest.NewRecorder()
+	ectx := e.NewContext(req, rec)
+
+	err := service.ClientInfoMiddleware()(next)(ectx)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, model.ClientInfo{IPAddress: "203.0.113.10", UserAgent: "Mozilla/5.0"}, client)
+
+	t.Run("the forwarded headers forged by the client are ignored", func(t *testing.T) {
+		extractor, err := rest.NewIPExtractor([]string{"10.0.0.0/8"})
+		require.NoError(t, err)
+
+		e.IPExtractor = extractor
+		t.Cleanup(func() { e.IPExtractor = nil })
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "203.0.113.10:54321"
+		req.Header.Set("User-Agent", "Mozilla/5.0")
+		req.Header.Set(echo.HeaderXForwardedFor, "198.51.100.7")
+		req.Header.Set(echo.HeaderXRealIP, "198.51.100.7")
+		rec := httptest.NewRecorder()
+		ectx := e.NewContext(req, rec)
+
+		err = service.ClientInfoMiddleware()(next)(ectx)
+		require.NoError(t, err)
+		assert.Equal(t, model.ClientInfo{IPAddress: "203.0.113.10", UserAgent: "Mozilla/5.0"}, client)
+	})
+}
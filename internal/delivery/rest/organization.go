@@ -0,0 +1,135 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/usecase"
+)
+
+// @Summary		Create organization
+// @Description	Allow administrator to register a new organization. The organization name must be unique
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		AdministratorLevelAuth
+// @Param			Authorization						header		string											true	"JWT Token"
+// @Param			admin_create_organization_input	body		AdminCreateOrganizationInput					true	"organization detail"
+// @Success		200									{object}	StandardSuccessResponse{data=OrganizationOutput}	"Successful response"
+// @Failure		400									{object}	StandardErrorResponse							"Bad Request"
+// @Failure		401									{object}	StandardErrorResponse							"Unauthorized"
+// @Failure		403									{object}	StandardErrorResponse							"Forbidden"
+// @Failure		500									{object}	StandardErrorResponse							"Internal Error"
+// @Router			/v1/admin/organizations [post]
+func (s *Service) HandleAdminCreateOrganization() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &AdminCreateOrganizationInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.usersUsecase.AdminCreateOrganization(c.Request().Context(), usecase.AdminCreateOrganizationInput{
+			Name: input.Name,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data:       newOrganizationOutput(*output),
+		})
+	}
+}
+
+// @Summary		List organizations
+// @Description	Allow administrator to list all the organizations
+// @Tags			Admin
+// @Produce		json
+// @Security		AdministratorLevelAuth
+// @Param			Authorization	header		string												true	"JWT Token"
+// @Success		200				{object}	StandardSuccessResponse{data=[]OrganizationOutput}	"Successful response"
+// @Failure		401				{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		403				{object}	StandardErrorResponse								"Forbidden"
+// @Failure		500				{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/admin/organizations [get]
+func (s *Service) HandleAdminListOrganizations() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		organizations, err := s.usersUsecase.AdminListOrganizations(c.Request().Context())
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		resp := make([]OrganizationOutput, 0, len(organizations))
+		for _, organization := range organizations {
+			resp = append(resp, newOrganizationOutput(organization))
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data:       resp,
+		})
+	}
+}
+
+// @Summary		Set user organization
+// @Description	Allow administrator to move a therapist into an organization, optionally as the organization admin,
+// @Description	or remove the therapist from the organization by leaving the organization_id empty. All the user's sessions will be revoked
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		AdministratorLevelAuth
+// @Param			Authorization						header		string												true	"JWT Token"
+// @Param			user_id								path		string												true	"user ID (UUID v4)"
+// @Param			admin_set_user_organization_input	body		AdminSetUserOrganizationInput						true	"organization membership"
+// @Success		200									{object}	StandardSuccessResponse{data=AdminUpdateUserOutput}	"Successful response"
+// @Failure		400									{object}	StandardErrorResponse								"Bad Request"
+// @Failure		401									{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		403									{object}	StandardErrorResponse								"Forbidden"
+// @Failure		404									{object}	StandardErrorResponse								"Not Found"
+// @Failure		500									{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/admin/users/{user_id}/organization [put]
+func (s *Service) HandleAdminSetUserOrganization() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &AdminSetUserOrganizationInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.usersUsecase.AdminSetUserOrganization(c.Request().Context(), usecase.AdminSetUserOrganizationInput{
+			UserID:              input.UserID,
+			OrganizationID:      input.OrganizationID,
+			IsOrganizationAdmin: input.IsOrganizationAdmin,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: AdminUpdateUserOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
+
+func newOrganizationOutput(organization usecase.OrganizationOutput) OrganizationOutput {
+	return OrganizationOutput{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		CreatedAt: organization.CreatedAt,
+		UpdatedAt: organization.UpdatedAt,
+	}
+}
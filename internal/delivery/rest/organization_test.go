@@ -0,0 +1,172 @@
+package rest_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/delivery/rest"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	usecase_mock "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersService_HandleAdminCreateOrganization(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockUsersUC := usecase_mock.NewUsersUsecaseIface(t)
+
+	svc := rest.NewService(group, nil, nil, nil, nil, mockUsersUC)
+
+	path := "/v1/admin/organizations"
+
+	t.Run("invalid body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{,}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+
+		err := svc.HandleAdminCreateOrganization()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("name already used", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"name":"clinic"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+
+		mockUsersUC.EXPECT().AdminCreateOrganization(ctx.Request().Context(), usecase.AdminCreateOrganizationInput{
+			Name: "clinic",
+		}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrBadRequest}).Once()
+
+		err := svc.HandleAdminCreateOrganization()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"name":"clinic"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+
+		organizationID := uuid.New()
+		mockUsersUC.EXPECT().AdminCreateOrganization(ctx.Request().Context(), usecase.AdminCreateOrganizationInput{
+			Name: "clinic",
+		}).Return(&usecase.OrganizationOutput{ID: organizationID, Name: "clinic"}, nil).Once()
+
+		err := svc.HandleAdminCreateOrganization()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), fmt.Sprintf(`"id":"%s"`, organizationID))
+	})
+}
+
+func TestUsersService_HandleAdminListOrganizations(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockUsersUC := usecase_mock.NewUsersUsecaseIface(t)
+
+	svc := rest.NewService(group, nil, nil, nil, nil, mockUsersUC)
+
+	t.Run("forbidden mapped from usecase", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/admin/organizations", nil)
+		ctx := e.NewContext(req, rec)
+
+		mockUsersUC.EXPECT().AdminListOrganizations(ctx.Request().Context()).
+			Return(nil, usecase.UsecaseError{ErrType: usecase.ErrForbidden}).Once()
+
+		err := svc.HandleAdminListOrganizations()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/admin/organizations", nil)
+		ctx := e.NewContext(req, rec)
+
+		mockUsersUC.EXPECT().AdminListOrganizations(ctx.Request().Context()).Return([]usecase.OrganizationOutput{
+			{ID: uuid.New(), Name: "a"},
+			{ID: uuid.New(), Name: "b"},
+		}, nil).Once()
+
+		err := svc.HandleAdminListOrganizations()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"name":"b"`)
+	})
+}
+
+func TestUsersService_HandleAdminSetUserOrganization(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockUsersUC := usecase_mock.NewUsersUsecaseIface(t)
+
+	svc := rest.NewService(group, nil, nil, nil, nil, mockUsersUC)
+
+	userID := uuid.New()
+	organizationID := uuid.New()
+	path := "/v1/admin/users/" + userID.String() + "/organization"
+
+	t.Run("invalid body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(`{,}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues(userID.String())
+
+		err := svc.HandleAdminSetUserOrganization()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("organization not found", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"organization_id":"%s","is_organization_admin":true}`, organizationID)
+		req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues(userID.String())
+
+		mockUsersUC.EXPECT().AdminSetUserOrganization(ctx.Request().Context(), usecase.AdminSetUserOrganizationInput{
+			UserID:              userID,
+			OrganizationID:      organizationID,
+			IsOrganizationAdmin: true,
+		}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrNotFound}).Once()
+
+		err := svc.HandleAdminSetUserOrganization()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("success removing the user from the organization", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues(userID.String())
+
+		mockUsersUC.EXPECT().AdminSetUserOrganization(ctx.Request().Context(), usecase.AdminSetUserOrganizationInput{
+			UserID: userID,
+		}).Return(&usecase.AdminUpdateUserOutput{Message: "ok"}, nil).Once()
+
+		err := svc.HandleAdminSetUserOrganization()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
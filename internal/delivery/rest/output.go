@@ -74,9 +74,38 @@ type ResetPasswordOutput struct {
 	Message string `json:"message"`
 }
 
-// LoginOutput output
+// LoginOutput output. When mfa_required or mfa_enrollment_required is true, token and refresh_token will be empty
+// and mfa_token must be used to either verify the second factor or enroll to two-factor authentication
 type LoginOutput struct {
-	Token string `json:"token"`
+	Token                 string `json:"token"`
+	RefreshToken          string `json:"refresh_token"`
+	MFARequired           bool   `json:"mfa_required,omitempty"`
+	MFAEnrollmentRequired bool   `json:"mfa_enrollment_required,omitempty"`
+	MFAToken              string `json:"mfa_token,omitempty"`
+}
+
+// MFAEnrollOutput output
+type MFAEnrollOutput struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri" example:"otpauth://totp/ATEC:user@mail.com?secret=SECRET&issuer=ATEC"`
+}
+
+// MFAConfirmEnrollmentOutput output. token and refresh_token only filled when enrolling using mfa_token
+type MFAConfirmEnrollmentOutput struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+	Token         string   `json:"token,omitempty"`
+	RefreshToken  string   `json:"refresh_token,omitempty"`
+}
+
+// MFADisableOutput output
+type MFADisableOutput struct {
+	Message string `json:"message" example:"two-factor authentication disabled"`
+}
+
+// RefreshTokenOutput output
+type RefreshTokenOutput struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 // RegisterChildOutput output
@@ -91,15 +120,17 @@ type UpdateChildernOutput struct {
 
 // GetMyChildernOutput output
 type GetMyChildernOutput struct {
-	ID             uuid.UUID   `json:"id"`
-	ParentUserID   uuid.UUID   `json:"parent_user_id"`
-	ParentUserName string      `json:"parent_user_name"`
-	DateOfBirth    time.Time   `json:"date_of_birth"`
-	Gender         bool        `json:"gender"`
-	Name           string      `json:"name"`
-	GuardianName   null.String `json:"guardian_name" swaggertype:"string"`
-	CreatedAt      time.Time   `json:"created_at"`
-	UpdatedAt      time.Time   `json:"updated_at"`
+	ID             uuid.UUID          `json:"id"`
+	ParentUserID   uuid.UUID          `json:"parent_user_id"`
+	ParentUserName string             `json:"parent_user_name"`
+	GuardianRole   model.GuardianRole `json:"guardian_role" enums:"owner,guardian"`
+	DateOfBirth    time.Time          `json:"date_of_birth"`
+	Gender         bool               `json:"gender"`
+	Name           string             `json:"name"`
+	GuardianName   null.String        `json:"guardian_name" swaggertype:"string"`
+	ArchivedAt     null.Time          `json:"archived_at"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
 }
 
 // CreatePackageOutput output
@@ -178,3 +209,197 @@ type GetTherapistOutput struct {
 type UpdateMyProfileOutput struct {
 	Message string `json:"message"`
 }
+
+// ChangePasswordOutput output
+type ChangePasswordOutput struct {
+	Message string `json:"message" example:"your password has been changed"`
+}
+
+// InitChangeEmailOutput output
+type InitChangeEmailOutput struct {
+	Message string `json:"message" example:"a confirmation link has been sent to the new email"`
+}
+
+// ConfirmChangeEmailOutput output
+type ConfirmChangeEmailOutput struct {
+	Message string `json:"message" example:"your email has been changed"`
+}
+
+// AdminUserOutput output
+type AdminUserOutput struct {
+	ID                  uuid.UUID   `json:"id"`
+	Username            string      `json:"username"`
+	Email               string      `json:"email"`
+	PhoneNumber         *string     `json:"phone_number"`
+	IsActive            bool        `json:"is_active"`
+	Roles               model.Roles `json:"roles"`
+	FailedLoginAttempts int         `json:"failed_login_attempts"`
+	LockedUntil         *time.Time  `json:"locked_until"`
+	OrganizationID      *uuid.UUID  `json:"organization_id"`
+	IsOrganizationAdmin bool        `json:"is_organization_admin"`
+	CreatedAt           time.Time   `json:"created_at"`
+	UpdatedAt           time.Time   `json:"updated_at"`
+}
+
+// AuditLogOutput output
+type AuditLogOutput struct {
+	ID         uuid.UUID             `json:"id"`
+	ActorID    uuid.UUID             `json:"actor_id"`
+	ActorRole  model.Roles           `json:"actor_role"`
+	Action     model.AuditAction     `json:"action" example:"child.search"`
+	TargetType model.AuditTargetType `json:"target_type" example:"child"`
+	TargetID   string                `json:"target_id"`
+	Metadata   model.AuditMetadata   `json:"metadata"`
+	IPAddress  string                `json:"ip_address" example:"203.0.113.10"`
+	UserAgent  string                `json:"user_agent" example:"Mozilla/5.0"`
+	CreatedAt  time.Time             `json:"created_at"`
+}
+
+// AdminUpdateUserOutput output
+type AdminUpdateUserOutput struct {
+	Message string `json:"message"`
+}
+
+// InviteTherapistOutput output
+type InviteTherapistOutput struct {
+	Message string `json:"message" example:"invitation sent"`
+}
+
+// UnlockAccountOutput output
+type UnlockAccountOutput struct {
+	Message string `json:"message" example:"your account has been unlocked"`
+}
+
+// RestoreAccountOutput output
+type RestoreAccountOutput struct {
+	Message string `json:"message" example:"your account has been restored"`
+}
+
+// RedeemTherapistInvitationOutput output
+type RedeemTherapistInvitationOutput struct {
+	Message string `json:"message" example:"your therapist account has been created"`
+}
+
+// InitPasswordlessLoginOutput output
+type InitPasswordlessLoginOutput struct {
+	Message string `json:"message" example:"ok"`
+}
+
+// PersonalAccessTokenOutput output
+type PersonalAccessTokenOutput struct {
+	ID         uuid.UUID                       `json:"id"`
+	Name       string                          `json:"name"`
+	Scopes     model.PersonalAccessTokenScopes `json:"scopes"`
+	ExpiresAt  time.Time                       `json:"expires_at"`
+	LastUsedAt *time.Time                      `json:"last_used_at"`
+	RevokedAt  *time.Time                      `json:"revoked_at"`
+	CreatedAt  time.Time                       `json:"created_at"`
+}
+
+// CreatePersonalAccessTokenOutput output
+type CreatePersonalAccessTokenOutput struct {
+	PersonalAccessTokenOutput
+	// Token is only shown once, store it securely
+	Token string `json:"token" example:"atec_pat_xxxxxxxx"`
+}
+
+// RevokePersonalAccessTokenOutput output
+type RevokePersonalAccessTokenOutput struct {
+	Message string `json:"message" example:"personal access token revoked"`
+}
+
+// SessionOutput output
+type SessionOutput struct {
+	ID         uuid.UUID  `json:"id"`
+	IPAddress  string     `json:"ip_address" example:"203.0.113.10"`
+	UserAgent  string     `json:"user_agent" example:"Mozilla/5.0"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	// Current is true for the session used to make the request
+	Current bool `json:"current"`
+}
+
+// RevokeMySessionOutput output
+type RevokeMySessionOutput struct {
+	Message string `json:"message" example:"session revoked"`
+}
+
+// DataExportOutput output
+type DataExportOutput struct {
+	ID     uuid.UUID              `json:"id"`
+	Status model.DataExportStatus `json:"status" enums:"pending,processing,completed,failed"`
+	// ExpiresAt is when the download link sent to the email expires, only set once the export is completed
+	ExpiresAt   *time.Time `json:"expires_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// TherapistAccessOutput output
+type TherapistAccessOutput struct {
+	ID          uuid.UUID       `json:"id"`
+	ChildID     uuid.UUID       `json:"child_id"`
+	TherapistID uuid.UUID       `json:"therapist_id"`
+	Scope       model.CareScope `json:"scope" enums:"read,submit"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// RevokeTherapistAccessOutput output
+type RevokeTherapistAccessOutput struct {
+	Message string `json:"message" example:"therapist access revoked"`
+}
+
+// OrganizationOutput output
+type OrganizationOutput struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// InviteGuardianOutput output
+type InviteGuardianOutput struct {
+	Message string `json:"message" example:"invitation sent"`
+}
+
+// GuardianOutput output
+type GuardianOutput struct {
+	ChildID   uuid.UUID          `json:"child_id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	Username  string             `json:"username"`
+	Role      model.GuardianRole `json:"role" enums:"owner,guardian"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// RemoveGuardianOutput output
+type RemoveGuardianOutput struct {
+	Message string `json:"message" example:"guardian removed"`
+}
+
+// InitiateChildTransferOutput output
+type InitiateChildTransferOutput struct {
+	Message string `json:"message" example:"transfer request sent"`
+}
+
+// ArchiveChildOutput output
+type ArchiveChildOutput struct {
+	Message string `json:"message" example:"child archived"`
+}
+
+// RestoreChildOutput output
+type RestoreChildOutput struct {
+	Message string `json:"message" example:"child restored"`
+}
+
+// DeleteChildOutput output
+type DeleteChildOutput struct {
+	Message string `json:"message" example:"child deleted"`
+}
+
+// AcceptChildTransferOutput output
+type AcceptChildTransferOutput struct {
+	ChildID              uuid.UUID `json:"child_id"`
+	PreviousParentUserID uuid.UUID `json:"previous_parent_user_id"`
+	ParentUserID         uuid.UUID `json:"parent_user_id"`
+}
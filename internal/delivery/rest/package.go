@@ -8,7 +8,7 @@ import (
 )
 
 // @Summary		Create new ATEC questionaire package
-// @Description	Create new ATEC questionaire package
+// @Description	Create new ATEC questionaire package. The package created by the organization admin is only usable within the organization
 // @Tags			ATEC Package
 // @Accept			json
 // @Produce		json
@@ -380,7 +380,7 @@ func (s *Service) HandleDeletePackage() echo.HandlerFunc {
 }
 
 // @Summary		Get all active packages
-// @Description	Get all active packages
+// @Description	Get all active packages usable by the requester. The organization scoped packages are only listed for the organization members
 // @Tags			ATEC Package
 // @Accept			json
 // @Produce		json
@@ -0,0 +1,134 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/usecase"
+)
+
+// @Summary		Request a one time code and a magic link to login without password
+// @Description	Only available for parent accounts. A 6 digit code and a magic link will be sent to the email,
+// @Description	both only valid for a short time and can only be used once. Requesting again will invalidate the previous ones.
+// @Description	The response is the same whether or not the email can be used to login without password,
+// @Description	only the email owner will be notified about it
+// @Tags			Authentication
+// @Accept			json
+// @Produce		json
+// @Param			init_passwordless_login_input	body		InitPasswordlessLoginInput									true	"the email of the account to login to"
+// @Success		200								{object}	StandardSuccessResponse{data=InitPasswordlessLoginOutput}	"Successful response"
+// @Failure		400								{object}	StandardErrorResponse										"Bad request"
+// @Failure		429								{object}	StandardErrorResponse										"Too many requests"
+// @Failure		500								{object}	StandardErrorResponse										"Internal Error"
+// @Router			/v1/auth/login/passwordless [post]
+func (s *Service) HandleInitPasswordlessLogin() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &InitPasswordlessLoginInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleInitPasswordlessLogin(c.Request().Context(), usecase.InitPasswordlessLoginInput{
+			Email: input.Email,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: InitPasswordlessLoginOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
+
+// @Summary		Login using the one time code sent to the email
+// @Description	Exchange the code from /v1/auth/login/passwordless with the login token.
+// @Description	The number of attempts is limited, and wrong, expired or used code all result in the same 401 response
+// @Tags			Authentication
+// @Accept			json
+// @Produce		json
+// @Param			verify_passwordless_login_input	body		VerifyPasswordlessLoginInput				true	"the email and the code"
+// @Success		200								{object}	StandardSuccessResponse{data=LoginOutput}	"Successful response"
+// @Failure		400								{object}	StandardErrorResponse						"Bad request"
+// @Failure		401								{object}	StandardErrorResponse						"Authentication Failed"
+// @Failure		429								{object}	StandardErrorResponse						"Too many login attempts"
+// @Failure		500								{object}	StandardErrorResponse						"Internal Error"
+// @Router			/v1/auth/login/passwordless/verify [post]
+func (s *Service) HandleVerifyPasswordlessLogin() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &VerifyPasswordlessLoginInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleVerifyPasswordlessLogin(c.Request().Context(), usecase.VerifyPasswordlessLoginInput{
+			Email: input.Email,
+			Code:  input.Code,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: LoginOutput{
+				Token:        output.Token,
+				RefreshToken: output.RefreshToken,
+			},
+		})
+	}
+}
+
+// @Summary		Login using the magic link sent to the email
+// @Description	Exchange the magic link token from /v1/auth/login/passwordless with the login token.
+// @Description	Expired or used magic link result in 401 response
+// @Tags			Authentication
+// @Accept			json
+// @Produce		json
+// @Param			magic_link_login_input	body		MagicLinkLoginInput							true	"magic link token from the email"
+// @Success		200						{object}	StandardSuccessResponse{data=LoginOutput}	"Successful response"
+// @Failure		400						{object}	StandardErrorResponse						"Bad request"
+// @Failure		401						{object}	StandardErrorResponse						"Authentication Failed"
+// @Failure		500						{object}	StandardErrorResponse						"Internal Error"
+// @Router			/v1/auth/login/magic-link [post]
+func (s *Service) HandleMagicLinkLogin() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &MagicLinkLoginInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleMagicLinkLogin(c.Request().Context(), usecase.MagicLinkLoginInput{
+			MagicLinkToken: input.MagicLinkToken,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: LoginOutput{
+				Token:        output.Token,
+				RefreshToken: output.RefreshToken,
+			},
+		})
+	}
+}
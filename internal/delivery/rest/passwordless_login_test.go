@@ -0,0 +1,211 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/delivery/rest"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	usecase_mock "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthService_HandleInitPasswordlessLogin(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	testCases := []struct {
+		name   string
+		body   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "invalid input",
+			body: `{,}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(_ echo.Context) {},
+		},
+		{
+			name: "too many requests",
+			body: `{"email":"parent@mail.com"}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleInitPasswordlessLogin(ectx.Request().Context(), usecase.InitPasswordlessLoginInput{
+					Email: "parent@mail.com",
+				}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrTooManyRequests}).Once()
+			},
+		},
+		{
+			name: "success",
+			body: `{"email":"parent@mail.com"}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"message":"ok"`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleInitPasswordlessLogin(ectx.Request().Context(), usecase.InitPasswordlessLoginInput{
+					Email: "parent@mail.com",
+				}).Return(&usecase.InitPasswordlessLoginOutput{Message: "ok"}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/auth/login/passwordless", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleInitPasswordlessLogin()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestAuthService_HandleVerifyPasswordlessLogin(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	testCases := []struct {
+		name   string
+		body   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "invalid input",
+			body: `{,}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(_ echo.Context) {},
+		},
+		{
+			name: "invalid code",
+			body: `{"email":"parent@mail.com","code":"123456"}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleVerifyPasswordlessLogin(ectx.Request().Context(), usecase.VerifyPasswordlessLoginInput{
+					Email: "parent@mail.com",
+					Code:  "123456",
+				}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrUnauthorized}).Once()
+			},
+		},
+		{
+			name: "success",
+			body: `{"email":"parent@mail.com","code":"123456"}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"token":"loginToken"`)
+				assert.Contains(t, rec.Body.String(), `"refresh_token":"refreshToken"`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleVerifyPasswordlessLogin(ectx.Request().Context(), usecase.VerifyPasswordlessLoginInput{
+					Email: "parent@mail.com",
+					Code:  "123456",
+				}).Return(&usecase.LoginOutput{Token: "loginToken", RefreshToken: "refreshToken"}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/auth/login/passwordless/verify", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleVerifyPasswordlessLogin()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestAuthService_HandleMagicLinkLogin(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	testCases := []struct {
+		name   string
+		body   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "invalid input",
+			body: `{,}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(_ echo.Context) {},
+		},
+		{
+			name: "expired magic link",
+			body: `{"magic_link_token":"token"}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleMagicLinkLogin(ectx.Request().Context(), usecase.MagicLinkLoginInput{
+					MagicLinkToken: "token",
+				}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrUnauthorized}).Once()
+			},
+		},
+		{
+			name: "success",
+			body: `{"magic_link_token":"token"}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"token":"loginToken"`)
+				assert.Contains(t, rec.Body.String(), `"refresh_token":"refreshToken"`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleMagicLinkLogin(ectx.Request().Context(), usecase.MagicLinkLoginInput{
+					MagicLinkToken: "token",
+				}).Return(&usecase.LoginOutput{Token: "loginToken", RefreshToken: "refreshToken"}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/auth/login/magic-link", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleMagicLinkLogin()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/usecase"
+)
+
+// @Summary		Create personal access token
+// @Description	Create a named, scoped and expiring token to be used by integrations in place of the login token.
+// @Description	Only available for therapist and administrator, and the admin scope can only be granted by administrator.
+// @Description	The token is only shown once in this response
+// @Tags			Users
+// @Accept			json
+// @Produce		json
+// @Security		TherapistLevelAuth
+// @Param			Authorization							header		string															true	"JWT Token"
+// @Param			create_personal_access_token_input	body		CreatePersonalAccessTokenInput									true	"token name, scopes and expiry"
+// @Success		200									{object}	StandardSuccessResponse{data=CreatePersonalAccessTokenOutput}	"Successful response"
+// @Failure		400									{object}	StandardErrorResponse											"Bad Request"
+// @Failure		401									{object}	StandardErrorResponse											"Unauthorized"
+// @Failure		403									{object}	StandardErrorResponse											"Forbidden"
+// @Failure		500									{object}	StandardErrorResponse											"Internal Error"
+// @Router			/v1/users/me/tokens [post]
+func (s *Service) HandleCreatePersonalAccessToken() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &CreatePersonalAccessTokenInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleCreatePersonalAccessToken(c.Request().Context(), usecase.CreatePersonalAccessTokenInput{
+			Name:          input.Name,
+			Scopes:        input.Scopes,
+			ExpiresInDays: input.ExpiresInDays,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: CreatePersonalAccessTokenOutput{
+				PersonalAccessTokenOutput: newPersonalAccessTokenOutput(output.PersonalAccessTokenOutput),
+				Token:                     output.Token,
+			},
+		})
+	}
+}
+
+// @Summary		List my personal access tokens
+// @Description	List all the personal access tokens owned by the user, including the revoked and expired ones
+// @Tags			Users
+// @Produce		json
+// @Security		TherapistLevelAuth
+// @Param			Authorization	header		string													true	"JWT Token"
+// @Success		200				{object}	StandardSuccessResponse{data=[]PersonalAccessTokenOutput}	"Successful response"
+// @Failure		401				{object}	StandardErrorResponse										"Unauthorized"
+// @Failure		500				{object}	StandardErrorResponse										"Internal Error"
+// @Router			/v1/users/me/tokens [get]
+func (s *Service) HandleListPersonalAccessTokens() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tokens, err := s.authUsecase.HandleListPersonalAccessTokens(c.Request().Context())
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		resp := make([]PersonalAccessTokenOutput, 0, len(tokens))
+		for _, token := range tokens {
+			resp = append(resp, newPersonalAccessTokenOutput(token))
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data:       resp,
+		})
+	}
+}
+
+// @Summary		Revoke personal access token
+// @Description	Revoke one of the user's personal access tokens. The token can no longer be used afterward
+// @Tags			Users
+// @Produce		json
+// @Security		TherapistLevelAuth
+// @Param			Authorization	header		string														true	"JWT Token"
+// @Param			token_id		path		string														true	"personal access token ID (UUID v4)"
+// @Success		200				{object}	StandardSuccessResponse{data=RevokePersonalAccessTokenOutput}	"Successful response"
+// @Failure		400				{object}	StandardErrorResponse											"Bad Request"
+// @Failure		401				{object}	StandardErrorResponse											"Unauthorized"
+// @Failure		404				{object}	StandardErrorResponse											"Not Found"
+// @Failure		500				{object}	StandardErrorResponse											"Internal Error"
+// @Router			/v1/users/me/tokens/{token_id} [delete]
+func (s *Service) HandleRevokePersonalAccessToken() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &RevokePersonalAccessTokenInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleRevokePersonalAccessToken(c.Request().Context(), usecase.RevokePersonalAccessTokenInput{
+			TokenID: input.TokenID,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: RevokePersonalAccessTokenOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
+
+func newPersonalAccessTokenOutput(token usecase.PersonalAccessTokenOutput) PersonalAccessTokenOutput {
+	return PersonalAccessTokenOutput{
+		ID:         token.ID,
+		Name:       token.Name,
+		Scopes:     token.Scopes,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		RevokedAt:  token.RevokedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
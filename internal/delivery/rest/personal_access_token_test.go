@@ -0,0 +1,216 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/delivery/rest"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	usecase_mock "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersService_HandleCreatePersonalAccessToken(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	testCases := []struct {
+		name   string
+		body   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "invalid input",
+			body: `{,}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(_ echo.Context) {},
+		},
+		{
+			name: "forbidden scope",
+			body: `{"name":"script","scopes":["admin"],"expires_in_days":30}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusForbidden, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleCreatePersonalAccessToken(ectx.Request().Context(), usecase.CreatePersonalAccessTokenInput{
+					Name:          "script",
+					Scopes:        []model.PersonalAccessTokenScope{model.PersonalAccessTokenScopeAdmin},
+					ExpiresInDays: 30,
+				}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrForbidden}).Once()
+			},
+		},
+		{
+			name: "success",
+			body: `{"name":"script","scopes":["children"],"expires_in_days":30}`,
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"token":"atec_pat_secret"`)
+				assert.Contains(t, rec.Body.String(), `"scopes":["children"]`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleCreatePersonalAccessToken(ectx.Request().Context(), usecase.CreatePersonalAccessTokenInput{
+					Name:          "script",
+					Scopes:        []model.PersonalAccessTokenScope{model.PersonalAccessTokenScopeChildren},
+					ExpiresInDays: 30,
+				}).Return(&usecase.CreatePersonalAccessTokenOutput{
+					PersonalAccessTokenOutput: usecase.PersonalAccessTokenOutput{
+						ID:        uuid.New(),
+						Name:      "script",
+						Scopes:    model.PersonalAccessTokenScopes{model.PersonalAccessTokenScopeChildren},
+						ExpiresAt: time.Now().AddDate(0, 0, 30),
+					},
+					Token: "atec_pat_secret",
+				}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/users/me/tokens", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleCreatePersonalAccessToken()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestUsersService_HandleListPersonalAccessTokens(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	tokenID := uuid.New()
+
+	testCases := []struct {
+		name   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "internal error",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleListPersonalAccessTokens(ectx.Request().Context()).
+					Return(nil, usecase.UsecaseError{ErrType: usecase.ErrInternal}).Once()
+			},
+		},
+		{
+			name: "success",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), tokenID.String())
+				assert.NotContains(t, rec.Body.String(), `"token"`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleListPersonalAccessTokens(ectx.Request().Context()).
+					Return([]usecase.PersonalAccessTokenOutput{{ID: tokenID, Name: "script"}}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/users/me/tokens", nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleListPersonalAccessTokens()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestUsersService_HandleRevokePersonalAccessToken(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	tokenID := uuid.New()
+
+	testCases := []struct {
+		name    string
+		tokenID string
+		expect  func(rec *httptest.ResponseRecorder)
+		mockFn  func(ectx echo.Context)
+	}{
+		{
+			name:    "invalid token id",
+			tokenID: "invalid",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(_ echo.Context) {},
+		},
+		{
+			name:    "not found",
+			tokenID: tokenID.String(),
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleRevokePersonalAccessToken(ectx.Request().Context(), usecase.RevokePersonalAccessTokenInput{
+					TokenID: tokenID,
+				}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrNotFound}).Once()
+			},
+		},
+		{
+			name:    "success",
+			tokenID: tokenID.String(),
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"message":"personal access token revoked"`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleRevokePersonalAccessToken(ectx.Request().Context(), usecase.RevokePersonalAccessTokenInput{
+					TokenID: tokenID,
+				}).Return(&usecase.RevokePersonalAccessTokenOutput{Message: "personal access token revoked"}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+			ectx.SetPath("/v1/users/me/tokens/:token_id")
+			ectx.SetParamNames("token_id")
+			ectx.SetParamValues(tc.tokenID)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleRevokePersonalAccessToken()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
@@ -98,7 +98,8 @@ func (s *Service) HandleDownloadQuestionnaireResult() echo.HandlerFunc {
 }
 
 // @Summary		Search questionnaire result
-// @Description	Search through all the submitted ATEC questionnaires to the systems
+// @Description	Search through all the submitted ATEC questionnaires to the systems. Therapist only finds the results of the children
+// @Description	assigned to them, while the organization admin finds the results of the organization. Only the administrator can search across the organizations
 // @Tags			Questionnaire
 // @Accept			json
 // @Produce		json
@@ -121,12 +122,13 @@ func (s *Service) HandleSearchQUestionnaireResults() echo.HandlerFunc {
 		}
 
 		output, err := s.questionnaireUsecase.HandleSearchQuestionnaireResult(c.Request().Context(), usecase.SearchQuestionnaireResultInput{
-			ID:        input.ResultID,
-			PackageID: input.PackageID,
-			ChildID:   input.ChildID,
-			CreatedBy: input.CreatedByID,
-			Limit:     input.Limit,
-			Offset:    input.Offset,
+			ID:             input.ResultID,
+			PackageID:      input.PackageID,
+			ChildID:        input.ChildID,
+			CreatedBy:      input.CreatedByID,
+			OrganizationID: input.OrganizationID,
+			Limit:          input.Limit,
+			Offset:         input.Offset,
 		})
 
 		if err != nil {
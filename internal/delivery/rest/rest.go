@@ -4,6 +4,7 @@ package rest
 import (
 	"github.com/labstack/echo/v4"
 	_ "github.com/luckyAkbar/atec/docs" // required by swaggo
+	"github.com/luckyAkbar/atec/internal/model"
 	"github.com/luckyAkbar/atec/internal/usecase"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
@@ -56,41 +57,104 @@ func NewService(
 }
 
 func (s *Service) initV1Routes() {
+	s.v1.Use(s.ClientInfoMiddleware())
+
 	s.v1.POST("/auth/signup", s.HandleSignUp())
 	s.v1.POST("/auth/signup/resend", s.HandleResendSignupVerification())
+	s.v1.POST("/auth/signup/therapist", s.HandleRedeemTherapistInvitation())
 	s.v1.GET("/auth/verify", s.HandleVerifyAccount())
 	s.v1.POST("/auth/login", s.HandleLogin())
+	s.v1.POST("/auth/login/mfa", s.HandleMFAVerifyLogin())
+	s.v1.POST("/auth/login/passwordless", s.HandleInitPasswordlessLogin())
+	s.v1.POST("/auth/login/passwordless/verify", s.HandleVerifyPasswordlessLogin())
+	s.v1.POST("/auth/login/magic-link", s.HandleMagicLinkLogin())
+	s.v1.POST("/auth/unlock", s.HandleUnlockAccount())
+	s.v1.POST("/auth/email/confirm", s.HandleConfirmChangeEmail())
+	s.v1.POST("/auth/mfa/enroll", s.HandleMFAEnroll(), s.AuthMiddleware(true))
+	s.v1.POST("/auth/mfa/enroll/confirm", s.HandleMFAConfirmEnrollment(), s.AuthMiddleware(true))
+	s.v1.DELETE("/auth/mfa", s.HandleMFADisable(), s.AuthMiddleware(false))
+	s.v1.POST("/auth/refresh", s.HandleRefreshToken())
+	s.v1.POST("/auth/logout", s.HandleLogout(), s.AuthMiddleware(false))
+	s.v1.POST("/auth/logout/all", s.HandleLogoutAll(), s.AuthMiddleware(false))
 	s.v1.PATCH("/auth/password", s.HandleInitResetPassword())
 	s.v1.POST("/auth/password", s.HandleResetPassword())
 	s.v1.GET("/auth/password", s.HandleRenderChangePasswordPage())
 	s.v1.DELETE("/auth/accounts", s.HandleDeleteAccount(), s.AuthMiddleware(false))
+	s.v1.POST("/auth/accounts/restore", s.HandleRestoreAccount())
+
+	// endpoints below also accept the personal access token granted with the matching scope,
+	// while the account and security related endpoints above only accept the login token
+	packagesAuth := s.scopedAuthMiddlewareFactory(model.PersonalAccessTokenScopePackages)
+	childrenAuth := s.scopedAuthMiddlewareFactory(model.PersonalAccessTokenScopeChildren)
+	questionnairesAuth := s.scopedAuthMiddlewareFactory(model.PersonalAccessTokenScopeQuestionnaires)
+	usersAuth := s.scopedAuthMiddlewareFactory(model.PersonalAccessTokenScopeUsers)
+	adminAuth := s.scopedAuthMiddlewareFactory(model.PersonalAccessTokenScopeAdmin)
 
-	s.v1.POST("/atec/packages", s.HandleCreatePackage(), s.AuthMiddleware(false))
-	s.v1.PUT("/atec/packages/:package_id", s.HandleUpdatePackage(), s.AuthMiddleware(false))
-	s.v1.PATCH("/atec/packages/:package_id", s.HandleActivationPackage(), s.AuthMiddleware(false))
-	s.v1.DELETE("/atec/packages/:package_id", s.HandleDeletePackage(), s.AuthMiddleware(false))
-	s.v1.GET("/atec/packages/active", s.HandleSearchActivePackage())
+	s.v1.POST("/atec/packages", s.HandleCreatePackage(), packagesAuth(false))
+	s.v1.PUT("/atec/packages/:package_id", s.HandleUpdatePackage(), packagesAuth(false))
+	s.v1.PATCH("/atec/packages/:package_id", s.HandleActivationPackage(), packagesAuth(false))
+	s.v1.DELETE("/atec/packages/:package_id", s.HandleDeletePackage(), packagesAuth(false))
+	s.v1.GET("/atec/packages/active", s.HandleSearchActivePackage(), packagesAuth(true))
 
-	s.v1.POST("/childern", s.HandleRegisterChildern(), s.AuthMiddleware(false))
-	s.v1.PUT("/childern/:child_id", s.HandleUpdateChildern(), s.AuthMiddleware(false))
-	s.v1.GET("/childern", s.HandleGetMyChildern(), s.AuthMiddleware(false))
-	s.v1.GET("/childern/search", s.HandleSearchChildern(), s.AuthMiddleware(false))
-	s.v1.GET("/childern/:child_id/stats", s.HandleGetChildStats(), s.AuthMiddleware(false))
+	s.v1.POST("/childern", s.HandleRegisterChildern(), childrenAuth(false))
+	s.v1.PUT("/childern/:child_id", s.HandleUpdateChildern(), childrenAuth(false))
+	s.v1.GET("/childern", s.HandleGetMyChildern(), childrenAuth(false))
+	s.v1.GET("/childern/search", s.HandleSearchChildern(), childrenAuth(false))
+	s.v1.GET("/childern/:child_id/stats", s.HandleGetChildStats(), childrenAuth(false))
+	s.v1.POST("/childern/:child_id/guardians/invitations", s.HandleInviteGuardian(), childrenAuth(false))
+	s.v1.POST("/childern/guardians/invitations/accept", s.HandleAcceptGuardianInvitation(), s.AuthMiddleware(false))
+	s.v1.GET("/childern/:child_id/guardians", s.HandleListGuardians(), childrenAuth(false))
+	s.v1.DELETE("/childern/:child_id/guardians/:user_id", s.HandleRemoveGuardian(), childrenAuth(false))
+	s.v1.POST("/childern/:child_id/transfers", s.HandleInitiateChildTransfer(), childrenAuth(false))
+	s.v1.POST("/childern/transfers/accept", s.HandleAcceptChildTransfer(), s.AuthMiddleware(false))
+	s.v1.POST("/childern/:child_id/archive", s.HandleArchiveChild(), childrenAuth(false))
+	s.v1.POST("/childern/:child_id/restore", s.HandleRestoreChild(), childrenAuth(false))
+	s.v1.DELETE("/childern/:child_id", s.HandleDeleteChild(), childrenAuth(false))
 
-	s.v1.GET("/atec/questionnaires", s.HandleGetATECQuestionaire())
-	s.v1.POST("/atec/questionnaires", s.HandleSubmitQuestionnaire(), s.AuthMiddleware(true))
+	s.v1.GET("/atec/questionnaires", s.HandleGetATECQuestionaire(), questionnairesAuth(true))
+	s.v1.POST("/atec/questionnaires", s.HandleSubmitQuestionnaire(), questionnairesAuth(true))
 	s.v1.GET(
 		"/atec/questionnaires/results/:result_id",
-		s.HandleDownloadQuestionnaireResult(), s.AuthMiddleware(true),
+		s.HandleDownloadQuestionnaireResult(), questionnairesAuth(true),
 	)
-	s.v1.GET("/atec/questionnaires/results", s.HandleSearchQUestionnaireResults(), s.AuthMiddleware(false))
-	s.v1.GET("/atec/questionnaires/results/my", s.HandleGetMyQUestionnaireResults(), s.AuthMiddleware(false))
+	s.v1.GET("/atec/questionnaires/results", s.HandleSearchQUestionnaireResults(), questionnairesAuth(false))
+	s.v1.GET("/atec/questionnaires/results/my", s.HandleGetMyQUestionnaireResults(), questionnairesAuth(false))
 
 	// users endpoints
-	s.v1.GET("/users/me", s.HandleGetMyProfile(), s.AuthMiddleware(false))
-	s.v1.PATCH("/users/me", s.HandleUpdateMyProfile(), s.AuthMiddleware(false))
+	s.v1.GET("/users/me", s.HandleGetMyProfile(), usersAuth(false))
+	s.v1.PATCH("/users/me", s.HandleUpdateMyProfile(), usersAuth(false))
+	s.v1.PUT("/users/me/password", s.HandleChangeMyPassword(), s.AuthMiddleware(false))
+	s.v1.PUT("/users/me/email", s.HandleInitChangeMyEmail(), s.AuthMiddleware(false))
+
+	s.v1.GET("/users/me/sessions", s.HandleListMySessions(), s.AuthMiddleware(false))
+	s.v1.DELETE("/users/me/sessions/:session_id", s.HandleRevokeMySession(), s.AuthMiddleware(false))
+
+	s.v1.POST("/users/me/tokens", s.HandleCreatePersonalAccessToken(), s.AuthMiddleware(false))
+	s.v1.GET("/users/me/tokens", s.HandleListPersonalAccessTokens(), s.AuthMiddleware(false))
+	s.v1.DELETE("/users/me/tokens/:token_id", s.HandleRevokePersonalAccessToken(), s.AuthMiddleware(false))
+
+	s.v1.POST("/users/me/exports", s.HandleRequestDataExport(), s.AuthMiddleware(false))
+	s.v1.GET("/users/me/exports", s.HandleListMyDataExports(), s.AuthMiddleware(false))
+	s.v1.GET("/users/exports/download", s.HandleDownloadDataExport())
+
+	s.v1.GET("/users/therapists", s.HandleGetTherapists(), usersAuth(false))
+	s.v1.GET("/users/therapists/access", s.HandleListGrantedTherapistAccess(), usersAuth(false))
+	s.v1.PUT("/users/therapists/:therapist_id/access", s.HandleGrantTherapistAccess(), usersAuth(false))
+	s.v1.DELETE("/users/therapists/:therapist_id/access/:child_id", s.HandleRevokeTherapistAccess(), usersAuth(false))
 
-	s.v1.GET("/users/therapists", s.HandleGetTherapists(), s.AuthMiddleware(false))
+	// admin endpoints
+	s.v1.GET("/admin/users", s.HandleAdminSearchUsers(), adminAuth(false))
+	s.v1.PATCH("/admin/users/:user_id/role", s.HandleAdminChangeUserRole(), adminAuth(false))
+	s.v1.PATCH("/admin/users/:user_id/activation", s.HandleAdminChangeUserActivation(), adminAuth(false))
+	s.v1.PATCH("/admin/users/:user_id/mfa", s.HandleAdminSetMFARequirement(), adminAuth(false))
+	s.v1.POST("/admin/users/:user_id/unlock", s.HandleAdminUnlockUser(), adminAuth(false))
+	s.v1.POST("/admin/users/:user_id/password/reset", s.HandleAdminForceResetPassword(), adminAuth(false))
+	s.v1.POST("/admin/users/:user_id/deletion/cancel", s.HandleAdminCancelAccountDeletion(), adminAuth(false))
+	s.v1.POST("/admin/therapists/invitations", s.HandleInviteTherapist(), adminAuth(false))
+	s.v1.GET("/admin/audit-logs", s.HandleAdminSearchAuditLogs(), adminAuth(false))
+	s.v1.POST("/admin/organizations", s.HandleAdminCreateOrganization(), adminAuth(false))
+	s.v1.GET("/admin/organizations", s.HandleAdminListOrganizations(), adminAuth(false))
+	s.v1.PUT("/admin/users/:user_id/organization", s.HandleAdminSetUserOrganization(), adminAuth(false))
 
 	s.v1.GET("/swagger/*", echoSwagger.WrapHandler)
 }
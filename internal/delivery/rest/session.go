@@ -0,0 +1,87 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/usecase"
+)
+
+// @Summary		List my active sessions
+// @Description	List every device currently logged in to the user's account, along with the ip address and user agent used to login
+// @Tags			Users
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization	header		string										true	"JWT Token"
+// @Success		200				{object}	StandardSuccessResponse{data=[]SessionOutput}	"Successful response"
+// @Failure		401				{object}	StandardErrorResponse							"Unauthorized"
+// @Failure		500				{object}	StandardErrorResponse							"Internal Error"
+// @Router			/v1/users/me/sessions [get]
+func (s *Service) HandleListMySessions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		sessions, err := s.authUsecase.HandleListMySessions(c.Request().Context())
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		resp := make([]SessionOutput, 0, len(sessions))
+		for _, session := range sessions {
+			resp = append(resp, SessionOutput{
+				ID:         session.ID,
+				IPAddress:  session.IPAddress,
+				UserAgent:  session.UserAgent,
+				CreatedAt:  session.CreatedAt,
+				LastSeenAt: session.LastSeenAt,
+				ExpiresAt:  session.ExpiresAt,
+				Current:    session.Current,
+			})
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data:       resp,
+		})
+	}
+}
+
+// @Summary		Revoke one of my sessions
+// @Description	Logout the device using the session. Both the access token and refresh token from that session will no longer be usable
+// @Tags			Users
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization	header		string											true	"JWT Token"
+// @Param			session_id		path		string											true	"session ID (UUID v4)"
+// @Success		200				{object}	StandardSuccessResponse{data=RevokeMySessionOutput}	"Successful response"
+// @Failure		400				{object}	StandardErrorResponse								"Bad Request"
+// @Failure		401				{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		404				{object}	StandardErrorResponse								"Not Found"
+// @Failure		500				{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/users/me/sessions/{session_id} [delete]
+func (s *Service) HandleRevokeMySession() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &RevokeMySessionInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleRevokeMySession(c.Request().Context(), usecase.RevokeMySessionInput{
+			SessionID: input.SessionID,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: RevokeMySessionOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec/internal/delivery/rest"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	usecase_mock "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersService_HandleListMySessions(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	sessionID := uuid.New()
+
+	testCases := []struct {
+		name   string
+		expect func(rec *httptest.ResponseRecorder)
+		mockFn func(ectx echo.Context)
+	}{
+		{
+			name: "unauthorized",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleListMySessions(ectx.Request().Context()).
+					Return(nil, usecase.UsecaseError{ErrType: usecase.ErrUnauthorized}).Once()
+			},
+		},
+		{
+			name: "success",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), sessionID.String())
+				assert.Contains(t, rec.Body.String(), `"ip_address":"203.0.113.10"`)
+				assert.Contains(t, rec.Body.String(), `"user_agent":"Mozilla/5.0"`)
+				assert.Contains(t, rec.Body.String(), `"current":true`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleListMySessions(ectx.Request().Context()).
+					Return([]usecase.SessionOutput{{
+						ID:        sessionID,
+						IPAddress: "203.0.113.10",
+						UserAgent: "Mozilla/5.0",
+						CreatedAt: time.Now(),
+						ExpiresAt: time.Now().Add(time.Hour),
+						Current:   true,
+					}}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/users/me/sessions", nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleListMySessions()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
+
+func TestUsersService_HandleRevokeMySession(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUsecase := usecase_mock.NewAuthUsecaseIface(t)
+	service := rest.NewService(group, mockAuthUsecase, nil, nil, nil, nil)
+
+	sessionID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		sessionID string
+		expect    func(rec *httptest.ResponseRecorder)
+		mockFn    func(ectx echo.Context)
+	}{
+		{
+			name:      "invalid session id",
+			sessionID: "invalid",
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+			mockFn: func(_ echo.Context) {},
+		},
+		{
+			name:      "not found",
+			sessionID: sessionID.String(),
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleRevokeMySession(ectx.Request().Context(), usecase.RevokeMySessionInput{
+					SessionID: sessionID,
+				}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrNotFound}).Once()
+			},
+		},
+		{
+			name:      "success",
+			sessionID: sessionID.String(),
+			expect: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"message":"session revoked"`)
+			},
+			mockFn: func(ectx echo.Context) {
+				mockAuthUsecase.EXPECT().HandleRevokeMySession(ectx.Request().Context(), usecase.RevokeMySessionInput{
+					SessionID: sessionID,
+				}).Return(&usecase.RevokeMySessionOutput{Message: "session revoked"}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+			ectx.SetPath("/v1/users/me/sessions/:session_id")
+			ectx.SetParamNames("session_id")
+			ectx.SetParamValues(tc.sessionID)
+
+			tc.mockFn(ectx)
+
+			err := service.HandleRevokeMySession()(ectx)
+			require.NoError(t, err)
+			tc.expect(rec)
+		})
+	}
+}
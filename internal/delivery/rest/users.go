@@ -125,3 +125,425 @@ func (s *Service) HandleUpdateMyProfile() echo.HandlerFunc {
 		})
 	}
 }
+
+// @Summary		Change my password
+// @Description	Allow authenticated user to change their password by supplying the current one. The new password must satisfy the password policy.
+// @Description	Once changed, all the user's other sessions and outstanding reset password links are revoked, and a confirmation email is sent.
+// @Tags			Users
+// @Accept			json
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization			header		string												true	"JWT Token"
+// @Param			change_password_input	body		ChangePasswordInput									true	"Change password input"
+// @Success		200						{object}	StandardSuccessResponse{data=ChangePasswordOutput}	"Successful response"
+// @Failure		400						{object}	StandardErrorResponse								"Bad Request"
+// @Failure		401						{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		404						{object}	StandardErrorResponse								"Not Found"
+// @Failure		500						{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/users/me/password [put]
+func (s *Service) HandleChangeMyPassword() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &ChangePasswordInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleChangePassword(c.Request().Context(), usecase.ChangePasswordInput{
+			CurrentPassword: input.CurrentPassword,
+			NewPassword:     input.NewPassword,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: ChangePasswordOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
+
+// @Summary		Change my email
+// @Description	Allow authenticated user to change their email by supplying the current password. A confirmation link is sent to the new email
+// @Description	and a notice is sent to the current email. The email is only changed once the link is confirmed before it expires.
+// @Tags			Users
+// @Accept			json
+// @Produce		json
+// @Security		ParentLevelAuth
+// @Param			Authorization			header		string												true	"JWT Token"
+// @Param			change_email_input		body		InitChangeEmailInput								true	"Change email input"
+// @Success		200						{object}	StandardSuccessResponse{data=InitChangeEmailOutput}	"Successful response"
+// @Failure		400						{object}	StandardErrorResponse								"Bad Request or the email has been used by another account"
+// @Failure		401						{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		404						{object}	StandardErrorResponse								"Not Found"
+// @Failure		429						{object}	StandardErrorResponse								"Too many requests"
+// @Failure		500						{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/users/me/email [put]
+func (s *Service) HandleInitChangeMyEmail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &InitChangeEmailInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleInitChangeEmail(c.Request().Context(), usecase.InitChangeEmailInput{
+			NewEmail:        input.NewEmail,
+			CurrentPassword: input.CurrentPassword,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: InitChangeEmailOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
+
+// @Summary		Search users
+// @Description	Allow administrator to search and paginate all registered users
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		AdministratorLevelAuth
+// @Param			Authorization			header		string											true	"JWT Token"
+// @Param			admin_search_users_input	query		AdminSearchUsersInput							true	"search parameters"
+// @Success		200						{object}	StandardSuccessResponse{data=[]AdminUserOutput}	"Successful response"
+// @Failure		400						{object}	StandardErrorResponse							"Bad Request"
+// @Failure		401						{object}	StandardErrorResponse							"Unauthorized"
+// @Failure		403						{object}	StandardErrorResponse							"Forbidden"
+// @Failure		404						{object}	StandardErrorResponse							"Not Found"
+// @Failure		500						{object}	StandardErrorResponse							"Internal Error"
+// @Router			/v1/admin/users [get]
+func (s *Service) HandleAdminSearchUsers() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &AdminSearchUsersInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		users, err := s.usersUsecase.AdminSearchUsers(c.Request().Context(), usecase.AdminSearchUsersInput{
+			Email:          input.Email,
+			Username:       input.Username,
+			Role:           input.Role,
+			IsActive:       input.IsActive,
+			OrganizationID: input.OrganizationID,
+			Limit:          input.Limit,
+			Offset:         input.Offset,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		resp := make([]AdminUserOutput, 0, len(users))
+		for _, user := range users {
+			resp = append(resp, AdminUserOutput{
+				ID:                  user.ID,
+				Username:            user.Username,
+				Email:               user.Email,
+				PhoneNumber:         user.PhoneNumber,
+				IsActive:            user.IsActive,
+				Roles:               user.Roles,
+				FailedLoginAttempts: user.FailedLoginAttempts,
+				LockedUntil:         user.LockedUntil,
+				OrganizationID:      user.OrganizationID,
+				IsOrganizationAdmin: user.IsOrganizationAdmin,
+				CreatedAt:           user.CreatedAt,
+				UpdatedAt:           user.UpdatedAt,
+			})
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data:       resp,
+		})
+	}
+}
+
+// @Summary		Change user role
+// @Description	Allow administrator to change other user's role. All the user's sessions will be revoked
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		AdministratorLevelAuth
+// @Param			Authorization				header		string												true	"JWT Token"
+// @Param			user_id						path		string												true	"user ID (UUID v4)"
+// @Param			admin_change_user_role_input	body		AdminChangeUserRoleInput							true	"new role"
+// @Success		200							{object}	StandardSuccessResponse{data=AdminUpdateUserOutput}	"Successful response"
+// @Failure		400							{object}	StandardErrorResponse								"Bad Request"
+// @Failure		401							{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		403							{object}	StandardErrorResponse								"Forbidden"
+// @Failure		404							{object}	StandardErrorResponse								"Not Found"
+// @Failure		500							{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/admin/users/{user_id}/role [patch]
+func (s *Service) HandleAdminChangeUserRole() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &AdminChangeUserRoleInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.usersUsecase.AdminChangeUserRole(c.Request().Context(), usecase.AdminChangeUserRoleInput{
+			UserID: input.UserID,
+			Role:   input.Role,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: AdminUpdateUserOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
+
+// @Summary		Activate or deactivate user account
+// @Description	Allow administrator to change other user's active status. Deactivating will revoke all the user's sessions
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		AdministratorLevelAuth
+// @Param			Authorization						header		string												true	"JWT Token"
+// @Param			user_id								path		string												true	"user ID (UUID v4)"
+// @Param			admin_change_user_activation_input	body		AdminChangeUserActivationInput						true	"active status"
+// @Success		200									{object}	StandardSuccessResponse{data=AdminUpdateUserOutput}	"Successful response"
+// @Failure		400									{object}	StandardErrorResponse								"Bad Request"
+// @Failure		401									{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		403									{object}	StandardErrorResponse								"Forbidden"
+// @Failure		404									{object}	StandardErrorResponse								"Not Found"
+// @Failure		500									{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/admin/users/{user_id}/activation [patch]
+func (s *Service) HandleAdminChangeUserActivation() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &AdminChangeUserActivationInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.usersUsecase.AdminChangeUserActivation(c.Request().Context(), usecase.AdminChangeUserActivationInput{
+			UserID:   input.UserID,
+			IsActive: input.IsActive,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: AdminUpdateUserOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
+
+// @Summary		Require or unrequire two-factor authentication
+// @Description	Allow administrator to require therapist or administrator account to use two-factor authentication.
+// @Description	When required but not yet enrolled, all the user's sessions are revoked and the enrollment is enforced on next login
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		AdministratorLevelAuth
+// @Param			Authorization						header		string												true	"JWT Token"
+// @Param			user_id								path		string												true	"user ID (UUID v4)"
+// @Param			admin_set_mfa_requirement_input		body		AdminSetMFARequirementInput							true	"mfa requirement"
+// @Success		200									{object}	StandardSuccessResponse{data=AdminUpdateUserOutput}	"Successful response"
+// @Failure		400									{object}	StandardErrorResponse								"Bad Request"
+// @Failure		401									{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		403									{object}	StandardErrorResponse								"Forbidden"
+// @Failure		404									{object}	StandardErrorResponse								"Not Found"
+// @Failure		500									{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/admin/users/{user_id}/mfa [patch]
+func (s *Service) HandleAdminSetMFARequirement() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &AdminSetMFARequirementInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.usersUsecase.AdminSetMFARequirement(c.Request().Context(), usecase.AdminSetMFARequirementInput{
+			UserID:   input.UserID,
+			Required: input.Required,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: AdminUpdateUserOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
+
+// @Summary		Unlock user account
+// @Description	Allow administrator to unlock account locked due to too many failed login attempts and reset the failed login counter
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		AdministratorLevelAuth
+// @Param			Authorization	header		string												true	"JWT Token"
+// @Param			user_id			path		string												true	"user ID (UUID v4)"
+// @Success		200				{object}	StandardSuccessResponse{data=AdminUpdateUserOutput}	"Successful response"
+// @Failure		400				{object}	StandardErrorResponse								"Bad Request"
+// @Failure		401				{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		403				{object}	StandardErrorResponse								"Forbidden"
+// @Failure		404				{object}	StandardErrorResponse								"Not Found"
+// @Failure		500				{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/admin/users/{user_id}/unlock [post]
+func (s *Service) HandleAdminUnlockUser() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &AdminUnlockUserInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.usersUsecase.AdminUnlockUser(c.Request().Context(), usecase.AdminUnlockUserInput{
+			UserID: input.UserID,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: AdminUpdateUserOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
+
+// @Summary		Cancel account deletion
+// @Description	Allow administrator to cancel the scheduled deletion of other user's account, restoring the account along with its children and results
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		AdministratorLevelAuth
+// @Param			Authorization	header		string												true	"JWT Token"
+// @Param			user_id			path		string												true	"user ID (UUID v4)"
+// @Success		200				{object}	StandardSuccessResponse{data=AdminUpdateUserOutput}	"Successful response"
+// @Failure		400				{object}	StandardErrorResponse								"Bad Request or the account is not scheduled for deletion"
+// @Failure		401				{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		403				{object}	StandardErrorResponse								"Forbidden"
+// @Failure		404				{object}	StandardErrorResponse								"Not Found"
+// @Failure		500				{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/admin/users/{user_id}/deletion/cancel [post]
+func (s *Service) HandleAdminCancelAccountDeletion() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &AdminCancelAccountDeletionInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleAdminCancelAccountDeletion(
+			c.Request().Context(),
+			usecase.AdminCancelAccountDeletionInput{
+				UserID: input.UserID,
+			},
+		)
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: AdminUpdateUserOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
+
+// @Summary		Force user password reset
+// @Description	Allow administrator to invalidate other user's password and sessions, then send a reset password email to the user
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Security		AdministratorLevelAuth
+// @Param			Authorization	header		string												true	"JWT Token"
+// @Param			user_id			path		string												true	"user ID (UUID v4)"
+// @Success		200				{object}	StandardSuccessResponse{data=AdminUpdateUserOutput}	"Successful response"
+// @Failure		400				{object}	StandardErrorResponse								"Bad Request"
+// @Failure		401				{object}	StandardErrorResponse								"Unauthorized"
+// @Failure		403				{object}	StandardErrorResponse								"Forbidden"
+// @Failure		404				{object}	StandardErrorResponse								"Not Found"
+// @Failure		500				{object}	StandardErrorResponse								"Internal Error"
+// @Router			/v1/admin/users/{user_id}/password/reset [post]
+func (s *Service) HandleAdminForceResetPassword() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &AdminForceResetPasswordInput{}
+		if err := c.Bind(input); err != nil {
+			return c.JSON(http.StatusBadRequest, StandardErrorResponse{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: "failed to parse input",
+				ErrorCode:    http.StatusText(http.StatusBadRequest),
+			})
+		}
+
+		output, err := s.authUsecase.HandleAdminForceResetPassword(c.Request().Context(), usecase.AdminForceResetPasswordInput{
+			UserID: input.UserID,
+		})
+		if err != nil {
+			return UsecaseErrorToRESTResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, StandardSuccessResponse{
+			StatusCode: http.StatusOK,
+			Message:    http.StatusText(http.StatusOK),
+			Data: AdminUpdateUserOutput{
+				Message: output.Message,
+			},
+		})
+	}
+}
@@ -3,6 +3,7 @@ package rest_test
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -12,6 +13,7 @@ import (
 	"github.com/luckyAkbar/atec/internal/usecase"
 	usecase_mock "github.com/luckyAkbar/atec/mocks/internal_/usecase"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -95,3 +97,440 @@ func TestUsersService_HandleGetTherapists(t *testing.T) {
 		assert.Equal(t, "application/json", rec.Header().Get(echo.HeaderContentType))
 	})
 }
+
+func TestUsersService_HandleAdminSearchUsers(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockUsersUC := usecase_mock.NewUsersUsecaseIface(t)
+
+	svc := rest.NewService(group, nil, nil, nil, nil, mockUsersUC)
+
+	t.Run("invalid query params", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/admin/users?limit=abc", nil)
+		ctx := e.NewContext(req, rec)
+
+		err := svc.HandleAdminSearchUsers()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("forbidden mapped from usecase", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/admin/users?limit=10", nil)
+		ctx := e.NewContext(req, rec)
+
+		mockUsersUC.EXPECT().AdminSearchUsers(ctx.Request().Context(), usecase.AdminSearchUsersInput{Limit: 10}).
+			Return(nil, usecase.UsecaseError{ErrType: usecase.ErrForbidden}).Once()
+
+		err := svc.HandleAdminSearchUsers()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/admin/users?limit=10&offset=5&role=therapist&is_active=true", nil)
+		ctx := e.NewContext(req, rec)
+
+		isActive := true
+		mockUsersUC.EXPECT().AdminSearchUsers(ctx.Request().Context(), usecase.AdminSearchUsersInput{
+			Role:     model.RolesTherapist,
+			IsActive: &isActive,
+			Limit:    10,
+			Offset:   5,
+		}).Return([]usecase.AdminUserOutput{{ID: uuid.New(), Email: "user@example.com"}}, nil).Once()
+
+		err := svc.HandleAdminSearchUsers()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "user@example.com")
+	})
+}
+
+func TestUsersService_HandleAdminChangeUserRole(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockUsersUC := usecase_mock.NewUsersUsecaseIface(t)
+
+	svc := rest.NewService(group, nil, nil, nil, nil, mockUsersUC)
+
+	userID := uuid.New()
+
+	t.Run("invalid body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPatch, "/v1/admin/users/"+userID.String()+"/role", strings.NewReader(`{,}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues(userID.String())
+
+		err := svc.HandleAdminChangeUserRole()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPatch, "/v1/admin/users/"+userID.String()+"/role", strings.NewReader(`{"role":"therapist"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues(userID.String())
+
+		mockUsersUC.EXPECT().AdminChangeUserRole(ctx.Request().Context(), usecase.AdminChangeUserRoleInput{
+			UserID: userID,
+			Role:   model.RolesTherapist,
+		}).Return(&usecase.AdminUpdateUserOutput{Message: "ok"}, nil).Once()
+
+		err := svc.HandleAdminChangeUserRole()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestUsersService_HandleAdminChangeUserActivation(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockUsersUC := usecase_mock.NewUsersUsecaseIface(t)
+
+	svc := rest.NewService(group, nil, nil, nil, nil, mockUsersUC)
+
+	userID := uuid.New()
+
+	t.Run("not found mapped from usecase", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPatch, "/v1/admin/users/"+userID.String()+"/activation", strings.NewReader(`{"is_active":false}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues(userID.String())
+
+		mockUsersUC.EXPECT().AdminChangeUserActivation(ctx.Request().Context(), mock.Anything).
+			Return(nil, usecase.UsecaseError{ErrType: usecase.ErrNotFound}).Once()
+
+		err := svc.HandleAdminChangeUserActivation()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPatch, "/v1/admin/users/"+userID.String()+"/activation", strings.NewReader(`{"is_active":false}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues(userID.String())
+
+		isActive := false
+		mockUsersUC.EXPECT().AdminChangeUserActivation(ctx.Request().Context(), usecase.AdminChangeUserActivationInput{
+			UserID:   userID,
+			IsActive: &isActive,
+		}).Return(&usecase.AdminUpdateUserOutput{Message: "ok"}, nil).Once()
+
+		err := svc.HandleAdminChangeUserActivation()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestUsersService_HandleAdminSetMFARequirement(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockUsersUC := usecase_mock.NewUsersUsecaseIface(t)
+
+	svc := rest.NewService(group, nil, nil, nil, nil, mockUsersUC)
+
+	userID := uuid.New()
+
+	t.Run("bad request mapped from usecase", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPatch, "/v1/admin/users/"+userID.String()+"/mfa", strings.NewReader(`{"required":true}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues(userID.String())
+
+		mockUsersUC.EXPECT().AdminSetMFARequirement(ctx.Request().Context(), mock.Anything).
+			Return(nil, usecase.UsecaseError{ErrType: usecase.ErrBadRequest}).Once()
+
+		err := svc.HandleAdminSetMFARequirement()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPatch, "/v1/admin/users/"+userID.String()+"/mfa", strings.NewReader(`{"required":true}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues(userID.String())
+
+		required := true
+		mockUsersUC.EXPECT().AdminSetMFARequirement(ctx.Request().Context(), usecase.AdminSetMFARequirementInput{
+			UserID:   userID,
+			Required: &required,
+		}).Return(&usecase.AdminUpdateUserOutput{Message: "ok"}, nil).Once()
+
+		err := svc.HandleAdminSetMFARequirement()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestUsersService_HandleAdminUnlockUser(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockUsersUC := usecase_mock.NewUsersUsecaseIface(t)
+
+	svc := rest.NewService(group, nil, nil, nil, nil, mockUsersUC)
+
+	userID := uuid.New()
+
+	t.Run("invalid user id", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/admin/users/invalid/unlock", nil)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues("invalid")
+
+		err := svc.HandleAdminUnlockUser()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("not found mapped from usecase", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/admin/users/"+userID.String()+"/unlock", nil)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues(userID.String())
+
+		mockUsersUC.EXPECT().AdminUnlockUser(ctx.Request().Context(), usecase.AdminUnlockUserInput{UserID: userID}).
+			Return(nil, usecase.UsecaseError{ErrType: usecase.ErrNotFound}).Once()
+
+		err := svc.HandleAdminUnlockUser()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/admin/users/"+userID.String()+"/unlock", nil)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues(userID.String())
+
+		mockUsersUC.EXPECT().AdminUnlockUser(ctx.Request().Context(), usecase.AdminUnlockUserInput{UserID: userID}).
+			Return(&usecase.AdminUpdateUserOutput{Message: "ok"}, nil).Once()
+
+		err := svc.HandleAdminUnlockUser()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestUsersService_HandleAdminCancelAccountDeletion(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUC := usecase_mock.NewAuthUsecaseIface(t)
+
+	svc := rest.NewService(group, mockAuthUC, nil, nil, nil, nil)
+
+	userID := uuid.New()
+
+	t.Run("invalid user id", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/admin/users/invalid/deletion/cancel", nil)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues("invalid")
+
+		err := svc.HandleAdminCancelAccountDeletion()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("not scheduled for deletion mapped from usecase", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/admin/users/"+userID.String()+"/deletion/cancel", nil)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues(userID.String())
+
+		mockAuthUC.EXPECT().HandleAdminCancelAccountDeletion(
+			ctx.Request().Context(), usecase.AdminCancelAccountDeletionInput{UserID: userID},
+		).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrBadRequest}).Once()
+
+		err := svc.HandleAdminCancelAccountDeletion()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/admin/users/"+userID.String()+"/deletion/cancel", nil)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues(userID.String())
+
+		mockAuthUC.EXPECT().HandleAdminCancelAccountDeletion(
+			ctx.Request().Context(), usecase.AdminCancelAccountDeletionInput{UserID: userID},
+		).Return(&usecase.AdminCancelAccountDeletionOutput{Message: "ok"}, nil).Once()
+
+		err := svc.HandleAdminCancelAccountDeletion()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestUsersService_HandleAdminForceResetPassword(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUC := usecase_mock.NewAuthUsecaseIface(t)
+
+	svc := rest.NewService(group, mockAuthUC, nil, nil, nil, nil)
+
+	userID := uuid.New()
+
+	t.Run("invalid user id", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/admin/users/invalid/password/reset", nil)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues("invalid")
+
+		err := svc.HandleAdminForceResetPassword()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/admin/users/"+userID.String()+"/password/reset", nil)
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("user_id")
+		ctx.SetParamValues(userID.String())
+
+		mockAuthUC.EXPECT().HandleAdminForceResetPassword(ctx.Request().Context(), usecase.AdminForceResetPasswordInput{
+			UserID: userID,
+		}).Return(&usecase.AdminForceResetPasswordOutput{Message: "ok"}, nil).Once()
+
+		err := svc.HandleAdminForceResetPassword()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestUsersService_HandleChangeMyPassword(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUC := usecase_mock.NewAuthUsecaseIface(t)
+
+	svc := rest.NewService(group, mockAuthUC, nil, nil, nil, nil)
+
+	t.Run("invalid body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/v1/users/me/password", strings.NewReader("{invalid"))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+
+		err := svc.HandleChangeMyPassword()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("bad request mapped from usecase", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/v1/users/me/password", strings.NewReader(`{"current_password":"old","new_password":"short"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+
+		mockAuthUC.EXPECT().HandleChangePassword(ctx.Request().Context(), usecase.ChangePasswordInput{
+			CurrentPassword: "old",
+			NewPassword:     "short",
+		}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrBadRequest}).Once()
+
+		err := svc.HandleChangeMyPassword()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/v1/users/me/password", strings.NewReader(`{"current_password":"old","new_password":"n3w-str0ng-passw0rd"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+
+		mockAuthUC.EXPECT().HandleChangePassword(ctx.Request().Context(), usecase.ChangePasswordInput{
+			CurrentPassword: "old",
+			NewPassword:     "n3w-str0ng-passw0rd",
+		}).Return(&usecase.ChangePasswordOutput{Message: "your password has been changed"}, nil).Once()
+
+		err := svc.HandleChangeMyPassword()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "your password has been changed")
+	})
+}
+
+func TestUsersService_HandleInitChangeMyEmail(t *testing.T) {
+	e := echo.New()
+	group := e.Group("")
+
+	mockAuthUC := usecase_mock.NewAuthUsecaseIface(t)
+
+	svc := rest.NewService(group, mockAuthUC, nil, nil, nil, nil)
+
+	t.Run("invalid body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/v1/users/me/email", strings.NewReader("{invalid"))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+
+		err := svc.HandleInitChangeMyEmail()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("too many requests mapped from usecase", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/v1/users/me/email", strings.NewReader(`{"new_email":"new@example.com","current_password":"password"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+
+		mockAuthUC.EXPECT().HandleInitChangeEmail(ctx.Request().Context(), usecase.InitChangeEmailInput{
+			NewEmail:        "new@example.com",
+			CurrentPassword: "password",
+		}).Return(nil, usecase.UsecaseError{ErrType: usecase.ErrTooManyRequests}).Once()
+
+		err := svc.HandleInitChangeMyEmail()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/v1/users/me/email", strings.NewReader(`{"new_email":"new@example.com","current_password":"password"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ctx := e.NewContext(req, rec)
+
+		mockAuthUC.EXPECT().HandleInitChangeEmail(ctx.Request().Context(), usecase.InitChangeEmailInput{
+			NewEmail:        "new@example.com",
+			CurrentPassword: "password",
+		}).Return(&usecase.InitChangeEmailOutput{Message: "a confirmation link has been sent to the new email"}, nil).Once()
+
+		err := svc.HandleInitChangeMyEmail()(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/schema"
+)
+
+// AuditAction sensitive action recorded to the audit trail
+type AuditAction string
+
+// list of audited actions
+const (
+	AuditActionSearchChild         AuditAction = "child.search"
+	AuditActionSearchResult        AuditAction = "result.search"
+	AuditActionDownloadResult      AuditAction = "result.download"
+	AuditActionCreatePackage       AuditAction = "package.create"
+	AuditActionUpdatePackage       AuditAction = "package.update"
+	AuditActionChangePackageStatus AuditAction = "package.change_active_status"
+	AuditActionDeletePackage       AuditAction = "package.delete"
+	AuditActionDeleteAccount       AuditAction = "user.delete_account"
+	AuditActionCancelDeleteAccount AuditAction = "user.cancel_delete_account"
+	AuditActionPurgeAccount        AuditAction = "user.purge_account"
+	AuditActionChangeUserRole      AuditAction = "user.change_role"
+	AuditActionRequestDataExport   AuditAction = "data_export.request"
+	AuditActionDownloadDataExport  AuditAction = "data_export.download"
+	AuditActionGrantCareAccess     AuditAction = "child.grant_care_access"
+	AuditActionRevokeCareAccess    AuditAction = "child.revoke_care_access"
+	AuditActionInviteGuardian      AuditAction = "child.invite_guardian"
+	AuditActionAcceptGuardianship  AuditAction = "child.accept_guardianship"
+	AuditActionRemoveGuardian      AuditAction = "child.remove_guardian"
+	AuditActionInitiateTransfer    AuditAction = "child.initiate_transfer"
+	AuditActionTransferChild       AuditAction = "child.transfer"
+	AuditActionArchiveChild        AuditAction = "child.archive"
+	AuditActionRestoreChild        AuditAction = "child.restore"
+	AuditActionDeleteChild         AuditAction = "child.delete"
+	AuditActionCreateOrganization  AuditAction = "organization.create"
+	AuditActionSetUserOrganization AuditAction = "user.set_organization"
+)
+
+// AuditTargetType the kind of resource targeted by the audited action
+type AuditTargetType string
+
+// list of audit target types
+const (
+	AuditTargetChild        AuditTargetType = "child"
+	AuditTargetResult       AuditTargetType = "result"
+	AuditTargetPackage      AuditTargetType = "package"
+	AuditTargetUser         AuditTargetType = "user"
+	AuditTargetExport       AuditTargetType = "data_export"
+	AuditTargetOrganization AuditTargetType = "organization"
+)
+
+// AuditMetadata additional detail of the audited action, e.g. the search params or the changed values
+type AuditMetadata map[string]any
+
+// Value implements Valuer/Scanner interface
+func (am AuditMetadata) Value(_ context.Context, _ *schema.Field, _ reflect.Value, fieldValue interface{}) (interface{}, error) {
+	if fieldValue == nil || reflect.ValueOf(fieldValue).IsNil() {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(fieldValue)
+}
+
+// Scan implements Valuer/Scanner interface
+func (am *AuditMetadata) Scan(_ context.Context, _ *schema.Field, _ reflect.Value, dbValue interface{}) error {
+	if dbValue == nil {
+		return nil
+	}
+
+	var bytes []byte
+	switch v := dbValue.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("failed to unmarshal JSONB value: %#v", dbValue)
+	}
+
+	return json.Unmarshal(bytes, am)
+}
+
+// AuditLog represent audit_logs table on database. Each entry records who did which sensitive action
+// to which resource, and from where. Entries are append only, and only removed after the retention period
+type AuditLog struct {
+	ID         uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	ActorID    uuid.UUID `gorm:"default:null"`
+	ActorRole  Roles
+	Action     AuditAction
+	TargetType AuditTargetType
+	TargetID   string
+	Metadata   AuditMetadata
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time
+}
@@ -10,13 +10,20 @@ import (
 type authCtxKey string
 
 var (
-	authUserCtxKey authCtxKey = "github.com/luckyAkbar/atec/internal/model:AuthUser"
+	authUserCtxKey   authCtxKey = "github.com/luckyAkbar/atec/internal/model:AuthUser"
+	clientInfoCtxKey authCtxKey = "github.com/luckyAkbar/atec/internal/model:ClientInfo"
 )
 
-// AuthUser represent authenticated user and will be used to embed value to context
+// AuthUser represent authenticated user and will be used to embed value to context.
+// PersonalAccessTokenID is only set when authenticated using the personal access token,
+// while OrganizationID is only set when the user belongs to an organization
 type AuthUser struct {
-	ID   uuid.UUID
-	Role Roles
+	ID                    uuid.UUID
+	Role                  Roles
+	SessionID             uuid.UUID
+	PersonalAccessTokenID uuid.UUID
+	OrganizationID        uuid.UUID
+	IsOrganizationAdmin   bool
 }
 
 // SetUserToCtx set user to context
@@ -34,9 +41,35 @@ func GetUserFromCtx(ctx context.Context) *AuthUser {
 	return &user
 }
 
+// ClientInfo represent the client making the request and will be used to embed value to context
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+// IsEmpty report whether nothing is known about the client
+func (ci ClientInfo) IsEmpty() bool {
+	return ci.IPAddress == "" && ci.UserAgent == ""
+}
+
+// SetClientInfoToCtx set client info to context
+func SetClientInfoToCtx(ctx context.Context, client ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoCtxKey, client)
+}
+
+// GetClientInfoFromCtx get client info from context. Empty ClientInfo will be returned if not set
+func GetClientInfoFromCtx(ctx context.Context) ClientInfo {
+	client, _ := ctx.Value(clientInfoCtxKey).(ClientInfo)
+
+	return client
+}
+
 // LoginTokenClaims custom claims to be placed in payload for login jwt token
 type LoginTokenClaims struct {
-	Role Roles `json:"role"`
+	Role                Roles  `json:"role"`
+	SessionID           string `json:"sid"`
+	OrganizationID      string `json:"org,omitempty"`
+	IsOrganizationAdmin bool   `json:"org_admin,omitempty"`
 
 	jwt.RegisteredClaims
 }
@@ -44,3 +77,31 @@ type LoginTokenClaims struct {
 // ChangePasswordTokenQuery is the key in the query parameters to handle
 // change password request
 const ChangePasswordTokenQuery = "change_password_token"
+
+// TherapistInvitationTokenQuery is the key in the query parameters to handle
+// therapist invitation
+const TherapistInvitationTokenQuery = "invitation_token"
+
+// AccountUnlockTokenQuery is the key in the query parameters to handle
+// unlocking account locked due to too many failed login attempts
+const AccountUnlockTokenQuery = "unlock_token"
+
+// ChangeEmailTokenQuery is the key in the query parameters to handle
+// confirming the new email address
+const ChangeEmailTokenQuery = "change_email_token"
+
+// MagicLinkTokenQuery is the key in the query parameters to handle
+// passwordless login using the magic link
+const MagicLinkTokenQuery = "magic_link_token"
+
+// AccountRestoreTokenQuery is the key in the query parameters to handle
+// cancelling the scheduled account deletion
+const AccountRestoreTokenQuery = "restore_token"
+
+// GuardianInvitationTokenQuery is the key in the query parameters to handle
+// accepting the invitation to become the child's guardian
+const GuardianInvitationTokenQuery = "guardian_invitation_token"
+
+// ChildTransferTokenQuery is the key in the query parameters to handle
+// accepting the transfer of a child to the recipient's account
+const ChildTransferTokenQuery = "child_transfer_token"
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CareScope what the assigned therapist is allowed to do to the child
+type CareScope string
+
+// list of care scopes
+const (
+	// CareScopeRead allow the therapist to read the child data, statistic and results
+	CareScopeRead CareScope = "read"
+	// CareScopeSubmit allow the therapist to also submit questionnaire results for the child
+	CareScopeSubmit CareScope = "submit"
+)
+
+// CareRelationship represent care_relationships table on database. Each relationship is granted by the child's
+// parent and gives the therapist access to the child, limited by the Scope, until revoked
+type CareRelationship struct {
+	ID          uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	ChildID     uuid.UUID
+	TherapistID uuid.UUID
+	GrantedBy   uuid.UUID
+	Scope       CareScope
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
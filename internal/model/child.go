@@ -17,6 +17,7 @@ type Child struct {
 	Gender       bool
 	Name         string
 	GuardianName sql.NullString
+	ArchivedAt   sql.NullTime
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	DeletedAt    gorm.DeletedAt
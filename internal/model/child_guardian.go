@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GuardianRole the role of the user in the child's guardian membership
+type GuardianRole string
+
+// list of guardian roles
+const (
+	// GuardianRoleOwner the user registered the child, mirrored as the child's ParentUserID. Only the owner
+	// can manage the guardians, delete or transfer the child
+	GuardianRoleOwner GuardianRole = "owner"
+	// GuardianRoleGuardian the user joined by accepting the owner's invitation and shares the access to the child
+	GuardianRoleGuardian GuardianRole = "guardian"
+)
+
+// ChildGuardian represent child_guardians table on database. Each record gives the user access to the child
+// as if they were the child's parent, limited by the Role
+type ChildGuardian struct {
+	ID        uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	ChildID   uuid.UUID
+	UserID    uuid.UUID
+	Role      GuardianRole
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
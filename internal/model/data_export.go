@@ -0,0 +1,42 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DataExportTokenQuery is the key in the query parameters to handle
+// the data export download
+const DataExportTokenQuery = "export_token"
+
+// DataExportStatus the progress of a data export
+type DataExportStatus string
+
+// list of data export statuses
+const (
+	DataExportStatusPending    DataExportStatus = "pending"
+	DataExportStatusProcessing DataExportStatus = "processing"
+	DataExportStatusCompleted  DataExportStatus = "completed"
+	DataExportStatusFailed     DataExportStatus = "failed"
+)
+
+// DataExport represent data_exports table on database. Each export holds the ZIP archive of everything stored
+// about the user, prepared in the background and downloadable using the token sent to the user's email until ExpiresAt
+type DataExport struct {
+	ID                uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	UserID            uuid.UUID
+	Status            DataExportStatus
+	Archive           []byte         `json:"-"`
+	DownloadTokenHash sql.NullString `json:"-"`
+	ExpiresAt         sql.NullTime
+	CompletedAt       sql.NullTime
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// IsDownloadable report whether the archive is ready and the download link is not yet expired
+func (de DataExport) IsDownloadable() bool {
+	return de.Status == DataExportStatusCompleted && de.ExpiresAt.Valid && de.ExpiresAt.Time.After(time.Now())
+}
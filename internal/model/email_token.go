@@ -0,0 +1,22 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailToken represent email_tokens table on database. Every token sent to the user's email
+// is recorded here using the jwt id (jti) as the primary key, so each token can only be redeemed once.
+// UserID will be null for tokens issued before the account exists, e.g. therapist invitation
+type EmailToken struct {
+	ID         uuid.UUID
+	UserID     uuid.NullUUID
+	Purpose    string
+	ExpiresAt  time.Time
+	ConsumedAt sql.NullTime
+	RevokedAt  sql.NullTime
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
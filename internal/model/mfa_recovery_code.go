@@ -0,0 +1,19 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MFARecoveryCode represent mfa_recovery_codes table on database. Each code can be used once
+// in place of the TOTP code when the user lost access to their authenticator app
+type MFARecoveryCode struct {
+	ID        uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	UserID    uuid.UUID
+	CodeHash  string `json:"-"`
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization represent organizations table on database. Each organization is a clinic running on the same
+// deployment, whose therapists, packages, and the children taken care of are isolated from the other organizations
+type Organization struct {
+	ID        uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
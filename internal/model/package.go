@@ -13,7 +13,8 @@ import (
 	"gorm.io/gorm/schema"
 )
 
-// Package represent packages table on database
+// Package represent packages table on database. Package without OrganizationID is a global package usable by everyone,
+// otherwise it is only usable by the members of the organization
 type Package struct {
 	ID                      uuid.UUID               `gorm:"default:uuid_generate_v4()" json:"id"`
 	CreatedBy               uuid.UUID               `json:"created_by"`
@@ -23,6 +24,7 @@ type Package struct {
 	Name                    string                  `json:"name"`
 	IsActive                bool                    `json:"is_active"`
 	IsLocked                bool                    `json:"is_locked"`
+	OrganizationID          uuid.NullUUID           `json:"organization_id"`
 	CreatedAt               time.Time               `gorm:"default:now()" json:"created_at"`
 	UpdatedAt               time.Time               `gorm:"default:now()" json:"updated_at"`
 	DeletedAt               gorm.DeletedAt          `json:"deleted_at"`
@@ -0,0 +1,13 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+// PasswordlessLogin represent a pending passwordless login stored on the cache until it expires.
+// Only the hash of the one time password and the magic link secret are stored
+type PasswordlessLogin struct {
+	UserID        uuid.UUID `json:"user_id"`
+	OTPHash       string    `json:"otp_hash"`
+	MagicLinkHash string    `json:"magic_link_hash"`
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/schema"
+)
+
+// PersonalAccessTokenPrefix is prepended to every personal access token, used to tell them apart from the login jwt
+const PersonalAccessTokenPrefix = "atec_pat_"
+
+// PersonalAccessTokenScope limit which group of endpoints can be accessed using a personal access token
+type PersonalAccessTokenScope string
+
+// list of available personal access token scopes
+const (
+	PersonalAccessTokenScopePackages       PersonalAccessTokenScope = "packages"
+	PersonalAccessTokenScopeChildren       PersonalAccessTokenScope = "children"
+	PersonalAccessTokenScopeQuestionnaires PersonalAccessTokenScope = "questionnaires"
+	PersonalAccessTokenScopeUsers          PersonalAccessTokenScope = "users"
+	PersonalAccessTokenScopeAdmin          PersonalAccessTokenScope = "admin"
+)
+
+// PersonalAccessTokenScopes list of scopes granted to a personal access token
+type PersonalAccessTokenScopes []PersonalAccessTokenScope
+
+// Has report whether the scope is granted
+func (pats PersonalAccessTokenScopes) Has(scope PersonalAccessTokenScope) bool {
+	return slices.Contains(pats, scope)
+}
+
+// Value implements Valuer/Scanner interface
+func (pats PersonalAccessTokenScopes) Value(
+	_ context.Context, _ *schema.Field, _ reflect.Value, fieldValue interface{},
+) (interface{}, error) {
+	return json.Marshal(fieldValue)
+}
+
+// Scan implements Valuer/Scanner interface
+func (pats *PersonalAccessTokenScopes) Scan(_ context.Context, _ *schema.Field, _ reflect.Value, dbValue interface{}) error {
+	if dbValue == nil {
+		return nil
+	}
+
+	var bytes []byte
+	switch v := dbValue.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("failed to unmarshal JSONB value: %#v", dbValue)
+	}
+
+	return json.Unmarshal(bytes, pats)
+}
+
+// PersonalAccessToken represent personal_access_tokens table on database. Each token is created by the user
+// to be used by integrations in place of the login token, limited only to the endpoints allowed by its scopes
+type PersonalAccessToken struct {
+	ID         uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	UserID     uuid.UUID
+	Name       string
+	TokenHash  string `json:"-"`
+	Scopes     PersonalAccessTokenScopes
+	ExpiresAt  time.Time
+	LastUsedAt sql.NullTime
+	RevokedAt  sql.NullTime
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// IsActive report whether the token can still be used, meaning it is not revoked and not yet expired
+func (pat PersonalAccessToken) IsActive() bool {
+	return !pat.RevokedAt.Valid && pat.ExpiresAt.After(time.Now())
+}
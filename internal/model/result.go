@@ -9,20 +9,30 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 )
 
-// Result represent results table on database
+// Result represent results table on database. AnonymizedAt, ChildAgeBand and ChildGender are only set on the
+// anonymized results, which are detached from the child and the creator when the account or the child is deleted
 type Result struct {
-	ID        uuid.UUID `gorm:"default:uuid_generate_v4()"`
-	PackageID uuid.UUID
-	ChildID   uuid.UUID `gorm:"default:null"`
-	CreatedBy uuid.UUID `gorm:"default:null"`
-	Answer    AnswerDetail
-	Result    ResultDetail
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt sql.NullTime
+	ID           uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	PackageID    uuid.UUID
+	ChildID      uuid.UUID `gorm:"default:null"`
+	CreatedBy    uuid.UUID `gorm:"default:null"`
+	Answer       AnswerDetail
+	Result       ResultDetail
+	ChildAgeBand sql.NullString `gorm:"default:null"`
+	ChildGender  sql.NullBool   `gorm:"default:null"`
+	AnonymizedAt sql.NullTime   `gorm:"default:null"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt
+}
+
+// IsAnonymized report whether the result has been detached from the child and the creator
+func (r Result) IsAnonymized() bool {
+	return r.AnonymizedAt.Valid
 }
 
 // AnswerDetail represent each checklisted option from the questionnaire.
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session represent sessions table on database. Each successful login will create one session
+// holding the hashed value of the currently valid refresh token, along with the client used to login
+type Session struct {
+	ID               uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	UserID           uuid.UUID
+	RefreshTokenHash string `json:"-"`
+	IPAddress        string
+	UserAgent        string
+	ExpiresAt        time.Time
+	LastSeenAt       sql.NullTime
+	RevokedAt        sql.NullTime
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// IsActive report whether the session can still be used, meaning it is not revoked and not yet expired
+func (s Session) IsActive() bool {
+	return !s.RevokedAt.Valid && s.ExpiresAt.After(time.Now())
+}
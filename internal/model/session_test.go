@@ -0,0 +1,48 @@
+package model_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/luckyAkbar/atec/internal/model"
+)
+
+func TestSession_IsActive(t *testing.T) {
+	testCases := []struct {
+		name     string
+		session  model.Session
+		expected bool
+	}{
+		{
+			name: "not revoked and not expired",
+			session: model.Session{
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+			expected: true,
+		},
+		{
+			name: "already expired",
+			session: model.Session{
+				ExpiresAt: time.Now().Add(-time.Hour),
+			},
+			expected: false,
+		},
+		{
+			name: "revoked before expired",
+			session: model.Session{
+				ExpiresAt: time.Now().Add(time.Hour),
+				RevokedAt: sql.NullTime{Time: time.Now(), Valid: true},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.session.IsActive(); got != tc.expected {
+				t.Errorf("expected IsActive to be %v, but got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -18,17 +18,38 @@ const (
 	RolesTherapist     Roles = "therapist"
 )
 
-// User represent users table on database
+// User represent users table on database. OrganizationID is only set for the therapist belonging to an organization,
+// and IsOrganizationAdmin allow the therapist to manage the organization's packages and see all of its children
 type User struct {
-	ID          uuid.UUID `gorm:"default:uuid_generate_v4()"`
-	Email       string
-	Password    string `json:"-"`
-	Username    string
-	IsActive    bool
-	Roles       Roles
-	PhoneNumber sql.NullString
-	Address     sql.NullString
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   gorm.DeletedAt
+	ID                  uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	Email               string
+	EmailBlindIndex     sql.NullString `json:"-"`
+	Password            string         `json:"-"`
+	Username            string
+	IsActive            bool
+	Roles               Roles
+	PhoneNumber         sql.NullString
+	Address             sql.NullString
+	MFASecret           sql.NullString `json:"-"`
+	MFAEnabled          bool
+	MFARequired         bool
+	FailedLoginAttempts int
+	LastFailedLoginAt   sql.NullTime
+	LockedUntil         sql.NullTime
+	DeletionRequestedAt sql.NullTime
+	OrganizationID      uuid.NullUUID
+	IsOrganizationAdmin bool
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	DeletedAt           gorm.DeletedAt
+}
+
+// IsLocked report whether the account is temporarily locked due to too many failed login attempts
+func (u User) IsLocked() bool {
+	return u.LockedUntil.Valid && u.LockedUntil.Time.After(time.Now())
+}
+
+// IsDeletionScheduled report whether the user has requested the account deletion which is still within the grace period
+func (u User) IsDeletionScheduled() bool {
+	return u.DeletionRequestedAt.Valid
 }
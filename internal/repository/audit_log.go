@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository is an instance containing functions to interact specifically to audit_logs table
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository create a new instance of AuditLogRepository
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{
+		db: db,
+	}
+}
+
+// Create append a new entry to audit_logs table
+func (r *AuditLogRepository) Create(ctx context.Context, input usecase.RepoCreateAuditLogInput) error {
+	auditLog := &model.AuditLog{
+		ActorID:    input.ActorID,
+		ActorRole:  input.ActorRole,
+		Action:     input.Action,
+		TargetType: input.TargetType,
+		TargetID:   input.TargetID,
+		Metadata:   input.Metadata,
+		IPAddress:  input.IPAddress,
+		UserAgent:  input.UserAgent,
+	}
+
+	return r.db.WithContext(ctx).Create(auditLog).Error
+}
+
+func searchAuditLogInputToSearchFields(cursor *gorm.DB, input usecase.RepoSearchAuditLogInput) *gorm.DB {
+	if input.ActorID != uuid.Nil {
+		cursor = cursor.Where("actor_id = ?", input.ActorID)
+	}
+
+	if input.Action != "" {
+		cursor = cursor.Where("action = ?", input.Action)
+	}
+
+	if input.TargetType != "" {
+		cursor = cursor.Where("target_type = ?", input.TargetType)
+	}
+
+	if input.TargetID != "" {
+		cursor = cursor.Where("target_id = ?", input.TargetID)
+	}
+
+	if !input.CreatedAfter.IsZero() {
+		cursor = cursor.Where("created_at >= ?", input.CreatedAfter)
+	}
+
+	if !input.CreatedBefore.IsZero() {
+		cursor = cursor.Where("created_at < ?", input.CreatedBefore)
+	}
+
+	if input.Limit > 0 {
+		cursor = cursor.Limit(input.Limit)
+	}
+
+	if input.Offset > 0 {
+		cursor = cursor.Offset(input.Offset)
+	}
+
+	return cursor
+}
+
+// Search search the audit logs with given filters, newest first
+func (r *AuditLogRepository) Search(ctx context.Context, input usecase.RepoSearchAuditLogInput) ([]model.AuditLog, error) {
+	auditLogs := []model.AuditLog{}
+
+	cursor := searchAuditLogInputToSearchFields(r.db.WithContext(ctx), input)
+
+	if err := cursor.Order("created_at DESC").Find(&auditLogs).Error; err != nil {
+		return nil, err
+	}
+
+	if len(auditLogs) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return auditLogs, nil
+}
+
+// DeleteCreatedBefore permanently delete the audit logs created before the given time, used to apply the retention period.
+// Returns the number of deleted entries
+func (r *AuditLogRepository) DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&model.AuditLog{})
+	if res.Error != nil {
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,299 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/repository"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewAuditLogRepository(kit.DB)
+
+	input := usecase.RepoCreateAuditLogInput{
+		ActorID:    uuid.New(),
+		ActorRole:  model.RolesAdministrator,
+		Action:     model.AuditActionDeletePackage,
+		TargetType: model.AuditTargetPackage,
+		TargetID:   uuid.NewString(),
+		Metadata:   model.AuditMetadata{"package_name": "ATEC"},
+		IPAddress:  "10.0.0.1",
+		UserAgent:  "Mozilla/5.0",
+	}
+
+	testCases := []struct {
+		name                 string
+		input                usecase.RepoCreateAuditLogInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			input:   input,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^INSERT INTO \"audit_logs\"").
+					WithArgs(
+						input.ActorRole, input.Action, input.TargetType, input.TargetID, []byte(`{"package_name":"ATEC"}`),
+						input.IPAddress, input.UserAgent, sqlmock.AnyArg(), input.ActorID,
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "actor_id"}).AddRow(uuid.New(), input.ActorID))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name: "success without actor and metadata",
+			input: usecase.RepoCreateAuditLogInput{
+				Action:     model.AuditActionDownloadResult,
+				TargetType: model.AuditTargetResult,
+				TargetID:   input.TargetID,
+			},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^INSERT INTO \"audit_logs\"").
+					WithArgs(
+						model.Roles(""), model.AuditActionDownloadResult, model.AuditTargetResult, input.TargetID,
+						[]byte(`{}`), "", "", sqlmock.AnyArg(),
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "actor_id"}).AddRow(uuid.New(), nil))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error",
+			input:       input,
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^INSERT INTO \"audit_logs\"").
+					WithArgs(
+						input.ActorRole, input.Action, input.TargetType, input.TargetID, []byte(`{"package_name":"ATEC"}`),
+						input.IPAddress, input.UserAgent, sqlmock.AnyArg(), input.ActorID,
+					).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := repo.Create(ctx, tc.input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+
+				if tc.expectedErr != nil {
+					assert.Equal(t, tc.expectedErr, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAuditLogRepository_Search(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewAuditLogRepository(kit.DB)
+
+	actorID := uuid.New()
+	targetID := uuid.NewString()
+	createdAfter := time.Now().Add(-time.Hour)
+	createdBefore := time.Now()
+
+	testCases := []struct {
+		name                 string
+		input                usecase.RepoSearchAuditLogInput
+		wantErr              bool
+		expectedErr          error
+		expectedLen          int
+		expectedFunctionCall func()
+	}{
+		{
+			name: "using all filters",
+			input: usecase.RepoSearchAuditLogInput{
+				ActorID:       actorID,
+				Action:        model.AuditActionDownloadResult,
+				TargetType:    model.AuditTargetResult,
+				TargetID:      targetID,
+				CreatedAfter:  createdAfter,
+				CreatedBefore: createdBefore,
+				Limit:         10,
+				Offset:        10,
+			},
+			wantErr:     false,
+			expectedLen: 1,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery("^SELECT .+ FROM \"audit_logs\" WHERE actor_id = .+ AND action = .+ AND target_type = .+ AND target_id = .+ "+
+					"AND created_at >= .+ AND created_at < .+ ORDER BY created_at DESC LIMIT .+ OFFSET .+").
+					WithArgs(actorID, model.AuditActionDownloadResult, model.AuditTargetResult, targetID, createdAfter, createdBefore, 10, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "actor_id", "action", "metadata"}).
+						AddRow(uuid.New(), actorID, model.AuditActionDownloadResult, []byte(`{"child_id":"abc"}`)))
+			},
+		},
+		{
+			name:        "without filters",
+			input:       usecase.RepoSearchAuditLogInput{Limit: 5},
+			wantErr:     false,
+			expectedLen: 2,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery("^SELECT .+ FROM \"audit_logs\" ORDER BY created_at DESC LIMIT .+").
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "actor_id"}).AddRow(uuid.New(), nil).AddRow(uuid.New(), actorID))
+			},
+		},
+		{
+			name:        "not found",
+			input:       usecase.RepoSearchAuditLogInput{ActorID: actorID, Limit: 5},
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery("^SELECT .+ FROM \"audit_logs\" WHERE actor_id = .+ ORDER BY created_at DESC LIMIT .+").
+					WithArgs(actorID, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+		},
+		{
+			name:        "db error",
+			input:       usecase.RepoSearchAuditLogInput{ActorID: actorID, Limit: 5},
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery("^SELECT .+ FROM \"audit_logs\" WHERE actor_id = .+ ORDER BY created_at DESC LIMIT .+").
+					WithArgs(actorID, 5).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := repo.Search(ctx, tc.input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, res)
+
+				if tc.expectedErr != nil {
+					assert.Equal(t, tc.expectedErr, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, res, tc.expectedLen)
+		})
+	}
+}
+
+func TestAuditLogRepository_DeleteCreatedBefore(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewAuditLogRepository(kit.DB)
+
+	before := time.Now().AddDate(0, 0, -365)
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedDeleted      int64
+		expectedFunctionCall func()
+	}{
+		{
+			name:            "success",
+			wantErr:         false,
+			expectedDeleted: 3,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec("^DELETE FROM \"audit_logs\" WHERE created_at < .+").
+					WithArgs(before).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec("^DELETE FROM \"audit_logs\" WHERE created_at < .+").
+					WithArgs(before).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			deleted, err := repo.DeleteCreatedBefore(ctx, before)
+
+			if tc.wantErr {
+				require.Error(t, err)
+
+				if tc.expectedErr != nil {
+					assert.Equal(t, tc.expectedErr, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDeleted, deleted)
+		})
+	}
+}
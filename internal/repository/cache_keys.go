@@ -3,6 +3,7 @@ package repository
 import (
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/luckyAkbar/atec/internal/model"
 )
 
@@ -22,3 +23,8 @@ const (
 func CacheKeyForPackage(pack model.Package) string {
 	return fmt.Sprintf("github.com/luckyAkbar/atec:cache-key:package:%s", pack.ID)
 }
+
+// CacheKeyForPasswordlessLogin will return a unique cache key for the user's pending passwordless login
+func CacheKeyForPasswordlessLogin(userID uuid.UUID) string {
+	return fmt.Sprintf("github.com/luckyAkbar/atec:cache-key:passwordless-login:%s", userID)
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// organizationChildIDsQuery select the ids of the children assigned to any therapist of the organization,
+// which is how the children are linked to the organization
+const organizationChildIDsQuery = `SELECT care_relationships.child_id FROM care_relationships
+	JOIN users ON users.id = care_relationships.therapist_id WHERE users.organization_id = ? AND users.deleted_at IS NULL`
+
+// CareRelationshipRepository is an instance containing functions to interact specifically to care_relationships table
+type CareRelationshipRepository struct {
+	db *gorm.DB
+}
+
+// NewCareRelationshipRepository create a new instance of CareRelationshipRepository
+func NewCareRelationshipRepository(db *gorm.DB) *CareRelationshipRepository {
+	return &CareRelationshipRepository{
+		db: db,
+	}
+}
+
+// Upsert assign the therapist to the child. If the therapist is already assigned, the scope will be replaced instead
+func (r *CareRelationshipRepository) Upsert(
+	ctx context.Context, input usecase.RepoUpsertCareRelationshipInput,
+) (*model.CareRelationship, error) {
+	relationship := &model.CareRelationship{
+		ChildID:     input.ChildID,
+		TherapistID: input.TherapistID,
+		GrantedBy:   input.GrantedBy,
+		Scope:       input.Scope,
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "child_id"}, {Name: "therapist_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"scope", "granted_by", "updated_at"}),
+	}).Create(relationship).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return relationship, nil
+}
+
+// FindByChildIDAndTherapistID find the care relationship between the child and the therapist
+func (r *CareRelationshipRepository) FindByChildIDAndTherapistID(
+	ctx context.Context, childID, therapistID uuid.UUID,
+) (*model.CareRelationship, error) {
+	relationship := &model.CareRelationship{}
+
+	err := r.db.WithContext(ctx).Take(relationship, "child_id = ? AND therapist_id = ?", childID, therapistID).Error
+	switch err {
+	default:
+		return nil, err
+	case gorm.ErrRecordNotFound:
+		return nil, ErrNotFound
+	case nil:
+		return relationship, nil
+	}
+}
+
+// FindByParentUserID find all the care relationships granted for the children registered by the user, newest first
+func (r *CareRelationshipRepository) FindByParentUserID(ctx context.Context, parentUserID uuid.UUID) ([]model.CareRelationship, error) {
+	relationships := []model.CareRelationship{}
+
+	err := r.db.WithContext(ctx).
+		Where("child_id IN (?)", r.db.Model(&model.Child{}).Select("id").Where("parent_user_id = ?", parentUserID)).
+		Order("created_at DESC").
+		Find(&relationships).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return relationships, nil
+}
+
+// Delete remove the therapist from the child's care relationships. ErrNotFound will be returned
+// if the therapist was not assigned to the child
+func (r *CareRelationshipRepository) Delete(ctx context.Context, childID, therapistID uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("child_id = ? AND therapist_id = ?", childID, therapistID).Delete(&model.CareRelationship{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteByUser remove the child's care relationships granted by the user or assigning the user as the therapist,
+// e.g. once the user no longer owns the child. Unlike Delete, no error will be returned if nothing was removed
+func (r *CareRelationshipRepository) DeleteByUser(ctx context.Context, childID, userID uuid.UUID, txController ...*gorm.DB) error {
+	tx := r.db
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	return tx.WithContext(ctx).
+		Where("child_id = ? AND (granted_by = ? OR therapist_id = ?)", childID, userID, userID).
+		Delete(&model.CareRelationship{}).Error
+}
@@ -0,0 +1,281 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/repository"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCareRelationshipRepository_Upsert(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewCareRelationshipRepository(kit.DB)
+
+	id := uuid.New()
+	input := usecase.RepoUpsertCareRelationshipInput{
+		ChildID:     uuid.New(),
+		TherapistID: uuid.New(),
+		GrantedBy:   uuid.New(),
+		Scope:       model.CareScopeRead,
+	}
+	upsertQuery := `^INSERT INTO "care_relationships" .+ ON CONFLICT \("child_id","therapist_id"\) DO UPDATE SET ` +
+		`"scope"="excluded"."scope","granted_by"="excluded"."granted_by","updated_at"="excluded"."updated_at"`
+
+	t.Run("success replacing the scope when already assigned", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery(upsertQuery).
+			WithArgs(input.ChildID, input.TherapistID, input.GrantedBy, input.Scope, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+		dbMock.ExpectCommit()
+
+		res, err := repo.Upsert(ctx, input)
+		require.NoError(t, err)
+		assert.Equal(t, id, res.ID)
+		assert.Equal(t, model.CareScopeRead, res.Scope)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery(`^INSERT INTO "care_relationships"`).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		res, err := repo.Upsert(ctx, input)
+		require.Error(t, err)
+		require.Nil(t, res)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestCareRelationshipRepository_FindByChildIDAndTherapistID(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewCareRelationshipRepository(kit.DB)
+
+	childID := uuid.New()
+	therapistID := uuid.New()
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "care_relationships" WHERE child_id = \$1 AND therapist_id = \$2`).
+					WithArgs(childID, therapistID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"child_id", "therapist_id", "scope"}).
+						AddRow(childID, therapistID, model.CareScopeSubmit))
+			},
+		},
+		{
+			name:        "error - unknown just pass the error to the caller",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "care_relationships"`).
+					WithArgs(childID, therapistID, 1).
+					WillReturnError(assert.AnError)
+			},
+		},
+		{
+			name:        "the therapist is not assigned to the child",
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "care_relationships"`).
+					WithArgs(childID, therapistID, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := repo.FindByChildIDAndTherapistID(ctx, childID, therapistID)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				if tc.expectedErr != nil {
+					assert.Equal(t, tc.expectedErr, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, model.CareScopeSubmit, res.Scope)
+		})
+	}
+}
+
+func TestCareRelationshipRepository_FindByParentUserID(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewCareRelationshipRepository(kit.DB)
+
+	parentUserID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT \* FROM "care_relationships" WHERE child_id IN ` +
+			`\(SELECT "id" FROM "children" WHERE parent_user_id = \$1 AND "children"."deleted_at" IS NULL\) ORDER BY created_at DESC`).
+			WithArgs(parentUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+
+		res, err := repo.FindByParentUserID(ctx, parentUserID)
+		require.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "care_relationships"`).
+			WithArgs(parentUserID).
+			WillReturnError(assert.AnError)
+
+		res, err := repo.FindByParentUserID(ctx, parentUserID)
+		require.Error(t, err)
+		require.Nil(t, res)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestCareRelationshipRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewCareRelationshipRepository(kit.DB)
+
+	childID := uuid.New()
+	therapistID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec(`^DELETE FROM "care_relationships" WHERE child_id = \$1 AND therapist_id = \$2`).
+			WithArgs(childID, therapistID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := repo.Delete(ctx, childID, therapistID)
+		require.NoError(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec(`^DELETE FROM "care_relationships"`).
+			WithArgs(childID, therapistID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := repo.Delete(ctx, childID, therapistID)
+		require.Error(t, err)
+		assert.Equal(t, repository.ErrNotFound, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec(`^DELETE FROM "care_relationships"`).
+			WithArgs(childID, therapistID).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.Delete(ctx, childID, therapistID)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestCareRelationshipRepository_DeleteByUser(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewCareRelationshipRepository(kit.DB)
+
+	childID := uuid.New()
+	userID := uuid.New()
+	query := `^DELETE FROM "care_relationships" WHERE child_id = \$1 AND \(granted_by = \$2 OR therapist_id = \$3\)$`
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec(query).
+			WithArgs(childID, userID, userID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		dbMock.ExpectCommit()
+
+		err := repo.DeleteByUser(ctx, childID, userID)
+		require.NoError(t, err)
+	})
+
+	t.Run("nothing to delete", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec(query).
+			WithArgs(childID, userID, userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := repo.DeleteByUser(ctx, childID, userID)
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec(query).
+			WithArgs(childID, userID, userID).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.DeleteByUser(ctx, childID, userID)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
@@ -23,7 +23,8 @@ func NewChildRepository(db *gorm.DB) *ChildRepository {
 	}
 }
 
-// Create create a new record on children table
+// Create create a new record on children table. The parent is also recorded as the owner of the child
+// on the child_guardians table in the same transaction
 func (r *ChildRepository) Create(ctx context.Context, input usecase.RepoCreateChildInput) (*model.Child, error) {
 	child := &model.Child{
 		ParentUserID: input.ParentUserID,
@@ -33,7 +34,17 @@ func (r *ChildRepository) Create(ctx context.Context, input usecase.RepoCreateCh
 		GuardianName: input.GuardianName,
 	}
 
-	err := r.db.WithContext(ctx).Create(child).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(child).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&model.ChildGuardian{
+			ChildID: child.ID,
+			UserID:  child.ParentUserID,
+			Role:    model.GuardianRoleOwner,
+		}).Error
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +76,14 @@ func updateChildInputToUpdateFields(uci usecase.RepoUpdateChildInput) map[string
 		}
 	}
 
+	if uci.ArchivedAt != nil {
+		if uci.ArchivedAt.Valid {
+			fields["archived_at"] = uci.ArchivedAt.Time
+		} else {
+			fields["archived_at"] = gorm.Expr("NULL")
+		}
+	}
+
 	return fields
 }
 
@@ -103,6 +122,18 @@ func buildSearchFieldFromSearchChildInput(cursor *gorm.DB, sci usecase.RepoSearc
 		cursor = cursor.Where("parent_user_id = ?", sci.ParentUserID)
 	}
 
+	if sci.GuardianUserID != nil {
+		cursor = cursor.Where("id IN ("+guardianChildIDsQuery+")", *sci.GuardianUserID)
+	}
+
+	if sci.TherapistID != nil {
+		cursor = cursor.Where("id IN (SELECT child_id FROM care_relationships WHERE therapist_id = ?)", *sci.TherapistID)
+	}
+
+	if sci.OrganizationID != nil {
+		cursor = cursor.Where("id IN ("+organizationChildIDsQuery+")", *sci.OrganizationID)
+	}
+
 	if sci.Name != nil {
 		cursor = cursor.Where("name ILIKE ?", fmt.Sprintf("%%%s%%", *sci.Name))
 	}
@@ -111,6 +142,14 @@ func buildSearchFieldFromSearchChildInput(cursor *gorm.DB, sci usecase.RepoSearc
 		cursor = cursor.Where("gender = ?", *sci.Gender)
 	}
 
+	if sci.Archived != nil {
+		if *sci.Archived {
+			cursor = cursor.Where("archived_at IS NOT NULL")
+		} else {
+			cursor = cursor.Where("archived_at IS NULL")
+		}
+	}
+
 	if sci.Limit > 0 {
 		cursor = cursor.Limit(sci.Limit)
 	}
@@ -141,7 +180,8 @@ func (r *ChildRepository) Search(ctx context.Context, input usecase.RepoSearchCh
 	return children, nil
 }
 
-// DeleteAllUserChildren delete all children of the userID
+// DeleteAllUserChildren delete all children of the userID. Unless input.HardDelete is true, the children shared with
+// other guardians are kept. Thus before hard deleting, the shared children must be handed over to another guardian
 func (r *ChildRepository) DeleteAllUserChildren(ctx context.Context, input usecase.RepoDeleteAllUserChildrenInput, txController ...*gorm.DB) error {
 	tx := r.db
 	if len(txController) > 0 {
@@ -152,10 +192,85 @@ func (r *ChildRepository) DeleteAllUserChildren(ctx context.Context, input useca
 		tx = tx.Unscoped()
 	}
 
-	err := tx.WithContext(ctx).Where("parent_user_id = ?", input.UserID).Delete(&model.Child{}).Error
+	cursor := tx.WithContext(ctx).Where("parent_user_id = ?", input.UserID)
+
+	// the shared children are kept for the other guardians while the account deletion can still be cancelled
+	if !input.HardDelete {
+		cursor = cursor.Where("id NOT IN ("+sharedChildIDsQuery+")", input.UserID)
+	}
+
+	err := cursor.Delete(&model.Child{}).Error
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// RestoreAllUserChildren restore the user's soft deleted children which were deleted at or after input.DeletedSince
+func (r *ChildRepository) RestoreAllUserChildren(
+	ctx context.Context, input usecase.RepoRestoreAllUserChildrenInput, txController ...*gorm.DB,
+) error {
+	tx := r.db
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	return tx.WithContext(ctx).Unscoped().Model(&model.Child{}).
+		Where("parent_user_id = ? AND deleted_at >= ?", input.UserID, input.DeletedSince).
+		Update("deleted_at", nil).Error
+}
+
+// TransferOwnership move the child to input.ToUserID as long as it is still owned by input.FromUserID.
+// The owner membership is moved as well, replacing the recipient's guardian membership if any, thus the
+// previous owner loses the access to the child. Should be called inside a transaction.
+// Will return ErrNotFound if the child is not owned by input.FromUserID
+func (r *ChildRepository) TransferOwnership(ctx context.Context, input usecase.RepoTransferChildInput, txController ...*gorm.DB) error {
+	tx := r.db
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	tx = tx.WithContext(ctx)
+
+	res := tx.Model(&model.Child{}).
+		Where("id = ? AND parent_user_id = ?", input.ChildID, input.FromUserID).
+		Update("parent_user_id", input.ToUserID)
+
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	err := tx.Where("child_id = ? AND user_id = ?", input.ChildID, input.ToUserID).Delete(&model.ChildGuardian{}).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&model.ChildGuardian{}).
+		Where("child_id = ? AND role = ?", input.ChildID, model.GuardianRoleOwner).
+		Update("user_id", input.ToUserID).Error
+}
+
+// DeleteByID permanently delete the child. The child's guardians and care relationships are deleted by the database,
+// while the child's results must be deleted or detached beforehand. Will return ErrNotFound if the child doesn't exist
+func (r *ChildRepository) DeleteByID(ctx context.Context, id uuid.UUID, txController ...*gorm.DB) error {
+	tx := r.db
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	res := tx.WithContext(ctx).Unscoped().Where("id = ?", id).Delete(&model.Child{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"gorm.io/gorm"
+)
+
+// guardianChildIDsQuery select the ids of the children the user is the owner or a guardian of
+const guardianChildIDsQuery = `SELECT child_id FROM child_guardians WHERE user_id = ?`
+
+// sharedChildIDsQuery select the ids of the children having any guardian other than the user
+const sharedChildIDsQuery = `SELECT child_id FROM child_guardians WHERE user_id <> ?`
+
+// ChildGuardianRepository is an instance containing functions to interact specifically to child_guardians table
+type ChildGuardianRepository struct {
+	db *gorm.DB
+}
+
+// NewChildGuardianRepository create a new instance of ChildGuardianRepository
+func NewChildGuardianRepository(db *gorm.DB) *ChildGuardianRepository {
+	return &ChildGuardianRepository{
+		db: db,
+	}
+}
+
+// Create add the user to the child's guardians. ErrDuplicate will be returned if the user is already
+// one of the child's guardians, or when adding a second owner to the child
+func (r *ChildGuardianRepository) Create(
+	ctx context.Context, input usecase.RepoCreateChildGuardianInput, txController ...*gorm.DB,
+) (*model.ChildGuardian, error) {
+	tx := r.db.WithContext(ctx)
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	guardian := &model.ChildGuardian{
+		ChildID: input.ChildID,
+		UserID:  input.UserID,
+		Role:    input.Role,
+	}
+
+	err := tx.Create(guardian).Error
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicate
+		}
+
+		return nil, err
+	}
+
+	return guardian, nil
+}
+
+// FindByChildIDAndUserID find the user's guardian membership of the child
+func (r *ChildGuardianRepository) FindByChildIDAndUserID(ctx context.Context, childID, userID uuid.UUID) (*model.ChildGuardian, error) {
+	guardian := &model.ChildGuardian{}
+
+	err := r.db.WithContext(ctx).Take(guardian, "child_id = ? AND user_id = ?", childID, userID).Error
+	switch err {
+	default:
+		return nil, err
+	case gorm.ErrRecordNotFound:
+		return nil, ErrNotFound
+	case nil:
+		return guardian, nil
+	}
+}
+
+// FindByChildID find all the child's guardian memberships including the owner, oldest first
+func (r *ChildGuardianRepository) FindByChildID(ctx context.Context, childID uuid.UUID) ([]model.ChildGuardian, error) {
+	guardians := []model.ChildGuardian{}
+
+	err := r.db.WithContext(ctx).Where("child_id = ?", childID).Order("created_at ASC").Find(&guardians).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return guardians, nil
+}
+
+// DeleteGuardian remove the user from the child's guardians. The owner membership is never removed,
+// thus ErrNotFound will be returned if the user is the owner or not a guardian of the child
+func (r *ChildGuardianRepository) DeleteGuardian(ctx context.Context, childID, userID uuid.UUID) error {
+	res := r.db.WithContext(ctx).
+		Where("child_id = ? AND user_id = ? AND role = ?", childID, userID, model.GuardianRoleGuardian).
+		Delete(&model.ChildGuardian{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// FindSuccessors find, for each child owned by the user and shared with other guardians, the guardian membership
+// who joined the child the earliest. Used to hand the children over before the owner's account is deleted
+func (r *ChildGuardianRepository) FindSuccessors(
+	ctx context.Context, ownerUserID uuid.UUID, txController ...*gorm.DB,
+) ([]model.ChildGuardian, error) {
+	tx := r.db
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	guardians := []model.ChildGuardian{}
+
+	err := tx.WithContext(ctx).Select("DISTINCT ON (child_id) *").
+		Where("role = ? AND child_id IN (?)",
+			model.GuardianRoleGuardian,
+			r.db.Model(&model.Child{}).Select("id").Where("parent_user_id = ?", ownerUserID),
+		).
+		Order("child_id, created_at ASC").
+		Find(&guardians).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return guardians, nil
+}
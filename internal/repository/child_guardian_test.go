@@ -0,0 +1,272 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/repository"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestChildGuardianRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewChildGuardianRepository(kit.DB)
+
+	id := uuid.New()
+	input := usecase.RepoCreateChildGuardianInput{
+		ChildID: uuid.New(),
+		UserID:  uuid.New(),
+		Role:    model.GuardianRoleGuardian,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery(`^INSERT INTO "child_guardians"`).
+			WithArgs(input.ChildID, input.UserID, input.Role, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+		dbMock.ExpectCommit()
+
+		res, err := repo.Create(ctx, input)
+		require.NoError(t, err)
+		assert.Equal(t, id, res.ID)
+		assert.Equal(t, model.GuardianRoleGuardian, res.Role)
+	})
+
+	t.Run("already a guardian of the child", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery(`^INSERT INTO "child_guardians"`).
+			WillReturnError(&pgconn.PgError{Code: "23505"})
+
+		dbMock.ExpectRollback()
+
+		res, err := repo.Create(ctx, input)
+		require.Error(t, err)
+		require.Nil(t, res)
+		assert.Equal(t, repository.ErrDuplicate, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery(`^INSERT INTO "child_guardians"`).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		res, err := repo.Create(ctx, input)
+		require.Error(t, err)
+		require.Nil(t, res)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestChildGuardianRepository_FindByChildIDAndUserID(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewChildGuardianRepository(kit.DB)
+
+	childID := uuid.New()
+	userID := uuid.New()
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "child_guardians" WHERE child_id = \$1 AND user_id = \$2`).
+					WithArgs(childID, userID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"child_id", "user_id", "role"}).
+						AddRow(childID, userID, model.GuardianRoleGuardian))
+			},
+		},
+		{
+			name:        "error - unknown just pass the error to the caller",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "child_guardians"`).
+					WithArgs(childID, userID, 1).
+					WillReturnError(assert.AnError)
+			},
+		},
+		{
+			name:        "the user is not a guardian of the child",
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "child_guardians"`).
+					WithArgs(childID, userID, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := repo.FindByChildIDAndUserID(ctx, childID, userID)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				if tc.expectedErr != nil {
+					assert.Equal(t, tc.expectedErr, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, model.GuardianRoleGuardian, res.Role)
+		})
+	}
+}
+
+func TestChildGuardianRepository_FindByChildID(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewChildGuardianRepository(kit.DB)
+
+	childID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT \* FROM "child_guardians" WHERE child_id = \$1 ORDER BY created_at ASC`).
+			WithArgs(childID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+
+		res, err := repo.FindByChildID(ctx, childID)
+		require.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "child_guardians"`).
+			WithArgs(childID).
+			WillReturnError(assert.AnError)
+
+		res, err := repo.FindByChildID(ctx, childID)
+		require.Error(t, err)
+		require.Nil(t, res)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestChildGuardianRepository_DeleteGuardian(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewChildGuardianRepository(kit.DB)
+
+	childID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec(`^DELETE FROM "child_guardians" WHERE child_id = \$1 AND user_id = \$2 AND role = \$3`).
+			WithArgs(childID, userID, model.GuardianRoleGuardian).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := repo.DeleteGuardian(ctx, childID, userID)
+		require.NoError(t, err)
+	})
+
+	t.Run("the owner or not a guardian of the child", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec(`^DELETE FROM "child_guardians"`).
+			WithArgs(childID, userID, model.GuardianRoleGuardian).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := repo.DeleteGuardian(ctx, childID, userID)
+		assert.Equal(t, repository.ErrNotFound, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec(`^DELETE FROM "child_guardians"`).
+			WithArgs(childID, userID, model.GuardianRoleGuardian).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.DeleteGuardian(ctx, childID, userID)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestChildGuardianRepository_FindSuccessors(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewChildGuardianRepository(kit.DB)
+
+	ownerUserID := uuid.New()
+	successorsQuery := `^SELECT DISTINCT ON \(child_id\) \* FROM "child_guardians" WHERE role = \$1 AND child_id IN ` +
+		`\(SELECT "id" FROM "children" WHERE parent_user_id = \$2 AND "children"."deleted_at" IS NULL\) ORDER BY child_id, created_at ASC$`
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectQuery(successorsQuery).
+			WithArgs(model.GuardianRoleGuardian, ownerUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"child_id", "user_id", "role"}).
+				AddRow(uuid.New(), uuid.New(), model.GuardianRoleGuardian))
+
+		res, err := repo.FindSuccessors(ctx, ownerUserID)
+		require.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectQuery(successorsQuery).
+			WithArgs(model.GuardianRoleGuardian, ownerUserID).
+			WillReturnError(assert.AnError)
+
+		res, err := repo.FindSuccessors(ctx, ownerUserID)
+		require.Error(t, err)
+		require.Nil(t, res)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
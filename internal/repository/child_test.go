@@ -57,9 +57,13 @@ func TestChildRepository_Create(t *testing.T) {
 				dbMock.ExpectBegin()
 
 				dbMock.ExpectQuery("^INSERT INTO \"children\"").
-					WithArgs(parentUserID, dateOfBirth, gender, name, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(parentUserID, dateOfBirth, gender, name, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(dbGeneratedUUID))
 
+				dbMock.ExpectQuery("^INSERT INTO \"child_guardians\"").
+					WithArgs(dbGeneratedUUID, parentUserID, model.GuardianRoleOwner, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
 				dbMock.ExpectCommit()
 			},
 			expectedOutput: &model.Child{
@@ -84,9 +88,13 @@ func TestChildRepository_Create(t *testing.T) {
 				dbMock.ExpectBegin()
 
 				dbMock.ExpectQuery("^INSERT INTO \"children\"").
-					WithArgs(parentUserID, dateOfBirth, gender, name, "Guardian", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(parentUserID, dateOfBirth, gender, name, "Guardian", nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(dbGeneratedUUID))
 
+				dbMock.ExpectQuery("^INSERT INTO \"child_guardians\"").
+					WithArgs(dbGeneratedUUID, parentUserID, model.GuardianRoleOwner, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
 				dbMock.ExpectCommit()
 			},
 			expectedOutput: &model.Child{
@@ -110,7 +118,30 @@ func TestChildRepository_Create(t *testing.T) {
 				dbMock.ExpectBegin()
 
 				dbMock.ExpectQuery("^INSERT INTO \"children\"").
-					WithArgs(parentUserID, dateOfBirth, gender, name, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(parentUserID, dateOfBirth, gender, name, sqlmock.AnyArg(), nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name: "failed to record the parent as the owner",
+			input: usecase.RepoCreateChildInput{
+				ParentUserID: parentUserID,
+				DateOfBirth:  dateOfBirth,
+				Gender:       gender,
+				Name:         name,
+			},
+			wantErr: true,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^INSERT INTO \"children\"").
+					WithArgs(parentUserID, dateOfBirth, gender, name, sqlmock.AnyArg(), nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(dbGeneratedUUID))
+
+				dbMock.ExpectQuery("^INSERT INTO \"child_guardians\"").
+					WithArgs(dbGeneratedUUID, parentUserID, model.GuardianRoleOwner, sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnError(assert.AnError)
 
 				dbMock.ExpectRollback()
@@ -163,6 +194,7 @@ func TestChildRepository_Update(t *testing.T) {
 	dateOfBirth := time.Now().Add(-time.Hour * 24 * 365 * 5)
 	gender := false
 	name := "Jane Doe"
+	archivedAt := time.Now().UTC()
 
 	testCases := []struct {
 		name                 string
@@ -197,6 +229,47 @@ func TestChildRepository_Update(t *testing.T) {
 				DateOfBirth:  dateOfBirth,
 			},
 		},
+		{
+			name: "archive the child",
+			input: usecase.RepoUpdateChildInput{
+				ArchivedAt: &sql.NullTime{Time: archivedAt, Valid: true},
+			},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery(`^UPDATE "children" SET "archived_at"=\$1,"updated_at"=\$2 WHERE id = \$3`).
+					WithArgs(archivedAt, sqlmock.AnyArg(), childID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "parent_user_id", "archived_at"}).AddRow(childID, parentUserID, archivedAt))
+
+				dbMock.ExpectCommit()
+			},
+			expectedOutput: &model.Child{
+				ID:           childID,
+				ParentUserID: parentUserID,
+				ArchivedAt:   sql.NullTime{Time: archivedAt, Valid: true},
+			},
+		},
+		{
+			name: "restore the archived child",
+			input: usecase.RepoUpdateChildInput{
+				ArchivedAt: &sql.NullTime{},
+			},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery(`^UPDATE "children" SET "archived_at"=NULL,"updated_at"=\$1 WHERE id = \$2`).
+					WithArgs(sqlmock.AnyArg(), childID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "parent_user_id"}).AddRow(childID, parentUserID))
+
+				dbMock.ExpectCommit()
+			},
+			expectedOutput: &model.Child{
+				ID:           childID,
+				ParentUserID: parentUserID,
+			},
+		},
 		{
 			name: "error",
 			input: usecase.RepoUpdateChildInput{
@@ -370,6 +443,8 @@ func TestChildRepository_Search(t *testing.T) {
 
 	childID := uuid.New()
 	parentUserID := uuid.New()
+	therapistID := uuid.New()
+	organizationID := uuid.New()
 	name := "mary currie"
 	gender := true
 	limit := 111
@@ -427,6 +502,80 @@ func TestChildRepository_Search(t *testing.T) {
 					)
 			},
 		},
+		{
+			name: "only the children assigned to the therapist",
+			input: usecase.RepoSearchChildInput{
+				TherapistID: &therapistID,
+				Limit:       limit,
+			},
+			wantErr:           false,
+			expectedOutputLen: 1,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`SELECT .+ FROM "children" WHERE id IN \(SELECT child_id FROM care_relationships WHERE therapist_id = \$1\)`).
+					WithArgs(therapistID, limit).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(childID))
+			},
+		},
+		{
+			name: "only the children the user is the owner or a guardian of",
+			input: usecase.RepoSearchChildInput{
+				GuardianUserID: &parentUserID,
+				Limit:          limit,
+			},
+			wantErr:           false,
+			expectedOutputLen: 1,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`SELECT .+ FROM "children" WHERE id IN \(SELECT child_id FROM child_guardians WHERE user_id = \$1\)`).
+					WithArgs(parentUserID, limit).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(childID))
+			},
+		},
+		{
+			name: "only the active children the user is the owner or a guardian of",
+			input: usecase.RepoSearchChildInput{
+				GuardianUserID: &parentUserID,
+				Archived:       &[]bool{false}[0],
+				Limit:          limit,
+			},
+			wantErr:           false,
+			expectedOutputLen: 1,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`SELECT .+ FROM "children" WHERE id IN \(SELECT child_id FROM child_guardians WHERE user_id = \$1\) `+
+					`AND archived_at IS NULL AND "children"."deleted_at" IS NULL`).
+					WithArgs(parentUserID, limit).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(childID))
+			},
+		},
+		{
+			name: "only the archived children",
+			input: usecase.RepoSearchChildInput{
+				ParentUserID: &parentUserID,
+				Archived:     &[]bool{true}[0],
+				Limit:        limit,
+			},
+			wantErr:           false,
+			expectedOutputLen: 1,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`SELECT .+ FROM "children" WHERE parent_user_id = \$1 AND archived_at IS NOT NULL`).
+					WithArgs(parentUserID, limit).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(childID))
+			},
+		},
+		{
+			name: "only the children taken care of by the organization",
+			input: usecase.RepoSearchChildInput{
+				OrganizationID: &organizationID,
+				Limit:          limit,
+			},
+			wantErr:           false,
+			expectedOutputLen: 1,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`SELECT .+ FROM "children" WHERE \(id IN \(SELECT care_relationships.child_id FROM care_relationships\s+`+
+					`JOIN users ON users.id = care_relationships.therapist_id WHERE users.organization_id = \$1 AND users.deleted_at IS NULL\)`).
+					WithArgs(organizationID, limit).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(childID))
+			},
+		},
 		{
 			name: "error db",
 			input: usecase.RepoSearchChildInput{
@@ -529,6 +678,23 @@ func TestChildRepository_DeleteAllUserChildren(t *testing.T) {
 				dbMock.ExpectCommit()
 			},
 		},
+		{
+			name: "success - soft delete keeps the shared children",
+			input: usecase.RepoDeleteAllUserChildrenInput{
+				UserID: userID,
+			},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "children" SET "deleted_at"=\$1 WHERE parent_user_id = \$2 AND `+
+					`id NOT IN \(SELECT child_id FROM child_guardians WHERE user_id <> \$3\) AND "children"."deleted_at" IS NULL$`).
+					WithArgs(sqlmock.AnyArg(), userID, userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbMock.ExpectCommit()
+			},
+		},
 		{
 			name: "error - hard delete",
 			input: usecase.RepoDeleteAllUserChildrenInput{
@@ -571,3 +737,263 @@ func TestChildRepository_DeleteAllUserChildren(t *testing.T) {
 		})
 	}
 }
+
+func TestChildRepository_RestoreAllUserChildren(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewChildRepository(kit.DB)
+
+	input := usecase.RepoRestoreAllUserChildrenInput{
+		UserID:       uuid.New(),
+		DeletedSince: time.Now().Add(-time.Hour),
+	}
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "children" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE parent_user_id = \$3 AND deleted_at >= \$4`).
+					WithArgs(nil, sqlmock.AnyArg(), input.UserID, input.DeletedSince).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "children" SET`).
+					WithArgs(nil, sqlmock.AnyArg(), input.UserID, input.DeletedSince).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := repo.RestoreAllUserChildren(ctx, input, kit.DB)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestChildRepository_TransferOwnership(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewChildRepository(kit.DB)
+
+	input := usecase.RepoTransferChildInput{
+		ChildID:    uuid.New(),
+		FromUserID: uuid.New(),
+		ToUserID:   uuid.New(),
+	}
+
+	expectChildUpdate := func() *sqlmock.ExpectedExec {
+		dbMock.ExpectBegin()
+
+		return dbMock.ExpectExec(regexp.QuoteMeta(`UPDATE "children" SET "parent_user_id"=$1,"updated_at"=$2 `+
+			`WHERE (id = $3 AND parent_user_id = $4) AND "children"."deleted_at" IS NULL`)).
+			WithArgs(input.ToUserID, sqlmock.AnyArg(), input.ChildID, input.FromUserID)
+	}
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "child is not owned by the previous owner",
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				expectChildUpdate().WillReturnResult(sqlmock.NewResult(0, 0))
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "failed to update the child",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				expectChildUpdate().WillReturnError(assert.AnError)
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name:        "failed to move the owner membership",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				expectChildUpdate().WillReturnResult(sqlmock.NewResult(0, 1))
+				dbMock.ExpectCommit()
+
+				dbMock.ExpectBegin()
+				dbMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "child_guardians" WHERE child_id = $1 AND user_id = $2`)).
+					WithArgs(input.ChildID, input.ToUserID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				dbMock.ExpectCommit()
+
+				dbMock.ExpectBegin()
+				dbMock.ExpectExec(regexp.QuoteMeta(`UPDATE "child_guardians" SET "user_id"=$1,"updated_at"=$2 WHERE child_id = $3 AND role = $4`)).
+					WithArgs(input.ToUserID, sqlmock.AnyArg(), input.ChildID, model.GuardianRoleOwner).
+					WillReturnError(assert.AnError)
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				expectChildUpdate().WillReturnResult(sqlmock.NewResult(0, 1))
+				dbMock.ExpectCommit()
+
+				dbMock.ExpectBegin()
+				dbMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "child_guardians" WHERE child_id = $1 AND user_id = $2`)).
+					WithArgs(input.ChildID, input.ToUserID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbMock.ExpectCommit()
+
+				dbMock.ExpectBegin()
+				dbMock.ExpectExec(regexp.QuoteMeta(`UPDATE "child_guardians" SET "user_id"=$1,"updated_at"=$2 WHERE child_id = $3 AND role = $4`)).
+					WithArgs(input.ToUserID, sqlmock.AnyArg(), input.ChildID, model.GuardianRoleOwner).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbMock.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := repo.TransferOwnership(ctx, input, kit.DB)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestChildRepository_DeleteByID(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewChildRepository(kit.DB)
+
+	childID := uuid.New()
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "not found",
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "children" WHERE id = $1`)).
+					WithArgs(childID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "children" WHERE id = $1`)).
+					WithArgs(childID).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "children" WHERE id = $1`)).
+					WithArgs(childID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbMock.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := repo.DeleteByID(ctx, childID, kit.DB)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"gorm.io/gorm"
+)
+
+// DataExportRepository is an instance containing functions to interact specifically to data_exports table
+type DataExportRepository struct {
+	db *gorm.DB
+}
+
+// NewDataExportRepository create a new instance of DataExportRepository
+func NewDataExportRepository(db *gorm.DB) *DataExportRepository {
+	return &DataExportRepository{
+		db: db,
+	}
+}
+
+// Create insert a new pending data export for the user. ErrDuplicate will be returned
+// if the user still has another data export being prepared
+func (r *DataExportRepository) Create(ctx context.Context, userID uuid.UUID) (*model.DataExport, error) {
+	export := &model.DataExport{
+		UserID: userID,
+		Status: model.DataExportStatusPending,
+	}
+
+	err := r.db.WithContext(ctx).Create(export).Error
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicate
+		}
+
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// FindByUserID find all the user's data exports, newest first. The archive is not loaded
+func (r *DataExportRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]model.DataExport, error) {
+	exports := []model.DataExport{}
+
+	err := r.db.WithContext(ctx).Omit("archive").Where("user_id = ?", userID).Order("created_at DESC").Find(&exports).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
+
+// FindByDownloadTokenHash find exactly one record from data_exports table with matching download token hash
+func (r *DataExportRepository) FindByDownloadTokenHash(ctx context.Context, tokenHash string) (*model.DataExport, error) {
+	export := &model.DataExport{}
+
+	err := r.db.WithContext(ctx).Take(export, "download_token_hash = ?", tokenHash).Error
+	switch err {
+	default:
+		return nil, err
+	case gorm.ErrRecordNotFound:
+		return nil, ErrNotFound
+	case nil:
+		return export, nil
+	}
+}
+
+// UpdateStatus change the data export status. ErrNotFound will be returned if the data export does not exist
+func (r *DataExportRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status model.DataExportStatus) error {
+	res := r.db.WithContext(ctx).Model(&model.DataExport{}).Where("id = ?", id).Update("status", status)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// FailStaleByUserID mark the user's data exports which have been pending or processing since before the given time
+// as failed, so an abandoned export doesn't prevent the user from requesting a new one
+func (r *DataExportRepository) FailStaleByUserID(ctx context.Context, userID uuid.UUID, updatedBefore time.Time) error {
+	return r.db.WithContext(ctx).Model(&model.DataExport{}).
+		Where("user_id = ? AND status IN ? AND updated_at < ?", userID,
+			[]model.DataExportStatus{model.DataExportStatusPending, model.DataExportStatusProcessing}, updatedBefore).
+		Update("status", model.DataExportStatusFailed).Error
+}
+
+// DeleteExpired permanently delete the completed data exports whose download link has expired, along with
+// the failed ones last updated before failedBefore, so the archives don't pile up on the database.
+// Returns the number of deleted data exports
+func (r *DataExportRepository) DeleteExpired(ctx context.Context, failedBefore time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).
+		Where("(status = ? AND expires_at < ?) OR (status = ? AND updated_at < ?)",
+			model.DataExportStatusCompleted, time.Now(), model.DataExportStatusFailed, failedBefore).
+		Delete(&model.DataExport{})
+	if res.Error != nil {
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, nil
+}
+
+// Complete store the finished archive along with the hash of its download token, and mark the data export as completed.
+// ErrNotFound will be returned if the data export does not exist
+func (r *DataExportRepository) Complete(ctx context.Context, input usecase.RepoCompleteDataExportInput) error {
+	res := r.db.WithContext(ctx).Model(&model.DataExport{}).Where("id = ?", input.ID).Updates(map[string]interface{}{
+		"status":              model.DataExportStatusCompleted,
+		"archive":             input.Archive,
+		"download_token_hash": input.DownloadTokenHash,
+		"expires_at":          input.ExpiresAt,
+		"completed_at":        time.Now(),
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,417 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/repository"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestDataExportRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewDataExportRepository(kit.DB)
+
+	id := uuid.New()
+	userID := uuid.New()
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^INSERT INTO \"data_exports\"").
+					WithArgs(
+						userID, model.DataExportStatusPending, sqlmock.AnyArg(), nil, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(),
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "another export still in progress",
+			wantErr:     true,
+			expectedErr: repository.ErrDuplicate,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^INSERT INTO \"data_exports\"").
+					WillReturnError(&pgconn.PgError{Code: "23505"})
+
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name:        "error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^INSERT INTO \"data_exports\"").
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := repo.Create(ctx, userID)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				if tc.expectedErr != nil {
+					assert.Equal(t, tc.expectedErr, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, id, res.ID)
+			assert.Equal(t, model.DataExportStatusPending, res.Status)
+		})
+	}
+}
+
+func TestDataExportRepository_FindByUserID(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewDataExportRepository(kit.DB)
+
+	userID := uuid.New()
+
+	t.Run("success without loading the archive", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT "data_exports"."id","data_exports"."user_id","data_exports"."status","data_exports"."download_token_hash"` +
+			`.+ FROM "data_exports" WHERE user_id = .+ ORDER BY created_at DESC`).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+
+		res, err := repo.FindByUserID(ctx, userID)
+		require.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "data_exports"`).
+			WithArgs(userID).
+			WillReturnError(assert.AnError)
+
+		res, err := repo.FindByUserID(ctx, userID)
+		require.Error(t, err)
+		require.Nil(t, res)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestDataExportRepository_FindByDownloadTokenHash(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewDataExportRepository(kit.DB)
+
+	id := uuid.New()
+	tokenHash := "hashed"
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "data_exports"`).
+					WithArgs(tokenHash, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "archive"}).AddRow(id, []byte("zip")))
+			},
+		},
+		{
+			name:        "error - unknown just pass the error to the caller",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "data_exports"`).
+					WithArgs(tokenHash, 1).
+					WillReturnError(assert.AnError)
+			},
+		},
+		{
+			name:        "data not found on db",
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "data_exports"`).
+					WithArgs(tokenHash, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := repo.FindByDownloadTokenHash(ctx, tokenHash)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				if tc.expectedErr != nil {
+					assert.Equal(t, tc.expectedErr, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, id, res.ID)
+			assert.Equal(t, []byte("zip"), res.Archive)
+		})
+	}
+}
+
+func TestDataExportRepository_UpdateStatus(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewDataExportRepository(kit.DB)
+
+	id := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"data_exports\" SET").
+			WithArgs(model.DataExportStatusProcessing, sqlmock.AnyArg(), id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := repo.UpdateStatus(ctx, id, model.DataExportStatusProcessing)
+		require.NoError(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"data_exports\" SET").
+			WithArgs(model.DataExportStatusFailed, sqlmock.AnyArg(), id).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := repo.UpdateStatus(ctx, id, model.DataExportStatusFailed)
+		require.Error(t, err)
+		assert.Equal(t, repository.ErrNotFound, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"data_exports\" SET").
+			WithArgs(model.DataExportStatusFailed, sqlmock.AnyArg(), id).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.UpdateStatus(ctx, id, model.DataExportStatusFailed)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestDataExportRepository_FailStaleByUserID(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewDataExportRepository(kit.DB)
+
+	userID := uuid.New()
+	updatedBefore := time.Now().Add(-time.Hour)
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec(`^UPDATE "data_exports" SET "status"=\$1,"updated_at"=\$2 WHERE user_id = \$3 AND status IN \(\$4,\$5\) AND updated_at < \$6`).
+			WithArgs(model.DataExportStatusFailed, sqlmock.AnyArg(), userID,
+				model.DataExportStatusPending, model.DataExportStatusProcessing, updatedBefore).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := repo.FailStaleByUserID(ctx, userID, updatedBefore)
+		require.NoError(t, err)
+	})
+
+	t.Run("nothing is stale", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"data_exports\" SET").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := repo.FailStaleByUserID(ctx, userID, updatedBefore)
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"data_exports\" SET").
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.FailStaleByUserID(ctx, userID, updatedBefore)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestDataExportRepository_DeleteExpired(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewDataExportRepository(kit.DB)
+
+	failedBefore := time.Now().Add(-7 * 24 * time.Hour)
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec(`^DELETE FROM "data_exports" WHERE \(status = \$1 AND expires_at < \$2\) OR \(status = \$3 AND updated_at < \$4\)`).
+			WithArgs(model.DataExportStatusCompleted, sqlmock.AnyArg(), model.DataExportStatusFailed, failedBefore).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		dbMock.ExpectCommit()
+
+		deleted, err := repo.DeleteExpired(ctx, failedBefore)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"data_exports\"").
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		deleted, err := repo.DeleteExpired(ctx, failedBefore)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+		assert.Zero(t, deleted)
+	})
+}
+
+func TestDataExportRepository_Complete(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewDataExportRepository(kit.DB)
+
+	input := usecase.RepoCompleteDataExportInput{
+		ID:                uuid.New(),
+		Archive:           []byte("zip"),
+		DownloadTokenHash: "hashed",
+		ExpiresAt:         time.Now().Add(time.Hour),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"data_exports\" SET").
+			WithArgs(
+				input.Archive, sqlmock.AnyArg(), input.DownloadTokenHash, input.ExpiresAt,
+				model.DataExportStatusCompleted, sqlmock.AnyArg(), input.ID,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := repo.Complete(ctx, input)
+		require.NoError(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"data_exports\" SET").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := repo.Complete(ctx, input)
+		require.Error(t, err)
+		assert.Equal(t, repository.ErrNotFound, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"data_exports\" SET").
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.Complete(ctx, input)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"gorm.io/gorm"
+)
+
+// EmailTokenRepository is an instance containing functions to interact specifically to email_tokens table
+type EmailTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailTokenRepository create a new instance of EmailTokenRepository
+func NewEmailTokenRepository(db *gorm.DB) *EmailTokenRepository {
+	return &EmailTokenRepository{
+		db: db,
+	}
+}
+
+// Create insert a new record to email_tokens table
+func (r *EmailTokenRepository) Create(
+	ctx context.Context,
+	input usecase.RepoCreateEmailTokenInput,
+	txController ...*gorm.DB,
+) (*model.EmailToken, error) {
+	tx := r.db.WithContext(ctx)
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	token := &model.EmailToken{
+		ID:        input.ID,
+		UserID:    uuid.NullUUID{UUID: input.UserID, Valid: input.UserID != uuid.Nil},
+		Purpose:   input.Purpose,
+		ExpiresAt: input.ExpiresAt,
+	}
+
+	err := tx.Create(token).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// Consume mark the email token as consumed. The update is only applied if the token is still
+// redeemable, meaning not yet consumed, not revoked and not expired. If nothing was updated,
+// ErrNotFound will be returned
+func (r *EmailTokenRepository) Consume(
+	ctx context.Context,
+	input usecase.RepoConsumeEmailTokenInput,
+	txController ...*gorm.DB,
+) error {
+	tx := r.db.WithContext(ctx)
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	cursor := tx.Model(&model.EmailToken{}).Where("id = ?", input.ID)
+
+	if input.UserID == uuid.Nil {
+		cursor = cursor.Where("user_id IS NULL")
+	} else {
+		cursor = cursor.Where("user_id = ?", input.UserID)
+	}
+
+	res := cursor.
+		Where("purpose = ? AND consumed_at IS NULL AND revoked_at IS NULL AND expires_at > ?", input.Purpose, time.Now()).
+		Update("consumed_at", time.Now())
+
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// RevokeAllUserTokens mark all the user's redeemable email tokens with the given purpose as revoked
+func (r *EmailTokenRepository) RevokeAllUserTokens(
+	ctx context.Context, userID uuid.UUID, purpose string, txController ...*gorm.DB,
+) error {
+	tx := r.db.WithContext(ctx)
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	return tx.Model(&model.EmailToken{}).
+		Where("user_id = ? AND purpose = ? AND consumed_at IS NULL AND revoked_at IS NULL", userID, purpose).
+		Update("revoked_at", time.Now()).Error
+}
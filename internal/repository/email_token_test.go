@@ -0,0 +1,244 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/repository"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailTokenRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewEmailTokenRepository(kit.DB)
+
+	input := usecase.RepoCreateEmailTokenInput{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		Purpose:   string(usecase.ChangePasswordToken),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec("^INSERT INTO \"email_tokens\"").
+					WithArgs(input.ID, input.UserID, input.Purpose, input.ExpiresAt, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec("^INSERT INTO \"email_tokens\"").
+					WithArgs(input.ID, input.UserID, input.Purpose, input.ExpiresAt, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := repo.Create(ctx, input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				if tc.expectedErr != nil {
+					assert.Equal(t, tc.expectedErr, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, input.ID, res.ID)
+		})
+	}
+}
+
+func TestEmailTokenRepository_Consume(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewEmailTokenRepository(kit.DB)
+
+	input := usecase.RepoConsumeEmailTokenInput{
+		ID:      uuid.New(),
+		UserID:  uuid.New(),
+		Purpose: string(usecase.SignupVerificationToken),
+	}
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec("^UPDATE \"email_tokens\" SET").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), input.ID, input.UserID, input.Purpose, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "nothing consumed means the token is no longer redeemable",
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec("^UPDATE \"email_tokens\" SET").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), input.ID, input.UserID, input.Purpose, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec("^UPDATE \"email_tokens\" SET").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), input.ID, input.UserID, input.Purpose, sqlmock.AnyArg()).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := repo.Consume(ctx, input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+
+				if tc.expectedErr != nil {
+					assert.Equal(t, tc.expectedErr, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEmailTokenRepository_RevokeAllUserTokens(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewEmailTokenRepository(kit.DB)
+
+	userID := uuid.New()
+	purpose := string(usecase.ChangePasswordToken)
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"email_tokens\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, purpose).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		dbMock.ExpectCommit()
+
+		err := repo.RevokeAllUserTokens(ctx, userID, purpose)
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"email_tokens\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, purpose).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.RevokeAllUserTokens(ctx, userID, purpose)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestEmailTokenRepository_ConsumeTokenWithoutUser(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewEmailTokenRepository(kit.DB)
+
+	input := usecase.RepoConsumeEmailTokenInput{
+		ID:      uuid.New(),
+		UserID:  uuid.Nil,
+		Purpose: string(usecase.TherapistInvitation),
+	}
+
+	dbMock.ExpectBegin()
+
+	dbMock.ExpectExec(`^UPDATE "email_tokens" SET .+ WHERE id = .+ AND user_id IS NULL AND`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), input.ID, input.Purpose, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	dbMock.ExpectCommit()
+
+	err := repo.Consume(ctx, input)
+	require.NoError(t, err)
+}
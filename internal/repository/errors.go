@@ -1,9 +1,24 @@
 package repository
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
 
 // known errors that might be returned by this repository's functions
 var (
-	ErrNotFound = errors.New("data not found")
-	ErrTimeout  = errors.New("timeout")
+	ErrNotFound  = errors.New("data not found")
+	ErrTimeout   = errors.New("timeout")
+	ErrDuplicate = errors.New("duplicate data")
 )
+
+// pgUniqueViolationCode postgres error code raised when a unique constraint is violated
+const pgUniqueViolationCode = "23505"
+
+// isUniqueViolation report whether the error is caused by a unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolationCode
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"gorm.io/gorm"
+)
+
+// MFARecoveryCodeRepository is an instance containing functions to interact specifically to mfa_recovery_codes table
+type MFARecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewMFARecoveryCodeRepository create a new instance of MFARecoveryCodeRepository
+func NewMFARecoveryCodeRepository(db *gorm.DB) *MFARecoveryCodeRepository {
+	return &MFARecoveryCodeRepository{
+		db: db,
+	}
+}
+
+// ReplaceAll delete all the user's recovery codes and insert the new ones.
+// Should be called inside a transaction to avoid leaving the user without any recovery codes
+func (r *MFARecoveryCodeRepository) ReplaceAll(ctx context.Context, userID uuid.UUID, codeHashes []string, txController ...*gorm.DB) error {
+	tx := r.db.WithContext(ctx)
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	if err := tx.Where("user_id = ?", userID).Delete(&model.MFARecoveryCode{}).Error; err != nil {
+		return err
+	}
+
+	if len(codeHashes) == 0 {
+		return nil
+	}
+
+	codes := []model.MFARecoveryCode{}
+	for _, hash := range codeHashes {
+		codes = append(codes, model.MFARecoveryCode{
+			UserID:   userID,
+			CodeHash: hash,
+		})
+	}
+
+	return tx.Create(&codes).Error
+}
+
+// Consume mark the recovery code as used. The update is only applied if the code belongs
+// to the user and not yet used. If nothing was updated, ErrNotFound will be returned
+func (r *MFARecoveryCodeRepository) Consume(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	res := r.db.WithContext(ctx).Model(&model.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteAll delete all the user's recovery codes
+func (r *MFARecoveryCodeRepository) DeleteAll(ctx context.Context, userID uuid.UUID, txController ...*gorm.DB) error {
+	tx := r.db.WithContext(ctx)
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	return tx.Where("user_id = ?", userID).Delete(&model.MFARecoveryCode{}).Error
+}
@@ -0,0 +1,167 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMFARecoveryCodeRepository_ReplaceAll(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewMFARecoveryCodeRepository(kit.DB)
+
+	userID := uuid.New()
+	hashes := []string{"hash1", "hash2"}
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"mfa_recovery_codes\"").
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 10))
+
+		dbMock.ExpectCommit()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery("^INSERT INTO \"mfa_recovery_codes\"").
+			WithArgs(userID, "hash1", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				userID, "hash2", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+
+		dbMock.ExpectCommit()
+
+		err := repo.ReplaceAll(ctx, userID, hashes)
+		require.NoError(t, err)
+	})
+
+	t.Run("failed to delete old codes", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"mfa_recovery_codes\"").
+			WithArgs(userID).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.ReplaceAll(ctx, userID, hashes)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+
+	t.Run("using tx controller", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"mfa_recovery_codes\"").
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := repo.ReplaceAll(ctx, userID, nil, kit.DB)
+		require.NoError(t, err)
+	})
+}
+
+func TestMFARecoveryCodeRepository_Consume(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewMFARecoveryCodeRepository(kit.DB)
+
+	userID := uuid.New()
+	hash := "hash"
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"mfa_recovery_codes\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, hash).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := repo.Consume(ctx, userID, hash)
+		require.NoError(t, err)
+	})
+
+	t.Run("already used or not found", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"mfa_recovery_codes\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, hash).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := repo.Consume(ctx, userID, hash)
+		require.Error(t, err)
+		assert.Equal(t, repository.ErrNotFound, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"mfa_recovery_codes\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, hash).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.Consume(ctx, userID, hash)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestMFARecoveryCodeRepository_DeleteAll(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewMFARecoveryCodeRepository(kit.DB)
+
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"mfa_recovery_codes\"").
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 10))
+
+		dbMock.ExpectCommit()
+
+		err := repo.DeleteAll(ctx, userID)
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"mfa_recovery_codes\"").
+			WithArgs(userID).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.DeleteAll(ctx, userID)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"gorm.io/gorm"
+)
+
+// OrganizationRepository is an instance containing functions to interact specifically to organizations table
+type OrganizationRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationRepository create a new instance of OrganizationRepository
+func NewOrganizationRepository(db *gorm.DB) *OrganizationRepository {
+	return &OrganizationRepository{
+		db: db,
+	}
+}
+
+// Create insert a new organization. ErrDuplicate will be returned if the name is already used by another organization
+func (r *OrganizationRepository) Create(ctx context.Context, name string) (*model.Organization, error) {
+	organization := &model.Organization{
+		Name: name,
+	}
+
+	err := r.db.WithContext(ctx).Create(organization).Error
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicate
+		}
+
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+// FindByID find exactly one record from organizations table with matching id
+func (r *OrganizationRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Organization, error) {
+	organization := &model.Organization{}
+
+	err := r.db.WithContext(ctx).Take(organization, "id = ?", id).Error
+	switch err {
+	default:
+		return nil, err
+	case gorm.ErrRecordNotFound:
+		return nil, ErrNotFound
+	case nil:
+		return organization, nil
+	}
+}
+
+// FindAll find all the organizations ordered by the name
+func (r *OrganizationRepository) FindAll(ctx context.Context) ([]model.Organization, error) {
+	organizations := []model.Organization{}
+
+	err := r.db.WithContext(ctx).Order("name ASC").Find(&organizations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return organizations, nil
+}
@@ -0,0 +1,158 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/luckyAkbar/atec/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewOrganizationRepository(kit.DB)
+
+	id := uuid.New()
+	name := "clinic"
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery(`^INSERT INTO "organizations"`).
+			WithArgs(name, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+		dbMock.ExpectCommit()
+
+		res, err := repo.Create(ctx, name)
+		require.NoError(t, err)
+		assert.Equal(t, id, res.ID)
+		assert.Equal(t, name, res.Name)
+	})
+
+	t.Run("name already used", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery(`^INSERT INTO "organizations"`).
+			WillReturnError(&pgconn.PgError{Code: "23505"})
+
+		dbMock.ExpectRollback()
+
+		res, err := repo.Create(ctx, name)
+		require.Nil(t, res)
+		assert.ErrorIs(t, err, repository.ErrDuplicate)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery(`^INSERT INTO "organizations"`).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		res, err := repo.Create(ctx, name)
+		require.Nil(t, res)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestOrganizationRepository_FindByID(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewOrganizationRepository(kit.DB)
+
+	id := uuid.New()
+
+	testCases := []struct {
+		name        string
+		expectedErr error
+		mockFunc    func()
+	}{
+		{
+			name:        "not found",
+			expectedErr: repository.ErrNotFound,
+			mockFunc: func() {
+				dbMock.ExpectQuery(`^SELECT \* FROM "organizations" WHERE id = \$1 LIMIT \$2`).
+					WithArgs(id, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+		},
+		{
+			name:        "error",
+			expectedErr: assert.AnError,
+			mockFunc: func() {
+				dbMock.ExpectQuery(`^SELECT \* FROM "organizations" WHERE id = \$1 LIMIT \$2`).
+					WithArgs(id, 1).
+					WillReturnError(assert.AnError)
+			},
+		},
+		{
+			name: "found",
+			mockFunc: func() {
+				dbMock.ExpectQuery(`^SELECT \* FROM "organizations" WHERE id = \$1 LIMIT \$2`).
+					WithArgs(id, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(id, "clinic"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			res, err := repo.FindByID(ctx, id)
+			if tc.expectedErr != nil {
+				require.Nil(t, res)
+				assert.ErrorIs(t, err, tc.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, id, res.ID)
+			assert.Equal(t, "clinic", res.Name)
+		})
+	}
+}
+
+func TestOrganizationRepository_FindAll(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewOrganizationRepository(kit.DB)
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT \* FROM "organizations" ORDER BY name ASC`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(uuid.New(), "a").AddRow(uuid.New(), "b"))
+
+		res, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT \* FROM "organizations" ORDER BY name ASC`).
+			WillReturnError(assert.AnError)
+
+		res, err := repo.FindAll(ctx)
+		require.Nil(t, res)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
@@ -38,6 +38,7 @@ func (r *PackageRepo) Create(ctx context.Context, input usecase.RepoCreatePackag
 
 	pack := &model.Package{
 		CreatedBy:               input.UserID,
+		OrganizationID:          input.OrganizationID,
 		Questionnaire:           input.Questionnaire,
 		Name:                    input.PackageName,
 		IndicationCategories:    input.IndicationCategories,
@@ -340,11 +341,12 @@ func (r *PackageRepo) FindAllActivePackages(ctx context.Context) ([]model.Packag
 	return r.findAndSetAllActivePackagesToCache(ctx)
 }
 
-// FindOldestActiveAndLockedPackage get the oldest active and locked package
+// FindOldestActiveAndLockedPackage get the oldest active and locked global package
 func (r *PackageRepo) FindOldestActiveAndLockedPackage(ctx context.Context) (*model.Package, error) {
 	pack := &model.Package{}
 
-	err := r.db.WithContext(ctx).Where("is_active = ? AND is_locked = ?", true, true).Order("created_at ASC").Take(pack).Error
+	err := r.db.WithContext(ctx).Where("is_active = ? AND is_locked = ? AND organization_id IS NULL", true, true).
+		Order("created_at ASC").Take(pack).Error
 	switch err {
 	default:
 		return nil, err
@@ -48,7 +48,7 @@ func TestPackageRepository_Create(t *testing.T) {
 			expectedFunctionCall: func() {
 				dbMock.ExpectBegin()
 				dbMock.ExpectQuery(`^INSERT INTO "packages"`).
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnError(assert.AnError)
 				dbMock.ExpectRollback()
 			},
@@ -61,7 +61,7 @@ func TestPackageRepository_Create(t *testing.T) {
 			expectedFunctionCall: func() {
 				dbMock.ExpectBegin()
 				dbMock.ExpectQuery(`^INSERT INTO "packages"`).
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
 				dbMock.ExpectCommit()
 
@@ -75,7 +75,7 @@ func TestPackageRepository_Create(t *testing.T) {
 			expectedFunctionCall: func() {
 				dbMock.ExpectBegin()
 				dbMock.ExpectQuery(`^INSERT INTO "packages"`).
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
 				dbMock.ExpectCommit()
 
@@ -91,7 +91,7 @@ func TestPackageRepository_Create(t *testing.T) {
 			expectedFunctionCall: func() {
 				dbMock.ExpectBegin()
 				dbMock.ExpectQuery(`^INSERT INTO "packages"`).
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
 				dbMock.ExpectCommit()
 
@@ -351,7 +351,7 @@ func TestPackageRepository_Update(t *testing.T) {
 				cacher.EXPECT().Del(ctx, mock.Anything).Return(nil).Once()
 
 				kit.DBmock.ExpectQuery(`^UPDATE "packages"`).
-					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnError(assert.AnError)
 			},
 		},
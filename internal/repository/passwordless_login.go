@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/db"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+)
+
+// PasswordlessLoginRepository is an instance containing functions to store the pending passwordless login on cache
+type PasswordlessLoginRepository struct {
+	cacheKeeper db.CacheKeeperIface
+}
+
+// NewPasswordlessLoginRepository create a new instance of PasswordlessLoginRepository
+func NewPasswordlessLoginRepository(cacheKeeper db.CacheKeeperIface) *PasswordlessLoginRepository {
+	return &PasswordlessLoginRepository{
+		cacheKeeper: cacheKeeper,
+	}
+}
+
+// Create store the pending passwordless login, replacing the previous one owned by the same user if any
+func (r *PasswordlessLoginRepository) Create(ctx context.Context, input usecase.RepoCreatePasswordlessLoginInput) error {
+	login := model.PasswordlessLogin{
+		UserID:        input.UserID,
+		OTPHash:       input.OTPHash,
+		MagicLinkHash: input.MagicLinkHash,
+	}
+
+	return r.cacheKeeper.SetJSON(ctx, CacheKeyForPasswordlessLogin(input.UserID), login, input.ExpiresIn)
+}
+
+// FindByUserID find the user's pending passwordless login. ErrNotFound will be returned if it does not exist or already expired
+func (r *PasswordlessLoginRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*model.PasswordlessLogin, error) {
+	val, err := r.cacheKeeper.Get(ctx, CacheKeyForPasswordlessLogin(userID))
+	switch err {
+	default:
+		return nil, err
+	case db.ErrCacheKeyNotFound, db.ErrCacheNil:
+		return nil, ErrNotFound
+	case nil:
+		break
+	}
+
+	login := &model.PasswordlessLogin{}
+	if err := json.Unmarshal([]byte(val), login); err != nil {
+		return nil, err
+	}
+
+	return login, nil
+}
+
+// DeleteByUserID delete the user's pending passwordless login. Deleting a non existing one will do nothing
+func (r *PasswordlessLoginRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return r.cacheKeeper.Del(ctx, CacheKeyForPasswordlessLogin(userID))
+}
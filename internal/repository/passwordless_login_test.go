@@ -0,0 +1,147 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/db"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/repository"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	db_mock "github.com/luckyAkbar/atec/mocks/internal_/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordlessLoginRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	cacher := db_mock.NewCacheKeeperIface(t)
+	repo := repository.NewPasswordlessLoginRepository(cacher)
+
+	input := usecase.RepoCreatePasswordlessLoginInput{
+		UserID:        uuid.New(),
+		OTPHash:       "otp-hash",
+		MagicLinkHash: "magic-link-hash",
+		ExpiresIn:     10 * time.Minute,
+	}
+	expectedValue := model.PasswordlessLogin{
+		UserID:        input.UserID,
+		OTPHash:       input.OTPHash,
+		MagicLinkHash: input.MagicLinkHash,
+	}
+	key := repository.CacheKeyForPasswordlessLogin(input.UserID)
+
+	t.Run("failed to store to cache", func(t *testing.T) {
+		cacher.EXPECT().SetJSON(ctx, key, expectedValue, input.ExpiresIn).Return(assert.AnError).Once()
+
+		err := repo.Create(ctx, input)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		cacher.EXPECT().SetJSON(ctx, key, expectedValue, input.ExpiresIn).Return(nil).Once()
+
+		err := repo.Create(ctx, input)
+		assert.NoError(t, err)
+	})
+}
+
+func TestPasswordlessLoginRepository_FindByUserID(t *testing.T) {
+	ctx := context.Background()
+	cacher := db_mock.NewCacheKeeperIface(t)
+	repo := repository.NewPasswordlessLoginRepository(cacher)
+
+	userID := uuid.New()
+	key := repository.CacheKeyForPasswordlessLogin(userID)
+	login := model.PasswordlessLogin{
+		UserID:        userID,
+		OTPHash:       "otp-hash",
+		MagicLinkHash: "magic-link-hash",
+	}
+
+	loginJSON, err := json.Marshal(login)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "key not found",
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				cacher.EXPECT().Get(ctx, key).Return("", db.ErrCacheKeyNotFound).Once()
+			},
+		},
+		{
+			name:        "nil value",
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				cacher.EXPECT().Get(ctx, key).Return("", db.ErrCacheNil).Once()
+			},
+		},
+		{
+			name:        "cache error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				cacher.EXPECT().Get(ctx, key).Return("", assert.AnError).Once()
+			},
+		},
+		{
+			name:    "invalid json",
+			wantErr: true,
+			expectedFunctionCall: func() {
+				cacher.EXPECT().Get(ctx, key).Return("{invalid", nil).Once()
+			},
+		},
+		{
+			name:    "ok",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				cacher.EXPECT().Get(ctx, key).Return(string(loginJSON), nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.expectedFunctionCall()
+
+			res, err := repo.FindByUserID(ctx, userID)
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, login, *res)
+
+				return
+			}
+
+			assert.Error(t, err)
+			assert.Nil(t, res)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestPasswordlessLoginRepository_DeleteByUserID(t *testing.T) {
+	ctx := context.Background()
+	cacher := db_mock.NewCacheKeeperIface(t)
+	repo := repository.NewPasswordlessLoginRepository(cacher)
+
+	userID := uuid.New()
+
+	cacher.EXPECT().Del(ctx, repository.CacheKeyForPasswordlessLogin(userID)).Return(assert.AnError).Once()
+
+	err := repo.DeleteByUserID(ctx, userID)
+	assert.ErrorIs(t, err, assert.AnError)
+}
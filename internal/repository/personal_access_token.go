@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"gorm.io/gorm"
+)
+
+// PersonalAccessTokenRepository is an instance containing functions to interact specifically to personal_access_tokens table
+type PersonalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewPersonalAccessTokenRepository create a new instance of PersonalAccessTokenRepository
+func NewPersonalAccessTokenRepository(db *gorm.DB) *PersonalAccessTokenRepository {
+	return &PersonalAccessTokenRepository{
+		db: db,
+	}
+}
+
+// Create insert a new record to personal_access_tokens table
+func (r *PersonalAccessTokenRepository) Create(
+	ctx context.Context, input usecase.RepoCreatePersonalAccessTokenInput,
+) (*model.PersonalAccessToken, error) {
+	token := &model.PersonalAccessToken{
+		UserID:    input.UserID,
+		Name:      input.Name,
+		TokenHash: input.TokenHash,
+		Scopes:    input.Scopes,
+		ExpiresAt: input.ExpiresAt,
+	}
+
+	err := r.db.WithContext(ctx).Create(token).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// FindByTokenHash find exactly one record from personal_access_tokens table with matching token hash
+func (r *PersonalAccessTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error) {
+	token := &model.PersonalAccessToken{}
+
+	err := r.db.WithContext(ctx).Take(token, "token_hash = ?", tokenHash).Error
+	switch err {
+	default:
+		return nil, err
+	case gorm.ErrRecordNotFound:
+		return nil, ErrNotFound
+	case nil:
+		return token, nil
+	}
+}
+
+// FindByUserID find all the user's personal access tokens, including the revoked and expired ones, newest first
+func (r *PersonalAccessTokenRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]model.PersonalAccessToken, error) {
+	tokens := []model.PersonalAccessToken{}
+
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// Revoke mark the user's personal access token as revoked. ErrNotFound will be returned
+// if the token does not exist, is owned by another user or already revoked
+func (r *PersonalAccessTokenRepository) Revoke(ctx context.Context, id, userID uuid.UUID) error {
+	res := r.db.WithContext(ctx).Model(&model.PersonalAccessToken{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", time.Now())
+
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateLastUsedAt record the current time as the last time the personal access token was used
+func (r *PersonalAccessTokenRepository) UpdateLastUsedAt(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&model.PersonalAccessToken{}).
+		Where("id = ?", id).
+		Update("last_used_at", time.Now()).Error
+}
@@ -0,0 +1,304 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/repository"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestPersonalAccessTokenRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewPersonalAccessTokenRepository(kit.DB)
+
+	id := uuid.New()
+	input := usecase.RepoCreatePersonalAccessTokenInput{
+		UserID:    uuid.New(),
+		Name:      "report script",
+		TokenHash: "hashed",
+		Scopes:    model.PersonalAccessTokenScopes{model.PersonalAccessTokenScopeChildren},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^INSERT INTO \"personal_access_tokens\"").
+					WithArgs(
+						input.UserID, input.Name, input.TokenHash, []byte(`["children"]`), input.ExpiresAt,
+						nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(),
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^INSERT INTO \"personal_access_tokens\"").
+					WithArgs(
+						input.UserID, input.Name, input.TokenHash, []byte(`["children"]`), input.ExpiresAt,
+						nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(),
+					).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := repo.Create(ctx, input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				if tc.expectedErr != nil {
+					assert.Equal(t, tc.expectedErr, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, id, res.ID)
+			assert.Equal(t, input.Scopes, res.Scopes)
+		})
+	}
+}
+
+func TestPersonalAccessTokenRepository_FindByTokenHash(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewPersonalAccessTokenRepository(kit.DB)
+
+	id := uuid.New()
+	tokenHash := "hashed"
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "personal_access_tokens"`).
+					WithArgs(tokenHash, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "scopes"}).AddRow(id, `["children","users"]`))
+			},
+		},
+		{
+			name:        "error - unknown just pass the error to the caller",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "personal_access_tokens"`).
+					WithArgs(tokenHash, 1).
+					WillReturnError(assert.AnError)
+			},
+		},
+		{
+			name:        "data not found on db",
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "personal_access_tokens"`).
+					WithArgs(tokenHash, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := repo.FindByTokenHash(ctx, tokenHash)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				if tc.expectedErr != nil {
+					assert.Equal(t, tc.expectedErr, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, id, res.ID)
+			assert.Equal(t, model.PersonalAccessTokenScopes{
+				model.PersonalAccessTokenScopeChildren, model.PersonalAccessTokenScopeUsers,
+			}, res.Scopes)
+		})
+	}
+}
+
+func TestPersonalAccessTokenRepository_FindByUserID(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewPersonalAccessTokenRepository(kit.DB)
+
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "personal_access_tokens" WHERE user_id = .+ ORDER BY created_at DESC`).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+
+		res, err := repo.FindByUserID(ctx, userID)
+		require.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "personal_access_tokens"`).
+			WithArgs(userID).
+			WillReturnError(assert.AnError)
+
+		res, err := repo.FindByUserID(ctx, userID)
+		require.Error(t, err)
+		require.Nil(t, res)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestPersonalAccessTokenRepository_Revoke(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewPersonalAccessTokenRepository(kit.DB)
+
+	id := uuid.New()
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"personal_access_tokens\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id, userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := repo.Revoke(ctx, id, userID)
+		require.NoError(t, err)
+	})
+
+	t.Run("not found, owned by other user or already revoked", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"personal_access_tokens\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id, userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := repo.Revoke(ctx, id, userID)
+		require.Error(t, err)
+		assert.Equal(t, repository.ErrNotFound, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"personal_access_tokens\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id, userID).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.Revoke(ctx, id, userID)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestPersonalAccessTokenRepository_UpdateLastUsedAt(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewPersonalAccessTokenRepository(kit.DB)
+
+	id := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"personal_access_tokens\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := repo.UpdateLastUsedAt(ctx, id)
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"personal_access_tokens\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.UpdateLastUsedAt(ctx, id)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
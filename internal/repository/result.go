@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/luckyAkbar/atec/internal/model"
@@ -70,6 +71,14 @@ func searchResultInputoSearchFields(cursor *gorm.DB, sri usecase.RepoSearchResul
 		cursor = cursor.Where("created_by = ?", sri.CreatedBy)
 	}
 
+	if sri.TherapistID != uuid.Nil {
+		cursor = cursor.Where("child_id IN (SELECT child_id FROM care_relationships WHERE therapist_id = ?)", sri.TherapistID)
+	}
+
+	if sri.OrganizationID != uuid.Nil {
+		cursor = cursor.Where("child_id IN ("+organizationChildIDsQuery+")", sri.OrganizationID)
+	}
+
 	if sri.Limit > 0 {
 		cursor = cursor.Limit(sri.Limit)
 	}
@@ -99,16 +108,15 @@ func (r *ResultRepository) Search(ctx context.Context, input usecase.RepoSearchR
 	return results, nil
 }
 
-// FindAllUserHistory find the result made by the userID or the child of the userID
+// FindAllUserHistory find the result made by the userID or the child the userID is the owner or a guardian of
 func (r *ResultRepository) FindAllUserHistory(ctx context.Context, input usecase.RepoFindAllUserHistoryInput) ([]model.Result, error) {
 	results := []model.Result{}
 
 	err := r.db.WithContext(ctx).Where("created_by = ?", input.UserID).
-		Or(
-			"child_id IN (?)",
-			r.db.Model(&model.Child{}).
-				Select("id").Where("parent_user_id = ?", input.UserID),
-		).Limit(input.Limit).Offset(input.Offset).
+		Or("child_id IN ("+guardianChildIDsQuery+")", input.UserID).
+		// stable order is required to page through the whole history without skipping or repeating results
+		Order("created_at ASC, id ASC").
+		Limit(input.Limit).Offset(input.Offset).
 		Find(&results).Error
 
 	if err != nil {
@@ -122,27 +130,145 @@ func (r *ResultRepository) FindAllUserHistory(ctx context.Context, input usecase
 	return results, nil
 }
 
-// DeleteAllUserResults delete all results made by the userID or submitted for child of the userID
+// childAgeBandExpr reduce the child's age at the time the result was submitted into a coarse age band
+const childAgeBandExpr = `(
+	SELECT CASE
+		WHEN age < 3 THEN '0-2'
+		WHEN age < 6 THEN '3-5'
+		WHEN age < 9 THEN '6-8'
+		WHEN age < 12 THEN '9-11'
+		WHEN age < 15 THEN '12-14'
+		ELSE '15+'
+	END
+	FROM (
+		SELECT DATE_PART('year', AGE(results.created_at, children.date_of_birth)) AS age
+		FROM children WHERE children.id = results.child_id
+	) AS child_age
+)`
+
+// DeleteAllUserResults delete the results submitted by the userID without a child, and the results of the userID's children.
+// The results the userID submitted for other users' children are kept. Unless input.HardDelete is true, the results of the
+// children shared with other guardians are kept as well, the same way as DeleteAllUserChildren keeps those children,
+// and the user is removed as the creator of every kept result. If input.Anonymize is true, the results are anonymized
+// instead of deleted
 func (r *ResultRepository) DeleteAllUserResults(ctx context.Context, input usecase.RepoDeleteAllUserResultsInput, txController ...*gorm.DB) error {
 	tx := r.db
 	if len(txController) > 0 {
 		tx = txController[0]
 	}
 
-	if input.HardDelete {
-		tx = tx.Unscoped()
+	// the children may have been soft deleted beforehand, their results must be deleted as well
+	children := r.db.Unscoped().Model(&model.Child{}).Select("id").Where("parent_user_id = ?", input.UserID)
+	if !input.HardDelete {
+		children = children.Where("id NOT IN ("+sharedChildIDsQuery+")", input.UserID)
 	}
 
-	err := tx.WithContext(ctx).Where("created_by = ?", input.UserID).
-		Or(
-			"child_id IN (?)",
-			r.db.Model(&model.Child{}).
-				Select("id").Where("parent_user_id = ?", input.UserID),
-		).Delete(&model.Result{}).Error
+	var err error
 
-	if err != nil {
+	switch {
+	case input.Anonymize:
+		err = r.anonymizeResults(ctx, r.userResults(input.UserID, children), input.DeletedSince, tx)
+	case input.HardDelete:
+		err = tx.WithContext(ctx).Unscoped().Where(r.userResults(input.UserID, children)).Delete(&model.Result{}).Error
+	default:
+		err = tx.WithContext(ctx).Where(r.userResults(input.UserID, children)).Delete(&model.Result{}).Error
+	}
+
+	if err != nil || !input.HardDelete {
+		return err
+	}
+
+	// the kept results must no longer point to the user being permanently deleted
+	return tx.WithContext(ctx).Unscoped().Model(&model.Result{}).
+		Where("created_by = ?", input.UserID).
+		Update("created_by", nil).Error
+}
+
+// userResults match the results submitted by the user without a child, and the results of the children
+func (r *ResultRepository) userResults(userID uuid.UUID, children *gorm.DB) *gorm.DB {
+	return r.db.Where("child_id IS NULL AND created_by = ?", userID).Or("child_id IN (?)", children)
+}
+
+// anonymizeResults detach the results from the user and the child, keeping only the child's age band and gender.
+// The results are given a new id and only the month they were submitted in is kept, so they can no longer be linked
+// back to the user, e.g. through the audit logs. The results deleted by the user are permanently deleted instead,
+// so they never show up again, while the ones deleted at or after deletedSince, i.e. along with the account,
+// are restored once anonymized. Leave deletedSince empty when every deleted result was deleted by the user
+func (r *ResultRepository) anonymizeResults(ctx context.Context, results *gorm.DB, deletedSince time.Time, tx *gorm.DB) error {
+	deletedByUser := tx.WithContext(ctx).Unscoped().Where(results).Where("deleted_at IS NOT NULL")
+	if !deletedSince.IsZero() {
+		deletedByUser = deletedByUser.Where("deleted_at < ?", deletedSince)
+	}
+
+	if err := deletedByUser.Delete(&model.Result{}).Error; err != nil {
 		return err
 	}
 
-	return nil
+	return tx.WithContext(ctx).Unscoped().Model(&model.Result{}).
+		Where(results).
+		Updates(anonymizedResultFields()).Error
+}
+
+// anonymizedResultFields the fields to update to detach the results from the child and the creator
+func anonymizedResultFields() map[string]interface{} {
+	return map[string]interface{}{
+		"id":             gorm.Expr("uuid_generate_v4()"),
+		"child_age_band": gorm.Expr(childAgeBandExpr),
+		"child_gender":   gorm.Expr("(SELECT gender FROM children WHERE children.id = results.child_id)"),
+		"child_id":       nil,
+		"created_by":     nil,
+		"created_at":     gorm.Expr("DATE_TRUNC('month', created_at)"),
+		"updated_at":     gorm.Expr("DATE_TRUNC('month', created_at)"),
+		"anonymized_at":  gorm.Expr("DATE_TRUNC('month', NOW())"),
+		"deleted_at":     nil,
+	}
+}
+
+// RestoreAllUserResults restore the soft deleted results deleted by DeleteAllUserResults, as long as they were
+// deleted at or after input.DeletedSince
+func (r *ResultRepository) RestoreAllUserResults(
+	ctx context.Context, input usecase.RepoRestoreAllUserResultsInput, txController ...*gorm.DB,
+) error {
+	tx := r.db
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	return tx.WithContext(ctx).Unscoped().Model(&model.Result{}).
+		Where("deleted_at >= ?", input.DeletedSince).
+		Where(r.userResults(
+			input.UserID,
+			r.db.Unscoped().Model(&model.Child{}).Select("id").Where("parent_user_id = ?", input.UserID),
+		)).
+		Update("deleted_at", nil).Error
+}
+
+// TransferChildResults move the child's results created by input.FromUserID to input.ToUserID
+func (r *ResultRepository) TransferChildResults(ctx context.Context, input usecase.RepoTransferChildInput, txController ...*gorm.DB) error {
+	tx := r.db
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	return tx.WithContext(ctx).Model(&model.Result{}).
+		Where("child_id = ? AND created_by = ?", input.ChildID, input.FromUserID).
+		Update("created_by", input.ToUserID).Error
+}
+
+// DeleteAllChildResults permanently delete the child's results, including the soft deleted ones. When input.Anonymize
+// is true, the results not deleted by the user are detached from the child and the creator instead, the same way as
+// anonymizing the user's results
+func (r *ResultRepository) DeleteAllChildResults(
+	ctx context.Context, input usecase.RepoDeleteAllChildResultsInput, txController ...*gorm.DB,
+) error {
+	tx := r.db
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	if input.Anonymize {
+		return r.anonymizeResults(ctx, r.db.Where("child_id = ?", input.ChildID), time.Time{}, tx)
+	}
+
+	return tx.WithContext(ctx).Unscoped().Where("child_id = ?", input.ChildID).Delete(&model.Result{}).Error
 }
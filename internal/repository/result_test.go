@@ -3,6 +3,7 @@ package repository_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
@@ -184,6 +185,8 @@ func TestResultRepository_Search(t *testing.T) {
 	packageID := uuid.New()
 	childID := uuid.New()
 	createdByID := uuid.New()
+	therapistID := uuid.New()
+	organizationID := uuid.New()
 	limit := 100
 	offset := 10
 
@@ -213,6 +216,35 @@ func TestResultRepository_Search(t *testing.T) {
 					WillReturnError(assert.AnError)
 			},
 		},
+		{
+			name:    "only the results of the children assigned to the therapist",
+			wantErr: false,
+			input: usecase.RepoSearchResultInput{
+				TherapistID: therapistID,
+				Limit:       limit,
+			},
+			expectedOutputLen: 1,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "results" WHERE child_id IN \(SELECT child_id FROM care_relationships WHERE therapist_id = \$1\)`).
+					WithArgs(therapistID, limit).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(resultID))
+			},
+		},
+		{
+			name:    "only the results of the children taken care of by the organization",
+			wantErr: false,
+			input: usecase.RepoSearchResultInput{
+				OrganizationID: organizationID,
+				Limit:          limit,
+			},
+			expectedOutputLen: 1,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "results" WHERE \(child_id IN \(SELECT care_relationships.child_id FROM care_relationships\s+`+
+					`JOIN users ON users.id = care_relationships.therapist_id WHERE users.organization_id = \$1 AND users.deleted_at IS NULL\)`).
+					WithArgs(organizationID, limit).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(resultID))
+			},
+		},
 		{
 			name:    "no result found must return not found error",
 			wantErr: true,
@@ -339,7 +371,9 @@ func TestResultRepository_FindAllUserHistory(t *testing.T) {
 			},
 			expectedOutputLen: 2,
 			expectedFunctionCall: func() {
-				dbMock.ExpectQuery(`^SELECT .+ FROM "results"`).
+				dbMock.ExpectQuery(`^SELECT \* FROM "results" WHERE \(created_by = \$1 OR child_id IN `+
+					`\(SELECT child_id FROM child_guardians WHERE user_id = \$2\)\) AND "results"."deleted_at" IS NULL `+
+					`ORDER BY created_at ASC, id ASC LIMIT \$3 OFFSET \$4`).
 					WithArgs(userID, userID, limit, offset).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
 			},
@@ -383,6 +417,15 @@ func TestResultRepository_DeleteAllUserResults(t *testing.T) {
 	repo := repository.NewResultRepository(kit.DB)
 
 	userID := uuid.New()
+	deletedSince := time.Now().Add(-time.Hour)
+	deletedByUserQuery := `^DELETE FROM "results" WHERE \(\(child_id IS NULL AND created_by = \$1\) OR child_id IN ` +
+		`\(SELECT "id" FROM "children" WHERE parent_user_id = \$2\)\) AND deleted_at IS NOT NULL AND deleted_at < \$3$`
+	anonymizeQuery := `^UPDATE "results" SET "anonymized_at"=DATE_TRUNC\('month', NOW\(\)\),"child_age_band"=\(.+children.date_of_birth.+\),` +
+		`"child_gender"=\(SELECT gender FROM children WHERE children.id = results.child_id\),"child_id"=\$1,` +
+		`"created_at"=DATE_TRUNC\('month', created_at\),"created_by"=\$2,"deleted_at"=\$3,"id"=uuid_generate_v4\(\),` +
+		`"updated_at"=DATE_TRUNC\('month', created_at\) WHERE \(child_id IS NULL AND created_by = \$4\) OR child_id IN ` +
+		`\(SELECT "id" FROM "children" WHERE parent_user_id = \$5\)$`
+	detachCreatorQuery := `^UPDATE "results" SET "created_by"=\$1,"updated_at"=\$2 WHERE created_by = \$3$`
 
 	testCases := []struct {
 		name                 string
@@ -401,11 +444,143 @@ func TestResultRepository_DeleteAllUserResults(t *testing.T) {
 			expectedFunctionCall: func() {
 				dbMock.ExpectBegin()
 
-				dbMock.ExpectExec("^DELETE FROM \"results\"").
+				dbMock.ExpectExec(`^DELETE FROM "results" WHERE \(child_id IS NULL AND created_by = \$1\) OR child_id IN `+
+					`\(SELECT "id" FROM "children" WHERE parent_user_id = \$2\)$`).
+					WithArgs(userID, userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbMock.ExpectCommit()
+				dbMock.ExpectBegin()
+
+				// the results the user submitted for other users' children are kept without the user as their creator
+				dbMock.ExpectExec(detachCreatorQuery).
+					WithArgs(nil, sqlmock.AnyArg(), userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name: "error - remove the user as the creator of the kept results",
+			input: usecase.RepoDeleteAllUserResultsInput{
+				UserID:     userID,
+				HardDelete: true,
+			},
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^DELETE FROM "results"`).
 					WithArgs(userID, userID).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 
 				dbMock.ExpectCommit()
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(detachCreatorQuery).
+					WithArgs(nil, sqlmock.AnyArg(), userID).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name: "success - anonymize",
+			input: usecase.RepoDeleteAllUserResultsInput{
+				UserID:       userID,
+				HardDelete:   true,
+				Anonymize:    true,
+				DeletedSince: deletedSince,
+			},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				// the results deleted by the user before the account deletion was requested must not be brought back
+				dbMock.ExpectExec(deletedByUserQuery).
+					WithArgs(userID, userID, deletedSince).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbMock.ExpectCommit()
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(anonymizeQuery).
+					WithArgs(nil, nil, nil, userID, userID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+
+				dbMock.ExpectCommit()
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(detachCreatorQuery).
+					WithArgs(nil, sqlmock.AnyArg(), userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name: "error - delete the results deleted by the user before anonymizing",
+			input: usecase.RepoDeleteAllUserResultsInput{
+				UserID:       userID,
+				HardDelete:   true,
+				Anonymize:    true,
+				DeletedSince: deletedSince,
+			},
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(deletedByUserQuery).
+					WithArgs(userID, userID, deletedSince).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name: "error - anonymize",
+			input: usecase.RepoDeleteAllUserResultsInput{
+				UserID:    userID,
+				Anonymize: true,
+			},
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^DELETE FROM "results" WHERE .+ AND deleted_at IS NOT NULL$`).
+					WithArgs(userID, userID, userID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				dbMock.ExpectCommit()
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "results" SET`).
+					WithArgs(nil, nil, nil, userID, userID, userID).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name: "success - soft delete",
+			input: usecase.RepoDeleteAllUserResultsInput{
+				UserID: userID,
+			},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				// the results of the children shared with other guardians are kept
+				dbMock.ExpectExec(`^UPDATE "results" SET "deleted_at"=\$1 WHERE \(\(child_id IS NULL AND created_by = \$2\) OR `+
+					`child_id IN \(SELECT "id" FROM "children" WHERE parent_user_id = \$3 AND id NOT IN `+
+					`\(SELECT child_id FROM child_guardians WHERE user_id <> \$4\)\)\) AND "results"."deleted_at" IS NULL$`).
+					WithArgs(sqlmock.AnyArg(), userID, userID, userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbMock.ExpectCommit()
 			},
 		},
 		{
@@ -450,3 +625,237 @@ func TestResultRepository_DeleteAllUserResults(t *testing.T) {
 		})
 	}
 }
+
+func TestResultRepository_RestoreAllUserResults(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewResultRepository(kit.DB)
+
+	input := usecase.RepoRestoreAllUserResultsInput{
+		UserID:       uuid.New(),
+		DeletedSince: time.Now().Add(-time.Hour),
+	}
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "results" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE deleted_at >= \$3 `+
+					`AND \(\(child_id IS NULL AND created_by = \$4\) OR child_id IN \(SELECT "id" FROM "children" WHERE parent_user_id = \$5\)\)`).
+					WithArgs(nil, sqlmock.AnyArg(), input.DeletedSince, input.UserID, input.UserID).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "results" SET`).
+					WithArgs(nil, sqlmock.AnyArg(), input.DeletedSince, input.UserID, input.UserID).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := repo.RestoreAllUserResults(ctx, input, kit.DB)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestResultRepository_TransferChildResults(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewResultRepository(kit.DB)
+
+	input := usecase.RepoTransferChildInput{
+		ChildID:    uuid.New(),
+		FromUserID: uuid.New(),
+		ToUserID:   uuid.New(),
+	}
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "results" SET "created_by"=\$1,"updated_at"=\$2 `+
+					`WHERE \(child_id = \$3 AND created_by = \$4\) AND "results"."deleted_at" IS NULL`).
+					WithArgs(input.ToUserID, sqlmock.AnyArg(), input.ChildID, input.FromUserID).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "results" SET`).
+					WithArgs(input.ToUserID, sqlmock.AnyArg(), input.ChildID, input.FromUserID).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := repo.TransferChildResults(ctx, input, kit.DB)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestResultRepository_DeleteAllChildResults(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewResultRepository(kit.DB)
+
+	childID := uuid.New()
+
+	testCases := []struct {
+		name                 string
+		input                usecase.RepoDeleteAllChildResultsInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "permanently delete the results",
+			input:   usecase.RepoDeleteAllChildResultsInput{ChildID: childID},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^DELETE FROM "results" WHERE child_id = \$1$`).
+					WithArgs(childID).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:    "anonymize the results",
+			input:   usecase.RepoDeleteAllChildResultsInput{ChildID: childID, Anonymize: true},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				// the results deleted by the user are permanently deleted instead of being brought back
+				dbMock.ExpectExec(`^DELETE FROM "results" WHERE child_id = \$1 AND deleted_at IS NOT NULL$`).
+					WithArgs(childID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbMock.ExpectCommit()
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "results" SET "anonymized_at"=DATE_TRUNC\('month', NOW\(\)\),"child_age_band"=\(.+\),"child_gender"=\(SELECT gender FROM children WHERE children.id = results.child_id\),`+
+					`"child_id"=\$1,"created_at"=DATE_TRUNC\('month', created_at\),"created_by"=\$2,"deleted_at"=\$3,"id"=uuid_generate_v4\(\),`+
+					`"updated_at"=DATE_TRUNC\('month', created_at\) WHERE child_id = \$4$`).
+					WithArgs(nil, nil, nil, childID).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error",
+			input:       usecase.RepoDeleteAllChildResultsInput{ChildID: childID},
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^DELETE FROM "results"`).
+					WithArgs(childID).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := repo.DeleteAllChildResults(ctx, tc.input, kit.DB)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SessionRepository is an instance containing functions to interact specifically to sessions table
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository create a new instance of SessionRepository
+func NewSessionRepository(db *gorm.DB) *SessionRepository {
+	return &SessionRepository{
+		db: db,
+	}
+}
+
+// Create insert a new record to sessions table
+func (r *SessionRepository) Create(ctx context.Context, input usecase.RepoCreateSessionInput) (*model.Session, error) {
+	session := &model.Session{
+		ID:               input.ID,
+		UserID:           input.UserID,
+		RefreshTokenHash: input.RefreshTokenHash,
+		IPAddress:        input.IPAddress,
+		UserAgent:        input.UserAgent,
+		ExpiresAt:        input.ExpiresAt,
+	}
+
+	err := r.db.WithContext(ctx).Create(session).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// FindByID find exactly one record from sessions table with matching id
+func (r *SessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Session, error) {
+	session := &model.Session{}
+
+	err := r.db.WithContext(ctx).Take(session, "id = ?", id).Error
+	switch err {
+	default:
+		return nil, err
+	case gorm.ErrRecordNotFound:
+		return nil, ErrNotFound
+	case nil:
+		return session, nil
+	}
+}
+
+// RotateRefreshToken replace the refresh token hash of a session. The update will only be applied
+// if the old refresh token hash still match and the session is still active. If nothing was updated,
+// ErrNotFound will be returned. This can happen when the same refresh token is used more than once.
+// Using the refresh token also counts as activity, thus the last seen time is updated as well
+func (r *SessionRepository) RotateRefreshToken(ctx context.Context, input usecase.RepoRotateRefreshTokenInput) (*model.Session, error) {
+	session := &model.Session{}
+
+	res := r.db.WithContext(ctx).Model(session).Clauses(clause.Returning{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL AND expires_at > ?",
+			input.SessionID, input.OldRefreshTokenHash, time.Now()).
+		Updates(map[string]interface{}{
+			"refresh_token_hash": input.NewRefreshTokenHash,
+			"expires_at":         input.ExpiresAt,
+			"last_seen_at":       time.Now(),
+		})
+
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return nil, ErrNotFound
+	}
+
+	return session, nil
+}
+
+// RevokeByID mark a session as revoked. Revoking an already revoked session will do nothing
+func (r *SessionRepository) RevokeByID(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&model.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAllUserSessions mark all the user's active sessions as revoked
+func (r *SessionRepository) RevokeAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&model.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeOtherUserSessions mark all the user's active sessions as revoked, except the one identified by exceptSessionID
+func (r *SessionRepository) RevokeOtherUserSessions(ctx context.Context, userID, exceptSessionID uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&model.Session{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, exceptSessionID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// FindActiveByUserID find all the user's sessions which are not revoked and not yet expired, newest first
+func (r *SessionRepository) FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]model.Session, error) {
+	sessions := []model.Session{}
+
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("created_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// RevokeUserSession mark the user's session as revoked. ErrNotFound will be returned
+// if the session does not exist, is owned by another user or already revoked
+func (r *SessionRepository) RevokeUserSession(ctx context.Context, id, userID uuid.UUID) error {
+	res := r.db.WithContext(ctx).Model(&model.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", time.Now())
+
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateLastSeenAt record the current time as the last time the session was used
+func (r *SessionRepository) UpdateLastSeenAt(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&model.Session{}).
+		Where("id = ?", id).
+		Update("last_seen_at", time.Now()).Error
+}
+
+// HasAnySession check whether the user ever logged in, including using the revoked and expired sessions
+func (r *SessionRepository) HasAnySession(ctx context.Context, userID uuid.UUID) (bool, error) {
+	session := &model.Session{}
+
+	err := r.db.WithContext(ctx).Take(session, "user_id = ?", userID).Error
+	switch err {
+	default:
+		return false, err
+	case gorm.ErrRecordNotFound:
+		return false, nil
+	case nil:
+		return true, nil
+	}
+}
+
+// HasSessionFromClient check whether the user ever logged in using the same ip address and user agent,
+// including using the revoked and expired sessions
+func (r *SessionRepository) HasSessionFromClient(ctx context.Context, userID uuid.UUID, client model.ClientInfo) (bool, error) {
+	session := &model.Session{}
+
+	err := r.db.WithContext(ctx).
+		Take(session, "user_id = ? AND ip_address = ? AND user_agent = ?", userID, client.IPAddress, client.UserAgent).Error
+	switch err {
+	default:
+		return false, err
+	case gorm.ErrRecordNotFound:
+		return false, nil
+	case nil:
+		return true, nil
+	}
+}
@@ -0,0 +1,606 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/repository"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestSessionRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewSessionRepository(kit.DB)
+
+	input := usecase.RepoCreateSessionInput{
+		ID:               uuid.New(),
+		UserID:           uuid.New(),
+		RefreshTokenHash: "hashed",
+		IPAddress:        "203.0.113.10",
+		UserAgent:        "Mozilla/5.0",
+		ExpiresAt:        time.Now().Add(time.Hour),
+	}
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^INSERT INTO \"sessions\"").
+					WithArgs(
+						input.UserID, input.RefreshTokenHash, input.IPAddress, input.UserAgent, input.ExpiresAt,
+						nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), input.ID,
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(input.ID))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^INSERT INTO \"sessions\"").
+					WithArgs(
+						input.UserID, input.RefreshTokenHash, input.IPAddress, input.UserAgent, input.ExpiresAt,
+						nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), input.ID,
+					).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := repo.Create(ctx, input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				if tc.expectedErr != nil {
+					assert.Equal(t, tc.expectedErr, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, input.ID, res.ID)
+		})
+	}
+}
+
+func TestSessionRepository_FindByID(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewSessionRepository(kit.DB)
+
+	id := uuid.New()
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "sessions"`).
+					WithArgs(id, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+			},
+		},
+		{
+			name:        "error - unknown just pass the error to the caller",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "sessions"`).
+					WithArgs(id, 1).
+					WillReturnError(assert.AnError)
+			},
+		},
+		{
+			name:        "data not found on db",
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "sessions"`).
+					WithArgs(id, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := repo.FindByID(ctx, id)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				if tc.expectedErr != nil {
+					assert.Equal(t, tc.expectedErr, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, id, res.ID)
+		})
+	}
+}
+
+func TestSessionRepository_RotateRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewSessionRepository(kit.DB)
+
+	input := usecase.RepoRotateRefreshTokenInput{
+		SessionID:           uuid.New(),
+		OldRefreshTokenHash: "old",
+		NewRefreshTokenHash: "new",
+		ExpiresAt:           time.Now().Add(time.Hour),
+	}
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^UPDATE \"sessions\" SET").
+					WithArgs(
+						input.ExpiresAt, sqlmock.AnyArg(), input.NewRefreshTokenHash, sqlmock.AnyArg(),
+						input.SessionID, input.OldRefreshTokenHash, sqlmock.AnyArg(),
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(input.SessionID))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "no session updated means the old refresh token is no longer valid",
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^UPDATE \"sessions\" SET").
+					WithArgs(
+						input.ExpiresAt, sqlmock.AnyArg(), input.NewRefreshTokenHash, sqlmock.AnyArg(),
+						input.SessionID, input.OldRefreshTokenHash, sqlmock.AnyArg(),
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^UPDATE \"sessions\" SET").
+					WithArgs(
+						input.ExpiresAt, sqlmock.AnyArg(), input.NewRefreshTokenHash, sqlmock.AnyArg(),
+						input.SessionID, input.OldRefreshTokenHash, sqlmock.AnyArg(),
+					).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := repo.RotateRefreshToken(ctx, input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				if tc.expectedErr != nil {
+					assert.Equal(t, tc.expectedErr, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, input.SessionID, res.ID)
+		})
+	}
+}
+
+func TestSessionRepository_RevokeByID(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewSessionRepository(kit.DB)
+
+	id := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := repo.RevokeByID(ctx, id)
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.RevokeByID(ctx, id)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestSessionRepository_RevokeAllUserSessions(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewSessionRepository(kit.DB)
+
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		dbMock.ExpectCommit()
+
+		err := repo.RevokeAllUserSessions(ctx, userID)
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.RevokeAllUserSessions(ctx, userID)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestSessionRepository_RevokeOtherUserSessions(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewSessionRepository(kit.DB)
+
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, sessionID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		dbMock.ExpectCommit()
+
+		err := repo.RevokeOtherUserSessions(ctx, userID, sessionID)
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, sessionID).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.RevokeOtherUserSessions(ctx, userID, sessionID)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestSessionRepository_FindActiveByUserID(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewSessionRepository(kit.DB)
+
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "sessions" WHERE .+revoked_at IS NULL AND expires_at > .+ ORDER BY created_at DESC`).
+			WithArgs(userID, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+
+		res, err := repo.FindActiveByUserID(ctx, userID)
+		require.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "sessions"`).
+			WithArgs(userID, sqlmock.AnyArg()).
+			WillReturnError(assert.AnError)
+
+		res, err := repo.FindActiveByUserID(ctx, userID)
+		require.Error(t, err)
+		require.Nil(t, res)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestSessionRepository_RevokeUserSession(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewSessionRepository(kit.DB)
+
+	id := uuid.New()
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id, userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := repo.RevokeUserSession(ctx, id, userID)
+		require.NoError(t, err)
+	})
+
+	t.Run("not found, owned by other user or already revoked", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id, userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := repo.RevokeUserSession(ctx, id, userID)
+		require.Error(t, err)
+		assert.Equal(t, repository.ErrNotFound, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id, userID).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.RevokeUserSession(ctx, id, userID)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestSessionRepository_UpdateLastSeenAt(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewSessionRepository(kit.DB)
+
+	id := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := repo.UpdateLastSeenAt(ctx, id)
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := repo.UpdateLastSeenAt(ctx, id)
+		require.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestSessionRepository_HasAnySession(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewSessionRepository(kit.DB)
+
+	userID := uuid.New()
+
+	t.Run("has session", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "sessions"`).
+			WithArgs(userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+		res, err := repo.HasAnySession(ctx, userID)
+		require.NoError(t, err)
+		assert.True(t, res)
+	})
+
+	t.Run("never logged in", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "sessions"`).
+			WithArgs(userID, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		res, err := repo.HasAnySession(ctx, userID)
+		require.NoError(t, err)
+		assert.False(t, res)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "sessions"`).
+			WithArgs(userID, 1).
+			WillReturnError(assert.AnError)
+
+		res, err := repo.HasAnySession(ctx, userID)
+		require.Error(t, err)
+		assert.False(t, res)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestSessionRepository_HasSessionFromClient(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewSessionRepository(kit.DB)
+
+	userID := uuid.New()
+	client := model.ClientInfo{
+		IPAddress: "203.0.113.10",
+		UserAgent: "Mozilla/5.0",
+	}
+
+	t.Run("has session from the client", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "sessions"`).
+			WithArgs(userID, client.IPAddress, client.UserAgent, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+		res, err := repo.HasSessionFromClient(ctx, userID, client)
+		require.NoError(t, err)
+		assert.True(t, res)
+	})
+
+	t.Run("never logged in from the client", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "sessions"`).
+			WithArgs(userID, client.IPAddress, client.UserAgent, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		res, err := repo.HasSessionFromClient(ctx, userID, client)
+		require.NoError(t, err)
+		assert.False(t, res)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "sessions"`).
+			WithArgs(userID, client.IPAddress, client.UserAgent, 1).
+			WillReturnError(assert.AnError)
+
+		res, err := repo.HasSessionFromClient(ctx, userID, client)
+		require.Error(t, err)
+		assert.False(t, res)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/luckyAkbar/atec/internal/model"
@@ -22,6 +24,8 @@ func UsecaseErrorUCAdapter(repoErr error) error {
 		return usecase.ErrRepoNotFound
 	case ErrTimeout:
 		return usecase.ErrRepoTimeout
+	case ErrDuplicate:
+		return usecase.ErrRepoDuplicate
 	case nil:
 		return nil
 	}
@@ -84,6 +88,61 @@ func (r *ChildRepositoryUCAdapter) DeleteAllUserChildren(
 	return fmt.Errorf("%w: invalid transaction controller, expecting typeof gorm transaction", usecase.ErrRepoInternal)
 }
 
+// RestoreAllUserChildren call the repository's RestoreAllUserChildren method and convert the error to usecase error
+func (r *ChildRepositoryUCAdapter) RestoreAllUserChildren(
+	ctx context.Context,
+	input usecase.RepoRestoreAllUserChildrenInput,
+	txController ...any,
+) error {
+	if len(txController) == 0 {
+		return r.repo.RestoreAllUserChildren(ctx, input)
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		return r.repo.RestoreAllUserChildren(ctx, input, tx)
+	}
+
+	return fmt.Errorf("%w: invalid transaction controller, expecting typeof gorm transaction", usecase.ErrRepoInternal)
+}
+
+// TransferOwnership call the repository's TransferOwnership method and convert the error to usecase error
+func (r *ChildRepositoryUCAdapter) TransferOwnership(
+	ctx context.Context,
+	input usecase.RepoTransferChildInput,
+	txController ...any,
+) error {
+	if len(txController) == 0 {
+		return UsecaseErrorUCAdapter(r.repo.TransferOwnership(ctx, input))
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		return UsecaseErrorUCAdapter(r.repo.TransferOwnership(ctx, input, tx))
+	}
+
+	return fmt.Errorf(
+		"%w: invalid transaction controller, expecting typeof gorm transaction got: %+v",
+		usecase.ErrRepoInternal,
+		reflect.TypeOf(txController[0]),
+	)
+}
+
+// DeleteByID call the repository's DeleteByID method and convert the error to usecase error
+func (r *ChildRepositoryUCAdapter) DeleteByID(ctx context.Context, id uuid.UUID, txController ...any) error {
+	if len(txController) == 0 {
+		return UsecaseErrorUCAdapter(r.repo.DeleteByID(ctx, id))
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		return UsecaseErrorUCAdapter(r.repo.DeleteByID(ctx, id, tx))
+	}
+
+	return fmt.Errorf(
+		"%w: invalid transaction controller, expecting typeof gorm transaction got: %+v",
+		usecase.ErrRepoInternal,
+		reflect.TypeOf(txController[0]),
+	)
+}
+
 // PackageRepositoryUCAdapter package repository usecase adapter
 type PackageRepositoryUCAdapter struct {
 	repo *PackageRepo
@@ -224,6 +283,65 @@ func (r *ResultRepositoryUCAdapter) DeleteAllUserResults(
 	return fmt.Errorf("%w: invalid transaction controller, expecting typeof gorm transaction", usecase.ErrRepoInternal)
 }
 
+// RestoreAllUserResults call the repository's RestoreAllUserResults method and convert the error to usecase error
+func (r *ResultRepositoryUCAdapter) RestoreAllUserResults(
+	ctx context.Context,
+	input usecase.RepoRestoreAllUserResultsInput,
+	txController ...any,
+) error {
+	if len(txController) == 0 {
+		return r.repo.RestoreAllUserResults(ctx, input)
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		return r.repo.RestoreAllUserResults(ctx, input, tx)
+	}
+
+	return fmt.Errorf("%w: invalid transaction controller, expecting typeof gorm transaction", usecase.ErrRepoInternal)
+}
+
+// TransferChildResults call the repository's TransferChildResults method and convert the error to usecase error
+func (r *ResultRepositoryUCAdapter) TransferChildResults(
+	ctx context.Context,
+	input usecase.RepoTransferChildInput,
+	txController ...any,
+) error {
+	if len(txController) == 0 {
+		return UsecaseErrorUCAdapter(r.repo.TransferChildResults(ctx, input))
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		return UsecaseErrorUCAdapter(r.repo.TransferChildResults(ctx, input, tx))
+	}
+
+	return fmt.Errorf(
+		"%w: invalid transaction controller, expecting typeof gorm transaction got: %+v",
+		usecase.ErrRepoInternal,
+		reflect.TypeOf(txController[0]),
+	)
+}
+
+// DeleteAllChildResults call the repository's DeleteAllChildResults method and convert the error to usecase error
+func (r *ResultRepositoryUCAdapter) DeleteAllChildResults(
+	ctx context.Context,
+	input usecase.RepoDeleteAllChildResultsInput,
+	txController ...any,
+) error {
+	if len(txController) == 0 {
+		return UsecaseErrorUCAdapter(r.repo.DeleteAllChildResults(ctx, input))
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		return UsecaseErrorUCAdapter(r.repo.DeleteAllChildResults(ctx, input, tx))
+	}
+
+	return fmt.Errorf(
+		"%w: invalid transaction controller, expecting typeof gorm transaction got: %+v",
+		usecase.ErrRepoInternal,
+		reflect.TypeOf(txController[0]),
+	)
+}
+
 // UserRepositoryUCAdapter user repository usecase adapter
 type UserRepositoryUCAdapter struct {
 	repo *UserRepository
@@ -254,8 +372,8 @@ func (r *UserRepositoryUCAdapter) Create(ctx context.Context, input usecase.Repo
 }
 
 // FindByEmail call the repository's FindByEmail method and convert the error to usecase error
-func (r *UserRepositoryUCAdapter) FindByEmail(ctx context.Context, email string) (*model.User, error) {
-	res, err := r.repo.FindByEmail(ctx, email)
+func (r *UserRepositoryUCAdapter) FindByEmail(ctx context.Context, lookup usecase.RepoEmailLookup) (*model.User, error) {
+	res, err := r.repo.FindByEmail(ctx, lookup)
 
 	return res, UsecaseErrorUCAdapter(err)
 }
@@ -282,8 +400,76 @@ func (r *UserRepositoryUCAdapter) Search(ctx context.Context, input usecase.Repo
 }
 
 // Update call the repository's Update method and convert the error to usecase error
-func (r *UserRepositoryUCAdapter) Update(ctx context.Context, userID uuid.UUID, input usecase.RepoUpdateUserInput) (*model.User, error) {
-	res, err := r.repo.Update(ctx, userID, input)
+func (r *UserRepositoryUCAdapter) Update(
+	ctx context.Context, userID uuid.UUID, input usecase.RepoUpdateUserInput, txController ...any,
+) (*model.User, error) {
+	if len(txController) == 0 {
+		res, err := r.repo.Update(ctx, userID, input)
+
+		return res, UsecaseErrorUCAdapter(err)
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		res, err := r.repo.Update(ctx, userID, input, tx)
+
+		return res, UsecaseErrorUCAdapter(err)
+	}
+
+	return nil, fmt.Errorf(
+		"%w: invalid transaction controller, expecting typeof gorm transaction got: %+v",
+		usecase.ErrRepoInternal,
+		reflect.TypeOf(txController[0]),
+	)
+}
+
+// IncrementFailedLoginAttempts call the repository's IncrementFailedLoginAttempts method and convert the error to usecase error
+func (r *UserRepositoryUCAdapter) IncrementFailedLoginAttempts(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	res, err := r.repo.IncrementFailedLoginAttempts(ctx, userID)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// UpdateEmail call the repository's UpdateEmail method and convert the error to usecase error
+func (r *UserRepositoryUCAdapter) UpdateEmail(
+	ctx context.Context, userID uuid.UUID, email, emailBlindIndex string, txController ...any,
+) error {
+	if len(txController) == 0 {
+		return UsecaseErrorUCAdapter(r.repo.UpdateEmail(ctx, userID, email, emailBlindIndex))
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		return UsecaseErrorUCAdapter(r.repo.UpdateEmail(ctx, userID, email, emailBlindIndex, tx))
+	}
+
+	return fmt.Errorf(
+		"%w: invalid transaction controller, expecting typeof gorm transaction got: %+v",
+		usecase.ErrRepoInternal,
+		reflect.TypeOf(txController[0]),
+	)
+}
+
+// UpdateDeletionRequestedAt call the repository's UpdateDeletionRequestedAt method and convert the error to usecase error
+func (r *UserRepositoryUCAdapter) UpdateDeletionRequestedAt(
+	ctx context.Context, userID uuid.UUID, requestedAt sql.NullTime, txController ...any,
+) error {
+	if len(txController) == 0 {
+		return UsecaseErrorUCAdapter(r.repo.UpdateDeletionRequestedAt(ctx, userID, requestedAt))
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		return UsecaseErrorUCAdapter(r.repo.UpdateDeletionRequestedAt(ctx, userID, requestedAt, tx))
+	}
+
+	return fmt.Errorf(
+		"%w: invalid transaction controller, expecting typeof gorm transaction got: %+v",
+		usecase.ErrRepoInternal,
+		reflect.TypeOf(txController[0]),
+	)
+}
+
+// FindDeletionRequestedBefore call the repository's FindDeletionRequestedBefore method and convert the error to usecase error
+func (r *UserRepositoryUCAdapter) FindDeletionRequestedBefore(ctx context.Context, before time.Time) ([]model.User, error) {
+	res, err := r.repo.FindDeletionRequestedBefore(ctx, before)
 
 	return res, UsecaseErrorUCAdapter(err)
 }
@@ -318,3 +504,555 @@ func (r *UserRepositoryUCAdapter) GetUsersByRoles(ctx context.Context, roles mod
 
 	return res, UsecaseErrorUCAdapter(err)
 }
+
+// SessionRepositoryUCAdapter session repository usecase adapter
+type SessionRepositoryUCAdapter struct {
+	repo *SessionRepository
+}
+
+// NewSessionRepositoryUCAdapter create new SessionRepositoryUCAdapter instance
+func NewSessionRepositoryUCAdapter(repo *SessionRepository) *SessionRepositoryUCAdapter {
+	return &SessionRepositoryUCAdapter{
+		repo: repo,
+	}
+}
+
+// Create call the repository's Create method and convert the error to usecase error
+func (r *SessionRepositoryUCAdapter) Create(ctx context.Context, input usecase.RepoCreateSessionInput) (*model.Session, error) {
+	res, err := r.repo.Create(ctx, input)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// FindByID call the repository's FindByID method and convert the error to usecase error
+func (r *SessionRepositoryUCAdapter) FindByID(ctx context.Context, id uuid.UUID) (*model.Session, error) {
+	res, err := r.repo.FindByID(ctx, id)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// RotateRefreshToken call the repository's RotateRefreshToken method and convert the error to usecase error
+func (r *SessionRepositoryUCAdapter) RotateRefreshToken(
+	ctx context.Context,
+	input usecase.RepoRotateRefreshTokenInput,
+) (*model.Session, error) {
+	res, err := r.repo.RotateRefreshToken(ctx, input)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// RevokeByID call the repository's RevokeByID method and convert the error to usecase error
+func (r *SessionRepositoryUCAdapter) RevokeByID(ctx context.Context, id uuid.UUID) error {
+	err := r.repo.RevokeByID(ctx, id)
+
+	return UsecaseErrorUCAdapter(err)
+}
+
+// RevokeAllUserSessions call the repository's RevokeAllUserSessions method and convert the error to usecase error
+func (r *SessionRepositoryUCAdapter) RevokeAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	err := r.repo.RevokeAllUserSessions(ctx, userID)
+
+	return UsecaseErrorUCAdapter(err)
+}
+
+// RevokeOtherUserSessions call the repository's RevokeOtherUserSessions method and convert the error to usecase error
+func (r *SessionRepositoryUCAdapter) RevokeOtherUserSessions(ctx context.Context, userID, exceptSessionID uuid.UUID) error {
+	err := r.repo.RevokeOtherUserSessions(ctx, userID, exceptSessionID)
+
+	return UsecaseErrorUCAdapter(err)
+}
+
+// FindActiveByUserID call the repository's FindActiveByUserID method and convert the error to usecase error
+func (r *SessionRepositoryUCAdapter) FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]model.Session, error) {
+	res, err := r.repo.FindActiveByUserID(ctx, userID)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// RevokeUserSession call the repository's RevokeUserSession method and convert the error to usecase error
+func (r *SessionRepositoryUCAdapter) RevokeUserSession(ctx context.Context, id, userID uuid.UUID) error {
+	return UsecaseErrorUCAdapter(r.repo.RevokeUserSession(ctx, id, userID))
+}
+
+// UpdateLastSeenAt call the repository's UpdateLastSeenAt method and convert the error to usecase error
+func (r *SessionRepositoryUCAdapter) UpdateLastSeenAt(ctx context.Context, id uuid.UUID) error {
+	return UsecaseErrorUCAdapter(r.repo.UpdateLastSeenAt(ctx, id))
+}
+
+// HasAnySession call the repository's HasAnySession method and convert the error to usecase error
+func (r *SessionRepositoryUCAdapter) HasAnySession(ctx context.Context, userID uuid.UUID) (bool, error) {
+	res, err := r.repo.HasAnySession(ctx, userID)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// HasSessionFromClient call the repository's HasSessionFromClient method and convert the error to usecase error
+func (r *SessionRepositoryUCAdapter) HasSessionFromClient(ctx context.Context, userID uuid.UUID, client model.ClientInfo) (bool, error) {
+	res, err := r.repo.HasSessionFromClient(ctx, userID, client)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// EmailTokenRepositoryUCAdapter email token repository usecase adapter
+type EmailTokenRepositoryUCAdapter struct {
+	repo *EmailTokenRepository
+}
+
+// NewEmailTokenRepositoryUCAdapter create new EmailTokenRepositoryUCAdapter instance
+func NewEmailTokenRepositoryUCAdapter(repo *EmailTokenRepository) *EmailTokenRepositoryUCAdapter {
+	return &EmailTokenRepositoryUCAdapter{
+		repo: repo,
+	}
+}
+
+// Create call the repository's Create method and convert the error to usecase error
+func (r *EmailTokenRepositoryUCAdapter) Create(
+	ctx context.Context,
+	input usecase.RepoCreateEmailTokenInput,
+	txController ...any,
+) (*model.EmailToken, error) {
+	if len(txController) == 0 {
+		res, err := r.repo.Create(ctx, input)
+
+		return res, UsecaseErrorUCAdapter(err)
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		res, err := r.repo.Create(ctx, input, tx)
+
+		return res, UsecaseErrorUCAdapter(err)
+	}
+
+	return nil, fmt.Errorf(
+		"%w: invalid transaction controller, expecting typeof gorm transaction got: %+v",
+		usecase.ErrRepoInternal,
+		reflect.TypeOf(txController[0]),
+	)
+}
+
+// Consume call the repository's Consume method and convert the error to usecase error
+func (r *EmailTokenRepositoryUCAdapter) Consume(
+	ctx context.Context,
+	input usecase.RepoConsumeEmailTokenInput,
+	txController ...any,
+) error {
+	if len(txController) == 0 {
+		return UsecaseErrorUCAdapter(r.repo.Consume(ctx, input))
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		return UsecaseErrorUCAdapter(r.repo.Consume(ctx, input, tx))
+	}
+
+	return fmt.Errorf(
+		"%w: invalid transaction controller, expecting typeof gorm transaction got: %+v",
+		usecase.ErrRepoInternal,
+		reflect.TypeOf(txController[0]),
+	)
+}
+
+// RevokeAllUserTokens call the repository's RevokeAllUserTokens method and convert the error to usecase error
+func (r *EmailTokenRepositoryUCAdapter) RevokeAllUserTokens(
+	ctx context.Context, userID uuid.UUID, purpose string, txController ...any,
+) error {
+	if len(txController) == 0 {
+		return UsecaseErrorUCAdapter(r.repo.RevokeAllUserTokens(ctx, userID, purpose))
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		return UsecaseErrorUCAdapter(r.repo.RevokeAllUserTokens(ctx, userID, purpose, tx))
+	}
+
+	return fmt.Errorf(
+		"%w: invalid transaction controller, expecting typeof gorm transaction got: %+v",
+		usecase.ErrRepoInternal,
+		reflect.TypeOf(txController[0]),
+	)
+}
+
+// MFARecoveryCodeRepositoryUCAdapter mfa recovery code repository usecase adapter
+type MFARecoveryCodeRepositoryUCAdapter struct {
+	repo *MFARecoveryCodeRepository
+}
+
+// NewMFARecoveryCodeRepositoryUCAdapter create new MFARecoveryCodeRepositoryUCAdapter instance
+func NewMFARecoveryCodeRepositoryUCAdapter(repo *MFARecoveryCodeRepository) *MFARecoveryCodeRepositoryUCAdapter {
+	return &MFARecoveryCodeRepositoryUCAdapter{
+		repo: repo,
+	}
+}
+
+// ReplaceAll call the repository's ReplaceAll method and convert the error to usecase error
+func (r *MFARecoveryCodeRepositoryUCAdapter) ReplaceAll(
+	ctx context.Context,
+	userID uuid.UUID,
+	codeHashes []string,
+	txController ...any,
+) error {
+	if len(txController) == 0 {
+		return UsecaseErrorUCAdapter(r.repo.ReplaceAll(ctx, userID, codeHashes))
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		return UsecaseErrorUCAdapter(r.repo.ReplaceAll(ctx, userID, codeHashes, tx))
+	}
+
+	return fmt.Errorf(
+		"%w: invalid transaction controller, expecting typeof gorm transaction got: %+v",
+		usecase.ErrRepoInternal,
+		reflect.TypeOf(txController[0]),
+	)
+}
+
+// Consume call the repository's Consume method and convert the error to usecase error
+func (r *MFARecoveryCodeRepositoryUCAdapter) Consume(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	return UsecaseErrorUCAdapter(r.repo.Consume(ctx, userID, codeHash))
+}
+
+// DeleteAll call the repository's DeleteAll method and convert the error to usecase error
+func (r *MFARecoveryCodeRepositoryUCAdapter) DeleteAll(ctx context.Context, userID uuid.UUID, txController ...any) error {
+	if len(txController) == 0 {
+		return UsecaseErrorUCAdapter(r.repo.DeleteAll(ctx, userID))
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		return UsecaseErrorUCAdapter(r.repo.DeleteAll(ctx, userID, tx))
+	}
+
+	return fmt.Errorf(
+		"%w: invalid transaction controller, expecting typeof gorm transaction got: %+v",
+		usecase.ErrRepoInternal,
+		reflect.TypeOf(txController[0]),
+	)
+}
+
+// PasswordlessLoginRepositoryUCAdapter passwordless login repository usecase adapter
+type PasswordlessLoginRepositoryUCAdapter struct {
+	repo *PasswordlessLoginRepository
+}
+
+// NewPasswordlessLoginRepositoryUCAdapter create new PasswordlessLoginRepositoryUCAdapter instance
+func NewPasswordlessLoginRepositoryUCAdapter(repo *PasswordlessLoginRepository) *PasswordlessLoginRepositoryUCAdapter {
+	return &PasswordlessLoginRepositoryUCAdapter{
+		repo: repo,
+	}
+}
+
+// Create call the repository's Create method and convert the error to usecase error
+func (r *PasswordlessLoginRepositoryUCAdapter) Create(ctx context.Context, input usecase.RepoCreatePasswordlessLoginInput) error {
+	return UsecaseErrorUCAdapter(r.repo.Create(ctx, input))
+}
+
+// FindByUserID call the repository's FindByUserID method and convert the error to usecase error
+func (r *PasswordlessLoginRepositoryUCAdapter) FindByUserID(ctx context.Context, userID uuid.UUID) (*model.PasswordlessLogin, error) {
+	res, err := r.repo.FindByUserID(ctx, userID)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// DeleteByUserID call the repository's DeleteByUserID method and convert the error to usecase error
+func (r *PasswordlessLoginRepositoryUCAdapter) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return UsecaseErrorUCAdapter(r.repo.DeleteByUserID(ctx, userID))
+}
+
+// PersonalAccessTokenRepositoryUCAdapter personal access token repository usecase adapter
+type PersonalAccessTokenRepositoryUCAdapter struct {
+	repo *PersonalAccessTokenRepository
+}
+
+// NewPersonalAccessTokenRepositoryUCAdapter create new PersonalAccessTokenRepositoryUCAdapter instance
+func NewPersonalAccessTokenRepositoryUCAdapter(repo *PersonalAccessTokenRepository) *PersonalAccessTokenRepositoryUCAdapter {
+	return &PersonalAccessTokenRepositoryUCAdapter{
+		repo: repo,
+	}
+}
+
+// Create call the repository's Create method and convert the error to usecase error
+func (r *PersonalAccessTokenRepositoryUCAdapter) Create(
+	ctx context.Context,
+	input usecase.RepoCreatePersonalAccessTokenInput,
+) (*model.PersonalAccessToken, error) {
+	res, err := r.repo.Create(ctx, input)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// FindByTokenHash call the repository's FindByTokenHash method and convert the error to usecase error
+func (r *PersonalAccessTokenRepositoryUCAdapter) FindByTokenHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error) {
+	res, err := r.repo.FindByTokenHash(ctx, tokenHash)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// FindByUserID call the repository's FindByUserID method and convert the error to usecase error
+func (r *PersonalAccessTokenRepositoryUCAdapter) FindByUserID(ctx context.Context, userID uuid.UUID) ([]model.PersonalAccessToken, error) {
+	res, err := r.repo.FindByUserID(ctx, userID)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// Revoke call the repository's Revoke method and convert the error to usecase error
+func (r *PersonalAccessTokenRepositoryUCAdapter) Revoke(ctx context.Context, id, userID uuid.UUID) error {
+	return UsecaseErrorUCAdapter(r.repo.Revoke(ctx, id, userID))
+}
+
+// UpdateLastUsedAt call the repository's UpdateLastUsedAt method and convert the error to usecase error
+func (r *PersonalAccessTokenRepositoryUCAdapter) UpdateLastUsedAt(ctx context.Context, id uuid.UUID) error {
+	return UsecaseErrorUCAdapter(r.repo.UpdateLastUsedAt(ctx, id))
+}
+
+// AuditLogRepositoryUCAdapter audit log repository usecase adapter
+type AuditLogRepositoryUCAdapter struct {
+	repo *AuditLogRepository
+}
+
+// NewAuditLogRepositoryUCAdapter create new AuditLogRepositoryUCAdapter instance
+func NewAuditLogRepositoryUCAdapter(repo *AuditLogRepository) *AuditLogRepositoryUCAdapter {
+	return &AuditLogRepositoryUCAdapter{
+		repo: repo,
+	}
+}
+
+// Create call the repository's Create method and convert the error to usecase error
+func (r *AuditLogRepositoryUCAdapter) Create(ctx context.Context, input usecase.RepoCreateAuditLogInput) error {
+	return UsecaseErrorUCAdapter(r.repo.Create(ctx, input))
+}
+
+// Search call the repository's Search method and convert the error to usecase error
+func (r *AuditLogRepositoryUCAdapter) Search(ctx context.Context, input usecase.RepoSearchAuditLogInput) ([]model.AuditLog, error) {
+	res, err := r.repo.Search(ctx, input)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// DeleteCreatedBefore call the repository's DeleteCreatedBefore method and convert the error to usecase error
+func (r *AuditLogRepositoryUCAdapter) DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.repo.DeleteCreatedBefore(ctx, before)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// DataExportRepositoryUCAdapter data export repository usecase adapter
+type DataExportRepositoryUCAdapter struct {
+	repo *DataExportRepository
+}
+
+// NewDataExportRepositoryUCAdapter create new DataExportRepositoryUCAdapter instance
+func NewDataExportRepositoryUCAdapter(repo *DataExportRepository) *DataExportRepositoryUCAdapter {
+	return &DataExportRepositoryUCAdapter{
+		repo: repo,
+	}
+}
+
+// Create call the repository's Create method and convert the error to usecase error
+func (r *DataExportRepositoryUCAdapter) Create(ctx context.Context, userID uuid.UUID) (*model.DataExport, error) {
+	res, err := r.repo.Create(ctx, userID)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// FindByUserID call the repository's FindByUserID method and convert the error to usecase error
+func (r *DataExportRepositoryUCAdapter) FindByUserID(ctx context.Context, userID uuid.UUID) ([]model.DataExport, error) {
+	res, err := r.repo.FindByUserID(ctx, userID)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// FindByDownloadTokenHash call the repository's FindByDownloadTokenHash method and convert the error to usecase error
+func (r *DataExportRepositoryUCAdapter) FindByDownloadTokenHash(ctx context.Context, tokenHash string) (*model.DataExport, error) {
+	res, err := r.repo.FindByDownloadTokenHash(ctx, tokenHash)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// UpdateStatus call the repository's UpdateStatus method and convert the error to usecase error
+func (r *DataExportRepositoryUCAdapter) UpdateStatus(ctx context.Context, id uuid.UUID, status model.DataExportStatus) error {
+	return UsecaseErrorUCAdapter(r.repo.UpdateStatus(ctx, id, status))
+}
+
+// FailStaleByUserID call the repository's FailStaleByUserID method and convert the error to usecase error
+func (r *DataExportRepositoryUCAdapter) FailStaleByUserID(ctx context.Context, userID uuid.UUID, updatedBefore time.Time) error {
+	return UsecaseErrorUCAdapter(r.repo.FailStaleByUserID(ctx, userID, updatedBefore))
+}
+
+// Complete call the repository's Complete method and convert the error to usecase error
+func (r *DataExportRepositoryUCAdapter) Complete(ctx context.Context, input usecase.RepoCompleteDataExportInput) error {
+	return UsecaseErrorUCAdapter(r.repo.Complete(ctx, input))
+}
+
+// CareRelationshipRepositoryUCAdapter care relationship repository usecase adapter
+type CareRelationshipRepositoryUCAdapter struct {
+	repo *CareRelationshipRepository
+}
+
+// NewCareRelationshipRepositoryUCAdapter create new CareRelationshipRepositoryUCAdapter instance
+func NewCareRelationshipRepositoryUCAdapter(repo *CareRelationshipRepository) *CareRelationshipRepositoryUCAdapter {
+	return &CareRelationshipRepositoryUCAdapter{
+		repo: repo,
+	}
+}
+
+// Upsert call the repository's Upsert method and convert the error to usecase error
+func (r *CareRelationshipRepositoryUCAdapter) Upsert(
+	ctx context.Context, input usecase.RepoUpsertCareRelationshipInput,
+) (*model.CareRelationship, error) {
+	res, err := r.repo.Upsert(ctx, input)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// FindByChildIDAndTherapistID call the repository's FindByChildIDAndTherapistID method and convert the error to usecase error
+func (r *CareRelationshipRepositoryUCAdapter) FindByChildIDAndTherapistID(
+	ctx context.Context, childID, therapistID uuid.UUID,
+) (*model.CareRelationship, error) {
+	res, err := r.repo.FindByChildIDAndTherapistID(ctx, childID, therapistID)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// FindByParentUserID call the repository's FindByParentUserID method and convert the error to usecase error
+func (r *CareRelationshipRepositoryUCAdapter) FindByParentUserID(
+	ctx context.Context, parentUserID uuid.UUID,
+) ([]model.CareRelationship, error) {
+	res, err := r.repo.FindByParentUserID(ctx, parentUserID)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// Delete call the repository's Delete method and convert the error to usecase error
+func (r *CareRelationshipRepositoryUCAdapter) Delete(ctx context.Context, childID, therapistID uuid.UUID) error {
+	return UsecaseErrorUCAdapter(r.repo.Delete(ctx, childID, therapistID))
+}
+
+// DeleteByUser call the repository's DeleteByUser method and convert the error to usecase error
+func (r *CareRelationshipRepositoryUCAdapter) DeleteByUser(ctx context.Context, childID, userID uuid.UUID, txController ...any) error {
+	if len(txController) == 0 {
+		return UsecaseErrorUCAdapter(r.repo.DeleteByUser(ctx, childID, userID))
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		return UsecaseErrorUCAdapter(r.repo.DeleteByUser(ctx, childID, userID, tx))
+	}
+
+	return fmt.Errorf(
+		"%w: invalid transaction controller, expecting typeof gorm transaction got: %+v",
+		usecase.ErrRepoInternal,
+		reflect.TypeOf(txController[0]),
+	)
+}
+
+// ChildGuardianRepositoryUCAdapter child guardian repository usecase adapter
+type ChildGuardianRepositoryUCAdapter struct {
+	repo *ChildGuardianRepository
+}
+
+// NewChildGuardianRepositoryUCAdapter create new ChildGuardianRepositoryUCAdapter instance
+func NewChildGuardianRepositoryUCAdapter(repo *ChildGuardianRepository) *ChildGuardianRepositoryUCAdapter {
+	return &ChildGuardianRepositoryUCAdapter{
+		repo: repo,
+	}
+}
+
+// Create call the repository's Create method and convert the error to usecase error
+func (r *ChildGuardianRepositoryUCAdapter) Create(
+	ctx context.Context,
+	input usecase.RepoCreateChildGuardianInput,
+	txController ...any,
+) (*model.ChildGuardian, error) {
+	if len(txController) == 0 {
+		res, err := r.repo.Create(ctx, input)
+
+		return res, UsecaseErrorUCAdapter(err)
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		res, err := r.repo.Create(ctx, input, tx)
+
+		return res, UsecaseErrorUCAdapter(err)
+	}
+
+	return nil, fmt.Errorf(
+		"%w: invalid transaction controller, expecting typeof gorm transaction got: %+v",
+		usecase.ErrRepoInternal,
+		reflect.TypeOf(txController[0]),
+	)
+}
+
+// FindByChildIDAndUserID call the repository's FindByChildIDAndUserID method and convert the error to usecase error
+func (r *ChildGuardianRepositoryUCAdapter) FindByChildIDAndUserID(
+	ctx context.Context, childID, userID uuid.UUID,
+) (*model.ChildGuardian, error) {
+	res, err := r.repo.FindByChildIDAndUserID(ctx, childID, userID)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// FindByChildID call the repository's FindByChildID method and convert the error to usecase error
+func (r *ChildGuardianRepositoryUCAdapter) FindByChildID(ctx context.Context, childID uuid.UUID) ([]model.ChildGuardian, error) {
+	res, err := r.repo.FindByChildID(ctx, childID)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// DeleteGuardian call the repository's DeleteGuardian method and convert the error to usecase error
+func (r *ChildGuardianRepositoryUCAdapter) DeleteGuardian(ctx context.Context, childID, userID uuid.UUID) error {
+	return UsecaseErrorUCAdapter(r.repo.DeleteGuardian(ctx, childID, userID))
+}
+
+// FindSuccessors call the repository's FindSuccessors method and convert the error to usecase error
+func (r *ChildGuardianRepositoryUCAdapter) FindSuccessors(
+	ctx context.Context,
+	ownerUserID uuid.UUID,
+	txController ...any,
+) ([]model.ChildGuardian, error) {
+	if len(txController) == 0 {
+		res, err := r.repo.FindSuccessors(ctx, ownerUserID)
+
+		return res, UsecaseErrorUCAdapter(err)
+	}
+
+	if tx, ok := txController[0].(*gorm.DB); ok {
+		res, err := r.repo.FindSuccessors(ctx, ownerUserID, tx)
+
+		return res, UsecaseErrorUCAdapter(err)
+	}
+
+	return nil, fmt.Errorf(
+		"%w: invalid transaction controller, expecting typeof gorm transaction got: %+v",
+		usecase.ErrRepoInternal,
+		reflect.TypeOf(txController[0]),
+	)
+}
+
+// OrganizationRepositoryUCAdapter organization repository usecase adapter
+type OrganizationRepositoryUCAdapter struct {
+	repo *OrganizationRepository
+}
+
+// NewOrganizationRepositoryUCAdapter create new OrganizationRepositoryUCAdapter instance
+func NewOrganizationRepositoryUCAdapter(repo *OrganizationRepository) *OrganizationRepositoryUCAdapter {
+	return &OrganizationRepositoryUCAdapter{
+		repo: repo,
+	}
+}
+
+// Create call the repository's Create method and convert the error to usecase error
+func (r *OrganizationRepositoryUCAdapter) Create(ctx context.Context, name string) (*model.Organization, error) {
+	res, err := r.repo.Create(ctx, name)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// FindByID call the repository's FindByID method and convert the error to usecase error
+func (r *OrganizationRepositoryUCAdapter) FindByID(ctx context.Context, id uuid.UUID) (*model.Organization, error) {
+	res, err := r.repo.FindByID(ctx, id)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
+
+// FindAll call the repository's FindAll method and convert the error to usecase error
+func (r *OrganizationRepositoryUCAdapter) FindAll(ctx context.Context) ([]model.Organization, error) {
+	res, err := r.repo.FindAll(ctx)
+
+	return res, UsecaseErrorUCAdapter(err)
+}
@@ -2,10 +2,14 @@ package repository_test
 
 import (
 	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/luckyAkbar/atec/internal/db"
 	"github.com/luckyAkbar/atec/internal/model"
 	"github.com/luckyAkbar/atec/internal/repository"
 	"github.com/luckyAkbar/atec/internal/usecase"
@@ -32,6 +36,10 @@ func TestUsecaeAdapter_UsecaseErrorUCAdapter(t *testing.T) {
 			input:  repository.ErrTimeout,
 			output: usecase.ErrRepoTimeout,
 		},
+		{
+			input:  repository.ErrDuplicate,
+			output: usecase.ErrRepoDuplicate,
+		},
 		{
 			input:  nil,
 			output: nil,
@@ -61,7 +69,14 @@ func TestChildRepositoryUCAdapter(t *testing.T) {
 		dbMock.ExpectBegin()
 
 		dbMock.ExpectQuery("^INSERT INTO \"children\"").
-			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+		dbMock.ExpectQuery("^INSERT INTO \"child_guardians\"").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
 
 		dbMock.ExpectCommit()
@@ -154,6 +169,111 @@ func TestChildRepositoryUCAdapter(t *testing.T) {
 		}, 1)
 		assert.Error(t, err)
 	})
+
+	t.Run("RestoreAllUserChildren", func(t *testing.T) {
+		input := usecase.RepoRestoreAllUserChildrenInput{UserID: uuid.New(), DeletedSince: time.Now()}
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"children\" SET").
+			WithArgs(nil, sqlmock.AnyArg(), input.UserID, input.DeletedSince).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.RestoreAllUserChildren(ctx, input)
+		assert.NoError(t, err)
+	})
+
+	t.Run("RestoreAllUserChildren with custom tx", func(t *testing.T) {
+		input := usecase.RepoRestoreAllUserChildrenInput{UserID: uuid.New(), DeletedSince: time.Now()}
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"children\" SET").
+			WithArgs(nil, sqlmock.AnyArg(), input.UserID, input.DeletedSince).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.RestoreAllUserChildren(ctx, input, kit.DB)
+		assert.NoError(t, err)
+	})
+
+	t.Run("RestoreAllUserChildren with invalid tx", func(t *testing.T) {
+		err := adapter.RestoreAllUserChildren(ctx, usecase.RepoRestoreAllUserChildrenInput{UserID: uuid.New()}, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("TransferOwnership", func(t *testing.T) {
+		input := usecase.RepoTransferChildInput{ChildID: uuid.New(), FromUserID: uuid.New(), ToUserID: uuid.New()}
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"children\" SET").
+			WithArgs(input.ToUserID, sqlmock.AnyArg(), input.ChildID, input.FromUserID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.TransferOwnership(ctx, input)
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+	})
+
+	t.Run("TransferOwnership with custom tx", func(t *testing.T) {
+		input := usecase.RepoTransferChildInput{ChildID: uuid.New(), FromUserID: uuid.New(), ToUserID: uuid.New()}
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"children\" SET").
+			WithArgs(input.ToUserID, sqlmock.AnyArg(), input.ChildID, input.FromUserID).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := adapter.TransferOwnership(ctx, input, kit.DB)
+		assert.ErrorIs(t, err, usecase.ErrRepoInternal)
+	})
+
+	t.Run("TransferOwnership with invalid tx", func(t *testing.T) {
+		err := adapter.TransferOwnership(ctx, usecase.RepoTransferChildInput{ChildID: uuid.New()}, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("DeleteByID", func(t *testing.T) {
+		id := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"children\"").
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.DeleteByID(ctx, id)
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+	})
+
+	t.Run("DeleteByID with custom tx", func(t *testing.T) {
+		id := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"children\"").
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.DeleteByID(ctx, id, kit.DB)
+		assert.NoError(t, err)
+	})
+
+	t.Run("DeleteByID with invalid tx", func(t *testing.T) {
+		err := adapter.DeleteByID(ctx, uuid.New(), 1)
+		assert.Error(t, err)
+	})
 }
 
 func TestPackageRepositoryUCAdapter(t *testing.T) {
@@ -171,7 +291,7 @@ func TestPackageRepositoryUCAdapter(t *testing.T) {
 	t.Run("Create - no controller", func(t *testing.T) {
 		dbMock.ExpectBegin()
 		dbMock.ExpectQuery(`^INSERT INTO "packages"`).
-			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnError(assert.AnError)
 		dbMock.ExpectRollback()
 
@@ -181,7 +301,7 @@ func TestPackageRepositoryUCAdapter(t *testing.T) {
 	t.Run("Create - with controller", func(t *testing.T) {
 		dbMock.ExpectBegin()
 		dbMock.ExpectQuery(`^INSERT INTO "packages"`).
-			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnError(assert.AnError)
 		dbMock.ExpectRollback()
 
@@ -322,6 +442,13 @@ func TestResultRepositoryUCAdapter(t *testing.T) {
 			WithArgs(userID, userID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
+		dbMock.ExpectCommit()
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"results\" SET \"created_by\"").
+			WithArgs(nil, sqlmock.AnyArg(), userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
 		dbMock.ExpectCommit()
 
 		err := adapter.DeleteAllUserResults(ctx, usecase.RepoDeleteAllUserResultsInput{
@@ -340,6 +467,13 @@ func TestResultRepositoryUCAdapter(t *testing.T) {
 			WithArgs(userID, userID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
+		dbMock.ExpectCommit()
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"results\" SET \"created_by\"").
+			WithArgs(nil, sqlmock.AnyArg(), userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
 		dbMock.ExpectCommit()
 
 		err := adapter.DeleteAllUserResults(ctx, usecase.RepoDeleteAllUserResultsInput{
@@ -356,6 +490,111 @@ func TestResultRepositoryUCAdapter(t *testing.T) {
 		}, 1)
 		assert.Error(t, err)
 	})
+
+	t.Run("RestoreAllUserResults", func(t *testing.T) {
+		input := usecase.RepoRestoreAllUserResultsInput{UserID: uuid.New(), DeletedSince: time.Now()}
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"results\" SET").
+			WithArgs(nil, sqlmock.AnyArg(), input.DeletedSince, input.UserID, input.UserID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.RestoreAllUserResults(ctx, input)
+		assert.NoError(t, err)
+	})
+
+	t.Run("RestoreAllUserResults with custom tx", func(t *testing.T) {
+		input := usecase.RepoRestoreAllUserResultsInput{UserID: uuid.New(), DeletedSince: time.Now()}
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"results\" SET").
+			WithArgs(nil, sqlmock.AnyArg(), input.DeletedSince, input.UserID, input.UserID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.RestoreAllUserResults(ctx, input, kit.DB)
+		assert.NoError(t, err)
+	})
+
+	t.Run("RestoreAllUserResults with invalid tx", func(t *testing.T) {
+		err := adapter.RestoreAllUserResults(ctx, usecase.RepoRestoreAllUserResultsInput{UserID: uuid.New()}, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("TransferChildResults", func(t *testing.T) {
+		input := usecase.RepoTransferChildInput{ChildID: uuid.New(), FromUserID: uuid.New(), ToUserID: uuid.New()}
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"results\" SET").
+			WithArgs(input.ToUserID, sqlmock.AnyArg(), input.ChildID, input.FromUserID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.TransferChildResults(ctx, input)
+		assert.NoError(t, err)
+	})
+
+	t.Run("TransferChildResults with custom tx", func(t *testing.T) {
+		input := usecase.RepoTransferChildInput{ChildID: uuid.New(), FromUserID: uuid.New(), ToUserID: uuid.New()}
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"results\" SET").
+			WithArgs(input.ToUserID, sqlmock.AnyArg(), input.ChildID, input.FromUserID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.TransferChildResults(ctx, input, kit.DB)
+		assert.NoError(t, err)
+	})
+
+	t.Run("TransferChildResults with invalid tx", func(t *testing.T) {
+		err := adapter.TransferChildResults(ctx, usecase.RepoTransferChildInput{ChildID: uuid.New()}, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("DeleteAllChildResults", func(t *testing.T) {
+		input := usecase.RepoDeleteAllChildResultsInput{ChildID: uuid.New()}
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"results\"").
+			WithArgs(input.ChildID).
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := adapter.DeleteAllChildResults(ctx, input)
+		assert.ErrorIs(t, err, usecase.ErrRepoInternal)
+	})
+
+	t.Run("DeleteAllChildResults with custom tx", func(t *testing.T) {
+		input := usecase.RepoDeleteAllChildResultsInput{ChildID: uuid.New()}
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"results\"").
+			WithArgs(input.ChildID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.DeleteAllChildResults(ctx, input, kit.DB)
+		assert.NoError(t, err)
+	})
+
+	t.Run("DeleteAllChildResults with invalid tx", func(t *testing.T) {
+		err := adapter.DeleteAllChildResults(ctx, usecase.RepoDeleteAllChildResultsInput{ChildID: uuid.New()}, 1)
+		assert.Error(t, err)
+	})
 }
 
 func TestUserRepositoryUCAdapter(t *testing.T) {
@@ -383,7 +622,9 @@ func TestUserRepositoryUCAdapter(t *testing.T) {
 		dbMock.ExpectBegin()
 
 		dbMock.ExpectQuery("^INSERT INTO \"users\"").
-			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
 
 		dbMock.ExpectCommit()
@@ -393,13 +634,13 @@ func TestUserRepositoryUCAdapter(t *testing.T) {
 	})
 
 	t.Run("FindByEmail - ok", func(t *testing.T) {
-		email := "email"
+		lookup := usecase.RepoEmailLookup{BlindIndex: "email-blind-index", LegacyEncryptedEmails: []string{"encrypted-email"}}
 
 		dbMock.ExpectQuery(`^SELECT .+ FROM "users"`).
-			WithArgs(email, 1).
+			WithArgs(lookup.BlindIndex, lookup.LegacyEncryptedEmails[0], 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
 
-		_, err := adapter.FindByEmail(ctx, email)
+		_, err := adapter.FindByEmail(ctx, lookup)
 		assert.NoError(t, err)
 	})
 
@@ -475,6 +716,140 @@ func TestUserRepositoryUCAdapter(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Update with tx - ok", func(t *testing.T) {
+		userID := uuid.New()
+		isActive := true
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery("^UPDATE \"users\" SET").
+			WithArgs(isActive, sqlmock.AnyArg(), userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+
+		dbMock.ExpectCommit()
+
+		_, err := adapter.Update(ctx, userID, usecase.RepoUpdateUserInput{IsActive: &isActive}, kit.DB)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Update with invalid tx", func(t *testing.T) {
+		_, err := adapter.Update(ctx, uuid.New(), usecase.RepoUpdateUserInput{}, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("IncrementFailedLoginAttempts - ok", func(t *testing.T) {
+		userID := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery("^UPDATE \"users\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+
+		dbMock.ExpectCommit()
+
+		_, err := adapter.IncrementFailedLoginAttempts(ctx, userID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("UpdateEmail - ok", func(t *testing.T) {
+		userID := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"users\" SET").
+			WithArgs("new-email", "new-email-blind-index", sqlmock.AnyArg(), userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.UpdateEmail(ctx, userID, "new-email", "new-email-blind-index")
+		assert.NoError(t, err)
+	})
+
+	t.Run("UpdateEmail with tx - ok", func(t *testing.T) {
+		userID := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"users\" SET").
+			WithArgs("new-email", "new-email-blind-index", sqlmock.AnyArg(), userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.UpdateEmail(ctx, userID, "new-email", "new-email-blind-index", kit.DB)
+		assert.NoError(t, err)
+	})
+
+	t.Run("UpdateEmail - duplicate", func(t *testing.T) {
+		userID := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"users\" SET").
+			WithArgs("new-email", "new-email-blind-index", sqlmock.AnyArg(), userID).
+			WillReturnError(&pgconn.PgError{Code: "23505"})
+
+		dbMock.ExpectRollback()
+
+		err := adapter.UpdateEmail(ctx, userID, "new-email", "new-email-blind-index")
+		assert.Equal(t, usecase.ErrRepoDuplicate, err)
+	})
+
+	t.Run("UpdateEmail with invalid tx", func(t *testing.T) {
+		err := adapter.UpdateEmail(ctx, uuid.New(), "new-email", "new-email-blind-index", 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("UpdateDeletionRequestedAt - ok", func(t *testing.T) {
+		userID := uuid.New()
+		requestedAt := sql.NullTime{Time: time.Now(), Valid: true}
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"users\" SET").
+			WithArgs(requestedAt, false, sqlmock.AnyArg(), userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.UpdateDeletionRequestedAt(ctx, userID, requestedAt)
+		assert.NoError(t, err)
+	})
+
+	t.Run("UpdateDeletionRequestedAt with tx - not found", func(t *testing.T) {
+		userID := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"users\" SET").
+			WithArgs(sql.NullTime{}, true, sqlmock.AnyArg(), userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.UpdateDeletionRequestedAt(ctx, userID, sql.NullTime{}, kit.DB)
+		assert.Equal(t, usecase.ErrRepoNotFound, err)
+	})
+
+	t.Run("UpdateDeletionRequestedAt with invalid tx", func(t *testing.T) {
+		err := adapter.UpdateDeletionRequestedAt(ctx, uuid.New(), sql.NullTime{}, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("FindDeletionRequestedBefore - ok", func(t *testing.T) {
+		before := time.Now()
+
+		dbMock.ExpectQuery("^SELECT .+ FROM \"users\"").
+			WithArgs(before).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+		res, err := adapter.FindDeletionRequestedBefore(ctx, before)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
 	t.Run("DeleteByID - ok", func(t *testing.T) {
 		userID := uuid.New()
 
@@ -518,3 +893,774 @@ func TestUserRepositoryUCAdapter(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestSessionRepositoryUCAdapter(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewSessionRepository(kit.DB)
+
+	adapter := repository.NewSessionRepositoryUCAdapter(repo)
+
+	t.Run("Create", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery("^INSERT INTO \"sessions\"").
+			WithArgs(
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+		dbMock.ExpectCommit()
+
+		_, err := adapter.Create(ctx, usecase.RepoCreateSessionInput{ID: uuid.New()})
+		assert.NoError(t, err)
+	})
+
+	t.Run("FindByID", func(t *testing.T) {
+		id := uuid.New()
+
+		dbMock.ExpectQuery(`^SELECT .+ FROM "sessions"`).
+			WithArgs(id, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+		_, err := adapter.FindByID(ctx, id)
+		assert.NoError(t, err)
+	})
+
+	t.Run("RotateRefreshToken", func(t *testing.T) {
+		id := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), id, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		dbMock.ExpectCommit()
+
+		_, err := adapter.RotateRefreshToken(ctx, usecase.RepoRotateRefreshTokenInput{SessionID: id})
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+	})
+
+	t.Run("RevokeByID", func(t *testing.T) {
+		id := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.RevokeByID(ctx, id)
+		assert.NoError(t, err)
+	})
+
+	t.Run("RevokeAllUserSessions", func(t *testing.T) {
+		userID := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.RevokeAllUserSessions(ctx, userID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("RevokeOtherUserSessions", func(t *testing.T) {
+		userID := uuid.New()
+		sessionID := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, sessionID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.RevokeOtherUserSessions(ctx, userID, sessionID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("FindActiveByUserID", func(t *testing.T) {
+		userID := uuid.New()
+
+		dbMock.ExpectQuery(`^SELECT .+ FROM "sessions"`).
+			WithArgs(userID, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+		_, err := adapter.FindActiveByUserID(ctx, userID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("RevokeUserSession", func(t *testing.T) {
+		id := uuid.New()
+		userID := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id, userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.RevokeUserSession(ctx, id, userID)
+		assert.Equal(t, usecase.ErrRepoNotFound, err)
+	})
+
+	t.Run("UpdateLastSeenAt", func(t *testing.T) {
+		id := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"sessions\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.UpdateLastSeenAt(ctx, id)
+		assert.NoError(t, err)
+	})
+
+	t.Run("HasAnySession", func(t *testing.T) {
+		userID := uuid.New()
+
+		dbMock.ExpectQuery(`^SELECT .+ FROM "sessions"`).
+			WithArgs(userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+		res, err := adapter.HasAnySession(ctx, userID)
+		assert.NoError(t, err)
+		assert.True(t, res)
+	})
+
+	t.Run("HasSessionFromClient", func(t *testing.T) {
+		userID := uuid.New()
+
+		dbMock.ExpectQuery(`^SELECT .+ FROM "sessions"`).
+			WithArgs(userID, "203.0.113.10", "Mozilla/5.0", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, err := adapter.HasSessionFromClient(ctx, userID, model.ClientInfo{
+			IPAddress: "203.0.113.10",
+			UserAgent: "Mozilla/5.0",
+		})
+		assert.NoError(t, err)
+		assert.False(t, res)
+	})
+}
+
+func TestEmailTokenRepositoryUCAdapter(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewEmailTokenRepository(kit.DB)
+
+	adapter := repository.NewEmailTokenRepositoryUCAdapter(repo)
+
+	t.Run("Create", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^INSERT INTO \"email_tokens\"").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		_, err := adapter.Create(ctx, usecase.RepoCreateEmailTokenInput{ID: uuid.New()})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Create with invalid tx controller", func(t *testing.T) {
+		_, err := adapter.Create(ctx, usecase.RepoCreateEmailTokenInput{ID: uuid.New()}, "invalid")
+		assert.Error(t, err)
+	})
+
+	t.Run("Consume", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"email_tokens\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.Consume(ctx, usecase.RepoConsumeEmailTokenInput{ID: uuid.New(), UserID: uuid.New()})
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+	})
+
+	t.Run("Consume with invalid tx controller", func(t *testing.T) {
+		err := adapter.Consume(ctx, usecase.RepoConsumeEmailTokenInput{ID: uuid.New()}, "invalid")
+		assert.Error(t, err)
+	})
+
+	t.Run("RevokeAllUserTokens", func(t *testing.T) {
+		userID := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"email_tokens\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.RevokeAllUserTokens(ctx, userID, string(usecase.ChangePasswordToken))
+		assert.NoError(t, err)
+	})
+
+	t.Run("RevokeAllUserTokens with tx", func(t *testing.T) {
+		userID := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"email_tokens\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.RevokeAllUserTokens(ctx, userID, string(usecase.ChangePasswordToken), kit.DB)
+		assert.NoError(t, err)
+	})
+
+	t.Run("RevokeAllUserTokens with invalid tx controller", func(t *testing.T) {
+		err := adapter.RevokeAllUserTokens(ctx, uuid.New(), string(usecase.ChangePasswordToken), "invalid")
+		assert.Error(t, err)
+	})
+}
+
+func TestMFARecoveryCodeRepositoryUCAdapter(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewMFARecoveryCodeRepository(kit.DB)
+
+	adapter := repository.NewMFARecoveryCodeRepositoryUCAdapter(repo)
+
+	t.Run("ReplaceAll", func(t *testing.T) {
+		userID := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"mfa_recovery_codes\"").
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.ReplaceAll(ctx, userID, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("ReplaceAll with invalid tx controller", func(t *testing.T) {
+		err := adapter.ReplaceAll(ctx, uuid.New(), nil, "invalid")
+		assert.Error(t, err)
+	})
+
+	t.Run("Consume", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"mfa_recovery_codes\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.Consume(ctx, uuid.New(), "hash")
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+	})
+
+	t.Run("DeleteAll", func(t *testing.T) {
+		userID := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"mfa_recovery_codes\"").
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 10))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.DeleteAll(ctx, userID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("DeleteAll with invalid tx controller", func(t *testing.T) {
+		err := adapter.DeleteAll(ctx, uuid.New(), "invalid")
+		assert.Error(t, err)
+	})
+}
+
+func TestPasswordlessLoginRepositoryUCAdapter(t *testing.T) {
+	ctx := context.Background()
+	cacher := db_mock.NewCacheKeeperIface(t)
+	repo := repository.NewPasswordlessLoginRepository(cacher)
+
+	adapter := repository.NewPasswordlessLoginRepositoryUCAdapter(repo)
+
+	t.Run("Create", func(t *testing.T) {
+		userID := uuid.New()
+
+		cacher.EXPECT().SetJSON(ctx, repository.CacheKeyForPasswordlessLogin(userID), mock.Anything, time.Minute).Return(nil).Once()
+
+		err := adapter.Create(ctx, usecase.RepoCreatePasswordlessLoginInput{UserID: userID, ExpiresIn: time.Minute})
+		assert.NoError(t, err)
+	})
+
+	t.Run("FindByUserID", func(t *testing.T) {
+		userID := uuid.New()
+
+		cacher.EXPECT().Get(ctx, repository.CacheKeyForPasswordlessLogin(userID)).Return("", db.ErrCacheKeyNotFound).Once()
+
+		res, err := adapter.FindByUserID(ctx, userID)
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("DeleteByUserID", func(t *testing.T) {
+		userID := uuid.New()
+
+		cacher.EXPECT().Del(ctx, repository.CacheKeyForPasswordlessLogin(userID)).Return(nil).Once()
+
+		err := adapter.DeleteByUserID(ctx, userID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestPersonalAccessTokenRepositoryUCAdapter(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewPersonalAccessTokenRepository(kit.DB)
+
+	adapter := repository.NewPersonalAccessTokenRepositoryUCAdapter(repo)
+
+	t.Run("Create", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery("^INSERT INTO \"personal_access_tokens\"").
+			WithArgs(
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+		dbMock.ExpectCommit()
+
+		_, err := adapter.Create(ctx, usecase.RepoCreatePersonalAccessTokenInput{UserID: uuid.New()})
+		assert.NoError(t, err)
+	})
+
+	t.Run("FindByTokenHash", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "personal_access_tokens"`).
+			WithArgs("hashed", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := adapter.FindByTokenHash(ctx, "hashed")
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+	})
+
+	t.Run("FindByUserID", func(t *testing.T) {
+		userID := uuid.New()
+
+		dbMock.ExpectQuery(`^SELECT .+ FROM "personal_access_tokens"`).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+		_, err := adapter.FindByUserID(ctx, userID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		id := uuid.New()
+		userID := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"personal_access_tokens\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id, userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.Revoke(ctx, id, userID)
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+	})
+
+	t.Run("UpdateLastUsedAt", func(t *testing.T) {
+		id := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"personal_access_tokens\" SET").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.UpdateLastUsedAt(ctx, id)
+		assert.NoError(t, err)
+	})
+}
+
+func TestAuditLogRepositoryUCAdapter(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewAuditLogRepository(kit.DB)
+
+	adapter := repository.NewAuditLogRepositoryUCAdapter(repo)
+
+	t.Run("Create", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery("^INSERT INTO \"audit_logs\"").
+			WithArgs(
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "actor_id"}).AddRow(uuid.New(), uuid.New()))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.Create(ctx, usecase.RepoCreateAuditLogInput{ActorID: uuid.New()})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "audit_logs"`).
+			WithArgs(10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := adapter.Search(ctx, usecase.RepoSearchAuditLogInput{Limit: 10})
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+	})
+
+	t.Run("DeleteCreatedBefore", func(t *testing.T) {
+		before := time.Now()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"audit_logs\"").
+			WithArgs(before).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		dbMock.ExpectCommit()
+
+		deleted, err := adapter.DeleteCreatedBefore(ctx, before)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+	})
+}
+
+func TestDataExportRepositoryUCAdapter(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewDataExportRepository(kit.DB)
+
+	adapter := repository.NewDataExportRepositoryUCAdapter(repo)
+
+	t.Run("Create", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery("^INSERT INTO \"data_exports\"").
+			WillReturnError(&pgconn.PgError{Code: "23505"})
+
+		dbMock.ExpectRollback()
+
+		_, err := adapter.Create(ctx, uuid.New())
+		assert.ErrorIs(t, err, usecase.ErrRepoDuplicate)
+	})
+
+	t.Run("FindByUserID", func(t *testing.T) {
+		userID := uuid.New()
+
+		dbMock.ExpectQuery(`^SELECT .+ FROM "data_exports"`).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+		_, err := adapter.FindByUserID(ctx, userID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("FindByDownloadTokenHash", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "data_exports"`).
+			WithArgs("hashed", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := adapter.FindByDownloadTokenHash(ctx, "hashed")
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+	})
+
+	t.Run("UpdateStatus", func(t *testing.T) {
+		id := uuid.New()
+
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"data_exports\" SET").
+			WithArgs(model.DataExportStatusFailed, sqlmock.AnyArg(), id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.UpdateStatus(ctx, id, model.DataExportStatusFailed)
+		assert.NoError(t, err)
+	})
+
+	t.Run("FailStaleByUserID", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"data_exports\" SET").
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		err := adapter.FailStaleByUserID(ctx, uuid.New(), time.Now())
+		assert.ErrorIs(t, err, usecase.ErrRepoInternal)
+	})
+
+	t.Run("Complete", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^UPDATE \"data_exports\" SET").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.Complete(ctx, usecase.RepoCompleteDataExportInput{ID: uuid.New()})
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+	})
+}
+
+func TestCareRelationshipRepositoryUCAdapter(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewCareRelationshipRepository(kit.DB)
+
+	adapter := repository.NewCareRelationshipRepositoryUCAdapter(repo)
+
+	t.Run("Upsert", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery("^INSERT INTO \"care_relationships\"").
+			WillReturnError(assert.AnError)
+
+		dbMock.ExpectRollback()
+
+		_, err := adapter.Upsert(ctx, usecase.RepoUpsertCareRelationshipInput{ChildID: uuid.New(), TherapistID: uuid.New()})
+		assert.ErrorIs(t, err, usecase.ErrRepoInternal)
+	})
+
+	t.Run("FindByChildIDAndTherapistID", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "care_relationships"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := adapter.FindByChildIDAndTherapistID(ctx, uuid.New(), uuid.New())
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+	})
+
+	t.Run("FindByParentUserID", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "care_relationships"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+		_, err := adapter.FindByParentUserID(ctx, uuid.New())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"care_relationships\"").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.Delete(ctx, uuid.New(), uuid.New())
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+	})
+
+	t.Run("DeleteByUser", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"care_relationships\"").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.DeleteByUser(ctx, uuid.New(), uuid.New())
+		assert.NoError(t, err)
+	})
+
+	t.Run("DeleteByUser with custom tx", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"care_relationships\"").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.DeleteByUser(ctx, uuid.New(), uuid.New(), kit.DB)
+		assert.NoError(t, err)
+	})
+
+	t.Run("DeleteByUser with invalid tx", func(t *testing.T) {
+		err := adapter.DeleteByUser(ctx, uuid.New(), uuid.New(), 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestOrganizationRepositoryUCAdapter(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewOrganizationRepository(kit.DB)
+
+	adapter := repository.NewOrganizationRepositoryUCAdapter(repo)
+
+	t.Run("Create", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery("^INSERT INTO \"organizations\"").
+			WillReturnError(&pgconn.PgError{Code: "23505"})
+
+		dbMock.ExpectRollback()
+
+		_, err := adapter.Create(ctx, "clinic")
+		assert.ErrorIs(t, err, usecase.ErrRepoDuplicate)
+	})
+
+	t.Run("FindByID", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "organizations"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := adapter.FindByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+	})
+
+	t.Run("FindAll", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "organizations"`).
+			WillReturnError(assert.AnError)
+
+		_, err := adapter.FindAll(ctx)
+		assert.ErrorIs(t, err, usecase.ErrRepoInternal)
+	})
+}
+
+func TestChildGuardianRepositoryUCAdapter(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewChildGuardianRepository(kit.DB)
+
+	adapter := repository.NewChildGuardianRepositoryUCAdapter(repo)
+
+	t.Run("Create", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery("^INSERT INTO \"child_guardians\"").
+			WillReturnError(&pgconn.PgError{Code: "23505"})
+
+		dbMock.ExpectRollback()
+
+		_, err := adapter.Create(ctx, usecase.RepoCreateChildGuardianInput{ChildID: uuid.New(), UserID: uuid.New()})
+		assert.ErrorIs(t, err, usecase.ErrRepoDuplicate)
+	})
+
+	t.Run("Create with custom tx", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectQuery("^INSERT INTO \"child_guardians\"").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+		dbMock.ExpectCommit()
+
+		_, err := adapter.Create(ctx, usecase.RepoCreateChildGuardianInput{ChildID: uuid.New(), UserID: uuid.New()}, kit.DB)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Create with invalid tx", func(t *testing.T) {
+		_, err := adapter.Create(ctx, usecase.RepoCreateChildGuardianInput{ChildID: uuid.New(), UserID: uuid.New()}, 1)
+		assert.ErrorIs(t, err, usecase.ErrRepoInternal)
+	})
+
+	t.Run("FindByChildIDAndUserID", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "child_guardians"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := adapter.FindByChildIDAndUserID(ctx, uuid.New(), uuid.New())
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+	})
+
+	t.Run("FindByChildID", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT .+ FROM "child_guardians"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+		_, err := adapter.FindByChildID(ctx, uuid.New())
+		assert.NoError(t, err)
+	})
+
+	t.Run("DeleteGuardian", func(t *testing.T) {
+		dbMock.ExpectBegin()
+
+		dbMock.ExpectExec("^DELETE FROM \"child_guardians\"").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		dbMock.ExpectCommit()
+
+		err := adapter.DeleteGuardian(ctx, uuid.New(), uuid.New())
+		assert.ErrorIs(t, err, usecase.ErrRepoNotFound)
+	})
+
+	t.Run("FindSuccessors", func(t *testing.T) {
+		dbMock.ExpectQuery(`^SELECT DISTINCT ON \(child_id\) \* FROM "child_guardians"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+		_, err := adapter.FindSuccessors(ctx, uuid.New())
+		assert.NoError(t, err)
+	})
+
+	t.Run("FindSuccessors - invalid transaction controller", func(t *testing.T) {
+		_, err := adapter.FindSuccessors(ctx, uuid.New(), "invalid")
+		assert.ErrorIs(t, err, usecase.ErrRepoInternal)
+	})
+}
@@ -3,6 +3,8 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/luckyAkbar/atec/internal/model"
@@ -24,10 +26,10 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 }
 
 // FindByEmail find exactly one record from users table with matching email
-func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+func (r *UserRepository) FindByEmail(ctx context.Context, lookup usecase.RepoEmailLookup) (*model.User, error) {
 	user := &model.User{}
 
-	err := r.db.WithContext(ctx).Take(user, "email = ?", email).Error
+	err := whereEmail(r.db.WithContext(ctx), lookup).Take(user).Error
 	switch err {
 	default:
 		return nil, err
@@ -46,13 +48,14 @@ func (r *UserRepository) Create(ctx context.Context, input usecase.RepoCreateUse
 	}
 
 	user := &model.User{
-		Email:       input.Email,
-		Password:    input.Password,
-		IsActive:    input.IsActive,
-		Roles:       input.Roles,
-		Username:    input.Username,
-		PhoneNumber: input.PhoneNumber,
-		Address:     input.Address,
+		Email:           input.Email,
+		EmailBlindIndex: sql.NullString{String: input.EmailBlindIndex, Valid: true},
+		Password:        input.Password,
+		IsActive:        input.IsActive,
+		Roles:           input.Roles,
+		Username:        input.Username,
+		PhoneNumber:     input.PhoneNumber,
+		Address:         input.Address,
 	}
 
 	err := tx.Create(user).Error
@@ -94,6 +97,50 @@ func updateUserInputToUpdatedFields(uui usecase.RepoUpdateUserInput) map[string]
 		fields["is_active"] = *uui.IsActive
 	}
 
+	if uui.Roles != "" {
+		fields["roles"] = uui.Roles
+	}
+
+	if uui.MFASecret != nil {
+		if uui.MFASecret.Valid {
+			fields["mfa_secret"] = uui.MFASecret.String
+		} else {
+			fields["mfa_secret"] = gorm.Expr("NULL")
+		}
+	}
+
+	if uui.MFAEnabled != nil {
+		fields["mfa_enabled"] = *uui.MFAEnabled
+	}
+
+	if uui.MFARequired != nil {
+		fields["mfa_required"] = *uui.MFARequired
+	}
+
+	if uui.FailedLoginAttempts != nil {
+		fields["failed_login_attempts"] = *uui.FailedLoginAttempts
+	}
+
+	if uui.LockedUntil != nil {
+		if uui.LockedUntil.Valid {
+			fields["locked_until"] = uui.LockedUntil.Time
+		} else {
+			fields["locked_until"] = gorm.Expr("NULL")
+		}
+	}
+
+	if uui.OrganizationID != nil {
+		if uui.OrganizationID.Valid {
+			fields["organization_id"] = uui.OrganizationID.UUID
+		} else {
+			fields["organization_id"] = gorm.Expr("NULL")
+		}
+	}
+
+	if uui.IsOrganizationAdmin != nil {
+		fields["is_organization_admin"] = *uui.IsOrganizationAdmin
+	}
+
 	return fields
 }
 
@@ -120,10 +167,17 @@ func updateUserProfileInputToUpdatedFields(input usecase.RepoUpdateUserProfileIn
 }
 
 // Update update a users record by its id
-func (r *UserRepository) Update(ctx context.Context, userID uuid.UUID, input usecase.RepoUpdateUserInput) (*model.User, error) {
+func (r *UserRepository) Update(
+	ctx context.Context, userID uuid.UUID, input usecase.RepoUpdateUserInput, txController ...*gorm.DB,
+) (*model.User, error) {
+	tx := r.db.WithContext(ctx)
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
 	user := &model.User{}
 
-	err := r.db.WithContext(ctx).Model(user).
+	err := tx.Model(user).
 		Clauses(clause.Returning{}).Where("id = ?", userID).
 		Updates(updateUserInputToUpdatedFields(input)).Error
 	if err != nil {
@@ -133,6 +187,140 @@ func (r *UserRepository) Update(ctx context.Context, userID uuid.UUID, input use
 	return user, nil
 }
 
+// IncrementFailedLoginAttempts atomically increase the failed login attempts counter by one and record the time
+// of the failure, returning the updated user record
+func (r *UserRepository) IncrementFailedLoginAttempts(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	user := &model.User{}
+
+	res := r.db.WithContext(ctx).Model(user).
+		Clauses(clause.Returning{}).Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"failed_login_attempts": gorm.Expr("failed_login_attempts + 1"),
+			"last_failed_login_at":  time.Now(),
+		})
+
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return nil, ErrNotFound
+	}
+
+	return user, nil
+}
+
+// UpdateEmail replace the user's email and its blind index. ErrDuplicate will be returned if the email is already used
+// by another user, and ErrNotFound if the user does not exist
+func (r *UserRepository) UpdateEmail(
+	ctx context.Context, userID uuid.UUID, email, emailBlindIndex string, txController ...*gorm.DB,
+) error {
+	tx := r.db.WithContext(ctx)
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	res := tx.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"email":             email,
+		"email_blind_index": emailBlindIndex,
+	})
+	if res.Error != nil {
+		if isUniqueViolation(res.Error) {
+			return ErrDuplicate
+		}
+
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateDeletionRequestedAt set when the user requested the account deletion. The account is deactivated when requestedAt
+// is valid, and activated again when it is not. ErrNotFound will be returned if the user does not exist
+func (r *UserRepository) UpdateDeletionRequestedAt(
+	ctx context.Context, userID uuid.UUID, requestedAt sql.NullTime, txController ...*gorm.DB,
+) error {
+	tx := r.db.WithContext(ctx)
+	if len(txController) > 0 {
+		tx = txController[0]
+	}
+
+	res := tx.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"deletion_requested_at": requestedAt,
+		"is_active":             !requestedAt.Valid,
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// FindDeletionRequestedBefore find all users whose account deletion was requested before the given time
+func (r *UserRepository) FindDeletionRequestedBefore(ctx context.Context, before time.Time) ([]model.User, error) {
+	users := []model.User{}
+
+	err := r.db.WithContext(ctx).
+		Where("deletion_requested_at IS NOT NULL AND deletion_requested_at < ?", before).
+		Order("deletion_requested_at ASC").
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// FindBatchAfterID find at most limit users ordered by id, starting right after afterID. Soft deleted users are included.
+// Use uuid.Nil as afterID to start from the first user
+func (r *UserRepository) FindBatchAfterID(ctx context.Context, afterID uuid.UUID, limit int) ([]model.User, error) {
+	users := []model.User{}
+
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("id > ?", afterID).Order("id ASC").Limit(limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// Reencrypt replace the encrypted fields of the user, including the soft deleted one, without changing updated_at.
+// ErrNotFound will be returned if the user does not exist or any of the encrypted fields has changed since it was read
+func (r *UserRepository) Reencrypt(ctx context.Context, input usecase.RepoReencryptUserInput) error {
+	res := r.db.WithContext(ctx).Unscoped().Model(&model.User{}).
+		Where("id = ? AND email = ?", input.UserID, input.Current.Email).
+		Where("email_blind_index IS NOT DISTINCT FROM ?", input.Current.EmailBlindIndex).
+		Where("phone_number IS NOT DISTINCT FROM ?", input.Current.PhoneNumber).
+		Where("address IS NOT DISTINCT FROM ?", input.Current.Address).
+		Where("mfa_secret IS NOT DISTINCT FROM ?", input.Current.MFASecret).
+		UpdateColumns(map[string]interface{}{
+			"email":             input.Reencrypted.Email,
+			"email_blind_index": input.Reencrypted.EmailBlindIndex,
+			"phone_number":      input.Reencrypted.PhoneNumber,
+			"address":           input.Reencrypted.Address,
+			"mfa_secret":        input.Reencrypted.MFASecret,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
 // UpdateProfile update changeable fields in user's profile by its id
 func (r *UserRepository) UpdateProfile(ctx context.Context, userID uuid.UUID, input usecase.RepoUpdateUserProfileInput) (*model.User, error) {
 	user := &model.User{}
@@ -163,11 +351,35 @@ func (r *UserRepository) IsAdminAccountExists(ctx context.Context) (bool, error)
 	}
 }
 
+// whereEmail match the user by the email blind index, or by the legacy encrypted emails when the index is not yet backfilled
+func whereEmail(cursor *gorm.DB, lookup usecase.RepoEmailLookup) *gorm.DB {
+	return cursor.Where(
+		"email_blind_index = ? OR (email_blind_index IS NULL AND email IN ?)", lookup.BlindIndex, lookup.LegacyEncryptedEmails,
+	)
+}
+
 func toSearchFields(cursor *gorm.DB, sui usecase.RepoSearchUserInput) *gorm.DB {
 	if sui.Role != "" {
 		cursor = cursor.Where("roles = ?", sui.Role)
 	}
 
+	// email is stored encrypted, thus only exact match is possible
+	if sui.Email != nil {
+		cursor = whereEmail(cursor, *sui.Email)
+	}
+
+	if sui.Username != "" {
+		cursor = cursor.Where("username ILIKE ?", "%"+sui.Username+"%")
+	}
+
+	if sui.IsActive != nil {
+		cursor = cursor.Where("is_active = ?", *sui.IsActive)
+	}
+
+	if sui.OrganizationID != uuid.Nil {
+		cursor = cursor.Where("organization_id = ?", sui.OrganizationID)
+	}
+
 	if sui.Limit > 0 {
 		cursor = cursor.Limit(sui.Limit)
 	}
@@ -183,7 +395,7 @@ func toSearchFields(cursor *gorm.DB, sui usecase.RepoSearchUserInput) *gorm.DB {
 func (r *UserRepository) Search(ctx context.Context, input usecase.RepoSearchUserInput) ([]model.User, error) {
 	users := []model.User{}
 	cursor := r.db.WithContext(ctx)
-	cursor = toSearchFields(cursor, input)
+	cursor = toSearchFields(cursor, input).Order("created_at DESC")
 
 	if err := cursor.Find(&users).Error; err != nil {
 		return nil, err
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/luckyAkbar/atec/internal/model"
 	"github.com/luckyAkbar/atec/internal/repository"
 	"github.com/luckyAkbar/atec/internal/usecase"
@@ -24,11 +26,13 @@ func TestUserRepository_FindByEmail(t *testing.T) {
 	dbMock := kit.DBmock
 	repo := repository.NewUserRepository(kit.DB)
 
-	email := "email@test.com"
+	lookup := usecase.RepoEmailLookup{
+		BlindIndex:            "email-blind-index",
+		LegacyEncryptedEmails: []string{"legacy-encrypted-email", "v2:legacy-encrypted-email"},
+	}
 
 	testCases := []struct {
 		name                 string
-		email                string
 		wantErr              bool
 		expectedErr          error
 		expectedFunctionCall func()
@@ -37,8 +41,8 @@ func TestUserRepository_FindByEmail(t *testing.T) {
 			name:    "success",
 			wantErr: false,
 			expectedFunctionCall: func() {
-				dbMock.ExpectQuery(`^SELECT .+ FROM "users"`).
-					WithArgs(email, 1).
+				dbMock.ExpectQuery(`^SELECT \* FROM "users" WHERE \(email_blind_index = \$1 OR \(email_blind_index IS NULL AND email IN \(\$2,\$3\)\)\)`).
+					WithArgs(lookup.BlindIndex, lookup.LegacyEncryptedEmails[0], lookup.LegacyEncryptedEmails[1], 1).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
 			},
 		},
@@ -48,7 +52,7 @@ func TestUserRepository_FindByEmail(t *testing.T) {
 			expectedErr: assert.AnError,
 			expectedFunctionCall: func() {
 				dbMock.ExpectQuery(`^SELECT .+ FROM "users"`).
-					WithArgs(email, 1).
+					WithArgs(lookup.BlindIndex, lookup.LegacyEncryptedEmails[0], lookup.LegacyEncryptedEmails[1], 1).
 					WillReturnError(assert.AnError)
 			},
 		},
@@ -58,7 +62,7 @@ func TestUserRepository_FindByEmail(t *testing.T) {
 			expectedErr: repository.ErrNotFound,
 			expectedFunctionCall: func() {
 				dbMock.ExpectQuery(`^SELECT .+ FROM "users"`).
-					WithArgs(email, 1).
+					WithArgs(lookup.BlindIndex, lookup.LegacyEncryptedEmails[0], lookup.LegacyEncryptedEmails[1], 1).
 					WillReturnError(gorm.ErrRecordNotFound)
 			},
 		},
@@ -70,7 +74,7 @@ func TestUserRepository_FindByEmail(t *testing.T) {
 				tc.expectedFunctionCall()
 			}
 
-			res, err := repo.FindByEmail(ctx, email)
+			res, err := repo.FindByEmail(ctx, lookup)
 
 			if tc.wantErr {
 				require.Error(t, err)
@@ -99,6 +103,7 @@ func TestUserRepository_Create(t *testing.T) {
 	repo := repository.NewUserRepository(kit.DB)
 
 	email := "test@emailc.com"
+	emailBlindIndex := "email-blind-index"
 	password := "password"
 	isActive := true
 	roles := model.RolesAdministrator
@@ -114,18 +119,23 @@ func TestUserRepository_Create(t *testing.T) {
 		{
 			name: "success",
 			input: usecase.RepoCreateUserInput{
-				Email:    email,
-				Password: password,
-				IsActive: isActive,
-				Roles:    roles,
-				Username: username,
+				Email:           email,
+				EmailBlindIndex: emailBlindIndex,
+				Password:        password,
+				IsActive:        isActive,
+				Roles:           roles,
+				Username:        username,
 			},
 			wantErr: false,
 			expectedFunctionCall: func() {
 				dbMock.ExpectBegin()
 
 				dbMock.ExpectQuery("^INSERT INTO \"users\"").
-					WithArgs(email, password, username, isActive, roles, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(email, sql.NullString{String: emailBlindIndex, Valid: true}, password, username, isActive, roles,
+						sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
 
 				dbMock.ExpectCommit()
@@ -134,18 +144,23 @@ func TestUserRepository_Create(t *testing.T) {
 		{
 			name: "error",
 			input: usecase.RepoCreateUserInput{
-				Email:    email,
-				Password: password,
-				IsActive: isActive,
-				Roles:    roles,
-				Username: username,
+				Email:           email,
+				EmailBlindIndex: emailBlindIndex,
+				Password:        password,
+				IsActive:        isActive,
+				Roles:           roles,
+				Username:        username,
 			},
 			wantErr: true,
 			expectedFunctionCall: func() {
 				dbMock.ExpectBegin()
 
 				dbMock.ExpectQuery("^INSERT INTO \"users\"").
-					WithArgs(email, password, username, isActive, roles, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(email, sql.NullString{String: emailBlindIndex, Valid: true}, password, username, isActive, roles,
+						sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnError(assert.AnError)
 
 				dbMock.ExpectRollback()
@@ -265,6 +280,7 @@ func TestUserRepository_Update(t *testing.T) {
 	password := "password"
 	username := "testuser"
 	isActive := true
+	noFailure := 0
 
 	testCases := []struct {
 		name                 string
@@ -293,6 +309,89 @@ func TestUserRepository_Update(t *testing.T) {
 				dbMock.ExpectCommit()
 			},
 		},
+		{
+			name: "success - changing user roles",
+			input: usecase.RepoUpdateUserInput{
+				Roles: model.RolesTherapist,
+			},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^UPDATE \"users\" SET").
+					WithArgs(model.RolesTherapist, sqlmock.AnyArg(), userID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name: "success - storing mfa secret and enabling mfa",
+			input: usecase.RepoUpdateUserInput{
+				MFASecret:   &sql.NullString{String: "encryptedSecret", Valid: true},
+				MFAEnabled:  &isActive,
+				MFARequired: &isActive,
+			},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^UPDATE \"users\" SET").
+					WithArgs(isActive, isActive, "encryptedSecret", sqlmock.AnyArg(), userID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name: "success - removing mfa secret",
+			input: usecase.RepoUpdateUserInput{
+				MFASecret: &sql.NullString{Valid: false},
+			},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery(`^UPDATE "users" SET "mfa_secret"=NULL`).
+					WithArgs(sqlmock.AnyArg(), userID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name: "success - locking the account",
+			input: usecase.RepoUpdateUserInput{
+				LockedUntil: &sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+			},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery("^UPDATE \"users\" SET").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name: "success - resetting failed login attempts and unlocking the account",
+			input: usecase.RepoUpdateUserInput{
+				FailedLoginAttempts: &noFailure,
+				LockedUntil:         &sql.NullTime{Valid: false},
+			},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery(`^UPDATE "users" SET "failed_login_attempts"=\$1,"locked_until"=NULL`).
+					WithArgs(noFailure, sqlmock.AnyArg(), userID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+
+				dbMock.ExpectCommit()
+			},
+		},
 		{
 			name: "error",
 			input: usecase.RepoUpdateUserInput{
@@ -342,6 +441,343 @@ func TestUserRepository_Update(t *testing.T) {
 	}
 }
 
+func TestUserRepository_IncrementFailedLoginAttempts(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewUserRepository(kit.DB)
+
+	userID := uuid.New()
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery(`^UPDATE "users" SET "failed_login_attempts"=failed_login_attempts \+ 1,"last_failed_login_at"=\$1`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "failed_login_attempts"}).AddRow(userID, 3))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "user not found",
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery(`^UPDATE "users" SET`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "failed_login_attempts"}))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectQuery(`^UPDATE "users" SET`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := repo.IncrementFailedLoginAttempts(ctx, userID)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+				assert.Equal(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, res)
+			assert.Equal(t, 3, res.FailedLoginAttempts)
+		})
+	}
+}
+
+func TestUserRepository_UpdateEmail(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewUserRepository(kit.DB)
+
+	userID := uuid.New()
+	email := "encrypted-new-email"
+	emailBlindIndex := "new-email-blind-index"
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "users" SET "email"=\$1,"email_blind_index"=\$2,"updated_at"=\$3 WHERE id = \$4`).
+					WithArgs(email, emailBlindIndex, sqlmock.AnyArg(), userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "user not found",
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "users" SET`).
+					WithArgs(email, emailBlindIndex, sqlmock.AnyArg(), userID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "email already used",
+			wantErr:     true,
+			expectedErr: repository.ErrDuplicate,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "users" SET`).
+					WithArgs(email, emailBlindIndex, sqlmock.AnyArg(), userID).
+					WillReturnError(&pgconn.PgError{Code: "23505"})
+
+				dbMock.ExpectRollback()
+			},
+		},
+		{
+			name:        "error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "users" SET`).
+					WithArgs(email, emailBlindIndex, sqlmock.AnyArg(), userID).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := repo.UpdateEmail(ctx, userID, email, emailBlindIndex)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUserRepository_FindBatchAfterID(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewUserRepository(kit.DB)
+
+	afterID := uuid.New()
+	limit := 2
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedOutputLen    int
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "database returning unexpected error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT \* FROM "users" WHERE id > \$1 ORDER BY id ASC LIMIT \$2`).
+					WithArgs(afterID, limit).
+					WillReturnError(assert.AnError)
+			},
+		},
+		{
+			name:              "no more users is not an error",
+			wantErr:           false,
+			expectedOutputLen: 0,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT \* FROM "users" WHERE id > \$1 ORDER BY id ASC LIMIT \$2`).
+					WithArgs(afterID, limit).
+					WillReturnRows(sqlmock.NewRows([]string{}))
+			},
+		},
+		{
+			name:              "ok",
+			wantErr:           false,
+			expectedOutputLen: 2,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT \* FROM "users" WHERE id > \$1 ORDER BY id ASC LIMIT \$2`).
+					WithArgs(afterID, limit).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.expectedFunctionCall()
+
+			res, err := repo.FindBatchAfterID(ctx, afterID, limit)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, res, tc.expectedOutputLen)
+		})
+	}
+}
+
+func TestUserRepository_Reencrypt(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewUserRepository(kit.DB)
+
+	input := usecase.RepoReencryptUserInput{
+		UserID: uuid.New(),
+		Current: usecase.RepoUserEncryptedFields{
+			Email:       "old-email",
+			PhoneNumber: sql.NullString{String: "old-phone", Valid: true},
+		},
+		Reencrypted: usecase.RepoUserEncryptedFields{
+			Email:           "g2:new-email",
+			EmailBlindIndex: sql.NullString{String: "email-blind-index", Valid: true},
+			PhoneNumber:     sql.NullString{String: "g2:new-phone", Valid: true},
+		},
+	}
+
+	expectUpdate := func() *sqlmock.ExpectedExec {
+		return dbMock.ExpectExec(`^UPDATE "users" SET "address"=\$1,"email"=\$2,"email_blind_index"=\$3,"mfa_secret"=\$4,"phone_number"=\$5 `+
+			`WHERE \(id = \$6 AND email = \$7\) AND email_blind_index IS NOT DISTINCT FROM \$8 `+
+			`AND phone_number IS NOT DISTINCT FROM \$9 AND address IS NOT DISTINCT FROM \$10 AND mfa_secret IS NOT DISTINCT FROM \$11`).
+			WithArgs(
+				input.Reencrypted.Address, input.Reencrypted.Email, input.Reencrypted.EmailBlindIndex, input.Reencrypted.MFASecret,
+				input.Reencrypted.PhoneNumber, input.UserID, input.Current.Email, input.Current.EmailBlindIndex,
+				input.Current.PhoneNumber, input.Current.Address, input.Current.MFASecret,
+			)
+	}
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+				expectUpdate().WillReturnResult(sqlmock.NewResult(0, 1))
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "changed since it was read",
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+				expectUpdate().WillReturnResult(sqlmock.NewResult(0, 0))
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error",
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+				expectUpdate().WillReturnError(assert.AnError)
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.expectedFunctionCall()
+
+			err := repo.Reencrypt(ctx, input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestUserRepository_IsAdminAccountExists(t *testing.T) {
 	ctx := context.Background()
 	kit, closer := InitializeRepoTestKit(t)
@@ -431,6 +867,8 @@ func TestUserRepository_Search(t *testing.T) {
 	role := model.RolesAdministrator
 	limit := 100
 	offset := 10
+	isActive := true
+	organizationID := uuid.New()
 
 	testCases := []struct {
 		name                 string
@@ -485,6 +923,40 @@ func TestUserRepository_Search(t *testing.T) {
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
 			},
 		},
+		{
+			name:    "ok - using all the search options",
+			wantErr: false,
+			input: usecase.RepoSearchUserInput{
+				Role:     role,
+				Email:    &usecase.RepoEmailLookup{BlindIndex: "email-blind-index", LegacyEncryptedEmails: []string{"encrypted-email"}},
+				Username: "user",
+				IsActive: &isActive,
+				Limit:    limit,
+				Offset:   offset,
+			},
+			expectedOutputLen: 1,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "users" WHERE roles = .+ AND \(email_blind_index = .+ OR \(email_blind_index IS NULL AND email IN .+\)\) AND username ILIKE .+ AND is_active = .+ ORDER BY created_at DESC`).
+					WithArgs(role, "email-blind-index", "encrypted-email", "%user%", isActive, limit, offset).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+			},
+		},
+		{
+			name:    "ok - only the organization members",
+			wantErr: false,
+			input: usecase.RepoSearchUserInput{
+				Role:           model.RolesTherapist,
+				OrganizationID: organizationID,
+				Limit:          limit,
+				Offset:         offset,
+			},
+			expectedOutputLen: 1,
+			expectedFunctionCall: func() {
+				dbMock.ExpectQuery(`^SELECT .+ FROM "users" WHERE roles = .+ AND organization_id = .+ ORDER BY created_at DESC`).
+					WithArgs(model.RolesTherapist, organizationID, limit, offset).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -753,3 +1225,137 @@ func TestUserRepository_UpdateProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRepository_UpdateDeletionRequestedAt(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewUserRepository(kit.DB)
+
+	userID := uuid.New()
+	requestedAt := sql.NullTime{Time: time.Now(), Valid: true}
+
+	testCases := []struct {
+		name                 string
+		requestedAt          sql.NullTime
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "scheduling the deletion deactivates the account",
+			requestedAt: requestedAt,
+			wantErr:     false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "users" SET "deletion_requested_at"=\$1,"is_active"=\$2,"updated_at"=\$3 WHERE id = \$4`).
+					WithArgs(requestedAt, false, sqlmock.AnyArg(), userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "cancelling the deletion activates the account",
+			requestedAt: sql.NullTime{},
+			wantErr:     false,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "users" SET "deletion_requested_at"=\$1,"is_active"=\$2,"updated_at"=\$3 WHERE id = \$4`).
+					WithArgs(sql.NullTime{}, true, sqlmock.AnyArg(), userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "user not found",
+			requestedAt: requestedAt,
+			wantErr:     true,
+			expectedErr: repository.ErrNotFound,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "users" SET`).
+					WithArgs(requestedAt, false, sqlmock.AnyArg(), userID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				dbMock.ExpectCommit()
+			},
+		},
+		{
+			name:        "error",
+			requestedAt: requestedAt,
+			wantErr:     true,
+			expectedErr: assert.AnError,
+			expectedFunctionCall: func() {
+				dbMock.ExpectBegin()
+
+				dbMock.ExpectExec(`^UPDATE "users" SET`).
+					WithArgs(requestedAt, false, sqlmock.AnyArg(), userID).
+					WillReturnError(assert.AnError)
+
+				dbMock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := repo.UpdateDeletionRequestedAt(ctx, userID, tc.requestedAt)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUserRepository_FindDeletionRequestedBefore(t *testing.T) {
+	ctx := context.Background()
+	kit, closer := InitializeRepoTestKit(t)
+
+	defer closer()
+
+	dbMock := kit.DBmock
+	repo := repository.NewUserRepository(kit.DB)
+
+	before := time.Now().AddDate(0, 0, -30)
+	query := `^SELECT \* FROM "users" WHERE \(deletion_requested_at IS NOT NULL AND deletion_requested_at < \$1\) ` +
+		`AND "users"."deleted_at" IS NULL ORDER BY deletion_requested_at ASC`
+
+	t.Run("success", func(t *testing.T) {
+		dbMock.ExpectQuery(query).
+			WithArgs(before).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+
+		res, err := repo.FindDeletionRequestedBefore(ctx, before)
+		require.NoError(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dbMock.ExpectQuery(query).
+			WithArgs(before).
+			WillReturnError(assert.AnError)
+
+		res, err := repo.FindDeletionRequestedBefore(ctx, before)
+		require.Error(t, err)
+		assert.Nil(t, res)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
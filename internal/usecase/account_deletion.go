@@ -0,0 +1,356 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/config"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// scheduleAccountDeletion deactivate the account and soft delete all of the user's results and children, except the children
+// shared with other guardians, then send the restore link to the user's email. Everything will be permanently deleted
+// once the grace period has passed, unless the deletion is cancelled before that, while the shared children are handed over
+func (u *AuthUsecase) scheduleAccountDeletion(ctx context.Context, user *model.User) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"user-id": user.ID,
+		"func":    "AuthUsecase.scheduleAccountDeletion",
+	})
+
+	email, err := u.sharedCryptor.Decrypt(user.Email)
+	if err != nil {
+		logger.WithError(err).Error("failed to decrypt user email")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	// truncated to the database precision, so every row soft deleted below is deleted at or after this time
+	requestedAt := time.Now().Truncate(time.Microsecond)
+	gracePeriod := config.AccountDeletionGracePeriod()
+
+	txCtrl := u.transactionControllerFactory.New()
+	tx := txCtrl.Begin()
+
+	err = u.resultRepo.DeleteAllUserResults(ctx, RepoDeleteAllUserResultsInput{UserID: user.ID}, tx)
+	if err != nil {
+		logger.WithError(err).Error("failed to delete user's results")
+
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	err = u.childRepo.DeleteAllUserChildren(ctx, RepoDeleteAllUserChildrenInput{UserID: user.ID}, tx)
+	if err != nil {
+		logger.WithError(err).Error("failed to delete user's children")
+
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	err = u.userRepo.UpdateDeletionRequestedAt(ctx, user.ID, sql.NullTime{Time: requestedAt, Valid: true}, tx)
+	if err != nil {
+		logger.WithError(err).Error("failed to deactivate the account")
+
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	token, err := u.issueEmailToken(ctx, user.ID, AccountRestoreToken, gracePeriod, tx)
+	if err != nil {
+		logger.WithError(err).Error("failed to create account restore token")
+
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	_, err = u.mailer.SendEmail(ctx, common.SendEmailInput{
+		ReceiverName:  user.Username,
+		ReceiverEmail: email,
+		Subject:       "Penghapusan Akun Dijadwalkan",
+		HTMLContent:   accountDeletionScheduledEmailTemplate(token, requestedAt.Add(gracePeriod)),
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to send account deletion email")
+
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if err := txCtrl.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if err := u.sessionRepo.RevokeAllUserSessions(ctx, user.ID); err != nil {
+		logger.WithError(err).Error("failed to revoke user sessions after scheduling the account deletion")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return nil
+}
+
+// restoreAccount cancel the scheduled account deletion. The results and children deleted along with the account
+// are restored and the account is activated again. Any remaining restore link will no longer be usable
+func (u *AuthUsecase) restoreAccount(ctx context.Context, user *model.User) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"user-id": user.ID,
+		"func":    "AuthUsecase.restoreAccount",
+	})
+
+	txCtrl := u.transactionControllerFactory.New()
+	tx := txCtrl.Begin()
+
+	err := u.childRepo.RestoreAllUserChildren(ctx, RepoRestoreAllUserChildrenInput{
+		UserID:       user.ID,
+		DeletedSince: user.DeletionRequestedAt.Time,
+	}, tx)
+	if err != nil {
+		logger.WithError(err).Error("failed to restore user's children")
+
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	err = u.resultRepo.RestoreAllUserResults(ctx, RepoRestoreAllUserResultsInput{
+		UserID:       user.ID,
+		DeletedSince: user.DeletionRequestedAt.Time,
+	}, tx)
+	if err != nil {
+		logger.WithError(err).Error("failed to restore user's results")
+
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if err := u.userRepo.UpdateDeletionRequestedAt(ctx, user.ID, sql.NullTime{}, tx); err != nil {
+		logger.WithError(err).Error("failed to activate the account")
+
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if err := txCtrl.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	// the account is already restored, the leftover link will be rejected anyway because nothing is scheduled
+	if err := u.emailTokenRepo.RevokeAllUserTokens(ctx, user.ID, string(AccountRestoreToken)); err != nil {
+		logger.WithError(err).Error("failed to revoke the remaining account restore tokens")
+	}
+
+	return nil
+}
+
+// RestoreAccountInput input
+type RestoreAccountInput struct {
+	RestoreToken string `validate:"required"`
+}
+
+func (rai RestoreAccountInput) validate() error {
+	return common.Validator.Struct(rai)
+}
+
+// RestoreAccountOutput output
+type RestoreAccountOutput struct {
+	Message string
+}
+
+// HandleRestoreAccount cancel the scheduled account deletion using the restore token sent to the user's email
+func (u *AuthUsecase) HandleRestoreAccount(ctx context.Context, input RestoreAccountInput) (*RestoreAccountOutput, error) {
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	_, claims, err := parseJWTToken(u.sharedCryptor, input.RestoreToken, parseJWTTokenInput{
+		expectedIssuer:      TokenIssuerSystem,
+		expectedSubject:     AccountRestoreToken,
+		expectedAudienceLen: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	audiences, _ := claims.GetAudience()
+
+	userID, err := uuid.Parse(audiences[0])
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "invalid value of user id",
+		}
+	}
+
+	user, err := u.findAccountScheduledForDeletion(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := consumeEmailToken(ctx, u.emailTokenRepo, claims, user.ID, AccountRestoreToken); err != nil {
+		return nil, err
+	}
+
+	if err := u.restoreAccount(ctx, user); err != nil {
+		return nil, err
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionCancelDeleteAccount,
+		TargetType: model.AuditTargetUser,
+		TargetID:   user.ID.String(),
+	})
+
+	return &RestoreAccountOutput{
+		Message: "your account has been restored",
+	}, nil
+}
+
+// AdminCancelAccountDeletionInput input
+type AdminCancelAccountDeletionInput struct {
+	UserID uuid.UUID `validate:"required"`
+}
+
+func (acadi AdminCancelAccountDeletionInput) validate() error {
+	return common.Validator.Struct(acadi)
+}
+
+// AdminCancelAccountDeletionOutput output
+type AdminCancelAccountDeletionOutput struct {
+	Message string
+}
+
+// HandleAdminCancelAccountDeletion allow administrator to cancel the scheduled deletion of other user's account,
+// restoring the account along with their results and children
+func (u *AuthUsecase) HandleAdminCancelAccountDeletion(
+	ctx context.Context,
+	input AdminCancelAccountDeletionInput,
+) (*AdminCancelAccountDeletionOutput, error) {
+	if err := Authorize(model.GetUserFromCtx(ctx), ActionManageUser, RelationNone); err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	user, err := u.findAccountScheduledForDeletion(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.restoreAccount(ctx, user); err != nil {
+		return nil, err
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionCancelDeleteAccount,
+		TargetType: model.AuditTargetUser,
+		TargetID:   user.ID.String(),
+	})
+
+	return &AdminCancelAccountDeletionOutput{
+		Message: "ok",
+	}, nil
+}
+
+func (u *AuthUsecase) findAccountScheduledForDeletion(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).WithField("user-id", userID).Error("failed to find user by id")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	if !user.IsDeletionScheduled() {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "this account is not scheduled for deletion",
+		}
+	}
+
+	return user, nil
+}
@@ -0,0 +1,372 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	mockCommon "github.com/luckyAkbar/atec/mocks/internal_/common"
+	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthUsecase_HandleRestoreAccount(t *testing.T) {
+	ctx := context.Background()
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockResultRepo := mockUsecase.NewResultRepository(t)
+	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockTxCtrlFactory := mockUsecase.NewTransactionControllerFactory(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	uc := usecase.NewAuthUsecase(
+		mockSharedCryptor, mockUserRepo, mockResultRepo, mockChildRepo, mockTxCtrlFactory, nil, nil,
+		nil, mockEmailTokenRepo, nil, nil, nil, nil, mockAuditLogRepo,
+	)
+
+	userID := uuid.New()
+	tokenID := uuid.New()
+	requestedAt := time.Now().Add(-24 * time.Hour)
+	scheduledUser := &model.User{ID: userID, DeletionRequestedAt: sql.NullTime{Time: requestedAt, Valid: true}}
+	validateJWTOpts := common.ValidateJWTOpts{
+		Issuer:  string(usecase.TokenIssuerSystem),
+		Subject: string(usecase.AccountRestoreToken),
+	}
+
+	restoreToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": string(usecase.TokenIssuerSystem),
+		"sub": string(usecase.AccountRestoreToken),
+		"aud": []string{userID.String()},
+		"exp": jwt.NewNumericDate(time.Now().Add(time.Hour)).Unix(),
+		"jti": tokenID.String(),
+	})
+	restoreToken.Valid = true
+
+	restoreTokenString, err := restoreToken.SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	consumeInput := usecase.RepoConsumeEmailTokenInput{
+		ID:      tokenID,
+		UserID:  userID,
+		Purpose: string(usecase.AccountRestoreToken),
+	}
+
+	testCases := []struct {
+		name                 string
+		input                usecase.RestoreAccountInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "restore token is required",
+			input:       usecase.RestoreAccountInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "invalid restore token",
+			input:       usecase.RestoreAccountInput{RestoreToken: "invalid"},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT("invalid", validateJWTOpts).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "user not found",
+			input:       usecase.RestoreAccountInput{RestoreToken: restoreTokenString},
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(restoreTokenString, validateJWTOpts).Return(restoreToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "account is not scheduled for deletion",
+			input:       usecase.RestoreAccountInput{RestoreToken: restoreTokenString},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(restoreTokenString, validateJWTOpts).Return(restoreToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&model.User{ID: userID, IsActive: true}, nil).Once()
+			},
+		},
+		{
+			name:        "token has already been used",
+			input:       usecase.RestoreAccountInput{RestoreToken: restoreTokenString},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(restoreTokenString, validateJWTOpts).Return(restoreToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(scheduledUser, nil).Once()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput).Return(usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "failed to restore the children",
+			input:       usecase.RestoreAccountInput{RestoreToken: restoreTokenString},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(restoreTokenString, validateJWTOpts).Return(restoreToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(scheduledUser, nil).Once()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput).Return(nil).Once()
+
+				underlyingTransaction := mockUsecase.NewTransactionController(t)
+				mockTxCtrlFactory.EXPECT().New().Return(usecase.NewTxControllerWrapper(underlyingTransaction)).Once()
+				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+				mockChildRepo.EXPECT().RestoreAllUserChildren(ctx, usecase.RepoRestoreAllUserChildrenInput{
+					UserID:       userID,
+					DeletedSince: requestedAt,
+				}, mock.Anything).Return(assert.AnError).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to restore the results",
+			input:       usecase.RestoreAccountInput{RestoreToken: restoreTokenString},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(restoreTokenString, validateJWTOpts).Return(restoreToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(scheduledUser, nil).Once()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput).Return(nil).Once()
+
+				underlyingTransaction := mockUsecase.NewTransactionController(t)
+				mockTxCtrlFactory.EXPECT().New().Return(usecase.NewTxControllerWrapper(underlyingTransaction)).Once()
+				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+				mockChildRepo.EXPECT().RestoreAllUserChildren(ctx, mock.Anything, mock.Anything).Return(nil).Once()
+				mockResultRepo.EXPECT().RestoreAllUserResults(ctx, usecase.RepoRestoreAllUserResultsInput{
+					UserID:       userID,
+					DeletedSince: requestedAt,
+				}, mock.Anything).Return(assert.AnError).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name:        "failed to activate the account",
+			input:       usecase.RestoreAccountInput{RestoreToken: restoreTokenString},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(restoreTokenString, validateJWTOpts).Return(restoreToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(scheduledUser, nil).Once()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput).Return(nil).Once()
+
+				underlyingTransaction := mockUsecase.NewTransactionController(t)
+				mockTxCtrlFactory.EXPECT().New().Return(usecase.NewTxControllerWrapper(underlyingTransaction)).Once()
+				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+				mockChildRepo.EXPECT().RestoreAllUserChildren(ctx, mock.Anything, mock.Anything).Return(nil).Once()
+				mockResultRepo.EXPECT().RestoreAllUserResults(ctx, mock.Anything, mock.Anything).Return(nil).Once()
+				mockUserRepo.EXPECT().UpdateDeletionRequestedAt(ctx, userID, sql.NullTime{}, mock.Anything).Return(assert.AnError).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name:        "failed to commit",
+			input:       usecase.RestoreAccountInput{RestoreToken: restoreTokenString},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(restoreTokenString, validateJWTOpts).Return(restoreToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(scheduledUser, nil).Once()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput).Return(nil).Once()
+
+				underlyingTransaction := mockUsecase.NewTransactionController(t)
+				mockTxCtrlFactory.EXPECT().New().Return(usecase.NewTxControllerWrapper(underlyingTransaction)).Once()
+				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+				mockChildRepo.EXPECT().RestoreAllUserChildren(ctx, mock.Anything, mock.Anything).Return(nil).Once()
+				mockResultRepo.EXPECT().RestoreAllUserResults(ctx, mock.Anything, mock.Anything).Return(nil).Once()
+				mockUserRepo.EXPECT().UpdateDeletionRequestedAt(ctx, userID, sql.NullTime{}, mock.Anything).Return(nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok even when failed to revoke the remaining restore tokens",
+			input:   usecase.RestoreAccountInput{RestoreToken: restoreTokenString},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(restoreTokenString, validateJWTOpts).Return(restoreToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(scheduledUser, nil).Once()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput).Return(nil).Once()
+
+				underlyingTransaction := mockUsecase.NewTransactionController(t)
+				mockTxCtrlFactory.EXPECT().New().Return(usecase.NewTxControllerWrapper(underlyingTransaction)).Once()
+				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+				mockChildRepo.EXPECT().RestoreAllUserChildren(ctx, mock.Anything, mock.Anything).Return(nil).Once()
+				mockResultRepo.EXPECT().RestoreAllUserResults(ctx, mock.Anything, mock.Anything).Return(nil).Once()
+				mockUserRepo.EXPECT().UpdateDeletionRequestedAt(ctx, userID, sql.NullTime{}, mock.Anything).Return(nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(ctx, userID, string(usecase.AccountRestoreToken)).
+					Return(assert.AnError).Once()
+				mockAuditLogRepo.EXPECT().Create(ctx, usecase.RepoCreateAuditLogInput{
+					Action:     model.AuditActionCancelDeleteAccount,
+					TargetType: model.AuditTargetUser,
+					TargetID:   userID.String(),
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleRestoreAccount(ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Message)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Nil(t, res)
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthUsecase_HandleAdminCancelAccountDeletion(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockResultRepo := mockUsecase.NewResultRepository(t)
+	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockTxCtrlFactory := mockUsecase.NewTransactionControllerFactory(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	uc := usecase.NewAuthUsecase(
+		nil, mockUserRepo, mockResultRepo, mockChildRepo, mockTxCtrlFactory, nil, nil,
+		nil, mockEmailTokenRepo, nil, nil, nil, nil, mockAuditLogRepo,
+	)
+
+	admin := model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator}
+	adminCtx := model.SetUserToCtx(ctx, admin)
+	parentCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesParent})
+
+	userID := uuid.New()
+	requestedAt := time.Now().Add(-24 * time.Hour)
+	scheduledUser := &model.User{ID: userID, DeletionRequestedAt: sql.NullTime{Time: requestedAt, Valid: true}}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.AdminCancelAccountDeletionInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       usecase.AdminCancelAccountDeletionInput{UserID: userID},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "non administrator is forbidden",
+			ctx:         parentCtx,
+			input:       usecase.AdminCancelAccountDeletionInput{UserID: userID},
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:        "user id is required",
+			ctx:         adminCtx,
+			input:       usecase.AdminCancelAccountDeletionInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "failed to find user",
+			ctx:         adminCtx,
+			input:       usecase.AdminCancelAccountDeletionInput{UserID: userID},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, userID).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "account is not scheduled for deletion",
+			ctx:         adminCtx,
+			input:       usecase.AdminCancelAccountDeletionInput{UserID: userID},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, userID).Return(&model.User{ID: userID}, nil).Once()
+			},
+		},
+		{
+			name:    "ok",
+			ctx:     adminCtx,
+			input:   usecase.AdminCancelAccountDeletionInput{UserID: userID},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, userID).Return(scheduledUser, nil).Once()
+
+				underlyingTransaction := mockUsecase.NewTransactionController(t)
+				mockTxCtrlFactory.EXPECT().New().Return(usecase.NewTxControllerWrapper(underlyingTransaction)).Once()
+				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+				mockChildRepo.EXPECT().RestoreAllUserChildren(adminCtx, usecase.RepoRestoreAllUserChildrenInput{
+					UserID:       userID,
+					DeletedSince: requestedAt,
+				}, mock.Anything).Return(nil).Once()
+				mockResultRepo.EXPECT().RestoreAllUserResults(adminCtx, usecase.RepoRestoreAllUserResultsInput{
+					UserID:       userID,
+					DeletedSince: requestedAt,
+				}, mock.Anything).Return(nil).Once()
+				mockUserRepo.EXPECT().UpdateDeletionRequestedAt(adminCtx, userID, sql.NullTime{}, mock.Anything).Return(nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(adminCtx, userID, string(usecase.AccountRestoreToken)).Return(nil).Once()
+				mockAuditLogRepo.EXPECT().Create(adminCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    admin.ID,
+					ActorRole:  model.RolesAdministrator,
+					Action:     model.AuditActionCancelDeleteAccount,
+					TargetType: model.AuditTargetUser,
+					TargetID:   userID.String(),
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleAdminCancelAccountDeletion(tc.ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, "ok", res.Message)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Nil(t, res)
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
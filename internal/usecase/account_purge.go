@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/config"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// AccountPurgeUsecase usecase to permanently delete the accounts whose deletion grace period has passed.
+// Kept apart from AuthUsecase because it is run from the console and only needs the repositories holding the user data
+type AccountPurgeUsecase struct {
+	userRepo                     UserRepository
+	resultRepo                   ResultRepository
+	childRepo                    ChildRepository
+	childGuardianRepo            ChildGuardianRepository
+	transactionControllerFactory TransactionControllerFactory
+	auditLogRepo                 AuditLogRepository
+}
+
+// NewAccountPurgeUsecase create new AccountPurgeUsecase instance
+func NewAccountPurgeUsecase(
+	userRepo UserRepository,
+	resultRepo ResultRepository,
+	childRepo ChildRepository,
+	childGuardianRepo ChildGuardianRepository,
+	transactionControllerFactory TransactionControllerFactory,
+	auditLogRepo AuditLogRepository,
+) *AccountPurgeUsecase {
+	return &AccountPurgeUsecase{
+		userRepo:                     userRepo,
+		resultRepo:                   resultRepo,
+		childRepo:                    childRepo,
+		childGuardianRepo:            childGuardianRepo,
+		transactionControllerFactory: transactionControllerFactory,
+		auditLogRepo:                 auditLogRepo,
+	}
+}
+
+// PurgeScheduledAccountDeletionsOutput output
+type PurgeScheduledAccountDeletionsOutput struct {
+	Purged int
+	Failed int
+}
+
+// HandlePurgeScheduledAccountDeletions permanently delete the accounts, along with all of their data, whose deletion was
+// requested longer than the grace period ago. The results are anonymized and kept instead when configured to do so.
+// Failing to purge an account does not stop the others from being purged
+func (u *AccountPurgeUsecase) HandlePurgeScheduledAccountDeletions(ctx context.Context) (*PurgeScheduledAccountDeletionsOutput, error) {
+	before := time.Now().Add(-config.AccountDeletionGracePeriod())
+
+	users, err := u.userRepo.FindDeletionRequestedBefore(ctx, before)
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).WithField("before", before).Error("failed to find accounts due for deletion")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	output := &PurgeScheduledAccountDeletionsOutput{}
+	anonymizeResults := config.AccountDeletionAnonymizeResults()
+
+	for _, user := range users {
+		if err := u.purgeAllUserData(ctx, user, anonymizeResults); err != nil {
+			output.Failed++
+
+			continue
+		}
+
+		output.Purged++
+
+		recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+			Action:     model.AuditActionPurgeAccount,
+			TargetType: model.AuditTargetUser,
+			TargetID:   user.ID.String(),
+			Metadata: model.AuditMetadata{
+				"deletion_requested_at": user.DeletionRequestedAt.Time,
+				"results_anonymized":    anonymizeResults,
+			},
+		})
+	}
+
+	return output, nil
+}
+
+// purgeAllUserData permanently delete the user along with the children and results. The children shared with other
+// guardians are handed over to the guardian who joined the earliest, along with the results the user created for them,
+// instead of being deleted. The results are detached from the user and kept instead if anonymizeResults is true
+func (u *AccountPurgeUsecase) purgeAllUserData(ctx context.Context, user model.User, anonymizeResults bool) error {
+	userID := user.ID
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"user-id": userID,
+		"func":    "AccountPurgeUsecase.purgeAllUserData",
+	})
+
+	txCtrl := u.transactionControllerFactory.New()
+	tx := txCtrl.Begin()
+
+	successors, err := u.childGuardianRepo.FindSuccessors(ctx, userID, tx)
+	if err != nil {
+		logger.WithError(err).Error("failed to find the guardians to hand the shared children over to")
+
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	for _, successor := range successors {
+		if err := u.handOverChild(ctx, successor, userID, tx); err != nil {
+			logger.WithError(err).WithField("child-id", successor.ChildID).Error("failed to hand the shared child over")
+
+			if err := txCtrl.Rollback(); err != nil {
+				logger.WithError(err).Error("failed to rollback transaction")
+			}
+
+			return UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		}
+	}
+
+	err = u.resultRepo.DeleteAllUserResults(ctx, RepoDeleteAllUserResultsInput{
+		UserID:       userID,
+		HardDelete:   true,
+		Anonymize:    anonymizeResults,
+		DeletedSince: user.DeletionRequestedAt.Time,
+	}, tx)
+
+	if err != nil {
+		logger.WithError(err).Error("failed to delete user's results")
+
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	err = u.childRepo.DeleteAllUserChildren(ctx, RepoDeleteAllUserChildrenInput{
+		UserID:     userID,
+		HardDelete: true,
+	}, tx)
+
+	if err != nil {
+		logger.WithError(err).Error("failed to delete user's children")
+
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	err = u.userRepo.DeleteByID(ctx, RepoDeleteUserByIDInput{
+		UserID:     userID,
+		HardDelete: true,
+	}, tx)
+
+	if err != nil {
+		logger.WithError(err).Error("failed to delete user")
+
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if err := txCtrl.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	for _, successor := range successors {
+		recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+			Action:     model.AuditActionTransferChild,
+			TargetType: model.AuditTargetChild,
+			TargetID:   successor.ChildID.String(),
+			Metadata: model.AuditMetadata{
+				"from_user_id": userID,
+				"to_user_id":   successor.UserID,
+				"reason":       "owner account purged",
+			},
+		})
+	}
+
+	return nil
+}
+
+// handOverChild make the successor the owner of the child, along with the results the previous owner created for the child
+func (u *AccountPurgeUsecase) handOverChild(ctx context.Context, successor model.ChildGuardian, ownerUserID uuid.UUID, tx any) error {
+	input := RepoTransferChildInput{
+		ChildID:    successor.ChildID,
+		FromUserID: ownerUserID,
+		ToUserID:   successor.UserID,
+	}
+
+	if err := u.childRepo.TransferOwnership(ctx, input, tx); err != nil {
+		return err
+	}
+
+	return u.resultRepo.TransferChildResults(ctx, input, tx)
+}
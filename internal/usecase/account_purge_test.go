@@ -0,0 +1,226 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountPurgeUsecase_HandlePurgeScheduledAccountDeletions(t *testing.T) {
+	ctx := context.Background()
+
+	purgedUser := model.User{ID: uuid.New(), DeletionRequestedAt: sql.NullTime{Time: time.Now().AddDate(0, 0, -31), Valid: true}}
+	failedUser := model.User{ID: uuid.New(), DeletionRequestedAt: sql.NullTime{Time: time.Now().AddDate(0, 0, -40), Valid: true}}
+	dueBefore := mock.MatchedBy(func(before time.Time) bool {
+		return before.Before(time.Now().AddDate(0, 0, -29))
+	})
+
+	t.Run("failed to find the accounts", func(t *testing.T) {
+		mockUserRepo := mockUsecase.NewUserRepository(t)
+		uc := usecase.NewAccountPurgeUsecase(mockUserRepo, nil, nil, nil, nil, nil)
+
+		mockUserRepo.EXPECT().FindDeletionRequestedBefore(ctx, dueBefore).Return(nil, assert.AnError).Once()
+
+		res, err := uc.HandlePurgeScheduledAccountDeletions(ctx)
+		require.Error(t, err)
+		assert.Nil(t, res)
+		assertUsecaseErrType(t, usecase.ErrInternal, err)
+	})
+
+	t.Run("failing to purge an account should not stop the others", func(t *testing.T) {
+		mockUserRepo := mockUsecase.NewUserRepository(t)
+		mockResultRepo := mockUsecase.NewResultRepository(t)
+		mockChildRepo := mockUsecase.NewChildRepository(t)
+		mockChildGuardianRepo := mockUsecase.NewChildGuardianRepository(t)
+		mockTxCtrlFactory := mockUsecase.NewTransactionControllerFactory(t)
+		mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+		uc := usecase.NewAccountPurgeUsecase(
+			mockUserRepo, mockResultRepo, mockChildRepo, mockChildGuardianRepo, mockTxCtrlFactory, mockAuditLogRepo,
+		)
+
+		mockUserRepo.EXPECT().FindDeletionRequestedBefore(ctx, dueBefore).
+			Return([]model.User{failedUser, purgedUser}, nil).Once()
+
+		failedTransaction := mockUsecase.NewTransactionController(t)
+		mockTxCtrlFactory.EXPECT().New().Return(usecase.NewTxControllerWrapper(failedTransaction)).Once()
+		failedTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+		mockChildGuardianRepo.EXPECT().FindSuccessors(ctx, failedUser.ID, mock.Anything).Return(nil, nil).Once()
+		mockResultRepo.EXPECT().DeleteAllUserResults(ctx, usecase.RepoDeleteAllUserResultsInput{
+			UserID:       failedUser.ID,
+			HardDelete:   true,
+			DeletedSince: failedUser.DeletionRequestedAt.Time,
+		}, mock.Anything).Return(assert.AnError).Once()
+		failedTransaction.EXPECT().Rollback().Return(nil).Once()
+
+		purgedTransaction := mockUsecase.NewTransactionController(t)
+		mockTxCtrlFactory.EXPECT().New().Return(usecase.NewTxControllerWrapper(purgedTransaction)).Once()
+		purgedTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+		mockChildGuardianRepo.EXPECT().FindSuccessors(ctx, purgedUser.ID, mock.Anything).Return(nil, nil).Once()
+		mockResultRepo.EXPECT().DeleteAllUserResults(ctx, usecase.RepoDeleteAllUserResultsInput{
+			UserID:       purgedUser.ID,
+			HardDelete:   true,
+			DeletedSince: purgedUser.DeletionRequestedAt.Time,
+		}, mock.Anything).Return(nil).Once()
+		mockChildRepo.EXPECT().DeleteAllUserChildren(ctx, usecase.RepoDeleteAllUserChildrenInput{
+			UserID:     purgedUser.ID,
+			HardDelete: true,
+		}, mock.Anything).Return(nil).Once()
+		mockUserRepo.EXPECT().DeleteByID(ctx, usecase.RepoDeleteUserByIDInput{
+			UserID:     purgedUser.ID,
+			HardDelete: true,
+		}, mock.Anything).Return(nil).Once()
+		purgedTransaction.EXPECT().Commit().Return(nil).Once()
+		mockAuditLogRepo.EXPECT().Create(ctx, usecase.RepoCreateAuditLogInput{
+			Action:     model.AuditActionPurgeAccount,
+			TargetType: model.AuditTargetUser,
+			TargetID:   purgedUser.ID.String(),
+			Metadata: model.AuditMetadata{
+				"deletion_requested_at": purgedUser.DeletionRequestedAt.Time,
+				"results_anonymized":    false,
+			},
+		}).Return(nil).Once()
+
+		res, err := uc.HandlePurgeScheduledAccountDeletions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &usecase.PurgeScheduledAccountDeletionsOutput{Purged: 1, Failed: 1}, res)
+	})
+
+	t.Run("anonymize the results instead of deleting them when configured", func(t *testing.T) {
+		viper.Set("account_deletion.anonymize_results", true)
+		t.Cleanup(func() { viper.Set("account_deletion.anonymize_results", nil) })
+
+		mockUserRepo := mockUsecase.NewUserRepository(t)
+		mockResultRepo := mockUsecase.NewResultRepository(t)
+		mockChildRepo := mockUsecase.NewChildRepository(t)
+		mockChildGuardianRepo := mockUsecase.NewChildGuardianRepository(t)
+		mockTxCtrlFactory := mockUsecase.NewTransactionControllerFactory(t)
+		mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+		uc := usecase.NewAccountPurgeUsecase(
+			mockUserRepo, mockResultRepo, mockChildRepo, mockChildGuardianRepo, mockTxCtrlFactory, mockAuditLogRepo,
+		)
+
+		mockUserRepo.EXPECT().FindDeletionRequestedBefore(ctx, dueBefore).Return([]model.User{purgedUser}, nil).Once()
+
+		transaction := mockUsecase.NewTransactionController(t)
+		mockTxCtrlFactory.EXPECT().New().Return(usecase.NewTxControllerWrapper(transaction)).Once()
+		transaction.EXPECT().Begin().Return(struct{}{}).Once()
+		mockChildGuardianRepo.EXPECT().FindSuccessors(ctx, purgedUser.ID, mock.Anything).Return(nil, nil).Once()
+		mockResultRepo.EXPECT().DeleteAllUserResults(ctx, usecase.RepoDeleteAllUserResultsInput{
+			UserID:       purgedUser.ID,
+			HardDelete:   true,
+			Anonymize:    true,
+			DeletedSince: purgedUser.DeletionRequestedAt.Time,
+		}, mock.Anything).Return(nil).Once()
+		mockChildRepo.EXPECT().DeleteAllUserChildren(ctx, usecase.RepoDeleteAllUserChildrenInput{
+			UserID:     purgedUser.ID,
+			HardDelete: true,
+		}, mock.Anything).Return(nil).Once()
+		mockUserRepo.EXPECT().DeleteByID(ctx, usecase.RepoDeleteUserByIDInput{
+			UserID:     purgedUser.ID,
+			HardDelete: true,
+		}, mock.Anything).Return(nil).Once()
+		transaction.EXPECT().Commit().Return(nil).Once()
+		mockAuditLogRepo.EXPECT().Create(ctx, mock.MatchedBy(func(input usecase.RepoCreateAuditLogInput) bool {
+			return input.Action == model.AuditActionPurgeAccount && input.Metadata["results_anonymized"] == true
+		})).Return(nil).Once()
+
+		res, err := uc.HandlePurgeScheduledAccountDeletions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &usecase.PurgeScheduledAccountDeletionsOutput{Purged: 1}, res)
+	})
+
+	t.Run("hand the shared children over to another guardian instead of deleting them", func(t *testing.T) {
+		mockUserRepo := mockUsecase.NewUserRepository(t)
+		mockResultRepo := mockUsecase.NewResultRepository(t)
+		mockChildRepo := mockUsecase.NewChildRepository(t)
+		mockChildGuardianRepo := mockUsecase.NewChildGuardianRepository(t)
+		mockTxCtrlFactory := mockUsecase.NewTransactionControllerFactory(t)
+		mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+		uc := usecase.NewAccountPurgeUsecase(
+			mockUserRepo, mockResultRepo, mockChildRepo, mockChildGuardianRepo, mockTxCtrlFactory, mockAuditLogRepo,
+		)
+
+		successor := model.ChildGuardian{ChildID: uuid.New(), UserID: uuid.New(), Role: model.GuardianRoleGuardian}
+		transferInput := usecase.RepoTransferChildInput{
+			ChildID:    successor.ChildID,
+			FromUserID: purgedUser.ID,
+			ToUserID:   successor.UserID,
+		}
+
+		mockUserRepo.EXPECT().FindDeletionRequestedBefore(ctx, dueBefore).Return([]model.User{purgedUser}, nil).Once()
+
+		transaction := mockUsecase.NewTransactionController(t)
+		mockTxCtrlFactory.EXPECT().New().Return(usecase.NewTxControllerWrapper(transaction)).Once()
+		transaction.EXPECT().Begin().Return(struct{}{}).Once()
+		mockChildGuardianRepo.EXPECT().FindSuccessors(ctx, purgedUser.ID, mock.Anything).
+			Return([]model.ChildGuardian{successor}, nil).Once()
+		mockChildRepo.EXPECT().TransferOwnership(ctx, transferInput, mock.Anything).Return(nil).Once()
+		mockResultRepo.EXPECT().TransferChildResults(ctx, transferInput, mock.Anything).Return(nil).Once()
+		mockResultRepo.EXPECT().DeleteAllUserResults(ctx, usecase.RepoDeleteAllUserResultsInput{
+			UserID:       purgedUser.ID,
+			HardDelete:   true,
+			DeletedSince: purgedUser.DeletionRequestedAt.Time,
+		}, mock.Anything).Return(nil).Once()
+		mockChildRepo.EXPECT().DeleteAllUserChildren(ctx, usecase.RepoDeleteAllUserChildrenInput{
+			UserID:     purgedUser.ID,
+			HardDelete: true,
+		}, mock.Anything).Return(nil).Once()
+		mockUserRepo.EXPECT().DeleteByID(ctx, usecase.RepoDeleteUserByIDInput{
+			UserID:     purgedUser.ID,
+			HardDelete: true,
+		}, mock.Anything).Return(nil).Once()
+		transaction.EXPECT().Commit().Return(nil).Once()
+		mockAuditLogRepo.EXPECT().Create(ctx, usecase.RepoCreateAuditLogInput{
+			Action:     model.AuditActionTransferChild,
+			TargetType: model.AuditTargetChild,
+			TargetID:   successor.ChildID.String(),
+			Metadata: model.AuditMetadata{
+				"from_user_id": purgedUser.ID,
+				"to_user_id":   successor.UserID,
+				"reason":       "owner account purged",
+			},
+		}).Return(nil).Once()
+		mockAuditLogRepo.EXPECT().Create(ctx, mock.MatchedBy(func(input usecase.RepoCreateAuditLogInput) bool {
+			return input.Action == model.AuditActionPurgeAccount
+		})).Return(nil).Once()
+
+		res, err := uc.HandlePurgeScheduledAccountDeletions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &usecase.PurgeScheduledAccountDeletionsOutput{Purged: 1}, res)
+	})
+
+	t.Run("the account is not purged when the shared child can't be handed over", func(t *testing.T) {
+		mockUserRepo := mockUsecase.NewUserRepository(t)
+		mockResultRepo := mockUsecase.NewResultRepository(t)
+		mockChildRepo := mockUsecase.NewChildRepository(t)
+		mockChildGuardianRepo := mockUsecase.NewChildGuardianRepository(t)
+		mockTxCtrlFactory := mockUsecase.NewTransactionControllerFactory(t)
+		uc := usecase.NewAccountPurgeUsecase(mockUserRepo, mockResultRepo, mockChildRepo, mockChildGuardianRepo, mockTxCtrlFactory, nil)
+
+		successor := model.ChildGuardian{ChildID: uuid.New(), UserID: uuid.New(), Role: model.GuardianRoleGuardian}
+
+		mockUserRepo.EXPECT().FindDeletionRequestedBefore(ctx, dueBefore).Return([]model.User{purgedUser}, nil).Once()
+
+		transaction := mockUsecase.NewTransactionController(t)
+		mockTxCtrlFactory.EXPECT().New().Return(usecase.NewTxControllerWrapper(transaction)).Once()
+		transaction.EXPECT().Begin().Return(struct{}{}).Once()
+		mockChildGuardianRepo.EXPECT().FindSuccessors(ctx, purgedUser.ID, mock.Anything).
+			Return([]model.ChildGuardian{successor}, nil).Once()
+		mockChildRepo.EXPECT().TransferOwnership(ctx, mock.Anything, mock.Anything).Return(assert.AnError).Once()
+		transaction.EXPECT().Rollback().Return(nil).Once()
+
+		res, err := uc.HandlePurgeScheduledAccountDeletions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &usecase.PurgeScheduledAccountDeletionsOutput{Failed: 1}, res)
+	})
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/sweet-go/stdlib/helper"
+)
+
+// auditEntry the sensitive action to be recorded to the audit trail
+type auditEntry struct {
+	Action     model.AuditAction
+	TargetType model.AuditTargetType
+	TargetID   string
+	Metadata   model.AuditMetadata
+}
+
+// recordAuditLog append the action to the audit trail. The actor is the requester found in the context,
+// along with the client used. The action itself is already done when this is called, thus failing to record
+// is only logged instead of failing the request
+func recordAuditLog(ctx context.Context, auditLogRepo AuditLogRepository, entry auditEntry) {
+	input := RepoCreateAuditLogInput{
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Metadata:   entry.Metadata,
+	}
+
+	if requester := model.GetUserFromCtx(ctx); requester != nil {
+		input.ActorID = requester.ID
+		input.ActorRole = requester.Role
+	}
+
+	client := model.GetClientInfoFromCtx(ctx)
+	input.IPAddress = client.IPAddress
+	input.UserAgent = client.UserAgent
+
+	if err := auditLogRepo.Create(ctx, input); err != nil {
+		logrus.WithContext(ctx).WithError(err).WithField("audit-log", helper.Dump(input)).Error("failed to record audit log")
+	}
+}
+
+// AdminSearchAuditLogsInput input. Zero value fields are not used as filter
+type AdminSearchAuditLogsInput struct {
+	ActorID       uuid.UUID
+	Action        model.AuditAction
+	TargetType    model.AuditTargetType
+	TargetID      string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Limit         int `validate:"required,min=1,max=100"`
+	Offset        int `validate:"min=0"`
+}
+
+func (i AdminSearchAuditLogsInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// AuditLogOutput an entry of the audit trail
+type AuditLogOutput struct {
+	ID         uuid.UUID
+	ActorID    uuid.UUID
+	ActorRole  model.Roles
+	Action     model.AuditAction
+	TargetType model.AuditTargetType
+	TargetID   string
+	Metadata   model.AuditMetadata
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time
+}
+
+// AdminSearchAuditLogs allow administrator to search and paginate the audit trail, newest first
+func (u *UsersUsecase) AdminSearchAuditLogs(ctx context.Context, input AdminSearchAuditLogsInput) ([]AuditLogOutput, error) {
+	if err := Authorize(model.GetUserFromCtx(ctx), ActionReadAuditLog, RelationNone); err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	auditLogs, err := u.auditLogRepo.Search(ctx, RepoSearchAuditLogInput{
+		ActorID:       input.ActorID,
+		Action:        input.Action,
+		TargetType:    input.TargetType,
+		TargetID:      input.TargetID,
+		CreatedAfter:  input.CreatedAfter,
+		CreatedBefore: input.CreatedBefore,
+		Limit:         input.Limit,
+		Offset:        input.Offset,
+	})
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).WithField("input", helper.Dump(input)).Error("failed to search audit logs")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	output := make([]AuditLogOutput, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		output = append(output, AuditLogOutput{
+			ID:         auditLog.ID,
+			ActorID:    auditLog.ActorID,
+			ActorRole:  auditLog.ActorRole,
+			Action:     auditLog.Action,
+			TargetType: auditLog.TargetType,
+			TargetID:   auditLog.TargetID,
+			Metadata:   auditLog.Metadata,
+			IPAddress:  auditLog.IPAddress,
+			UserAgent:  auditLog.UserAgent,
+			CreatedAt:  auditLog.CreatedAt,
+		})
+	}
+
+	return output, nil
+}
@@ -0,0 +1,207 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersUsecase_AdminSearchAuditLogs(t *testing.T) {
+	ctx := context.Background()
+
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	uc := usecase.NewUsersUsecase(nil, nil, nil, mockAuditLogRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	adminCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator})
+	therapistCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesTherapist})
+
+	actorID := uuid.New()
+	createdAfter := time.Now().Add(-24 * time.Hour)
+	validInput := usecase.AdminSearchAuditLogsInput{
+		ActorID:      actorID,
+		Action:       model.AuditActionDownloadResult,
+		TargetType:   model.AuditTargetResult,
+		CreatedAfter: createdAfter,
+		Limit:        10,
+		Offset:       0,
+	}
+	repoInput := usecase.RepoSearchAuditLogInput{
+		ActorID:      actorID,
+		Action:       model.AuditActionDownloadResult,
+		TargetType:   model.AuditTargetResult,
+		CreatedAfter: createdAfter,
+		Limit:        10,
+		Offset:       0,
+	}
+
+	auditLog := model.AuditLog{
+		ID:         uuid.New(),
+		ActorID:    actorID,
+		ActorRole:  model.RolesTherapist,
+		Action:     model.AuditActionDownloadResult,
+		TargetType: model.AuditTargetResult,
+		TargetID:   uuid.NewString(),
+		Metadata:   model.AuditMetadata{"child_id": uuid.NewString()},
+		IPAddress:  "10.0.0.1",
+		UserAgent:  "Mozilla/5.0",
+		CreatedAt:  time.Now(),
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.AdminSearchAuditLogsInput
+		wantErr              bool
+		expectedErr          error
+		expectedOutput       []usecase.AuditLogOutput
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "non administrator is forbidden",
+			ctx:         therapistCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:        "limit is required",
+			ctx:         adminCtx,
+			input:       usecase.AdminSearchAuditLogsInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "limit is too big",
+			ctx:         adminCtx,
+			input:       usecase.AdminSearchAuditLogsInput{Limit: 101},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "offset should not be negative",
+			ctx:         adminCtx,
+			input:       usecase.AdminSearchAuditLogsInput{Limit: 10, Offset: -1},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "repository returning unexpected error",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockAuditLogRepo.EXPECT().Search(adminCtx, repoInput).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "not found",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockAuditLogRepo.EXPECT().Search(adminCtx, repoInput).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:    "ok",
+			ctx:     adminCtx,
+			input:   validInput,
+			wantErr: false,
+			expectedOutput: []usecase.AuditLogOutput{
+				{
+					ID:         auditLog.ID,
+					ActorID:    auditLog.ActorID,
+					ActorRole:  auditLog.ActorRole,
+					Action:     auditLog.Action,
+					TargetType: auditLog.TargetType,
+					TargetID:   auditLog.TargetID,
+					Metadata:   auditLog.Metadata,
+					IPAddress:  auditLog.IPAddress,
+					UserAgent:  auditLog.UserAgent,
+					CreatedAt:  auditLog.CreatedAt,
+				},
+			},
+			expectedFunctionCall: func() {
+				mockAuditLogRepo.EXPECT().Search(adminCtx, repoInput).Return([]model.AuditLog{auditLog}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.AdminSearchAuditLogs(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, res)
+				assertUsecaseErrType(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
+
+func TestAuditLog_RecordedWithClientInfo(t *testing.T) {
+	admin := model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator}
+	client := model.ClientInfo{IPAddress: "10.0.0.1", UserAgent: "Mozilla/5.0"}
+	ctx := model.SetClientInfoToCtx(model.SetUserToCtx(context.Background(), admin), client)
+
+	packageID := uuid.New()
+	expectedAuditLog := usecase.RepoCreateAuditLogInput{
+		ActorID:    admin.ID,
+		ActorRole:  model.RolesAdministrator,
+		Action:     model.AuditActionDeletePackage,
+		TargetType: model.AuditTargetPackage,
+		TargetID:   packageID.String(),
+		IPAddress:  client.IPAddress,
+		UserAgent:  client.UserAgent,
+	}
+
+	t.Run("recorded", func(t *testing.T) {
+		mockPackageRepo := mockUsecase.NewPackageRepo(t)
+		mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+		uc := usecase.NewPackageUsecase(mockPackageRepo, mockAuditLogRepo)
+
+		mockPackageRepo.EXPECT().FindByID(ctx, packageID).Return(&model.Package{ID: packageID}, nil).Once()
+		mockPackageRepo.EXPECT().Delete(ctx, packageID).Return(nil).Once()
+		mockAuditLogRepo.EXPECT().Create(ctx, expectedAuditLog).Return(nil).Once()
+
+		require.NoError(t, uc.Delete(ctx, packageID))
+	})
+
+	t.Run("failing to record should not fail the action", func(t *testing.T) {
+		mockPackageRepo := mockUsecase.NewPackageRepo(t)
+		mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+		uc := usecase.NewPackageUsecase(mockPackageRepo, mockAuditLogRepo)
+
+		mockPackageRepo.EXPECT().FindByID(ctx, packageID).Return(&model.Package{ID: packageID}, nil).Once()
+		mockPackageRepo.EXPECT().Delete(ctx, packageID).Return(nil).Once()
+		mockAuditLogRepo.EXPECT().Create(ctx, expectedAuditLog).Return(assert.AnError).Once()
+
+		require.NoError(t, uc.Delete(ctx, packageID))
+	})
+}
@@ -3,10 +3,12 @@ package usecase
 
 import (
 	"context"
+	"crypto/subtle"
 	"database/sql"
 	"encoding/base64"
+	"errors"
 	"fmt"
-	"reflect"
+	"html"
 	"strings"
 	"time"
 
@@ -29,6 +31,13 @@ type AuthUsecase struct {
 	transactionControllerFactory TransactionControllerFactory
 	mailer                       common.MailerIface
 	rateLimiter                  RateLimiter
+	sessionRepo                  SessionRepository
+	emailTokenRepo               EmailTokenRepository
+	mfaRecoveryCodeRepo          MFARecoveryCodeRepository
+	passwordPolicy               *common.PasswordPolicy
+	passwordlessLoginRepo        PasswordlessLoginRepository
+	personalAccessTokenRepo      PersonalAccessTokenRepository
+	auditLogRepo                 AuditLogRepository
 }
 
 // AuthUsecaseIface interface exported by AuthUsecase to help ease mocking
@@ -41,6 +50,38 @@ type AuthUsecaseIface interface {
 	AuthenticateAccessToken(ctx context.Context, input AuthenticateAccessTokenInput) (*AuthenticateAccessTokenOutput, error)
 	HandleResendSignupVerification(ctx context.Context, input ResendSignupVerificationInput) (*ResendSignupVerificationOutput, error)
 	HandleDeleteUserData(ctx context.Context, input DeleteUserDataInput) error
+	HandleRefreshToken(ctx context.Context, input RefreshTokenInput) (*RefreshTokenOutput, error)
+	HandleLogout(ctx context.Context) error
+	HandleLogoutAll(ctx context.Context) error
+	HandleAdminForceResetPassword(ctx context.Context, input AdminForceResetPasswordInput) (*AdminForceResetPasswordOutput, error)
+	HandleInviteTherapist(ctx context.Context, input InviteTherapistInput) (*InviteTherapistOutput, error)
+	HandleRedeemTherapistInvitation(
+		ctx context.Context, input RedeemTherapistInvitationInput,
+	) (*RedeemTherapistInvitationOutput, error)
+	HandleMFAVerifyLogin(ctx context.Context, input MFAVerifyLoginInput) (*LoginOutput, error)
+	HandleMFAEnroll(ctx context.Context, input MFAEnrollInput) (*MFAEnrollOutput, error)
+	HandleMFAConfirmEnrollment(ctx context.Context, input MFAConfirmEnrollmentInput) (*MFAConfirmEnrollmentOutput, error)
+	HandleMFADisable(ctx context.Context, input MFADisableInput) (*MFADisableOutput, error)
+	HandleUnlockAccount(ctx context.Context, input UnlockAccountInput) (*UnlockAccountOutput, error)
+	HandleChangePassword(ctx context.Context, input ChangePasswordInput) (*ChangePasswordOutput, error)
+	HandleInitChangeEmail(ctx context.Context, input InitChangeEmailInput) (*InitChangeEmailOutput, error)
+	HandleConfirmChangeEmail(ctx context.Context, input ConfirmChangeEmailInput) (*ConfirmChangeEmailOutput, error)
+	HandleInitPasswordlessLogin(ctx context.Context, input InitPasswordlessLoginInput) (*InitPasswordlessLoginOutput, error)
+	HandleVerifyPasswordlessLogin(ctx context.Context, input VerifyPasswordlessLoginInput) (*LoginOutput, error)
+	HandleMagicLinkLogin(ctx context.Context, input MagicLinkLoginInput) (*LoginOutput, error)
+	HandleCreatePersonalAccessToken(
+		ctx context.Context, input CreatePersonalAccessTokenInput,
+	) (*CreatePersonalAccessTokenOutput, error)
+	HandleListPersonalAccessTokens(ctx context.Context) ([]PersonalAccessTokenOutput, error)
+	HandleRevokePersonalAccessToken(
+		ctx context.Context, input RevokePersonalAccessTokenInput,
+	) (*RevokePersonalAccessTokenOutput, error)
+	HandleListMySessions(ctx context.Context) ([]SessionOutput, error)
+	HandleRevokeMySession(ctx context.Context, input RevokeMySessionInput) (*RevokeMySessionOutput, error)
+	HandleRestoreAccount(ctx context.Context, input RestoreAccountInput) (*RestoreAccountOutput, error)
+	HandleAdminCancelAccountDeletion(
+		ctx context.Context, input AdminCancelAccountDeletionInput,
+	) (*AdminCancelAccountDeletionOutput, error)
 }
 
 // NewAuthUsecase create new instance for AuthUsecase
@@ -52,6 +93,13 @@ func NewAuthUsecase(
 	transactionControllerFactory TransactionControllerFactory,
 	mailer common.MailerIface,
 	rateLimiter RateLimiter,
+	sessionRepo SessionRepository,
+	emailTokenRepo EmailTokenRepository,
+	mfaRecoveryCodeRepo MFARecoveryCodeRepository,
+	passwordPolicy *common.PasswordPolicy,
+	passwordlessLoginRepo PasswordlessLoginRepository,
+	personalAccessTokenRepo PersonalAccessTokenRepository,
+	auditLogRepo AuditLogRepository,
 ) *AuthUsecase {
 	return &AuthUsecase{
 		sharedCryptor:                sharedCryptor,
@@ -61,6 +109,13 @@ func NewAuthUsecase(
 		transactionControllerFactory: transactionControllerFactory,
 		mailer:                       mailer,
 		rateLimiter:                  rateLimiter,
+		sessionRepo:                  sessionRepo,
+		emailTokenRepo:               emailTokenRepo,
+		mfaRecoveryCodeRepo:          mfaRecoveryCodeRepo,
+		passwordPolicy:               passwordPolicy,
+		passwordlessLoginRepo:        passwordlessLoginRepo,
+		personalAccessTokenRepo:      personalAccessTokenRepo,
+		auditLogRepo:                 auditLogRepo,
 	}
 }
 
@@ -73,6 +128,20 @@ func sqlNullFromPtr(s *string) sql.NullString {
 	return sql.NullString{String: *s, Valid: true}
 }
 
+// newEmailLookup create the lookup to find the user by the email, including the one whose email blind index
+// is not yet backfilled
+func newEmailLookup(sharedCryptor common.SharedCryptorIface, email string) (RepoEmailLookup, error) {
+	legacyEncryptedEmails, err := sharedCryptor.LegacyEncrypt(email)
+	if err != nil {
+		return RepoEmailLookup{}, err
+	}
+
+	return RepoEmailLookup{
+		BlindIndex:            sharedCryptor.BlindIndex(email),
+		LegacyEncryptedEmails: legacyEncryptedEmails,
+	}, nil
+}
+
 // encryptUserData encrypts email/phone/address fields from a user-like payload.
 // If Email is empty string, only encrypts optional fields.
 func (u *AuthUsecase) encryptUserData(user model.User) (string, sql.NullString, sql.NullString, error) {
@@ -112,10 +181,11 @@ func (u *AuthUsecase) encryptUserData(user model.User) (string, sql.NullString,
 	return encryptedEmail, encPhone, encAddress, nil
 }
 
-// LoginInput input
+// LoginInput input. IPAddress is used to limit the login attempts from the same client, leave empty to skip it
 type LoginInput struct {
-	Email    string `validate:"required,email"`
-	Password string `validate:"required,min=8"`
+	Email     string `validate:"required,email"`
+	Password  string `validate:"required,min=8"`
+	IPAddress string
 }
 
 // Validate validate LoginInput's
@@ -123,12 +193,18 @@ func (li LoginInput) Validate() error {
 	return common.Validator.Struct(li)
 }
 
-// LoginOutput output
+// LoginOutput output. When MFARequired or MFAEnrollmentRequired is true, Token and RefreshToken
+// will be empty and the MFAToken must be used to complete the two-factor authentication first
 type LoginOutput struct {
-	Token string
+	Token                 string
+	RefreshToken          string
+	MFARequired           bool
+	MFAEnrollmentRequired bool
+	MFAToken              string
 }
 
-// HandleLogin contains logic to handle login request
+// HandleLogin contains logic to handle login request. Unknown email, wrong password and locked account
+// will all result in the same response, to prevent the registered accounts from being enumerated
 func (u *AuthUsecase) HandleLogin(ctx context.Context, input LoginInput) (*LoginOutput, error) {
 	logger := logrus.WithContext(ctx).WithField("email", input.Email)
 
@@ -139,7 +215,13 @@ func (u *AuthUsecase) HandleLogin(ctx context.Context, input LoginInput) (*Login
 		}
 	}
 
-	emailEnc, err := u.sharedCryptor.Encrypt(input.Email)
+	if input.IPAddress != "" {
+		if err := u.limitLoginAttempt(ctx, "login-ip:"+input.IPAddress, config.LoginIPAttemptLimit()); err != nil {
+			return nil, err
+		}
+	}
+
+	emailLookup, err := newEmailLookup(u.sharedCryptor, input.Email)
 	if err != nil {
 		return nil, UsecaseError{
 			ErrType: ErrInternal,
@@ -147,7 +229,11 @@ func (u *AuthUsecase) HandleLogin(ctx context.Context, input LoginInput) (*Login
 		}
 	}
 
-	user, err := u.userRepo.FindByEmail(ctx, emailEnc)
+	if err := u.limitLoginAttempt(ctx, "login-account:"+emailLookup.BlindIndex, config.LoginAccountAttemptLimit()); err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.FindByEmail(ctx, emailLookup)
 	switch err {
 	default:
 		logger.WithError(err).Error("failed to find user by email")
@@ -157,54 +243,72 @@ func (u *AuthUsecase) HandleLogin(ctx context.Context, input LoginInput) (*Login
 			Message: ErrInternal.Error(),
 		}
 	case ErrRepoNotFound:
-		return nil, UsecaseError{
-			ErrType: ErrNotFound,
-			Message: ErrNotFound.Error(),
-		}
+		// compare against a dummy hash, so unknown email can't be distinguished by the response time
+		u.compareDummyPassword(input.Password)
+
+		return nil, errInvalidLoginCredentials
 	case nil:
 		break
 	}
 
-	if !user.IsActive {
-		return nil, UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: "this account still not activated",
-		}
+	if err := u.checkLoginLockout(ctx, user); err != nil {
+		u.compareDummyPassword(input.Password)
+
+		return nil, err
 	}
 
-	pwDecoded, err := base64.StdEncoding.DecodeString(user.Password)
+	match, err := u.verifyUserPassword(ctx, user, input.Password)
 	if err != nil {
-		logger.WithError(err).Error("failed to decode base 64 string")
+		return nil, err
+	}
+
+	if !match {
+		return nil, u.handleFailedLogin(ctx, user)
+	}
 
+	// only revealed to whoever knows the password, so it can't be used to enumerate the registered accounts
+	if user.IsDeletionScheduled() {
 		return nil, UsecaseError{
-			ErrType: ErrInternal,
-			Message: ErrInternal.Error(),
+			ErrType: ErrUnauthorized,
+			Message: "this account is scheduled for deletion, use the restore link sent to your email to restore it",
 		}
 	}
 
-	err = u.sharedCryptor.CompareHash(pwDecoded, []byte(input.Password))
-	if err != nil {
+	if !user.IsActive {
 		return nil, UsecaseError{
 			ErrType: ErrUnauthorized,
-			Message: "invalid password",
+			Message: "this account still not activated",
 		}
 	}
 
-	loginToken, err := u.sharedCryptor.CreateJWT(model.LoginTokenClaims{
-		Role: user.Roles,
-		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:   string(TokenIssuerSystem),
-			Subject:  string(LoginToken),
-			Audience: []string{user.ID.String()},
-			ExpiresAt: jwt.NewNumericDate(
-				time.Now().Add(config.LoginTokenExpiry()),
-			),
-			IssuedAt: jwt.NewNumericDate(time.Now()),
-		},
-	})
+	if user.FailedLoginAttempts > 0 {
+		if err := u.resetLoginFailures(ctx, user.ID); err != nil {
+			logger.WithError(err).Error("failed to reset failed login attempts")
+
+			return nil, UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		}
+	}
+
+	if IsAllowed(user.Roles, ActionUseMFA, RelationNone) && (user.MFAEnabled || user.MFARequired) {
+		output, err := u.issueMFAChallenge(user)
+		if err != nil {
+			logger.WithError(err).Error("failed to issue mfa challenge")
+
+			return nil, UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		}
 
+		return output, nil
+	}
+
+	loginToken, refreshToken, err := u.issueLoginSession(ctx, user)
 	if err != nil {
-		logger.WithError(err).Error("failed to generate jwt token after login")
+		logger.WithError(err).Error("failed to issue login session")
 
 		return nil, UsecaseError{
 			ErrType: ErrInternal,
@@ -212,7 +316,10 @@ func (u *AuthUsecase) HandleLogin(ctx context.Context, input LoginInput) (*Login
 		}
 	}
 
-	return &LoginOutput{Token: loginToken}, nil
+	return &LoginOutput{
+		Token:        loginToken,
+		RefreshToken: refreshToken,
+	}, nil
 }
 
 // SignupInput input
@@ -254,6 +361,13 @@ var (
 	SignupVerificationToken JWTTokenType = "signup-verification-token"
 	LoginToken              JWTTokenType = "login-token"
 	ChangePasswordToken     JWTTokenType = "change-password"
+	TherapistInvitation     JWTTokenType = "therapist-invitation"
+	MFAPendingToken         JWTTokenType = "mfa-pending"
+	AccountUnlockToken      JWTTokenType = "account-unlock"
+	ChangeEmailToken        JWTTokenType = "change-email"
+	AccountRestoreToken     JWTTokenType = "account-restore"
+	GuardianInvitation      JWTTokenType = "guardian-invitation"
+	ChildTransferToken      JWTTokenType = "child-transfer"
 )
 
 // JWTTokenIssuer known jwt token issuer for field iss
@@ -264,7 +378,8 @@ var (
 	TokenIssuerSystem JWTTokenIssuer = "system"
 )
 
-// HandleSignup contains logic to handle signup request
+// HandleSignup contains logic to handle signup request. If the email is already registered, the response
+// will be the same as the successful signup and the email owner will be notified instead
 func (u *AuthUsecase) HandleSignup(ctx context.Context, input SignupInput) (*SignupOutput, error) {
 	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"email": helper.Dump(input.Email),
@@ -285,24 +400,15 @@ func (u *AuthUsecase) HandleSignup(ctx context.Context, input SignupInput) (*Sig
 		}
 	}
 
-	_, err = u.userRepo.FindByEmail(ctx, emailEncrypted)
-	switch err {
-	default:
-		logger.WithError(err).Error("failed to perform query to find user by id")
-
+	emailLookup, err := newEmailLookup(u.sharedCryptor, input.Email)
+	if err != nil {
 		return nil, UsecaseError{
 			ErrType: ErrInternal,
-			Message: ErrInternal.Error(),
-		}
-	case nil:
-		return nil, UsecaseError{
-			ErrType: ErrBadRequest,
-			Message: "your email has been used by another account",
+			Message: "encryption process failed",
 		}
-	case ErrRepoNotFound:
-		break
 	}
 
+	// hashing is done before checking the email, so registered and new email take comparable time
 	hashedPassword, err := u.sharedCryptor.Hash([]byte(input.Password))
 	if err != nil {
 		logger.WithError(err).Error("failed to perform hasing password")
@@ -313,6 +419,34 @@ func (u *AuthUsecase) HandleSignup(ctx context.Context, input SignupInput) (*Sig
 		}
 	}
 
+	_, err = u.userRepo.FindByEmail(ctx, emailLookup)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to perform query to find user by id")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case nil:
+		// only the email owner should know that the email is registered
+		err := u.sendAccountNoticeEmail(ctx, input.Email, "Akun Sudah Terdaftar", accountAlreadyRegisteredEmailTemplate())
+		if err != nil {
+			logger.WithError(err).Error("failed to send account already registered email")
+
+			return nil, UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		}
+
+		return &SignupOutput{
+			Message: "email confirmation sent",
+		}, nil
+	case ErrRepoNotFound:
+		break
+	}
+
 	_, encPhone, encAddress, err := u.encryptUserData(
 		model.User{
 			Email:       "",
@@ -326,11 +460,12 @@ func (u *AuthUsecase) HandleSignup(ctx context.Context, input SignupInput) (*Sig
 	}
 
 	createUserInput := RepoCreateUserInput{
-		Email:    emailEncrypted,
-		Password: hashedPassword,
-		Username: input.Username,
-		IsActive: false,
-		Roles:    model.RolesParent,
+		Email:           emailEncrypted,
+		EmailBlindIndex: emailLookup.BlindIndex,
+		Password:        hashedPassword,
+		Username:        input.Username,
+		IsActive:        false,
+		Roles:           model.RolesParent,
 		PhoneNumber: sql.NullString{
 			String: encPhone.String,
 			Valid:  encPhone.Valid,
@@ -358,13 +493,7 @@ func (u *AuthUsecase) HandleSignup(ctx context.Context, input SignupInput) (*Sig
 		}
 	}
 
-	token, err := u.sharedCryptor.CreateJWT(jwt.RegisteredClaims{
-		Issuer:    string(TokenIssuerSystem),
-		Subject:   string(SignupVerificationToken),
-		Audience:  []string{user.ID.String()},
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(config.SignupTokenExpiry())),
-	})
-
+	token, err := u.issueEmailToken(ctx, user.ID, SignupVerificationToken, config.SignupTokenExpiry(), tx)
 	if err != nil {
 		logger.WithError(err).Error("failed to create JWT token for signup verification")
 
@@ -444,18 +573,18 @@ func (u *AuthUsecase) HandleAccountVerification(ctx context.Context, input Accou
 		Subject: string(SignupVerificationToken),
 	})
 
-	switch err {
+	switch {
 	default:
 		return nil, UsecaseError{
 			ErrType: ErrUnauthorized,
 			Message: "invalid token for account verification",
 		}
-	case jwt.ErrTokenExpired:
+	case errors.Is(err, jwt.ErrTokenExpired):
 		return nil, UsecaseError{
 			ErrType: ErrUnauthorized,
 			Message: "account validation token has expired",
 		}
-	case nil:
+	case err == nil:
 		break
 	}
 
@@ -519,6 +648,14 @@ func (u *AuthUsecase) HandleAccountVerification(ctx context.Context, input Accou
 		break
 	}
 
+	// the account was already activated before its deletion was requested
+	if user.IsDeletionScheduled() {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "this account is scheduled for deletion",
+		}
+	}
+
 	// early return if already activated
 	if user.IsActive {
 		return &AccountVerificationOutput{
@@ -526,12 +663,29 @@ func (u *AuthUsecase) HandleAccountVerification(ctx context.Context, input Accou
 		}, nil
 	}
 
+	// the token must only be consumed when the account is activated, otherwise the user is left with
+	// an inactive account and a used verification token
+	txCtrl := u.transactionControllerFactory.New()
+	tx := txCtrl.Begin()
+
+	if err := consumeEmailToken(ctx, u.emailTokenRepo, claims, user.ID, SignupVerificationToken, tx); err != nil {
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return nil, err
+	}
+
 	activeTrue := true
 	_, err = u.userRepo.Update(ctx, user.ID, RepoUpdateUserInput{
 		IsActive: &activeTrue,
-	})
+	}, tx)
 
 	if err != nil {
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
 		logger.WithError(err).Error("failed to activate user account to database")
 
 		return nil, UsecaseError{
@@ -540,6 +694,15 @@ func (u *AuthUsecase) HandleAccountVerification(ctx context.Context, input Accou
 		}
 	}
 
+	if err := txCtrl.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
 	return &AccountVerificationOutput{
 		Message: "your account has been activated",
 	}, nil
@@ -561,7 +724,7 @@ type InitResetPasswordOutput struct {
 }
 
 // HandleInitesetPassword will handle change password request by sending email containing the necessary
-// data to change password
+// data to change password. The response is the same whether or not an active account is registered using the email
 func (u *AuthUsecase) HandleInitesetPassword(ctx context.Context, input InitResetPasswordInput) (*InitResetPasswordOutput, error) {
 	logger := logrus.WithContext(ctx).WithField("email", input.Email)
 
@@ -572,7 +735,7 @@ func (u *AuthUsecase) HandleInitesetPassword(ctx context.Context, input InitRese
 		}
 	}
 
-	emailEnc, err := u.sharedCryptor.Encrypt(input.Email)
+	emailLookup, err := newEmailLookup(u.sharedCryptor, input.Email)
 	if err != nil {
 		return nil, UsecaseError{
 			ErrType: ErrInternal,
@@ -580,7 +743,30 @@ func (u *AuthUsecase) HandleInitesetPassword(ctx context.Context, input InitRese
 		}
 	}
 
-	user, err := u.userRepo.FindByEmail(ctx, emailEnc)
+	resetLimit := redis_rate.Limit{
+		Rate:   1,
+		Burst:  1,
+		Period: config.InitResetPasswordLimiterDuration(),
+	}
+
+	rateLimit, err := u.rateLimiter.Allow(ctx, "reset-password:"+emailLookup.BlindIndex, resetLimit)
+	if err != nil {
+		logger.WithError(err).Error("failed to perform rate limiter ops")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if rateLimit.Allowed == 0 {
+		return nil, UsecaseError{
+			ErrType: ErrTooManyRequests,
+			Message: fmt.Sprintf("please retry again after %d", int64(rateLimit.ResetAfter.Seconds())),
+		}
+	}
+
+	user, err := u.userRepo.FindByEmail(ctx, emailLookup)
 	switch err {
 	default:
 		logger.WithError(err).Error("failed to find user by email")
@@ -589,30 +775,38 @@ func (u *AuthUsecase) HandleInitesetPassword(ctx context.Context, input InitRese
 			ErrType: ErrInternal,
 			Message: ErrInternal.Error(),
 		}
-	case ErrRepoNotFound:
-		return nil, UsecaseError{
-			ErrType: ErrNotFound,
-			Message: ErrNotFound.Error(),
-		}
-	case nil:
+	case nil, ErrRepoNotFound:
 		break
 	}
 
-	if !user.IsActive {
-		return nil, UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: "this account active status is disabled",
+	// only the email owner should know whether an active account is registered using the email
+	if user == nil || !user.IsActive {
+		err := u.sendAccountNoticeEmail(ctx, input.Email, "Permintaan Reset Kata Sandi", noActiveAccountEmailTemplate())
+		if err != nil {
+			logger.WithError(err).Error("failed to send no active account email")
+
+			return nil, UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
 		}
+
+		return &InitResetPasswordOutput{
+			Message: "ok",
+		}, nil
 	}
 
-	changePassToken, err := u.sharedCryptor.CreateJWT(jwt.RegisteredClaims{
-		Issuer:    string(TokenIssuerSystem),
-		Subject:   string(ChangePasswordToken),
-		Audience:  []string{user.ID.String()},
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(config.ChangePasswordTokenExpiry())),
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
-	})
+	// issuing a new reset password token invalidates the older ones
+	if err := u.emailTokenRepo.RevokeAllUserTokens(ctx, user.ID, string(ChangePasswordToken)); err != nil {
+		logger.WithError(err).Error("failed to revoke previous change password tokens")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
 
+	changePassToken, err := u.issueEmailToken(ctx, user.ID, ChangePasswordToken, config.ChangePasswordTokenExpiry())
 	if err != nil {
 		logger.WithError(err).Error("failed to create JWT token for change password")
 
@@ -626,84 +820,7 @@ func (u *AuthUsecase) HandleInitesetPassword(ctx context.Context, input InitRese
 		ReceiverName:  user.Username,
 		ReceiverEmail: input.Email,
 		Subject:       "Permintaan Reset Kata Sandi",
-		//nolint:lll
-		HTMLContent: fmt.Sprintf(`
-			<!DOCTYPE html>
-			<html>
-			<head>
-				<style>
-					/* General styles */
-					body {
-						font-family: Arial, sans-serif;
-						margin: 0;
-						padding: 0;
-						background-color: #f6f6f6;
-						color: #333;
-					}
-					.email-container {
-						max-width: 600px;
-						margin: 20px auto;
-						background-color: #ffffff;
-						border: 1px solid #ddd;
-						border-radius: 8px;
-						overflow: hidden;
-					}
-					.header {
-						background-color: #4CAF50;
-						color: white;
-						padding: 20px;
-						text-align: center;
-					}
-					.content {
-						padding: 20px;
-					}
-					.content p {
-						margin: 0 0 15px;
-						line-height: 1.6;
-					}
-					.btn-container {
-						text-align: center;
-						margin: 20px 0;
-					}
-					.btn {
-						display: inline-block;
-						background-color: #4CAF50;
-						color: white;
-						text-decoration: none;
-						padding: 10px 20px;
-						font-size: 16px;
-						border-radius: 5px;
-					}
-					.btn:hover {
-						background-color: #45a049;
-					}
-					.footer {
-						background-color: #f1f1f1;
-						text-align: center;
-						padding: 10px;
-						font-size: 12px;
-						color: #666;
-					}
-				</style>
-			</head>
-			<body>
-				<div class="email-container">
-					<div class="header">
-						<h1>Permintaan Reset Kata Sandi</h1>
-					</div>
-					<div class="content">
-						<p>Baru saja sistem menerima permintaan reset kata sandi terhadap akun Anda. Apabila Anda merasa melakukannya, silahkan klik tombol di bawah ini:</p>
-						<div class="btn-container">
-							<a href="%s?%s=%s" class="btn">Reset Kata Sandi</a>
-						</div>
-					</div>
-					<div class="footer">
-						<p>Jika Anda tidak merasa melakukannya, silahkan abaikan email ini dan akun anda tidak akan diganti kata sandinya</p>
-					</div>
-				</div>
-			</body>
-			</html>
-			`, config.ServerResetPasswordBaseURL(), model.ChangePasswordTokenQuery, changePassToken),
+		HTMLContent:   resetPasswordEmailTemplate(changePassToken),
 	})
 
 	if err != nil {
@@ -747,7 +864,7 @@ func (u *AuthUsecase) HandleResetPassword(ctx context.Context, input ResetPasswo
 		}
 	}
 
-	_, claims, err := u.parseJWTToken(input.ResetPasswordToken, parseJWTTokenInput{
+	_, claims, err := parseJWTToken(u.sharedCryptor, input.ResetPasswordToken, parseJWTTokenInput{
 		expectedIssuer:      TokenIssuerSystem,
 		expectedSubject:     ChangePasswordToken,
 		expectedAudiences:   nil,
@@ -799,11 +916,40 @@ func (u *AuthUsecase) HandleResetPassword(ctx context.Context, input ResetPasswo
 		}
 	}
 
+	txCtrl := u.transactionControllerFactory.New()
+	tx := txCtrl.Begin()
+
+	if err := consumeEmailToken(ctx, u.emailTokenRepo, claims, user.ID, ChangePasswordToken, tx); err != nil {
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return nil, err
+	}
+
+	// once the password is changed, every other outstanding reset password token must no longer be usable
+	if err := u.emailTokenRepo.RevokeAllUserTokens(ctx, user.ID, string(ChangePasswordToken), tx); err != nil {
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		logger.WithError(err).Error("failed to revoke outstanding change password tokens")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
 	_, err = u.userRepo.Update(ctx, user.ID, RepoUpdateUserInput{
 		Password: newPasswordHashed,
-	})
+	}, tx)
 
 	if err != nil {
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
 		logger.WithError(err).Error("failed to update new user password to database")
 
 		return nil, UsecaseError{
@@ -812,122 +958,119 @@ func (u *AuthUsecase) HandleResetPassword(ctx context.Context, input ResetPasswo
 		}
 	}
 
+	if err := txCtrl.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
 	return &ResetPasswordOutput{
 		Message: "ok",
 	}, nil
 }
 
-// AuthenticateAccessTokenInput input
-type AuthenticateAccessTokenInput struct {
-	Token string
+// ChangePasswordInput input
+type ChangePasswordInput struct {
+	CurrentPassword string `validate:"required"`
+	NewPassword     string `validate:"required"`
 }
 
-// AuthenticateAccessTokenOutput output
-type AuthenticateAccessTokenOutput struct {
-	UserID   uuid.UUID
-	UserRole model.Roles
+func (cpi ChangePasswordInput) validate() error {
+	return common.Validator.Struct(cpi)
 }
 
-// AuthenticateAccessToken will perform validation and checking for supplied jwt token.
-// Based on the supplied params, you can determine whether to allow the request or not based on the
-// returned value. If the error is not nil, safe to assume that you should not let the request pass.
-func (u *AuthUsecase) AuthenticateAccessToken(_ context.Context, input AuthenticateAccessTokenInput) (*AuthenticateAccessTokenOutput, error) {
-	jwtToken, _, err := u.parseJWTToken(input.Token, parseJWTTokenInput{
-		expectedIssuer:      TokenIssuerSystem,
-		expectedSubject:     LoginToken,
-		expectedAudiences:   nil,
-		expectedAudienceLen: 1,
-	})
+// ChangePasswordOutput output
+type ChangePasswordOutput struct {
+	Message string
+}
 
+// verifyUserPassword compare the password against the user's password hash, which is stored base64 encoded.
+// A wrong password is reported by the returned boolean, while the returned error is always an internal error
+func (u *AuthUsecase) verifyUserPassword(ctx context.Context, user *model.User, password string) (bool, error) {
+	pwDecoded, err := base64.StdEncoding.DecodeString(user.Password)
 	if err != nil {
-		return nil, err
-	}
+		logrus.WithContext(ctx).WithError(err).WithField("user-id", user.ID).Error("failed to decode base 64 string")
 
-	claims, ok := jwtToken.Claims.(jwt.MapClaims)
-	if !(ok && jwtToken.Valid) {
-		return nil, UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: "invalid jwt payload or the token is invalid",
+		return false, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
 		}
 	}
 
-	// no need to check the err here, because it's already checked
-	// when calling the parseJWTToken
-	audiences, _ := claims.GetAudience()
-	targetUserID := audiences[0]
+	return u.sharedCryptor.CompareHash(pwDecoded, []byte(password)) == nil, nil
+}
 
-	userID, err := uuid.Parse(targetUserID)
-	if err != nil {
+// HandleChangePassword change the requester's password after verifying the current one. Once changed, every
+// other session and outstanding reset password token of the requester will be revoked, and a confirmation
+// email will be sent. The session used to make this request is kept, so the requester stays logged in
+func (u *AuthUsecase) HandleChangePassword(ctx context.Context, input ChangePasswordInput) (*ChangePasswordOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
 		return nil, UsecaseError{
 			ErrType: ErrUnauthorized,
-			Message: "invalid value of user id",
+			Message: ErrUnauthorized.Error(),
 		}
 	}
 
-	role, ok := claims["role"].(string)
-	if !ok {
+	if err := input.validate(); err != nil {
 		return nil, UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: "undefined role on auth token",
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
 		}
 	}
 
-	var userRole model.Roles
+	logger := logrus.WithContext(ctx).WithField("user-id", requester.ID)
 
-	switch role {
+	user, err := u.userRepo.FindByID(ctx, requester.ID)
+	switch err {
 	default:
+		logger.WithError(err).Error("failed to find user by id")
+
 		return nil, UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: "invalid role on auth token",
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
 		}
-	case string(model.RolesTherapist):
-		userRole = model.RolesTherapist
-	case string(model.RolesAdministrator):
-		userRole = model.RolesAdministrator
-	case string(model.RolesParent):
-		userRole = model.RolesParent
+	case nil:
+		break
 	}
 
-	return &AuthenticateAccessTokenOutput{
-		UserID:   userID,
-		UserRole: userRole,
-	}, nil
-}
+	match, err := u.verifyUserPassword(ctx, user, input.CurrentPassword)
+	if err != nil {
+		return nil, err
+	}
 
-// ResendSignupVerificationInput input
-type ResendSignupVerificationInput struct {
-	Email string `validate:"required,email"`
-}
-
-func (rsvi ResendSignupVerificationInput) validate() error {
-	return common.Validator.Struct(rsvi)
-}
-
-// ResendSignupVerificationOutput output
-type ResendSignupVerificationOutput struct {
-	Message string `json:"message"`
-}
+	if !match {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "current password is incorrect",
+		}
+	}
 
-// HandleResendSignupVerification will resend the email verification to the user's email
-func (u *AuthUsecase) HandleResendSignupVerification(ctx context.Context, input ResendSignupVerificationInput) (
-	*ResendSignupVerificationOutput,
-	error,
-) {
-	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
-		"email": input.Email,
-		"func":  "HandleResendSignupVerification",
-	})
+	if input.NewPassword == input.CurrentPassword {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "new password must be different from the current password",
+		}
+	}
 
-	if err := input.validate(); err != nil {
+	if err := u.passwordPolicy.Validate(input.NewPassword); err != nil {
 		return nil, UsecaseError{
 			ErrType: ErrBadRequest,
 			Message: err.Error(),
 		}
 	}
 
-	emailEnc, err := u.sharedCryptor.Encrypt(input.Email)
+	newPasswordHashed, err := u.sharedCryptor.Hash([]byte(input.NewPassword))
 	if err != nil {
-		logger.WithError(err).Error("failed to encrypt email")
+		logger.WithError(err).Error("failed to hash new user password")
 
 		return nil, UsecaseError{
 			ErrType: ErrInternal,
@@ -935,15 +1078,11 @@ func (u *AuthUsecase) HandleResendSignupVerification(ctx context.Context, input
 		}
 	}
 
-	resendLimit := redis_rate.Limit{
-		Rate:   1,
-		Burst:  1,
-		Period: config.ResendSignupVerificationLimiterDuration(),
-	}
-
-	rateLimit, err := u.rateLimiter.Allow(ctx, emailEnc, resendLimit)
+	_, err = u.userRepo.Update(ctx, user.ID, RepoUpdateUserInput{
+		Password: newPasswordHashed,
+	})
 	if err != nil {
-		logger.WithError(err).Error("failed to perform rate limiter ops")
+		logger.WithError(err).Error("failed to update new user password to database")
 
 		return nil, UsecaseError{
 			ErrType: ErrInternal,
@@ -951,322 +1090,1035 @@ func (u *AuthUsecase) HandleResendSignupVerification(ctx context.Context, input
 		}
 	}
 
-	if rateLimit.Allowed == 0 {
+	if err := u.sessionRepo.RevokeOtherUserSessions(ctx, user.ID, requester.SessionID); err != nil {
+		logger.WithError(err).Error("failed to revoke other user sessions")
+
 		return nil, UsecaseError{
-			ErrType: ErrTooManyRequests,
-			Message: fmt.Sprintf("please retry again after %d", int64(rateLimit.ResetAfter.Seconds())),
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
 		}
 	}
 
-	user, err := u.userRepo.FindByEmail(ctx, emailEnc)
-	switch err {
-	default:
-		logger.WithError(err).Error("failed to find user by email")
+	if err := u.emailTokenRepo.RevokeAllUserTokens(ctx, user.ID, string(ChangePasswordToken)); err != nil {
+		logger.WithError(err).Error("failed to revoke outstanding change password tokens")
 
 		return nil, UsecaseError{
 			ErrType: ErrInternal,
 			Message: ErrInternal.Error(),
 		}
-	case ErrRepoNotFound:
-		return nil, UsecaseError{
-			ErrType: ErrNotFound,
-			Message: ErrNotFound.Error(),
-		}
-	case nil:
-		break
 	}
 
-	if user.IsActive {
-		return nil, UsecaseError{
-			ErrType: ErrBadRequest,
-			Message: "this account has been activated",
-		}
+	// the password is already changed at this point, so failing to notify the user must not fail the request
+	if err := u.sendPasswordChangedEmail(ctx, user); err != nil {
+		logger.WithError(err).Error("failed to send password changed email")
 	}
 
-	token, err := u.sharedCryptor.CreateJWT(jwt.RegisteredClaims{
-		Issuer:    string(TokenIssuerSystem),
-		Subject:   string(SignupVerificationToken),
-		Audience:  []string{user.ID.String()},
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(config.SignupTokenExpiry())),
-	})
+	return &ChangePasswordOutput{
+		Message: "your password has been changed",
+	}, nil
+}
 
+func (u *AuthUsecase) sendPasswordChangedEmail(ctx context.Context, user *model.User) error {
+	email, err := u.sharedCryptor.Decrypt(user.Email)
 	if err != nil {
-		logger.WithError(err).Error("failed to create JWT token for resend signup verification")
-
-		return nil, UsecaseError{
-			ErrType: ErrInternal,
-			Message: ErrInternal.Error(),
-		}
+		return err
 	}
 
 	_, err = u.mailer.SendEmail(ctx, common.SendEmailInput{
 		ReceiverName:  user.Username,
-		ReceiverEmail: input.Email,
-		Subject:       "Verifikasi Akun",
-		HTMLContent:   accountVerificationEmailTemplate(token),
+		ReceiverEmail: email,
+		Subject:       "Kata Sandi Berhasil Diubah",
+		HTMLContent:   passwordChangedEmailTemplate(),
 	})
 
-	if err != nil {
-		logger.WithError(err).Error("failed to send verification email to user's mail")
-
-		return nil, UsecaseError{
-			ErrType: ErrInternal,
-			Message: ErrInternal.Error(),
-		}
-	}
-
-	return &ResendSignupVerificationOutput{
-		Message: "email confirmation sent",
-	}, nil
+	return err
 }
 
-// DeleteUserDataInput input
-type DeleteUserDataInput struct {
-	Email    string `validate:"required,email"`
-	Password string `validate:"required,min=8"`
+// AuthenticateAccessTokenInput input. Scope is the personal access token scope required by the endpoint,
+// leave it empty to only accept the login token
+type AuthenticateAccessTokenInput struct {
+	Token string
+	Scope model.PersonalAccessTokenScope
 }
 
-func (udi DeleteUserDataInput) validate() error {
-	return common.Validator.Struct(udi)
+// AuthenticateAccessTokenOutput output. SessionID is only set when authenticated using the login token,
+// while PersonalAccessTokenID is only set when authenticated using the personal access token.
+// OrganizationID is only set when the user currently belongs to an organization
+type AuthenticateAccessTokenOutput struct {
+	UserID                uuid.UUID
+	UserRole              model.Roles
+	SessionID             uuid.UUID
+	PersonalAccessTokenID uuid.UUID
+	OrganizationID        uuid.UUID
+	IsOrganizationAdmin   bool
 }
 
-// HandleDeleteUserData will handle the deletion of user's generated data
-func (u *AuthUsecase) HandleDeleteUserData(ctx context.Context, input DeleteUserDataInput) error {
-	user := model.GetUserFromCtx(ctx)
-	if user == nil {
-		return UsecaseError{
+// AuthenticateAccessToken will perform validation and checking for supplied jwt token or personal access token.
+// Based on the supplied params, you can determine whether to allow the request or not based on the
+// returned value. If the error is not nil, safe to assume that you should not let the request pass.
+func (u *AuthUsecase) AuthenticateAccessToken(ctx context.Context, input AuthenticateAccessTokenInput) (*AuthenticateAccessTokenOutput, error) {
+	if strings.HasPrefix(input.Token, model.PersonalAccessTokenPrefix) {
+		return u.authenticatePersonalAccessToken(ctx, input)
+	}
+
+	jwtToken, _, err := parseJWTToken(u.sharedCryptor, input.Token, parseJWTTokenInput{
+		expectedIssuer:      TokenIssuerSystem,
+		expectedSubject:     LoginToken,
+		expectedAudiences:   nil,
+		expectedAudienceLen: 1,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := jwtToken.Claims.(jwt.MapClaims)
+	if !(ok && jwtToken.Valid) {
+		return nil, UsecaseError{
 			ErrType: ErrUnauthorized,
-			Message: ErrUnauthorized.Error(),
+			Message: "invalid jwt payload or the token is invalid",
 		}
 	}
 
-	if user.Role != model.RolesParent {
-		return UsecaseError{
+	// no need to check the err here, because it's already checked
+	// when calling the parseJWTToken
+	audiences, _ := claims.GetAudience()
+	targetUserID := audiences[0]
+
+	userID, err := uuid.Parse(targetUserID)
+	if err != nil {
+		return nil, UsecaseError{
 			ErrType: ErrUnauthorized,
-			Message: ErrUnauthorized.Error(),
+			Message: "invalid value of user id",
 		}
 	}
 
-	if err := input.validate(); err != nil {
-		return UsecaseError{
-			ErrType: ErrBadRequest,
-			Message: err.Error(),
+	sid, ok := claims["sid"].(string)
+	if !ok {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "undefined session on auth token",
 		}
 	}
 
-	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
-		"user-id": user.ID,
-		"func":    "AuthUsecase.HandleDeleteUserData",
-	})
-
-	emailEnc, err := u.sharedCryptor.Encrypt(input.Email)
+	sessionID, err := uuid.Parse(sid)
 	if err != nil {
-		logger.WithError(err).Error("failed to encrypt email")
-
-		return UsecaseError{
-			ErrType: ErrInternal,
-			Message: ErrInternal.Error(),
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "invalid value of session id",
 		}
 	}
 
-	userAccount, err := u.userRepo.FindByEmail(ctx, emailEnc)
+	session, err := u.sessionRepo.FindByID(ctx, sessionID)
 	switch err {
 	default:
-		logger.WithError(err).Error("failed to find user by email")
+		logrus.WithContext(ctx).WithError(err).WithField("session-id", sessionID).Error("failed to find session by id")
 
-		return UsecaseError{
+		return nil, UsecaseError{
 			ErrType: ErrInternal,
 			Message: ErrInternal.Error(),
 		}
 	case ErrRepoNotFound:
-		return UsecaseError{
-			ErrType: ErrNotFound,
-			Message: ErrNotFound.Error(),
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "session not found",
 		}
 	case nil:
 		break
 	}
 
-	// to prevent user from deleting other user's account
-	if user.ID != userAccount.ID {
-		return UsecaseError{
-			ErrType: ErrForbidden,
-			Message: "this action is not allowed",
+	if session.UserID != userID || !session.IsActive() {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "session has been revoked or expired",
 		}
 	}
 
-	if !userAccount.IsActive {
-		return UsecaseError{
-			ErrType: ErrBadRequest,
-			Message: "this account has not been activated",
+	// only used to help the user to identify their devices, thus failure should not block the request
+	if !session.LastSeenAt.Valid || time.Since(session.LastSeenAt.Time) >= config.SessionLastSeenUpdateInterval() {
+		if err := u.sessionRepo.UpdateLastSeenAt(ctx, sessionID); err != nil {
+			logrus.WithContext(ctx).WithError(err).WithField("session-id", sessionID).Error("failed to update session last seen at")
 		}
 	}
 
-	pwDecoded, err := base64.StdEncoding.DecodeString(userAccount.Password)
-	if err != nil {
-		logger.WithError(err).Error("failed to decode base 64 string")
+	// the role and the organization membership are not taken from the token claims, because they can be changed by the
+	// administrator at any time and the stale claims must not keep granting the access
+	user, err := u.userRepo.FindByID(ctx, userID)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).WithField("user-id", userID).Error("failed to find user by id")
 
-		return UsecaseError{
+		return nil, UsecaseError{
 			ErrType: ErrInternal,
 			Message: ErrInternal.Error(),
 		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "user not found",
+		}
+	case nil:
+		break
 	}
 
-	err = u.sharedCryptor.CompareHash(pwDecoded, []byte(input.Password))
-	if err != nil {
-		return UsecaseError{
+	if !user.IsActive || user.IsLocked() {
+		return nil, UsecaseError{
 			ErrType: ErrUnauthorized,
-			Message: "invalid password",
+			Message: "this account is deactivated or locked",
 		}
 	}
 
-	return u.purgeAllUserData(ctx, user.ID, true)
+	return &AuthenticateAccessTokenOutput{
+		UserID:              userID,
+		UserRole:            user.Roles,
+		SessionID:           sessionID,
+		OrganizationID:      user.OrganizationID.UUID,
+		IsOrganizationAdmin: user.OrganizationID.Valid && user.IsOrganizationAdmin,
+	}, nil
+}
+
+// RefreshTokenInput input
+type RefreshTokenInput struct {
+	RefreshToken string `validate:"required"`
 }
 
-type parseJWTTokenInput struct {
-	expectedIssuer      JWTTokenIssuer
-	expectedSubject     JWTTokenType
-	expectedAudiences   *[]string
-	expectedAudienceLen int
+// Validate validate RefreshTokenInput
+func (rti RefreshTokenInput) Validate() error {
+	return common.Validator.Struct(rti)
 }
 
-func (u *AuthUsecase) parseJWTToken(token string, input parseJWTTokenInput) (*jwt.Token, jwt.MapClaims, error) {
-	jwtToken, err := u.sharedCryptor.ValidateJWT(token, common.ValidateJWTOpts{
-		Issuer:  string(input.expectedIssuer),
-		Subject: string(input.expectedSubject),
-	})
+// RefreshTokenOutput output
+type RefreshTokenOutput struct {
+	Token        string
+	RefreshToken string
+}
+
+// HandleRefreshToken exchange a valid refresh token for a new access token. The refresh token is rotated
+// on every use, and if an already rotated refresh token is presented, the whole session will be revoked
+// because it's likely the refresh token was stolen.
+func (u *AuthUsecase) HandleRefreshToken(ctx context.Context, input RefreshTokenInput) (*RefreshTokenOutput, error) {
+	logger := logrus.WithContext(ctx).WithField("func", "AuthUsecase.HandleRefreshToken")
+
+	if err := input.Validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	sessionID, secret, err := parseRefreshToken(input.RefreshToken)
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "invalid refresh token",
+		}
+	}
+
+	logger = logger.WithField("session-id", sessionID)
 
+	session, err := u.sessionRepo.FindByID(ctx, sessionID)
 	switch err {
 	default:
-		return nil, nil, UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: err.Error(),
+		logger.WithError(err).Error("failed to find session by id")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
 		}
-	case jwt.ErrTokenExpired:
-		return nil, nil, UsecaseError{
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
 			ErrType: ErrUnauthorized,
-			Message: "change password token has expired",
+			Message: "invalid refresh token",
 		}
 	case nil:
 		break
 	}
 
-	claims, ok := jwtToken.Claims.(jwt.MapClaims)
-	if !(ok && jwtToken.Valid) {
-		return nil, nil, UsecaseError{
+	if !session.IsActive() {
+		return nil, UsecaseError{
 			ErrType: ErrUnauthorized,
-			Message: "invalid token claims",
+			Message: "session has been revoked or expired",
 		}
 	}
 
-	issuer, err := claims.GetIssuer()
-	if err != nil || issuer != string(input.expectedIssuer) {
-		return nil, nil, UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: "incorrect token issuer used",
+	oldHash := common.HashToken(secret)
+	if subtle.ConstantTimeCompare([]byte(oldHash), []byte(session.RefreshTokenHash)) != 1 {
+		// the session id is valid but the secret is not the latest one. This means an old refresh token
+		// is being reused, so the session is revoked to protect the legitimate owner
+		logger.Warn("detected refresh token reuse, revoking session")
+
+		if err := u.sessionRepo.RevokeByID(ctx, session.ID); err != nil {
+			logger.WithError(err).Error("failed to revoke session after detecting refresh token reuse")
 		}
-	}
 
-	subject, err := claims.GetSubject()
-	if err != nil || subject != string(input.expectedSubject) {
-		return nil, nil, UsecaseError{
+		return nil, UsecaseError{
 			ErrType: ErrUnauthorized,
-			Message: "incorrect token subject used",
+			Message: "invalid refresh token",
 		}
 	}
 
-	audiences, err := claims.GetAudience()
-	if err != nil {
-		return nil, nil, UsecaseError{
+	user, err := u.userRepo.FindByID(ctx, session.UserID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by id")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
 			ErrType: ErrUnauthorized,
-			Message: "invalid audience on token used",
+			Message: "user not found",
 		}
+	case nil:
+		break
 	}
 
-	if len(audiences) != input.expectedAudienceLen {
-		return nil, nil, UsecaseError{
+	if !user.IsActive {
+		return nil, UsecaseError{
 			ErrType: ErrUnauthorized,
-			Message: "invalid number of audience",
+			Message: "this account is not active",
 		}
 	}
 
-	// early return if no expected audience is supplied
-	// some times, this checking is not required to performed here
-	// but may still be checked by the caller
-	if input.expectedAudiences == nil {
-		return jwtToken, claims, nil
-	}
-
-	expectedAudiences := *input.expectedAudiences
+	newSecret, err := common.GenerateSecureToken(refreshTokenSecretLength)
+	if err != nil {
+		logger.WithError(err).Error("failed to generate refresh token")
 
-	if !reflect.DeepEqual(audiences, expectedAudiences) {
-		return nil, nil, UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: "unexpected audiences value",
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
 		}
 	}
 
-	return jwtToken, claims, nil
-}
-
-func (u *AuthUsecase) purgeAllUserData(ctx context.Context, userID uuid.UUID, hardDelete bool) error {
-	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
-		"user-id": userID,
-		"func":    "AuthUsecase.purgeAllUserData",
+	_, err = u.sessionRepo.RotateRefreshToken(ctx, RepoRotateRefreshTokenInput{
+		SessionID:           session.ID,
+		OldRefreshTokenHash: oldHash,
+		NewRefreshTokenHash: common.HashToken(newSecret),
+		ExpiresAt:           time.Now().Add(config.RefreshTokenExpiry()),
 	})
 
-	txCtrl := u.transactionControllerFactory.New()
-	tx := txCtrl.Begin()
-
-	err := u.resultRepo.DeleteAllUserResults(ctx, RepoDeleteAllUserResultsInput{
-		UserID:     userID,
-		HardDelete: hardDelete,
-	}, tx)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to rotate refresh token")
 
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		// another request has rotated the refresh token first
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "invalid refresh token",
+		}
+	case nil:
+		break
+	}
+
+	accessToken, err := u.createAccessToken(user, session.ID)
+	if err != nil {
+		logger.WithError(err).Error("failed to generate access token")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return &RefreshTokenOutput{
+		Token:        accessToken,
+		RefreshToken: formatRefreshToken(session.ID, newSecret),
+	}, nil
+}
+
+// HandleLogout revoke the session used by the requester. Both the access token and refresh token
+// from that session will no longer be usable
+func (u *AuthUsecase) HandleLogout(ctx context.Context) error {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	if err := u.sessionRepo.RevokeByID(ctx, requester.SessionID); err != nil {
+		logrus.WithContext(ctx).WithError(err).WithField("session-id", requester.SessionID).Error("failed to revoke session")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return nil
+}
+
+// HandleLogoutAll revoke all the requester's active sessions, logging them out from every device
+func (u *AuthUsecase) HandleLogoutAll(ctx context.Context) error {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	if err := u.sessionRepo.RevokeAllUserSessions(ctx, requester.ID); err != nil {
+		logrus.WithContext(ctx).WithError(err).WithField("user-id", requester.ID).Error("failed to revoke all user sessions")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return nil
+}
+
+// ResendSignupVerificationInput input
+type ResendSignupVerificationInput struct {
+	Email string `validate:"required,email"`
+}
+
+func (rsvi ResendSignupVerificationInput) validate() error {
+	return common.Validator.Struct(rsvi)
+}
+
+// ResendSignupVerificationOutput output
+type ResendSignupVerificationOutput struct {
+	Message string `json:"message"`
+}
+
+// HandleResendSignupVerification will resend the email verification to the user's email. Unknown and already
+// activated email will get the same response, only the email owner will be notified about the actual state
+func (u *AuthUsecase) HandleResendSignupVerification(ctx context.Context, input ResendSignupVerificationInput) (
+	*ResendSignupVerificationOutput,
+	error,
+) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"email": input.Email,
+		"func":  "HandleResendSignupVerification",
+	})
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	emailLookup, err := newEmailLookup(u.sharedCryptor, input.Email)
+	if err != nil {
+		logger.WithError(err).Error("failed to encrypt email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	resendLimit := redis_rate.Limit{
+		Rate:   1,
+		Burst:  1,
+		Period: config.ResendSignupVerificationLimiterDuration(),
+	}
+
+	rateLimit, err := u.rateLimiter.Allow(ctx, emailLookup.BlindIndex, resendLimit)
+	if err != nil {
+		logger.WithError(err).Error("failed to perform rate limiter ops")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if rateLimit.Allowed == 0 {
+		return nil, UsecaseError{
+			ErrType: ErrTooManyRequests,
+			Message: fmt.Sprintf("please retry again after %d", int64(rateLimit.ResetAfter.Seconds())),
+		}
+	}
+
+	user, err := u.userRepo.FindByEmail(ctx, emailLookup)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		if err := u.sendAccountNoticeEmail(ctx, input.Email, "Verifikasi Akun", noActiveAccountEmailTemplate()); err != nil {
+			logger.WithError(err).Error("failed to send no active account email")
+
+			return nil, UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		}
+
+		return &ResendSignupVerificationOutput{
+			Message: "email confirmation sent",
+		}, nil
+	case nil:
+		break
+	}
+
+	if user.IsActive || user.IsDeletionScheduled() {
+		if err := u.sendAccountNoticeEmail(ctx, input.Email, "Verifikasi Akun", accountAlreadyRegisteredEmailTemplate()); err != nil {
+			logger.WithError(err).Error("failed to send account already registered email")
+
+			return nil, UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		}
+
+		return &ResendSignupVerificationOutput{
+			Message: "email confirmation sent",
+		}, nil
+	}
+
+	// only the latest verification email should be usable
+	if err := u.emailTokenRepo.RevokeAllUserTokens(ctx, user.ID, string(SignupVerificationToken)); err != nil {
+		logger.WithError(err).Error("failed to revoke previous signup verification tokens")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	token, err := u.issueEmailToken(ctx, user.ID, SignupVerificationToken, config.SignupTokenExpiry())
+	if err != nil {
+		logger.WithError(err).Error("failed to create JWT token for resend signup verification")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	_, err = u.mailer.SendEmail(ctx, common.SendEmailInput{
+		ReceiverName:  user.Username,
+		ReceiverEmail: input.Email,
+		Subject:       "Verifikasi Akun",
+		HTMLContent:   accountVerificationEmailTemplate(token),
+	})
+
+	if err != nil {
+		logger.WithError(err).Error("failed to send verification email to user's mail")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return &ResendSignupVerificationOutput{
+		Message: "email confirmation sent",
+	}, nil
+}
+
+// DeleteUserDataInput input
+type DeleteUserDataInput struct {
+	Email    string `validate:"required,email"`
+	Password string `validate:"required,min=8"`
+}
+
+func (udi DeleteUserDataInput) validate() error {
+	return common.Validator.Struct(udi)
+}
+
+// HandleDeleteUserData will schedule the deletion of user's account and generated data. The account is deactivated
+// right away and permanently deleted after the grace period, unless restored using the link sent to the user's email
+func (u *AuthUsecase) HandleDeleteUserData(ctx context.Context, input DeleteUserDataInput) error {
+	user := model.GetUserFromCtx(ctx)
+	if err := Authorize(user, ActionDeleteAccount, RelationNone); err != nil {
+		return err
+	}
+
+	if err := input.validate(); err != nil {
+		return UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"user-id": user.ID,
+		"func":    "AuthUsecase.HandleDeleteUserData",
+	})
+
+	emailLookup, err := newEmailLookup(u.sharedCryptor, input.Email)
+	if err != nil {
+		logger.WithError(err).Error("failed to encrypt email")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	userAccount, err := u.userRepo.FindByEmail(ctx, emailLookup)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by email")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	// to prevent user from deleting other user's account
+	if user.ID != userAccount.ID {
+		return UsecaseError{
+			ErrType: ErrForbidden,
+			Message: "this action is not allowed",
+		}
+	}
+
+	if !userAccount.IsActive {
+		return UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "this account has not been activated",
+		}
+	}
+
+	match, err := u.verifyUserPassword(ctx, userAccount, input.Password)
+	if err != nil {
+		return err
+	}
+
+	if !match {
+		return UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "invalid password",
+		}
+	}
+
+	if err := u.scheduleAccountDeletion(ctx, userAccount); err != nil {
+		return err
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionDeleteAccount,
+		TargetType: model.AuditTargetUser,
+		TargetID:   user.ID.String(),
+	})
+
+	return nil
+}
+
+// AdminForceResetPasswordInput input
+type AdminForceResetPasswordInput struct {
+	UserID uuid.UUID `validate:"required"`
+}
+
+func (afrpi AdminForceResetPasswordInput) validate() error {
+	return common.Validator.Struct(afrpi)
+}
+
+// AdminForceResetPasswordOutput output
+type AdminForceResetPasswordOutput struct {
+	Message string
+}
+
+// HandleAdminForceResetPassword allow administrator to force a password reset on other user's account.
+// The current password will be replaced with a random one, all the user's sessions will be revoked
+// and a reset password email will be sent to the user so they can set a new password
+func (u *AuthUsecase) HandleAdminForceResetPassword(
+	ctx context.Context,
+	input AdminForceResetPasswordInput,
+) (*AdminForceResetPasswordOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if err := Authorize(requester, ActionManageUser, RelationNone); err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"user-id":      input.UserID,
+		"requester-id": requester.ID,
+		"func":         "AuthUsecase.HandleAdminForceResetPassword",
+	})
+
+	user, err := u.userRepo.FindByID(ctx, input.UserID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by id")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	email, err := u.sharedCryptor.Decrypt(user.Email)
+	if err != nil {
+		logger.WithError(err).Error("failed to decrypt user email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	// the old password must no longer be usable, so it's replaced by a random value nobody knows
+	randomPassword, err := common.GenerateSecureToken(refreshTokenSecretLength)
+	if err != nil {
+		logger.WithError(err).Error("failed to generate random password")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	randomPasswordHashed, err := u.sharedCryptor.Hash([]byte(randomPassword))
+	if err != nil {
+		logger.WithError(err).Error("failed to hash random password")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if _, err := u.userRepo.Update(ctx, user.ID, RepoUpdateUserInput{Password: randomPasswordHashed}); err != nil {
+		logger.WithError(err).Error("failed to update user password")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if err := u.sessionRepo.RevokeAllUserSessions(ctx, user.ID); err != nil {
+		logger.WithError(err).Error("failed to revoke all user sessions")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if err := u.emailTokenRepo.RevokeAllUserTokens(ctx, user.ID, string(ChangePasswordToken)); err != nil {
+		logger.WithError(err).Error("failed to revoke previous change password tokens")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	changePassToken, err := u.issueEmailToken(ctx, user.ID, ChangePasswordToken, config.ChangePasswordTokenExpiry())
+	if err != nil {
+		logger.WithError(err).Error("failed to create JWT token for change password")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	_, err = u.mailer.SendEmail(ctx, common.SendEmailInput{
+		ReceiverName:  user.Username,
+		ReceiverEmail: email,
+		Subject:       "Kata Sandi Anda Telah Direset",
+		HTMLContent:   forcedResetPasswordEmailTemplate(changePassToken),
+	})
+
+	if err != nil {
+		logger.WithError(err).Error("failed to send reset password email to user's mail")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return &AdminForceResetPasswordOutput{
+		Message: "ok",
+	}, nil
+}
+
+// InviteTherapistInput input
+type InviteTherapistInput struct {
+	Email string `validate:"required,email"`
+}
+
+func (iti InviteTherapistInput) validate() error {
+	return common.Validator.Struct(iti)
+}
+
+// InviteTherapistOutput output
+type InviteTherapistOutput struct {
+	Message string
+}
+
+// HandleInviteTherapist allow administrator to invite a therapist by email. The invitation is a signed
+// single use token bound to the invited email, which later can be redeemed to create a therapist account
+func (u *AuthUsecase) HandleInviteTherapist(ctx context.Context, input InviteTherapistInput) (*InviteTherapistOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if err := Authorize(requester, ActionManageUser, RelationNone); err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"requester-id": requester.ID,
+		"func":         "AuthUsecase.HandleInviteTherapist",
+	})
+
+	emailEncrypted, err := u.sharedCryptor.Encrypt(input.Email)
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: "encryption process failed",
+		}
+	}
+
+	emailLookup, err := newEmailLookup(u.sharedCryptor, input.Email)
 	if err != nil {
-		logger.WithError(err).Error("failed to delete user's results")
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: "encryption process failed",
+		}
+	}
 
-		if err := txCtrl.Rollback(); err != nil {
-			logger.WithError(err).Error("failed to rollback transaction")
+	_, err = u.userRepo.FindByEmail(ctx, emailLookup)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case nil:
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "this email has been used by another account",
 		}
+	case ErrRepoNotFound:
+		break
+	}
 
-		return UsecaseError{
+	// the account does not exist yet, so the invitation is bound to the encrypted email instead
+	token, err := signEmailToken(
+		ctx, u.sharedCryptor, u.emailTokenRepo, uuid.Nil, []string{emailEncrypted}, TherapistInvitation, config.TherapistInvitationTokenExpiry(),
+	)
+	if err != nil {
+		logger.WithError(err).Error("failed to create JWT token for therapist invitation")
+
+		return nil, UsecaseError{
 			ErrType: ErrInternal,
 			Message: ErrInternal.Error(),
 		}
 	}
 
-	err = u.childRepo.DeleteAllUserChildren(ctx, RepoDeleteAllUserChildrenInput{
-		UserID:     userID,
-		HardDelete: hardDelete,
-	}, tx)
+	_, err = u.mailer.SendEmail(ctx, common.SendEmailInput{
+		ReceiverName:  input.Email,
+		ReceiverEmail: input.Email,
+		Subject:       "Undangan Bergabung Sebagai Terapis",
+		HTMLContent:   therapistInvitationEmailTemplate(token),
+	})
 
 	if err != nil {
-		logger.WithError(err).Error("failed to delete user's children")
+		logger.WithError(err).Error("failed to send therapist invitation email")
 
-		if err := txCtrl.Rollback(); err != nil {
-			logger.WithError(err).Error("failed to rollback transaction")
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
 		}
+	}
 
-		return UsecaseError{
+	return &InviteTherapistOutput{
+		Message: "invitation sent",
+	}, nil
+}
+
+// RedeemTherapistInvitationInput input
+type RedeemTherapistInvitationInput struct {
+	InvitationToken string  `validate:"required"`
+	Password        string  `validate:"required,min=8"`
+	Username        string  `validate:"required"`
+	PhoneNumber     *string `validate:"omitempty,e164"`
+	Address         *string `validate:"omitempty,max=256"`
+}
+
+func (rtii *RedeemTherapistInvitationInput) validate() error {
+	if rtii.PhoneNumber != nil {
+		trimmed := strings.ReplaceAll(*rtii.PhoneNumber, " ", "")
+		rtii.PhoneNumber = &trimmed
+	}
+
+	if rtii.Address != nil {
+		addr := strings.TrimSpace(*rtii.Address)
+		rtii.Address = &addr
+	}
+
+	return common.Validator.Struct(rtii)
+}
+
+// RedeemTherapistInvitationOutput output
+type RedeemTherapistInvitationOutput struct {
+	Message string
+}
+
+// HandleRedeemTherapistInvitation redeem the therapist invitation token and create an active therapist
+// account using the invited email and the password chosen by the therapist
+func (u *AuthUsecase) HandleRedeemTherapistInvitation(
+	ctx context.Context,
+	input RedeemTherapistInvitationInput,
+) (*RedeemTherapistInvitationOutput, error) {
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithField("func", "AuthUsecase.HandleRedeemTherapistInvitation")
+
+	_, claims, err := parseJWTToken(u.sharedCryptor, input.InvitationToken, parseJWTTokenInput{
+		expectedIssuer:      TokenIssuerSystem,
+		expectedSubject:     TherapistInvitation,
+		expectedAudiences:   nil,
+		expectedAudienceLen: 1,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// no need to check the err here, because it's already checked
+	// when calling the parseJWTToken
+	audiences, _ := claims.GetAudience()
+	emailEncrypted := audiences[0]
+
+	email, err := u.sharedCryptor.Decrypt(emailEncrypted)
+	if err != nil {
+		logger.WithError(err).Error("failed to decrypt invited email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	emailLookup, err := newEmailLookup(u.sharedCryptor, email)
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: "encryption process failed",
+		}
+	}
+
+	_, err = u.userRepo.FindByEmail(ctx, emailLookup)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by email")
+
+		return nil, UsecaseError{
 			ErrType: ErrInternal,
 			Message: ErrInternal.Error(),
 		}
+	case nil:
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "your email has been used by another account",
+		}
+	case ErrRepoNotFound:
+		break
+	}
+
+	hashedPassword, err := u.sharedCryptor.Hash([]byte(input.Password))
+	if err != nil {
+		logger.WithError(err).Error("failed to perform hasing password")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	_, encPhone, encAddress, err := u.encryptUserData(
+		model.User{
+			Email:       "",
+			PhoneNumber: sqlNullFromPtr(input.PhoneNumber),
+			Address:     sqlNullFromPtr(input.Address),
+		},
+	)
+
+	if err != nil {
+		return nil, UsecaseError{ErrType: ErrInternal, Message: "encryption process failed"}
+	}
+
+	txCtrl := u.transactionControllerFactory.New()
+	tx := txCtrl.Begin()
+
+	if err := consumeEmailToken(ctx, u.emailTokenRepo, claims, uuid.Nil, TherapistInvitation, tx); err != nil {
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return nil, err
 	}
 
-	err = u.userRepo.DeleteByID(ctx, RepoDeleteUserByIDInput{
-		UserID:     userID,
-		HardDelete: hardDelete,
+	// the invitation proves the therapist owns the email, thus the account is activated right away
+	_, err = u.userRepo.Create(ctx, RepoCreateUserInput{
+		Email:           emailEncrypted,
+		EmailBlindIndex: emailLookup.BlindIndex,
+		Password:        hashedPassword,
+		Username:        input.Username,
+		IsActive:        true,
+		Roles:           model.RolesTherapist,
+		PhoneNumber:     encPhone,
+		Address:         encAddress,
 	}, tx)
 
 	if err != nil {
-		logger.WithError(err).Error("failed to delete user")
+		logger.WithError(err).Error("failed to create therapist account to database")
 
 		if err := txCtrl.Rollback(); err != nil {
 			logger.WithError(err).Error("failed to rollback transaction")
 		}
 
-		return UsecaseError{
+		return nil, UsecaseError{
 			ErrType: ErrInternal,
 			Message: ErrInternal.Error(),
 		}
@@ -1275,92 +2127,297 @@ func (u *AuthUsecase) purgeAllUserData(ctx context.Context, userID uuid.UUID, ha
 	if err := txCtrl.Commit(); err != nil {
 		logger.WithError(err).Error("failed to commit transaction")
 
-		return UsecaseError{
+		return nil, UsecaseError{
 			ErrType: ErrInternal,
 			Message: ErrInternal.Error(),
 		}
 	}
 
-	return nil
+	return &RedeemTherapistInvitationOutput{
+		Message: "your therapist account has been created",
+	}, nil
+}
+
+// refreshTokenSecretLength is the number of random bytes used as the refresh token secret
+const refreshTokenSecretLength = 32
+
+// issueLoginSession create a new session for the user and return the access token and the refresh token.
+// The client used to login is recorded to the session, and the user is alerted when it was never used before
+func (u *AuthUsecase) issueLoginSession(ctx context.Context, user *model.User) (string, string, error) {
+	secret, err := common.GenerateSecureToken(refreshTokenSecretLength)
+	if err != nil {
+		return "", "", err
+	}
+
+	client := model.GetClientInfoFromCtx(ctx)
+	isNewClient := u.isNewLoginClient(ctx, user.ID, client)
+
+	session, err := u.sessionRepo.Create(ctx, RepoCreateSessionInput{
+		ID:               uuid.New(),
+		UserID:           user.ID,
+		RefreshTokenHash: common.HashToken(secret),
+		IPAddress:        client.IPAddress,
+		UserAgent:        client.UserAgent,
+		ExpiresAt:        time.Now().Add(config.RefreshTokenExpiry()),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	if isNewClient {
+		// the login itself is already successful, so failing to alert the user must not fail the request
+		if err := u.sendNewLoginAlertEmail(ctx, user, client); err != nil {
+			logrus.WithContext(ctx).WithError(err).WithField("user-id", user.ID).Error("failed to send new login alert email")
+		}
+	}
+
+	accessToken, err := u.createAccessToken(user, session.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	return accessToken, formatRefreshToken(session.ID, secret), nil
+}
+
+func (u *AuthUsecase) createAccessToken(user *model.User, sessionID uuid.UUID) (string, error) {
+	claims := model.LoginTokenClaims{
+		Role:      user.Roles,
+		SessionID: sessionID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:   string(TokenIssuerSystem),
+			Subject:  string(LoginToken),
+			Audience: []string{user.ID.String()},
+			ExpiresAt: jwt.NewNumericDate(
+				time.Now().Add(config.LoginTokenExpiry()),
+			),
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	if user.OrganizationID.Valid {
+		claims.OrganizationID = user.OrganizationID.UUID.String()
+		claims.IsOrganizationAdmin = user.IsOrganizationAdmin
+	}
+
+	return u.sharedCryptor.CreateJWT(claims)
+}
+
+// formatRefreshToken refresh token is formatted as <session id>.<secret>. Only the hash of the secret is stored
+func formatRefreshToken(sessionID uuid.UUID, secret string) string {
+	return fmt.Sprintf("%s.%s", sessionID.String(), secret)
+}
+
+func parseRefreshToken(token string) (uuid.UUID, string, error) {
+	sid, secret, found := strings.Cut(token, ".")
+	if !found || secret == "" {
+		return uuid.Nil, "", errors.New("malformed refresh token")
+	}
+
+	sessionID, err := uuid.Parse(sid)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	return sessionID, secret, nil
+}
+
+// issueEmailToken record a new single use token bound to the user to the email token ledger and return the signed jwt
+func (u *AuthUsecase) issueEmailToken(
+	ctx context.Context,
+	userID uuid.UUID,
+	subject JWTTokenType,
+	expiry time.Duration,
+	txController ...any,
+) (string, error) {
+	return signEmailToken(
+		ctx, u.sharedCryptor, u.emailTokenRepo, userID, []string{userID.String()}, subject, expiry, txController...,
+	)
+}
+
+// sendAccountNoticeEmail send an informational email without any token, used to tell the email owner the real
+// outcome of a request whose response is kept uniform to prevent account enumeration
+func (u *AuthUsecase) sendAccountNoticeEmail(ctx context.Context, receiverEmail, subject, htmlContent string) error {
+	_, err := u.mailer.SendEmail(ctx, common.SendEmailInput{
+		ReceiverName:  receiverEmail,
+		ReceiverEmail: receiverEmail,
+		Subject:       subject,
+		HTMLContent:   htmlContent,
+	})
+
+	return err
+}
+
+//nolint:lll
+func resetPasswordEmailTemplate(token string) string {
+	return emailLayout(emailLayoutInput{
+		Title: "Permintaan Reset Kata Sandi",
+		Content: []string{
+			emailParagraph("Baru saja sistem menerima permintaan reset kata sandi terhadap akun Anda. Apabila Anda merasa melakukannya, silahkan klik tombol di bawah ini:"),
+			emailButton("Reset Kata Sandi", config.ServerResetPasswordBaseURL(), model.ChangePasswordTokenQuery, token),
+		},
+		Footer: "Jika Anda tidak merasa melakukannya, silahkan abaikan email ini dan akun anda tidak akan diganti kata sandinya",
+	})
 }
 
 //nolint:lll
 func accountVerificationEmailTemplate(token string) string {
-	return fmt.Sprintf(`
-		<!DOCTYPE html>
-		<html>
-		<head>
-			<style>
-				/* General styles */
-				body {
-					font-family: Arial, sans-serif;
-					margin: 0;
-					padding: 0;
-					background-color: #f6f6f6;
-					color: #333;
-				}
-				.email-container {
-					max-width: 600px;
-					margin: 20px auto;
-					background-color: #ffffff;
-					border: 1px solid #ddd;
-					border-radius: 8px;
-					overflow: hidden;
-				}
-				.header {
-					background-color: #4CAF50;
-					color: white;
-					padding: 20px;
-					text-align: center;
-				}
-				.content {
-					padding: 20px;
-				}
-				.content p {
-					margin: 0 0 15px;
-					line-height: 1.6;
-				}
-				.btn-container {
-					text-align: center;
-					margin: 20px 0;
-				}
-				.btn {
-					display: inline-block;
-					background-color: #4CAF50;
-					color: white;
-					text-decoration: none;
-					padding: 10px 20px;
-					font-size: 16px;
-					border-radius: 5px;
-				}
-				.btn:hover {
-					background-color: #45a049;
-				}
-				.footer {
-					background-color: #f1f1f1;
-					text-align: center;
-					padding: 10px;
-					font-size: 12px;
-					color: #666;
-				}
-			</style>
-		</head>
-		<body>
-			<div class="email-container">
-				<div class="header">
-					<h1>Konfirmasi Akun</h1>
-				</div>
-				<div class="content">
-					<p>Terimakasih telah mendaftar pada layanan Autism Treatment Evaluation Checklist (ATEC). Untuk mengaktifkan akun Anda, silakan klik tombol berikut:</p>
-					<div class="btn-container">
-						<a href="%s?validation_token=%s" class="btn">Aktifkan Akun</a>
-					</div>
-				</div>
-				<div class="footer">
-					<p>Jika Anda tidak merasa mendaftar, abaikan email ini.</p>
-				</div>
-			</div>
-		</body>
-		</html>
-		`, config.ServerAccountVerificationBaseURL(), token)
+	return emailLayout(emailLayoutInput{
+		Title: "Konfirmasi Akun",
+		Content: []string{
+			emailParagraph("Terimakasih telah mendaftar pada layanan Autism Treatment Evaluation Checklist (ATEC). Untuk mengaktifkan akun Anda, silakan klik tombol berikut:"),
+			emailButton("Aktifkan Akun", config.ServerAccountVerificationBaseURL(), "validation_token", token),
+		},
+		Footer: "Jika Anda tidak merasa mendaftar, abaikan email ini.",
+	})
+}
+
+//nolint:lll
+func forcedResetPasswordEmailTemplate(token string) string {
+	return emailLayout(emailLayoutInput{
+		Title: "Kata Sandi Anda Telah Direset",
+		Content: []string{
+			emailParagraph("Administrator telah mereset kata sandi akun Anda dan seluruh sesi login Anda telah diakhiri. Silahkan klik tombol di bawah ini untuk membuat kata sandi baru:"),
+			emailButton("Buat Kata Sandi Baru", config.ServerResetPasswordBaseURL(), model.ChangePasswordTokenQuery, token),
+		},
+		Footer: "Jika Anda memiliki pertanyaan, silahkan hubungi administrator.",
+	})
+}
+
+//nolint:lll
+func therapistInvitationEmailTemplate(token string) string {
+	return emailLayout(emailLayoutInput{
+		Title: "Undangan Terapis",
+		Content: []string{
+			emailParagraph("Anda diundang untuk bergabung sebagai terapis pada layanan Autism Treatment Evaluation Checklist (ATEC). Untuk membuat akun Anda, silakan klik tombol berikut:"),
+			emailButton("Buat Akun Terapis", config.ServerTherapistInvitationBaseURL(), model.TherapistInvitationTokenQuery, token),
+		},
+		Footer: "Undangan ini hanya dapat digunakan satu kali. Jika Anda tidak merasa diundang, abaikan email ini.",
+	})
+}
+
+//nolint:lll
+func accountLockedEmailTemplate(token string) string {
+	return emailLayout(emailLayoutInput{
+		Title: "Akun Dikunci Sementara",
+		Content: []string{
+			emailParagraph("Kami mendeteksi terlalu banyak percobaan masuk yang gagal pada akun Autism Treatment Evaluation Checklist (ATEC) Anda, sehingga akun Anda dikunci sementara. Jika itu adalah Anda, silakan klik tombol berikut untuk membuka kunci akun Anda:"),
+			emailButton("Buka Kunci Akun", config.ServerAccountUnlockBaseURL(), model.AccountUnlockTokenQuery, token),
+			emailParagraph("Jika Anda tidak merasa mencoba masuk, kami sarankan untuk segera mengganti kata sandi Anda."),
+		},
+		Footer: "Jika Anda memiliki pertanyaan, silahkan hubungi administrator.",
+	})
+}
+
+//nolint:lll
+func accountAlreadyRegisteredEmailTemplate() string {
+	return emailLayout(emailLayoutInput{
+		Title: "Akun Sudah Terdaftar",
+		Content: []string{
+			emailParagraph("Kami menerima permintaan pendaftaran atau verifikasi akun Autism Treatment Evaluation Checklist (ATEC) menggunakan email ini. Email ini sudah terdaftar, sehingga Anda dapat langsung masuk menggunakan akun tersebut."),
+			emailParagraph("Jika Anda lupa kata sandi, silakan gunakan fitur lupa kata sandi untuk mengatur ulang kata sandi Anda."),
+		},
+		Footer: "Jika Anda tidak merasa melakukannya, silahkan abaikan email ini.",
+	})
+}
+
+//nolint:lll
+func noActiveAccountEmailTemplate() string {
+	return emailLayout(emailLayoutInput{
+		Title: "Tidak Ada Akun Aktif",
+		Content: []string{
+			emailParagraph("Kami menerima permintaan terkait akun Autism Treatment Evaluation Checklist (ATEC) menggunakan email ini, namun tidak ada akun aktif yang terdaftar dengan email ini."),
+			emailParagraph("Jika Anda sudah mendaftar namun belum melakukan verifikasi, silakan gunakan fitur kirim ulang email verifikasi. Jika akun Anda dinonaktifkan, silahkan hubungi administrator."),
+		},
+		Footer: "Jika Anda tidak merasa melakukannya, silahkan abaikan email ini.",
+	})
+}
+
+//nolint:lll
+func passwordChangedEmailTemplate() string {
+	return emailLayout(emailLayoutInput{
+		Title: "Kata Sandi Berhasil Diubah",
+		Content: []string{
+			emailParagraph("Kata sandi akun Autism Treatment Evaluation Checklist (ATEC) Anda baru saja diubah. Demi keamanan, Anda telah dikeluarkan dari semua perangkat lain yang sebelumnya menggunakan akun ini."),
+			emailParagraph("Jika Anda tidak merasa mengubah kata sandi, segera gunakan fitur lupa kata sandi untuk mengatur ulang kata sandi Anda dan hubungi administrator."),
+		},
+		Footer: "Email ini dikirim secara otomatis, mohon untuk tidak membalas email ini.",
+	})
+}
+
+//nolint:lll
+func changeEmailConfirmationEmailTemplate(token string) string {
+	return emailLayout(emailLayoutInput{
+		Title: "Konfirmasi Perubahan Email",
+		Content: []string{
+			emailParagraph("Kami menerima permintaan untuk menggunakan email ini pada akun Autism Treatment Evaluation Checklist (ATEC). Untuk menyelesaikan perubahan email, silakan klik tombol berikut:"),
+			emailButton("Konfirmasi Email", config.ServerChangeEmailBaseURL(), model.ChangeEmailTokenQuery, token),
+			emailParagraph("Tautan ini hanya berlaku untuk sementara waktu. Email akun tidak akan berubah sebelum Anda melakukan konfirmasi."),
+		},
+		Footer: "Jika Anda tidak merasa melakukannya, silahkan abaikan email ini.",
+	})
+}
+
+//nolint:lll
+func changeEmailNoticeEmailTemplate(newEmail string) string {
+	return emailLayout(emailLayoutInput{
+		Title: "Permintaan Perubahan Email",
+		Content: []string{
+			emailParagraph(fmt.Sprintf("Kami menerima permintaan untuk mengubah email akun Autism Treatment Evaluation Checklist (ATEC) Anda menjadi <b>%s</b>. Perubahan baru akan diterapkan setelah dikonfirmasi melalui tautan yang dikirimkan ke email baru tersebut.", html.EscapeString(newEmail))),
+			emailParagraph("Jika Anda tidak merasa melakukannya, segera ganti kata sandi Anda dan hubungi administrator."),
+		},
+		Footer: "Jika Anda memiliki pertanyaan, silahkan hubungi administrator.",
+	})
+}
+
+//nolint:lll
+func passwordlessLoginEmailTemplate(code, magicLinkToken string) string {
+	return emailLayout(emailLayoutInput{
+		Title: "Login Tanpa Kata Sandi",
+		Content: []string{
+			emailParagraph("Kami menerima permintaan untuk masuk ke akun Autism Treatment Evaluation Checklist (ATEC) Anda tanpa kata sandi. Silakan masukkan kode berikut:"),
+			emailCode(code),
+			emailParagraph("Atau klik tombol berikut untuk langsung masuk:"),
+			emailButton("Masuk", config.ServerPasswordlessLoginBaseURL(), model.MagicLinkTokenQuery, magicLinkToken),
+			emailParagraph("Kode dan tautan ini hanya berlaku untuk sementara waktu dan hanya dapat digunakan satu kali. Jangan berikan kode ini kepada siapa pun."),
+		},
+		Footer: "Jika Anda tidak merasa melakukannya, silahkan abaikan email ini.",
+	})
+}
+
+//nolint:lll
+func passwordlessLoginUnavailableEmailTemplate() string {
+	return emailLayout(emailLayoutInput{
+		Title: "Login Tanpa Kata Sandi Tidak Tersedia",
+		Content: []string{
+			emailParagraph("Kami menerima permintaan untuk masuk ke akun Autism Treatment Evaluation Checklist (ATEC) tanpa kata sandi menggunakan email ini, namun fitur tersebut tidak dapat digunakan untuk akun Anda saat ini."),
+			emailParagraph("Silakan masuk menggunakan email dan kata sandi Anda. Jika Anda mengalami kendala, silahkan hubungi administrator."),
+		},
+		Footer: "Jika Anda tidak merasa melakukannya, silahkan abaikan email ini.",
+	})
+}
+
+//nolint:lll
+func newLoginAlertEmailTemplate(ipAddress, userAgent string, loginAt time.Time) string {
+	return emailLayout(emailLayoutInput{
+		Title: "Login dari Perangkat Baru",
+		Content: []string{
+			emailParagraph("Akun Autism Treatment Evaluation Checklist (ATEC) Anda baru saja digunakan untuk login dari perangkat atau alamat IP yang belum pernah digunakan sebelumnya."),
+			emailParagraph(fmt.Sprintf("Waktu: <b>%s</b><br>Alamat IP: <b>%s</b><br>Perangkat: <b>%s</b>", loginAt.Format(time.RFC1123), html.EscapeString(ipAddress), html.EscapeString(userAgent))),
+			emailParagraph("Jika Anda tidak merasa melakukannya, segera ganti kata sandi Anda, keluarkan sesi tersebut dari daftar sesi aktif, dan hubungi administrator."),
+		},
+		Footer: "Jika Anda memiliki pertanyaan, silahkan hubungi administrator.",
+	})
+}
+
+//nolint:lll
+func accountDeletionScheduledEmailTemplate(token string, purgeAt time.Time) string {
+	return emailLayout(emailLayoutInput{
+		Title: "Penghapusan Akun Dijadwalkan",
+		Content: []string{
+			emailParagraph(fmt.Sprintf("Akun Autism Treatment Evaluation Checklist (ATEC) Anda telah dinonaktifkan dan dijadwalkan untuk dihapus. Seluruh data akun, termasuk data anak dan hasil kuesioner, akan dihapus secara permanen pada <b>%s</b>.", purgeAt.Format(time.RFC1123))),
+			emailParagraph("Jika Anda berubah pikiran, Anda masih dapat memulihkan akun beserta seluruh datanya sebelum waktu tersebut dengan mengklik tombol berikut:"),
+			emailButton("Pulihkan Akun", config.ServerAccountRestoreBaseURL(), model.AccountRestoreTokenQuery, token),
+		},
+		Footer: "Jika Anda tidak merasa melakukannya, segera pulihkan akun Anda dan hubungi administrator.",
+	})
 }
@@ -2,7 +2,10 @@ package usecase_test
 
 import (
 	"context"
+	"crypto/ed25519"
 	"database/sql"
+	"encoding/base64"
+	"strings"
 	"testing"
 	"time"
 
@@ -254,15 +257,24 @@ func TestAuthUsecase_HandleLogin(t *testing.T) {
 	ctx := context.Background()
 	mockUserRepo := mockUsecase.NewUserRepository(t)
 	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
-	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, nil, nil)
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+	mockRateLimiter := mockUsecase.NewRateLimiter(t)
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, nil, mockRateLimiter, mockSessionRepo, nil, nil, nil, nil, nil, nil)
 	validSampleEmail := "valid@sample.email"
 	validSamplePassword := "validPass!!"
 	sampleEncryptedEmail := "encryptedEmail"
+	emailBlindIndex := "email-blind-index"
+	emailLookup := usecase.RepoEmailLookup{BlindIndex: emailBlindIndex, LegacyEncryptedEmails: []string{sampleEncryptedEmail}}
 	sampleJWTToken := "jwtToken"
+	allowed := &redis_rate.Result{Allowed: 1}
 	user := model.User{
 		ID:       uuid.New(),
 		IsActive: true,
 	}
+	session := &model.Session{
+		ID:     uuid.New(),
+		UserID: user.ID,
+	}
 
 	testCases := []struct {
 		name                 string
@@ -308,7 +320,7 @@ func TestAuthUsecase_HandleLogin(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(validSampleEmail).Return("", assert.AnError).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validSampleEmail).Return(nil, assert.AnError).Once()
 			},
 		},
 		{
@@ -320,8 +332,10 @@ func TestAuthUsecase_HandleLogin(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(validSampleEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(nil, assert.AnError).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validSampleEmail).Return([]string{sampleEncryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validSampleEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, "login-account:"+emailBlindIndex, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(nil, assert.AnError).Once()
 			},
 		},
 		{
@@ -331,10 +345,13 @@ func TestAuthUsecase_HandleLogin(t *testing.T) {
 				Password: validSamplePassword,
 			},
 			wantErr:     true,
-			expectedErr: usecase.ErrNotFound,
+			expectedErr: usecase.ErrUnauthorized,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(validSampleEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validSampleEmail).Return([]string{sampleEncryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validSampleEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, "login-account:"+emailBlindIndex, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(validSamplePassword)).Return(assert.AnError).Once()
 			},
 		},
 		{
@@ -346,12 +363,15 @@ func TestAuthUsecase_HandleLogin(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrUnauthorized,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(validSampleEmail).Return(sampleEncryptedEmail, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validSampleEmail).Return([]string{sampleEncryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validSampleEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, "login-account:"+emailBlindIndex, mock.Anything).Return(allowed, nil).Once()
 
 				inactiveUser := user
 				inactiveUser.IsActive = false
 
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(&inactiveUser, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&inactiveUser, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(validSamplePassword)).Return(nil).Once()
 			},
 		},
 		{
@@ -363,12 +383,36 @@ func TestAuthUsecase_HandleLogin(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrUnauthorized,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(validSampleEmail).Return(sampleEncryptedEmail, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validSampleEmail).Return([]string{sampleEncryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validSampleEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, "login-account:"+emailBlindIndex, mock.Anything).Return(allowed, nil).Once()
 
 				inactiveUser := user
 				inactiveUser.IsActive = false
 
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(&inactiveUser, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&inactiveUser, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(validSamplePassword)).Return(nil).Once()
+			},
+		},
+		{
+			name: "account scheduled for deletion shouldn't be able to login",
+			input: usecase.LoginInput{
+				Email:    validSampleEmail,
+				Password: validSamplePassword,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validSampleEmail).Return([]string{sampleEncryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validSampleEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, "login-account:"+emailBlindIndex, mock.Anything).Return(allowed, nil).Once()
+
+				scheduledUser := user
+				scheduledUser.IsActive = false
+				scheduledUser.DeletionRequestedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&scheduledUser, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(validSamplePassword)).Return(nil).Once()
 			},
 		},
 		{
@@ -380,9 +424,29 @@ func TestAuthUsecase_HandleLogin(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrUnauthorized,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(validSampleEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(&user, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validSampleEmail).Return([]string{sampleEncryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validSampleEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, "login-account:"+emailBlindIndex, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&user, nil).Once()
 				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(validSamplePassword)).Return(assert.AnError).Once()
+				mockUserRepo.EXPECT().IncrementFailedLoginAttempts(ctx, user.ID).Return(&model.User{ID: user.ID, FailedLoginAttempts: 1}, nil).Once()
+			},
+		},
+		{
+			name: "failure to create session must return internal error",
+			input: usecase.LoginInput{
+				Email:    validSampleEmail,
+				Password: validSamplePassword,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validSampleEmail).Return([]string{sampleEncryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validSampleEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, "login-account:"+emailBlindIndex, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(validSamplePassword)).Return(nil).Once()
+				mockSessionRepo.EXPECT().Create(ctx, mock.Anything).Return(nil, assert.AnError).Once()
 			},
 		},
 		{
@@ -394,9 +458,12 @@ func TestAuthUsecase_HandleLogin(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(validSampleEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(&user, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validSampleEmail).Return([]string{sampleEncryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validSampleEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, "login-account:"+emailBlindIndex, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&user, nil).Once()
 				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(validSamplePassword)).Return(nil).Once()
+				mockSessionRepo.EXPECT().Create(ctx, mock.Anything).Return(session, nil).Once()
 				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("", assert.AnError).Once()
 			},
 		},
@@ -412,10 +479,17 @@ func TestAuthUsecase_HandleLogin(t *testing.T) {
 				Token: sampleJWTToken,
 			},
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(validSampleEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(&user, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validSampleEmail).Return([]string{sampleEncryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validSampleEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, "login-account:"+emailBlindIndex, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&user, nil).Once()
 				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(validSamplePassword)).Return(nil).Once()
-				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return(sampleJWTToken, nil).Once()
+				mockSessionRepo.EXPECT().Create(ctx, mock.MatchedBy(func(input usecase.RepoCreateSessionInput) bool {
+					return input.UserID == user.ID && input.RefreshTokenHash != "" && input.ExpiresAt.After(time.Now())
+				})).Return(session, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.MatchedBy(func(claims model.LoginTokenClaims) bool {
+					return claims.SessionID == session.ID.String()
+				})).Return(sampleJWTToken, nil).Once()
 			},
 		},
 	}
@@ -430,7 +504,8 @@ func TestAuthUsecase_HandleLogin(t *testing.T) {
 
 			if !tc.wantErr {
 				require.NoError(t, err)
-				assert.Equal(t, tc.expectedOutput, res)
+				assert.Equal(t, tc.expectedOutput.Token, res.Token)
+				assert.True(t, strings.HasPrefix(res.RefreshToken, session.ID.String()+"."))
 
 				return
 			}
@@ -455,11 +530,16 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 	mockTxCtrlFactory := mockUsecase.NewTransactionControllerFactory(t)
 	mockMailer := mockCommon.NewMailerIface(t)
 	mockRateLimiter := mockUsecase.NewRateLimiter(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
 
 	sampleValidEmail := "valid@email.sample"
 	sampleValidPassword := "validPass!!"
 	sampleValidUsername := "validUsername"
 	sampleEncryptedEmail := "encryptedEmail"
+	// one legacy encrypted email for every configured key
+	sampleLegacyEncryptedEmails := []string{"legacyEncryptedEmail", "v2:legacyEncryptedEmail"}
+	sampleEmailBlindIndex := "emailBlindIndex"
+	sampleEmailLookup := usecase.RepoEmailLookup{BlindIndex: sampleEmailBlindIndex, LegacyEncryptedEmails: sampleLegacyEncryptedEmails}
 	sampleHashedPassword := "hashedPassword"
 	// optional fields samples
 	samplePhone := "+6281234567890"
@@ -468,22 +548,24 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 	sampleEncryptedAddress := "encryptedAddress"
 
 	repoCreateUserInput := usecase.RepoCreateUserInput{
-		Email:       sampleEncryptedEmail,
-		Password:    sampleHashedPassword,
-		Username:    sampleValidUsername,
-		IsActive:    false,
-		Roles:       model.RolesParent,
-		PhoneNumber: sql.NullString{},
-		Address:     sql.NullString{},
+		Email:           sampleEncryptedEmail,
+		EmailBlindIndex: sampleEmailBlindIndex,
+		Password:        sampleHashedPassword,
+		Username:        sampleValidUsername,
+		IsActive:        false,
+		Roles:           model.RolesParent,
+		PhoneNumber:     sql.NullString{},
+		Address:         sql.NullString{},
 	}
 
 	// variations for optional fields
 	repoCreateUserInputPhoneOnly := usecase.RepoCreateUserInput{
-		Email:    sampleEncryptedEmail,
-		Password: sampleHashedPassword,
-		Username: sampleValidUsername,
-		IsActive: false,
-		Roles:    model.RolesParent,
+		Email:           sampleEncryptedEmail,
+		EmailBlindIndex: sampleEmailBlindIndex,
+		Password:        sampleHashedPassword,
+		Username:        sampleValidUsername,
+		IsActive:        false,
+		Roles:           model.RolesParent,
 		PhoneNumber: sql.NullString{
 			String: sampleEncryptedPhone,
 			Valid:  true,
@@ -492,12 +574,13 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 	}
 
 	repoCreateUserInputAddressOnly := usecase.RepoCreateUserInput{
-		Email:       sampleEncryptedEmail,
-		Password:    sampleHashedPassword,
-		Username:    sampleValidUsername,
-		IsActive:    false,
-		Roles:       model.RolesParent,
-		PhoneNumber: sql.NullString{},
+		Email:           sampleEncryptedEmail,
+		EmailBlindIndex: sampleEmailBlindIndex,
+		Password:        sampleHashedPassword,
+		Username:        sampleValidUsername,
+		IsActive:        false,
+		Roles:           model.RolesParent,
+		PhoneNumber:     sql.NullString{},
 		Address: sql.NullString{
 			String: sampleEncryptedAddress,
 			Valid:  true,
@@ -505,11 +588,12 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 	}
 
 	repoCreateUserInputAll := usecase.RepoCreateUserInput{
-		Email:    sampleEncryptedEmail,
-		Password: sampleHashedPassword,
-		Username: sampleValidUsername,
-		IsActive: false,
-		Roles:    model.RolesParent,
+		Email:           sampleEncryptedEmail,
+		EmailBlindIndex: sampleEmailBlindIndex,
+		Password:        sampleHashedPassword,
+		Username:        sampleValidUsername,
+		IsActive:        false,
+		Roles:           model.RolesParent,
 		PhoneNumber: sql.NullString{
 			String: sampleEncryptedPhone,
 			Valid:  true,
@@ -520,7 +604,7 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 		},
 	}
 
-	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, mockTxCtrlFactory, mockMailer, mockRateLimiter)
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, mockTxCtrlFactory, mockMailer, mockRateLimiter, nil, mockEmailTokenRepo, nil, nil, nil, nil, nil)
 
 	testCases := []struct {
 		name                 string
@@ -583,6 +667,20 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return("", assert.AnError).Once()
 			},
 		},
+		{
+			name: "system failed to create the email lookup",
+			input: usecase.SignupInput{
+				Email:    sampleValidEmail,
+				Password: sampleValidPassword,
+				Username: sampleValidUsername,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(nil, assert.AnError).Once()
+			},
+		},
 		{
 			name: "repository failure to fetch user by email",
 			input: usecase.SignupInput{
@@ -594,21 +692,48 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(nil, assert.AnError).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return(sampleHashedPassword, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEmailLookup).Return(nil, assert.AnError).Once()
 			},
 		},
 		{
-			name: "user with the same email already exists",
+			name: "failed to notify the owner of already registered email",
 			input: usecase.SignupInput{
 				Email:    sampleValidEmail,
 				Password: sampleValidPassword,
 				Username: sampleValidUsername,
 			},
 			wantErr:     true,
-			expectedErr: usecase.ErrBadRequest,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return(sampleHashedPassword, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEmailLookup).Return(&model.User{}, nil).Once()
+				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name: "user with the same email already exists get the same response as successful signup",
+			input: usecase.SignupInput{
+				Email:    sampleValidEmail,
+				Password: sampleValidPassword,
+				Username: sampleValidUsername,
+			},
+			wantErr:        false,
+			expectedOutput: &usecase.SignupOutput{Message: "email confirmation sent"},
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(&model.User{}, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return(sampleHashedPassword, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEmailLookup).Return(&model.User{}, nil).Once()
+				mockMailer.EXPECT().SendEmail(ctx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == sampleValidEmail && input.Subject == "Akun Sudah Terdaftar"
+				})).Return(&lib.CreateSmtpEmail{}, nil).Once()
 			},
 		},
 		{
@@ -622,7 +747,8 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
 				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return("", assert.AnError).Once()
 			},
 		},
@@ -638,7 +764,9 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEmailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
 				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return(sampleHashedPassword, nil).Once()
 				// failure occurs when encrypting phone number
 				mockSharedCryptor.EXPECT().Encrypt(samplePhone).Return("", assert.AnError).Once()
@@ -656,7 +784,9 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEmailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
 				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return(sampleHashedPassword, nil).Once()
 				// failure occurs when encrypting address
 				mockSharedCryptor.EXPECT().Encrypt(sampleAddress).Return("", assert.AnError).Once()
@@ -673,7 +803,9 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEmailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
 				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return(sampleHashedPassword, nil).Once()
 
 				underlyingTransaction := mockUsecase.NewTransactionController(t)
@@ -696,7 +828,9 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEmailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
 				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return(sampleHashedPassword, nil).Once()
 
 				underlyingTransaction := mockUsecase.NewTransactionController(t)
@@ -708,6 +842,34 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 				underlyingTransaction.EXPECT().Rollback().Return(assert.AnError).Once()
 			},
 		},
+		{
+			name: "system failed to record the verification token",
+			input: usecase.SignupInput{
+				Email:    sampleValidEmail,
+				Password: sampleValidPassword,
+				Username: sampleValidUsername,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEmailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return(sampleHashedPassword, nil).Once()
+
+				underlyingTransaction := mockUsecase.NewTransactionController(t)
+				txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
+
+				mockTxCtrlFactory.EXPECT().New().Return(txCtrlWrapper).Once()
+				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+				mockUserRepo.EXPECT().Create(ctx, repoCreateUserInput, mock.Anything).Return(&model.User{}, nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
 		{
 			name: "system failed to create JWT token for signup",
 			input: usecase.SignupInput{
@@ -719,7 +881,9 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEmailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
 				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return(sampleHashedPassword, nil).Once()
 
 				underlyingTransaction := mockUsecase.NewTransactionController(t)
@@ -729,6 +893,7 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
 
 				mockUserRepo.EXPECT().Create(ctx, repoCreateUserInput, mock.Anything).Return(&model.User{}, nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
 				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("", assert.AnError).Once()
 
 				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
@@ -745,7 +910,9 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEmailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
 				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return(sampleHashedPassword, nil).Once()
 
 				underlyingTransaction := mockUsecase.NewTransactionController(t)
@@ -755,6 +922,7 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
 
 				mockUserRepo.EXPECT().Create(ctx, repoCreateUserInput, mock.Anything).Return(&model.User{}, nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
 				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("jwt", nil).Once()
 				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(&lib.CreateSmtpEmail{}, assert.AnError).Once()
 
@@ -772,7 +940,9 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEmailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
 				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return(sampleHashedPassword, nil).Once()
 
 				underlyingTransaction := mockUsecase.NewTransactionController(t)
@@ -782,6 +952,7 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
 
 				mockUserRepo.EXPECT().Create(ctx, repoCreateUserInput, mock.Anything).Return(&model.User{}, nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
 				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("jwt", nil).Once()
 				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(&lib.CreateSmtpEmail{}, nil).Once()
 
@@ -801,7 +972,9 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 			},
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEmailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
 				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return(sampleHashedPassword, nil).Once()
 
 				underlyingTransaction := mockUsecase.NewTransactionController(t)
@@ -811,6 +984,7 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
 
 				mockUserRepo.EXPECT().Create(ctx, repoCreateUserInput, mock.Anything).Return(&model.User{}, nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
 				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("jwt", nil).Once()
 				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(&lib.CreateSmtpEmail{}, nil).Once()
 
@@ -829,7 +1003,9 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 			expectedOutput: &usecase.SignupOutput{Message: "email confirmation sent"},
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEmailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
 				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return(sampleHashedPassword, nil).Once()
 				mockSharedCryptor.EXPECT().Encrypt(sampleAddress).Return(sampleEncryptedAddress, nil).Once()
 
@@ -840,6 +1016,7 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
 
 				mockUserRepo.EXPECT().Create(ctx, repoCreateUserInputAddressOnly, mock.Anything).Return(&model.User{}, nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
 				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("jwt", nil).Once()
 				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(&lib.CreateSmtpEmail{}, nil).Once()
 
@@ -858,7 +1035,9 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 			expectedOutput: &usecase.SignupOutput{Message: "email confirmation sent"},
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEmailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
 				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return(sampleHashedPassword, nil).Once()
 				mockSharedCryptor.EXPECT().Encrypt(samplePhone).Return(sampleEncryptedPhone, nil).Once()
 
@@ -869,6 +1048,7 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
 
 				mockUserRepo.EXPECT().Create(ctx, repoCreateUserInputPhoneOnly, mock.Anything).Return(&model.User{}, nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
 				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("jwt", nil).Once()
 				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(&lib.CreateSmtpEmail{}, nil).Once()
 
@@ -888,7 +1068,9 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 			expectedOutput: &usecase.SignupOutput{Message: "email confirmation sent"},
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().Encrypt(sampleValidEmail).Return(sampleEncryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEncryptedEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(sampleValidEmail).Return(sampleLegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(sampleValidEmail).Return(sampleEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, sampleEmailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
 				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPassword)).Return(sampleHashedPassword, nil).Once()
 				mockSharedCryptor.EXPECT().Encrypt(samplePhone).Return(sampleEncryptedPhone, nil).Once()
 				mockSharedCryptor.EXPECT().Encrypt(sampleAddress).Return(sampleEncryptedAddress, nil).Once()
@@ -900,6 +1082,7 @@ func TestAuthUsecase_HandleSignup(t *testing.T) {
 				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
 
 				mockUserRepo.EXPECT().Create(ctx, repoCreateUserInputAll, mock.Anything).Return(&model.User{}, nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
 				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("jwt", nil).Once()
 				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(&lib.CreateSmtpEmail{}, nil).Once()
 
@@ -940,8 +1123,22 @@ func TestAuthUsecase_HandleAccountVerification(t *testing.T) {
 
 	mockUserRepo := mockUsecase.NewUserRepository(t)
 	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+	mockTxCtrlFactory := mockUsecase.NewTransactionControllerFactory(t)
+
+	uc := usecase.NewAuthUsecase(
+		mockSharedCryptor, mockUserRepo, nil, nil, mockTxCtrlFactory, nil, nil, nil, mockEmailTokenRepo, nil, nil, nil, nil, nil,
+	)
+
+	// expectTransactionBegin set the expectation to start the transaction and return the underlying transaction
+	expectTransactionBegin := func() *mockUsecase.TransactionController {
+		underlyingTransaction := mockUsecase.NewTransactionController(t)
+
+		mockTxCtrlFactory.EXPECT().New().Return(usecase.NewTxControllerWrapper(underlyingTransaction)).Once()
+		underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
 
-	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, nil, nil)
+		return underlyingTransaction
+	}
 
 	validateJWTOpts := common.ValidateJWTOpts{
 		Issuer:  string(usecase.TokenIssuerSystem),
@@ -997,15 +1194,32 @@ func TestAuthUsecase_HandleAccountVerification(t *testing.T) {
 	invalidUUIDAudTokenString, _ := invalidUUIDAudToken.SignedString(signingKey)
 
 	userID := uuid.New()
+	tokenID := uuid.New()
 	validToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"iss": string(usecase.TokenIssuerSystem),
 		"sub": string(usecase.SignupVerificationToken),
 		"aud": []string{userID.String()},
 		"exp": jwt.NewNumericDate(time.Now().Add(time.Hour * 1)).Unix(),
+		"jti": tokenID.String(),
 	})
 	validToken.Valid = true
 	validTokenString, _ := validToken.SignedString(signingKey)
 
+	noTokenIDToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": string(usecase.TokenIssuerSystem),
+		"sub": string(usecase.SignupVerificationToken),
+		"aud": []string{userID.String()},
+		"exp": jwt.NewNumericDate(time.Now().Add(time.Hour * 1)).Unix(),
+	})
+	noTokenIDToken.Valid = true
+	noTokenIDTokenString, _ := noTokenIDToken.SignedString(signingKey)
+
+	consumeInput := usecase.RepoConsumeEmailTokenInput{
+		ID:      tokenID,
+		UserID:  userID,
+		Purpose: string(usecase.SignupVerificationToken),
+	}
+
 	testCases := []struct {
 		name                 string
 		input                usecase.AccountVerificationInput
@@ -1159,6 +1373,50 @@ func TestAuthUsecase_HandleAccountVerification(t *testing.T) {
 				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&model.User{IsActive: true}, nil).Once()
 			},
 		},
+		{
+			name: "token without id can not be redeemed",
+			input: usecase.AccountVerificationInput{
+				VerificationToken: noTokenIDTokenString,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(noTokenIDTokenString, validateJWTOpts).Return(noTokenIDToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&model.User{ID: userID}, nil).Once()
+				underlyingTransaction := expectTransactionBegin()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name: "token has already been used",
+			input: usecase.AccountVerificationInput{
+				VerificationToken: validTokenString,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&model.User{ID: userID}, nil).Once()
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(usecase.ErrRepoNotFound).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name: "failed to consume the token",
+			input: usecase.AccountVerificationInput{
+				VerificationToken: validTokenString,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&model.User{ID: userID}, nil).Once()
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(assert.AnError).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
 		{
 			name: "repository failed to activate user",
 			input: usecase.AccountVerificationInput{
@@ -1170,9 +1428,31 @@ func TestAuthUsecase_HandleAccountVerification(t *testing.T) {
 				truth := true
 				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
 				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&model.User{ID: userID}, nil).Once()
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(nil).Once()
+				mockUserRepo.EXPECT().Update(ctx, userID, usecase.RepoUpdateUserInput{
+					IsActive: &truth,
+				}, mock.Anything).Return(nil, assert.AnError).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name: "failed to commit the transaction, thus the token is not consumed",
+			input: usecase.AccountVerificationInput{
+				VerificationToken: validTokenString,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				truth := true
+				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&model.User{ID: userID}, nil).Once()
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(nil).Once()
 				mockUserRepo.EXPECT().Update(ctx, userID, usecase.RepoUpdateUserInput{
 					IsActive: &truth,
-				}).Return(nil, assert.AnError).Once()
+				}, mock.Anything).Return(&model.User{}, nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(assert.AnError).Once()
 			},
 		},
 		{
@@ -1188,9 +1468,12 @@ func TestAuthUsecase_HandleAccountVerification(t *testing.T) {
 				truth := true
 				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
 				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&model.User{ID: userID}, nil).Once()
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(nil).Once()
 				mockUserRepo.EXPECT().Update(ctx, userID, usecase.RepoUpdateUserInput{
 					IsActive: &truth,
-				}).Return(&model.User{}, nil).Once()
+				}, mock.Anything).Return(&model.User{}, nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(nil).Once()
 			},
 		},
 	}
@@ -1228,12 +1511,18 @@ func TestAuthUsecase_HandleInitesetPassword(t *testing.T) {
 	mockUserRepo := mockUsecase.NewUserRepository(t)
 	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
 	mockMailer := mockCommon.NewMailerIface(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+	mockRateLimiter := mockUsecase.NewRateLimiter(t)
 
-	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, mockMailer, nil)
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, mockMailer, mockRateLimiter, nil, mockEmailTokenRepo, nil, nil, nil, nil, nil)
 
 	validEmail := "valid@email.sample"
 	encryptedEmail := "sampleEncryptedEmail"
+	emailBlindIndex := "email-blind-index"
+	emailLookup := usecase.RepoEmailLookup{BlindIndex: emailBlindIndex, LegacyEncryptedEmails: []string{encryptedEmail}}
 	sampleJWTToken := "token"
+	rateLimitKey := "reset-password:" + emailBlindIndex
+	allowed := &redis_rate.Result{Allowed: 1}
 	userID := uuid.New()
 	user := &model.User{
 		ID:       userID,
@@ -1272,7 +1561,33 @@ func TestAuthUsecase_HandleInitesetPassword(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(validEmail).Return("", assert.AnError).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validEmail).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name: "failed to perform rate limiter ops",
+			input: usecase.InitResetPasswordInput{
+				Email: validEmail,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validEmail).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name: "too many reset password requests for the same email",
+			input: usecase.InitResetPasswordInput{
+				Email: validEmail,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrTooManyRequests,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validEmail).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(&redis_rate.Result{Allowed: 0}, nil).Once()
 			},
 		},
 		{
@@ -1283,32 +1598,92 @@ func TestAuthUsecase_HandleInitesetPassword(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(validEmail).Return(encryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, encryptedEmail).Return(nil, assert.AnError).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validEmail).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name: "no user found with the supplied email get the same response, only the email owner is notified",
+			input: usecase.InitResetPasswordInput{
+				Email: validEmail,
+			},
+			wantErr: false,
+			expectedOutput: &usecase.InitResetPasswordOutput{
+				Message: "ok",
+			},
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validEmail).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockMailer.EXPECT().SendEmail(ctx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == validEmail && input.Subject == "Permintaan Reset Kata Sandi"
+				})).Return(&lib.CreateSmtpEmail{}, nil).Once()
 			},
 		},
 		{
-			name: "no user found with the supplied email",
+			name: "failed to notify the owner of inactive account",
 			input: usecase.InitResetPasswordInput{
 				Email: validEmail,
 			},
 			wantErr:     true,
-			expectedErr: usecase.ErrNotFound,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validEmail).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&model.User{IsActive: false}, nil).Once()
+				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name: "inactive user should not get the reset password token",
+			input: usecase.InitResetPasswordInput{
+				Email: validEmail,
+			},
+			wantErr: false,
+			expectedOutput: &usecase.InitResetPasswordOutput{
+				Message: "ok",
+			},
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(validEmail).Return(encryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, encryptedEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validEmail).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&model.User{IsActive: false}, nil).Once()
+				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(&lib.CreateSmtpEmail{}, nil).Once()
 			},
 		},
 		{
-			name: "inactive user should not be able to use this",
+			name: "failed to revoke previous tokens",
 			input: usecase.InitResetPasswordInput{
 				Email: validEmail,
 			},
 			wantErr:     true,
-			expectedErr: usecase.ErrUnauthorized,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validEmail).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(user, nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(ctx, userID, string(usecase.ChangePasswordToken)).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name: "failed to record the token",
+			input: usecase.InitResetPasswordInput{
+				Email: validEmail,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(validEmail).Return(encryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, encryptedEmail).Return(&model.User{IsActive: false}, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validEmail).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(user, nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(ctx, userID, string(usecase.ChangePasswordToken)).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything).Return(nil, assert.AnError).Once()
 			},
 		},
 		{
@@ -1319,8 +1694,12 @@ func TestAuthUsecase_HandleInitesetPassword(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(validEmail).Return(encryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, encryptedEmail).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validEmail).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(user, nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(ctx, userID, string(usecase.ChangePasswordToken)).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
 				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("", assert.AnError).Once()
 			},
 		},
@@ -1332,8 +1711,12 @@ func TestAuthUsecase_HandleInitesetPassword(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(validEmail).Return(encryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, encryptedEmail).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validEmail).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(user, nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(ctx, userID, string(usecase.ChangePasswordToken)).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
 				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return(sampleJWTToken, nil).Once()
 				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(nil, assert.AnError).Once()
 			},
@@ -1348,8 +1731,12 @@ func TestAuthUsecase_HandleInitesetPassword(t *testing.T) {
 				Message: "ok",
 			},
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(validEmail).Return(encryptedEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, encryptedEmail).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(validEmail).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(validEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(user, nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(ctx, userID, string(usecase.ChangePasswordToken)).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
 				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return(sampleJWTToken, nil).Once()
 				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(&lib.CreateSmtpEmail{}, nil).Once()
 			},
@@ -1383,14 +1770,85 @@ func TestAuthUsecase_HandleInitesetPassword(t *testing.T) {
 	}
 }
 
+func TestAuthUsecase_ExpiredEmailToken(t *testing.T) {
+	ctx := context.Background()
+
+	_, signingKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	sharedCryptor := common.NewSharedCryptor(&common.CreateCryptorOpts{
+		EncryptionKey: []byte("encryption-key"),
+		IV:            "000102030405060708090a0b0c0d0e0f",
+		BlockSize:     common.DefaultBlockSize,
+		JWTKeySet:     common.NewJWTKeySet(signingKey),
+	})
+	uc := usecase.NewAuthUsecase(sharedCryptor, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	createExpiredToken := func(subject usecase.JWTTokenType) string {
+		token, err := sharedCryptor.CreateJWT(jwt.RegisteredClaims{
+			Issuer:    string(usecase.TokenIssuerSystem),
+			Subject:   string(subject),
+			Audience:  jwt.ClaimStrings{uuid.NewString()},
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(time.Now().Add(-time.Hour * 2)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Hour)),
+		})
+		require.NoError(t, err)
+
+		return token
+	}
+
+	assertExpired := func(t *testing.T, err error, expectedMessage string) {
+		t.Helper()
+
+		require.Error(t, err)
+
+		ucErr, ok := err.(usecase.UsecaseError)
+		require.True(t, ok)
+		assert.Equal(t, usecase.ErrUnauthorized, ucErr.ErrType)
+		assert.Equal(t, expectedMessage, ucErr.Message)
+	}
+
+	t.Run("reset password", func(t *testing.T) {
+		_, err := uc.HandleResetPassword(ctx, usecase.ResetPasswordInput{
+			ResetPasswordToken: createExpiredToken(usecase.ChangePasswordToken),
+			NewPassword:        "thisShouldBeVal1dPass!",
+		})
+
+		assertExpired(t, err, "token has expired")
+	})
+
+	t.Run("account verification", func(t *testing.T) {
+		_, err := uc.HandleAccountVerification(ctx, usecase.AccountVerificationInput{
+			VerificationToken: createExpiredToken(usecase.SignupVerificationToken),
+		})
+
+		assertExpired(t, err, "account validation token has expired")
+	})
+}
+
 func TestAuthUsecase_HandleResetPassword(t *testing.T) {
 	ctx := context.Background()
 
 	mockUserRepo := mockUsecase.NewUserRepository(t)
 	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
 	mockMailer := mockCommon.NewMailerIface(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+	mockTxCtrlFactory := mockUsecase.NewTransactionControllerFactory(t)
 
-	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, mockMailer, nil)
+	uc := usecase.NewAuthUsecase(
+		mockSharedCryptor, mockUserRepo, nil, nil, mockTxCtrlFactory, mockMailer, nil, nil, mockEmailTokenRepo, nil, nil, nil, nil, nil,
+	)
+
+	// expectTransactionBegin set the expectation to start the transaction and return the underlying transaction
+	expectTransactionBegin := func() *mockUsecase.TransactionController {
+		underlyingTransaction := mockUsecase.NewTransactionController(t)
+
+		mockTxCtrlFactory.EXPECT().New().Return(usecase.NewTxControllerWrapper(underlyingTransaction)).Once()
+		underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+		return underlyingTransaction
+	}
 
 	sampleValidPass := "123ValidPass"
 	hashedPw := "hashedpw"
@@ -1449,15 +1907,23 @@ func TestAuthUsecase_HandleResetPassword(t *testing.T) {
 
 	userID := uuid.New()
 	user := &model.User{ID: userID}
+	tokenID := uuid.New()
 	validToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"iss": string(usecase.TokenIssuerSystem),
 		"sub": string(usecase.ChangePasswordToken),
 		"aud": []string{userID.String()},
 		"exp": jwt.NewNumericDate(time.Now().Add(time.Hour * 1)).Unix(),
+		"jti": tokenID.String(),
 	})
 	validToken.Valid = true
 	validTokenString, _ := validToken.SignedString(signingKey)
 
+	consumeInput := usecase.RepoConsumeEmailTokenInput{
+		ID:      tokenID,
+		UserID:  userID,
+		Purpose: string(usecase.ChangePasswordToken),
+	}
+
 	testCases := []struct {
 		name                 string
 		input                usecase.ResetPasswordInput
@@ -1628,21 +2094,24 @@ func TestAuthUsecase_HandleResetPassword(t *testing.T) {
 			},
 		},
 		{
-			name: "failed to hash new password",
+			name: "reset password token has already been used",
 			input: usecase.ResetPasswordInput{
 				ResetPasswordToken: validTokenString,
 				NewPassword:        sampleValidPass,
 			},
 			wantErr:     true,
-			expectedErr: usecase.ErrInternal,
+			expectedErr: usecase.ErrUnauthorized,
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
 				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(user, nil).Once()
-				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPass)).Return("", assert.AnError).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPass)).Return(hashedPw, nil).Once()
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(usecase.ErrRepoNotFound).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
 			},
 		},
 		{
-			name: "failed to update user data on repository",
+			name: "failed to revoke outstanding reset password tokens",
 			input: usecase.ResetPasswordInput{
 				ResetPasswordToken: validTokenString,
 				NewPassword:        sampleValidPass,
@@ -1653,28 +2122,89 @@ func TestAuthUsecase_HandleResetPassword(t *testing.T) {
 				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
 				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(user, nil).Once()
 				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPass)).Return(hashedPw, nil).Once()
-				mockUserRepo.EXPECT().Update(ctx, userID, usecase.RepoUpdateUserInput{
-					Password: hashedPw,
-				}).Return(nil, assert.AnError).Once()
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(ctx, userID, string(usecase.ChangePasswordToken), mock.Anything).Return(assert.AnError).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
 			},
 		},
 		{
-			name: "ok",
+			name: "failed to hash new password",
 			input: usecase.ResetPasswordInput{
 				ResetPasswordToken: validTokenString,
 				NewPassword:        sampleValidPass,
 			},
-			wantErr: false,
-			expectedOutput: &usecase.ResetPasswordOutput{
-				Message: "ok",
-			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPass)).Return("", assert.AnError).Once()
+			},
+		},
+		{
+			name: "failed to update user data on repository",
+			input: usecase.ResetPasswordInput{
+				ResetPasswordToken: validTokenString,
+				NewPassword:        sampleValidPass,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPass)).Return(hashedPw, nil).Once()
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(ctx, userID, string(usecase.ChangePasswordToken), mock.Anything).Return(nil).Once()
+				mockUserRepo.EXPECT().Update(ctx, userID, usecase.RepoUpdateUserInput{
+					Password: hashedPw,
+				}, mock.Anything).Return(nil, assert.AnError).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name: "failed to commit the transaction, thus the token is not consumed",
+			input: usecase.ResetPasswordInput{
+				ResetPasswordToken: validTokenString,
+				NewPassword:        sampleValidPass,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPass)).Return(hashedPw, nil).Once()
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(ctx, userID, string(usecase.ChangePasswordToken), mock.Anything).Return(nil).Once()
+				mockUserRepo.EXPECT().Update(ctx, userID, usecase.RepoUpdateUserInput{
+					Password: hashedPw,
+				}, mock.Anything).Return(user, nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(assert.AnError).Once()
+			},
+		},
+		{
+			name: "ok",
+			input: usecase.ResetPasswordInput{
+				ResetPasswordToken: validTokenString,
+				NewPassword:        sampleValidPass,
+			},
+			wantErr: false,
+			expectedOutput: &usecase.ResetPasswordOutput{
+				Message: "ok",
+			},
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
 				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(user, nil).Once()
 				mockSharedCryptor.EXPECT().Hash([]byte(sampleValidPass)).Return(hashedPw, nil).Once()
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(ctx, userID, string(usecase.ChangePasswordToken), mock.Anything).Return(nil).Once()
 				mockUserRepo.EXPECT().Update(ctx, userID, usecase.RepoUpdateUserInput{
 					Password: hashedPw,
-				}).Return(user, nil).Once()
+				}, mock.Anything).Return(user, nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(nil).Once()
 			},
 		},
 	}
@@ -1711,7 +2241,10 @@ func TestAuthUsecase_AllowAccess(t *testing.T) {
 
 	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
 
-	uc := usecase.NewAuthUsecase(mockSharedCryptor, nil, nil, nil, nil, nil, nil)
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil, nil)
 
 	sampleVerificationToken := "sampleToken"
 	validateJWTOpts := common.ValidateJWTOpts{
@@ -1766,65 +2299,86 @@ func TestAuthUsecase_AllowAccess(t *testing.T) {
 	invalidUUIDAudToken.Valid = true
 	invalidUUIDAudTokenString, _ := invalidUUIDAudToken.SignedString(signingKey)
 
-	userID := uuid.New()
-	invalidRoleToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"iss":  string(usecase.TokenIssuerSystem),
-		"sub":  string(usecase.LoginToken),
-		"aud":  []string{userID.String()},
-		"exp":  jwt.NewNumericDate(time.Now().Add(time.Hour * 1)).Unix(),
-		"role": "invalid",
-	})
-	invalidRoleToken.Valid = true
-	invalidRoleTokenString, _ := invalidRoleToken.SignedString(signingKey)
-
-	invalidRoleStringToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"iss":  string(usecase.TokenIssuerSystem),
-		"sub":  string(usecase.LoginToken),
-		"aud":  []string{userID.String()},
-		"exp":  jwt.NewNumericDate(time.Now().Add(time.Hour * 1)).Unix(),
-		"role": "123",
-	})
-	invalidRoleStringToken.Valid = true
-	invalidRoleStringTokenString, _ := invalidRoleStringToken.SignedString(signingKey)
-
 	adminID := uuid.New()
+	adminSessionID := uuid.New()
 	validAdminToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"iss":  string(usecase.TokenIssuerSystem),
 		"sub":  string(usecase.LoginToken),
 		"aud":  []string{adminID.String()},
 		"exp":  jwt.NewNumericDate(time.Now().Add(time.Hour * 1)).Unix(),
+		"sid":  adminSessionID.String(),
 		"role": string(model.RolesAdministrator),
 	})
 	validAdminToken.Valid = true
 	validAdminTokenString, _ := validAdminToken.SignedString(signingKey)
 
 	parentID := uuid.New()
+	parentSessionID := uuid.New()
 	validParentToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"iss":  string(usecase.TokenIssuerSystem),
 		"sub":  string(usecase.LoginToken),
 		"aud":  []string{parentID.String()},
 		"exp":  jwt.NewNumericDate(time.Now().Add(time.Hour * 1)).Unix(),
+		"sid":  parentSessionID.String(),
 		"role": string(model.RolesParent),
 	})
 	validParentToken.Valid = true
 	validParentTokenString, _ := validParentToken.SignedString(signingKey)
 
 	therapistID := uuid.New()
+	therapistSessionID := uuid.New()
 	validTherapistToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"iss":  string(usecase.TokenIssuerSystem),
 		"sub":  string(usecase.LoginToken),
 		"aud":  []string{therapistID.String()},
 		"exp":  jwt.NewNumericDate(time.Now().Add(time.Hour * 1)).Unix(),
+		"sid":  therapistSessionID.String(),
 		"role": string(model.RolesTherapist),
 	})
 	validTherapistToken.Valid = true
 	validTherapistTokenString, _ := validTherapistToken.SignedString(signingKey)
 
+	noSessionToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss":  string(usecase.TokenIssuerSystem),
+		"sub":  string(usecase.LoginToken),
+		"aud":  []string{parentID.String()},
+		"exp":  jwt.NewNumericDate(time.Now().Add(time.Hour * 1)).Unix(),
+		"role": string(model.RolesParent),
+	})
+	noSessionToken.Valid = true
+	noSessionTokenString, _ := noSessionToken.SignedString(signingKey)
+
+	invalidSessionIDToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss":  string(usecase.TokenIssuerSystem),
+		"sub":  string(usecase.LoginToken),
+		"aud":  []string{parentID.String()},
+		"exp":  jwt.NewNumericDate(time.Now().Add(time.Hour * 1)).Unix(),
+		"role": string(model.RolesParent),
+		"sid":  "invalid-UUID",
+	})
+	invalidSessionIDToken.Valid = true
+	invalidSessionIDTokenString, _ := invalidSessionIDToken.SignedString(signingKey)
+
+	organizationID := uuid.New()
+	organizationAdminToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss":       string(usecase.TokenIssuerSystem),
+		"sub":       string(usecase.LoginToken),
+		"aud":       []string{therapistID.String()},
+		"exp":       jwt.NewNumericDate(time.Now().Add(time.Hour * 1)).Unix(),
+		"sid":       therapistSessionID.String(),
+		"role":      string(model.RolesTherapist),
+		"org":       organizationID.String(),
+		"org_admin": true,
+	})
+	organizationAdminToken.Valid = true
+	organizationAdminTokenString, _ := organizationAdminToken.SignedString(signingKey)
+
 	testCases := []struct {
 		name                 string
 		input                usecase.AuthenticateAccessTokenInput
 		wantErr              bool
 		expectedErr          error
+		expectedErrMessage   string
 		expectedOutput       *usecase.AuthenticateAccessTokenOutput
 		expectedFunctionCall func()
 	}{
@@ -1833,8 +2387,9 @@ func TestAuthUsecase_AllowAccess(t *testing.T) {
 			input: usecase.AuthenticateAccessTokenInput{
 				Token: sampleVerificationToken,
 			},
-			wantErr:     true,
-			expectedErr: usecase.ErrUnauthorized,
+			wantErr:            true,
+			expectedErr:        usecase.ErrUnauthorized,
+			expectedErrMessage: "token has expired",
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().ValidateJWT(sampleVerificationToken, validateJWTOpts).Return(nil, jwt.ErrTokenExpired).Once()
 			},
@@ -1917,36 +2472,161 @@ func TestAuthUsecase_AllowAccess(t *testing.T) {
 			},
 		},
 		{
-			name: "undefined role in the token claims",
+			name: "session id is missing from token claims",
 			input: usecase.AuthenticateAccessTokenInput{
-				Token: invalidUUIDAudTokenString,
+				Token: noSessionTokenString,
 			},
 			wantErr:     true,
 			expectedErr: usecase.ErrUnauthorized,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().ValidateJWT(invalidUUIDAudTokenString, validateJWTOpts).Return(invalidUUIDAudToken, nil).Once()
+				mockSharedCryptor.EXPECT().ValidateJWT(noSessionTokenString, validateJWTOpts).Return(noSessionToken, nil).Once()
+			},
+		},
+		{
+			name: "session id is not a valid uuid",
+			input: usecase.AuthenticateAccessTokenInput{
+				Token: invalidSessionIDTokenString,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(invalidSessionIDTokenString, validateJWTOpts).Return(invalidSessionIDToken, nil).Once()
+			},
+		},
+		{
+			name: "failed to find the session from db",
+			input: usecase.AuthenticateAccessTokenInput{
+				Token: validParentTokenString,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validParentTokenString, validateJWTOpts).Return(validParentToken, nil).Once()
+				mockSessionRepo.EXPECT().FindByID(ctx, parentSessionID).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name: "session not found",
+			input: usecase.AuthenticateAccessTokenInput{
+				Token: validParentTokenString,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validParentTokenString, validateJWTOpts).Return(validParentToken, nil).Once()
+				mockSessionRepo.EXPECT().FindByID(ctx, parentSessionID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name: "session already revoked",
+			input: usecase.AuthenticateAccessTokenInput{
+				Token: validParentTokenString,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validParentTokenString, validateJWTOpts).Return(validParentToken, nil).Once()
+				mockSessionRepo.EXPECT().FindByID(ctx, parentSessionID).Return(&model.Session{
+					ID:        parentSessionID,
+					UserID:    parentID,
+					ExpiresAt: time.Now().Add(time.Hour),
+					RevokedAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil).Once()
 			},
 		},
 		{
-			name: "role was not string",
+			name: "session belongs to other user",
 			input: usecase.AuthenticateAccessTokenInput{
-				Token: invalidRoleStringTokenString,
+				Token: validParentTokenString,
 			},
 			wantErr:     true,
 			expectedErr: usecase.ErrUnauthorized,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().ValidateJWT(invalidRoleStringTokenString, validateJWTOpts).Return(invalidRoleStringToken, nil).Once()
+				mockSharedCryptor.EXPECT().ValidateJWT(validParentTokenString, validateJWTOpts).Return(validParentToken, nil).Once()
+				mockSessionRepo.EXPECT().FindByID(ctx, parentSessionID).Return(&model.Session{
+					ID:        parentSessionID,
+					UserID:    uuid.New(),
+					ExpiresAt: time.Now().Add(time.Hour),
+				}, nil).Once()
+			},
+		},
+		{
+			name: "failed to find the user from db",
+			input: usecase.AuthenticateAccessTokenInput{
+				Token: validParentTokenString,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validParentTokenString, validateJWTOpts).Return(validParentToken, nil).Once()
+				mockSessionRepo.EXPECT().FindByID(ctx, parentSessionID).Return(&model.Session{
+					ID:         parentSessionID,
+					UserID:     parentID,
+					ExpiresAt:  time.Now().Add(time.Hour),
+					LastSeenAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, parentID).Return(nil, assert.AnError).Once()
 			},
 		},
 		{
-			name: "role was not string",
+			name: "user not found",
 			input: usecase.AuthenticateAccessTokenInput{
-				Token: invalidRoleTokenString,
+				Token: validParentTokenString,
 			},
 			wantErr:     true,
 			expectedErr: usecase.ErrUnauthorized,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().ValidateJWT(invalidRoleTokenString, validateJWTOpts).Return(invalidRoleToken, nil).Once()
+				mockSharedCryptor.EXPECT().ValidateJWT(validParentTokenString, validateJWTOpts).Return(validParentToken, nil).Once()
+				mockSessionRepo.EXPECT().FindByID(ctx, parentSessionID).Return(&model.Session{
+					ID:         parentSessionID,
+					UserID:     parentID,
+					ExpiresAt:  time.Now().Add(time.Hour),
+					LastSeenAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, parentID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name: "the user has been deactivated while the token is still live",
+			input: usecase.AuthenticateAccessTokenInput{
+				Token: validParentTokenString,
+			},
+			wantErr:            true,
+			expectedErr:        usecase.ErrUnauthorized,
+			expectedErrMessage: "this account is deactivated or locked",
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validParentTokenString, validateJWTOpts).Return(validParentToken, nil).Once()
+				mockSessionRepo.EXPECT().FindByID(ctx, parentSessionID).Return(&model.Session{
+					ID:         parentSessionID,
+					UserID:     parentID,
+					ExpiresAt:  time.Now().Add(time.Hour),
+					LastSeenAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, parentID).Return(&model.User{ID: parentID, Roles: model.RolesParent, IsActive: false}, nil).Once()
+			},
+		},
+		{
+			name: "the user has been locked while the token is still live",
+			input: usecase.AuthenticateAccessTokenInput{
+				Token: validParentTokenString,
+			},
+			wantErr:            true,
+			expectedErr:        usecase.ErrUnauthorized,
+			expectedErrMessage: "this account is deactivated or locked",
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validParentTokenString, validateJWTOpts).Return(validParentToken, nil).Once()
+				mockSessionRepo.EXPECT().FindByID(ctx, parentSessionID).Return(&model.Session{
+					ID:         parentSessionID,
+					UserID:     parentID,
+					ExpiresAt:  time.Now().Add(time.Hour),
+					LastSeenAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, parentID).Return(&model.User{
+					ID:          parentID,
+					Roles:       model.RolesParent,
+					IsActive:    true,
+					LockedUntil: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+				}, nil).Once()
 			},
 		},
 		{
@@ -1956,39 +2636,138 @@ func TestAuthUsecase_AllowAccess(t *testing.T) {
 			},
 			wantErr: false,
 			expectedOutput: &usecase.AuthenticateAccessTokenOutput{
-				UserID:   adminID,
-				UserRole: model.RolesAdministrator,
+				UserID:    adminID,
+				UserRole:  model.RolesAdministrator,
+				SessionID: adminSessionID,
 			},
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().ValidateJWT(validAdminTokenString, validateJWTOpts).Return(validAdminToken, nil).Once()
+				mockSessionRepo.EXPECT().FindByID(ctx, adminSessionID).Return(&model.Session{
+					ID:        adminSessionID,
+					UserID:    adminID,
+					ExpiresAt: time.Now().Add(time.Hour),
+				}, nil).Once()
+				mockSessionRepo.EXPECT().UpdateLastSeenAt(ctx, adminSessionID).Return(nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, adminID).Return(&model.User{ID: adminID, Roles: model.RolesAdministrator, IsActive: true}, nil).Once()
 			},
 		},
 		{
-			name: "ok - role is parent",
+			name: "ok - role is parent and the session was just seen, so the last seen time is not updated",
 			input: usecase.AuthenticateAccessTokenInput{
 				Token: validParentTokenString,
 			},
 			wantErr: false,
 			expectedOutput: &usecase.AuthenticateAccessTokenOutput{
-				UserID:   parentID,
-				UserRole: model.RolesParent,
+				UserID:    parentID,
+				UserRole:  model.RolesParent,
+				SessionID: parentSessionID,
 			},
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().ValidateJWT(validParentTokenString, validateJWTOpts).Return(validParentToken, nil).Once()
+				mockSessionRepo.EXPECT().FindByID(ctx, parentSessionID).Return(&model.Session{
+					ID:         parentSessionID,
+					UserID:     parentID,
+					ExpiresAt:  time.Now().Add(time.Hour),
+					LastSeenAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, parentID).Return(&model.User{ID: parentID, Roles: model.RolesParent, IsActive: true}, nil).Once()
 			},
 		},
 		{
-			name: "ok - role is therapist",
+			name: "ok - role is therapist, failing to update the last seen time is ignored",
 			input: usecase.AuthenticateAccessTokenInput{
 				Token: validTherapistTokenString,
 			},
 			wantErr: false,
 			expectedOutput: &usecase.AuthenticateAccessTokenOutput{
-				UserID:   therapistID,
-				UserRole: model.RolesTherapist,
+				UserID:    therapistID,
+				UserRole:  model.RolesTherapist,
+				SessionID: therapistSessionID,
 			},
 			expectedFunctionCall: func() {
 				mockSharedCryptor.EXPECT().ValidateJWT(validTherapistTokenString, validateJWTOpts).Return(validTherapistToken, nil).Once()
+				mockSessionRepo.EXPECT().FindByID(ctx, therapistSessionID).Return(&model.Session{
+					ID:         therapistSessionID,
+					UserID:     therapistID,
+					ExpiresAt:  time.Now().Add(time.Hour),
+					LastSeenAt: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+				}, nil).Once()
+				mockSessionRepo.EXPECT().UpdateLastSeenAt(ctx, therapistSessionID).Return(assert.AnError).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, therapistID).Return(&model.User{ID: therapistID, Roles: model.RolesTherapist, IsActive: true}, nil).Once()
+			},
+		},
+		{
+			name: "ok - therapist administering an organization",
+			input: usecase.AuthenticateAccessTokenInput{
+				Token: organizationAdminTokenString,
+			},
+			wantErr: false,
+			expectedOutput: &usecase.AuthenticateAccessTokenOutput{
+				UserID:              therapistID,
+				UserRole:            model.RolesTherapist,
+				SessionID:           therapistSessionID,
+				OrganizationID:      organizationID,
+				IsOrganizationAdmin: true,
+			},
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(organizationAdminTokenString, validateJWTOpts).Return(organizationAdminToken, nil).Once()
+				mockSessionRepo.EXPECT().FindByID(ctx, therapistSessionID).Return(&model.Session{
+					ID:         therapistSessionID,
+					UserID:     therapistID,
+					ExpiresAt:  time.Now().Add(time.Hour),
+					LastSeenAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, therapistID).Return(&model.User{
+					ID:                  therapistID,
+					Roles:               model.RolesTherapist,
+					IsActive:            true,
+					OrganizationID:      uuid.NullUUID{UUID: organizationID, Valid: true},
+					IsOrganizationAdmin: true,
+				}, nil).Once()
+			},
+		},
+		{
+			name: "ok - the stale organization claims are ignored after the user was removed from the organization",
+			input: usecase.AuthenticateAccessTokenInput{
+				Token: organizationAdminTokenString,
+			},
+			wantErr: false,
+			expectedOutput: &usecase.AuthenticateAccessTokenOutput{
+				UserID:    therapistID,
+				UserRole:  model.RolesTherapist,
+				SessionID: therapistSessionID,
+			},
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(organizationAdminTokenString, validateJWTOpts).Return(organizationAdminToken, nil).Once()
+				mockSessionRepo.EXPECT().FindByID(ctx, therapistSessionID).Return(&model.Session{
+					ID:         therapistSessionID,
+					UserID:     therapistID,
+					ExpiresAt:  time.Now().Add(time.Hour),
+					LastSeenAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, therapistID).Return(&model.User{ID: therapistID, Roles: model.RolesTherapist, IsActive: true}, nil).Once()
+			},
+		},
+		{
+			name: "ok - the role is taken from the db after the administrator changed it while the token is still live",
+			input: usecase.AuthenticateAccessTokenInput{
+				Token: validParentTokenString,
+			},
+			wantErr: false,
+			expectedOutput: &usecase.AuthenticateAccessTokenOutput{
+				UserID:    parentID,
+				UserRole:  model.RolesTherapist,
+				SessionID: parentSessionID,
+			},
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validParentTokenString, validateJWTOpts).Return(validParentToken, nil).Once()
+				mockSessionRepo.EXPECT().FindByID(ctx, parentSessionID).Return(&model.Session{
+					ID:         parentSessionID,
+					UserID:     parentID,
+					ExpiresAt:  time.Now().Add(time.Hour),
+					LastSeenAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, parentID).Return(&model.User{ID: parentID, Roles: model.RolesTherapist, IsActive: true}, nil).Once()
 			},
 		},
 	}
@@ -2015,6 +2794,10 @@ func TestAuthUsecase_AllowAccess(t *testing.T) {
 				t.Errorf("expecting usecase error but got %T", err)
 			case usecase.UsecaseError:
 				assert.Equal(t, tc.expectedErr, e.ErrType)
+
+				if tc.expectedErrMessage != "" {
+					assert.Equal(t, tc.expectedErrMessage, e.Message)
+				}
 			}
 		})
 	}
@@ -2027,11 +2810,14 @@ func TestAuthUSecase_HandleResendSignupVerification(t *testing.T) {
 	mockUserRepo := mockUsecase.NewUserRepository(t)
 	mockMailer := mockCommon.NewMailerIface(t)
 	mockRateLimiter := mockUsecase.NewRateLimiter(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
 
-	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, mockMailer, mockRateLimiter)
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, mockMailer, mockRateLimiter, nil, mockEmailTokenRepo, nil, nil, nil, nil, nil)
 
 	userEmail := "email@sample.com"
 	encryptedUserEmail := "encryptedUserEmail"
+	emailBlindIndex := "email-blind-index"
+	emailLookup := usecase.RepoEmailLookup{BlindIndex: emailBlindIndex, LegacyEncryptedEmails: []string{encryptedUserEmail}}
 	limiterAllow := redis_rate.Result{
 		Allowed: 10,
 	}
@@ -2077,7 +2863,7 @@ func TestAuthUSecase_HandleResendSignupVerification(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return("", assert.AnError).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return(nil, assert.AnError).Once()
 			},
 		},
 		{
@@ -2088,8 +2874,9 @@ func TestAuthUSecase_HandleResendSignupVerification(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockRateLimiter.EXPECT().Allow(ctx, encryptedUserEmail, mock.Anything).Return(nil, assert.AnError).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, emailBlindIndex, mock.Anything).Return(nil, assert.AnError).Once()
 			},
 		},
 		{
@@ -2100,8 +2887,9 @@ func TestAuthUSecase_HandleResendSignupVerification(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrTooManyRequests,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockRateLimiter.EXPECT().Allow(ctx, encryptedUserEmail, mock.Anything).Return(&redis_rate.Result{
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, emailBlindIndex, mock.Anything).Return(&redis_rate.Result{
 					Allowed: 0,
 				}, nil).Once()
 			},
@@ -2114,35 +2902,91 @@ func TestAuthUSecase_HandleResendSignupVerification(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockRateLimiter.EXPECT().Allow(ctx, encryptedUserEmail, mock.Anything).Return(&limiterAllow, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, encryptedUserEmail).Return(nil, assert.AnError).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, emailBlindIndex, mock.Anything).Return(&limiterAllow, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(nil, assert.AnError).Once()
 			},
 		},
 		{
-			name: "repository return user not found error",
+			name: "failed to notify the owner of unregistered email",
 			input: usecase.ResendSignupVerificationInput{
 				Email: userEmail,
 			},
 			wantErr:     true,
-			expectedErr: usecase.ErrNotFound,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, emailBlindIndex, mock.Anything).Return(&limiterAllow, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name: "unregistered email get the same response, only the email owner is notified",
+			input: usecase.ResendSignupVerificationInput{
+				Email: userEmail,
+			},
+			wantErr: false,
+			expectedOutput: &usecase.ResendSignupVerificationOutput{
+				Message: "email confirmation sent",
+			},
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockRateLimiter.EXPECT().Allow(ctx, encryptedUserEmail, mock.Anything).Return(&limiterAllow, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, encryptedUserEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, emailBlindIndex, mock.Anything).Return(&limiterAllow, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockMailer.EXPECT().SendEmail(ctx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == userEmail
+				})).Return(&lib.CreateSmtpEmail{}, nil).Once()
 			},
 		},
 		{
-			name: "bad request if user already active",
+			name: "failed to notify the owner of already active account",
 			input: usecase.ResendSignupVerificationInput{
 				Email: userEmail,
 			},
 			wantErr:     true,
-			expectedErr: usecase.ErrBadRequest,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, emailBlindIndex, mock.Anything).Return(&limiterAllow, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&model.User{IsActive: true}, nil).Once()
+				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name: "already active account get the same response, only the email owner is notified",
+			input: usecase.ResendSignupVerificationInput{
+				Email: userEmail,
+			},
+			wantErr: false,
+			expectedOutput: &usecase.ResendSignupVerificationOutput{
+				Message: "email confirmation sent",
+			},
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, emailBlindIndex, mock.Anything).Return(&limiterAllow, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&model.User{IsActive: true}, nil).Once()
+				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(&lib.CreateSmtpEmail{}, nil).Once()
+			},
+		},
+		{
+			name: "failed to revoke previous verification tokens",
+			input: usecase.ResendSignupVerificationInput{
+				Email: userEmail,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockRateLimiter.EXPECT().Allow(ctx, encryptedUserEmail, mock.Anything).Return(&limiterAllow, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, encryptedUserEmail).Return(&model.User{IsActive: true}, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, emailBlindIndex, mock.Anything).Return(&limiterAllow, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(user, nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(ctx, user.ID, string(usecase.SignupVerificationToken)).Return(assert.AnError).Once()
 			},
 		},
 		{
@@ -2153,9 +2997,12 @@ func TestAuthUSecase_HandleResendSignupVerification(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockRateLimiter.EXPECT().Allow(ctx, encryptedUserEmail, mock.Anything).Return(&limiterAllow, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, encryptedUserEmail).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, emailBlindIndex, mock.Anything).Return(&limiterAllow, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(user, nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(ctx, user.ID, string(usecase.SignupVerificationToken)).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
 				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("", assert.AnError).Once()
 			},
 		},
@@ -2167,9 +3014,12 @@ func TestAuthUSecase_HandleResendSignupVerification(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockRateLimiter.EXPECT().Allow(ctx, encryptedUserEmail, mock.Anything).Return(&limiterAllow, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, encryptedUserEmail).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, emailBlindIndex, mock.Anything).Return(&limiterAllow, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(user, nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(ctx, user.ID, string(usecase.SignupVerificationToken)).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
 				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("token", nil).Once()
 				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(nil, assert.AnError).Once()
 			},
@@ -2184,9 +3034,12 @@ func TestAuthUSecase_HandleResendSignupVerification(t *testing.T) {
 				Message: "email confirmation sent",
 			},
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockRateLimiter.EXPECT().Allow(ctx, encryptedUserEmail, mock.Anything).Return(&limiterAllow, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(ctx, encryptedUserEmail).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, emailBlindIndex, mock.Anything).Return(&limiterAllow, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(user, nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(ctx, user.ID, string(usecase.SignupVerificationToken)).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
 				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("token", nil).Once()
 				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(&lib.CreateSmtpEmail{}, nil).Once()
 			},
@@ -2230,6 +3083,9 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 	mockTxCtrlFactory := mockUsecase.NewTransactionControllerFactory(t)
 	mockMailer := mockCommon.NewMailerIface(t)
 	mockRateLimiter := mockUsecase.NewRateLimiter(t)
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
 
 	user := model.AuthUser{
 		ID:   uuid.New(),
@@ -2237,10 +3093,29 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 	}
 	userEmail := "email@sample.com"
 	encryptedUserEmail := "encryptedUserEmail"
+	emailBlindIndex := "email-blind-index"
+	emailLookup := usecase.RepoEmailLookup{BlindIndex: emailBlindIndex, LegacyEncryptedEmails: []string{encryptedUserEmail}}
+	restoreToken := "restoreToken"
 
 	userCtx := model.SetUserToCtx(ctx, user)
 
-	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, mockResultRepo, mockChildRepo, mockTxCtrlFactory, mockMailer, mockRateLimiter)
+	uc := usecase.NewAuthUsecase(
+		mockSharedCryptor, mockUserRepo, mockResultRepo, mockChildRepo, mockTxCtrlFactory, mockMailer, mockRateLimiter,
+		mockSessionRepo, mockEmailTokenRepo, nil, nil, nil, nil, mockAuditLogRepo,
+	)
+
+	expectPasswordVerified := func() {
+		mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+		mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+		mockUserRepo.EXPECT().FindByEmail(userCtx, emailLookup).Return(
+			&model.User{
+				ID:       user.ID,
+				Email:    encryptedUserEmail,
+				IsActive: true,
+			}, nil,
+		).Once()
+		mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte("password")).Return(nil).Once()
+	}
 
 	testCases := []struct {
 		name                 string
@@ -2268,7 +3143,7 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 				Password: "password",
 			},
 			wantErr:     true,
-			expectedErr: usecase.ErrUnauthorized,
+			expectedErr: usecase.ErrForbidden,
 		},
 		{
 			name: "email is required",
@@ -2318,7 +3193,7 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return("", assert.AnError).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return(nil, assert.AnError).Once()
 			},
 		},
 		{
@@ -2331,8 +3206,9 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(userCtx, encryptedUserEmail).Return(nil, assert.AnError).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(userCtx, emailLookup).Return(nil, assert.AnError).Once()
 			},
 		},
 		{
@@ -2345,8 +3221,9 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrNotFound,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(userCtx, encryptedUserEmail).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(userCtx, emailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
 			},
 		},
 		{
@@ -2359,8 +3236,9 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrForbidden,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(userCtx, encryptedUserEmail).Return(&model.User{ID: uuid.New()}, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(userCtx, emailLookup).Return(&model.User{ID: uuid.New()}, nil).Once()
 			},
 		},
 		{
@@ -2373,8 +3251,9 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrBadRequest,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(userCtx, encryptedUserEmail).Return(&model.User{ID: user.ID, IsActive: false}, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(userCtx, emailLookup).Return(&model.User{ID: user.ID, IsActive: false}, nil).Once()
 			},
 		},
 		{
@@ -2387,8 +3266,9 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrUnauthorized,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(userCtx, encryptedUserEmail).Return(
+				mockSharedCryptor.EXPECT().LegacyEncrypt(userEmail).Return([]string{encryptedUserEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(userEmail).Return(emailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(userCtx, emailLookup).Return(
 					&model.User{
 						ID:       user.ID,
 						IsActive: true,
@@ -2397,6 +3277,20 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte("password")).Return(assert.AnError).Once()
 			},
 		},
+		{
+			name: "failed to decrypt user's email",
+			input: usecase.DeleteUserDataInput{
+				Email:    userEmail,
+				Password: "password",
+			},
+			ctx:         userCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectPasswordVerified()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedUserEmail).Return("", assert.AnError).Once()
+			},
+		},
 		{
 			name: "failed to delete user's results",
 			input: usecase.DeleteUserDataInput{
@@ -2407,14 +3301,8 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(userCtx, encryptedUserEmail).Return(
-					&model.User{
-						ID:       user.ID,
-						IsActive: true,
-					}, nil,
-				).Once()
-				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte("password")).Return(nil).Once()
+				expectPasswordVerified()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedUserEmail).Return(userEmail, nil).Once()
 
 				underlyingTransaction := mockUsecase.NewTransactionController(t)
 				txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
@@ -2423,8 +3311,7 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
 
 				mockResultRepo.EXPECT().DeleteAllUserResults(userCtx, usecase.RepoDeleteAllUserResultsInput{
-					UserID:     user.ID,
-					HardDelete: true,
+					UserID: user.ID,
 				}, mock.Anything).Return(assert.AnError).Once()
 				underlyingTransaction.EXPECT().Rollback().Return(assert.AnError).Once()
 			},
@@ -2439,14 +3326,8 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(userCtx, encryptedUserEmail).Return(
-					&model.User{
-						ID:       user.ID,
-						IsActive: true,
-					}, nil,
-				).Once()
-				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte("password")).Return(nil).Once()
+				expectPasswordVerified()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedUserEmail).Return(userEmail, nil).Once()
 
 				underlyingTransaction := mockUsecase.NewTransactionController(t)
 				txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
@@ -2455,18 +3336,16 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
 
 				mockResultRepo.EXPECT().DeleteAllUserResults(userCtx, usecase.RepoDeleteAllUserResultsInput{
-					UserID:     user.ID,
-					HardDelete: true,
+					UserID: user.ID,
 				}, mock.Anything).Return(nil).Once()
 				mockChildRepo.EXPECT().DeleteAllUserChildren(userCtx, usecase.RepoDeleteAllUserChildrenInput{
-					UserID:     user.ID,
-					HardDelete: true,
+					UserID: user.ID,
 				}, mock.Anything).Return(assert.AnError).Once()
 				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
 			},
 		},
 		{
-			name: "failed to delete user data",
+			name: "failed to deactivate the account",
 			input: usecase.DeleteUserDataInput{
 				Email:    userEmail,
 				Password: "password",
@@ -2475,14 +3354,8 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 			wantErr:     true,
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(userCtx, encryptedUserEmail).Return(
-					&model.User{
-						ID:       user.ID,
-						IsActive: true,
-					}, nil,
-				).Once()
-				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte("password")).Return(nil).Once()
+				expectPasswordVerified()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedUserEmail).Return(userEmail, nil).Once()
 
 				underlyingTransaction := mockUsecase.NewTransactionController(t)
 				txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
@@ -2491,20 +3364,130 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
 
 				mockResultRepo.EXPECT().DeleteAllUserResults(userCtx, usecase.RepoDeleteAllUserResultsInput{
-					UserID:     user.ID,
-					HardDelete: true,
+					UserID: user.ID,
 				}, mock.Anything).Return(nil).Once()
 				mockChildRepo.EXPECT().DeleteAllUserChildren(userCtx, usecase.RepoDeleteAllUserChildrenInput{
-					UserID:     user.ID,
-					HardDelete: true,
+					UserID: user.ID,
 				}, mock.Anything).Return(nil).Once()
-				mockUserRepo.EXPECT().DeleteByID(userCtx, usecase.RepoDeleteUserByIDInput{
-					UserID:     user.ID,
-					HardDelete: true,
-				}, mock.Anything).Return(assert.AnError).Once()
+				mockUserRepo.EXPECT().UpdateDeletionRequestedAt(userCtx, user.ID, mock.MatchedBy(func(requestedAt sql.NullTime) bool {
+					return requestedAt.Valid
+				}), mock.Anything).Return(assert.AnError).Once()
 				underlyingTransaction.EXPECT().Rollback().Return(assert.AnError).Once()
 			},
 		},
+		{
+			name: "failed to create restore token",
+			input: usecase.DeleteUserDataInput{
+				Email:    userEmail,
+				Password: "password",
+			},
+			ctx:         userCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectPasswordVerified()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedUserEmail).Return(userEmail, nil).Once()
+
+				underlyingTransaction := mockUsecase.NewTransactionController(t)
+				txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
+
+				mockTxCtrlFactory.EXPECT().New().Return(txCtrlWrapper).Once()
+				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+				mockResultRepo.EXPECT().DeleteAllUserResults(userCtx, mock.Anything, mock.Anything).Return(nil).Once()
+				mockChildRepo.EXPECT().DeleteAllUserChildren(userCtx, mock.Anything, mock.Anything).Return(nil).Once()
+				mockUserRepo.EXPECT().UpdateDeletionRequestedAt(userCtx, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(userCtx, mock.MatchedBy(func(input usecase.RepoCreateEmailTokenInput) bool {
+					return input.UserID == user.ID && input.Purpose == string(usecase.AccountRestoreToken)
+				}), mock.Anything).Return(nil, assert.AnError).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name: "failed to send the restore email",
+			input: usecase.DeleteUserDataInput{
+				Email:    userEmail,
+				Password: "password",
+			},
+			ctx:         userCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectPasswordVerified()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedUserEmail).Return(userEmail, nil).Once()
+
+				underlyingTransaction := mockUsecase.NewTransactionController(t)
+				txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
+
+				mockTxCtrlFactory.EXPECT().New().Return(txCtrlWrapper).Once()
+				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+				mockResultRepo.EXPECT().DeleteAllUserResults(userCtx, mock.Anything, mock.Anything).Return(nil).Once()
+				mockChildRepo.EXPECT().DeleteAllUserChildren(userCtx, mock.Anything, mock.Anything).Return(nil).Once()
+				mockUserRepo.EXPECT().UpdateDeletionRequestedAt(userCtx, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(userCtx, mock.Anything, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return(restoreToken, nil).Once()
+				mockMailer.EXPECT().SendEmail(userCtx, mock.Anything).Return(nil, assert.AnError).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name: "failed to commit",
+			input: usecase.DeleteUserDataInput{
+				Email:    userEmail,
+				Password: "password",
+			},
+			ctx:         userCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectPasswordVerified()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedUserEmail).Return(userEmail, nil).Once()
+
+				underlyingTransaction := mockUsecase.NewTransactionController(t)
+				txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
+
+				mockTxCtrlFactory.EXPECT().New().Return(txCtrlWrapper).Once()
+				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+				mockResultRepo.EXPECT().DeleteAllUserResults(userCtx, mock.Anything, mock.Anything).Return(nil).Once()
+				mockChildRepo.EXPECT().DeleteAllUserChildren(userCtx, mock.Anything, mock.Anything).Return(nil).Once()
+				mockUserRepo.EXPECT().UpdateDeletionRequestedAt(userCtx, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(userCtx, mock.Anything, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return(restoreToken, nil).Once()
+				mockMailer.EXPECT().SendEmail(userCtx, mock.Anything).Return(nil, nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(assert.AnError).Once()
+			},
+		},
+		{
+			name: "failed to revoke the sessions",
+			input: usecase.DeleteUserDataInput{
+				Email:    userEmail,
+				Password: "password",
+			},
+			ctx:         userCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectPasswordVerified()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedUserEmail).Return(userEmail, nil).Once()
+
+				underlyingTransaction := mockUsecase.NewTransactionController(t)
+				txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
+
+				mockTxCtrlFactory.EXPECT().New().Return(txCtrlWrapper).Once()
+				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+				mockResultRepo.EXPECT().DeleteAllUserResults(userCtx, mock.Anything, mock.Anything).Return(nil).Once()
+				mockChildRepo.EXPECT().DeleteAllUserChildren(userCtx, mock.Anything, mock.Anything).Return(nil).Once()
+				mockUserRepo.EXPECT().UpdateDeletionRequestedAt(userCtx, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(userCtx, mock.Anything, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return(restoreToken, nil).Once()
+				mockMailer.EXPECT().SendEmail(userCtx, mock.Anything).Return(nil, nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(nil).Once()
+				mockSessionRepo.EXPECT().RevokeAllUserSessions(userCtx, user.ID).Return(assert.AnError).Once()
+			},
+		},
 		{
 			name: "ok",
 			input: usecase.DeleteUserDataInput{
@@ -2513,14 +3496,8 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 			},
 			ctx: userCtx,
 			expectedFunctionCall: func() {
-				mockSharedCryptor.EXPECT().Encrypt(userEmail).Return(encryptedUserEmail, nil).Once()
-				mockUserRepo.EXPECT().FindByEmail(userCtx, encryptedUserEmail).Return(
-					&model.User{
-						ID:       user.ID,
-						IsActive: true,
-					}, nil,
-				).Once()
-				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte("password")).Return(nil).Once()
+				expectPasswordVerified()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedUserEmail).Return(userEmail, nil).Once()
 
 				underlyingTransaction := mockUsecase.NewTransactionController(t)
 				txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
@@ -2529,18 +3506,30 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
 
 				mockResultRepo.EXPECT().DeleteAllUserResults(userCtx, usecase.RepoDeleteAllUserResultsInput{
-					UserID:     user.ID,
-					HardDelete: true,
+					UserID: user.ID,
 				}, mock.Anything).Return(nil).Once()
 				mockChildRepo.EXPECT().DeleteAllUserChildren(userCtx, usecase.RepoDeleteAllUserChildrenInput{
-					UserID:     user.ID,
-					HardDelete: true,
-				}, mock.Anything).Return(nil).Once()
-				mockUserRepo.EXPECT().DeleteByID(userCtx, usecase.RepoDeleteUserByIDInput{
-					UserID:     user.ID,
-					HardDelete: true,
+					UserID: user.ID,
 				}, mock.Anything).Return(nil).Once()
+				mockUserRepo.EXPECT().UpdateDeletionRequestedAt(userCtx, user.ID, mock.MatchedBy(func(requestedAt sql.NullTime) bool {
+					return requestedAt.Valid && time.Since(requestedAt.Time) < time.Minute
+				}), mock.Anything).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(userCtx, mock.MatchedBy(func(input usecase.RepoCreateEmailTokenInput) bool {
+					return input.UserID == user.ID && input.Purpose == string(usecase.AccountRestoreToken)
+				}), mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return(restoreToken, nil).Once()
+				mockMailer.EXPECT().SendEmail(userCtx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == userEmail && strings.Contains(input.HTMLContent, restoreToken)
+				})).Return(nil, nil).Once()
 				underlyingTransaction.EXPECT().Commit().Return(nil).Once()
+				mockSessionRepo.EXPECT().RevokeAllUserSessions(userCtx, user.ID).Return(nil).Once()
+				mockAuditLogRepo.EXPECT().Create(userCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    user.ID,
+					ActorRole:  model.RolesParent,
+					Action:     model.AuditActionDeleteAccount,
+					TargetType: model.AuditTargetUser,
+					TargetID:   user.ID.String(),
+				}).Return(nil).Once()
 			},
 		},
 	}
@@ -2570,3 +3559,1131 @@ func TestAuthUsecase_HandleDeleteUserData(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthUsecase_HandleRefreshToken(t *testing.T) {
+	ctx := context.Background()
+
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil, nil)
+
+	user := &model.User{
+		ID:       uuid.New(),
+		IsActive: true,
+		Roles:    model.RolesParent,
+	}
+
+	secret := "refreshTokenSecret"
+	session := &model.Session{
+		ID:               uuid.New(),
+		UserID:           user.ID,
+		RefreshTokenHash: common.HashToken(secret),
+		ExpiresAt:        time.Now().Add(time.Hour),
+	}
+	validRefreshToken := session.ID.String() + "." + secret
+	sampleJWTToken := "jwtToken"
+
+	testCases := []struct {
+		name                 string
+		input                usecase.RefreshTokenInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "refresh token is required",
+			input:       usecase.RefreshTokenInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name: "malformed refresh token",
+			input: usecase.RefreshTokenInput{
+				RefreshToken: "malformed",
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name: "session id in refresh token is not a valid uuid",
+			input: usecase.RefreshTokenInput{
+				RefreshToken: "invalid-uuid." + secret,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name: "failed to find session",
+			input: usecase.RefreshTokenInput{
+				RefreshToken: validRefreshToken,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSessionRepo.EXPECT().FindByID(ctx, session.ID).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name: "session not found",
+			input: usecase.RefreshTokenInput{
+				RefreshToken: validRefreshToken,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSessionRepo.EXPECT().FindByID(ctx, session.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name: "session already expired",
+			input: usecase.RefreshTokenInput{
+				RefreshToken: validRefreshToken,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				expired := *session
+				expired.ExpiresAt = time.Now().Add(-time.Hour)
+
+				mockSessionRepo.EXPECT().FindByID(ctx, session.ID).Return(&expired, nil).Once()
+			},
+		},
+		{
+			name: "reusing an old refresh token revokes the session",
+			input: usecase.RefreshTokenInput{
+				RefreshToken: session.ID.String() + ".oldSecret",
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSessionRepo.EXPECT().FindByID(ctx, session.ID).Return(session, nil).Once()
+				mockSessionRepo.EXPECT().RevokeByID(ctx, session.ID).Return(nil).Once()
+			},
+		},
+		{
+			name: "user is no longer active",
+			input: usecase.RefreshTokenInput{
+				RefreshToken: validRefreshToken,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				inactiveUser := *user
+				inactiveUser.IsActive = false
+
+				mockSessionRepo.EXPECT().FindByID(ctx, session.ID).Return(session, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(&inactiveUser, nil).Once()
+			},
+		},
+		{
+			name: "refresh token was already rotated by another request",
+			input: usecase.RefreshTokenInput{
+				RefreshToken: validRefreshToken,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSessionRepo.EXPECT().FindByID(ctx, session.ID).Return(session, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(user, nil).Once()
+				mockSessionRepo.EXPECT().RotateRefreshToken(ctx, mock.Anything).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name: "failed to create access token",
+			input: usecase.RefreshTokenInput{
+				RefreshToken: validRefreshToken,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSessionRepo.EXPECT().FindByID(ctx, session.ID).Return(session, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(user, nil).Once()
+				mockSessionRepo.EXPECT().RotateRefreshToken(ctx, mock.Anything).Return(session, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("", assert.AnError).Once()
+			},
+		},
+		{
+			name: "ok",
+			input: usecase.RefreshTokenInput{
+				RefreshToken: validRefreshToken,
+			},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockSessionRepo.EXPECT().FindByID(ctx, session.ID).Return(session, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(user, nil).Once()
+				mockSessionRepo.EXPECT().RotateRefreshToken(ctx, mock.MatchedBy(func(input usecase.RepoRotateRefreshTokenInput) bool {
+					return input.SessionID == session.ID &&
+						input.OldRefreshTokenHash == session.RefreshTokenHash &&
+						input.NewRefreshTokenHash != session.RefreshTokenHash
+				})).Return(session, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.MatchedBy(func(claims model.LoginTokenClaims) bool {
+					return claims.SessionID == session.ID.String() && claims.Role == user.Roles
+				})).Return(sampleJWTToken, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleRefreshToken(ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, sampleJWTToken, res.Token)
+				assert.True(t, strings.HasPrefix(res.RefreshToken, session.ID.String()+"."))
+				assert.NotEqual(t, validRefreshToken, res.RefreshToken)
+
+				return
+			}
+
+			require.Error(t, err)
+
+			switch e := err.(type) {
+			default:
+				t.Errorf("expecting usecase error but got %T", err)
+			case usecase.UsecaseError:
+				assert.Equal(t, tc.expectedErr, e.ErrType)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_HandleLogout(t *testing.T) {
+	ctx := context.Background()
+
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+
+	uc := usecase.NewAuthUsecase(nil, nil, nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil, nil)
+
+	user := model.AuthUser{
+		ID:        uuid.New(),
+		Role:      model.RolesParent,
+		SessionID: uuid.New(),
+	}
+	userCtx := model.SetUserToCtx(ctx, user)
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "failed to revoke session",
+			ctx:         userCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSessionRepo.EXPECT().RevokeByID(userCtx, user.SessionID).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok",
+			ctx:     userCtx,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockSessionRepo.EXPECT().RevokeByID(userCtx, user.SessionID).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := uc.HandleLogout(tc.ctx)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+
+			switch e := err.(type) {
+			default:
+				t.Errorf("expecting usecase error but got %T", err)
+			case usecase.UsecaseError:
+				assert.Equal(t, tc.expectedErr, e.ErrType)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_HandleLogoutAll(t *testing.T) {
+	ctx := context.Background()
+
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+
+	uc := usecase.NewAuthUsecase(nil, nil, nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil, nil)
+
+	user := model.AuthUser{
+		ID:        uuid.New(),
+		Role:      model.RolesParent,
+		SessionID: uuid.New(),
+	}
+	userCtx := model.SetUserToCtx(ctx, user)
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "failed to revoke all sessions",
+			ctx:         userCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSessionRepo.EXPECT().RevokeAllUserSessions(userCtx, user.ID).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok",
+			ctx:     userCtx,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockSessionRepo.EXPECT().RevokeAllUserSessions(userCtx, user.ID).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := uc.HandleLogoutAll(tc.ctx)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+
+			switch e := err.(type) {
+			default:
+				t.Errorf("expecting usecase error but got %T", err)
+			case usecase.UsecaseError:
+				assert.Equal(t, tc.expectedErr, e.ErrType)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_HandleAdminForceResetPassword(t *testing.T) {
+	ctx := context.Background()
+
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockMailer := mockCommon.NewMailerIface(t)
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, mockMailer, nil, mockSessionRepo, mockEmailTokenRepo, nil, nil, nil, nil, nil)
+
+	adminCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator})
+	parentCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesParent})
+
+	userID := uuid.New()
+	user := &model.User{
+		ID:       userID,
+		Email:    "encrypted-email",
+		Username: "user",
+		IsActive: true,
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.AdminForceResetPasswordInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       usecase.AdminForceResetPasswordInput{UserID: userID},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "forbidden for non administrator",
+			ctx:         parentCtx,
+			input:       usecase.AdminForceResetPasswordInput{UserID: userID},
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:        "missing user id",
+			ctx:         adminCtx,
+			input:       usecase.AdminForceResetPasswordInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "user not found",
+			ctx:         adminCtx,
+			input:       usecase.AdminForceResetPasswordInput{UserID: userID},
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, userID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "failed to decrypt email",
+			ctx:         adminCtx,
+			input:       usecase.AdminForceResetPasswordInput{UserID: userID},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, userID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(user.Email).Return("", assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to scramble the password",
+			ctx:         adminCtx,
+			input:       usecase.AdminForceResetPasswordInput{UserID: userID},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, userID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(user.Email).Return("user@example.com", nil).Once()
+				mockSharedCryptor.EXPECT().Hash(mock.Anything).Return("hashed", nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, userID, usecase.RepoUpdateUserInput{Password: "hashed"}).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to revoke sessions",
+			ctx:         adminCtx,
+			input:       usecase.AdminForceResetPasswordInput{UserID: userID},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, userID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(user.Email).Return("user@example.com", nil).Once()
+				mockSharedCryptor.EXPECT().Hash(mock.Anything).Return("hashed", nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, userID, usecase.RepoUpdateUserInput{Password: "hashed"}).Return(user, nil).Once()
+				mockSessionRepo.EXPECT().RevokeAllUserSessions(adminCtx, userID).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to send email",
+			ctx:         adminCtx,
+			input:       usecase.AdminForceResetPasswordInput{UserID: userID},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, userID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(user.Email).Return("user@example.com", nil).Once()
+				mockSharedCryptor.EXPECT().Hash(mock.Anything).Return("hashed", nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, userID, usecase.RepoUpdateUserInput{Password: "hashed"}).Return(user, nil).Once()
+				mockSessionRepo.EXPECT().RevokeAllUserSessions(adminCtx, userID).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(adminCtx, userID, string(usecase.ChangePasswordToken)).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(adminCtx, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("token", nil).Once()
+				mockMailer.EXPECT().SendEmail(adminCtx, mock.Anything).Return(&lib.CreateSmtpEmail{}, assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok",
+			ctx:     adminCtx,
+			input:   usecase.AdminForceResetPasswordInput{UserID: userID},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, userID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(user.Email).Return("user@example.com", nil).Once()
+				mockSharedCryptor.EXPECT().Hash(mock.Anything).Return("hashed", nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, userID, usecase.RepoUpdateUserInput{Password: "hashed"}).Return(user, nil).Once()
+				mockSessionRepo.EXPECT().RevokeAllUserSessions(adminCtx, userID).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(adminCtx, userID, string(usecase.ChangePasswordToken)).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(adminCtx, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("token", nil).Once()
+				mockMailer.EXPECT().SendEmail(adminCtx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == "user@example.com" && strings.Contains(input.HTMLContent, "token")
+				})).Return(&lib.CreateSmtpEmail{}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleAdminForceResetPassword(tc.ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, "ok", res.Message)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Nil(t, res)
+
+			switch e := err.(type) {
+			default:
+				t.Errorf("expecting usecase error but got %T", err)
+			case usecase.UsecaseError:
+				assert.Equal(t, tc.expectedErr, e.ErrType)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_HandleChangePassword(t *testing.T) {
+	ctx := context.Background()
+
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockMailer := mockCommon.NewMailerIface(t)
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+
+	passwordPolicy := common.NewPasswordPolicy(8, []string{"password123"})
+
+	uc := usecase.NewAuthUsecase(
+		mockSharedCryptor, mockUserRepo, nil, nil, nil, mockMailer, nil, mockSessionRepo, mockEmailTokenRepo, nil, passwordPolicy, nil, nil, nil,
+	)
+
+	requester := model.AuthUser{
+		ID:        uuid.New(),
+		Role:      model.RolesParent,
+		SessionID: uuid.New(),
+	}
+	userCtx := model.SetUserToCtx(ctx, requester)
+
+	// the password hash is stored base64 encoded, while CompareHash must receive the decoded hash
+	passwordHash := []byte("hashed-current-password")
+	user := &model.User{
+		ID:       requester.ID,
+		Email:    "encrypted-email",
+		Password: base64.StdEncoding.EncodeToString(passwordHash),
+		Username: "user",
+		IsActive: true,
+	}
+
+	validInput := usecase.ChangePasswordInput{
+		CurrentPassword: "current-password",
+		NewPassword:     "n3w-str0ng-passw0rd",
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.ChangePasswordInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "missing current password",
+			ctx:         userCtx,
+			input:       usecase.ChangePasswordInput{NewPassword: validInput.NewPassword},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "user not found",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "failed to find user",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "stored password hash is not base64 encoded",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(&model.User{
+					ID:       requester.ID,
+					Password: "not-base64-encoded!",
+					IsActive: true,
+				}, nil).Once()
+			},
+		},
+		{
+			name:        "wrong current password",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(passwordHash, []byte(validInput.CurrentPassword)).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name: "new password is the same as the current one",
+			ctx:  userCtx,
+			input: usecase.ChangePasswordInput{
+				CurrentPassword: validInput.CurrentPassword,
+				NewPassword:     validInput.CurrentPassword,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(passwordHash, []byte(validInput.CurrentPassword)).Return(nil).Once()
+			},
+		},
+		{
+			name: "new password is too short",
+			ctx:  userCtx,
+			input: usecase.ChangePasswordInput{
+				CurrentPassword: validInput.CurrentPassword,
+				NewPassword:     "short",
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(passwordHash, []byte(validInput.CurrentPassword)).Return(nil).Once()
+			},
+		},
+		{
+			name: "new password is too long",
+			ctx:  userCtx,
+			input: usecase.ChangePasswordInput{
+				CurrentPassword: validInput.CurrentPassword,
+				NewPassword:     strings.Repeat("a", common.PasswordMaxLength+1),
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(passwordHash, []byte(validInput.CurrentPassword)).Return(nil).Once()
+			},
+		},
+		{
+			name: "new password is listed as breached regardless of the case",
+			ctx:  userCtx,
+			input: usecase.ChangePasswordInput{
+				CurrentPassword: validInput.CurrentPassword,
+				NewPassword:     "PassWord123",
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(passwordHash, []byte(validInput.CurrentPassword)).Return(nil).Once()
+			},
+		},
+		{
+			name:        "failed to hash new password",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(passwordHash, []byte(validInput.CurrentPassword)).Return(nil).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(validInput.NewPassword)).Return("", assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to update password",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(passwordHash, []byte(validInput.CurrentPassword)).Return(nil).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(validInput.NewPassword)).Return("hashed", nil).Once()
+				mockUserRepo.EXPECT().Update(userCtx, user.ID, usecase.RepoUpdateUserInput{Password: "hashed"}).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to revoke other sessions",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(passwordHash, []byte(validInput.CurrentPassword)).Return(nil).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(validInput.NewPassword)).Return("hashed", nil).Once()
+				mockUserRepo.EXPECT().Update(userCtx, user.ID, usecase.RepoUpdateUserInput{Password: "hashed"}).Return(user, nil).Once()
+				mockSessionRepo.EXPECT().RevokeOtherUserSessions(userCtx, user.ID, requester.SessionID).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to revoke reset password tokens",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(passwordHash, []byte(validInput.CurrentPassword)).Return(nil).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(validInput.NewPassword)).Return("hashed", nil).Once()
+				mockUserRepo.EXPECT().Update(userCtx, user.ID, usecase.RepoUpdateUserInput{Password: "hashed"}).Return(user, nil).Once()
+				mockSessionRepo.EXPECT().RevokeOtherUserSessions(userCtx, user.ID, requester.SessionID).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(userCtx, user.ID, string(usecase.ChangePasswordToken)).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:    "failing to send the confirmation email must not fail the request",
+			ctx:     userCtx,
+			input:   validInput,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(passwordHash, []byte(validInput.CurrentPassword)).Return(nil).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(validInput.NewPassword)).Return("hashed", nil).Once()
+				mockUserRepo.EXPECT().Update(userCtx, user.ID, usecase.RepoUpdateUserInput{Password: "hashed"}).Return(user, nil).Once()
+				mockSessionRepo.EXPECT().RevokeOtherUserSessions(userCtx, user.ID, requester.SessionID).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(userCtx, user.ID, string(usecase.ChangePasswordToken)).Return(nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(user.Email).Return("user@example.com", nil).Once()
+				mockMailer.EXPECT().SendEmail(userCtx, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok",
+			ctx:     userCtx,
+			input:   validInput,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(passwordHash, []byte(validInput.CurrentPassword)).Return(nil).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(validInput.NewPassword)).Return("hashed", nil).Once()
+				mockUserRepo.EXPECT().Update(userCtx, user.ID, usecase.RepoUpdateUserInput{Password: "hashed"}).Return(user, nil).Once()
+				mockSessionRepo.EXPECT().RevokeOtherUserSessions(userCtx, user.ID, requester.SessionID).Return(nil).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(userCtx, user.ID, string(usecase.ChangePasswordToken)).Return(nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(user.Email).Return("user@example.com", nil).Once()
+				mockMailer.EXPECT().SendEmail(userCtx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == "user@example.com" && input.Subject == "Kata Sandi Berhasil Diubah"
+				})).Return(&lib.CreateSmtpEmail{}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleChangePassword(tc.ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, "your password has been changed", res.Message)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Nil(t, res)
+
+			switch e := err.(type) {
+			default:
+				t.Errorf("expecting usecase error but got %T", err)
+			case usecase.UsecaseError:
+				assert.Equal(t, tc.expectedErr, e.ErrType)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_HandleInviteTherapist(t *testing.T) {
+	ctx := context.Background()
+
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockMailer := mockCommon.NewMailerIface(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, mockMailer, nil, nil, mockEmailTokenRepo, nil, nil, nil, nil, nil)
+
+	adminCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator})
+	therapistCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesTherapist})
+
+	email := "therapist@example.com"
+	encryptedEmail := "encrypted-email"
+	emailBlindIndex := "email-blind-index"
+	emailLookup := usecase.RepoEmailLookup{BlindIndex: emailBlindIndex, LegacyEncryptedEmails: []string{"legacy-encrypted-email"}}
+	validInput := usecase.InviteTherapistInput{Email: email}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.InviteTherapistInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "forbidden for non administrator",
+			ctx:         therapistCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:        "invalid email",
+			ctx:         adminCtx,
+			input:       usecase.InviteTherapistInput{Email: "not-an-email"},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "email already used by another account",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().Encrypt(email).Return(encryptedEmail, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return(emailLookup.LegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(adminCtx, emailLookup).Return(&model.User{}, nil).Once()
+			},
+		},
+		{
+			name:        "failed to record invitation token",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().Encrypt(email).Return(encryptedEmail, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return(emailLookup.LegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(adminCtx, emailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockEmailTokenRepo.EXPECT().Create(adminCtx, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to send invitation email",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().Encrypt(email).Return(encryptedEmail, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return(emailLookup.LegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(adminCtx, emailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockEmailTokenRepo.EXPECT().Create(adminCtx, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("token", nil).Once()
+				mockMailer.EXPECT().SendEmail(adminCtx, mock.Anything).Return(&lib.CreateSmtpEmail{}, assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok",
+			ctx:     adminCtx,
+			input:   validInput,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().Encrypt(email).Return(encryptedEmail, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return(emailLookup.LegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(adminCtx, emailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockEmailTokenRepo.EXPECT().Create(adminCtx, mock.MatchedBy(func(input usecase.RepoCreateEmailTokenInput) bool {
+					return input.UserID == uuid.Nil && input.Purpose == string(usecase.TherapistInvitation)
+				})).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.MatchedBy(func(claims jwt.RegisteredClaims) bool {
+					return claims.Subject == string(usecase.TherapistInvitation) &&
+						len(claims.Audience) == 1 && claims.Audience[0] == encryptedEmail
+				})).Return("token", nil).Once()
+				mockMailer.EXPECT().SendEmail(adminCtx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == email && strings.Contains(input.HTMLContent, "token")
+				})).Return(&lib.CreateSmtpEmail{}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleInviteTherapist(tc.ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Message)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Nil(t, res)
+
+			switch e := err.(type) {
+			default:
+				t.Errorf("expecting usecase error but got %T", err)
+			case usecase.UsecaseError:
+				assert.Equal(t, tc.expectedErr, e.ErrType)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_HandleRedeemTherapistInvitation(t *testing.T) {
+	ctx := context.Background()
+
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockTxCtrlFactory := mockUsecase.NewTransactionControllerFactory(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, mockTxCtrlFactory, nil, nil, nil, mockEmailTokenRepo, nil, nil, nil, nil, nil)
+
+	validateJWTOpts := common.ValidateJWTOpts{
+		Issuer:  string(usecase.TokenIssuerSystem),
+		Subject: string(usecase.TherapistInvitation),
+	}
+
+	signingKey := []byte("key")
+	email := "therapist@example.com"
+	encryptedEmail := "encrypted-email"
+	emailBlindIndex := "email-blind-index"
+	emailLookup := usecase.RepoEmailLookup{BlindIndex: emailBlindIndex, LegacyEncryptedEmails: []string{"legacy-encrypted-email"}}
+	tokenID := uuid.New()
+	password := "validPassword123"
+	username := "therapist"
+
+	validToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": string(usecase.TokenIssuerSystem),
+		"sub": string(usecase.TherapistInvitation),
+		"aud": []string{encryptedEmail},
+		"exp": jwt.NewNumericDate(time.Now().Add(time.Hour * 1)).Unix(),
+		"jti": tokenID.String(),
+	})
+	validToken.Valid = true
+	validTokenString, _ := validToken.SignedString(signingKey)
+
+	validInput := usecase.RedeemTherapistInvitationInput{
+		InvitationToken: validTokenString,
+		Password:        password,
+		Username:        username,
+	}
+
+	consumeInput := usecase.RepoConsumeEmailTokenInput{
+		ID:      tokenID,
+		UserID:  uuid.Nil,
+		Purpose: string(usecase.TherapistInvitation),
+	}
+
+	createUserInput := usecase.RepoCreateUserInput{
+		Email:           encryptedEmail,
+		EmailBlindIndex: emailBlindIndex,
+		Password:        "hashed",
+		Username:        username,
+		IsActive:        true,
+		Roles:           model.RolesTherapist,
+	}
+
+	testCases := []struct {
+		name                 string
+		input                usecase.RedeemTherapistInvitationInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "missing required input",
+			input:       usecase.RedeemTherapistInvitationInput{InvitationToken: validTokenString},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "invalid invitation token",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to decrypt the invited email",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedEmail).Return("", assert.AnError).Once()
+			},
+		},
+		{
+			name:        "email already used by another account",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedEmail).Return(email, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return(emailLookup.LegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&model.User{}, nil).Once()
+			},
+		},
+		{
+			name:        "invitation has already been used",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedEmail).Return(email, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return(emailLookup.LegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(password)).Return("hashed", nil).Once()
+
+				underlyingTransaction := mockUsecase.NewTransactionController(t)
+				txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
+
+				mockTxCtrlFactory.EXPECT().New().Return(txCtrlWrapper).Once()
+				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(usecase.ErrRepoNotFound).Once()
+
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name:        "failed to create therapist account",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedEmail).Return(email, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return(emailLookup.LegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(password)).Return("hashed", nil).Once()
+
+				underlyingTransaction := mockUsecase.NewTransactionController(t)
+				txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
+
+				mockTxCtrlFactory.EXPECT().New().Return(txCtrlWrapper).Once()
+				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(nil).Once()
+				mockUserRepo.EXPECT().Create(ctx, createUserInput, mock.Anything).Return(nil, assert.AnError).Once()
+
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name:    "ok",
+			input:   validInput,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(validTokenString, validateJWTOpts).Return(validToken, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedEmail).Return(email, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return(emailLookup.LegacyEncryptedEmails, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockSharedCryptor.EXPECT().Hash([]byte(password)).Return("hashed", nil).Once()
+
+				underlyingTransaction := mockUsecase.NewTransactionController(t)
+				txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
+
+				mockTxCtrlFactory.EXPECT().New().Return(txCtrlWrapper).Once()
+				underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(nil).Once()
+				mockUserRepo.EXPECT().Create(ctx, createUserInput, mock.Anything).Return(&model.User{}, nil).Once()
+
+				underlyingTransaction.EXPECT().Commit().Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleRedeemTherapistInvitation(ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Message)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Nil(t, res)
+
+			switch e := err.(type) {
+			default:
+				t.Errorf("expecting usecase error but got %T", err)
+			case usecase.UsecaseError:
+				assert.Equal(t, tc.expectedErr, e.ErrType)
+			}
+		})
+	}
+}
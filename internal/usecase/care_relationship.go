@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/sweet-go/stdlib/helper"
+)
+
+// careRelation resolve the relation of the therapist assigned to the child based on the granted care scope.
+// RelationNone will be returned if the therapist is not assigned to the child
+func careRelation(ctx context.Context, careRelationshipRepo CareRelationshipRepository, therapistID, childID uuid.UUID) (Relation, error) {
+	relationship, err := careRelationshipRepo.FindByChildIDAndTherapistID(ctx, childID, therapistID)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"child_id":     childID,
+			"therapist_id": therapistID,
+		}).Error("failed to find care relationship")
+
+		return RelationNone, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return RelationNone, nil
+	case nil:
+		break
+	}
+
+	if relationship.Scope == model.CareScopeSubmit {
+		return RelationCareSubmitter, nil
+	}
+
+	return RelationCareReader, nil
+}
+
+// childRelation resolve the requester's relation to the child. RelationOwner for the child's parent,
+// RelationGuardian for the child's guardians, and the care relation for the therapist assigned to the child
+func childRelation(
+	ctx context.Context, careRelationshipRepo CareRelationshipRepository, childGuardianRepo ChildGuardianRepository,
+	requester *model.AuthUser, child *model.Child,
+) (Relation, error) {
+	if relation := ownerRelation(requester, child.ParentUserID); relation == RelationOwner {
+		return relation, nil
+	}
+
+	if requester == nil {
+		return RelationNone, nil
+	}
+
+	relation, err := guardianRelation(ctx, childGuardianRepo, requester.ID, child.ID)
+	if err != nil || relation != RelationNone {
+		return relation, err
+	}
+
+	if !IsAllowed(requester.Role, ActionReceiveCareAccess, RelationNone) {
+		return RelationNone, nil
+	}
+
+	return careRelation(ctx, careRelationshipRepo, requester.ID, child.ID)
+}
+
+// TherapistAccessOutput the therapist's access to the child
+type TherapistAccessOutput struct {
+	ID          uuid.UUID
+	ChildID     uuid.UUID
+	TherapistID uuid.UUID
+	Scope       model.CareScope
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func newTherapistAccessOutput(relationship model.CareRelationship) TherapistAccessOutput {
+	return TherapistAccessOutput{
+		ID:          relationship.ID,
+		ChildID:     relationship.ChildID,
+		TherapistID: relationship.TherapistID,
+		Scope:       relationship.Scope,
+		CreatedAt:   relationship.CreatedAt,
+		UpdatedAt:   relationship.UpdatedAt,
+	}
+}
+
+// GrantTherapistAccessInput input
+type GrantTherapistAccessInput struct {
+	TherapistID uuid.UUID       `validate:"required"`
+	ChildID     uuid.UUID       `validate:"required"`
+	Scope       model.CareScope `validate:"required,oneof=read submit"`
+}
+
+func (i GrantTherapistAccessInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// findManagedChild find the child and ensure the requester is allowed to manage who can take care of it
+func (u *UsersUsecase) findManagedChild(ctx context.Context, requester *model.AuthUser, childID uuid.UUID) (*model.Child, error) {
+	child, err := u.childRepo.FindByID(ctx, childID)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).WithField("child_id", childID).Error("failed to find child data from database")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: "child not found",
+		}
+	case nil:
+		break
+	}
+
+	if err := Authorize(requester, ActionManageCareAccess, ownerRelation(requester, child.ParentUserID)); err != nil {
+		return nil, err
+	}
+
+	return child, nil
+}
+
+// HandleGrantTherapistAccess allow the child's parent to assign a therapist from the therapist directory to the child.
+// The scope decides whether the therapist can only read the child's data or also submit results for the child.
+// Granting the access again to the same therapist will replace the previous scope
+func (u *UsersUsecase) HandleGrantTherapistAccess(ctx context.Context, input GrantTherapistAccessInput) (*TherapistAccessOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithField("input", helper.Dump(input))
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	child, err := u.findManagedChild(ctx, requester, input.ChildID)
+	if err != nil {
+		return nil, err
+	}
+
+	therapist, err := u.userRepo.FindByID(ctx, input.TherapistID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find therapist data from database")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: "therapist not found",
+		}
+	case nil:
+		break
+	}
+
+	if !IsAllowed(therapist.Roles, ActionReceiveCareAccess, RelationNone) || !therapist.IsActive {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "the access can only be granted to an active therapist",
+		}
+	}
+
+	relationship, err := u.careRelationshipRepo.Upsert(ctx, RepoUpsertCareRelationshipInput{
+		ChildID:     child.ID,
+		TherapistID: therapist.ID,
+		GrantedBy:   requester.ID,
+		Scope:       input.Scope,
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to grant therapist access to the child")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionGrantCareAccess,
+		TargetType: model.AuditTargetChild,
+		TargetID:   child.ID.String(),
+		Metadata: model.AuditMetadata{
+			"therapist_id": therapist.ID,
+			"scope":        input.Scope,
+		},
+	})
+
+	output := newTherapistAccessOutput(*relationship)
+
+	return &output, nil
+}
+
+// RevokeTherapistAccessInput input
+type RevokeTherapistAccessInput struct {
+	TherapistID uuid.UUID `validate:"required"`
+	ChildID     uuid.UUID `validate:"required"`
+}
+
+func (i RevokeTherapistAccessInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// HandleRevokeTherapistAccess allow the child's parent to remove the therapist's access to the child
+func (u *UsersUsecase) HandleRevokeTherapistAccess(ctx context.Context, input RevokeTherapistAccessInput) error {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	if err := input.validate(); err != nil {
+		return UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	child, err := u.findManagedChild(ctx, requester, input.ChildID)
+	if err != nil {
+		return err
+	}
+
+	err = u.careRelationshipRepo.Delete(ctx, child.ID, input.TherapistID)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).WithField("input", helper.Dump(input)).Error("failed to revoke therapist access to the child")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return UsecaseError{
+			ErrType: ErrNotFound,
+			Message: "the therapist has no access to the child",
+		}
+	case nil:
+		break
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionRevokeCareAccess,
+		TargetType: model.AuditTargetChild,
+		TargetID:   child.ID.String(),
+		Metadata: model.AuditMetadata{
+			"therapist_id": input.TherapistID,
+		},
+	})
+
+	return nil
+}
+
+// HandleListGrantedTherapistAccess list the therapists' access granted to the requester's children
+func (u *UsersUsecase) HandleListGrantedTherapistAccess(ctx context.Context) ([]TherapistAccessOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	relationships, err := u.careRelationshipRepo.FindByParentUserID(ctx, requester.ID)
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).WithField("user_id", requester.ID).Error("failed to find granted therapist access")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	output := make([]TherapistAccessOutput, 0, len(relationships))
+	for _, relationship := range relationships {
+		output = append(output, newTherapistAccessOutput(relationship))
+	}
+
+	return output, nil
+}
@@ -0,0 +1,346 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersUsecase_HandleGrantTherapistAccess(t *testing.T) {
+	ctx := context.Background()
+
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	mockCareRelationshipRepo := mockUsecase.NewCareRelationshipRepository(t)
+	uc := usecase.NewUsersUsecase(
+		mockUserRepo, nil, nil, mockAuditLogRepo, mockChildRepo, nil, nil, nil, mockCareRelationshipRepo, nil, nil, nil,
+	)
+
+	parent := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	parentCtx := model.SetUserToCtx(ctx, parent)
+	otherParentCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesParent})
+
+	child := &model.Child{ID: uuid.New(), ParentUserID: parent.ID}
+	therapist := &model.User{ID: uuid.New(), Roles: model.RolesTherapist, IsActive: true}
+
+	validInput := usecase.GrantTherapistAccessInput{
+		TherapistID: therapist.ID,
+		ChildID:     child.ID,
+		Scope:       model.CareScopeSubmit,
+	}
+	upsertInput := usecase.RepoUpsertCareRelationshipInput{
+		ChildID:     child.ID,
+		TherapistID: therapist.ID,
+		GrantedBy:   parent.ID,
+		Scope:       model.CareScopeSubmit,
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.GrantTherapistAccessInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized when requester not in context",
+			ctx:         ctx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name: "validation error - unknown scope",
+			ctx:  parentCtx,
+			input: usecase.GrantTherapistAccessInput{
+				TherapistID: therapist.ID,
+				ChildID:     child.ID,
+				Scope:       model.CareScope("write"),
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "child not found",
+			ctx:         parentCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(parentCtx, child.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "failed to find child",
+			ctx:         parentCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(parentCtx, child.ID).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "forbidden when requester is not the child's parent",
+			ctx:         otherParentCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(otherParentCtx, child.ID).Return(child, nil).Once()
+			},
+		},
+		{
+			name:        "therapist not found",
+			ctx:         parentCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(parentCtx, child.ID).Return(child, nil).Once()
+				mockUserRepo.EXPECT().FindByID(parentCtx, therapist.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "the user is not a therapist",
+			ctx:         parentCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(parentCtx, child.ID).Return(child, nil).Once()
+				mockUserRepo.EXPECT().FindByID(parentCtx, therapist.ID).
+					Return(&model.User{ID: therapist.ID, Roles: model.RolesParent, IsActive: true}, nil).Once()
+			},
+		},
+		{
+			name:        "the therapist is not active",
+			ctx:         parentCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(parentCtx, child.ID).Return(child, nil).Once()
+				mockUserRepo.EXPECT().FindByID(parentCtx, therapist.ID).
+					Return(&model.User{ID: therapist.ID, Roles: model.RolesTherapist}, nil).Once()
+			},
+		},
+		{
+			name:        "failed to grant the access",
+			ctx:         parentCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(parentCtx, child.ID).Return(child, nil).Once()
+				mockUserRepo.EXPECT().FindByID(parentCtx, therapist.ID).Return(therapist, nil).Once()
+				mockCareRelationshipRepo.EXPECT().Upsert(parentCtx, upsertInput).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:  "success",
+			ctx:   parentCtx,
+			input: validInput,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(parentCtx, child.ID).Return(child, nil).Once()
+				mockUserRepo.EXPECT().FindByID(parentCtx, therapist.ID).Return(therapist, nil).Once()
+				mockCareRelationshipRepo.EXPECT().Upsert(parentCtx, upsertInput).Return(&model.CareRelationship{
+					ID:          uuid.New(),
+					ChildID:     child.ID,
+					TherapistID: therapist.ID,
+					GrantedBy:   parent.ID,
+					Scope:       model.CareScopeSubmit,
+				}, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(parentCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    parent.ID,
+					ActorRole:  model.RolesParent,
+					Action:     model.AuditActionGrantCareAccess,
+					TargetType: model.AuditTargetChild,
+					TargetID:   child.ID.String(),
+					Metadata: model.AuditMetadata{
+						"therapist_id": therapist.ID,
+						"scope":        model.CareScopeSubmit,
+					},
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleGrantTherapistAccess(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				assertUsecaseErrType(t, tc.expectedErr, err)
+				assert.Nil(t, res)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, res)
+			assert.Equal(t, child.ID, res.ChildID)
+			assert.Equal(t, therapist.ID, res.TherapistID)
+			assert.Equal(t, model.CareScopeSubmit, res.Scope)
+		})
+	}
+}
+
+func TestUsersUsecase_HandleRevokeTherapistAccess(t *testing.T) {
+	ctx := context.Background()
+
+	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	mockCareRelationshipRepo := mockUsecase.NewCareRelationshipRepository(t)
+	uc := usecase.NewUsersUsecase(nil, nil, nil, mockAuditLogRepo, mockChildRepo, nil, nil, nil, mockCareRelationshipRepo, nil, nil, nil)
+
+	parent := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	parentCtx := model.SetUserToCtx(ctx, parent)
+	therapistCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesTherapist})
+
+	child := &model.Child{ID: uuid.New(), ParentUserID: parent.ID}
+	therapistID := uuid.New()
+
+	validInput := usecase.RevokeTherapistAccessInput{
+		TherapistID: therapistID,
+		ChildID:     child.ID,
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.RevokeTherapistAccessInput
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized when requester not in context",
+			ctx:         ctx,
+			input:       validInput,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "validation error - missing therapist",
+			ctx:         parentCtx,
+			input:       usecase.RevokeTherapistAccessInput{ChildID: child.ID},
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "forbidden when requester is not the child's parent",
+			ctx:         therapistCtx,
+			input:       validInput,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(therapistCtx, child.ID).Return(child, nil).Once()
+			},
+		},
+		{
+			name:        "the therapist has no access to the child",
+			ctx:         parentCtx,
+			input:       validInput,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(parentCtx, child.ID).Return(child, nil).Once()
+				mockCareRelationshipRepo.EXPECT().Delete(parentCtx, child.ID, therapistID).Return(usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "failed to revoke the access",
+			ctx:         parentCtx,
+			input:       validInput,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(parentCtx, child.ID).Return(child, nil).Once()
+				mockCareRelationshipRepo.EXPECT().Delete(parentCtx, child.ID, therapistID).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:  "success",
+			ctx:   parentCtx,
+			input: validInput,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(parentCtx, child.ID).Return(child, nil).Once()
+				mockCareRelationshipRepo.EXPECT().Delete(parentCtx, child.ID, therapistID).Return(nil).Once()
+				mockAuditLogRepo.EXPECT().Create(parentCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    parent.ID,
+					ActorRole:  model.RolesParent,
+					Action:     model.AuditActionRevokeCareAccess,
+					TargetType: model.AuditTargetChild,
+					TargetID:   child.ID.String(),
+					Metadata: model.AuditMetadata{
+						"therapist_id": therapistID,
+					},
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := uc.HandleRevokeTherapistAccess(tc.ctx, tc.input)
+
+			if tc.expectedErr != nil {
+				assertUsecaseErrType(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUsersUsecase_HandleListGrantedTherapistAccess(t *testing.T) {
+	ctx := context.Background()
+
+	mockCareRelationshipRepo := mockUsecase.NewCareRelationshipRepository(t)
+	uc := usecase.NewUsersUsecase(nil, nil, nil, nil, nil, nil, nil, nil, mockCareRelationshipRepo, nil, nil, nil)
+
+	parent := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	parentCtx := model.SetUserToCtx(ctx, parent)
+
+	t.Run("unauthorized when requester not in context", func(t *testing.T) {
+		res, err := uc.HandleListGrantedTherapistAccess(ctx)
+		assertUsecaseErrType(t, usecase.ErrUnauthorized, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed to find the granted access", func(t *testing.T) {
+		mockCareRelationshipRepo.EXPECT().FindByParentUserID(parentCtx, parent.ID).Return(nil, assert.AnError).Once()
+
+		res, err := uc.HandleListGrantedTherapistAccess(parentCtx)
+		assertUsecaseErrType(t, usecase.ErrInternal, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		relationships := []model.CareRelationship{
+			{ID: uuid.New(), ChildID: uuid.New(), TherapistID: uuid.New(), Scope: model.CareScopeRead},
+			{ID: uuid.New(), ChildID: uuid.New(), TherapistID: uuid.New(), Scope: model.CareScopeSubmit},
+		}
+
+		mockCareRelationshipRepo.EXPECT().FindByParentUserID(parentCtx, parent.ID).Return(relationships, nil).Once()
+
+		res, err := uc.HandleListGrantedTherapistAccess(parentCtx)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, relationships[0].TherapistID, res[0].TherapistID)
+		assert.Equal(t, model.CareScopeSubmit, res[1].Scope)
+	})
+}
@@ -0,0 +1,341 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-redis/redis_rate/v10"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/config"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// InitChangeEmailInput input
+type InitChangeEmailInput struct {
+	NewEmail        string `validate:"required,email"`
+	CurrentPassword string `validate:"required"`
+}
+
+func (icei InitChangeEmailInput) validate() error {
+	return common.Validator.Struct(icei)
+}
+
+// InitChangeEmailOutput output
+type InitChangeEmailOutput struct {
+	Message string
+}
+
+// HandleInitChangeEmail start the email change of the requester. A confirmation link is sent to the new email
+// and a notice is sent to the current one. The email is only changed once the link is confirmed before it expires.
+// Requesting another change will invalidate the previous pending change
+func (u *AuthUsecase) HandleInitChangeEmail(ctx context.Context, input InitChangeEmailInput) (*InitChangeEmailOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithField("user-id", requester.ID)
+
+	user, err := u.userRepo.FindByID(ctx, requester.ID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by id")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	match, err := u.verifyUserPassword(ctx, user, input.CurrentPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	if !match {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "current password is incorrect",
+		}
+	}
+
+	currentEmail, err := u.sharedCryptor.Decrypt(user.Email)
+	if err != nil {
+		logger.WithError(err).Error("failed to decrypt user email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if input.NewEmail == currentEmail {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "new email must be different from the current email",
+		}
+	}
+
+	newEmailEncrypted, err := u.sharedCryptor.Encrypt(input.NewEmail)
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: "encryption process failed",
+		}
+	}
+
+	newEmailLookup, err := newEmailLookup(u.sharedCryptor, input.NewEmail)
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: "encryption process failed",
+		}
+	}
+
+	rateLimit, err := u.rateLimiter.Allow(ctx, "change-email:"+user.ID.String(), redis_rate.Limit{
+		Rate:   1,
+		Burst:  1,
+		Period: config.ChangeEmailLimiterDuration(),
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to perform rate limiter ops")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if rateLimit.Allowed == 0 {
+		return nil, UsecaseError{
+			ErrType: ErrTooManyRequests,
+			Message: fmt.Sprintf("please retry again after %d", int64(rateLimit.ResetAfter.Seconds())),
+		}
+	}
+
+	_, err = u.userRepo.FindByEmail(ctx, newEmailLookup)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case nil:
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "this email has been used by another account",
+		}
+	case ErrRepoNotFound:
+		break
+	}
+
+	// only the latest requested change can be confirmed
+	if err := u.emailTokenRepo.RevokeAllUserTokens(ctx, user.ID, string(ChangeEmailToken)); err != nil {
+		logger.WithError(err).Error("failed to revoke pending change email tokens")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	// the new email is carried by the token itself, so nothing is changed until the token is redeemed
+	token, err := signEmailToken(
+		ctx, u.sharedCryptor, u.emailTokenRepo, user.ID, []string{user.ID.String(), newEmailEncrypted},
+		ChangeEmailToken, config.ChangeEmailTokenExpiry(),
+	)
+	if err != nil {
+		logger.WithError(err).Error("failed to create JWT token for change email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	// the notice is sent first, so the current email owner is always informed before the change can be confirmed
+	_, err = u.mailer.SendEmail(ctx, common.SendEmailInput{
+		ReceiverName:  user.Username,
+		ReceiverEmail: currentEmail,
+		Subject:       "Permintaan Perubahan Email",
+		HTMLContent:   changeEmailNoticeEmailTemplate(input.NewEmail),
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to send change email notice")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	_, err = u.mailer.SendEmail(ctx, common.SendEmailInput{
+		ReceiverName:  user.Username,
+		ReceiverEmail: input.NewEmail,
+		Subject:       "Konfirmasi Perubahan Email",
+		HTMLContent:   changeEmailConfirmationEmailTemplate(token),
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to send change email confirmation")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return &InitChangeEmailOutput{
+		Message: "a confirmation link has been sent to the new email",
+	}, nil
+}
+
+// ConfirmChangeEmailInput input
+type ConfirmChangeEmailInput struct {
+	ChangeEmailToken string `validate:"required"`
+}
+
+func (ccei ConfirmChangeEmailInput) validate() error {
+	return common.Validator.Struct(ccei)
+}
+
+// ConfirmChangeEmailOutput output
+type ConfirmChangeEmailOutput struct {
+	Message string
+}
+
+// HandleConfirmChangeEmail replace the user's email with the one carried by the change email token.
+// The token is consumed and the email is replaced in the same transaction
+func (u *AuthUsecase) HandleConfirmChangeEmail(ctx context.Context, input ConfirmChangeEmailInput) (*ConfirmChangeEmailOutput, error) {
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	_, claims, err := parseJWTToken(u.sharedCryptor, input.ChangeEmailToken, parseJWTTokenInput{
+		expectedIssuer:      TokenIssuerSystem,
+		expectedSubject:     ChangeEmailToken,
+		expectedAudienceLen: 2,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// no need to check the err here, because it's already checked
+	// when calling the parseJWTToken
+	audiences, _ := claims.GetAudience()
+	newEmailEncrypted := audiences[1]
+
+	userID, err := uuid.Parse(audiences[0])
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "invalid value of user id",
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithField("user-id", userID)
+
+	user, err := u.userRepo.FindByID(ctx, userID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by id")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	newEmail, err := u.sharedCryptor.Decrypt(newEmailEncrypted)
+	if err != nil {
+		logger.WithError(err).Error("failed to decrypt new email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	txCtrl := u.transactionControllerFactory.New()
+	tx := txCtrl.Begin()
+
+	if err := consumeEmailToken(ctx, u.emailTokenRepo, claims, user.ID, ChangeEmailToken, tx); err != nil {
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return nil, err
+	}
+
+	err = u.userRepo.UpdateEmail(ctx, user.ID, newEmailEncrypted, u.sharedCryptor.BlindIndex(newEmail), tx)
+	if err != nil {
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+	}
+
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to update user email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoDuplicate:
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "this email has been used by another account",
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	if err := txCtrl.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return &ConfirmChangeEmailOutput{
+		Message: "your email has been changed",
+	}, nil
+}
@@ -0,0 +1,460 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis_rate/v10"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	mockCommon "github.com/luckyAkbar/atec/mocks/internal_/common"
+	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/sendinblue/APIv3-go-library/v2/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthUsecase_HandleInitChangeEmail(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockRateLimiter := mockUsecase.NewRateLimiter(t)
+	mockMailer := mockCommon.NewMailerIface(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, mockMailer, mockRateLimiter, nil, mockEmailTokenRepo, nil, nil, nil, nil, nil)
+
+	requester := model.AuthUser{
+		ID:        uuid.New(),
+		Role:      model.RolesParent,
+		SessionID: uuid.New(),
+	}
+	userCtx := model.SetUserToCtx(ctx, requester)
+
+	// the password hash is stored base64 encoded, while CompareHash must receive the decoded hash
+	passwordHash := []byte("hashed-password")
+	user := &model.User{
+		ID:       requester.ID,
+		Email:    "encrypted-current-email",
+		Password: base64.StdEncoding.EncodeToString(passwordHash),
+		Username: "user",
+		IsActive: true,
+	}
+
+	validInput := usecase.InitChangeEmailInput{
+		NewEmail:        "new@example.com",
+		CurrentPassword: "password",
+	}
+	newEmailEncrypted := "encrypted-new-email"
+	newEmailLookup := usecase.RepoEmailLookup{BlindIndex: "new-email-blind-index", LegacyEncryptedEmails: []string{"legacy-encrypted-new-email"}}
+	limiterKey := "change-email:" + user.ID.String()
+
+	// expectUntilRateLimiter set the expectation of every call made before the rate limiter is checked
+	expectUntilRateLimiter := func(res *redis_rate.Result, err error) {
+		mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+		mockSharedCryptor.EXPECT().CompareHash(passwordHash, []byte(validInput.CurrentPassword)).Return(nil).Once()
+		mockSharedCryptor.EXPECT().Decrypt(user.Email).Return("current@example.com", nil).Once()
+		mockSharedCryptor.EXPECT().Encrypt(validInput.NewEmail).Return(newEmailEncrypted, nil).Once()
+		mockSharedCryptor.EXPECT().LegacyEncrypt(validInput.NewEmail).Return(newEmailLookup.LegacyEncryptedEmails, nil).Once()
+		mockSharedCryptor.EXPECT().BlindIndex(validInput.NewEmail).Return(newEmailLookup.BlindIndex).Once()
+		mockRateLimiter.EXPECT().Allow(userCtx, limiterKey, mock.Anything).Return(res, err).Once()
+	}
+
+	// expectUntilTokenSigned set the expectation of every call made until the change email token is signed
+	expectUntilTokenSigned := func() {
+		expectUntilRateLimiter(&redis_rate.Result{Allowed: 1}, nil)
+		mockUserRepo.EXPECT().FindByEmail(userCtx, newEmailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
+		mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(userCtx, user.ID, string(usecase.ChangeEmailToken)).Return(nil).Once()
+		mockEmailTokenRepo.EXPECT().Create(userCtx, mock.MatchedBy(func(input usecase.RepoCreateEmailTokenInput) bool {
+			return input.UserID == user.ID && input.Purpose == string(usecase.ChangeEmailToken)
+		})).Return(&model.EmailToken{ID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}, nil).Once()
+		mockSharedCryptor.EXPECT().CreateJWT(mock.MatchedBy(func(claims jwt.RegisteredClaims) bool {
+			return len(claims.Audience) == 2 && claims.Audience[0] == user.ID.String() && claims.Audience[1] == newEmailEncrypted
+		})).Return("change-email-token", nil).Once()
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.InitChangeEmailInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "invalid new email",
+			ctx:         userCtx,
+			input:       usecase.InitChangeEmailInput{NewEmail: "invalid", CurrentPassword: "password"},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "user not found",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "stored password hash is not base64 encoded",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(&model.User{
+					ID:       requester.ID,
+					Password: "not-base64-encoded!",
+					IsActive: true,
+				}, nil).Once()
+			},
+		},
+		{
+			name:        "wrong current password",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(passwordHash, []byte(validInput.CurrentPassword)).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:        "new email is the same as the current one",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(passwordHash, []byte(validInput.CurrentPassword)).Return(nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(user.Email).Return(validInput.NewEmail, nil).Once()
+			},
+		},
+		{
+			name:        "rate limiter error",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectUntilRateLimiter(nil, assert.AnError)
+			},
+		},
+		{
+			name:        "too many requests",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrTooManyRequests,
+			expectedFunctionCall: func() {
+				expectUntilRateLimiter(&redis_rate.Result{Allowed: 0, ResetAfter: time.Minute}, nil)
+			},
+		},
+		{
+			name:        "new email has been used by another account",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				expectUntilRateLimiter(&redis_rate.Result{Allowed: 1}, nil)
+				mockUserRepo.EXPECT().FindByEmail(userCtx, newEmailLookup).Return(&model.User{}, nil).Once()
+			},
+		},
+		{
+			name:        "failed to revoke pending change email tokens",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectUntilRateLimiter(&redis_rate.Result{Allowed: 1}, nil)
+				mockUserRepo.EXPECT().FindByEmail(userCtx, newEmailLookup).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockEmailTokenRepo.EXPECT().RevokeAllUserTokens(userCtx, user.ID, string(usecase.ChangeEmailToken)).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to send notice to the current email",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectUntilTokenSigned()
+				mockMailer.EXPECT().SendEmail(userCtx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == "current@example.com"
+				})).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to send confirmation to the new email",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectUntilTokenSigned()
+				mockMailer.EXPECT().SendEmail(userCtx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == "current@example.com"
+				})).Return(&lib.CreateSmtpEmail{}, nil).Once()
+				mockMailer.EXPECT().SendEmail(userCtx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == validInput.NewEmail
+				})).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok",
+			ctx:     userCtx,
+			input:   validInput,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				expectUntilTokenSigned()
+				mockMailer.EXPECT().SendEmail(userCtx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == "current@example.com" && input.Subject == "Permintaan Perubahan Email"
+				})).Return(&lib.CreateSmtpEmail{}, nil).Once()
+				mockMailer.EXPECT().SendEmail(userCtx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == validInput.NewEmail && input.Subject == "Konfirmasi Perubahan Email"
+				})).Return(&lib.CreateSmtpEmail{}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleInitChangeEmail(tc.ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Message)
+
+				return
+			}
+
+			assert.Nil(t, res)
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthUsecase_HandleConfirmChangeEmail(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockTxCtrlFactory := mockUsecase.NewTransactionControllerFactory(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, mockTxCtrlFactory, nil, nil, nil, mockEmailTokenRepo, nil, nil, nil, nil, nil)
+
+	userID := uuid.New()
+	tokenID := uuid.New()
+	newEmail := "new@example.com"
+	newEmailEncrypted := "encrypted-new-email"
+	newEmailBlindIndex := "new-email-blind-index"
+	validateJWTOpts := common.ValidateJWTOpts{
+		Issuer:  string(usecase.TokenIssuerSystem),
+		Subject: string(usecase.ChangeEmailToken),
+	}
+
+	changeEmailToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": string(usecase.TokenIssuerSystem),
+		"sub": string(usecase.ChangeEmailToken),
+		"aud": []string{userID.String(), newEmailEncrypted},
+		"exp": jwt.NewNumericDate(time.Now().Add(time.Hour)).Unix(),
+		"jti": tokenID.String(),
+	})
+	changeEmailToken.Valid = true
+
+	changeEmailTokenString, err := changeEmailToken.SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	singleAudienceToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": string(usecase.TokenIssuerSystem),
+		"sub": string(usecase.ChangeEmailToken),
+		"aud": []string{userID.String()},
+		"exp": jwt.NewNumericDate(time.Now().Add(time.Hour)).Unix(),
+		"jti": tokenID.String(),
+	})
+	singleAudienceToken.Valid = true
+
+	singleAudienceTokenString, err := singleAudienceToken.SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	validInput := usecase.ConfirmChangeEmailInput{ChangeEmailToken: changeEmailTokenString}
+
+	consumeInput := usecase.RepoConsumeEmailTokenInput{
+		ID:      tokenID,
+		UserID:  userID,
+		Purpose: string(usecase.ChangeEmailToken),
+	}
+
+	// expectTransactionBegin set the expectation until the transaction is started and return the underlying transaction
+	expectTransactionBegin := func() *mockUsecase.TransactionController {
+		mockSharedCryptor.EXPECT().ValidateJWT(changeEmailTokenString, validateJWTOpts).Return(changeEmailToken, nil).Once()
+		mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&model.User{ID: userID}, nil).Once()
+		mockSharedCryptor.EXPECT().Decrypt(newEmailEncrypted).Return(newEmail, nil).Once()
+
+		underlyingTransaction := mockUsecase.NewTransactionController(t)
+		txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
+
+		mockTxCtrlFactory.EXPECT().New().Return(txCtrlWrapper).Once()
+		underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+		return underlyingTransaction
+	}
+
+	testCases := []struct {
+		name                 string
+		input                usecase.ConfirmChangeEmailInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "change email token is required",
+			input:       usecase.ConfirmChangeEmailInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "invalid change email token",
+			input:       usecase.ConfirmChangeEmailInput{ChangeEmailToken: "invalid"},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT("invalid", validateJWTOpts).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "token without the new email",
+			input:       usecase.ConfirmChangeEmailInput{ChangeEmailToken: singleAudienceTokenString},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(singleAudienceTokenString, validateJWTOpts).Return(singleAudienceToken, nil).Once()
+			},
+		},
+		{
+			name:        "user not found",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(changeEmailTokenString, validateJWTOpts).Return(changeEmailToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "failed to decrypt the new email",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(changeEmailTokenString, validateJWTOpts).Return(changeEmailToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&model.User{ID: userID}, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(newEmailEncrypted).Return("", assert.AnError).Once()
+			},
+		},
+		{
+			name:        "token has already been used or expired",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(usecase.ErrRepoNotFound).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name:        "new email has been used by another account",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(newEmail).Return(newEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().UpdateEmail(ctx, userID, newEmailEncrypted, newEmailBlindIndex, mock.Anything).Return(usecase.ErrRepoDuplicate).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name:        "failed to update email",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(newEmail).Return(newEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().UpdateEmail(ctx, userID, newEmailEncrypted, newEmailBlindIndex, mock.Anything).Return(assert.AnError).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to commit",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(newEmail).Return(newEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().UpdateEmail(ctx, userID, newEmailEncrypted, newEmailBlindIndex, mock.Anything).Return(nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok",
+			input:   validInput,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput, mock.Anything).Return(nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(newEmail).Return(newEmailBlindIndex).Once()
+				mockUserRepo.EXPECT().UpdateEmail(ctx, userID, newEmailEncrypted, newEmailBlindIndex, mock.Anything).Return(nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleConfirmChangeEmail(ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, "your email has been changed", res.Message)
+
+				return
+			}
+
+			assert.Nil(t, res)
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
@@ -15,9 +15,16 @@ import (
 
 // ChildUsecase child usecase
 type ChildUsecase struct {
-	childRepo  ChildRepository
-	userRepo   UserRepository
-	resultRepo ResultRepository
+	childRepo                    ChildRepository
+	userRepo                     UserRepository
+	resultRepo                   ResultRepository
+	auditLogRepo                 AuditLogRepository
+	careRelationshipRepo         CareRelationshipRepository
+	childGuardianRepo            ChildGuardianRepository
+	emailTokenRepo               EmailTokenRepository
+	transactionControllerFactory TransactionControllerFactory
+	sharedCryptor                common.SharedCryptorIface
+	mailer                       common.MailerIface
 }
 
 // ChildUsecaseIface interface
@@ -27,14 +34,36 @@ type ChildUsecaseIface interface {
 	GetRegisteredChildren(ctx context.Context, input GetRegisteredChildrenInput) ([]GetRegisteredChildrenOutput, error)
 	Search(ctx context.Context, input SearchChildInput) ([]SearchChildOutput, error)
 	HandleGetStatistic(ctx context.Context, input GetStatisticInput) (*GetStatisticOutput, error)
+	HandleInviteGuardian(ctx context.Context, input InviteGuardianInput) (*InviteGuardianOutput, error)
+	HandleAcceptGuardianInvitation(ctx context.Context, input AcceptGuardianInvitationInput) (*GuardianOutput, error)
+	HandleListGuardians(ctx context.Context, input ListGuardiansInput) ([]GuardianOutput, error)
+	HandleRemoveGuardian(ctx context.Context, input RemoveGuardianInput) error
+	HandleInitiateChildTransfer(ctx context.Context, input InitiateChildTransferInput) (*InitiateChildTransferOutput, error)
+	HandleAcceptChildTransfer(ctx context.Context, input AcceptChildTransferInput) (*AcceptChildTransferOutput, error)
+	HandleArchiveChild(ctx context.Context, input ArchiveChildInput) error
+	HandleRestoreChild(ctx context.Context, input RestoreChildInput) error
+	HandleDeleteChild(ctx context.Context, input DeleteChildInput) error
 }
 
 // NewChildUsecase create new ChildUsecase instance
-func NewChildUsecase(childRepo ChildRepository, resultRepo ResultRepository, userRepo UserRepository) *ChildUsecase {
+func NewChildUsecase(
+	childRepo ChildRepository, resultRepo ResultRepository,
+	userRepo UserRepository, auditLogRepo AuditLogRepository,
+	careRelationshipRepo CareRelationshipRepository, childGuardianRepo ChildGuardianRepository,
+	emailTokenRepo EmailTokenRepository, transactionControllerFactory TransactionControllerFactory,
+	sharedCryptor common.SharedCryptorIface, mailer common.MailerIface,
+) *ChildUsecase {
 	return &ChildUsecase{
-		childRepo:  childRepo,
-		resultRepo: resultRepo,
-		userRepo:   userRepo,
+		childRepo:                    childRepo,
+		resultRepo:                   resultRepo,
+		userRepo:                     userRepo,
+		auditLogRepo:                 auditLogRepo,
+		careRelationshipRepo:         careRelationshipRepo,
+		childGuardianRepo:            childGuardianRepo,
+		emailTokenRepo:               emailTokenRepo,
+		transactionControllerFactory: transactionControllerFactory,
+		sharedCryptor:                sharedCryptor,
+		mailer:                       mailer,
 	}
 }
 
@@ -75,11 +104,8 @@ func (u *ChildUsecase) Register(ctx context.Context, input RegisterChildInput) (
 	logger := logrus.WithContext(ctx).WithField("input", helper.Dump(input))
 
 	requester := model.GetUserFromCtx(ctx)
-	if requester == nil {
-		return nil, UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: ErrUnauthorized.Error(),
-		}
+	if err := Authorize(requester, ActionRegisterChild, RelationNone); err != nil {
+		return nil, err
 	}
 
 	if err := input.Validate(); err != nil {
@@ -167,11 +193,8 @@ func (u *ChildUsecase) Update(ctx context.Context, input UpdateChildInput) (*Upd
 		break
 	}
 
-	if child.ParentUserID != requester.ID {
-		return nil, UsecaseError{
-			ErrType: ErrForbidden,
-			Message: "only the child's parent should be able to update child data",
-		}
+	if err := Authorize(requester, ActionUpdateChild, ownerRelation(requester, child.ParentUserID)); err != nil {
+		return nil, err
 	}
 
 	_, err = u.childRepo.Update(ctx, child.ID, RepoUpdateChildInput{
@@ -193,31 +216,34 @@ func (u *ChildUsecase) Update(ctx context.Context, input UpdateChildInput) (*Upd
 	}, nil
 }
 
-// GetRegisteredChildrenInput input
+// GetRegisteredChildrenInput input. Set Archived to list the archived children instead of the active ones
 type GetRegisteredChildrenInput struct {
-	Limit  int `validate:"min=1,max=100"`
-	Offset int `validate:"min=0"`
+	Archived bool
+	Limit    int `validate:"min=1,max=100"`
+	Offset   int `validate:"min=0"`
 }
 
 func (grci GetRegisteredChildrenInput) validate() error {
 	return common.Validator.Struct(grci)
 }
 
-// GetRegisteredChildrenOutput output
+// GetRegisteredChildrenOutput output. GuardianRole is the requester's role on the child
 type GetRegisteredChildrenOutput struct {
 	ID             uuid.UUID
 	ParentUserID   uuid.UUID
 	ParentUsername string
+	GuardianRole   model.GuardianRole
 	DateOfBirth    time.Time
 	Gender         bool
 	Name           string
 	GuardianName   sql.NullString
+	ArchivedAt     sql.NullTime
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 	DeletedAt      sql.NullTime
 }
 
-// GetRegisteredChildren get registered children by the requester account
+// GetRegisteredChildren get the children the requester is the owner or a guardian of
 func (u *ChildUsecase) GetRegisteredChildren(ctx context.Context, input GetRegisteredChildrenInput) ([]GetRegisteredChildrenOutput, error) {
 	if err := input.validate(); err != nil {
 		return nil, UsecaseError{
@@ -235,9 +261,10 @@ func (u *ChildUsecase) GetRegisteredChildren(ctx context.Context, input GetRegis
 	}
 
 	children, err := u.childRepo.Search(ctx, RepoSearchChildInput{
-		ParentUserID: &requester.ID,
-		Limit:        input.Limit,
-		Offset:       input.Offset,
+		GuardianUserID: &requester.ID,
+		Archived:       &input.Archived,
+		Limit:          input.Limit,
+		Offset:         input.Offset,
 	})
 
 	switch err {
@@ -257,35 +284,47 @@ func (u *ChildUsecase) GetRegisteredChildren(ctx context.Context, input GetRegis
 		break
 	}
 
-	parent, err := u.userRepo.FindByID(ctx, requester.ID)
-	switch err {
-	default:
-		logrus.WithContext(ctx).WithField("input", helper.Dump(input)).Error("failed to find parent data")
+	// the children shared with the requester may belong to different owners
+	parents := map[uuid.UUID]*model.User{}
+	output := []GetRegisteredChildrenOutput{}
 
-		return nil, UsecaseError{
-			ErrType: ErrInternal,
-			Message: ErrInternal.Error(),
-		}
-	case ErrRepoNotFound:
-		return nil, UsecaseError{
-			ErrType: ErrNotFound,
-			Message: ErrNotFound.Error(),
+	for _, child := range children {
+		parent, ok := parents[child.ParentUserID]
+		if !ok {
+			parent, err = u.userRepo.FindByID(ctx, child.ParentUserID)
+			switch err {
+			default:
+				logrus.WithContext(ctx).WithField("input", helper.Dump(input)).Error("failed to find parent data")
+
+				return nil, UsecaseError{
+					ErrType: ErrInternal,
+					Message: ErrInternal.Error(),
+				}
+			case ErrRepoNotFound:
+				return nil, UsecaseError{
+					ErrType: ErrNotFound,
+					Message: ErrNotFound.Error(),
+				}
+			case nil:
+				parents[child.ParentUserID] = parent
+			}
 		}
-	case nil:
-		break
-	}
 
-	output := []GetRegisteredChildrenOutput{}
+		guardianRole := model.GuardianRoleGuardian
+		if child.ParentUserID == requester.ID {
+			guardianRole = model.GuardianRoleOwner
+		}
 
-	for _, child := range children {
 		output = append(output, GetRegisteredChildrenOutput{
 			ID:             child.ID,
 			ParentUserID:   child.ParentUserID,
 			ParentUsername: parent.Username,
+			GuardianRole:   guardianRole,
 			DateOfBirth:    child.DateOfBirth,
 			Gender:         child.Gender,
 			Name:           child.Name,
 			GuardianName:   child.GuardianName,
+			ArchivedAt:     child.ArchivedAt,
 			CreatedAt:      child.CreatedAt,
 			UpdatedAt:      child.UpdatedAt,
 			DeletedAt:      sql.NullTime(child.DeletedAt),
@@ -297,11 +336,12 @@ func (u *ChildUsecase) GetRegisteredChildren(ctx context.Context, input GetRegis
 
 // SearchChildInput input
 type SearchChildInput struct {
-	ParentUserID *uuid.UUID
-	Name         *string
-	Gender       *bool
-	Limit        int `validate:"required,min=1,max=100"`
-	Offset       int `validate:"min=0"`
+	ParentUserID   *uuid.UUID
+	OrganizationID *uuid.UUID
+	Name           *string
+	Gender         *bool
+	Limit          int `validate:"required,min=1,max=100"`
+	Offset         int `validate:"min=0"`
 }
 
 func (sci SearchChildInput) validate() error {
@@ -321,21 +361,13 @@ type SearchChildOutput struct {
 	DeletedAt    sql.NullTime
 }
 
-// Search allow requester to full search registered child data based on multiple search params
+// Search allow requester to full search registered child data based on multiple search params.
+// Therapist will only find the children assigned to them, while the organization admin will find the children
+// assigned to any therapist of the organization. Only the administrator can search across the organizations
 func (u *ChildUsecase) Search(ctx context.Context, input SearchChildInput) ([]SearchChildOutput, error) {
 	requester := model.GetUserFromCtx(ctx)
-	if requester == nil {
-		return nil, UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: ErrUnauthorized.Error(),
-		}
-	}
-
-	if requester.Role != model.RolesTherapist {
-		return nil, UsecaseError{
-			ErrType: ErrForbidden,
-			Message: "insufficient permission to access this feature",
-		}
+	if err := Authorize(requester, ActionSearchChild, RelationNone); err != nil {
+		return nil, err
 	}
 
 	if err := input.validate(); err != nil {
@@ -345,13 +377,27 @@ func (u *ChildUsecase) Search(ctx context.Context, input SearchChildInput) ([]Se
 		}
 	}
 
-	children, err := u.childRepo.Search(ctx, RepoSearchChildInput{
+	// the archived children are hidden from everyone but their owner
+	archived := false
+	searchInput := RepoSearchChildInput{
 		ParentUserID: input.ParentUserID,
 		Name:         input.Name,
 		Gender:       input.Gender,
+		Archived:     &archived,
 		Limit:        input.Limit,
 		Offset:       input.Offset,
-	})
+	}
+
+	switch {
+	case IsAllowed(requester.Role, ActionSearchOrganizationChild, RelationNone):
+		searchInput.OrganizationID = input.OrganizationID
+	case IsAllowed(requester.Role, ActionSearchOrganizationChild, organizationAdminRelation(requester, requester.OrganizationID)):
+		searchInput.OrganizationID = &requester.OrganizationID
+	default:
+		searchInput.TherapistID = &requester.ID
+	}
+
+	children, err := u.childRepo.Search(ctx, searchInput)
 
 	switch err {
 	default:
@@ -371,8 +417,10 @@ func (u *ChildUsecase) Search(ctx context.Context, input SearchChildInput) ([]Se
 	}
 
 	output := []SearchChildOutput{}
+	childIDs := []string{}
 
 	for _, child := range children {
+		childIDs = append(childIDs, child.ID.String())
 		output = append(output, SearchChildOutput{
 			ID:           child.ID,
 			ParentUserID: child.ParentUserID,
@@ -386,6 +434,19 @@ func (u *ChildUsecase) Search(ctx context.Context, input SearchChildInput) ([]Se
 		})
 	}
 
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionSearchChild,
+		TargetType: model.AuditTargetChild,
+		Metadata: model.AuditMetadata{
+			"parent_user_id": input.ParentUserID,
+			"name":           input.Name,
+			"gender":         input.Gender,
+			"limit":          input.Limit,
+			"offset":         input.Offset,
+			"child_ids":      childIDs,
+		},
+	})
+
 	return output, nil
 }
 
@@ -412,7 +473,7 @@ type GetStatisticOutput struct {
 }
 
 // HandleGetStatistic get the statistic of a given child id. It requires the valid
-// authorization of the parent or admin role. It will return the overall statistic
+// authorization of the parent, the guardians or the therapist assigned to the child. It will return the overall statistic
 // of the child, which is composed of time of test and the total score of the test.
 func (u *ChildUsecase) HandleGetStatistic(ctx context.Context, input GetStatisticInput) (*GetStatisticOutput, error) {
 	logger := logrus.WithContext(ctx).WithField("input", helper.Dump(input))
@@ -450,11 +511,13 @@ func (u *ChildUsecase) HandleGetStatistic(ctx context.Context, input GetStatisti
 		break
 	}
 
-	if child.ParentUserID != requester.ID && requester.Role != model.RolesTherapist {
-		return nil, UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: "getting statistic for this child must be done either by parent or therapist",
-		}
+	relation, err := childRelation(ctx, u.careRelationshipRepo, u.childGuardianRepo, requester, child)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Authorize(requester, ActionReadChildStatistic, relation); err != nil {
+		return nil, err
 	}
 
 	batchSize := 100
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/sweet-go/stdlib/helper"
+)
+
+// ArchiveChildInput input
+type ArchiveChildInput struct {
+	ChildID uuid.UUID `validate:"required"`
+}
+
+func (i ArchiveChildInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// HandleArchiveChild allow the child's owner to archive the child. The archived child is hidden from the children
+// lists and the search, while the child data and the results are kept and the child can be restored at any time
+func (u *ChildUsecase) HandleArchiveChild(ctx context.Context, input ArchiveChildInput) error {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	if err := input.validate(); err != nil {
+		return UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	child, relation, err := u.findChild(ctx, requester, input.ChildID)
+	if err != nil {
+		return err
+	}
+
+	if err := Authorize(requester, ActionArchiveChild, relation); err != nil {
+		return err
+	}
+
+	if child.ArchivedAt.Valid {
+		return UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "the child is already archived",
+		}
+	}
+
+	_, err = u.childRepo.Update(ctx, child.ID, RepoUpdateChildInput{
+		ArchivedAt: &sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	})
+
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).WithField("input", helper.Dump(input)).Error("failed to archive the child")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionArchiveChild,
+		TargetType: model.AuditTargetChild,
+		TargetID:   child.ID.String(),
+	})
+
+	return nil
+}
+
+// RestoreChildInput input
+type RestoreChildInput struct {
+	ChildID uuid.UUID `validate:"required"`
+}
+
+func (i RestoreChildInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// HandleRestoreChild allow the child's owner to bring back the archived child to the children lists
+func (u *ChildUsecase) HandleRestoreChild(ctx context.Context, input RestoreChildInput) error {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	if err := input.validate(); err != nil {
+		return UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	child, relation, err := u.findChild(ctx, requester, input.ChildID)
+	if err != nil {
+		return err
+	}
+
+	if err := Authorize(requester, ActionArchiveChild, relation); err != nil {
+		return err
+	}
+
+	if !child.ArchivedAt.Valid {
+		return UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "the child is not archived",
+		}
+	}
+
+	_, err = u.childRepo.Update(ctx, child.ID, RepoUpdateChildInput{
+		ArchivedAt: &sql.NullTime{},
+	})
+
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).WithField("input", helper.Dump(input)).Error("failed to restore the child")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionRestoreChild,
+		TargetType: model.AuditTargetChild,
+		TargetID:   child.ID.String(),
+	})
+
+	return nil
+}
+
+// DeleteChildInput input. Set AnonymizeResults to keep the child's results as anonymous statistic data
+// instead of deleting them
+type DeleteChildInput struct {
+	ChildID          uuid.UUID `validate:"required"`
+	AnonymizeResults bool
+}
+
+func (i DeleteChildInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// HandleDeleteChild allow the child's owner to permanently delete the child. The child's results are deleted
+// or anonymized in the same transaction, so the child is never removed while leaving its results behind
+func (u *ChildUsecase) HandleDeleteChild(ctx context.Context, input DeleteChildInput) error {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	if err := input.validate(); err != nil {
+		return UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	child, relation, err := u.findChild(ctx, requester, input.ChildID)
+	if err != nil {
+		return err
+	}
+
+	if err := Authorize(requester, ActionDeleteChild, relation); err != nil {
+		return err
+	}
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"requester-id": requester.ID,
+		"child-id":     child.ID,
+		"func":         "ChildUsecase.HandleDeleteChild",
+	})
+
+	txCtrl := u.transactionControllerFactory.New()
+	tx := txCtrl.Begin()
+
+	err = u.resultRepo.DeleteAllChildResults(ctx, RepoDeleteAllChildResultsInput{
+		ChildID:   child.ID,
+		Anonymize: input.AnonymizeResults,
+	}, tx)
+
+	if err != nil {
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		logger.WithError(err).Error("failed to delete the child's results")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	err = u.childRepo.DeleteByID(ctx, child.ID, tx)
+	switch err {
+	default:
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		logger.WithError(err).Error("failed to delete the child")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return UsecaseError{
+			ErrType: ErrNotFound,
+			Message: "child not found",
+		}
+	case nil:
+		break
+	}
+
+	if err := txCtrl.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionDeleteChild,
+		TargetType: model.AuditTargetChild,
+		TargetID:   child.ID.String(),
+		Metadata: model.AuditMetadata{
+			"anonymize_results": input.AnonymizeResults,
+		},
+	})
+
+	return nil
+}
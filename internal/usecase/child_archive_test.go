@@ -0,0 +1,405 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChildUsecase_HandleArchiveChild(t *testing.T) {
+	ctx := context.Background()
+	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockChildGuardianRepo := mockUsecase.NewChildGuardianRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	uc := usecase.NewChildUsecase(mockChildRepo, nil, nil, mockAuditLogRepo, nil, mockChildGuardianRepo, nil, nil, nil, nil)
+
+	owner := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	ownerCtx := model.SetUserToCtx(ctx, owner)
+
+	guardian := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	guardianCtx := model.SetUserToCtx(ctx, guardian)
+
+	child := &model.Child{ID: uuid.New(), ParentUserID: owner.ID}
+	archivedChild := &model.Child{ID: uuid.New(), ParentUserID: owner.ID, ArchivedAt: sql.NullTime{Time: time.Now(), Valid: true}}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.ArchiveChildInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       usecase.ArchiveChildInput{ChildID: child.ID},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "child id is required",
+			ctx:         ownerCtx,
+			input:       usecase.ArchiveChildInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "child not found",
+			ctx:         ownerCtx,
+			input:       usecase.ArchiveChildInput{ChildID: child.ID},
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(ownerCtx, child.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "guardian can not archive the child",
+			ctx:         guardianCtx,
+			input:       usecase.ArchiveChildInput{ChildID: child.ID},
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(guardianCtx, child.ID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(guardianCtx, child.ID, guardian.ID).Return(&model.ChildGuardian{
+					ChildID: child.ID,
+					UserID:  guardian.ID,
+					Role:    model.GuardianRoleGuardian,
+				}, nil).Once()
+			},
+		},
+		{
+			name:        "child is already archived",
+			ctx:         ownerCtx,
+			input:       usecase.ArchiveChildInput{ChildID: archivedChild.ID},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(ownerCtx, archivedChild.ID).Return(archivedChild, nil).Once()
+			},
+		},
+		{
+			name:        "failed to archive the child",
+			ctx:         ownerCtx,
+			input:       usecase.ArchiveChildInput{ChildID: child.ID},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(ownerCtx, child.ID).Return(child, nil).Once()
+				mockChildRepo.EXPECT().Update(ownerCtx, child.ID, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok",
+			ctx:     ownerCtx,
+			input:   usecase.ArchiveChildInput{ChildID: child.ID},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(ownerCtx, child.ID).Return(child, nil).Once()
+				mockChildRepo.EXPECT().Update(ownerCtx, child.ID, mock.MatchedBy(func(input usecase.RepoUpdateChildInput) bool {
+					return input.ArchivedAt != nil && input.ArchivedAt.Valid && input.Name == nil
+				})).Return(child, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(ownerCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    owner.ID,
+					ActorRole:  model.RolesParent,
+					Action:     model.AuditActionArchiveChild,
+					TargetType: model.AuditTargetChild,
+					TargetID:   child.ID.String(),
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := uc.HandleArchiveChild(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				assertUsecaseErrType(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestChildUsecase_HandleRestoreChild(t *testing.T) {
+	ctx := context.Background()
+	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	uc := usecase.NewChildUsecase(mockChildRepo, nil, nil, mockAuditLogRepo, nil, nil, nil, nil, nil, nil)
+
+	owner := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	ownerCtx := model.SetUserToCtx(ctx, owner)
+
+	child := &model.Child{ID: uuid.New(), ParentUserID: owner.ID}
+	archivedChild := &model.Child{ID: uuid.New(), ParentUserID: owner.ID, ArchivedAt: sql.NullTime{Time: time.Now(), Valid: true}}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.RestoreChildInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       usecase.RestoreChildInput{ChildID: archivedChild.ID},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "child is not archived",
+			ctx:         ownerCtx,
+			input:       usecase.RestoreChildInput{ChildID: child.ID},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(ownerCtx, child.ID).Return(child, nil).Once()
+			},
+		},
+		{
+			name:        "failed to restore the child",
+			ctx:         ownerCtx,
+			input:       usecase.RestoreChildInput{ChildID: archivedChild.ID},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(ownerCtx, archivedChild.ID).Return(archivedChild, nil).Once()
+				mockChildRepo.EXPECT().Update(ownerCtx, archivedChild.ID, usecase.RepoUpdateChildInput{
+					ArchivedAt: &sql.NullTime{},
+				}).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok",
+			ctx:     ownerCtx,
+			input:   usecase.RestoreChildInput{ChildID: archivedChild.ID},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(ownerCtx, archivedChild.ID).Return(archivedChild, nil).Once()
+				mockChildRepo.EXPECT().Update(ownerCtx, archivedChild.ID, usecase.RepoUpdateChildInput{
+					ArchivedAt: &sql.NullTime{},
+				}).Return(archivedChild, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(ownerCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    owner.ID,
+					ActorRole:  model.RolesParent,
+					Action:     model.AuditActionRestoreChild,
+					TargetType: model.AuditTargetChild,
+					TargetID:   archivedChild.ID.String(),
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := uc.HandleRestoreChild(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				assertUsecaseErrType(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestChildUsecase_HandleDeleteChild(t *testing.T) {
+	ctx := context.Background()
+	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockResultRepo := mockUsecase.NewResultRepository(t)
+	mockChildGuardianRepo := mockUsecase.NewChildGuardianRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	mockTxCtrlFactory := mockUsecase.NewTransactionControllerFactory(t)
+	uc := usecase.NewChildUsecase(
+		mockChildRepo, mockResultRepo, nil, mockAuditLogRepo, nil, mockChildGuardianRepo, nil, mockTxCtrlFactory, nil, nil,
+	)
+
+	owner := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	ownerCtx := model.SetUserToCtx(ctx, owner)
+
+	guardian := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	guardianCtx := model.SetUserToCtx(ctx, guardian)
+
+	child := &model.Child{ID: uuid.New(), ParentUserID: owner.ID}
+	validInput := usecase.DeleteChildInput{ChildID: child.ID}
+
+	// expectTransactionBegin set the expectation until the transaction is started and return the underlying transaction
+	expectTransactionBegin := func() *mockUsecase.TransactionController {
+		mockChildRepo.EXPECT().FindByID(ownerCtx, child.ID).Return(child, nil).Once()
+
+		underlyingTransaction := mockUsecase.NewTransactionController(t)
+		txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
+
+		mockTxCtrlFactory.EXPECT().New().Return(txCtrlWrapper).Once()
+		underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+		return underlyingTransaction
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.DeleteChildInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "child id is required",
+			ctx:         ownerCtx,
+			input:       usecase.DeleteChildInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "guardian can not delete the child",
+			ctx:         guardianCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(guardianCtx, child.ID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(guardianCtx, child.ID, guardian.ID).Return(&model.ChildGuardian{
+					ChildID: child.ID,
+					UserID:  guardian.ID,
+					Role:    model.GuardianRoleGuardian,
+				}, nil).Once()
+			},
+		},
+		{
+			name:        "failed to delete the child's results",
+			ctx:         ownerCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockResultRepo.EXPECT().DeleteAllChildResults(ownerCtx, usecase.RepoDeleteAllChildResultsInput{
+					ChildID: child.ID,
+				}, mock.Anything).Return(assert.AnError).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name:        "child already deleted and failed to rollback",
+			ctx:         ownerCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockResultRepo.EXPECT().DeleteAllChildResults(ownerCtx, usecase.RepoDeleteAllChildResultsInput{
+					ChildID: child.ID,
+				}, mock.Anything).Return(nil).Once()
+				mockChildRepo.EXPECT().DeleteByID(ownerCtx, child.ID, mock.Anything).Return(usecase.ErrRepoNotFound).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to delete the child",
+			ctx:         ownerCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockResultRepo.EXPECT().DeleteAllChildResults(ownerCtx, usecase.RepoDeleteAllChildResultsInput{
+					ChildID: child.ID,
+				}, mock.Anything).Return(nil).Once()
+				mockChildRepo.EXPECT().DeleteByID(ownerCtx, child.ID, mock.Anything).Return(assert.AnError).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name:        "failed to commit",
+			ctx:         ownerCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockResultRepo.EXPECT().DeleteAllChildResults(ownerCtx, usecase.RepoDeleteAllChildResultsInput{
+					ChildID: child.ID,
+				}, mock.Anything).Return(nil).Once()
+				mockChildRepo.EXPECT().DeleteByID(ownerCtx, child.ID, mock.Anything).Return(nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok, the results are anonymized",
+			ctx:     ownerCtx,
+			input:   usecase.DeleteChildInput{ChildID: child.ID, AnonymizeResults: true},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockResultRepo.EXPECT().DeleteAllChildResults(ownerCtx, usecase.RepoDeleteAllChildResultsInput{
+					ChildID:   child.ID,
+					Anonymize: true,
+				}, mock.Anything).Return(nil).Once()
+				mockChildRepo.EXPECT().DeleteByID(ownerCtx, child.ID, mock.Anything).Return(nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(nil).Once()
+				mockAuditLogRepo.EXPECT().Create(ownerCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    owner.ID,
+					ActorRole:  model.RolesParent,
+					Action:     model.AuditActionDeleteChild,
+					TargetType: model.AuditTargetChild,
+					TargetID:   child.ID.String(),
+					Metadata: model.AuditMetadata{
+						"anonymize_results": true,
+					},
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := uc.HandleDeleteChild(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				assertUsecaseErrType(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
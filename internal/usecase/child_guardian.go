@@ -0,0 +1,532 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/config"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/sweet-go/stdlib/helper"
+)
+
+// guardianRelation resolve the relation of the user to the child based on the guardian membership.
+// RelationOwner for the owner membership, RelationGuardian for the guardian membership, and
+// RelationNone if the user is not one of the child's guardians
+func guardianRelation(ctx context.Context, childGuardianRepo ChildGuardianRepository, userID, childID uuid.UUID) (Relation, error) {
+	guardian, err := childGuardianRepo.FindByChildIDAndUserID(ctx, childID, userID)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"child_id": childID,
+			"user_id":  userID,
+		}).Error("failed to find child guardian")
+
+		return RelationNone, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return RelationNone, nil
+	case nil:
+		break
+	}
+
+	if guardian.Role == model.GuardianRoleOwner {
+		return RelationOwner, nil
+	}
+
+	return RelationGuardian, nil
+}
+
+// GuardianOutput the user's guardian membership of the child
+type GuardianOutput struct {
+	ChildID   uuid.UUID
+	UserID    uuid.UUID
+	Username  string
+	Role      model.GuardianRole
+	CreatedAt time.Time
+}
+
+// findChild find the child and resolve the requester's guardian relation to it
+func (u *ChildUsecase) findChild(ctx context.Context, requester *model.AuthUser, childID uuid.UUID) (*model.Child, Relation, error) {
+	child, err := u.childRepo.FindByID(ctx, childID)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).WithField("child_id", childID).Error("failed to find child data from database")
+
+		return nil, RelationNone, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, RelationNone, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: "child not found",
+		}
+	case nil:
+		break
+	}
+
+	if relation := ownerRelation(requester, child.ParentUserID); relation == RelationOwner {
+		return child, relation, nil
+	}
+
+	relation, err := guardianRelation(ctx, u.childGuardianRepo, requester.ID, child.ID)
+	if err != nil {
+		return nil, RelationNone, err
+	}
+
+	return child, relation, nil
+}
+
+// findInvitedUser find the requester's account and ensure it is registered with the invited email
+func (u *ChildUsecase) findInvitedUser(ctx context.Context, requester *model.AuthUser, encryptedInvitedEmail string) (*model.User, error) {
+	logger := logrus.WithContext(ctx).WithField("requester-id", requester.ID)
+
+	invitedEmail, err := u.sharedCryptor.Decrypt(encryptedInvitedEmail)
+	if err != nil {
+		logger.WithError(err).Error("failed to decrypt invited email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	user, err := u.userRepo.FindByID(ctx, requester.ID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user data from database")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: "user not found",
+		}
+	case nil:
+		break
+	}
+
+	email, err := u.sharedCryptor.Decrypt(user.Email)
+	if err != nil {
+		logger.WithError(err).Error("failed to decrypt user email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if !strings.EqualFold(email, invitedEmail) {
+		return nil, UsecaseError{
+			ErrType: ErrForbidden,
+			Message: "this invitation was sent to another email",
+		}
+	}
+
+	return user, nil
+}
+
+// InviteGuardianInput input
+type InviteGuardianInput struct {
+	ChildID uuid.UUID `validate:"required"`
+	Email   string    `validate:"required,email"`
+}
+
+func (i InviteGuardianInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// InviteGuardianOutput output
+type InviteGuardianOutput struct {
+	Message string
+}
+
+// HandleInviteGuardian allow the child's owner to invite another user by email to share the child.
+// The invitation is a signed single use token bound to the child and the invited email, which later
+// can be accepted by the account registered with the invited email
+func (u *ChildUsecase) HandleInviteGuardian(ctx context.Context, input InviteGuardianInput) (*InviteGuardianOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"requester-id": requester.ID,
+		"child-id":     input.ChildID,
+		"func":         "ChildUsecase.HandleInviteGuardian",
+	})
+
+	child, relation, err := u.findChild(ctx, requester, input.ChildID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Authorize(requester, ActionManageGuardian, relation); err != nil {
+		return nil, err
+	}
+
+	emailEncrypted, err := u.sharedCryptor.Encrypt(input.Email)
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: "encryption process failed",
+		}
+	}
+
+	// the invited email may not have any account yet, so the invitation is bound to the encrypted email instead
+	token, err := signEmailToken(
+		ctx, u.sharedCryptor, u.emailTokenRepo, uuid.Nil, []string{child.ID.String(), emailEncrypted},
+		GuardianInvitation, config.GuardianInvitationTokenExpiry(),
+	)
+	if err != nil {
+		logger.WithError(err).Error("failed to create JWT token for guardian invitation")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	_, err = u.mailer.SendEmail(ctx, common.SendEmailInput{
+		ReceiverName:  input.Email,
+		ReceiverEmail: input.Email,
+		Subject:       "Undangan Menjadi Wali Anak",
+		HTMLContent:   guardianInvitationEmailTemplate(child.Name, token),
+	})
+
+	if err != nil {
+		logger.WithError(err).Error("failed to send guardian invitation email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionInviteGuardian,
+		TargetType: model.AuditTargetChild,
+		TargetID:   child.ID.String(),
+	})
+
+	return &InviteGuardianOutput{
+		Message: "invitation sent",
+	}, nil
+}
+
+// AcceptGuardianInvitationInput input
+type AcceptGuardianInvitationInput struct {
+	InvitationToken string `validate:"required"`
+}
+
+func (i AcceptGuardianInvitationInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// HandleAcceptGuardianInvitation redeem the guardian invitation and add the requester to the child's guardians.
+// The invitation can only be accepted by the account registered with the invited email
+func (u *ChildUsecase) HandleAcceptGuardianInvitation(ctx context.Context, input AcceptGuardianInvitationInput) (*GuardianOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if err := Authorize(requester, ActionAcceptGuardianship, RelationNone); err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"requester-id": requester.ID,
+		"func":         "ChildUsecase.HandleAcceptGuardianInvitation",
+	})
+
+	_, claims, err := parseJWTToken(u.sharedCryptor, input.InvitationToken, parseJWTTokenInput{
+		expectedIssuer:      TokenIssuerSystem,
+		expectedSubject:     GuardianInvitation,
+		expectedAudiences:   nil,
+		expectedAudienceLen: 2,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// no need to check the err here, because it's already checked
+	// when calling the parseJWTToken
+	audiences, _ := claims.GetAudience()
+
+	childID, err := uuid.Parse(audiences[0])
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "invalid child on the invitation",
+		}
+	}
+
+	user, err := u.findInvitedUser(ctx, requester, audiences[1])
+	if err != nil {
+		return nil, err
+	}
+
+	child, err := u.childRepo.FindByID(ctx, childID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find child data from database")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: "child not found",
+		}
+	case nil:
+		break
+	}
+
+	txCtrl := u.transactionControllerFactory.New()
+	tx := txCtrl.Begin()
+
+	if err := consumeEmailToken(ctx, u.emailTokenRepo, claims, uuid.Nil, GuardianInvitation, tx); err != nil {
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return nil, err
+	}
+
+	guardian, err := u.childGuardianRepo.Create(ctx, RepoCreateChildGuardianInput{
+		ChildID: child.ID,
+		UserID:  requester.ID,
+		Role:    model.GuardianRoleGuardian,
+	}, tx)
+
+	if err != nil {
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		if err == ErrRepoDuplicate {
+			return nil, UsecaseError{
+				ErrType: ErrBadRequest,
+				Message: "you are already a guardian of this child",
+			}
+		}
+
+		logger.WithError(err).Error("failed to add the guardian to the child")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if err := txCtrl.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionAcceptGuardianship,
+		TargetType: model.AuditTargetChild,
+		TargetID:   child.ID.String(),
+		Metadata: model.AuditMetadata{
+			"owner_id": child.ParentUserID,
+		},
+	})
+
+	return &GuardianOutput{
+		ChildID:   guardian.ChildID,
+		UserID:    guardian.UserID,
+		Username:  user.Username,
+		Role:      guardian.Role,
+		CreatedAt: guardian.CreatedAt,
+	}, nil
+}
+
+// ListGuardiansInput input
+type ListGuardiansInput struct {
+	ChildID uuid.UUID `validate:"required"`
+}
+
+func (i ListGuardiansInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// HandleListGuardians list the owner and the guardians of the child. Only the child's owner and guardians can see the list
+func (u *ChildUsecase) HandleListGuardians(ctx context.Context, input ListGuardiansInput) ([]GuardianOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	child, relation, err := u.findChild(ctx, requester, input.ChildID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Authorize(requester, ActionListGuardian, relation); err != nil {
+		return nil, err
+	}
+
+	logger := logrus.WithContext(ctx).WithField("input", helper.Dump(input))
+
+	guardians, err := u.childGuardianRepo.FindByChildID(ctx, child.ID)
+	if err != nil {
+		logger.WithError(err).Error("failed to find the child's guardians")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	output := make([]GuardianOutput, 0, len(guardians))
+
+	for _, guardian := range guardians {
+		user, err := u.userRepo.FindByID(ctx, guardian.UserID)
+		if err != nil {
+			logger.WithError(err).WithField("user_id", guardian.UserID).Error("failed to find guardian data from database")
+
+			return nil, UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		}
+
+		output = append(output, GuardianOutput{
+			ChildID:   guardian.ChildID,
+			UserID:    guardian.UserID,
+			Username:  user.Username,
+			Role:      guardian.Role,
+			CreatedAt: guardian.CreatedAt,
+		})
+	}
+
+	return output, nil
+}
+
+// RemoveGuardianInput input
+type RemoveGuardianInput struct {
+	ChildID uuid.UUID `validate:"required"`
+	UserID  uuid.UUID `validate:"required"`
+}
+
+func (i RemoveGuardianInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// HandleRemoveGuardian allow the child's owner to remove a guardian from the child, or a guardian to leave
+// the child by removing themselves. The owner membership itself can't be removed
+func (u *ChildUsecase) HandleRemoveGuardian(ctx context.Context, input RemoveGuardianInput) error {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	if err := input.validate(); err != nil {
+		return UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	child, relation, err := u.findChild(ctx, requester, input.ChildID)
+	if err != nil {
+		return err
+	}
+
+	action := ActionManageGuardian
+	if input.UserID == requester.ID {
+		action = ActionLeaveGuardianship
+	}
+
+	if err := Authorize(requester, action, relation); err != nil {
+		return err
+	}
+
+	err = u.childGuardianRepo.DeleteGuardian(ctx, child.ID, input.UserID)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).WithField("input", helper.Dump(input)).Error("failed to remove the child's guardian")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return UsecaseError{
+			ErrType: ErrNotFound,
+			Message: "the user is not a guardian of the child",
+		}
+	case nil:
+		break
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionRemoveGuardian,
+		TargetType: model.AuditTargetChild,
+		TargetID:   child.ID.String(),
+		Metadata: model.AuditMetadata{
+			"user_id": input.UserID,
+		},
+	})
+
+	return nil
+}
+
+//nolint:lll
+func guardianInvitationEmailTemplate(childName, token string) string {
+	return emailLayout(emailLayoutInput{
+		Title: "Undangan Wali Anak",
+		Content: []string{
+			emailParagraph(fmt.Sprintf("Anda diundang untuk menjadi wali dari anak bernama <strong>%s</strong> pada layanan Autism Treatment Evaluation Checklist (ATEC). Sebagai wali, Anda dapat melihat statistik dan riwayat hasil serta mengisi kuesioner untuk anak tersebut.", html.EscapeString(childName))),
+			emailParagraph("Silakan masuk menggunakan akun yang terdaftar dengan email ini, lalu klik tombol berikut untuk menerima undangan:"),
+			emailButton("Terima Undangan", config.ServerGuardianInvitationBaseURL(), model.GuardianInvitationTokenQuery, token),
+		},
+		Footer: "Undangan ini hanya dapat digunakan satu kali. Jika Anda tidak mengenal anak ini, abaikan email ini.",
+	})
+}
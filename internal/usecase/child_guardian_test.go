@@ -0,0 +1,577 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	mockCommon "github.com/luckyAkbar/atec/mocks/internal_/common"
+	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/sendinblue/APIv3-go-library/v2/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChildUsecase_HandleInviteGuardian(t *testing.T) {
+	ctx := context.Background()
+	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockChildGuardianRepo := mockUsecase.NewChildGuardianRepository(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockMailer := mockCommon.NewMailerIface(t)
+	uc := usecase.NewChildUsecase(
+		mockChildRepo, nil, nil, mockAuditLogRepo, nil, mockChildGuardianRepo, mockEmailTokenRepo, nil, mockSharedCryptor, mockMailer,
+	)
+
+	owner := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	ownerCtx := model.SetUserToCtx(ctx, owner)
+
+	guardian := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	guardianCtx := model.SetUserToCtx(ctx, guardian)
+
+	child := &model.Child{ID: uuid.New(), ParentUserID: owner.ID, Name: "child"}
+	validInput := usecase.InviteGuardianInput{ChildID: child.ID, Email: "guardian@example.com"}
+	encryptedEmail := "encrypted-guardian-email"
+
+	// expectUntilTokenSigned set the expectation of every call made until the invitation token is signed
+	expectUntilTokenSigned := func() {
+		mockChildRepo.EXPECT().FindByID(ownerCtx, child.ID).Return(child, nil).Once()
+		mockSharedCryptor.EXPECT().Encrypt(validInput.Email).Return(encryptedEmail, nil).Once()
+		mockEmailTokenRepo.EXPECT().Create(ownerCtx, mock.MatchedBy(func(input usecase.RepoCreateEmailTokenInput) bool {
+			return input.UserID == uuid.Nil && input.Purpose == string(usecase.GuardianInvitation)
+		})).Return(&model.EmailToken{ID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}, nil).Once()
+		mockSharedCryptor.EXPECT().CreateJWT(mock.MatchedBy(func(claims jwt.RegisteredClaims) bool {
+			return len(claims.Audience) == 2 && claims.Audience[0] == child.ID.String() && claims.Audience[1] == encryptedEmail
+		})).Return("invitation-token", nil).Once()
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.InviteGuardianInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "invalid email",
+			ctx:         ownerCtx,
+			input:       usecase.InviteGuardianInput{ChildID: child.ID, Email: "invalid"},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "child not found",
+			ctx:         ownerCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(ownerCtx, child.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "guardian can not invite another guardian",
+			ctx:         guardianCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(guardianCtx, child.ID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(guardianCtx, child.ID, guardian.ID).Return(&model.ChildGuardian{
+					ChildID: child.ID,
+					UserID:  guardian.ID,
+					Role:    model.GuardianRoleGuardian,
+				}, nil).Once()
+			},
+		},
+		{
+			name:        "failed to send the invitation email",
+			ctx:         ownerCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectUntilTokenSigned()
+				mockMailer.EXPECT().SendEmail(ownerCtx, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:  "ok",
+			ctx:   ownerCtx,
+			input: validInput,
+			expectedFunctionCall: func() {
+				expectUntilTokenSigned()
+				mockMailer.EXPECT().SendEmail(ownerCtx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == validInput.Email
+				})).Return(&lib.CreateSmtpEmail{}, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(ownerCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    owner.ID,
+					ActorRole:  model.RolesParent,
+					Action:     model.AuditActionInviteGuardian,
+					TargetType: model.AuditTargetChild,
+					TargetID:   child.ID.String(),
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleInviteGuardian(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				assertUsecaseErrType(t, tc.expectedErr, err)
+				assert.Nil(t, res)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "invitation sent", res.Message)
+		})
+	}
+}
+
+func TestChildUsecase_HandleAcceptGuardianInvitation(t *testing.T) {
+	ctx := context.Background()
+	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockChildGuardianRepo := mockUsecase.NewChildGuardianRepository(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	mockTxCtrlFactory := mockUsecase.NewTransactionControllerFactory(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	uc := usecase.NewChildUsecase(
+		mockChildRepo, nil, mockUserRepo, mockAuditLogRepo, nil, mockChildGuardianRepo, mockEmailTokenRepo, mockTxCtrlFactory, mockSharedCryptor, nil,
+	)
+
+	requester := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	userCtx := model.SetUserToCtx(ctx, requester)
+
+	user := &model.User{ID: requester.ID, Email: "encrypted-user-email", Username: "guardian"}
+	child := &model.Child{ID: uuid.New(), ParentUserID: uuid.New()}
+	tokenID := uuid.New()
+	invitedEmailEncrypted := "encrypted-invited-email"
+	validateJWTOpts := common.ValidateJWTOpts{
+		Issuer:  string(usecase.TokenIssuerSystem),
+		Subject: string(usecase.GuardianInvitation),
+	}
+
+	invitationToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": string(usecase.TokenIssuerSystem),
+		"sub": string(usecase.GuardianInvitation),
+		"aud": []string{child.ID.String(), invitedEmailEncrypted},
+		"exp": jwt.NewNumericDate(time.Now().Add(time.Hour)).Unix(),
+		"jti": tokenID.String(),
+	})
+	invitationToken.Valid = true
+
+	invitationTokenString, err := invitationToken.SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	validInput := usecase.AcceptGuardianInvitationInput{InvitationToken: invitationTokenString}
+
+	consumeInput := usecase.RepoConsumeEmailTokenInput{
+		ID:      tokenID,
+		UserID:  uuid.Nil,
+		Purpose: string(usecase.GuardianInvitation),
+	}
+
+	createInput := usecase.RepoCreateChildGuardianInput{
+		ChildID: child.ID,
+		UserID:  requester.ID,
+		Role:    model.GuardianRoleGuardian,
+	}
+
+	// expectUntilEmailDecrypted set the expectation until both the invited and the requester email are decrypted
+	expectUntilEmailDecrypted := func(userEmail string) {
+		mockSharedCryptor.EXPECT().ValidateJWT(invitationTokenString, validateJWTOpts).Return(invitationToken, nil).Once()
+		mockSharedCryptor.EXPECT().Decrypt(invitedEmailEncrypted).Return("guardian@example.com", nil).Once()
+		mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+		mockSharedCryptor.EXPECT().Decrypt(user.Email).Return(userEmail, nil).Once()
+	}
+
+	// expectTransactionBegin set the expectation until the transaction is started and return the underlying transaction
+	expectTransactionBegin := func() *mockUsecase.TransactionController {
+		expectUntilEmailDecrypted("Guardian@example.com")
+		mockChildRepo.EXPECT().FindByID(userCtx, child.ID).Return(child, nil).Once()
+
+		underlyingTransaction := mockUsecase.NewTransactionController(t)
+		txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
+
+		mockTxCtrlFactory.EXPECT().New().Return(txCtrlWrapper).Once()
+		underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+		return underlyingTransaction
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.AcceptGuardianInvitationInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "invitation token is required",
+			ctx:         userCtx,
+			input:       usecase.AcceptGuardianInvitationInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "invalid invitation token",
+			ctx:         userCtx,
+			input:       usecase.AcceptGuardianInvitationInput{InvitationToken: "invalid"},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT("invalid", validateJWTOpts).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "invitation was sent to another email",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				expectUntilEmailDecrypted("another@example.com")
+			},
+		},
+		{
+			name:        "child not found",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				expectUntilEmailDecrypted("guardian@example.com")
+				mockChildRepo.EXPECT().FindByID(userCtx, child.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "invitation already used",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(userCtx, consumeInput, mock.Anything).Return(usecase.ErrRepoNotFound).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name:        "already a guardian of the child",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(userCtx, consumeInput, mock.Anything).Return(nil).Once()
+				mockChildGuardianRepo.EXPECT().Create(userCtx, createInput, mock.Anything).Return(nil, usecase.ErrRepoDuplicate).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name:        "failed to commit",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(userCtx, consumeInput, mock.Anything).Return(nil).Once()
+				mockChildGuardianRepo.EXPECT().Create(userCtx, createInput, mock.Anything).Return(&model.ChildGuardian{}, nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:  "ok",
+			ctx:   userCtx,
+			input: validInput,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(userCtx, consumeInput, mock.Anything).Return(nil).Once()
+				mockChildGuardianRepo.EXPECT().Create(userCtx, createInput, mock.Anything).Return(&model.ChildGuardian{
+					ID:      uuid.New(),
+					ChildID: child.ID,
+					UserID:  requester.ID,
+					Role:    model.GuardianRoleGuardian,
+				}, nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(nil).Once()
+				mockAuditLogRepo.EXPECT().Create(userCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    requester.ID,
+					ActorRole:  model.RolesParent,
+					Action:     model.AuditActionAcceptGuardianship,
+					TargetType: model.AuditTargetChild,
+					TargetID:   child.ID.String(),
+					Metadata: model.AuditMetadata{
+						"owner_id": child.ParentUserID,
+					},
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleAcceptGuardianInvitation(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				assertUsecaseErrType(t, tc.expectedErr, err)
+				assert.Nil(t, res)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, child.ID, res.ChildID)
+			assert.Equal(t, requester.ID, res.UserID)
+			assert.Equal(t, user.Username, res.Username)
+			assert.Equal(t, model.GuardianRoleGuardian, res.Role)
+		})
+	}
+}
+
+func TestChildUsecase_HandleListGuardians(t *testing.T) {
+	ctx := context.Background()
+	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockChildGuardianRepo := mockUsecase.NewChildGuardianRepository(t)
+	uc := usecase.NewChildUsecase(mockChildRepo, nil, mockUserRepo, nil, nil, mockChildGuardianRepo, nil, nil, nil, nil)
+
+	owner := &model.User{ID: uuid.New(), Username: "owner"}
+	guardian := &model.User{ID: uuid.New(), Username: "guardian"}
+	stranger := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	strangerCtx := model.SetUserToCtx(ctx, stranger)
+	guardianCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: guardian.ID, Role: model.RolesParent})
+
+	child := &model.Child{ID: uuid.New(), ParentUserID: owner.ID}
+	guardianMembership := &model.ChildGuardian{ChildID: child.ID, UserID: guardian.ID, Role: model.GuardianRoleGuardian}
+	memberships := []model.ChildGuardian{
+		{ChildID: child.ID, UserID: owner.ID, Role: model.GuardianRoleOwner},
+		*guardianMembership,
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "requester is not one of the child's guardians",
+			ctx:         strangerCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(strangerCtx, child.ID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(strangerCtx, child.ID, stranger.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "failed to find the guardians",
+			ctx:         guardianCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(guardianCtx, child.ID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(guardianCtx, child.ID, guardian.ID).Return(guardianMembership, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildID(guardianCtx, child.ID).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name: "ok",
+			ctx:  guardianCtx,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(guardianCtx, child.ID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(guardianCtx, child.ID, guardian.ID).Return(guardianMembership, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildID(guardianCtx, child.ID).Return(memberships, nil).Once()
+				mockUserRepo.EXPECT().FindByID(guardianCtx, owner.ID).Return(owner, nil).Once()
+				mockUserRepo.EXPECT().FindByID(guardianCtx, guardian.ID).Return(guardian, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleListGuardians(tc.ctx, usecase.ListGuardiansInput{ChildID: child.ID})
+
+			if tc.wantErr {
+				assertUsecaseErrType(t, tc.expectedErr, err)
+				assert.Nil(t, res)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, res, 2)
+			assert.Equal(t, owner.Username, res[0].Username)
+			assert.Equal(t, model.GuardianRoleOwner, res[0].Role)
+			assert.Equal(t, guardian.Username, res[1].Username)
+			assert.Equal(t, model.GuardianRoleGuardian, res[1].Role)
+		})
+	}
+}
+
+func TestChildUsecase_HandleRemoveGuardian(t *testing.T) {
+	ctx := context.Background()
+	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockChildGuardianRepo := mockUsecase.NewChildGuardianRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	uc := usecase.NewChildUsecase(mockChildRepo, nil, nil, mockAuditLogRepo, nil, mockChildGuardianRepo, nil, nil, nil, nil)
+
+	owner := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	ownerCtx := model.SetUserToCtx(ctx, owner)
+	guardian := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	guardianCtx := model.SetUserToCtx(ctx, guardian)
+
+	child := &model.Child{ID: uuid.New(), ParentUserID: owner.ID}
+	guardianMembership := &model.ChildGuardian{ChildID: child.ID, UserID: guardian.ID, Role: model.GuardianRoleGuardian}
+
+	expectAuditLog := func(ctx context.Context, actor model.AuthUser) {
+		mockAuditLogRepo.EXPECT().Create(ctx, usecase.RepoCreateAuditLogInput{
+			ActorID:    actor.ID,
+			ActorRole:  actor.Role,
+			Action:     model.AuditActionRemoveGuardian,
+			TargetType: model.AuditTargetChild,
+			TargetID:   child.ID.String(),
+			Metadata: model.AuditMetadata{
+				"user_id": guardian.ID,
+			},
+		}).Return(nil).Once()
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.RemoveGuardianInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       usecase.RemoveGuardianInput{ChildID: child.ID, UserID: guardian.ID},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "guardian can not remove the owner",
+			ctx:         guardianCtx,
+			input:       usecase.RemoveGuardianInput{ChildID: child.ID, UserID: owner.ID},
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(guardianCtx, child.ID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(guardianCtx, child.ID, guardian.ID).Return(guardianMembership, nil).Once()
+			},
+		},
+		{
+			name:        "owner can not leave the child",
+			ctx:         ownerCtx,
+			input:       usecase.RemoveGuardianInput{ChildID: child.ID, UserID: owner.ID},
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(ownerCtx, child.ID).Return(child, nil).Once()
+			},
+		},
+		{
+			name:        "guardian not found",
+			ctx:         ownerCtx,
+			input:       usecase.RemoveGuardianInput{ChildID: child.ID, UserID: guardian.ID},
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(ownerCtx, child.ID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().DeleteGuardian(ownerCtx, child.ID, guardian.ID).Return(usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:  "owner removes the guardian",
+			ctx:   ownerCtx,
+			input: usecase.RemoveGuardianInput{ChildID: child.ID, UserID: guardian.ID},
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(ownerCtx, child.ID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().DeleteGuardian(ownerCtx, child.ID, guardian.ID).Return(nil).Once()
+				expectAuditLog(ownerCtx, owner)
+			},
+		},
+		{
+			name:  "guardian leaves the child",
+			ctx:   guardianCtx,
+			input: usecase.RemoveGuardianInput{ChildID: child.ID, UserID: guardian.ID},
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(guardianCtx, child.ID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(guardianCtx, child.ID, guardian.ID).Return(guardianMembership, nil).Once()
+				mockChildGuardianRepo.EXPECT().DeleteGuardian(guardianCtx, child.ID, guardian.ID).Return(nil).Once()
+				expectAuditLog(guardianCtx, guardian)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			err := uc.HandleRemoveGuardian(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				assertUsecaseErrType(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -152,7 +152,7 @@ func TestChildUsecase_Register(t *testing.T) {
 
 	mockChildRepo := mockUsecase.NewChildRepository(t)
 
-	uc := usecase.NewChildUsecase(mockChildRepo, nil, nil)
+	uc := usecase.NewChildUsecase(mockChildRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	childID := uuid.New()
 	dateOfBirth := time.Now()
@@ -312,7 +312,7 @@ func TestChildUsecase_Update(t *testing.T) {
 
 	mockChildRepo := mockUsecase.NewChildRepository(t)
 
-	uc := usecase.NewChildUsecase(mockChildRepo, nil, nil)
+	uc := usecase.NewChildUsecase(mockChildRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	childID := uuid.New()
 	dateOfBirth := time.Now()
@@ -525,10 +525,12 @@ func TestAuthUsecase_GetRegisteredChildren(t *testing.T) {
 
 	userCtx := model.SetUserToCtx(ctx, user)
 
+	archived := false
+
 	mockChildRepo := mockUsecase.NewChildRepository(t)
 	mockUserRepo := mockUsecase.NewUserRepository(t)
 
-	uc := usecase.NewChildUsecase(mockChildRepo, nil, mockUserRepo)
+	uc := usecase.NewChildUsecase(mockChildRepo, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
 
 	children := []model.Child{
 		{
@@ -542,6 +544,21 @@ func TestAuthUsecase_GetRegisteredChildren(t *testing.T) {
 		},
 	}
 
+	otherParentID := uuid.New()
+	sharedChildren := []model.Child{
+		children[0],
+		{
+			ID:           uuid.New(),
+			ParentUserID: otherParentID,
+			Name:         "Shared Child",
+		},
+		{
+			ID:           uuid.New(),
+			ParentUserID: otherParentID,
+			Name:         "Another Shared Child",
+		},
+	}
+
 	testCases := []struct {
 		name                 string
 		input                usecase.GetRegisteredChildrenInput
@@ -549,6 +566,7 @@ func TestAuthUsecase_GetRegisteredChildren(t *testing.T) {
 		wantErr              bool
 		expectedErr          error
 		expectedOutputLen    int
+		expectedRoles        []model.GuardianRole
 		expectedFunctionCall func()
 	}{
 		{
@@ -591,9 +609,10 @@ func TestAuthUsecase_GetRegisteredChildren(t *testing.T) {
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
 				mockChildRepo.EXPECT().Search(userCtx, usecase.RepoSearchChildInput{
-					ParentUserID: &userID,
-					Limit:        20,
-					Offset:       1,
+					GuardianUserID: &userID,
+					Archived:       &archived,
+					Limit:          20,
+					Offset:         1,
 				}).Return(nil, assert.AnError).Once()
 			},
 		},
@@ -608,9 +627,10 @@ func TestAuthUsecase_GetRegisteredChildren(t *testing.T) {
 			expectedErr: usecase.ErrNotFound,
 			expectedFunctionCall: func() {
 				mockChildRepo.EXPECT().Search(userCtx, usecase.RepoSearchChildInput{
-					ParentUserID: &userID,
-					Limit:        20,
-					Offset:       1,
+					GuardianUserID: &userID,
+					Archived:       &archived,
+					Limit:          20,
+					Offset:         1,
 				}).Return(nil, usecase.ErrRepoNotFound).Once()
 			},
 		},
@@ -626,9 +646,10 @@ func TestAuthUsecase_GetRegisteredChildren(t *testing.T) {
 			expectedOutputLen: 1,
 			expectedFunctionCall: func() {
 				mockChildRepo.EXPECT().Search(userCtx, usecase.RepoSearchChildInput{
-					ParentUserID: &userID,
-					Limit:        20,
-					Offset:       1,
+					GuardianUserID: &userID,
+					Archived:       &archived,
+					Limit:          20,
+					Offset:         1,
 				}).Return(children, nil).Once()
 				mockUserRepo.EXPECT().FindByID(userCtx, userID).Return(nil, assert.AnError).Once()
 			},
@@ -645,9 +666,10 @@ func TestAuthUsecase_GetRegisteredChildren(t *testing.T) {
 			expectedOutputLen: 1,
 			expectedFunctionCall: func() {
 				mockChildRepo.EXPECT().Search(userCtx, usecase.RepoSearchChildInput{
-					ParentUserID: &userID,
-					Limit:        20,
-					Offset:       1,
+					GuardianUserID: &userID,
+					Archived:       &archived,
+					Limit:          20,
+					Offset:         1,
 				}).Return(children, nil).Once()
 				mockUserRepo.EXPECT().FindByID(userCtx, userID).Return(nil, usecase.ErrRepoNotFound).Once()
 			},
@@ -663,13 +685,35 @@ func TestAuthUsecase_GetRegisteredChildren(t *testing.T) {
 			expectedOutputLen: 1,
 			expectedFunctionCall: func() {
 				mockChildRepo.EXPECT().Search(userCtx, usecase.RepoSearchChildInput{
-					ParentUserID: &userID,
-					Limit:        20,
-					Offset:       1,
+					GuardianUserID: &userID,
+					Archived:       &archived,
+					Limit:          20,
+					Offset:         1,
 				}).Return(children, nil).Once()
 				mockUserRepo.EXPECT().FindByID(userCtx, userID).Return(&model.User{ID: userID}, nil).Once()
 			},
 		},
+		{
+			name: "ok - including the children shared by other owner",
+			input: usecase.GetRegisteredChildrenInput{
+				Limit:  20,
+				Offset: 1,
+			},
+			ctx:               userCtx,
+			wantErr:           false,
+			expectedOutputLen: 3,
+			expectedRoles:     []model.GuardianRole{model.GuardianRoleOwner, model.GuardianRoleGuardian, model.GuardianRoleGuardian},
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().Search(userCtx, usecase.RepoSearchChildInput{
+					GuardianUserID: &userID,
+					Archived:       &archived,
+					Limit:          20,
+					Offset:         1,
+				}).Return(sharedChildren, nil).Once()
+				mockUserRepo.EXPECT().FindByID(userCtx, userID).Return(&model.User{ID: userID, Username: "me"}, nil).Once()
+				mockUserRepo.EXPECT().FindByID(userCtx, otherParentID).Return(&model.User{ID: otherParentID, Username: "other"}, nil).Once()
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -684,6 +728,10 @@ func TestAuthUsecase_GetRegisteredChildren(t *testing.T) {
 				require.NoError(t, err)
 				assert.Len(t, res, tc.expectedOutputLen)
 
+				for i, role := range tc.expectedRoles {
+					assert.Equal(t, role, res[i].GuardianRole)
+				}
+
 				return
 			}
 
@@ -710,13 +758,26 @@ func TestChildUsecase_Search(t *testing.T) {
 
 	userCtx := model.SetUserToCtx(ctx, user)
 
+	archived := false
+	adminCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator})
+
+	organizationAdmin := model.AuthUser{
+		ID:                  uuid.New(),
+		Role:                model.RolesTherapist,
+		OrganizationID:      uuid.New(),
+		IsOrganizationAdmin: true,
+	}
+	organizationAdminCtx := model.SetUserToCtx(ctx, organizationAdmin)
+
 	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
 
-	uc := usecase.NewChildUsecase(mockChildRepo, nil, nil)
+	uc := usecase.NewChildUsecase(mockChildRepo, nil, nil, mockAuditLogRepo, nil, nil, nil, nil, nil, nil)
 
 	parentUserID := uuid.New()
 	name := "Jane Doe"
 	gender := false
+	otherOrganizationID := uuid.New()
 
 	children := []model.Child{
 		{
@@ -771,8 +832,10 @@ func TestChildUsecase_Search(t *testing.T) {
 			expectedFunctionCall: func() {
 				mockChildRepo.EXPECT().Search(userCtx, usecase.RepoSearchChildInput{
 					ParentUserID: &parentUserID,
+					TherapistID:  &userID,
 					Name:         &name,
 					Gender:       &gender,
+					Archived:     &archived,
 					Limit:        10,
 					Offset:       1,
 				}).Return(nil, assert.AnError).Once()
@@ -793,13 +856,49 @@ func TestChildUsecase_Search(t *testing.T) {
 			expectedFunctionCall: func() {
 				mockChildRepo.EXPECT().Search(userCtx, usecase.RepoSearchChildInput{
 					ParentUserID: &parentUserID,
+					TherapistID:  &userID,
 					Name:         &name,
 					Gender:       &gender,
+					Archived:     &archived,
 					Limit:        10,
 					Offset:       1,
 				}).Return(nil, usecase.ErrRepoNotFound).Once()
 			},
 		},
+		{
+			name: "administrator is able to search across the organizations",
+			input: usecase.SearchChildInput{
+				OrganizationID: &otherOrganizationID,
+				Limit:          10,
+			},
+			ctx:         adminCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().Search(adminCtx, usecase.RepoSearchChildInput{
+					OrganizationID: &otherOrganizationID,
+					Archived:       &archived,
+					Limit:          10,
+				}).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name: "organization admin is isolated to the own organization",
+			input: usecase.SearchChildInput{
+				OrganizationID: &otherOrganizationID,
+				Limit:          10,
+			},
+			ctx:         organizationAdminCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().Search(organizationAdminCtx, usecase.RepoSearchChildInput{
+					OrganizationID: &organizationAdmin.OrganizationID,
+					Archived:       &archived,
+					Limit:          10,
+				}).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
 		{
 			name: "ok",
 			input: usecase.SearchChildInput{
@@ -815,11 +914,27 @@ func TestChildUsecase_Search(t *testing.T) {
 			expectedFunctionCall: func() {
 				mockChildRepo.EXPECT().Search(userCtx, usecase.RepoSearchChildInput{
 					ParentUserID: &parentUserID,
+					TherapistID:  &userID,
 					Name:         &name,
 					Gender:       &gender,
+					Archived:     &archived,
 					Limit:        10,
 					Offset:       1,
 				}).Return(children, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(userCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    userID,
+					ActorRole:  model.RolesTherapist,
+					Action:     model.AuditActionSearchChild,
+					TargetType: model.AuditTargetChild,
+					Metadata: model.AuditMetadata{
+						"parent_user_id": &parentUserID,
+						"name":           &name,
+						"gender":         &gender,
+						"limit":          10,
+						"offset":         1,
+						"child_ids":      []string{children[0].ID.String()},
+					},
+				}).Return(nil).Once()
 			},
 		},
 	}
@@ -878,8 +993,10 @@ func TestChildUseccase_HandleGetStatistic(t *testing.T) {
 
 	mockChildRepo := mockUsecase.NewChildRepository(t)
 	mockResultRepo := mockUsecase.NewResultRepository(t)
+	mockCareRelationshipRepo := mockUsecase.NewCareRelationshipRepository(t)
+	mockChildGuardianRepo := mockUsecase.NewChildGuardianRepository(t)
 
-	uc := usecase.NewChildUsecase(mockChildRepo, mockResultRepo, nil)
+	uc := usecase.NewChildUsecase(mockChildRepo, mockResultRepo, nil, nil, mockCareRelationshipRepo, mockChildGuardianRepo, nil, nil, nil, nil)
 
 	childID := uuid.New()
 	child := &model.Child{
@@ -958,13 +1075,76 @@ func TestChildUseccase_HandleGetStatistic(t *testing.T) {
 			},
 			ctx:         nonParentCtx,
 			wantErr:     true,
-			expectedErr: usecase.ErrUnauthorized,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(nonParentCtx, childID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(nonParentCtx, childID, nonParentID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name: "failed to find the requester guardian membership",
+			input: usecase.GetStatisticInput{
+				ChildID: childID,
+			},
+			ctx:         nonParentCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(nonParentCtx, childID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(nonParentCtx, childID, nonParentID).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name: "guardian: 1 query only if result less than batch size",
+			input: usecase.GetStatisticInput{
+				ChildID: childID,
+			},
+			ctx:               nonParentCtx,
+			wantErr:           false,
+			expectedOutputLen: 10,
 			expectedFunctionCall: func() {
 				mockChildRepo.EXPECT().FindByID(nonParentCtx, childID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(nonParentCtx, childID, nonParentID).
+					Return(&model.ChildGuardian{ChildID: childID, UserID: nonParentID, Role: model.GuardianRoleGuardian}, nil).Once()
+				mockResultRepo.EXPECT().Search(nonParentCtx, usecase.RepoSearchResultInput{
+					ChildID: childID,
+					Limit:   batchSize,
+					Offset:  0,
+				}).Return(genResult(10), nil).Once()
+			},
+		},
+		{
+			name: "therapist not assigned to the child",
+			input: usecase.GetStatisticInput{
+				ChildID: childID,
+			},
+			ctx:         therapistCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(therapistCtx, childID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(therapistCtx, childID, therapistID).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockCareRelationshipRepo.EXPECT().FindByChildIDAndTherapistID(therapistCtx, childID, therapistID).
+					Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name: "failed to find the therapist care relationship",
+			input: usecase.GetStatisticInput{
+				ChildID: childID,
+			},
+			ctx:         therapistCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(therapistCtx, childID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(therapistCtx, childID, therapistID).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockCareRelationshipRepo.EXPECT().FindByChildIDAndTherapistID(therapistCtx, childID, therapistID).
+					Return(nil, assert.AnError).Once()
 			},
 		},
 		{
-			name: "admin: search from repository returning unexpected error",
+			name: "assigned therapist: search from repository returning unexpected error",
 			input: usecase.GetStatisticInput{
 				ChildID: childID,
 			},
@@ -973,6 +1153,9 @@ func TestChildUseccase_HandleGetStatistic(t *testing.T) {
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
 				mockChildRepo.EXPECT().FindByID(therapistCtx, childID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(therapistCtx, childID, therapistID).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockCareRelationshipRepo.EXPECT().FindByChildIDAndTherapistID(therapistCtx, childID, therapistID).
+					Return(&model.CareRelationship{ChildID: childID, TherapistID: therapistID, Scope: model.CareScopeRead}, nil).Once()
 				mockResultRepo.EXPECT().Search(therapistCtx, usecase.RepoSearchResultInput{
 					ChildID: childID,
 					Limit:   batchSize,
@@ -1037,7 +1220,7 @@ func TestChildUseccase_HandleGetStatistic(t *testing.T) {
 			},
 		},
 		{
-			name: "admin: more query needed if result returned is equal to batch size",
+			name: "assigned therapist: more query needed if result returned is equal to batch size",
 			input: usecase.GetStatisticInput{
 				ChildID: childID,
 			},
@@ -1046,6 +1229,9 @@ func TestChildUseccase_HandleGetStatistic(t *testing.T) {
 			expectedOutputLen: 299,
 			expectedFunctionCall: func() {
 				mockChildRepo.EXPECT().FindByID(therapistCtx, childID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(therapistCtx, childID, therapistID).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockCareRelationshipRepo.EXPECT().FindByChildIDAndTherapistID(therapistCtx, childID, therapistID).
+					Return(&model.CareRelationship{ChildID: childID, TherapistID: therapistID, Scope: model.CareScopeSubmit}, nil).Once()
 				mockResultRepo.EXPECT().Search(therapistCtx, usecase.RepoSearchResultInput{
 					ChildID: childID,
 					Limit:   batchSize,
@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"html"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/config"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// InitiateChildTransferInput input
+type InitiateChildTransferInput struct {
+	ChildID uuid.UUID `validate:"required"`
+	Email   string    `validate:"required,email"`
+}
+
+func (i InitiateChildTransferInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// InitiateChildTransferOutput output
+type InitiateChildTransferOutput struct {
+	Message string
+}
+
+// HandleInitiateChildTransfer allow the child's owner to start moving the child to another account.
+// The transfer is a signed single use token bound to the child, the current owner and the recipient email,
+// and nothing is moved until the account registered with the recipient email accepts it
+func (u *ChildUsecase) HandleInitiateChildTransfer(ctx context.Context, input InitiateChildTransferInput) (*InitiateChildTransferOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"requester-id": requester.ID,
+		"child-id":     input.ChildID,
+		"func":         "ChildUsecase.HandleInitiateChildTransfer",
+	})
+
+	child, relation, err := u.findChild(ctx, requester, input.ChildID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Authorize(requester, ActionTransferChild, relation); err != nil {
+		return nil, err
+	}
+
+	emailEncrypted, err := u.sharedCryptor.Encrypt(input.Email)
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: "encryption process failed",
+		}
+	}
+
+	// the current owner is carried by the token, so the transfer is no longer valid once the child has moved elsewhere
+	token, err := signEmailToken(
+		ctx, u.sharedCryptor, u.emailTokenRepo, requester.ID, []string{child.ID.String(), requester.ID.String(), emailEncrypted},
+		ChildTransferToken, config.ChildTransferTokenExpiry(),
+	)
+	if err != nil {
+		logger.WithError(err).Error("failed to create JWT token for child transfer")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	_, err = u.mailer.SendEmail(ctx, common.SendEmailInput{
+		ReceiverName:  input.Email,
+		ReceiverEmail: input.Email,
+		Subject:       "Pemindahan Data Anak",
+		HTMLContent:   childTransferEmailTemplate(child.Name, token),
+	})
+
+	if err != nil {
+		logger.WithError(err).Error("failed to send child transfer email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionInitiateTransfer,
+		TargetType: model.AuditTargetChild,
+		TargetID:   child.ID.String(),
+	})
+
+	return &InitiateChildTransferOutput{
+		Message: "transfer request sent",
+	}, nil
+}
+
+// AcceptChildTransferInput input
+type AcceptChildTransferInput struct {
+	TransferToken string `validate:"required"`
+}
+
+func (i AcceptChildTransferInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// AcceptChildTransferOutput output
+type AcceptChildTransferOutput struct {
+	ChildID              uuid.UUID
+	PreviousParentUserID uuid.UUID
+	ParentUserID         uuid.UUID
+}
+
+// HandleAcceptChildTransfer redeem the transfer token and move the child to the requester. The child's parent
+// and the results the previous parent created for the child are moved, and the care relationships granted by
+// the previous parent are revoked, in one transaction. The transfer can only
+// be accepted by the account registered with the recipient email, and only while the child is still owned by
+// the parent who started the transfer
+func (u *ChildUsecase) HandleAcceptChildTransfer(ctx context.Context, input AcceptChildTransferInput) (*AcceptChildTransferOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if err := Authorize(requester, ActionAcceptTransfer, RelationNone); err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"requester-id": requester.ID,
+		"func":         "ChildUsecase.HandleAcceptChildTransfer",
+	})
+
+	_, claims, err := parseJWTToken(u.sharedCryptor, input.TransferToken, parseJWTTokenInput{
+		expectedIssuer:      TokenIssuerSystem,
+		expectedSubject:     ChildTransferToken,
+		expectedAudiences:   nil,
+		expectedAudienceLen: 3,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// no need to check the err here, because it's already checked
+	// when calling the parseJWTToken
+	audiences, _ := claims.GetAudience()
+
+	childID, err := uuid.Parse(audiences[0])
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "invalid child on the transfer",
+		}
+	}
+
+	previousParentID, err := uuid.Parse(audiences[1])
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "invalid parent on the transfer",
+		}
+	}
+
+	if previousParentID == requester.ID {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "the child is already registered to your account",
+		}
+	}
+
+	if _, err := u.findInvitedUser(ctx, requester, audiences[2]); err != nil {
+		return nil, err
+	}
+
+	logger = logger.WithField("child-id", childID)
+
+	txCtrl := u.transactionControllerFactory.New()
+	tx := txCtrl.Begin()
+
+	if err := consumeEmailToken(ctx, u.emailTokenRepo, claims, previousParentID, ChildTransferToken, tx); err != nil {
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return nil, err
+	}
+
+	transferInput := RepoTransferChildInput{
+		ChildID:    childID,
+		FromUserID: previousParentID,
+		ToUserID:   requester.ID,
+	}
+
+	err = u.childRepo.TransferOwnership(ctx, transferInput, tx)
+	switch err {
+	default:
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		logger.WithError(err).Error("failed to transfer the child")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "the child is no longer owned by the parent who started the transfer",
+		}
+	case nil:
+		break
+	}
+
+	if err := u.resultRepo.TransferChildResults(ctx, transferInput, tx); err != nil {
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		logger.WithError(err).Error("failed to transfer the child's results")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	// the previous owner's guardian membership is moved by TransferOwnership, while the therapists given access by
+	// the previous owner, including the previous owner themselves, must be granted again by the new owner
+	if err := u.careRelationshipRepo.DeleteByUser(ctx, childID, previousParentID, tx); err != nil {
+		if err := txCtrl.Rollback(); err != nil {
+			logger.WithError(err).Error("failed to rollback transaction")
+		}
+
+		logger.WithError(err).Error("failed to revoke the care relationships granted by the previous owner")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if err := txCtrl.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionTransferChild,
+		TargetType: model.AuditTargetChild,
+		TargetID:   childID.String(),
+		Metadata: model.AuditMetadata{
+			"from_user_id": previousParentID,
+			"to_user_id":   requester.ID,
+		},
+	})
+
+	return &AcceptChildTransferOutput{
+		ChildID:              childID,
+		PreviousParentUserID: previousParentID,
+		ParentUserID:         requester.ID,
+	}, nil
+}
+
+//nolint:lll
+func childTransferEmailTemplate(childName, token string) string {
+	return emailLayout(emailLayoutInput{
+		Title: "Pemindahan Data Anak",
+		Content: []string{
+			emailParagraph(fmt.Sprintf("Data anak bernama <strong>%s</strong> beserta riwayat hasil kuesionernya akan dipindahkan ke akun Anda pada layanan Autism Treatment Evaluation Checklist (ATEC). Setelah diterima, pemilik sebelumnya tidak lagi memiliki akses ke data anak tersebut.", html.EscapeString(childName))),
+			emailParagraph("Silakan masuk menggunakan akun yang terdaftar dengan email ini, lalu klik tombol berikut untuk menerima pemindahan:"),
+			emailButton("Terima Pemindahan", config.ServerChildTransferBaseURL(), model.ChildTransferTokenQuery, token),
+		},
+		Footer: "Tautan ini hanya dapat digunakan satu kali. Jika Anda tidak mengenal anak ini, abaikan email ini.",
+	})
+}
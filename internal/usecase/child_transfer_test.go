@@ -0,0 +1,404 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	mockCommon "github.com/luckyAkbar/atec/mocks/internal_/common"
+	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/sendinblue/APIv3-go-library/v2/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChildUsecase_HandleInitiateChildTransfer(t *testing.T) {
+	ctx := context.Background()
+	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockChildGuardianRepo := mockUsecase.NewChildGuardianRepository(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockMailer := mockCommon.NewMailerIface(t)
+	uc := usecase.NewChildUsecase(
+		mockChildRepo, nil, nil, mockAuditLogRepo, nil, mockChildGuardianRepo, mockEmailTokenRepo, nil, mockSharedCryptor, mockMailer,
+	)
+
+	owner := model.AuthUser{ID: uuid.New(), Role: model.RolesTherapist}
+	ownerCtx := model.SetUserToCtx(ctx, owner)
+
+	guardian := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	guardianCtx := model.SetUserToCtx(ctx, guardian)
+
+	child := &model.Child{ID: uuid.New(), ParentUserID: owner.ID, Name: "child"}
+	validInput := usecase.InitiateChildTransferInput{ChildID: child.ID, Email: "parent@example.com"}
+	encryptedEmail := "encrypted-parent-email"
+
+	// expectUntilTokenSigned set the expectation of every call made until the transfer token is signed
+	expectUntilTokenSigned := func() {
+		mockChildRepo.EXPECT().FindByID(ownerCtx, child.ID).Return(child, nil).Once()
+		mockSharedCryptor.EXPECT().Encrypt(validInput.Email).Return(encryptedEmail, nil).Once()
+		mockEmailTokenRepo.EXPECT().Create(ownerCtx, mock.MatchedBy(func(input usecase.RepoCreateEmailTokenInput) bool {
+			return input.UserID == owner.ID && input.Purpose == string(usecase.ChildTransferToken)
+		})).Return(&model.EmailToken{ID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}, nil).Once()
+		mockSharedCryptor.EXPECT().CreateJWT(mock.MatchedBy(func(claims jwt.RegisteredClaims) bool {
+			return len(claims.Audience) == 3 &&
+				claims.Audience[0] == child.ID.String() &&
+				claims.Audience[1] == owner.ID.String() &&
+				claims.Audience[2] == encryptedEmail
+		})).Return("transfer-token", nil).Once()
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.InitiateChildTransferInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "invalid email",
+			ctx:         ownerCtx,
+			input:       usecase.InitiateChildTransferInput{ChildID: child.ID, Email: "invalid"},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "guardian can not transfer the child",
+			ctx:         guardianCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockChildRepo.EXPECT().FindByID(guardianCtx, child.ID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(guardianCtx, child.ID, guardian.ID).Return(&model.ChildGuardian{
+					ChildID: child.ID,
+					UserID:  guardian.ID,
+					Role:    model.GuardianRoleGuardian,
+				}, nil).Once()
+			},
+		},
+		{
+			name:        "failed to send the transfer email",
+			ctx:         ownerCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectUntilTokenSigned()
+				mockMailer.EXPECT().SendEmail(ownerCtx, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:  "ok",
+			ctx:   ownerCtx,
+			input: validInput,
+			expectedFunctionCall: func() {
+				expectUntilTokenSigned()
+				mockMailer.EXPECT().SendEmail(ownerCtx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == validInput.Email
+				})).Return(&lib.CreateSmtpEmail{}, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(ownerCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    owner.ID,
+					ActorRole:  model.RolesTherapist,
+					Action:     model.AuditActionInitiateTransfer,
+					TargetType: model.AuditTargetChild,
+					TargetID:   child.ID.String(),
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleInitiateChildTransfer(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				assertUsecaseErrType(t, tc.expectedErr, err)
+				assert.Nil(t, res)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "transfer request sent", res.Message)
+		})
+	}
+}
+
+func TestChildUsecase_HandleAcceptChildTransfer(t *testing.T) {
+	ctx := context.Background()
+	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockResultRepo := mockUsecase.NewResultRepository(t)
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	mockTxCtrlFactory := mockUsecase.NewTransactionControllerFactory(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockCareRelationshipRepo := mockUsecase.NewCareRelationshipRepository(t)
+	mockChildGuardianRepo := mockUsecase.NewChildGuardianRepository(t)
+	uc := usecase.NewChildUsecase(
+		mockChildRepo, mockResultRepo, mockUserRepo, mockAuditLogRepo, mockCareRelationshipRepo, mockChildGuardianRepo,
+		mockEmailTokenRepo, mockTxCtrlFactory, mockSharedCryptor, nil,
+	)
+
+	requester := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	userCtx := model.SetUserToCtx(ctx, requester)
+	previousOwnerID := uuid.New()
+	previousOwnerCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: previousOwnerID, Role: model.RolesTherapist})
+
+	user := &model.User{ID: requester.ID, Email: "encrypted-user-email"}
+	childID := uuid.New()
+	tokenID := uuid.New()
+	recipientEmailEncrypted := "encrypted-recipient-email"
+	validateJWTOpts := common.ValidateJWTOpts{
+		Issuer:  string(usecase.TokenIssuerSystem),
+		Subject: string(usecase.ChildTransferToken),
+	}
+
+	transferToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": string(usecase.TokenIssuerSystem),
+		"sub": string(usecase.ChildTransferToken),
+		"aud": []string{childID.String(), previousOwnerID.String(), recipientEmailEncrypted},
+		"exp": jwt.NewNumericDate(time.Now().Add(time.Hour)).Unix(),
+		"jti": tokenID.String(),
+	})
+	transferToken.Valid = true
+
+	transferTokenString, err := transferToken.SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	validInput := usecase.AcceptChildTransferInput{TransferToken: transferTokenString}
+
+	consumeInput := usecase.RepoConsumeEmailTokenInput{
+		ID:      tokenID,
+		UserID:  previousOwnerID,
+		Purpose: string(usecase.ChildTransferToken),
+	}
+
+	transferInput := usecase.RepoTransferChildInput{
+		ChildID:    childID,
+		FromUserID: previousOwnerID,
+		ToUserID:   requester.ID,
+	}
+
+	// expectUntilEmailDecrypted set the expectation until both the recipient and the requester email are decrypted
+	expectUntilEmailDecrypted := func(userEmail string) {
+		mockSharedCryptor.EXPECT().ValidateJWT(transferTokenString, validateJWTOpts).Return(transferToken, nil).Once()
+		mockSharedCryptor.EXPECT().Decrypt(recipientEmailEncrypted).Return("parent@example.com", nil).Once()
+		mockUserRepo.EXPECT().FindByID(userCtx, requester.ID).Return(user, nil).Once()
+		mockSharedCryptor.EXPECT().Decrypt(user.Email).Return(userEmail, nil).Once()
+	}
+
+	// expectTransactionBegin set the expectation until the transaction is started and return the underlying transaction
+	expectTransactionBegin := func() *mockUsecase.TransactionController {
+		expectUntilEmailDecrypted("parent@example.com")
+
+		underlyingTransaction := mockUsecase.NewTransactionController(t)
+		txCtrlWrapper := usecase.NewTxControllerWrapper(underlyingTransaction)
+
+		mockTxCtrlFactory.EXPECT().New().Return(txCtrlWrapper).Once()
+		underlyingTransaction.EXPECT().Begin().Return(struct{}{}).Once()
+
+		return underlyingTransaction
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.AcceptChildTransferInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "transfer token is required",
+			ctx:         userCtx,
+			input:       usecase.AcceptChildTransferInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "invalid transfer token",
+			ctx:         userCtx,
+			input:       usecase.AcceptChildTransferInput{TransferToken: "invalid"},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT("invalid", validateJWTOpts).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "transfer to the same account",
+			ctx:         previousOwnerCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(transferTokenString, validateJWTOpts).Return(transferToken, nil).Once()
+			},
+		},
+		{
+			name:        "transfer was sent to another email",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				expectUntilEmailDecrypted("another@example.com")
+			},
+		},
+		{
+			name:        "transfer already used",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(userCtx, consumeInput, mock.Anything).Return(usecase.ErrRepoNotFound).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name:        "child is no longer owned by the previous owner",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(userCtx, consumeInput, mock.Anything).Return(nil).Once()
+				mockChildRepo.EXPECT().TransferOwnership(userCtx, transferInput, mock.Anything).Return(usecase.ErrRepoNotFound).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name:        "failed to transfer the results",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(userCtx, consumeInput, mock.Anything).Return(nil).Once()
+				mockChildRepo.EXPECT().TransferOwnership(userCtx, transferInput, mock.Anything).Return(nil).Once()
+				mockResultRepo.EXPECT().TransferChildResults(userCtx, transferInput, mock.Anything).Return(assert.AnError).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to revoke the care relationships granted by the previous owner",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(userCtx, consumeInput, mock.Anything).Return(nil).Once()
+				mockChildRepo.EXPECT().TransferOwnership(userCtx, transferInput, mock.Anything).Return(nil).Once()
+				mockResultRepo.EXPECT().TransferChildResults(userCtx, transferInput, mock.Anything).Return(nil).Once()
+				mockCareRelationshipRepo.EXPECT().DeleteByUser(userCtx, childID, previousOwnerID, mock.Anything).Return(assert.AnError).Once()
+				underlyingTransaction.EXPECT().Rollback().Return(nil).Once()
+			},
+		},
+		{
+			name:        "failed to commit",
+			ctx:         userCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(userCtx, consumeInput, mock.Anything).Return(nil).Once()
+				mockChildRepo.EXPECT().TransferOwnership(userCtx, transferInput, mock.Anything).Return(nil).Once()
+				mockResultRepo.EXPECT().TransferChildResults(userCtx, transferInput, mock.Anything).Return(nil).Once()
+				mockCareRelationshipRepo.EXPECT().DeleteByUser(userCtx, childID, previousOwnerID, mock.Anything).Return(nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:  "ok",
+			ctx:   userCtx,
+			input: validInput,
+			expectedFunctionCall: func() {
+				underlyingTransaction := expectTransactionBegin()
+				mockEmailTokenRepo.EXPECT().Consume(userCtx, consumeInput, mock.Anything).Return(nil).Once()
+				mockChildRepo.EXPECT().TransferOwnership(userCtx, transferInput, mock.Anything).Return(nil).Once()
+				mockResultRepo.EXPECT().TransferChildResults(userCtx, transferInput, mock.Anything).Return(nil).Once()
+				mockCareRelationshipRepo.EXPECT().DeleteByUser(userCtx, childID, previousOwnerID, mock.Anything).Return(nil).Once()
+				underlyingTransaction.EXPECT().Commit().Return(nil).Once()
+				mockAuditLogRepo.EXPECT().Create(userCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    requester.ID,
+					ActorRole:  model.RolesParent,
+					Action:     model.AuditActionTransferChild,
+					TargetType: model.AuditTargetChild,
+					TargetID:   childID.String(),
+					Metadata: model.AuditMetadata{
+						"from_user_id": previousOwnerID,
+						"to_user_id":   requester.ID,
+					},
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleAcceptChildTransfer(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				assertUsecaseErrType(t, tc.expectedErr, err)
+				assert.Nil(t, res)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, childID, res.ChildID)
+			assert.Equal(t, previousOwnerID, res.PreviousParentUserID)
+			assert.Equal(t, requester.ID, res.ParentUserID)
+		})
+	}
+	t.Run("the previous owner can no longer read the transferred child", func(t *testing.T) {
+		// the owner membership was moved and the care relationships of the previous owner were revoked by the transfer
+		mockChildRepo.EXPECT().FindByID(previousOwnerCtx, childID).Return(&model.Child{ID: childID, ParentUserID: requester.ID}, nil).Once()
+		mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(previousOwnerCtx, childID, previousOwnerID).
+			Return(nil, usecase.ErrRepoNotFound).Once()
+		mockCareRelationshipRepo.EXPECT().FindByChildIDAndTherapistID(previousOwnerCtx, childID, previousOwnerID).
+			Return(nil, usecase.ErrRepoNotFound).Once()
+
+		res, err := uc.HandleGetStatistic(previousOwnerCtx, usecase.GetStatisticInput{ChildID: childID})
+		assertUsecaseErrType(t, usecase.ErrForbidden, err)
+		assert.Nil(t, res)
+	})
+}
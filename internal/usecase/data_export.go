@@ -0,0 +1,599 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/config"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	dataExportTokenByteLength = 32
+	dataExportBatchSize       = 100
+	dataExportContentType     = "application/zip"
+)
+
+// DataExportOutput the data export detail, excluding the archive
+type DataExportOutput struct {
+	ID          uuid.UUID
+	Status      model.DataExportStatus
+	ExpiresAt   *time.Time
+	CompletedAt *time.Time
+	CreatedAt   time.Time
+}
+
+func newDataExportOutput(export model.DataExport) DataExportOutput {
+	output := DataExportOutput{
+		ID:        export.ID,
+		Status:    export.Status,
+		CreatedAt: export.CreatedAt,
+	}
+
+	if export.ExpiresAt.Valid {
+		output.ExpiresAt = &export.ExpiresAt.Time
+	}
+
+	if export.CompletedAt.Valid {
+		output.CompletedAt = &export.CompletedAt.Time
+	}
+
+	return output
+}
+
+// HandleRequestDataExport start preparing the archive of everything stored about the requester in the background.
+// Once ready, the download link is sent to the requester's email. Only one export can be prepared at a time
+func (u *UsersUsecase) HandleRequestDataExport(ctx context.Context) (*DataExportOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if err := Authorize(requester, ActionExportData, RelationNone); err != nil {
+		return nil, err
+	}
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"user-id": requester.ID,
+		"func":    "UsersUsecase.HandleRequestDataExport",
+	})
+
+	// the export is prepared in memory, thus the one abandoned by a restart must not block the user forever
+	err := u.dataExportRepo.FailStaleByUserID(ctx, requester.ID, time.Now().Add(-config.DataExportProcessingTimeout()))
+	if err != nil {
+		logger.WithError(err).Error("failed to fail the stale data exports")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	export, err := u.dataExportRepo.Create(ctx, requester.ID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to create data export")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoDuplicate:
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "your previous data export is still being prepared",
+		}
+	case nil:
+		break
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionRequestDataExport,
+		TargetType: model.AuditTargetExport,
+		TargetID:   export.ID.String(),
+	})
+
+	go func(ctx context.Context) {
+		u.prepareDataExport(ctx, export.ID, requester.ID)
+	}(context.WithoutCancel(ctx))
+
+	output := newDataExportOutput(*export)
+
+	return &output, nil
+}
+
+// HandleListMyDataExports list all the requester's data exports, newest first
+func (u *UsersUsecase) HandleListMyDataExports(ctx context.Context) ([]DataExportOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if err := Authorize(requester, ActionExportData, RelationNone); err != nil {
+		return nil, err
+	}
+
+	exports, err := u.dataExportRepo.FindByUserID(ctx, requester.ID)
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).WithField("user-id", requester.ID).Error("failed to find user's data exports")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	output := make([]DataExportOutput, 0, len(exports))
+	for _, export := range exports {
+		output = append(output, newDataExportOutput(export))
+	}
+
+	return output, nil
+}
+
+// DownloadDataExportInput input
+type DownloadDataExportInput struct {
+	Token string `validate:"required"`
+}
+
+func (ddei DownloadDataExportInput) validate() error {
+	return common.Validator.Struct(ddei)
+}
+
+// DownloadDataExportOutput output
+type DownloadDataExportOutput struct {
+	FileName    string
+	ContentType string
+	Archive     []byte
+}
+
+// HandleDownloadDataExport return the data export archive using the token sent to the user's email.
+// The token is the only credential needed, so the link can be opened directly from the email
+func (u *UsersUsecase) HandleDownloadDataExport(ctx context.Context, input DownloadDataExportInput) (*DownloadDataExportOutput, error) {
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	export, err := u.dataExportRepo.FindByDownloadTokenHash(ctx, common.HashToken(input.Token))
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).Error("failed to find data export")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	if !export.IsDownloadable() {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "the download link is no longer valid, please request a new data export",
+		}
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionDownloadDataExport,
+		TargetType: model.AuditTargetExport,
+		TargetID:   export.ID.String(),
+		Metadata: model.AuditMetadata{
+			"user_id": export.UserID,
+		},
+	})
+
+	return &DownloadDataExportOutput{
+		FileName:    fmt.Sprintf("atec-data-export-%s.zip", export.CreatedAt.Format("20060102")),
+		ContentType: dataExportContentType,
+		Archive:     export.Archive,
+	}, nil
+}
+
+// prepareDataExport build the archive, store it and send the download link to the user's email.
+// Meant to be run in the background, thus any failure is recorded by marking the data export as failed
+func (u *UsersUsecase) prepareDataExport(ctx context.Context, exportID, userID uuid.UUID) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"user-id":   userID,
+		"export-id": exportID,
+		"func":      "UsersUsecase.prepareDataExport",
+	})
+
+	// nothing would recover the panic in the background, and the export would otherwise stay processing
+	defer func() {
+		if rec := recover(); rec != nil {
+			logger.WithField("panic", rec).Error("panic while preparing data export")
+			u.markDataExportFailed(ctx, logger, exportID)
+		}
+	}()
+
+	if err := u.dataExportRepo.UpdateStatus(ctx, exportID, model.DataExportStatusProcessing); err != nil {
+		logger.WithError(err).Error("failed to mark data export as processing")
+		u.markDataExportFailed(ctx, logger, exportID)
+
+		return
+	}
+
+	archive, err := u.buildDataExportArchive(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to build data export archive")
+		u.markDataExportFailed(ctx, logger, exportID)
+
+		return
+	}
+
+	token, err := common.GenerateSecureToken(dataExportTokenByteLength)
+	if err != nil {
+		logger.WithError(err).Error("failed to generate data export download token")
+		u.markDataExportFailed(ctx, logger, exportID)
+
+		return
+	}
+
+	expiresAt := time.Now().Add(config.DataExportLinkExpiry())
+
+	err = u.dataExportRepo.Complete(ctx, RepoCompleteDataExportInput{
+		ID:                exportID,
+		Archive:           archive.content,
+		DownloadTokenHash: common.HashToken(token),
+		ExpiresAt:         expiresAt,
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to store data export archive")
+		u.markDataExportFailed(ctx, logger, exportID)
+
+		return
+	}
+
+	_, err = u.mailer.SendEmail(ctx, common.SendEmailInput{
+		ReceiverName:  archive.profile.Username,
+		ReceiverEmail: archive.profile.Email,
+		Subject:       "Ekspor Data Anda Telah Siap",
+		HTMLContent:   dataExportReadyEmailTemplate(token, expiresAt),
+	})
+	if err != nil {
+		// the download link is only known from the email, thus the archive is useless without it
+		logger.WithError(err).Error("failed to send data export email")
+		u.markDataExportFailed(ctx, logger, exportID)
+	}
+}
+
+func (u *UsersUsecase) markDataExportFailed(ctx context.Context, logger *logrus.Entry, exportID uuid.UUID) {
+	if err := u.dataExportRepo.UpdateStatus(ctx, exportID, model.DataExportStatusFailed); err != nil {
+		logger.WithError(err).Error("failed to mark data export as failed")
+	}
+}
+
+type dataExportProfile struct {
+	ID          uuid.UUID   `json:"id"`
+	Username    string      `json:"username"`
+	Email       string      `json:"email"`
+	PhoneNumber *string     `json:"phone_number"`
+	Address     *string     `json:"address"`
+	Roles       model.Roles `json:"roles"`
+	IsActive    bool        `json:"is_active"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+type dataExportChild struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Gender       bool      `json:"gender"`
+	DateOfBirth  time.Time `json:"date_of_birth"`
+	GuardianName *string   `json:"guardian_name"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type dataExportResult struct {
+	ID          uuid.UUID          `json:"id"`
+	PackageID   uuid.UUID          `json:"package_id"`
+	PackageName string             `json:"package_name"`
+	ChildID     uuid.UUID          `json:"child_id"`
+	CreatedBy   uuid.UUID          `json:"created_by"`
+	Answer      model.AnswerDetail `json:"answer"`
+	Grade       model.ResultDetail `json:"grade"`
+	TotalScore  int                `json:"total_score"`
+	Indication  string             `json:"indication"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+type dataExportArchive struct {
+	profile dataExportProfile
+	content []byte
+}
+
+// buildDataExportArchive collect everything stored about the user and bundle it into a zip archive containing
+// the profile, children and results as json and csv files, along with the rendered image of each result
+func (u *UsersUsecase) buildDataExportArchive(ctx context.Context, userID uuid.UUID) (*dataExportArchive, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	email, phone, address, err := u.decryptUserData(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt user data: %w", err)
+	}
+
+	profile := dataExportProfile{
+		ID:          user.ID,
+		Username:    user.Username,
+		Email:       email,
+		PhoneNumber: phone,
+		Address:     address,
+		Roles:       user.Roles,
+		IsActive:    user.IsActive,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+	}
+
+	children, err := u.findDataExportChildren(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := u.findDataExportResults(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+
+	exportedResults := make([]dataExportResult, 0, len(results))
+	packages := map[uuid.UUID]*model.Package{}
+
+	for _, result := range results {
+		pack, ok := packages[result.PackageID]
+		if !ok {
+			pack, err = u.packageRepo.FindByID(ctx, result.PackageID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find package %s: %w", result.PackageID, err)
+			}
+
+			packages[result.PackageID] = pack
+		}
+
+		totalScore := result.Result.CountTotalScore()
+
+		exportedResults = append(exportedResults, dataExportResult{
+			ID:          result.ID,
+			PackageID:   result.PackageID,
+			PackageName: pack.Name,
+			ChildID:     result.ChildID,
+			CreatedBy:   result.CreatedBy,
+			Answer:      result.Answer,
+			Grade:       result.Result,
+			TotalScore:  totalScore,
+			Indication:  pack.IndicationCategories.GetIndicationCategoryByScore(totalScore).Name,
+			CreatedAt:   result.CreatedAt,
+		})
+
+		image := newImageGenerator(u.font, imageGenerationOpts{
+			Result:                  result.Result,
+			TestID:                  result.PackageID,
+			indicationCategories:    pack.IndicationCategories,
+			imageResultAttributeKey: pack.ImageResultAttributeKey,
+		}).GenerateJPEG()
+
+		if err := writeZipFile(zw, fmt.Sprintf("images/%s.jpg", result.ID), image.Buffer.Bytes()); err != nil {
+			return nil, err
+		}
+
+		// the images take most of the space, thus stop early instead of building the whole oversized archive
+		if err := checkDataExportArchiveSize(buf); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeZipJSON(zw, "profile.json", profile); err != nil {
+		return nil, err
+	}
+
+	if err := writeZipJSON(zw, "children.json", children); err != nil {
+		return nil, err
+	}
+
+	if err := writeZipCSV(zw, "children.csv", dataExportChildrenCSV(children)); err != nil {
+		return nil, err
+	}
+
+	if err := writeZipJSON(zw, "results.json", exportedResults); err != nil {
+		return nil, err
+	}
+
+	if err := writeZipCSV(zw, "results.csv", dataExportResultsCSV(exportedResults)); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize zip archive: %w", err)
+	}
+
+	if err := checkDataExportArchiveSize(buf); err != nil {
+		return nil, err
+	}
+
+	return &dataExportArchive{
+		profile: profile,
+		content: buf.Bytes(),
+	}, nil
+}
+
+// checkDataExportArchiveSize ensure the archive doesn't grow past the limit, because it is stored on the database
+func checkDataExportArchiveSize(buf *bytes.Buffer) error {
+	if maxSize := config.DataExportMaxArchiveSize(); buf.Len() > maxSize {
+		return fmt.Errorf("data export archive exceeds the maximum size of %d bytes", maxSize)
+	}
+
+	return nil
+}
+
+func (u *UsersUsecase) findDataExportChildren(ctx context.Context, userID uuid.UUID) ([]dataExportChild, error) {
+	children, err := u.childRepo.Search(ctx, RepoSearchChildInput{ParentUserID: &userID})
+	switch err {
+	default:
+		return nil, fmt.Errorf("failed to find children: %w", err)
+	case ErrRepoNotFound, nil:
+		break
+	}
+
+	exported := make([]dataExportChild, 0, len(children))
+	for _, child := range children {
+		ec := dataExportChild{
+			ID:          child.ID,
+			Name:        child.Name,
+			Gender:      child.Gender,
+			DateOfBirth: child.DateOfBirth,
+			CreatedAt:   child.CreatedAt,
+			UpdatedAt:   child.UpdatedAt,
+		}
+
+		if child.GuardianName.Valid {
+			ec.GuardianName = &child.GuardianName.String
+		}
+
+		exported = append(exported, ec)
+	}
+
+	return exported, nil
+}
+
+func (u *UsersUsecase) findDataExportResults(ctx context.Context, userID uuid.UUID) ([]model.Result, error) {
+	allResults := []model.Result{}
+	offset := 0
+
+	for {
+		results, err := u.resultRepo.FindAllUserHistory(ctx, RepoFindAllUserHistoryInput{
+			UserID: userID,
+			Limit:  dataExportBatchSize,
+			Offset: offset,
+		})
+
+		switch err {
+		default:
+			return nil, fmt.Errorf("failed to find results: %w", err)
+		case ErrRepoNotFound, nil:
+			break
+		}
+
+		allResults = append(allResults, results...)
+		offset += dataExportBatchSize
+
+		// avoid extra one query if the result is less than batch size
+		if len(results) < dataExportBatchSize {
+			return allResults, nil
+		}
+	}
+}
+
+func dataExportChildrenCSV(children []dataExportChild) [][]string {
+	records := [][]string{{"id", "name", "gender", "date_of_birth", "guardian_name", "created_at", "updated_at"}}
+
+	for _, child := range children {
+		guardianName := ""
+		if child.GuardianName != nil {
+			guardianName = *child.GuardianName
+		}
+
+		records = append(records, []string{
+			child.ID.String(),
+			child.Name,
+			strconv.FormatBool(child.Gender),
+			child.DateOfBirth.Format(time.DateOnly),
+			guardianName,
+			child.CreatedAt.Format(time.RFC3339),
+			child.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return records
+}
+
+// dataExportResultsCSV flatten the results into csv records. The grade and answer differ between packages,
+// thus are kept as json on their own column
+func dataExportResultsCSV(results []dataExportResult) [][]string {
+	records := [][]string{{
+		"id", "package_id", "package_name", "child_id", "created_by", "total_score", "indication", "grade", "answer", "created_at",
+	}}
+
+	for _, result := range results {
+		grade, _ := json.Marshal(result.Grade)
+		answer, _ := json.Marshal(result.Answer)
+
+		records = append(records, []string{
+			result.ID.String(),
+			result.PackageID.String(),
+			result.PackageName,
+			result.ChildID.String(),
+			result.CreatedBy.String(),
+			strconv.Itoa(result.TotalScore),
+			result.Indication,
+			string(grade),
+			string(answer),
+			result.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return records
+}
+
+func writeZipFile(zw *zip.Writer, name string, content []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create %s in zip archive: %w", name, err)
+	}
+
+	if _, err := w.Write(content); err != nil {
+		return fmt.Errorf("failed to write %s to zip archive: %w", name, err)
+	}
+
+	return nil
+}
+
+func writeZipJSON(zw *zip.Writer, name string, data any) error {
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", name, err)
+	}
+
+	return writeZipFile(zw, name, content)
+}
+
+func writeZipCSV(zw *zip.Writer, name string, records [][]string) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create %s in zip archive: %w", name, err)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write %s to zip archive: %w", name, err)
+	}
+
+	return nil
+}
+
+//nolint:lll
+func dataExportReadyEmailTemplate(token string, expiresAt time.Time) string {
+	return emailLayout(emailLayoutInput{
+		Title: "Ekspor Data Anda Telah Siap",
+		Content: []string{
+			emailParagraph("Ekspor seluruh data akun Autism Treatment Evaluation Checklist (ATEC) Anda, termasuk profil, data anak, dan hasil kuesioner, telah selesai disiapkan."),
+			emailParagraph(fmt.Sprintf("Silakan unduh arsip data Anda dengan mengklik tombol berikut. Tautan ini hanya berlaku hingga <b>%s</b>:", expiresAt.Format(time.RFC1123))),
+			emailButton("Unduh Data", config.ServerDataExportDownloadBaseURL(), model.DataExportTokenQuery, token),
+		},
+		Footer: "Jangan bagikan tautan ini kepada siapa pun. Jika Anda tidak merasa meminta ekspor data, segera ubah kata sandi Anda dan hubungi administrator.",
+	})
+}
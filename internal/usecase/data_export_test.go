@@ -0,0 +1,466 @@
+package usecase_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/config"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	mockCommon "github.com/luckyAkbar/atec/mocks/internal_/common"
+	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersUsecase_HandleRequestDataExport(t *testing.T) {
+	ctx := context.Background()
+
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockResultRepo := mockUsecase.NewResultRepository(t)
+	mockPackageRepo := mockUsecase.NewPackageRepo(t)
+	mockDataExportRepo := mockUsecase.NewDataExportRepository(t)
+	mockMailer := mockCommon.NewMailerIface(t)
+
+	fontBytes, err := os.ReadFile("../../assets/font.ttf")
+	require.NoError(t, err)
+
+	font, err := truetype.Parse(fontBytes)
+	require.NoError(t, err)
+
+	uc := usecase.NewUsersUsecase(
+		mockUserRepo, mockCryptor, nil, mockAuditLogRepo, mockChildRepo, mockResultRepo, mockPackageRepo,
+		mockDataExportRepo, nil, nil, mockMailer, font,
+	)
+
+	parent := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	parentCtx := model.SetUserToCtx(ctx, parent)
+	therapistCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesTherapist})
+
+	export := &model.DataExport{ID: uuid.New(), UserID: parent.ID, Status: model.DataExportStatusPending}
+	user := &model.User{ID: parent.ID, Username: "parent", Email: "encrypted-email", Roles: model.RolesParent}
+	child := model.Child{ID: uuid.New(), ParentUserID: parent.ID, Name: "child", DateOfBirth: time.Now().AddDate(-5, 0, 0)}
+	pack := &model.Package{
+		ID:                      uuid.New(),
+		Name:                    "ATEC",
+		IndicationCategories:    validIndicationCategories,
+		ImageResultAttributeKey: validImageResultAttributeKey,
+	}
+	result := model.Result{
+		ID:        uuid.New(),
+		PackageID: pack.ID,
+		ChildID:   child.ID,
+		CreatedBy: parent.ID,
+		Answer:    model.AnswerDetail{1: {1: 2}},
+		Result:    model.ResultDetail{1: {Name: "Speech", Grade: 2}},
+	}
+
+	expectStaleExportsFailed := func() {
+		mockDataExportRepo.EXPECT().FailStaleByUserID(parentCtx, parent.ID, mock.MatchedBy(func(updatedBefore time.Time) bool {
+			return updatedBefore.Before(time.Now().Add(-config.DataExportProcessingTimeout() + time.Minute))
+		})).Return(nil).Once()
+	}
+
+	expectRequestRecorded := func() {
+		mockAuditLogRepo.EXPECT().Create(parentCtx, mock.MatchedBy(func(input usecase.RepoCreateAuditLogInput) bool {
+			return input.Action == model.AuditActionRequestDataExport && input.TargetID == export.ID.String()
+		})).Return(nil).Once()
+	}
+
+	expectArchiveBuilt := func() {
+		mockDataExportRepo.EXPECT().UpdateStatus(mock.Anything, export.ID, model.DataExportStatusProcessing).Return(nil).Once()
+		mockUserRepo.EXPECT().FindByID(mock.Anything, parent.ID).Return(user, nil).Once()
+		mockCryptor.EXPECT().Decrypt(user.Email).Return("parent@example.com", nil).Once()
+		mockChildRepo.EXPECT().Search(mock.Anything, usecase.RepoSearchChildInput{ParentUserID: &parent.ID}).
+			Return([]model.Child{child}, nil).Once()
+		mockResultRepo.EXPECT().FindAllUserHistory(mock.Anything, usecase.RepoFindAllUserHistoryInput{
+			UserID: parent.ID,
+			Limit:  100,
+		}).Return([]model.Result{result}, nil).Once()
+		mockPackageRepo.EXPECT().FindByID(mock.Anything, pack.ID).Return(pack, nil).Once()
+	}
+
+	var storedExport usecase.RepoCompleteDataExportInput
+
+	// the export is prepared in the background, thus the test must wait until the last expected call is made
+	var done chan struct{}
+
+	markDone := func(_ mock.Arguments) { close(done) }
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		maxArchiveSize       int
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+		assertBackground     func(t *testing.T)
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "only parent can export the data",
+			ctx:         therapistCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:        "failed to fail the stale data exports",
+			ctx:         parentCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockDataExportRepo.EXPECT().FailStaleByUserID(parentCtx, parent.ID, mock.Anything).Return(usecase.ErrRepoInternal).Once()
+			},
+		},
+		{
+			name:        "previous export is still being prepared",
+			ctx:         parentCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				expectStaleExportsFailed()
+				mockDataExportRepo.EXPECT().Create(parentCtx, parent.ID).Return(nil, usecase.ErrRepoDuplicate).Once()
+			},
+		},
+		{
+			name:        "failed to create data export",
+			ctx:         parentCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectStaleExportsFailed()
+				mockDataExportRepo.EXPECT().Create(parentCtx, parent.ID).Return(nil, usecase.ErrRepoInternal).Once()
+			},
+		},
+		{
+			name: "ok - archive sent to the email",
+			ctx:  parentCtx,
+			expectedFunctionCall: func() {
+				expectStaleExportsFailed()
+				mockDataExportRepo.EXPECT().Create(parentCtx, parent.ID).Return(export, nil).Once()
+				expectRequestRecorded()
+				expectArchiveBuilt()
+				mockDataExportRepo.EXPECT().Complete(mock.Anything, mock.MatchedBy(func(input usecase.RepoCompleteDataExportInput) bool {
+					return input.ID == export.ID && input.DownloadTokenHash != "" && input.ExpiresAt.After(time.Now())
+				})).Run(func(_ context.Context, input usecase.RepoCompleteDataExportInput) {
+					storedExport = input
+				}).Return(nil).Once()
+				mockMailer.EXPECT().SendEmail(mock.Anything, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == "parent@example.com" && input.ReceiverName == user.Username
+				})).Return(nil, nil).Once().Run(markDone)
+			},
+			assertBackground: func(t *testing.T) {
+				zr, err := zip.NewReader(bytes.NewReader(storedExport.Archive), int64(len(storedExport.Archive)))
+				require.NoError(t, err)
+
+				files := map[string][]byte{}
+
+				for _, f := range zr.File {
+					rc, err := f.Open()
+					require.NoError(t, err)
+
+					content, err := io.ReadAll(rc)
+					require.NoError(t, err)
+					require.NoError(t, rc.Close())
+
+					files[f.Name] = content
+				}
+
+				assert.Contains(t, files, "children.json")
+				assert.Contains(t, files, "children.csv")
+				assert.Contains(t, files, "results.csv")
+				assert.NotEmpty(t, files["images/"+result.ID.String()+".jpg"])
+
+				profile := map[string]any{}
+				require.NoError(t, json.Unmarshal(files["profile.json"], &profile))
+				assert.Equal(t, "parent@example.com", profile["email"])
+
+				results := []map[string]any{}
+				require.NoError(t, json.Unmarshal(files["results.json"], &results))
+				require.Len(t, results, 1)
+				assert.Equal(t, pack.Name, results[0]["package_name"])
+				assert.InDelta(t, 2, results[0]["total_score"], 0)
+			},
+		},
+		{
+			name: "failed to build the archive marks the export as failed",
+			ctx:  parentCtx,
+			expectedFunctionCall: func() {
+				expectStaleExportsFailed()
+				mockDataExportRepo.EXPECT().Create(parentCtx, parent.ID).Return(export, nil).Once()
+				expectRequestRecorded()
+				mockDataExportRepo.EXPECT().UpdateStatus(mock.Anything, export.ID, model.DataExportStatusProcessing).Return(nil).Once()
+				mockUserRepo.EXPECT().FindByID(mock.Anything, parent.ID).Return(nil, usecase.ErrRepoInternal).Once()
+				mockDataExportRepo.EXPECT().UpdateStatus(mock.Anything, export.ID, model.DataExportStatusFailed).
+					Return(nil).Once().Run(markDone)
+			},
+		},
+		{
+			name: "panic while preparing the archive marks the export as failed",
+			ctx:  parentCtx,
+			expectedFunctionCall: func() {
+				expectStaleExportsFailed()
+				mockDataExportRepo.EXPECT().Create(parentCtx, parent.ID).Return(export, nil).Once()
+				expectRequestRecorded()
+				mockDataExportRepo.EXPECT().UpdateStatus(mock.Anything, export.ID, model.DataExportStatusProcessing).Return(nil).Once()
+				mockUserRepo.EXPECT().FindByID(mock.Anything, parent.ID).Run(func(_ context.Context, _ uuid.UUID) {
+					panic("unexpected")
+				}).Return(nil, nil).Once()
+				mockDataExportRepo.EXPECT().UpdateStatus(mock.Anything, export.ID, model.DataExportStatusFailed).
+					Return(nil).Once().Run(markDone)
+			},
+		},
+		{
+			name:           "archive exceeding the maximum size marks the export as failed",
+			ctx:            parentCtx,
+			maxArchiveSize: 1,
+			expectedFunctionCall: func() {
+				expectStaleExportsFailed()
+				mockDataExportRepo.EXPECT().Create(parentCtx, parent.ID).Return(export, nil).Once()
+				expectRequestRecorded()
+				expectArchiveBuilt()
+				mockDataExportRepo.EXPECT().UpdateStatus(mock.Anything, export.ID, model.DataExportStatusFailed).
+					Return(nil).Once().Run(markDone)
+			},
+		},
+		{
+			name: "failed to send the email marks the export as failed",
+			ctx:  parentCtx,
+			expectedFunctionCall: func() {
+				expectStaleExportsFailed()
+				mockDataExportRepo.EXPECT().Create(parentCtx, parent.ID).Return(export, nil).Once()
+				expectRequestRecorded()
+				expectArchiveBuilt()
+				mockDataExportRepo.EXPECT().Complete(mock.Anything, mock.Anything).Return(nil).Once()
+				mockMailer.EXPECT().SendEmail(mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+				mockDataExportRepo.EXPECT().UpdateStatus(mock.Anything, export.ID, model.DataExportStatusFailed).
+					Return(nil).Once().Run(markDone)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			done = make(chan struct{})
+
+			if tc.maxArchiveSize > 0 {
+				viper.Set("data_export.max_archive_size", tc.maxArchiveSize)
+				t.Cleanup(func() { viper.Set("data_export.max_archive_size", nil) })
+			}
+
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			output, err := uc.HandleRequestDataExport(tc.ctx)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assertUsecaseErrType(t, tc.expectedErr, err)
+				assert.Nil(t, output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, export.ID, output.ID)
+			assert.Equal(t, model.DataExportStatusPending, output.Status)
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("data export is not prepared in time")
+			}
+
+			if tc.assertBackground != nil {
+				tc.assertBackground(t)
+			}
+		})
+	}
+}
+
+func TestUsersUsecase_HandleListMyDataExports(t *testing.T) {
+	ctx := context.Background()
+
+	mockDataExportRepo := mockUsecase.NewDataExportRepository(t)
+	uc := usecase.NewUsersUsecase(nil, nil, nil, nil, nil, nil, nil, mockDataExportRepo, nil, nil, nil, nil)
+
+	parent := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+	parentCtx := model.SetUserToCtx(ctx, parent)
+	adminCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator})
+
+	now := time.Now()
+
+	t.Run("only parent can export the data", func(t *testing.T) {
+		output, err := uc.HandleListMyDataExports(adminCtx)
+		require.Error(t, err)
+		assertUsecaseErrType(t, usecase.ErrForbidden, err)
+		assert.Nil(t, output)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockDataExportRepo.EXPECT().FindByUserID(parentCtx, parent.ID).Return(nil, usecase.ErrRepoInternal).Once()
+
+		output, err := uc.HandleListMyDataExports(parentCtx)
+		require.Error(t, err)
+		assertUsecaseErrType(t, usecase.ErrInternal, err)
+		assert.Nil(t, output)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		completed := model.DataExport{
+			ID:          uuid.New(),
+			Status:      model.DataExportStatusCompleted,
+			ExpiresAt:   sql.NullTime{Time: now.Add(time.Hour), Valid: true},
+			CompletedAt: sql.NullTime{Time: now, Valid: true},
+		}
+		pending := model.DataExport{ID: uuid.New(), Status: model.DataExportStatusPending}
+
+		mockDataExportRepo.EXPECT().FindByUserID(parentCtx, parent.ID).
+			Return([]model.DataExport{pending, completed}, nil).Once()
+
+		output, err := uc.HandleListMyDataExports(parentCtx)
+		require.NoError(t, err)
+		require.Len(t, output, 2)
+
+		assert.Equal(t, pending.ID, output[0].ID)
+		assert.Nil(t, output[0].ExpiresAt)
+		assert.Nil(t, output[0].CompletedAt)
+
+		assert.Equal(t, completed.ID, output[1].ID)
+		require.NotNil(t, output[1].ExpiresAt)
+		assert.Equal(t, completed.ExpiresAt.Time, *output[1].ExpiresAt)
+		require.NotNil(t, output[1].CompletedAt)
+	})
+}
+
+func TestUsersUsecase_HandleDownloadDataExport(t *testing.T) {
+	ctx := context.Background()
+
+	mockDataExportRepo := mockUsecase.NewDataExportRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	uc := usecase.NewUsersUsecase(nil, nil, nil, mockAuditLogRepo, nil, nil, nil, mockDataExportRepo, nil, nil, nil, nil)
+
+	token := "download-token"
+	tokenHash := common.HashToken(token)
+
+	downloadable := &model.DataExport{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		Status:    model.DataExportStatusCompleted,
+		Archive:   []byte("zip"),
+		ExpiresAt: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+		CreatedAt: time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC),
+	}
+	expired := &model.DataExport{
+		ID:        uuid.New(),
+		Status:    model.DataExportStatusCompleted,
+		ExpiresAt: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+	}
+	failed := &model.DataExport{ID: uuid.New(), Status: model.DataExportStatusFailed}
+
+	testCases := []struct {
+		name                 string
+		input                usecase.DownloadDataExportInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "missing token",
+			input:       usecase.DownloadDataExportInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "unknown token",
+			input:       usecase.DownloadDataExportInput{Token: token},
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockDataExportRepo.EXPECT().FindByDownloadTokenHash(ctx, tokenHash).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "repository error",
+			input:       usecase.DownloadDataExportInput{Token: token},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockDataExportRepo.EXPECT().FindByDownloadTokenHash(ctx, tokenHash).Return(nil, usecase.ErrRepoInternal).Once()
+			},
+		},
+		{
+			name:        "link expired",
+			input:       usecase.DownloadDataExportInput{Token: token},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockDataExportRepo.EXPECT().FindByDownloadTokenHash(ctx, tokenHash).Return(expired, nil).Once()
+			},
+		},
+		{
+			name:        "export failed",
+			input:       usecase.DownloadDataExportInput{Token: token},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockDataExportRepo.EXPECT().FindByDownloadTokenHash(ctx, tokenHash).Return(failed, nil).Once()
+			},
+		},
+		{
+			name:  "ok",
+			input: usecase.DownloadDataExportInput{Token: token},
+			expectedFunctionCall: func() {
+				mockDataExportRepo.EXPECT().FindByDownloadTokenHash(ctx, tokenHash).Return(downloadable, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(ctx, mock.MatchedBy(func(input usecase.RepoCreateAuditLogInput) bool {
+					return input.Action == model.AuditActionDownloadDataExport && input.TargetID == downloadable.ID.String() &&
+						input.Metadata["user_id"] == downloadable.UserID
+				})).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			output, err := uc.HandleDownloadDataExport(ctx, tc.input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assertUsecaseErrType(t, tc.expectedErr, err)
+				assert.Nil(t, output)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "atec-data-export-20240517.zip", output.FileName)
+			assert.Equal(t, "application/zip", output.ContentType)
+			assert.Equal(t, downloadable.Archive, output.Archive)
+		})
+	}
+}
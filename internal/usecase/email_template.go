@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+)
+
+// emailLayoutInput is the content of an email rendered using emailLayout. Every content block is placed
+// in order inside the email body and is not escaped, so escape any user supplied value before putting it there
+type emailLayoutInput struct {
+	Title   string
+	Content []string
+	Footer  string
+}
+
+// emailLayout render the email content using the layout shared by every email sent by the system
+func emailLayout(input emailLayoutInput) string {
+	return fmt.Sprintf(`
+		<!DOCTYPE html>
+		<html>
+		<head>
+			<style>
+				/* General styles */
+				body {
+					font-family: Arial, sans-serif;
+					margin: 0;
+					padding: 0;
+					background-color: #f6f6f6;
+					color: #333;
+				}
+				.email-container {
+					max-width: 600px;
+					margin: 20px auto;
+					background-color: #ffffff;
+					border: 1px solid #ddd;
+					border-radius: 8px;
+					overflow: hidden;
+				}
+				.header {
+					background-color: #4CAF50;
+					color: white;
+					padding: 20px;
+					text-align: center;
+				}
+				.content {
+					padding: 20px;
+				}
+				.content p {
+					margin: 0 0 15px;
+					line-height: 1.6;
+				}
+				.btn-container {
+					text-align: center;
+					margin: 20px 0;
+				}
+				.btn {
+					display: inline-block;
+					background-color: #4CAF50;
+					color: white;
+					text-decoration: none;
+					padding: 10px 20px;
+					font-size: 16px;
+					border-radius: 5px;
+				}
+				.code {
+					text-align: center;
+					font-size: 28px;
+					font-weight: bold;
+					letter-spacing: 6px;
+					margin: 20px 0;
+				}
+				.btn:hover {
+					background-color: #45a049;
+				}
+				.footer {
+					background-color: #f1f1f1;
+					text-align: center;
+					padding: 10px;
+					font-size: 12px;
+					color: #666;
+				}
+			</style>
+		</head>
+		<body>
+			<div class="email-container">
+				<div class="header">
+					<h1>%s</h1>
+				</div>
+				<div class="content">
+					%s
+				</div>
+				<div class="footer">
+					<p>%s</p>
+				</div>
+			</div>
+		</body>
+		</html>
+		`, input.Title, strings.Join(input.Content, "\n"), input.Footer)
+}
+
+func emailParagraph(text string) string {
+	return fmt.Sprintf("<p>%s</p>", text)
+}
+
+// emailButton create the button linking to the baseURL with the token set as the tokenQuery query param
+func emailButton(text, baseURL, tokenQuery, token string) string {
+	return fmt.Sprintf(`<div class="btn-container"><a href="%s?%s=%s" class="btn">%s</a></div>`, baseURL, tokenQuery, token, text)
+}
+
+func emailCode(code string) string {
+	return fmt.Sprintf(`<div class="code">%s</div>`, code)
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/sirupsen/logrus"
+)
+
+// signEmailToken record a new single use token to the email token ledger and return the signed jwt with
+// the given audiences. The ledger id is used as the jwt id (jti), so the token can later be consumed or revoked.
+// Use uuid.Nil as userID if the token is not bound to any existing user
+func signEmailToken(
+	ctx context.Context,
+	sharedCryptor common.SharedCryptorIface,
+	emailTokenRepo EmailTokenRepository,
+	userID uuid.UUID,
+	audiences []string,
+	subject JWTTokenType,
+	expiry time.Duration,
+	txController ...any,
+) (string, error) {
+	now := time.Now()
+
+	ledger, err := emailTokenRepo.Create(ctx, RepoCreateEmailTokenInput{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Purpose:   string(subject),
+		ExpiresAt: now.Add(expiry),
+	}, txController...)
+	if err != nil {
+		return "", err
+	}
+
+	return sharedCryptor.CreateJWT(jwt.RegisteredClaims{
+		ID:        ledger.ID.String(),
+		Issuer:    string(TokenIssuerSystem),
+		Subject:   string(subject),
+		Audience:  audiences,
+		ExpiresAt: jwt.NewNumericDate(ledger.ExpiresAt),
+		IssuedAt:  jwt.NewNumericDate(now),
+	})
+}
+
+// consumeEmailToken redeem the email token identified by the jti claim. Will return unauthorized error
+// if the token has been used, revoked or expired
+func consumeEmailToken(
+	ctx context.Context,
+	emailTokenRepo EmailTokenRepository,
+	claims jwt.MapClaims,
+	userID uuid.UUID,
+	subject JWTTokenType,
+	txController ...any,
+) error {
+	jti, ok := claims["jti"].(string)
+	if !ok {
+		return UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "missing token id",
+		}
+	}
+
+	tokenID, err := uuid.Parse(jti)
+	if err != nil {
+		return UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "invalid token id",
+		}
+	}
+
+	err = emailTokenRepo.Consume(ctx, RepoConsumeEmailTokenInput{
+		ID:      tokenID,
+		UserID:  userID,
+		Purpose: string(subject),
+	}, txController...)
+
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).WithField("token-id", tokenID).Error("failed to consume email token")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "this token has already been used or is no longer valid",
+		}
+	case nil:
+		return nil
+	}
+}
+
+type parseJWTTokenInput struct {
+	expectedIssuer      JWTTokenIssuer
+	expectedSubject     JWTTokenType
+	expectedAudiences   *[]string
+	expectedAudienceLen int
+}
+
+func parseJWTToken(sharedCryptor common.SharedCryptorIface, token string, input parseJWTTokenInput) (*jwt.Token, jwt.MapClaims, error) {
+	jwtToken, err := sharedCryptor.ValidateJWT(token, common.ValidateJWTOpts{
+		Issuer:  string(input.expectedIssuer),
+		Subject: string(input.expectedSubject),
+	})
+
+	// the jwt module wraps the sentinel errors, thus errors.Is must be used to check them
+	switch {
+	default:
+		return nil, nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: err.Error(),
+		}
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return nil, nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "token has expired",
+		}
+	case err == nil:
+		break
+	}
+
+	claims, ok := jwtToken.Claims.(jwt.MapClaims)
+	if !(ok && jwtToken.Valid) {
+		return nil, nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "invalid token claims",
+		}
+	}
+
+	issuer, err := claims.GetIssuer()
+	if err != nil || issuer != string(input.expectedIssuer) {
+		return nil, nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "incorrect token issuer used",
+		}
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject != string(input.expectedSubject) {
+		return nil, nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "incorrect token subject used",
+		}
+	}
+
+	audiences, err := claims.GetAudience()
+	if err != nil {
+		return nil, nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "invalid audience on token used",
+		}
+	}
+
+	if len(audiences) != input.expectedAudienceLen {
+		return nil, nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "invalid number of audience",
+		}
+	}
+
+	// early return if no expected audience is supplied
+	// some times, this checking is not required to performed here
+	// but may still be checked by the caller
+	if input.expectedAudiences == nil {
+		return jwtToken, claims, nil
+	}
+
+	expectedAudiences := *input.expectedAudiences
+
+	if !reflect.DeepEqual(audiences, expectedAudiences) {
+		return nil, nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "unexpected audiences value",
+		}
+	}
+
+	return jwtToken, claims, nil
+}
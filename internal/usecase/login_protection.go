@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-redis/redis_rate/v10"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/config"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// maxProgressiveLoginDelay is the upper bound of the delay enforced between failed login attempts
+const maxProgressiveLoginDelay = time.Minute
+
+// dummyPasswordHash is a bcrypt hash of a random value, generated using the same cost as the stored passwords.
+// It is used to spend the same amount of time comparing password when the account can't be logged in to
+//
+//nolint:gosec // not a credential
+const dummyPasswordHash = "$2a$10$f8zC/2Ce14SPwquGvzD/VOTT2rzmCQOvC3AFHTKUP6b5OdA2o6dGa"
+
+// errInvalidLoginCredentials is returned whenever the login fails due to unknown email, wrong password
+// or locked account, so those cases are indistinguishable to the requester
+var errInvalidLoginCredentials = UsecaseError{
+	ErrType: ErrUnauthorized,
+	Message: "invalid email or password",
+}
+
+// compareDummyPassword compare the password against dummyPasswordHash, the result is always discarded
+func (u *AuthUsecase) compareDummyPassword(password string) {
+	_ = u.sharedCryptor.CompareHash([]byte(dummyPasswordHash), []byte(password))
+}
+
+// progressiveLoginDelay return how long the user must wait after the last failed login before trying again.
+// The first failure is free, then the delay doubles for each consecutive failure: 2s, 4s, 8s, ...
+func progressiveLoginDelay(failedAttempts int) time.Duration {
+	if failedAttempts < 2 {
+		return 0
+	}
+
+	delay := time.Duration(math.Pow(2, float64(failedAttempts-1))) * time.Second
+	if delay > maxProgressiveLoginDelay {
+		return maxProgressiveLoginDelay
+	}
+
+	return delay
+}
+
+// limitLoginAttempt count the login attempt identified by key and reject it when the limit is reached
+func (u *AuthUsecase) limitLoginAttempt(ctx context.Context, key string, limit int) error {
+	rateLimit, err := u.rateLimiter.Allow(ctx, key, redis_rate.Limit{
+		Rate:   limit,
+		Burst:  limit,
+		Period: config.LoginAttemptLimiterWindow(),
+	})
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).Error("failed to perform rate limiter ops")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if rateLimit.Allowed == 0 {
+		return UsecaseError{
+			ErrType: ErrTooManyRequests,
+			Message: fmt.Sprintf("too many login attempts, please retry again after %d", int64(rateLimit.RetryAfter.Seconds())),
+		}
+	}
+
+	return nil
+}
+
+// checkLoginLockout reject the login when the account is locked or the progressive delay since
+// the last failed login has not yet passed. An expired lock will be cleared here.
+// The rejection is reported as invalid credentials, only the account owner is notified about the lock via email
+func (u *AuthUsecase) checkLoginLockout(ctx context.Context, user *model.User) error {
+	if user.IsLocked() {
+		return errInvalidLoginCredentials
+	}
+
+	if user.LockedUntil.Valid {
+		if err := u.resetLoginFailures(ctx, user.ID); err != nil {
+			logrus.WithContext(ctx).WithError(err).WithField("user-id", user.ID).Error("failed to clear expired account lock")
+
+			return UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		}
+
+		user.FailedLoginAttempts = 0
+		user.LockedUntil = sql.NullTime{}
+
+		return nil
+	}
+
+	if !user.LastFailedLoginAt.Valid {
+		return nil
+	}
+
+	retryAt := user.LastFailedLoginAt.Time.Add(progressiveLoginDelay(user.FailedLoginAttempts))
+	if time.Now().Before(retryAt) {
+		return errInvalidLoginCredentials
+	}
+
+	return nil
+}
+
+// handleFailedLogin record the failed login and lock the account once the lockout threshold is reached.
+// When locked, an email containing the unlock link will be sent to the account owner
+func (u *AuthUsecase) handleFailedLogin(ctx context.Context, user *model.User) error {
+	logger := logrus.WithContext(ctx).WithField("user-id", user.ID)
+
+	updated, err := u.userRepo.IncrementFailedLoginAttempts(ctx, user.ID)
+	if err != nil {
+		logger.WithError(err).Error("failed to record failed login attempt")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if updated.FailedLoginAttempts < config.LoginLockoutThreshold() {
+		return errInvalidLoginCredentials
+	}
+
+	_, err = u.userRepo.Update(ctx, user.ID, RepoUpdateUserInput{
+		LockedUntil: &sql.NullTime{Time: time.Now().Add(config.LoginLockoutDuration()), Valid: true},
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to lock the account")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if err := u.sendAccountUnlockEmail(ctx, user); err != nil {
+		logger.WithError(err).Error("failed to send account unlock email")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return errInvalidLoginCredentials
+}
+
+func (u *AuthUsecase) sendAccountUnlockEmail(ctx context.Context, user *model.User) error {
+	email, err := u.sharedCryptor.Decrypt(user.Email)
+	if err != nil {
+		return err
+	}
+
+	token, err := u.issueEmailToken(ctx, user.ID, AccountUnlockToken, config.AccountUnlockTokenExpiry())
+	if err != nil {
+		return err
+	}
+
+	_, err = u.mailer.SendEmail(ctx, common.SendEmailInput{
+		ReceiverName:  user.Username,
+		ReceiverEmail: email,
+		Subject:       "Akun Anda Dikunci Sementara",
+		HTMLContent:   accountLockedEmailTemplate(token),
+	})
+
+	return err
+}
+
+func (u *AuthUsecase) resetLoginFailures(ctx context.Context, userID uuid.UUID) error {
+	noFailure := 0
+	_, err := u.userRepo.Update(ctx, userID, RepoUpdateUserInput{
+		FailedLoginAttempts: &noFailure,
+		LockedUntil:         &sql.NullTime{Valid: false},
+	})
+
+	return err
+}
+
+// UnlockAccountInput input
+type UnlockAccountInput struct {
+	UnlockToken string `validate:"required"`
+}
+
+func (uai UnlockAccountInput) validate() error {
+	return common.Validator.Struct(uai)
+}
+
+// UnlockAccountOutput output
+type UnlockAccountOutput struct {
+	Message string
+}
+
+// HandleUnlockAccount unlock the account locked due to too many failed login attempts using the token sent to the user's email
+func (u *AuthUsecase) HandleUnlockAccount(ctx context.Context, input UnlockAccountInput) (*UnlockAccountOutput, error) {
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	_, claims, err := parseJWTToken(u.sharedCryptor, input.UnlockToken, parseJWTTokenInput{
+		expectedIssuer:      TokenIssuerSystem,
+		expectedSubject:     AccountUnlockToken,
+		expectedAudienceLen: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	audiences, _ := claims.GetAudience()
+
+	userID, err := uuid.Parse(audiences[0])
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "invalid value of user id",
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithField("user-id", userID)
+
+	user, err := u.userRepo.FindByID(ctx, userID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by id")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	if err := consumeEmailToken(ctx, u.emailTokenRepo, claims, user.ID, AccountUnlockToken); err != nil {
+		return nil, err
+	}
+
+	if err := u.resetLoginFailures(ctx, user.ID); err != nil {
+		logger.WithError(err).Error("failed to unlock the account")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return &UnlockAccountOutput{
+		Message: "your account has been unlocked",
+	}, nil
+}
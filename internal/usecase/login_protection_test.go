@@ -0,0 +1,416 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis_rate/v10"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	mockCommon "github.com/luckyAkbar/atec/mocks/internal_/common"
+	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthUsecase_HandleLogin_BruteForceProtection(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+	mockRateLimiter := mockUsecase.NewRateLimiter(t)
+	mockMailer := mockCommon.NewMailerIface(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, mockMailer, mockRateLimiter, mockSessionRepo, mockEmailTokenRepo, nil, nil, nil, nil, nil)
+
+	email := "parent@sample.email"
+	password := "validPass!!"
+	encryptedEmail := "encryptedEmail"
+	emailBlindIndex := "email-blind-index"
+	emailLookup := usecase.RepoEmailLookup{BlindIndex: emailBlindIndex, LegacyEncryptedEmails: []string{encryptedEmail}}
+	ipAddress := "10.0.0.1"
+	ipKey := "login-ip:" + ipAddress
+	accountKey := "login-account:" + emailBlindIndex
+	allowed := &redis_rate.Result{Allowed: 1}
+	denied := &redis_rate.Result{Allowed: 0, RetryAfter: time.Minute}
+
+	user := model.User{
+		ID:       uuid.New(),
+		Email:    encryptedEmail,
+		Username: "parent",
+		IsActive: true,
+		Roles:    model.RolesParent,
+	}
+
+	lockedUser := user
+	lockedUser.FailedLoginAttempts = 5
+	lockedUser.LockedUntil = sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true}
+
+	expiredLockUser := user
+	expiredLockUser.FailedLoginAttempts = 5
+	expiredLockUser.LastFailedLoginAt = sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true}
+	expiredLockUser.LockedUntil = sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+
+	delayedUser := user
+	delayedUser.FailedLoginAttempts = 3
+	delayedUser.LastFailedLoginAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	failedBeforeUser := user
+	failedBeforeUser.FailedLoginAttempts = 2
+	failedBeforeUser.LastFailedLoginAt = sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true}
+
+	noFailure := 0
+	resetInput := usecase.RepoUpdateUserInput{
+		FailedLoginAttempts: &noFailure,
+		LockedUntil:         &sql.NullTime{Valid: false},
+	}
+
+	isLockInput := mock.MatchedBy(func(input usecase.RepoUpdateUserInput) bool {
+		return input.LockedUntil != nil && input.LockedUntil.Valid && input.LockedUntil.Time.After(time.Now())
+	})
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "failed to check the ip rate limiter",
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockRateLimiter.EXPECT().Allow(ctx, ipKey, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "too many login attempts from the same ip address",
+			wantErr:     true,
+			expectedErr: usecase.ErrTooManyRequests,
+			expectedFunctionCall: func() {
+				mockRateLimiter.EXPECT().Allow(ctx, ipKey, mock.Anything).Return(denied, nil).Once()
+			},
+		},
+		{
+			name:        "too many login attempts to the same account",
+			wantErr:     true,
+			expectedErr: usecase.ErrTooManyRequests,
+			expectedFunctionCall: func() {
+				mockRateLimiter.EXPECT().Allow(ctx, ipKey, mock.Anything).Return(allowed, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, accountKey, mock.Anything).Return(denied, nil).Once()
+			},
+		},
+		{
+			name:        "locked account can not login even with the right password",
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockRateLimiter.EXPECT().Allow(ctx, ipKey, mock.Anything).Return(allowed, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, accountKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&lockedUser, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(password)).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:        "progressive delay has not yet passed",
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockRateLimiter.EXPECT().Allow(ctx, ipKey, mock.Anything).Return(allowed, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, accountKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&delayedUser, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(password)).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to clear the expired lock",
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockRateLimiter.EXPECT().Allow(ctx, ipKey, mock.Anything).Return(allowed, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, accountKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&expiredLockUser, nil).Once()
+				mockUserRepo.EXPECT().Update(ctx, user.ID, resetInput).Return(nil, assert.AnError).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(password)).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to record the failed login",
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockRateLimiter.EXPECT().Allow(ctx, ipKey, mock.Anything).Return(allowed, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, accountKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(password)).Return(assert.AnError).Once()
+				mockUserRepo.EXPECT().IncrementFailedLoginAttempts(ctx, user.ID).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "expired lock is cleared and the wrong password is recorded again",
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockRateLimiter.EXPECT().Allow(ctx, ipKey, mock.Anything).Return(allowed, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, accountKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&expiredLockUser, nil).Once()
+				mockUserRepo.EXPECT().Update(ctx, user.ID, resetInput).Return(&user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(password)).Return(assert.AnError).Once()
+				mockUserRepo.EXPECT().IncrementFailedLoginAttempts(ctx, user.ID).Return(&model.User{ID: user.ID, FailedLoginAttempts: 1}, nil).Once()
+			},
+		},
+		{
+			name:        "failed to lock the account after reaching the threshold",
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockRateLimiter.EXPECT().Allow(ctx, ipKey, mock.Anything).Return(allowed, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, accountKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(password)).Return(assert.AnError).Once()
+				mockUserRepo.EXPECT().IncrementFailedLoginAttempts(ctx, user.ID).Return(&model.User{ID: user.ID, FailedLoginAttempts: 5}, nil).Once()
+				mockUserRepo.EXPECT().Update(ctx, user.ID, isLockInput).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to send the unlock email",
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockRateLimiter.EXPECT().Allow(ctx, ipKey, mock.Anything).Return(allowed, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, accountKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(password)).Return(assert.AnError).Once()
+				mockUserRepo.EXPECT().IncrementFailedLoginAttempts(ctx, user.ID).Return(&model.User{ID: user.ID, FailedLoginAttempts: 5}, nil).Once()
+				mockUserRepo.EXPECT().Update(ctx, user.ID, isLockInput).Return(&user, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedEmail).Return(email, nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.Anything).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("unlockToken", nil).Once()
+				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "account is locked after reaching the threshold",
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockRateLimiter.EXPECT().Allow(ctx, ipKey, mock.Anything).Return(allowed, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, accountKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&user, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(password)).Return(assert.AnError).Once()
+				mockUserRepo.EXPECT().IncrementFailedLoginAttempts(ctx, user.ID).Return(&model.User{ID: user.ID, FailedLoginAttempts: 5}, nil).Once()
+				mockUserRepo.EXPECT().Update(ctx, user.ID, isLockInput).Return(&user, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(encryptedEmail).Return(email, nil).Once()
+				mockEmailTokenRepo.EXPECT().Create(ctx, mock.MatchedBy(func(input usecase.RepoCreateEmailTokenInput) bool {
+					return input.UserID == user.ID && input.Purpose == string(usecase.AccountUnlockToken)
+				})).Return(&model.EmailToken{ID: uuid.New()}, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("unlockToken", nil).Once()
+				mockMailer.EXPECT().SendEmail(ctx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == email
+				})).Return(nil, nil).Once()
+			},
+		},
+		{
+			name:        "failed to reset the failed login counter after successful login",
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockRateLimiter.EXPECT().Allow(ctx, ipKey, mock.Anything).Return(allowed, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, accountKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&failedBeforeUser, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(password)).Return(nil).Once()
+				mockUserRepo.EXPECT().Update(ctx, user.ID, resetInput).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:    "successful login reset the failed login counter",
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockRateLimiter.EXPECT().Allow(ctx, ipKey, mock.Anything).Return(allowed, nil).Once()
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, accountKey, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&failedBeforeUser, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(password)).Return(nil).Once()
+				mockUserRepo.EXPECT().Update(ctx, user.ID, resetInput).Return(&user, nil).Once()
+				mockSessionRepo.EXPECT().Create(ctx, mock.Anything).Return(&model.Session{ID: uuid.New(), UserID: user.ID}, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("jwtToken", nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleLogin(ctx, usecase.LoginInput{
+				Email:     email,
+				Password:  password,
+				IPAddress: ipAddress,
+			})
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, "jwtToken", res.Token)
+
+				return
+			}
+
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthUsecase_HandleUnlockAccount(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockEmailTokenRepo := mockUsecase.NewEmailTokenRepository(t)
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, nil, nil, nil, mockEmailTokenRepo, nil, nil, nil, nil, nil)
+
+	userID := uuid.New()
+	tokenID := uuid.New()
+	validateJWTOpts := common.ValidateJWTOpts{
+		Issuer:  string(usecase.TokenIssuerSystem),
+		Subject: string(usecase.AccountUnlockToken),
+	}
+
+	unlockToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": string(usecase.TokenIssuerSystem),
+		"sub": string(usecase.AccountUnlockToken),
+		"aud": []string{userID.String()},
+		"exp": jwt.NewNumericDate(time.Now().Add(time.Hour)).Unix(),
+		"jti": tokenID.String(),
+	})
+	unlockToken.Valid = true
+
+	unlockTokenString, err := unlockToken.SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	consumeInput := usecase.RepoConsumeEmailTokenInput{
+		ID:      tokenID,
+		UserID:  userID,
+		Purpose: string(usecase.AccountUnlockToken),
+	}
+
+	noFailure := 0
+	resetInput := usecase.RepoUpdateUserInput{
+		FailedLoginAttempts: &noFailure,
+		LockedUntil:         &sql.NullTime{Valid: false},
+	}
+
+	testCases := []struct {
+		name                 string
+		input                usecase.UnlockAccountInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unlock token is required",
+			input:       usecase.UnlockAccountInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "invalid unlock token",
+			input:       usecase.UnlockAccountInput{UnlockToken: "invalid"},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT("invalid", validateJWTOpts).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "user not found",
+			input:       usecase.UnlockAccountInput{UnlockToken: unlockTokenString},
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(unlockTokenString, validateJWTOpts).Return(unlockToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "token has already been used",
+			input:       usecase.UnlockAccountInput{UnlockToken: unlockTokenString},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(unlockTokenString, validateJWTOpts).Return(unlockToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&model.User{ID: userID}, nil).Once()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput).Return(usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "failed to reset the lockout state",
+			input:       usecase.UnlockAccountInput{UnlockToken: unlockTokenString},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(unlockTokenString, validateJWTOpts).Return(unlockToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&model.User{ID: userID}, nil).Once()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput).Return(nil).Once()
+				mockUserRepo.EXPECT().Update(ctx, userID, resetInput).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok",
+			input:   usecase.UnlockAccountInput{UnlockToken: unlockTokenString},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(unlockTokenString, validateJWTOpts).Return(unlockToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, userID).Return(&model.User{ID: userID}, nil).Once()
+				mockEmailTokenRepo.EXPECT().Consume(ctx, consumeInput).Return(nil).Once()
+				mockUserRepo.EXPECT().Update(ctx, userID, resetInput).Return(&model.User{ID: userID}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleUnlockAccount(ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Message)
+
+				return
+			}
+
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,529 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis_rate/v10"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/config"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// mfaRecoveryCodeCount is the number of recovery codes generated each time the user enable two-factor authentication
+const mfaRecoveryCodeCount = 10
+
+// mfaVerifyAttemptLimit is the number of allowed attempt to verify the second factor during the lifetime of an mfa pending token
+const mfaVerifyAttemptLimit = 5
+
+// issueMFAChallenge is called after a successful password check on an account with two-factor authentication
+// enabled or required. Instead of the login token, a short lived mfa pending token is returned and must be exchanged
+// either via HandleMFAVerifyLogin or, when the user still not enrolled, via the enrollment flow
+func (u *AuthUsecase) issueMFAChallenge(user *model.User) (*LoginOutput, error) {
+	mfaToken, err := u.sharedCryptor.CreateJWT(jwt.RegisteredClaims{
+		ID:        uuid.New().String(),
+		Issuer:    string(TokenIssuerSystem),
+		Subject:   string(MFAPendingToken),
+		Audience:  []string{user.ID.String()},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(config.MFAPendingTokenExpiry())),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.MFAEnabled {
+		return &LoginOutput{
+			MFAEnrollmentRequired: true,
+			MFAToken:              mfaToken,
+		}, nil
+	}
+
+	return &LoginOutput{
+		MFARequired: true,
+		MFAToken:    mfaToken,
+	}, nil
+}
+
+// findMFAPendingUser parse the mfa pending token and find the user it was issued for
+func (u *AuthUsecase) findMFAPendingUser(ctx context.Context, mfaToken string) (*model.User, error) {
+	_, claims, err := parseJWTToken(u.sharedCryptor, mfaToken, parseJWTTokenInput{
+		expectedIssuer:      TokenIssuerSystem,
+		expectedSubject:     MFAPendingToken,
+		expectedAudienceLen: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	audiences, _ := claims.GetAudience()
+
+	userID, err := uuid.Parse(audiences[0])
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "invalid audience on token used",
+		}
+	}
+
+	return u.findMFAUser(ctx, userID)
+}
+
+// resolveMFAUser find the user which is performing the two-factor authentication enrollment.
+// Logged in user is prioritized, otherwise the mfa pending token is used. The latter happens when the
+// administrator require the account to use two-factor authentication but the user still not enrolled
+func (u *AuthUsecase) resolveMFAUser(ctx context.Context, mfaToken string) (*model.User, bool, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if requester != nil {
+		user, err := u.findMFAUser(ctx, requester.ID)
+
+		return user, false, err
+	}
+
+	if mfaToken == "" {
+		return nil, false, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	user, err := u.findMFAPendingUser(ctx, mfaToken)
+
+	return user, true, err
+}
+
+func (u *AuthUsecase) findMFAUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).WithField("user-id", userID).Error("failed to find user by id")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	if !user.IsActive {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "this account still not activated",
+		}
+	}
+
+	if !IsAllowed(user.Roles, ActionUseMFA, RelationNone) {
+		return nil, UsecaseError{
+			ErrType: ErrForbidden,
+			Message: "two-factor authentication is only available for therapist and administrator",
+		}
+	}
+
+	return user, nil
+}
+
+// validateUserTOTP decrypt the user's TOTP secret and validate the code against it
+func (u *AuthUsecase) validateUserTOTP(ctx context.Context, user *model.User, code string) error {
+	if !user.MFASecret.Valid {
+		return UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "two-factor authentication is not set up for this account",
+		}
+	}
+
+	secret, err := u.sharedCryptor.Decrypt(user.MFASecret.String)
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).WithField("user-id", user.ID).Error("failed to decrypt mfa secret")
+
+		return UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if !common.ValidateTOTPCode(secret, code, time.Now()) {
+		return UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "invalid two-factor authentication code",
+		}
+	}
+
+	return nil
+}
+
+// MFAVerifyLoginInput input
+type MFAVerifyLoginInput struct {
+	MFAToken     string `validate:"required"`
+	Code         string `validate:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode string `validate:"required_without=Code"`
+}
+
+func (i MFAVerifyLoginInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// HandleMFAVerifyLogin complete the login process for account with two-factor authentication enabled
+// by exchanging the mfa pending token and either the TOTP code or one of the recovery codes with the login token
+func (u *AuthUsecase) HandleMFAVerifyLogin(ctx context.Context, input MFAVerifyLoginInput) (*LoginOutput, error) {
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	user, err := u.findMFAPendingUser(ctx, input.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logrus.WithContext(ctx).WithField("user-id", user.ID)
+
+	if !user.MFAEnabled {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "two-factor authentication is not enabled for this account",
+		}
+	}
+
+	rateLimit, err := u.rateLimiter.Allow(ctx, fmt.Sprintf("mfa-verify:%s", user.ID), redis_rate.Limit{
+		Rate:   mfaVerifyAttemptLimit,
+		Burst:  mfaVerifyAttemptLimit,
+		Period: config.MFAPendingTokenExpiry(),
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to perform rate limiter ops")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if rateLimit.Allowed == 0 {
+		return nil, UsecaseError{
+			ErrType: ErrTooManyRequests,
+			Message: fmt.Sprintf("please retry again after %d", int64(rateLimit.ResetAfter.Seconds())),
+		}
+	}
+
+	if input.Code != "" {
+		if err := u.validateUserTOTP(ctx, user, input.Code); err != nil {
+			return nil, err
+		}
+	} else {
+		err := u.mfaRecoveryCodeRepo.Consume(ctx, user.ID, common.HashToken(common.NormalizeMFARecoveryCode(input.RecoveryCode)))
+		switch err {
+		default:
+			logger.WithError(err).Error("failed to consume mfa recovery code")
+
+			return nil, UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		case ErrRepoNotFound:
+			return nil, UsecaseError{
+				ErrType: ErrUnauthorized,
+				Message: "invalid or already used recovery code",
+			}
+		case nil:
+			break
+		}
+	}
+
+	loginToken, refreshToken, err := u.issueLoginSession(ctx, user)
+	if err != nil {
+		logger.WithError(err).Error("failed to issue login session")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return &LoginOutput{
+		Token:        loginToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// MFAEnrollInput input. MFAToken is only needed when the user is not logged in,
+// and was asked to enroll after login because the administrator require it
+type MFAEnrollInput struct {
+	MFAToken string
+}
+
+// MFAEnrollOutput output
+type MFAEnrollOutput struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// HandleMFAEnroll start the two-factor authentication enrollment by generating a new TOTP secret.
+// The secret is not active until confirmed using HandleMFAConfirmEnrollment
+func (u *AuthUsecase) HandleMFAEnroll(ctx context.Context, input MFAEnrollInput) (*MFAEnrollOutput, error) {
+	user, _, err := u.resolveMFAUser(ctx, input.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logrus.WithContext(ctx).WithField("user-id", user.ID)
+
+	if user.MFAEnabled {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "two-factor authentication is already enabled",
+		}
+	}
+
+	email, err := u.sharedCryptor.Decrypt(user.Email)
+	if err != nil {
+		logger.WithError(err).Error("failed to decrypt email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	secret, err := common.GenerateTOTPSecret()
+	if err != nil {
+		logger.WithError(err).Error("failed to generate totp secret")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	encryptedSecret, err := u.sharedCryptor.Encrypt(secret)
+	if err != nil {
+		logger.WithError(err).Error("failed to encrypt totp secret")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if _, err := u.userRepo.Update(ctx, user.ID, RepoUpdateUserInput{
+		MFASecret: &sql.NullString{String: encryptedSecret, Valid: true},
+	}); err != nil {
+		logger.WithError(err).Error("failed to store mfa secret")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return &MFAEnrollOutput{
+		Secret:          secret,
+		ProvisioningURI: common.TOTPProvisioningURI(config.MFAIssuer(), email, secret),
+	}, nil
+}
+
+// MFAConfirmEnrollmentInput input
+type MFAConfirmEnrollmentInput struct {
+	MFAToken string
+	Code     string `validate:"required,len=6,numeric"`
+}
+
+func (i MFAConfirmEnrollmentInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// MFAConfirmEnrollmentOutput output. Token and RefreshToken will only be filled
+// when the enrollment was done using the mfa pending token
+type MFAConfirmEnrollmentOutput struct {
+	RecoveryCodes []string
+	Token         string
+	RefreshToken  string
+}
+
+// HandleMFAConfirmEnrollment enable two-factor authentication after the user proved the TOTP secret
+// was correctly saved on their authenticator app. The recovery codes are only shown once here
+func (u *AuthUsecase) HandleMFAConfirmEnrollment(ctx context.Context, input MFAConfirmEnrollmentInput) (*MFAConfirmEnrollmentOutput, error) {
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	user, usingMFAToken, err := u.resolveMFAUser(ctx, input.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logrus.WithContext(ctx).WithField("user-id", user.ID)
+
+	if user.MFAEnabled {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "two-factor authentication is already enabled",
+		}
+	}
+
+	if err := u.validateUserTOTP(ctx, user, input.Code); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := u.regenerateMFARecoveryCodes(ctx, user.ID)
+	if err != nil {
+		logger.WithError(err).Error("failed to generate mfa recovery codes")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	enabled := true
+	if _, err := u.userRepo.Update(ctx, user.ID, RepoUpdateUserInput{MFAEnabled: &enabled}); err != nil {
+		logger.WithError(err).Error("failed to enable mfa")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	output := &MFAConfirmEnrollmentOutput{
+		RecoveryCodes: recoveryCodes,
+	}
+
+	if !usingMFAToken {
+		return output, nil
+	}
+
+	output.Token, output.RefreshToken, err = u.issueLoginSession(ctx, user)
+	if err != nil {
+		logger.WithError(err).Error("failed to issue login session")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return output, nil
+}
+
+// regenerateMFARecoveryCodes generate new recovery codes replacing the old ones, returning the plain codes.
+// Only the hash of each code is stored
+func (u *AuthUsecase) regenerateMFARecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes := []string{}
+	hashes := []string{}
+
+	for i := 0; i < mfaRecoveryCodeCount; i++ {
+		code, err := common.GenerateMFARecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, code)
+		hashes = append(hashes, common.HashToken(common.NormalizeMFARecoveryCode(code)))
+	}
+
+	if err := u.mfaRecoveryCodeRepo.ReplaceAll(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// MFADisableInput input
+type MFADisableInput struct {
+	Code string `validate:"required,len=6,numeric"`
+}
+
+func (i MFADisableInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// MFADisableOutput output
+type MFADisableOutput struct {
+	Message string
+}
+
+// HandleMFADisable disable two-factor authentication for the logged in user. Not allowed
+// when the administrator require the account to use two-factor authentication
+func (u *AuthUsecase) HandleMFADisable(ctx context.Context, input MFADisableInput) (*MFADisableOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	user, err := u.findMFAUser(ctx, requester.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logrus.WithContext(ctx).WithField("user-id", user.ID)
+
+	if !user.MFAEnabled {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "two-factor authentication is not enabled for this account",
+		}
+	}
+
+	if user.MFARequired {
+		return nil, UsecaseError{
+			ErrType: ErrForbidden,
+			Message: "two-factor authentication is required for this account",
+		}
+	}
+
+	if err := u.validateUserTOTP(ctx, user, input.Code); err != nil {
+		return nil, err
+	}
+
+	enabled := false
+	if _, err := u.userRepo.Update(ctx, user.ID, RepoUpdateUserInput{
+		MFASecret:  &sql.NullString{Valid: false},
+		MFAEnabled: &enabled,
+	}); err != nil {
+		logger.WithError(err).Error("failed to disable mfa")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if err := u.mfaRecoveryCodeRepo.DeleteAll(ctx, user.ID); err != nil {
+		logger.WithError(err).Error("failed to delete mfa recovery codes")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return &MFADisableOutput{
+		Message: "two-factor authentication disabled",
+	}, nil
+}
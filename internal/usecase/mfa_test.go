@@ -0,0 +1,672 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis_rate/v10"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	mockCommon "github.com/luckyAkbar/atec/mocks/internal_/common"
+	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createMFAPendingToken(t *testing.T, userID uuid.UUID) (string, *jwt.Token) {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": string(usecase.TokenIssuerSystem),
+		"sub": string(usecase.MFAPendingToken),
+		"aud": []string{userID.String()},
+		"exp": jwt.NewNumericDate(time.Now().Add(time.Minute * 5)).Unix(),
+		"jti": uuid.NewString(),
+	})
+	token.Valid = true
+
+	tokenString, err := token.SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	return tokenString, token
+}
+
+func assertUsecaseErrType(t *testing.T, expected, err error) {
+	t.Helper()
+
+	require.Error(t, err)
+
+	switch e := err.(type) {
+	default:
+		t.Errorf("expecting usecase error but got %T", err)
+	case usecase.UsecaseError:
+		assert.Equal(t, expected, e.ErrType)
+	}
+}
+
+func TestAuthUsecase_HandleLogin_MFA(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+	mockRateLimiter := mockUsecase.NewRateLimiter(t)
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, nil, mockRateLimiter, mockSessionRepo, nil, nil, nil, nil, nil, nil)
+	email := "therapist@sample.email"
+	password := "validPass!!"
+	encryptedEmail := "encryptedEmail"
+	emailBlindIndex := "email-blind-index"
+	emailLookup := usecase.RepoEmailLookup{BlindIndex: emailBlindIndex, LegacyEncryptedEmails: []string{encryptedEmail}}
+	mfaToken := "mfaToken"
+	allowed := &redis_rate.Result{Allowed: 1}
+
+	therapist := model.User{
+		ID:         uuid.New(),
+		IsActive:   true,
+		Roles:      model.RolesTherapist,
+		MFAEnabled: true,
+	}
+
+	notEnrolled := therapist
+	notEnrolled.MFAEnabled = false
+	notEnrolled.MFARequired = true
+
+	isMFAPendingClaims := mock.MatchedBy(func(claims jwt.RegisteredClaims) bool {
+		return claims.Subject == string(usecase.MFAPendingToken) && claims.Audience[0] == therapist.ID.String()
+	})
+
+	testCases := []struct {
+		name                 string
+		wantErr              bool
+		expectedErr          error
+		expectedOutput       *usecase.LoginOutput
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "failed to create mfa pending token",
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, "login-account:"+emailBlindIndex, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&therapist, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(password)).Return(nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(isMFAPendingClaims).Return("", assert.AnError).Once()
+			},
+		},
+		{
+			name: "mfa enabled must not issue login session",
+			expectedOutput: &usecase.LoginOutput{
+				MFARequired: true,
+				MFAToken:    mfaToken,
+			},
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, "login-account:"+emailBlindIndex, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&therapist, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(password)).Return(nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(isMFAPendingClaims).Return(mfaToken, nil).Once()
+			},
+		},
+		{
+			name: "mfa required but not yet enrolled",
+			expectedOutput: &usecase.LoginOutput{
+				MFAEnrollmentRequired: true,
+				MFAToken:              mfaToken,
+			},
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{encryptedEmail}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, "login-account:"+emailBlindIndex, mock.Anything).Return(allowed, nil).Once()
+				mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&notEnrolled, nil).Once()
+				mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(password)).Return(nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(isMFAPendingClaims).Return(mfaToken, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleLogin(ctx, usecase.LoginInput{Email: email, Password: password})
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedOutput, res)
+
+				return
+			}
+
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthUsecase_HandleMFAVerifyLogin(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+	mockRateLimiter := mockUsecase.NewRateLimiter(t)
+	mockRecoveryCodeRepo := mockUsecase.NewMFARecoveryCodeRepository(t)
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, nil, mockRateLimiter, mockSessionRepo, nil, mockRecoveryCodeRepo, nil, nil, nil, nil)
+
+	secret, err := common.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	user := model.User{
+		ID:         uuid.New(),
+		IsActive:   true,
+		Roles:      model.RolesTherapist,
+		MFAEnabled: true,
+		MFASecret:  sql.NullString{String: "encryptedSecret", Valid: true},
+	}
+
+	notEnabled := user
+	notEnabled.MFAEnabled = false
+
+	session := &model.Session{
+		ID:     uuid.New(),
+		UserID: user.ID,
+	}
+
+	mfaTokenString, mfaToken := createMFAPendingToken(t, user.ID)
+	validateJWTOpts := common.ValidateJWTOpts{
+		Issuer:  string(usecase.TokenIssuerSystem),
+		Subject: string(usecase.MFAPendingToken),
+	}
+	rateLimitKey := "mfa-verify:" + user.ID.String()
+	allowed := &redis_rate.Result{Allowed: 1}
+	recoveryCode := "ABCDE-12345"
+
+	testCases := []struct {
+		name                 string
+		input                func() usecase.MFAVerifyLoginInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name: "either code or recovery code is required",
+			input: func() usecase.MFAVerifyLoginInput {
+				return usecase.MFAVerifyLoginInput{MFAToken: mfaTokenString}
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name: "invalid mfa token",
+			input: func() usecase.MFAVerifyLoginInput {
+				return usecase.MFAVerifyLoginInput{MFAToken: "invalid", Code: "123456"}
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT("invalid", validateJWTOpts).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name: "mfa is not enabled",
+			input: func() usecase.MFAVerifyLoginInput {
+				return usecase.MFAVerifyLoginInput{MFAToken: mfaTokenString, Code: "123456"}
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(mfaTokenString, validateJWTOpts).Return(mfaToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(&notEnabled, nil).Once()
+			},
+		},
+		{
+			name: "too many attempts",
+			input: func() usecase.MFAVerifyLoginInput {
+				return usecase.MFAVerifyLoginInput{MFAToken: mfaTokenString, Code: "123456"}
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrTooManyRequests,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(mfaTokenString, validateJWTOpts).Return(mfaToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(&user, nil).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(&redis_rate.Result{Allowed: 0}, nil).Once()
+			},
+		},
+		{
+			name: "invalid totp code",
+			input: func() usecase.MFAVerifyLoginInput {
+				code, _ := common.GenerateTOTPCode(secret, time.Now().Add(-time.Hour))
+
+				return usecase.MFAVerifyLoginInput{MFAToken: mfaTokenString, Code: code}
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(mfaTokenString, validateJWTOpts).Return(mfaToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(&user, nil).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(allowed, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(user.MFASecret.String).Return(secret, nil).Once()
+			},
+		},
+		{
+			name: "recovery code already used",
+			input: func() usecase.MFAVerifyLoginInput {
+				return usecase.MFAVerifyLoginInput{MFAToken: mfaTokenString, RecoveryCode: recoveryCode}
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(mfaTokenString, validateJWTOpts).Return(mfaToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(&user, nil).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(allowed, nil).Once()
+				mockRecoveryCodeRepo.EXPECT().Consume(ctx, user.ID, common.HashToken("abcde12345")).Return(usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name: "ok using totp code",
+			input: func() usecase.MFAVerifyLoginInput {
+				code, _ := common.GenerateTOTPCode(secret, time.Now())
+
+				return usecase.MFAVerifyLoginInput{MFAToken: mfaTokenString, Code: code}
+			},
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(mfaTokenString, validateJWTOpts).Return(mfaToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(&user, nil).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(allowed, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(user.MFASecret.String).Return(secret, nil).Once()
+				mockSessionRepo.EXPECT().Create(ctx, mock.Anything).Return(session, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("loginToken", nil).Once()
+			},
+		},
+		{
+			name: "ok using recovery code",
+			input: func() usecase.MFAVerifyLoginInput {
+				return usecase.MFAVerifyLoginInput{MFAToken: mfaTokenString, RecoveryCode: recoveryCode}
+			},
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(mfaTokenString, validateJWTOpts).Return(mfaToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(&user, nil).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, rateLimitKey, mock.Anything).Return(allowed, nil).Once()
+				mockRecoveryCodeRepo.EXPECT().Consume(ctx, user.ID, common.HashToken("abcde12345")).Return(nil).Once()
+				mockSessionRepo.EXPECT().Create(ctx, mock.Anything).Return(session, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("loginToken", nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleMFAVerifyLogin(ctx, tc.input())
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, "loginToken", res.Token)
+				assert.NotEmpty(t, res.RefreshToken)
+
+				return
+			}
+
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthUsecase_HandleMFAEnroll(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	therapist := model.User{
+		ID:       uuid.New(),
+		Email:    "encryptedEmail",
+		IsActive: true,
+		Roles:    model.RolesTherapist,
+	}
+
+	parent := therapist
+	parent.Roles = model.RolesParent
+
+	enrolled := therapist
+	enrolled.MFAEnabled = true
+
+	authCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: therapist.ID, Role: model.RolesTherapist})
+
+	mfaTokenString, mfaToken := createMFAPendingToken(t, therapist.ID)
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.MFAEnrollInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "neither logged in nor using mfa token",
+			ctx:         ctx,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "parent can not use mfa",
+			ctx:         authCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(authCtx, therapist.ID).Return(&parent, nil).Once()
+			},
+		},
+		{
+			name:        "already enrolled",
+			ctx:         authCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(authCtx, therapist.ID).Return(&enrolled, nil).Once()
+			},
+		},
+		{
+			name:        "failed to store the secret",
+			ctx:         authCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(authCtx, therapist.ID).Return(&therapist, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(therapist.Email).Return("therapist@mail.com", nil).Once()
+				mockSharedCryptor.EXPECT().Encrypt(mock.Anything).Return("encryptedSecret", nil).Once()
+				mockUserRepo.EXPECT().Update(authCtx, therapist.ID, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:  "ok using mfa token",
+			ctx:   ctx,
+			input: usecase.MFAEnrollInput{MFAToken: mfaTokenString},
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(mfaTokenString, common.ValidateJWTOpts{
+					Issuer:  string(usecase.TokenIssuerSystem),
+					Subject: string(usecase.MFAPendingToken),
+				}).Return(mfaToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, therapist.ID).Return(&therapist, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(therapist.Email).Return("therapist@mail.com", nil).Once()
+				mockSharedCryptor.EXPECT().Encrypt(mock.Anything).Return("encryptedSecret", nil).Once()
+				mockUserRepo.EXPECT().Update(ctx, therapist.ID, usecase.RepoUpdateUserInput{
+					MFASecret: &sql.NullString{String: "encryptedSecret", Valid: true},
+				}).Return(&therapist, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleMFAEnroll(tc.ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Secret)
+				assert.Contains(t, res.ProvisioningURI, "secret="+res.Secret)
+
+				return
+			}
+
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthUsecase_HandleMFAConfirmEnrollment(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+	mockRecoveryCodeRepo := mockUsecase.NewMFARecoveryCodeRepository(t)
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, nil, nil, mockSessionRepo, nil, mockRecoveryCodeRepo, nil, nil, nil, nil)
+
+	secret, err := common.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	therapist := model.User{
+		ID:        uuid.New(),
+		IsActive:  true,
+		Roles:     model.RolesTherapist,
+		MFASecret: sql.NullString{String: "encryptedSecret", Valid: true},
+	}
+
+	notStarted := therapist
+	notStarted.MFASecret = sql.NullString{}
+
+	session := &model.Session{
+		ID:     uuid.New(),
+		UserID: therapist.ID,
+	}
+
+	enabled := true
+	authCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: therapist.ID, Role: model.RolesTherapist})
+	mfaTokenString, mfaToken := createMFAPendingToken(t, therapist.ID)
+	isTenRecoveryCodes := mock.MatchedBy(func(hashes []string) bool {
+		return len(hashes) == 10
+	})
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                func() usecase.MFAConfirmEnrollmentInput
+		wantErr              bool
+		expectedErr          error
+		expectLoginToken     bool
+		expectedFunctionCall func()
+	}{
+		{
+			name: "code must be 6 digits",
+			ctx:  authCtx,
+			input: func() usecase.MFAConfirmEnrollmentInput {
+				return usecase.MFAConfirmEnrollmentInput{Code: "12345"}
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name: "enrollment not yet started",
+			ctx:  authCtx,
+			input: func() usecase.MFAConfirmEnrollmentInput {
+				return usecase.MFAConfirmEnrollmentInput{Code: "123456"}
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(authCtx, therapist.ID).Return(&notStarted, nil).Once()
+			},
+		},
+		{
+			name: "invalid code",
+			ctx:  authCtx,
+			input: func() usecase.MFAConfirmEnrollmentInput {
+				code, _ := common.GenerateTOTPCode(secret, time.Now().Add(-time.Hour))
+
+				return usecase.MFAConfirmEnrollmentInput{Code: code}
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(authCtx, therapist.ID).Return(&therapist, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(therapist.MFASecret.String).Return(secret, nil).Once()
+			},
+		},
+		{
+			name: "failed to store recovery codes",
+			ctx:  authCtx,
+			input: func() usecase.MFAConfirmEnrollmentInput {
+				code, _ := common.GenerateTOTPCode(secret, time.Now())
+
+				return usecase.MFAConfirmEnrollmentInput{Code: code}
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(authCtx, therapist.ID).Return(&therapist, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(therapist.MFASecret.String).Return(secret, nil).Once()
+				mockRecoveryCodeRepo.EXPECT().ReplaceAll(authCtx, therapist.ID, isTenRecoveryCodes).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name: "ok when logged in",
+			ctx:  authCtx,
+			input: func() usecase.MFAConfirmEnrollmentInput {
+				code, _ := common.GenerateTOTPCode(secret, time.Now())
+
+				return usecase.MFAConfirmEnrollmentInput{Code: code}
+			},
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(authCtx, therapist.ID).Return(&therapist, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(therapist.MFASecret.String).Return(secret, nil).Once()
+				mockRecoveryCodeRepo.EXPECT().ReplaceAll(authCtx, therapist.ID, isTenRecoveryCodes).Return(nil).Once()
+				mockUserRepo.EXPECT().Update(authCtx, therapist.ID, usecase.RepoUpdateUserInput{MFAEnabled: &enabled}).
+					Return(&therapist, nil).Once()
+			},
+		},
+		{
+			name: "ok using mfa token will also login",
+			ctx:  ctx,
+			input: func() usecase.MFAConfirmEnrollmentInput {
+				code, _ := common.GenerateTOTPCode(secret, time.Now())
+
+				return usecase.MFAConfirmEnrollmentInput{MFAToken: mfaTokenString, Code: code}
+			},
+			expectLoginToken: true,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().ValidateJWT(mfaTokenString, mock.Anything).Return(mfaToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, therapist.ID).Return(&therapist, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(therapist.MFASecret.String).Return(secret, nil).Once()
+				mockRecoveryCodeRepo.EXPECT().ReplaceAll(ctx, therapist.ID, isTenRecoveryCodes).Return(nil).Once()
+				mockUserRepo.EXPECT().Update(ctx, therapist.ID, usecase.RepoUpdateUserInput{MFAEnabled: &enabled}).
+					Return(&therapist, nil).Once()
+				mockSessionRepo.EXPECT().Create(ctx, mock.Anything).Return(session, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("loginToken", nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleMFAConfirmEnrollment(tc.ctx, tc.input())
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.Len(t, res.RecoveryCodes, 10)
+
+				if tc.expectLoginToken {
+					assert.Equal(t, "loginToken", res.Token)
+				} else {
+					assert.Empty(t, res.Token)
+				}
+
+				return
+			}
+
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthUsecase_HandleMFADisable(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockRecoveryCodeRepo := mockUsecase.NewMFARecoveryCodeRepository(t)
+	uc := usecase.NewAuthUsecase(mockSharedCryptor, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, mockRecoveryCodeRepo, nil, nil, nil, nil)
+
+	secret, err := common.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	admin := model.User{
+		ID:         uuid.New(),
+		IsActive:   true,
+		Roles:      model.RolesAdministrator,
+		MFAEnabled: true,
+		MFASecret:  sql.NullString{String: "encryptedSecret", Valid: true},
+	}
+
+	required := admin
+	required.MFARequired = true
+
+	authCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: admin.ID, Role: model.RolesAdministrator})
+	disabled := false
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "must be logged in",
+			ctx:         ctx,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "can not disable when required by administrator",
+			ctx:         authCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(authCtx, admin.ID).Return(&required, nil).Once()
+			},
+		},
+		{
+			name: "ok",
+			ctx:  authCtx,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(authCtx, admin.ID).Return(&admin, nil).Once()
+				mockSharedCryptor.EXPECT().Decrypt(admin.MFASecret.String).Return(secret, nil).Once()
+				mockUserRepo.EXPECT().Update(authCtx, admin.ID, usecase.RepoUpdateUserInput{
+					MFASecret:  &sql.NullString{Valid: false},
+					MFAEnabled: &disabled,
+				}).Return(&admin, nil).Once()
+				mockRecoveryCodeRepo.EXPECT().DeleteAll(authCtx, admin.ID).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			code, err := common.GenerateTOTPCode(secret, time.Now())
+			require.NoError(t, err)
+
+			_, err = uc.HandleMFADisable(tc.ctx, usecase.MFADisableInput{Code: code})
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+
+				return
+			}
+
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
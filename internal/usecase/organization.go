@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/sweet-go/stdlib/helper"
+)
+
+// OrganizationOutput output
+type OrganizationOutput struct {
+	ID        uuid.UUID
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func newOrganizationOutput(organization model.Organization) OrganizationOutput {
+	return OrganizationOutput{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		CreatedAt: organization.CreatedAt,
+		UpdatedAt: organization.UpdatedAt,
+	}
+}
+
+// AdminCreateOrganizationInput input
+type AdminCreateOrganizationInput struct {
+	Name string `validate:"required,max=255"`
+}
+
+func (i AdminCreateOrganizationInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// AdminCreateOrganization allow administrator to register a new organization. The organization name must be unique
+func (u *UsersUsecase) AdminCreateOrganization(ctx context.Context, input AdminCreateOrganizationInput) (*OrganizationOutput, error) {
+	if err := Authorize(model.GetUserFromCtx(ctx), ActionManageOrganization, RelationNone); err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	organization, err := u.organizationRepo.Create(ctx, input.Name)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).WithField("input", helper.Dump(input)).Error("failed to create organization")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoDuplicate:
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "organization name is already used",
+		}
+	case nil:
+		break
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionCreateOrganization,
+		TargetType: model.AuditTargetOrganization,
+		TargetID:   organization.ID.String(),
+		Metadata:   model.AuditMetadata{"name": organization.Name},
+	})
+
+	output := newOrganizationOutput(*organization)
+
+	return &output, nil
+}
+
+// AdminListOrganizations allow administrator to list all the organizations
+func (u *UsersUsecase) AdminListOrganizations(ctx context.Context) ([]OrganizationOutput, error) {
+	if err := Authorize(model.GetUserFromCtx(ctx), ActionManageOrganization, RelationNone); err != nil {
+		return nil, err
+	}
+
+	organizations, err := u.organizationRepo.FindAll(ctx)
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).Error("failed to find all organizations")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	output := make([]OrganizationOutput, 0, len(organizations))
+	for _, organization := range organizations {
+		output = append(output, newOrganizationOutput(organization))
+	}
+
+	return output, nil
+}
+
+// AdminSetUserOrganizationInput input. Leave OrganizationID empty to remove the user from the organization
+type AdminSetUserOrganizationInput struct {
+	UserID              uuid.UUID `validate:"required"`
+	OrganizationID      uuid.UUID
+	IsOrganizationAdmin bool
+}
+
+func (i AdminSetUserOrganizationInput) validate() error {
+	if err := common.Validator.Struct(i); err != nil {
+		return err
+	}
+
+	if i.IsOrganizationAdmin && i.OrganizationID == uuid.Nil {
+		return errors.New("organization admin must belong to an organization")
+	}
+
+	return nil
+}
+
+// AdminSetUserOrganization allow administrator to move a therapist into an organization, optionally as
+// the organization admin, or remove the therapist from the organization. Because the organization is
+// embedded in the access token, all the target user's sessions will be revoked to make the change
+// effective immediately
+func (u *UsersUsecase) AdminSetUserOrganization(
+	ctx context.Context,
+	input AdminSetUserOrganizationInput,
+) (*AdminUpdateUserOutput, error) {
+	if err := Authorize(model.GetUserFromCtx(ctx), ActionManageOrganization, RelationNone); err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithField("input", helper.Dump(input))
+
+	user, err := u.userRepo.FindByID(ctx, input.UserID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by id")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	organizationID := uuid.NullUUID{}
+
+	if input.OrganizationID != uuid.Nil {
+		if !IsAllowed(user.Roles, ActionJoinOrganization, RelationNone) {
+			return nil, UsecaseError{
+				ErrType: ErrBadRequest,
+				Message: "only therapist can belong to an organization",
+			}
+		}
+
+		_, err := u.organizationRepo.FindByID(ctx, input.OrganizationID)
+		switch err {
+		default:
+			logger.WithError(err).Error("failed to find organization by id")
+
+			return nil, UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		case ErrRepoNotFound:
+			return nil, UsecaseError{
+				ErrType: ErrNotFound,
+				Message: "organization not found",
+			}
+		case nil:
+			break
+		}
+
+		organizationID = uuid.NullUUID{UUID: input.OrganizationID, Valid: true}
+	}
+
+	_, err = u.userRepo.Update(ctx, user.ID, RepoUpdateUserInput{
+		OrganizationID:      &organizationID,
+		IsOrganizationAdmin: &input.IsOrganizationAdmin,
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to update user organization")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionSetUserOrganization,
+		TargetType: model.AuditTargetUser,
+		TargetID:   user.ID.String(),
+		Metadata: model.AuditMetadata{
+			"previous_organization_id": user.OrganizationID,
+			"organization_id":          organizationID,
+			"is_organization_admin":    input.IsOrganizationAdmin,
+		},
+	})
+
+	if err := u.sessionRepo.RevokeAllUserSessions(ctx, user.ID); err != nil {
+		logger.WithError(err).Error("failed to revoke user sessions after organization change")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return &AdminUpdateUserOutput{
+		Message: "ok",
+	}, nil
+}
@@ -0,0 +1,335 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersUsecase_AdminCreateOrganization(t *testing.T) {
+	ctx := context.Background()
+
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	mockOrganizationRepo := mockUsecase.NewOrganizationRepository(t)
+	uc := usecase.NewUsersUsecase(nil, nil, nil, mockAuditLogRepo, nil, nil, nil, nil, nil, mockOrganizationRepo, nil, nil)
+
+	admin := model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator}
+	adminCtx := model.SetUserToCtx(ctx, admin)
+	therapistCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesTherapist})
+
+	organization := &model.Organization{ID: uuid.New(), Name: "clinic"}
+	validInput := usecase.AdminCreateOrganizationInput{Name: organization.Name}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.AdminCreateOrganizationInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized when requester not in context",
+			ctx:         ctx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "forbidden for non administrator",
+			ctx:         therapistCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:        "validation error - empty name",
+			ctx:         adminCtx,
+			input:       usecase.AdminCreateOrganizationInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "name already used",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockOrganizationRepo.EXPECT().Create(adminCtx, organization.Name).Return(nil, usecase.ErrRepoDuplicate).Once()
+			},
+		},
+		{
+			name:        "failed to create organization",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockOrganizationRepo.EXPECT().Create(adminCtx, organization.Name).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:  "success",
+			ctx:   adminCtx,
+			input: validInput,
+			expectedFunctionCall: func() {
+				mockOrganizationRepo.EXPECT().Create(adminCtx, organization.Name).Return(organization, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(adminCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    admin.ID,
+					ActorRole:  model.RolesAdministrator,
+					Action:     model.AuditActionCreateOrganization,
+					TargetType: model.AuditTargetOrganization,
+					TargetID:   organization.ID.String(),
+					Metadata:   model.AuditMetadata{"name": organization.Name},
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.AdminCreateOrganization(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				assertUsecaseErrType(t, tc.expectedErr, err)
+				assert.Nil(t, res)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, res)
+			assert.Equal(t, organization.ID, res.ID)
+			assert.Equal(t, organization.Name, res.Name)
+		})
+	}
+}
+
+func TestUsersUsecase_AdminListOrganizations(t *testing.T) {
+	ctx := context.Background()
+
+	mockOrganizationRepo := mockUsecase.NewOrganizationRepository(t)
+	uc := usecase.NewUsersUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, mockOrganizationRepo, nil, nil)
+
+	adminCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator})
+	parentCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesParent})
+
+	t.Run("forbidden for non administrator", func(t *testing.T) {
+		res, err := uc.AdminListOrganizations(parentCtx)
+		assertUsecaseErrType(t, usecase.ErrForbidden, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed to find organizations", func(t *testing.T) {
+		mockOrganizationRepo.EXPECT().FindAll(adminCtx).Return(nil, assert.AnError).Once()
+
+		res, err := uc.AdminListOrganizations(adminCtx)
+		assertUsecaseErrType(t, usecase.ErrInternal, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockOrganizationRepo.EXPECT().FindAll(adminCtx).Return([]model.Organization{
+			{ID: uuid.New(), Name: "a"},
+			{ID: uuid.New(), Name: "b"},
+		}, nil).Once()
+
+		res, err := uc.AdminListOrganizations(adminCtx)
+		require.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, "a", res[0].Name)
+	})
+}
+
+func TestUsersUsecase_AdminSetUserOrganization(t *testing.T) {
+	ctx := context.Background()
+
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	mockOrganizationRepo := mockUsecase.NewOrganizationRepository(t)
+	uc := usecase.NewUsersUsecase(
+		mockUserRepo, nil, mockSessionRepo, mockAuditLogRepo, nil, nil, nil, nil, nil, mockOrganizationRepo, nil, nil,
+	)
+
+	admin := model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator}
+	adminCtx := model.SetUserToCtx(ctx, admin)
+	therapistCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesTherapist})
+
+	organizationID := uuid.New()
+	therapist := &model.User{ID: uuid.New(), Roles: model.RolesTherapist, IsActive: true}
+	parent := &model.User{ID: uuid.New(), Roles: model.RolesParent, IsActive: true}
+
+	validInput := usecase.AdminSetUserOrganizationInput{
+		UserID:              therapist.ID,
+		OrganizationID:      organizationID,
+		IsOrganizationAdmin: true,
+	}
+	joinedOrganization := uuid.NullUUID{UUID: organizationID, Valid: true}
+	isOrganizationAdmin := true
+	notOrganizationAdmin := false
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.AdminSetUserOrganizationInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "forbidden for non administrator",
+			ctx:         therapistCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name: "validation error - organization admin without organization",
+			ctx:  adminCtx,
+			input: usecase.AdminSetUserOrganizationInput{
+				UserID:              therapist.ID,
+				IsOrganizationAdmin: true,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "user not found",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, therapist.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name: "only therapist can join an organization",
+			ctx:  adminCtx,
+			input: usecase.AdminSetUserOrganizationInput{
+				UserID:         parent.ID,
+				OrganizationID: organizationID,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, parent.ID).Return(parent, nil).Once()
+			},
+		},
+		{
+			name:        "organization not found",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, therapist.ID).Return(therapist, nil).Once()
+				mockOrganizationRepo.EXPECT().FindByID(adminCtx, organizationID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "failed to update the user",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, therapist.ID).Return(therapist, nil).Once()
+				mockOrganizationRepo.EXPECT().FindByID(adminCtx, organizationID).
+					Return(&model.Organization{ID: organizationID}, nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, therapist.ID, usecase.RepoUpdateUserInput{
+					OrganizationID:      &joinedOrganization,
+					IsOrganizationAdmin: &isOrganizationAdmin,
+				}).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:  "success joining the organization as the organization admin",
+			ctx:   adminCtx,
+			input: validInput,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, therapist.ID).Return(therapist, nil).Once()
+				mockOrganizationRepo.EXPECT().FindByID(adminCtx, organizationID).
+					Return(&model.Organization{ID: organizationID}, nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, therapist.ID, usecase.RepoUpdateUserInput{
+					OrganizationID:      &joinedOrganization,
+					IsOrganizationAdmin: &isOrganizationAdmin,
+				}).Return(therapist, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(adminCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    admin.ID,
+					ActorRole:  model.RolesAdministrator,
+					Action:     model.AuditActionSetUserOrganization,
+					TargetType: model.AuditTargetUser,
+					TargetID:   therapist.ID.String(),
+					Metadata: model.AuditMetadata{
+						"previous_organization_id": uuid.NullUUID{},
+						"organization_id":          joinedOrganization,
+						"is_organization_admin":    true,
+					},
+				}).Return(nil).Once()
+				mockSessionRepo.EXPECT().RevokeAllUserSessions(adminCtx, therapist.ID).Return(nil).Once()
+			},
+		},
+		{
+			name: "failed to revoke the sessions after leaving the organization",
+			ctx:  adminCtx,
+			input: usecase.AdminSetUserOrganizationInput{
+				UserID: parent.ID,
+			},
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, parent.ID).Return(parent, nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, parent.ID, usecase.RepoUpdateUserInput{
+					OrganizationID:      &uuid.NullUUID{},
+					IsOrganizationAdmin: &notOrganizationAdmin,
+				}).Return(parent, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(adminCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    admin.ID,
+					ActorRole:  model.RolesAdministrator,
+					Action:     model.AuditActionSetUserOrganization,
+					TargetType: model.AuditTargetUser,
+					TargetID:   parent.ID.String(),
+					Metadata: model.AuditMetadata{
+						"previous_organization_id": uuid.NullUUID{},
+						"organization_id":          uuid.NullUUID{},
+						"is_organization_admin":    false,
+					},
+				}).Return(nil).Once()
+				mockSessionRepo.EXPECT().RevokeAllUserSessions(adminCtx, parent.ID).Return(assert.AnError).Once()
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.AdminSetUserOrganization(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				assertUsecaseErrType(t, tc.expectedErr, err)
+				assert.Nil(t, res)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "ok", res.Message)
+		})
+	}
+}
@@ -12,7 +12,8 @@ import (
 
 // PackageUsecase usecase for package
 type PackageUsecase struct {
-	packageRepo PackageRepo
+	packageRepo  PackageRepo
+	auditLogRepo AuditLogRepository
 }
 
 // PackageUsecaseIface interface
@@ -25,12 +26,48 @@ type PackageUsecaseIface interface {
 }
 
 // NewPackageUsecase create new PackageUsecase instance
-func NewPackageUsecase(packageRepo PackageRepo) *PackageUsecase {
+func NewPackageUsecase(packageRepo PackageRepo, auditLogRepo AuditLogRepository) *PackageUsecase {
 	return &PackageUsecase{
-		packageRepo: packageRepo,
+		packageRepo:  packageRepo,
+		auditLogRepo: auditLogRepo,
 	}
 }
 
+// authorizeManagePackage ensure the requester is allowed to manage packages at all, either as the administrator
+// or as the organization admin. Whether the requester can manage the specific package is checked by packageRelation
+func authorizeManagePackage(requester *model.AuthUser) error {
+	relation := RelationNone
+	if requester != nil {
+		relation = organizationAdminRelation(requester, requester.OrganizationID)
+	}
+
+	return Authorize(requester, ActionManagePackage, relation)
+}
+
+// packageRelation resolve the requester's relation to the package. Only the organization scoped package
+// can be managed by the organization admin
+func packageRelation(requester *model.AuthUser, pack *model.Package) Relation {
+	if !pack.OrganizationID.Valid {
+		return RelationNone
+	}
+
+	return organizationAdminRelation(requester, pack.OrganizationID.UUID)
+}
+
+// isPackageUsable report whether the requester can use the package. Global package is usable by everyone,
+// while the organization scoped package is only usable by the organization members and the administrator
+func isPackageUsable(requester *model.AuthUser, pack model.Package) bool {
+	if !pack.OrganizationID.Valid {
+		return true
+	}
+
+	if requester == nil {
+		return false
+	}
+
+	return IsAllowed(requester.Role, ActionUseOrganizationPackage, organizationMemberRelation(requester, pack.OrganizationID.UUID))
+}
+
 // CreatePackageInput input by embedding direcly model.Questionnaire to simplify the input anotation
 type CreatePackageInput struct {
 	PackageName             string                        `validate:"required"`
@@ -61,21 +98,11 @@ type CreatePackageOutput struct {
 	ID uuid.UUID
 }
 
-// Create create package
+// Create create package. The package created by the organization admin is only usable within the organization
 func (u *PackageUsecase) Create(ctx context.Context, input CreatePackageInput) (*CreatePackageOutput, error) {
 	user := model.GetUserFromCtx(ctx)
-	if user == nil {
-		return nil, UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: ErrUnauthorized.Error(),
-		}
-	}
-
-	if user.Role != model.RolesAdministrator {
-		return nil, UsecaseError{
-			ErrType: ErrForbidden,
-			Message: "insufficient permission to access this feature",
-		}
+	if err := authorizeManagePackage(user); err != nil {
+		return nil, err
 	}
 
 	logger := logrus.WithContext(ctx).WithField("user", helper.Dump(user))
@@ -87,8 +114,16 @@ func (u *PackageUsecase) Create(ctx context.Context, input CreatePackageInput) (
 		}
 	}
 
+	// the one allowed to manage any package manages the global packages, while the organization admin
+	// manages the organization's packages
+	organizationID := uuid.NullUUID{}
+	if !IsAllowed(user.Role, ActionManagePackage, RelationNone) {
+		organizationID = uuid.NullUUID{UUID: user.OrganizationID, Valid: true}
+	}
+
 	pack, err := u.packageRepo.Create(ctx, RepoCreatePackageInput{
 		UserID:                  user.ID,
+		OrganizationID:          organizationID,
 		PackageName:             input.PackageName,
 		Questionnaire:           input.Questionnaire,
 		IndicationCategories:    input.IndicationCategories,
@@ -104,6 +139,18 @@ func (u *PackageUsecase) Create(ctx context.Context, input CreatePackageInput) (
 		}
 	}
 
+	metadata := model.AuditMetadata{"package_name": input.PackageName}
+	if organizationID.Valid {
+		metadata["organization_id"] = organizationID.UUID.String()
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionCreatePackage,
+		TargetType: model.AuditTargetPackage,
+		TargetID:   pack.ID.String(),
+		Metadata:   metadata,
+	})
+
 	return &CreatePackageOutput{
 		ID: pack.ID,
 	}, nil
@@ -127,19 +174,9 @@ type ChangeActiveStatusOutput struct {
 
 // ChangeActiveStatus change package active status from its id. If the package is locked, will raise and forbidden error
 func (u *PackageUsecase) ChangeActiveStatus(ctx context.Context, input ChangeActiveStatusInput) (*ChangeActiveStatusOutput, error) {
-	user := model.GetUserFromCtx(ctx)
-	if user == nil {
-		return nil, UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: ErrUnauthorized.Error(),
-		}
-	}
-
-	if user.Role != model.RolesAdministrator {
-		return nil, UsecaseError{
-			ErrType: ErrForbidden,
-			Message: "insufficient permission to access this feature",
-		}
+	requester := model.GetUserFromCtx(ctx)
+	if err := authorizeManagePackage(requester); err != nil {
+		return nil, err
 	}
 
 	logger := logrus.WithContext(ctx).WithField("input", helper.Dump(input))
@@ -169,6 +206,10 @@ func (u *PackageUsecase) ChangeActiveStatus(ctx context.Context, input ChangeAct
 		break
 	}
 
+	if err := Authorize(requester, ActionManagePackage, packageRelation(requester, pack)); err != nil {
+		return nil, err
+	}
+
 	// early return if no need to change active status
 	if pack.IsActive == input.ActiveStatus {
 		return &ChangeActiveStatusOutput{
@@ -196,6 +237,13 @@ func (u *PackageUsecase) ChangeActiveStatus(ctx context.Context, input ChangeAct
 		}
 	}
 
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionChangePackageStatus,
+		TargetType: model.AuditTargetPackage,
+		TargetID:   input.PackageID.String(),
+		Metadata:   model.AuditMetadata{"active_status": input.ActiveStatus},
+	})
+
 	return &ChangeActiveStatusOutput{
 		Message: "ok",
 	}, nil
@@ -224,19 +272,9 @@ type UpdatePackageOutput struct {
 
 // Update update a package based on its id. Only applicable if the package is not yet locked
 func (u *PackageUsecase) Update(ctx context.Context, input UpdatePackageInput) (*UpdatePackageOutput, error) {
-	user := model.GetUserFromCtx(ctx)
-	if user == nil {
-		return nil, UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: ErrUnauthorized.Error(),
-		}
-	}
-
-	if user.Role != model.RolesAdministrator {
-		return nil, UsecaseError{
-			ErrType: ErrForbidden,
-			Message: "insufficient permission to access this feature",
-		}
+	requester := model.GetUserFromCtx(ctx)
+	if err := authorizeManagePackage(requester); err != nil {
+		return nil, err
 	}
 
 	logger := logrus.WithContext(ctx).WithField("id", input.PackageID)
@@ -266,6 +304,10 @@ func (u *PackageUsecase) Update(ctx context.Context, input UpdatePackageInput) (
 		break
 	}
 
+	if err := Authorize(requester, ActionManagePackage, packageRelation(requester, pack)); err != nil {
+		return nil, err
+	}
+
 	if pack.IsLocked {
 		return nil, UsecaseError{
 			ErrType: ErrForbidden,
@@ -287,6 +329,13 @@ func (u *PackageUsecase) Update(ctx context.Context, input UpdatePackageInput) (
 		}
 	}
 
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionUpdatePackage,
+		TargetType: model.AuditTargetPackage,
+		TargetID:   input.PackageID.String(),
+		Metadata:   model.AuditMetadata{"package_name": input.PackageName},
+	})
+
 	return &UpdatePackageOutput{
 		Message: "ok",
 	}, nil
@@ -294,19 +343,9 @@ func (u *PackageUsecase) Update(ctx context.Context, input UpdatePackageInput) (
 
 // Delete delete a package with its id by using soft delete technique
 func (u *PackageUsecase) Delete(ctx context.Context, id uuid.UUID) error {
-	user := model.GetUserFromCtx(ctx)
-	if user == nil {
-		return UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: ErrUnauthorized.Error(),
-		}
-	}
-
-	if user.Role != model.RolesAdministrator {
-		return UsecaseError{
-			ErrType: ErrForbidden,
-			Message: "insufficient permission to access this feature",
-		}
+	requester := model.GetUserFromCtx(ctx)
+	if err := authorizeManagePackage(requester); err != nil {
+		return err
 	}
 
 	logger := logrus.WithContext(ctx).WithField("id", id.String())
@@ -329,6 +368,10 @@ func (u *PackageUsecase) Delete(ctx context.Context, id uuid.UUID) error {
 		break
 	}
 
+	if err := Authorize(requester, ActionManagePackage, packageRelation(requester, pack)); err != nil {
+		return err
+	}
+
 	if pack.IsLocked {
 		return UsecaseError{
 			ErrType: ErrForbidden,
@@ -345,6 +388,12 @@ func (u *PackageUsecase) Delete(ctx context.Context, id uuid.UUID) error {
 		}
 	}
 
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionDeletePackage,
+		TargetType: model.AuditTargetPackage,
+		TargetID:   id.String(),
+	})
+
 	return nil
 }
 
@@ -357,7 +406,10 @@ type FindActiveQuestionnaireOutput struct {
 }
 
 // FindActiveQuestionnaires will find any packages on database that have is_active set to true
+// and usable by the requester
 func (u *PackageUsecase) FindActiveQuestionnaires(ctx context.Context) ([]FindActiveQuestionnaireOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+
 	packages, err := u.packageRepo.FindAllActivePackages(ctx)
 
 	switch err {
@@ -379,6 +431,10 @@ func (u *PackageUsecase) FindActiveQuestionnaires(ctx context.Context) ([]FindAc
 
 	output := []FindActiveQuestionnaireOutput{}
 	for _, pack := range packages {
+		if !isPackageUsable(requester, pack) {
+			continue
+		}
+
 		output = append(output, FindActiveQuestionnaireOutput{
 			ID:                   pack.ID,
 			Questionnaire:        pack.Questionnaire,
@@ -387,5 +443,12 @@ func (u *PackageUsecase) FindActiveQuestionnaires(ctx context.Context) ([]FindAc
 		})
 	}
 
+	if len(output) == 0 {
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: "system still doesn't have any questionnaire to be used yet",
+		}
+	}
+
 	return output, nil
 }
@@ -452,9 +452,23 @@ func TestPackageUsecase_Create(t *testing.T) {
 
 	userCtx := model.SetUserToCtx(ctx, user)
 
+	organizationAdmin := model.AuthUser{
+		ID:                  uuid.New(),
+		Role:                model.RolesTherapist,
+		OrganizationID:      uuid.New(),
+		IsOrganizationAdmin: true,
+	}
+	organizationAdminCtx := model.SetUserToCtx(ctx, organizationAdmin)
+	organizationMemberCtx := model.SetUserToCtx(ctx, model.AuthUser{
+		ID:             uuid.New(),
+		Role:           model.RolesTherapist,
+		OrganizationID: organizationAdmin.OrganizationID,
+	})
+
 	mockPackageRepo := mockUsecase.NewPackageRepo(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
 
-	uc := usecase.NewPackageUsecase(mockPackageRepo)
+	uc := usecase.NewPackageUsecase(mockPackageRepo, mockAuditLogRepo)
 
 	validInput := usecase.CreatePackageInput{
 		PackageName:             "valid package name",
@@ -519,6 +533,51 @@ func TestPackageUsecase_Create(t *testing.T) {
 					IndicationCategories:    validInput.IndicationCategories,
 					ImageResultAttributeKey: validInput.ImageResultAttributeKey,
 				}).Return(&model.Package{ID: packageID}, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(userCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    userID,
+					ActorRole:  model.RolesAdministrator,
+					Action:     model.AuditActionCreatePackage,
+					TargetType: model.AuditTargetPackage,
+					TargetID:   packageID.String(),
+					Metadata:   model.AuditMetadata{"package_name": validInput.PackageName},
+				}).Return(nil).Once()
+			},
+		},
+		{
+			name:        "organization member who is not the organization admin is forbidden",
+			input:       validInput,
+			wantErr:     true,
+			ctx:         organizationMemberCtx,
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:    "ok - organization admin creates organization scoped package",
+			input:   validInput,
+			wantErr: false,
+			ctx:     organizationAdminCtx,
+			expectedOutput: &usecase.CreatePackageOutput{
+				ID: packageID,
+			},
+			expectedFunctionCall: func() {
+				mockPackageRepo.EXPECT().Create(organizationAdminCtx, usecase.RepoCreatePackageInput{
+					UserID:                  organizationAdmin.ID,
+					OrganizationID:          uuid.NullUUID{UUID: organizationAdmin.OrganizationID, Valid: true},
+					PackageName:             validInput.PackageName,
+					Questionnaire:           validInput.Questionnaire,
+					IndicationCategories:    validInput.IndicationCategories,
+					ImageResultAttributeKey: validInput.ImageResultAttributeKey,
+				}).Return(&model.Package{ID: packageID}, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(organizationAdminCtx, usecase.RepoCreateAuditLogInput{
+					ActorID:    organizationAdmin.ID,
+					ActorRole:  model.RolesTherapist,
+					Action:     model.AuditActionCreatePackage,
+					TargetType: model.AuditTargetPackage,
+					TargetID:   packageID.String(),
+					Metadata: model.AuditMetadata{
+						"package_name":    validInput.PackageName,
+						"organization_id": organizationAdmin.OrganizationID.String(),
+					},
+				}).Return(nil).Once()
 			},
 		},
 	}
@@ -559,8 +618,9 @@ func TestPackageUsecase_ChangeActiveStatus(t *testing.T) {
 	ctx := model.SetUserToCtx(context.Background(), administrator)
 
 	mockPackageRepo := mockUsecase.NewPackageRepo(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
 
-	uc := usecase.NewPackageUsecase(mockPackageRepo)
+	uc := usecase.NewPackageUsecase(mockPackageRepo, mockAuditLogRepo)
 
 	packageID := uuid.New()
 	statusEnabled := true
@@ -699,6 +759,14 @@ func TestPackageUsecase_ChangeActiveStatus(t *testing.T) {
 				mockPackageRepo.EXPECT().Update(ctx, packageID, usecase.RepoUpdatePackageInput{
 					ActiveStatus: &statusEnabled,
 				}).Return(&model.Package{}, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(ctx, usecase.RepoCreateAuditLogInput{
+					ActorID:    administrator.ID,
+					ActorRole:  model.RolesAdministrator,
+					Action:     model.AuditActionChangePackageStatus,
+					TargetType: model.AuditTargetPackage,
+					TargetID:   packageID.String(),
+					Metadata:   model.AuditMetadata{"active_status": statusEnabled},
+				}).Return(nil).Once()
 			},
 		},
 	}
@@ -737,10 +805,17 @@ func TestPackageUsecase_Update(t *testing.T) {
 	}
 
 	ctx := model.SetUserToCtx(context.Background(), administrator)
+	organizationAdminCtx := model.SetUserToCtx(context.Background(), model.AuthUser{
+		ID:                  uuid.New(),
+		Role:                model.RolesTherapist,
+		OrganizationID:      uuid.New(),
+		IsOrganizationAdmin: true,
+	})
 
 	mockPackageRepo := mockUsecase.NewPackageRepo(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
 
-	uc := usecase.NewPackageUsecase(mockPackageRepo)
+	uc := usecase.NewPackageUsecase(mockPackageRepo, mockAuditLogRepo)
 
 	packageID := uuid.New()
 	unlockedPackage := &model.Package{
@@ -807,6 +882,16 @@ func TestPackageUsecase_Update(t *testing.T) {
 				mockPackageRepo.EXPECT().FindByID(ctx, packageID).Return(nil, usecase.ErrRepoNotFound).Once()
 			},
 		},
+		{
+			name:        "organization admin is unable to update global package",
+			input:       input,
+			wantErr:     true,
+			ctx:         organizationAdminCtx,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockPackageRepo.EXPECT().FindByID(organizationAdminCtx, packageID).Return(unlockedPackage, nil).Once()
+			},
+		},
 		{
 			name:        "unable to update locked package",
 			input:       input,
@@ -845,6 +930,14 @@ func TestPackageUsecase_Update(t *testing.T) {
 					PackageName:   input.PackageName,
 					Questionnaire: &input.Questionnaire,
 				}).Return(&model.Package{}, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(ctx, usecase.RepoCreateAuditLogInput{
+					ActorID:    administrator.ID,
+					ActorRole:  model.RolesAdministrator,
+					Action:     model.AuditActionUpdatePackage,
+					TargetType: model.AuditTargetPackage,
+					TargetID:   packageID.String(),
+					Metadata:   model.AuditMetadata{"package_name": input.PackageName},
+				}).Return(nil).Once()
 			},
 		},
 	}
@@ -885,8 +978,9 @@ func TestPackageUsecase_Delete(t *testing.T) {
 	ctx := model.SetUserToCtx(context.Background(), administrator)
 
 	mockPackageRepo := mockUsecase.NewPackageRepo(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
 
-	uc := usecase.NewPackageUsecase(mockPackageRepo)
+	uc := usecase.NewPackageUsecase(mockPackageRepo, mockAuditLogRepo)
 
 	packageID := uuid.New()
 	lockedPackage := &model.Package{
@@ -960,6 +1054,13 @@ func TestPackageUsecase_Delete(t *testing.T) {
 			expectedFunctionCall: func() {
 				mockPackageRepo.EXPECT().FindByID(ctx, packageID).Return(unlockedPackage, nil).Once()
 				mockPackageRepo.EXPECT().Delete(ctx, packageID).Return(nil).Once()
+				mockAuditLogRepo.EXPECT().Create(ctx, usecase.RepoCreateAuditLogInput{
+					ActorID:    administrator.ID,
+					ActorRole:  model.RolesAdministrator,
+					Action:     model.AuditActionDeletePackage,
+					TargetType: model.AuditTargetPackage,
+					TargetID:   packageID.String(),
+				}).Return(nil).Once()
 			},
 		},
 	}
@@ -995,7 +1096,7 @@ func TestPackageUsecase_FindActiveQuestionnaires(t *testing.T) {
 
 	mockPackageRepo := mockUsecase.NewPackageRepo(t)
 
-	uc := usecase.NewPackageUsecase(mockPackageRepo)
+	uc := usecase.NewPackageUsecase(mockPackageRepo, nil)
 
 	expectedOutputLen := 10
 
@@ -1030,6 +1131,16 @@ func TestPackageUsecase_FindActiveQuestionnaires(t *testing.T) {
 				mockPackageRepo.EXPECT().FindAllActivePackages(ctx).Return(make([]model.Package, expectedOutputLen), nil).Once()
 			},
 		},
+		{
+			name:        "organization scoped package is hidden from the outsider",
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockPackageRepo.EXPECT().FindAllActivePackages(ctx).Return([]model.Package{
+					{ID: uuid.New(), OrganizationID: uuid.NullUUID{UUID: uuid.New(), Valid: true}},
+				}, nil).Once()
+			},
+		},
 	}
 
 	for _, tc := range testCases {
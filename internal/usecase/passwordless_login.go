@@ -0,0 +1,368 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/go-redis/redis_rate/v10"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/config"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// passwordlessLoginCodeDigits is the number of digits of the one time code sent for passwordless login
+const passwordlessLoginCodeDigits = 6
+
+// magicLinkSecretLength is the number of random bytes used as the magic link secret
+const magicLinkSecretLength = 32
+
+// errInvalidPasswordlessLogin is returned whenever the passwordless login can't be completed, so the requester
+// can't tell whether the email is registered, the code is wrong or already expired
+var errInvalidPasswordlessLogin = UsecaseError{
+	ErrType: ErrUnauthorized,
+	Message: "invalid or expired login code",
+}
+
+// InitPasswordlessLoginInput input
+type InitPasswordlessLoginInput struct {
+	Email string `validate:"required,email"`
+}
+
+func (ipli InitPasswordlessLoginInput) validate() error {
+	return common.Validator.Struct(ipli)
+}
+
+// InitPasswordlessLoginOutput output
+type InitPasswordlessLoginOutput struct {
+	Message string
+}
+
+// HandleInitPasswordlessLogin send a one time code and a magic link to the email, both can be used once to login
+// without password before they expire. Only available for parent accounts. Requesting a new one will invalidate
+// the previous code and link. The response is the same whether or not the email can use passwordless login
+func (u *AuthUsecase) HandleInitPasswordlessLogin(ctx context.Context, input InitPasswordlessLoginInput) (*InitPasswordlessLoginOutput, error) {
+	logger := logrus.WithContext(ctx).WithField("email", input.Email)
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	emailLookup, err := newEmailLookup(u.sharedCryptor, input.Email)
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: "encryption process failed",
+		}
+	}
+
+	rateLimit, err := u.rateLimiter.Allow(ctx, "passwordless-login:"+emailLookup.BlindIndex, redis_rate.Limit{
+		Rate:   1,
+		Burst:  1,
+		Period: config.PasswordlessLoginLimiterDuration(),
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to perform rate limiter ops")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if rateLimit.Allowed == 0 {
+		return nil, UsecaseError{
+			ErrType: ErrTooManyRequests,
+			Message: fmt.Sprintf("please retry again after %d", int64(rateLimit.ResetAfter.Seconds())),
+		}
+	}
+
+	user, err := u.userRepo.FindByEmail(ctx, emailLookup)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case nil, ErrRepoNotFound:
+		break
+	}
+
+	output := &InitPasswordlessLoginOutput{
+		Message: "ok",
+	}
+
+	// only the email owner should know whether the email can be used to login without password
+	if user == nil || !user.IsActive {
+		err := u.sendAccountNoticeEmail(ctx, input.Email, "Login Tanpa Kata Sandi", noActiveAccountEmailTemplate())
+		if err != nil {
+			logger.WithError(err).Error("failed to send no active account email")
+
+			return nil, UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		}
+
+		return output, nil
+	}
+
+	if !IsAllowed(user.Roles, ActionPasswordlessLogin, RelationNone) || user.IsLocked() {
+		err := u.sendAccountNoticeEmail(ctx, input.Email, "Login Tanpa Kata Sandi", passwordlessLoginUnavailableEmailTemplate())
+		if err != nil {
+			logger.WithError(err).Error("failed to send passwordless login unavailable email")
+
+			return nil, UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		}
+
+		return output, nil
+	}
+
+	code, err := common.GenerateNumericCode(passwordlessLoginCodeDigits)
+	if err != nil {
+		logger.WithError(err).Error("failed to generate passwordless login code")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	secret, err := common.GenerateSecureToken(magicLinkSecretLength)
+	if err != nil {
+		logger.WithError(err).Error("failed to generate magic link secret")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	// only the hashes are stored, creating a new one will replace the previous pending login
+	err = u.passwordlessLoginRepo.Create(ctx, RepoCreatePasswordlessLoginInput{
+		UserID:        user.ID,
+		OTPHash:       common.HashToken(code),
+		MagicLinkHash: common.HashToken(secret),
+		ExpiresIn:     config.PasswordlessLoginCodeExpiry(),
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to store pending passwordless login")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	_, err = u.mailer.SendEmail(ctx, common.SendEmailInput{
+		ReceiverName:  user.Username,
+		ReceiverEmail: input.Email,
+		Subject:       "Login Tanpa Kata Sandi",
+		HTMLContent:   passwordlessLoginEmailTemplate(code, formatMagicLinkToken(user.ID, secret)),
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to send passwordless login email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return output, nil
+}
+
+// VerifyPasswordlessLoginInput input
+type VerifyPasswordlessLoginInput struct {
+	Email string `validate:"required,email"`
+	Code  string `validate:"required,len=6,numeric"`
+}
+
+func (vpli VerifyPasswordlessLoginInput) validate() error {
+	return common.Validator.Struct(vpli)
+}
+
+// HandleVerifyPasswordlessLogin login using the one time code sent by HandleInitPasswordlessLogin.
+// The number of attempts is limited, and once used, both the code and the magic link can't be used anymore
+func (u *AuthUsecase) HandleVerifyPasswordlessLogin(ctx context.Context, input VerifyPasswordlessLoginInput) (*LoginOutput, error) {
+	logger := logrus.WithContext(ctx).WithField("email", input.Email)
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	emailLookup, err := newEmailLookup(u.sharedCryptor, input.Email)
+	if err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: "encryption process failed",
+		}
+	}
+
+	// the code only has a million possible values, so the attempts must be limited to prevent guessing
+	attemptLimit := config.PasswordlessLoginVerifyAttemptLimit()
+
+	rateLimit, err := u.rateLimiter.Allow(ctx, "passwordless-login-verify:"+emailLookup.BlindIndex, redis_rate.Limit{
+		Rate:   attemptLimit,
+		Burst:  attemptLimit,
+		Period: config.PasswordlessLoginCodeExpiry(),
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to perform rate limiter ops")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if rateLimit.Allowed == 0 {
+		return nil, UsecaseError{
+			ErrType: ErrTooManyRequests,
+			Message: fmt.Sprintf("too many login attempts, please retry again after %d", int64(rateLimit.RetryAfter.Seconds())),
+		}
+	}
+
+	user, err := u.userRepo.FindByEmail(ctx, emailLookup)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by email")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, errInvalidPasswordlessLogin
+	case nil:
+		break
+	}
+
+	return u.completePasswordlessLogin(ctx, user, func(login *model.PasswordlessLogin) bool {
+		return subtle.ConstantTimeCompare([]byte(login.OTPHash), []byte(common.HashToken(input.Code))) == 1
+	})
+}
+
+// MagicLinkLoginInput input
+type MagicLinkLoginInput struct {
+	MagicLinkToken string `validate:"required"`
+}
+
+func (mlli MagicLinkLoginInput) validate() error {
+	return common.Validator.Struct(mlli)
+}
+
+// HandleMagicLinkLogin login using the magic link token sent by HandleInitPasswordlessLogin.
+// Once used, both the magic link and the code can't be used anymore
+func (u *AuthUsecase) HandleMagicLinkLogin(ctx context.Context, input MagicLinkLoginInput) (*LoginOutput, error) {
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	userID, secret, err := parseMagicLinkToken(input.MagicLinkToken)
+	if err != nil {
+		return nil, errInvalidPasswordlessLogin
+	}
+
+	user, err := u.userRepo.FindByID(ctx, userID)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).WithField("user-id", userID).Error("failed to find user by id")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, errInvalidPasswordlessLogin
+	case nil:
+		break
+	}
+
+	return u.completePasswordlessLogin(ctx, user, func(login *model.PasswordlessLogin) bool {
+		return subtle.ConstantTimeCompare([]byte(login.MagicLinkHash), []byte(common.HashToken(secret))) == 1
+	})
+}
+
+// completePasswordlessLogin check the user's pending passwordless login using the matcher, then consume it
+// and issue the same login session as HandleLogin
+func (u *AuthUsecase) completePasswordlessLogin(
+	ctx context.Context,
+	user *model.User,
+	matcher func(login *model.PasswordlessLogin) bool,
+) (*LoginOutput, error) {
+	logger := logrus.WithContext(ctx).WithField("user-id", user.ID)
+
+	login, err := u.passwordlessLoginRepo.FindByUserID(ctx, user.ID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find pending passwordless login")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, errInvalidPasswordlessLogin
+	case nil:
+		break
+	}
+
+	if !matcher(login) {
+		return nil, errInvalidPasswordlessLogin
+	}
+
+	if err := u.passwordlessLoginRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		logger.WithError(err).Error("failed to delete pending passwordless login")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	// the account may have changed since the code was sent
+	if !user.IsActive || !IsAllowed(user.Roles, ActionPasswordlessLogin, RelationNone) || user.IsLocked() {
+		return nil, errInvalidPasswordlessLogin
+	}
+
+	loginToken, refreshToken, err := u.issueLoginSession(ctx, user)
+	if err != nil {
+		logger.WithError(err).Error("failed to issue login session")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return &LoginOutput{
+		Token:        loginToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// formatMagicLinkToken magic link token is formatted as <user id>.<secret>. Only the hash of the secret is stored
+func formatMagicLinkToken(userID uuid.UUID, secret string) string {
+	return formatRefreshToken(userID, secret)
+}
+
+func parseMagicLinkToken(token string) (uuid.UUID, string, error) {
+	return parseRefreshToken(token)
+}
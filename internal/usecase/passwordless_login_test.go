@@ -0,0 +1,507 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis_rate/v10"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	mockCommon "github.com/luckyAkbar/atec/mocks/internal_/common"
+	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/sendinblue/APIv3-go-library/v2/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthUsecase_HandleInitPasswordlessLogin(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockRateLimiter := mockUsecase.NewRateLimiter(t)
+	mockMailer := mockCommon.NewMailerIface(t)
+	mockPasswordlessLoginRepo := mockUsecase.NewPasswordlessLoginRepository(t)
+	uc := usecase.NewAuthUsecase(
+		mockSharedCryptor, mockUserRepo, nil, nil, nil, mockMailer, mockRateLimiter, nil, nil, nil, nil, mockPasswordlessLoginRepo, nil, nil,
+	)
+
+	email := "parent@example.com"
+	emailEnc := "encrypted-email"
+	emailBlindIndex := "email-blind-index"
+	emailLookup := usecase.RepoEmailLookup{BlindIndex: emailBlindIndex, LegacyEncryptedEmails: []string{emailEnc}}
+	limiterKey := "passwordless-login:" + emailBlindIndex
+
+	parent := &model.User{
+		ID:       uuid.New(),
+		Email:    emailEnc,
+		Username: "parent",
+		IsActive: true,
+		Roles:    model.RolesParent,
+	}
+
+	// expectUntilUserFound set the expectation of every call made until the user is looked up by the email
+	expectUntilUserFound := func(user *model.User, err error) {
+		mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{emailEnc}, nil).Once()
+		mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+		mockRateLimiter.EXPECT().Allow(ctx, limiterKey, mock.Anything).Return(&redis_rate.Result{Allowed: 1}, nil).Once()
+		mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(user, err).Once()
+	}
+
+	isLoginEmail := func(input common.SendEmailInput) bool {
+		return input.ReceiverEmail == email && strings.Contains(input.HTMLContent, parent.ID.String()+".")
+	}
+
+	testCases := []struct {
+		name                 string
+		input                usecase.InitPasswordlessLoginInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "invalid email",
+			input:       usecase.InitPasswordlessLoginInput{Email: "invalid"},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "failed to encrypt email",
+			input:       usecase.InitPasswordlessLoginInput{Email: email},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "rate limiter error",
+			input:       usecase.InitPasswordlessLoginInput{Email: email},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{emailEnc}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, limiterKey, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "too many requests",
+			input:       usecase.InitPasswordlessLoginInput{Email: email},
+			wantErr:     true,
+			expectedErr: usecase.ErrTooManyRequests,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{emailEnc}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, limiterKey, mock.Anything).Return(&redis_rate.Result{Allowed: 0, ResetAfter: time.Minute}, nil).Once()
+			},
+		},
+		{
+			name:        "failed to find user",
+			input:       usecase.InitPasswordlessLoginInput{Email: email},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(nil, assert.AnError)
+			},
+		},
+		{
+			name:    "unregistered email only receive a notice",
+			input:   usecase.InitPasswordlessLoginInput{Email: email},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(nil, usecase.ErrRepoNotFound)
+				mockMailer.EXPECT().SendEmail(ctx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == email && strings.Contains(input.HTMLContent, "Tidak Ada Akun Aktif")
+				})).Return(&lib.CreateSmtpEmail{}, nil).Once()
+			},
+		},
+		{
+			name:        "failed to send notice to unregistered email",
+			input:       usecase.InitPasswordlessLoginInput{Email: email},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(nil, usecase.ErrRepoNotFound)
+				mockMailer.EXPECT().SendEmail(ctx, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:    "non parent account only receive a notice",
+			input:   usecase.InitPasswordlessLoginInput{Email: email},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(&model.User{ID: uuid.New(), IsActive: true, Roles: model.RolesTherapist}, nil)
+				mockMailer.EXPECT().SendEmail(ctx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == email && strings.Contains(input.HTMLContent, "Login Tanpa Kata Sandi Tidak Tersedia")
+				})).Return(&lib.CreateSmtpEmail{}, nil).Once()
+			},
+		},
+		{
+			name:    "locked account only receive a notice",
+			input:   usecase.InitPasswordlessLoginInput{Email: email},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(&model.User{
+					ID:          uuid.New(),
+					IsActive:    true,
+					Roles:       model.RolesParent,
+					LockedUntil: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+				}, nil)
+				mockMailer.EXPECT().SendEmail(ctx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+					return input.ReceiverEmail == email && strings.Contains(input.HTMLContent, "Login Tanpa Kata Sandi Tidak Tersedia")
+				})).Return(&lib.CreateSmtpEmail{}, nil).Once()
+			},
+		},
+		{
+			name:        "failed to store pending login",
+			input:       usecase.InitPasswordlessLoginInput{Email: email},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(parent, nil)
+				mockPasswordlessLoginRepo.EXPECT().Create(ctx, mock.Anything).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to send login email",
+			input:       usecase.InitPasswordlessLoginInput{Email: email},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(parent, nil)
+				mockPasswordlessLoginRepo.EXPECT().Create(ctx, mock.Anything).Return(nil).Once()
+				mockMailer.EXPECT().SendEmail(ctx, mock.MatchedBy(isLoginEmail)).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok",
+			input:   usecase.InitPasswordlessLoginInput{Email: email},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(parent, nil)
+				mockPasswordlessLoginRepo.EXPECT().Create(ctx, mock.MatchedBy(func(input usecase.RepoCreatePasswordlessLoginInput) bool {
+					return input.UserID == parent.ID && input.OTPHash != "" && input.MagicLinkHash != "" && input.ExpiresIn > 0
+				})).Return(nil).Once()
+				mockMailer.EXPECT().SendEmail(ctx, mock.MatchedBy(isLoginEmail)).Return(&lib.CreateSmtpEmail{}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleInitPasswordlessLogin(ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, "ok", res.Message)
+
+				return
+			}
+
+			assert.Nil(t, res)
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthUsecase_HandleVerifyPasswordlessLogin(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockRateLimiter := mockUsecase.NewRateLimiter(t)
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+	mockPasswordlessLoginRepo := mockUsecase.NewPasswordlessLoginRepository(t)
+	uc := usecase.NewAuthUsecase(
+		mockSharedCryptor, mockUserRepo, nil, nil, nil, nil, mockRateLimiter, mockSessionRepo, nil, nil, nil, mockPasswordlessLoginRepo, nil, nil,
+	)
+
+	email := "parent@example.com"
+	emailEnc := "encrypted-email"
+	emailBlindIndex := "email-blind-index"
+	emailLookup := usecase.RepoEmailLookup{BlindIndex: emailBlindIndex, LegacyEncryptedEmails: []string{emailEnc}}
+	code := "123456"
+	limiterKey := "passwordless-login-verify:" + emailBlindIndex
+
+	parent := &model.User{
+		ID:       uuid.New(),
+		Email:    emailEnc,
+		IsActive: true,
+		Roles:    model.RolesParent,
+	}
+	pendingLogin := &model.PasswordlessLogin{
+		UserID:        parent.ID,
+		OTPHash:       common.HashToken(code),
+		MagicLinkHash: common.HashToken("secret"),
+	}
+	validInput := usecase.VerifyPasswordlessLoginInput{Email: email, Code: code}
+
+	// expectUntilUserFound set the expectation of every call made until the user is looked up by the email
+	expectUntilUserFound := func(user *model.User, err error) {
+		mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{emailEnc}, nil).Once()
+		mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+		mockRateLimiter.EXPECT().Allow(ctx, limiterKey, mock.Anything).Return(&redis_rate.Result{Allowed: 1}, nil).Once()
+		mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(user, err).Once()
+	}
+
+	testCases := []struct {
+		name                 string
+		input                usecase.VerifyPasswordlessLoginInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "invalid code format",
+			input:       usecase.VerifyPasswordlessLoginInput{Email: email, Code: "abc"},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "too many attempts",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrTooManyRequests,
+			expectedFunctionCall: func() {
+				mockSharedCryptor.EXPECT().LegacyEncrypt(email).Return([]string{emailEnc}, nil).Once()
+				mockSharedCryptor.EXPECT().BlindIndex(email).Return(emailBlindIndex).Once()
+				mockRateLimiter.EXPECT().Allow(ctx, limiterKey, mock.Anything).Return(&redis_rate.Result{Allowed: 0, RetryAfter: time.Minute}, nil).Once()
+			},
+		},
+		{
+			name:        "unregistered email",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(nil, usecase.ErrRepoNotFound)
+			},
+		},
+		{
+			name:        "no pending login",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(parent, nil)
+				mockPasswordlessLoginRepo.EXPECT().FindByUserID(ctx, parent.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "failed to find pending login",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(parent, nil)
+				mockPasswordlessLoginRepo.EXPECT().FindByUserID(ctx, parent.ID).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "wrong code",
+			input:       usecase.VerifyPasswordlessLoginInput{Email: email, Code: "654321"},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(parent, nil)
+				mockPasswordlessLoginRepo.EXPECT().FindByUserID(ctx, parent.ID).Return(pendingLogin, nil).Once()
+			},
+		},
+		{
+			name:        "failed to delete pending login",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(parent, nil)
+				mockPasswordlessLoginRepo.EXPECT().FindByUserID(ctx, parent.ID).Return(pendingLogin, nil).Once()
+				mockPasswordlessLoginRepo.EXPECT().DeleteByUserID(ctx, parent.ID).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:        "account deactivated after the code was sent",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(&model.User{ID: parent.ID, IsActive: false, Roles: model.RolesParent}, nil)
+				mockPasswordlessLoginRepo.EXPECT().FindByUserID(ctx, parent.ID).Return(pendingLogin, nil).Once()
+				mockPasswordlessLoginRepo.EXPECT().DeleteByUserID(ctx, parent.ID).Return(nil).Once()
+			},
+		},
+		{
+			name:        "failed to create session",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(parent, nil)
+				mockPasswordlessLoginRepo.EXPECT().FindByUserID(ctx, parent.ID).Return(pendingLogin, nil).Once()
+				mockPasswordlessLoginRepo.EXPECT().DeleteByUserID(ctx, parent.ID).Return(nil).Once()
+				mockSessionRepo.EXPECT().Create(ctx, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok",
+			input:   validInput,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				expectUntilUserFound(parent, nil)
+				mockPasswordlessLoginRepo.EXPECT().FindByUserID(ctx, parent.ID).Return(pendingLogin, nil).Once()
+				mockPasswordlessLoginRepo.EXPECT().DeleteByUserID(ctx, parent.ID).Return(nil).Once()
+				mockSessionRepo.EXPECT().Create(ctx, mock.MatchedBy(func(input usecase.RepoCreateSessionInput) bool {
+					return input.UserID == parent.ID
+				})).Return(&model.Session{ID: uuid.New()}, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.MatchedBy(func(claims model.LoginTokenClaims) bool {
+					return claims.Role == model.RolesParent && claims.Audience[0] == parent.ID.String()
+				})).Return("loginToken", nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleVerifyPasswordlessLogin(ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, "loginToken", res.Token)
+				assert.NotEmpty(t, res.RefreshToken)
+
+				return
+			}
+
+			assert.Nil(t, res)
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthUsecase_HandleMagicLinkLogin(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+	mockPasswordlessLoginRepo := mockUsecase.NewPasswordlessLoginRepository(t)
+	uc := usecase.NewAuthUsecase(
+		mockSharedCryptor, mockUserRepo, nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, mockPasswordlessLoginRepo, nil, nil,
+	)
+
+	secret := "magic-link-secret"
+	parent := &model.User{
+		ID:       uuid.New(),
+		IsActive: true,
+		Roles:    model.RolesParent,
+	}
+	pendingLogin := &model.PasswordlessLogin{
+		UserID:        parent.ID,
+		OTPHash:       common.HashToken("123456"),
+		MagicLinkHash: common.HashToken(secret),
+	}
+	validInput := usecase.MagicLinkLoginInput{MagicLinkToken: parent.ID.String() + "." + secret}
+
+	testCases := []struct {
+		name                 string
+		input                usecase.MagicLinkLoginInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "empty token",
+			input:       usecase.MagicLinkLoginInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "malformed token",
+			input:       usecase.MagicLinkLoginInput{MagicLinkToken: "not-a-magic-link"},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "user not found",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(ctx, parent.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "failed to find user",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(ctx, parent.ID).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "magic link already used or expired",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(ctx, parent.ID).Return(parent, nil).Once()
+				mockPasswordlessLoginRepo.EXPECT().FindByUserID(ctx, parent.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "wrong secret",
+			input:       usecase.MagicLinkLoginInput{MagicLinkToken: parent.ID.String() + ".wrong-secret"},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(ctx, parent.ID).Return(parent, nil).Once()
+				mockPasswordlessLoginRepo.EXPECT().FindByUserID(ctx, parent.ID).Return(pendingLogin, nil).Once()
+			},
+		},
+		{
+			name:    "ok",
+			input:   validInput,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(ctx, parent.ID).Return(parent, nil).Once()
+				mockPasswordlessLoginRepo.EXPECT().FindByUserID(ctx, parent.ID).Return(pendingLogin, nil).Once()
+				mockPasswordlessLoginRepo.EXPECT().DeleteByUserID(ctx, parent.ID).Return(nil).Once()
+				mockSessionRepo.EXPECT().Create(ctx, mock.Anything).Return(&model.Session{ID: uuid.New()}, nil).Once()
+				mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("loginToken", nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleMagicLinkLogin(ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, "loginToken", res.Token)
+				assert.NotEmpty(t, res.RefreshToken)
+
+				return
+			}
+
+			assert.Nil(t, res)
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
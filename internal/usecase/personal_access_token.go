@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// personalAccessTokenByteLength is the number of random bytes used to generate each personal access token
+const personalAccessTokenByteLength = 32
+
+// isPersonalAccessTokenSupportedRole report whether the role is allowed to create personal access tokens
+func isPersonalAccessTokenSupportedRole(role model.Roles) bool {
+	return IsAllowed(role, ActionUsePersonalAccessToken, RelationNone)
+}
+
+// CreatePersonalAccessTokenInput input
+type CreatePersonalAccessTokenInput struct {
+	Name          string                           `validate:"required,max=100"`
+	Scopes        []model.PersonalAccessTokenScope `validate:"required,min=1,unique,dive,oneof=packages children questionnaires users admin"`
+	ExpiresInDays int                              `validate:"required,min=1,max=365"`
+}
+
+func (cpati CreatePersonalAccessTokenInput) validate() error {
+	return common.Validator.Struct(cpati)
+}
+
+// PersonalAccessTokenOutput the personal access token detail, excluding the token itself
+type PersonalAccessTokenOutput struct {
+	ID         uuid.UUID
+	Name       string
+	Scopes     model.PersonalAccessTokenScopes
+	ExpiresAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+func newPersonalAccessTokenOutput(token model.PersonalAccessToken) PersonalAccessTokenOutput {
+	output := PersonalAccessTokenOutput{
+		ID:        token.ID,
+		Name:      token.Name,
+		Scopes:    token.Scopes,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}
+
+	if token.LastUsedAt.Valid {
+		output.LastUsedAt = &token.LastUsedAt.Time
+	}
+
+	if token.RevokedAt.Valid {
+		output.RevokedAt = &token.RevokedAt.Time
+	}
+
+	return output
+}
+
+// CreatePersonalAccessTokenOutput output
+type CreatePersonalAccessTokenOutput struct {
+	PersonalAccessTokenOutput
+	Token string
+}
+
+// HandleCreatePersonalAccessToken create a new personal access token for the requester to be used by integrations.
+// The token is only returned once, because only its hash is stored. Only therapist and administrator are allowed
+// to create the token, and the admin scope can only be granted by administrator
+func (u *AuthUsecase) HandleCreatePersonalAccessToken(
+	ctx context.Context, input CreatePersonalAccessTokenInput,
+) (*CreatePersonalAccessTokenOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if err := Authorize(requester, ActionUsePersonalAccessToken, RelationNone); err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	scopes := model.PersonalAccessTokenScopes(input.Scopes)
+	if scopes.Has(model.PersonalAccessTokenScopeAdmin) && !IsAllowed(requester.Role, ActionGrantAdminScope, RelationNone) {
+		return nil, UsecaseError{
+			ErrType: ErrForbidden,
+			Message: "admin scope can only be granted by administrator",
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithField("user-id", requester.ID)
+
+	secret, err := common.GenerateSecureToken(personalAccessTokenByteLength)
+	if err != nil {
+		logger.WithError(err).Error("failed to generate personal access token")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	token := model.PersonalAccessTokenPrefix + secret
+
+	created, err := u.personalAccessTokenRepo.Create(ctx, RepoCreatePersonalAccessTokenInput{
+		UserID:    requester.ID,
+		Name:      input.Name,
+		TokenHash: common.HashToken(token),
+		Scopes:    scopes,
+		ExpiresAt: time.Now().AddDate(0, 0, input.ExpiresInDays),
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to create personal access token")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return &CreatePersonalAccessTokenOutput{
+		PersonalAccessTokenOutput: newPersonalAccessTokenOutput(*created),
+		Token:                     token,
+	}, nil
+}
+
+// HandleListPersonalAccessTokens list all the requester's personal access tokens, including the revoked and expired ones
+func (u *AuthUsecase) HandleListPersonalAccessTokens(ctx context.Context) ([]PersonalAccessTokenOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	tokens, err := u.personalAccessTokenRepo.FindByUserID(ctx, requester.ID)
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).WithField("user-id", requester.ID).Error("failed to find personal access tokens")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	output := make([]PersonalAccessTokenOutput, 0, len(tokens))
+	for _, token := range tokens {
+		output = append(output, newPersonalAccessTokenOutput(token))
+	}
+
+	return output, nil
+}
+
+// RevokePersonalAccessTokenInput input
+type RevokePersonalAccessTokenInput struct {
+	TokenID uuid.UUID `validate:"required"`
+}
+
+func (rpati RevokePersonalAccessTokenInput) validate() error {
+	return common.Validator.Struct(rpati)
+}
+
+// RevokePersonalAccessTokenOutput output
+type RevokePersonalAccessTokenOutput struct {
+	Message string
+}
+
+// HandleRevokePersonalAccessToken revoke one of the requester's personal access tokens, making it no longer usable
+func (u *AuthUsecase) HandleRevokePersonalAccessToken(
+	ctx context.Context, input RevokePersonalAccessTokenInput,
+) (*RevokePersonalAccessTokenOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	err := u.personalAccessTokenRepo.Revoke(ctx, input.TokenID, requester.ID)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).WithField("token-id", input.TokenID).Error("failed to revoke personal access token")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: "personal access token not found or already revoked",
+		}
+	case nil:
+		break
+	}
+
+	return &RevokePersonalAccessTokenOutput{
+		Message: "personal access token revoked",
+	}, nil
+}
+
+// authenticatePersonalAccessToken authenticate the request made using a personal access token. The token is only
+// accepted on endpoints requiring one of its scopes, and only while its owner is still an active and unlocked user.
+// The role is taken from the owner's current data, so a demoted user can no longer use the previous privileges
+func (u *AuthUsecase) authenticatePersonalAccessToken(
+	ctx context.Context, input AuthenticateAccessTokenInput,
+) (*AuthenticateAccessTokenOutput, error) {
+	if input.Scope == "" {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "personal access token can not be used on this endpoint",
+		}
+	}
+
+	token, err := u.personalAccessTokenRepo.FindByTokenHash(ctx, common.HashToken(input.Token))
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).Error("failed to find personal access token by hash")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "invalid personal access token",
+		}
+	case nil:
+		break
+	}
+
+	if !token.IsActive() {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "personal access token has been revoked or expired",
+		}
+	}
+
+	if !token.Scopes.Has(input.Scope) {
+		return nil, UsecaseError{
+			ErrType: ErrForbidden,
+			Message: "personal access token is missing the required scope: " + string(input.Scope),
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithField("token-id", token.ID).WithField("user-id", token.UserID)
+
+	user, err := u.userRepo.FindByID(ctx, token.UserID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by id")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "invalid personal access token",
+		}
+	case nil:
+		break
+	}
+
+	if !user.IsActive || user.IsLocked() || !isPersonalAccessTokenSupportedRole(user.Roles) {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: "the personal access token owner is no longer allowed to use it",
+		}
+	}
+
+	// only used to help the user to identify unused tokens, thus failure should not block the request
+	if err := u.personalAccessTokenRepo.UpdateLastUsedAt(ctx, token.ID); err != nil {
+		logger.WithError(err).Error("failed to update personal access token last used at")
+	}
+
+	return &AuthenticateAccessTokenOutput{
+		UserID:                user.ID,
+		UserRole:              user.Roles,
+		PersonalAccessTokenID: token.ID,
+		OrganizationID:        user.OrganizationID.UUID,
+		IsOrganizationAdmin:   user.OrganizationID.Valid && user.IsOrganizationAdmin,
+	}, nil
+}
@@ -0,0 +1,552 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthUsecase_HandleCreatePersonalAccessToken(t *testing.T) {
+	ctx := context.Background()
+
+	mockPersonalAccessTokenRepo := mockUsecase.NewPersonalAccessTokenRepository(t)
+
+	uc := usecase.NewAuthUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockPersonalAccessTokenRepo, nil)
+
+	therapist := model.AuthUser{ID: uuid.New(), Role: model.RolesTherapist, SessionID: uuid.New()}
+	therapistCtx := model.SetUserToCtx(ctx, therapist)
+	admin := model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator, SessionID: uuid.New()}
+	adminCtx := model.SetUserToCtx(ctx, admin)
+	parentCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesParent, SessionID: uuid.New()})
+
+	validInput := usecase.CreatePersonalAccessTokenInput{
+		Name:          "report script",
+		Scopes:        []model.PersonalAccessTokenScope{model.PersonalAccessTokenScopeChildren},
+		ExpiresInDays: 30,
+	}
+
+	matchCreateInput := func(userID uuid.UUID, scopes model.PersonalAccessTokenScopes) interface{} {
+		return mock.MatchedBy(func(input usecase.RepoCreatePersonalAccessTokenInput) bool {
+			expectedExpiry := time.Now().AddDate(0, 0, validInput.ExpiresInDays)
+
+			return input.UserID == userID && input.Name == validInput.Name && assert.ObjectsAreEqual(scopes, input.Scopes) &&
+				input.TokenHash != "" && input.ExpiresAt.Sub(expectedExpiry).Abs() < time.Minute
+		})
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.CreatePersonalAccessTokenInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "parent is not allowed",
+			ctx:         parentCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:        "missing name",
+			ctx:         therapistCtx,
+			input:       usecase.CreatePersonalAccessTokenInput{Scopes: validInput.Scopes, ExpiresInDays: 30},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "empty scopes",
+			ctx:         therapistCtx,
+			input:       usecase.CreatePersonalAccessTokenInput{Name: "script", ExpiresInDays: 30},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name: "unknown scope",
+			ctx:  therapistCtx,
+			input: usecase.CreatePersonalAccessTokenInput{
+				Name: "script", Scopes: []model.PersonalAccessTokenScope{"unknown"}, ExpiresInDays: 30,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name: "duplicate scope",
+			ctx:  therapistCtx,
+			input: usecase.CreatePersonalAccessTokenInput{
+				Name:          "script",
+				Scopes:        []model.PersonalAccessTokenScope{model.PersonalAccessTokenScopeUsers, model.PersonalAccessTokenScopeUsers},
+				ExpiresInDays: 30,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name: "expiry too long",
+			ctx:  therapistCtx,
+			input: usecase.CreatePersonalAccessTokenInput{
+				Name: "script", Scopes: validInput.Scopes, ExpiresInDays: 366,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name: "therapist can not grant admin scope",
+			ctx:  therapistCtx,
+			input: usecase.CreatePersonalAccessTokenInput{
+				Name: "script", Scopes: []model.PersonalAccessTokenScope{model.PersonalAccessTokenScopeAdmin}, ExpiresInDays: 30,
+			},
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:        "failed to store the token",
+			ctx:         therapistCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().Create(therapistCtx, matchCreateInput(therapist.ID, model.PersonalAccessTokenScopes(validInput.Scopes))).
+					Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok - therapist",
+			ctx:     therapistCtx,
+			input:   validInput,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().Create(therapistCtx, matchCreateInput(therapist.ID, model.PersonalAccessTokenScopes(validInput.Scopes))).
+					RunAndReturn(func(_ context.Context, input usecase.RepoCreatePersonalAccessTokenInput) (*model.PersonalAccessToken, error) {
+						return &model.PersonalAccessToken{
+							ID: uuid.New(), UserID: input.UserID, Name: input.Name, TokenHash: input.TokenHash,
+							Scopes: input.Scopes, ExpiresAt: input.ExpiresAt,
+						}, nil
+					}).Once()
+			},
+		},
+		{
+			name: "ok - administrator granting admin scope",
+			ctx:  adminCtx,
+			input: usecase.CreatePersonalAccessTokenInput{
+				Name:          validInput.Name,
+				Scopes:        []model.PersonalAccessTokenScope{model.PersonalAccessTokenScopeAdmin},
+				ExpiresInDays: validInput.ExpiresInDays,
+			},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				scopes := model.PersonalAccessTokenScopes{model.PersonalAccessTokenScopeAdmin}
+				mockPersonalAccessTokenRepo.EXPECT().Create(adminCtx, matchCreateInput(admin.ID, scopes)).
+					RunAndReturn(func(_ context.Context, input usecase.RepoCreatePersonalAccessTokenInput) (*model.PersonalAccessToken, error) {
+						return &model.PersonalAccessToken{
+							ID: uuid.New(), UserID: input.UserID, Name: input.Name, TokenHash: input.TokenHash,
+							Scopes: input.Scopes, ExpiresAt: input.ExpiresAt,
+						}, nil
+					}).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleCreatePersonalAccessToken(tc.ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.True(t, strings.HasPrefix(res.Token, model.PersonalAccessTokenPrefix))
+				assert.Equal(t, tc.input.Name, res.Name)
+				assert.Equal(t, model.PersonalAccessTokenScopes(tc.input.Scopes), res.Scopes)
+
+				return
+			}
+
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthUsecase_HandleListPersonalAccessTokens(t *testing.T) {
+	ctx := context.Background()
+
+	mockPersonalAccessTokenRepo := mockUsecase.NewPersonalAccessTokenRepository(t)
+
+	uc := usecase.NewAuthUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockPersonalAccessTokenRepo, nil)
+
+	user := model.AuthUser{ID: uuid.New(), Role: model.RolesTherapist, SessionID: uuid.New()}
+	userCtx := model.SetUserToCtx(ctx, user)
+
+	lastUsedAt := time.Now().Add(-time.Hour)
+	tokens := []model.PersonalAccessToken{
+		{
+			ID:         uuid.New(),
+			UserID:     user.ID,
+			Name:       "active",
+			Scopes:     model.PersonalAccessTokenScopes{model.PersonalAccessTokenScopeChildren},
+			ExpiresAt:  time.Now().Add(time.Hour),
+			LastUsedAt: sql.NullTime{Time: lastUsedAt, Valid: true},
+		},
+		{
+			ID:        uuid.New(),
+			UserID:    user.ID,
+			Name:      "revoked",
+			ExpiresAt: time.Now().Add(time.Hour),
+			RevokedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		},
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "failed to find the tokens",
+			ctx:         userCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().FindByUserID(userCtx, user.ID).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok",
+			ctx:     userCtx,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().FindByUserID(userCtx, user.ID).Return(tokens, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleListPersonalAccessTokens(tc.ctx)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				require.Len(t, res, 2)
+				assert.Equal(t, tokens[0].ID, res[0].ID)
+				assert.Equal(t, &lastUsedAt, res[0].LastUsedAt)
+				assert.Nil(t, res[0].RevokedAt)
+				assert.Nil(t, res[1].LastUsedAt)
+				assert.NotNil(t, res[1].RevokedAt)
+
+				return
+			}
+
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthUsecase_HandleRevokePersonalAccessToken(t *testing.T) {
+	ctx := context.Background()
+
+	mockPersonalAccessTokenRepo := mockUsecase.NewPersonalAccessTokenRepository(t)
+
+	uc := usecase.NewAuthUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockPersonalAccessTokenRepo, nil)
+
+	user := model.AuthUser{ID: uuid.New(), Role: model.RolesTherapist, SessionID: uuid.New()}
+	userCtx := model.SetUserToCtx(ctx, user)
+	tokenID := uuid.New()
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.RevokePersonalAccessTokenInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       usecase.RevokePersonalAccessTokenInput{TokenID: tokenID},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "missing token id",
+			ctx:         userCtx,
+			input:       usecase.RevokePersonalAccessTokenInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "not found, owned by other user or already revoked",
+			ctx:         userCtx,
+			input:       usecase.RevokePersonalAccessTokenInput{TokenID: tokenID},
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().Revoke(userCtx, tokenID, user.ID).Return(usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "failed to revoke",
+			ctx:         userCtx,
+			input:       usecase.RevokePersonalAccessTokenInput{TokenID: tokenID},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().Revoke(userCtx, tokenID, user.ID).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok",
+			ctx:     userCtx,
+			input:   usecase.RevokePersonalAccessTokenInput{TokenID: tokenID},
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().Revoke(userCtx, tokenID, user.ID).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleRevokePersonalAccessToken(tc.ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Message)
+
+				return
+			}
+
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthUsecase_AuthenticateAccessToken_PersonalAccessToken(t *testing.T) {
+	ctx := context.Background()
+
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockPersonalAccessTokenRepo := mockUsecase.NewPersonalAccessTokenRepository(t)
+
+	uc := usecase.NewAuthUsecase(nil, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockPersonalAccessTokenRepo, nil)
+
+	token := model.PersonalAccessTokenPrefix + "secret"
+	tokenHash := common.HashToken(token)
+
+	user := &model.User{
+		ID:       uuid.New(),
+		IsActive: true,
+		Roles:    model.RolesTherapist,
+	}
+
+	activeToken := &model.PersonalAccessToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		TokenHash: tokenHash,
+		Scopes:    model.PersonalAccessTokenScopes{model.PersonalAccessTokenScopeChildren},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	validInput := usecase.AuthenticateAccessTokenInput{
+		Token: token,
+		Scope: model.PersonalAccessTokenScopeChildren,
+	}
+
+	testCases := []struct {
+		name                 string
+		input                usecase.AuthenticateAccessTokenInput
+		wantErr              bool
+		expectedErr          error
+		expectedOutput       *usecase.AuthenticateAccessTokenOutput
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "endpoint only accept the login token",
+			input:       usecase.AuthenticateAccessTokenInput{Token: token},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "token not found",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().FindByTokenHash(ctx, tokenHash).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "failed to find the token",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().FindByTokenHash(ctx, tokenHash).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "token revoked",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				revoked := *activeToken
+				revoked.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				mockPersonalAccessTokenRepo.EXPECT().FindByTokenHash(ctx, tokenHash).Return(&revoked, nil).Once()
+			},
+		},
+		{
+			name:        "token expired",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				expired := *activeToken
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				mockPersonalAccessTokenRepo.EXPECT().FindByTokenHash(ctx, tokenHash).Return(&expired, nil).Once()
+			},
+		},
+		{
+			name:        "missing the required scope",
+			input:       usecase.AuthenticateAccessTokenInput{Token: token, Scope: model.PersonalAccessTokenScopeAdmin},
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().FindByTokenHash(ctx, tokenHash).Return(activeToken, nil).Once()
+			},
+		},
+		{
+			name:        "failed to find the owner",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().FindByTokenHash(ctx, tokenHash).Return(activeToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "owner is deactivated",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().FindByTokenHash(ctx, tokenHash).Return(activeToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(&model.User{
+					ID: user.ID, IsActive: false, Roles: model.RolesTherapist,
+				}, nil).Once()
+			},
+		},
+		{
+			name:        "owner is locked",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().FindByTokenHash(ctx, tokenHash).Return(activeToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(&model.User{
+					ID: user.ID, IsActive: true, Roles: model.RolesTherapist,
+					LockedUntil: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+				}, nil).Once()
+			},
+		},
+		{
+			name:        "owner is demoted to parent",
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().FindByTokenHash(ctx, tokenHash).Return(activeToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(&model.User{
+					ID: user.ID, IsActive: true, Roles: model.RolesParent,
+				}, nil).Once()
+			},
+		},
+		{
+			name:    "ok even when failed to record the last usage",
+			input:   validInput,
+			wantErr: false,
+			expectedOutput: &usecase.AuthenticateAccessTokenOutput{
+				UserID:                user.ID,
+				UserRole:              model.RolesTherapist,
+				PersonalAccessTokenID: activeToken.ID,
+			},
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().FindByTokenHash(ctx, tokenHash).Return(activeToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(user, nil).Once()
+				mockPersonalAccessTokenRepo.EXPECT().UpdateLastUsedAt(ctx, activeToken.ID).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:    "ok",
+			input:   validInput,
+			wantErr: false,
+			expectedOutput: &usecase.AuthenticateAccessTokenOutput{
+				UserID:                user.ID,
+				UserRole:              model.RolesTherapist,
+				PersonalAccessTokenID: activeToken.ID,
+			},
+			expectedFunctionCall: func() {
+				mockPersonalAccessTokenRepo.EXPECT().FindByTokenHash(ctx, tokenHash).Return(activeToken, nil).Once()
+				mockUserRepo.EXPECT().FindByID(ctx, user.ID).Return(user, nil).Once()
+				mockPersonalAccessTokenRepo.EXPECT().UpdateLastUsedAt(ctx, activeToken.ID).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.AuthenticateAccessToken(ctx, tc.input)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedOutput, res)
+
+				return
+			}
+
+			assertUsecaseErrType(t, tc.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+)
+
+// Action the operation the requester wants to perform, checked against the policy matrix
+type Action string
+
+// known actions
+const (
+	ActionRegisterChild      Action = "child:register"
+	ActionUpdateChild        Action = "child:update"
+	ActionSearchChild        Action = "child:search"
+	ActionReadChildStatistic Action = "child:read_statistic"
+	ActionManageCareAccess   Action = "child:manage_care_access"
+	ActionReceiveCareAccess  Action = "child:receive_care_access"
+	ActionManageGuardian     Action = "child:manage_guardian"
+	ActionListGuardian       Action = "child:list_guardian"
+	ActionLeaveGuardianship  Action = "child:leave_guardianship"
+	ActionAcceptGuardianship Action = "child:accept_guardianship"
+	ActionTransferChild      Action = "child:transfer"
+	ActionAcceptTransfer     Action = "child:accept_transfer"
+	ActionArchiveChild       Action = "child:archive"
+	ActionDeleteChild        Action = "child:delete"
+
+	ActionSearchOrganizationChild Action = "child:search_organization"
+
+	ActionSubmitChildResult        Action = "result:submit_for_child"
+	ActionReadResult               Action = "result:read"
+	ActionSearchResult             Action = "result:search"
+	ActionSearchOrganizationResult Action = "result:search_organization"
+
+	ActionManagePackage          Action = "package:manage"
+	ActionUseOrganizationPackage Action = "package:use_organization"
+
+	ActionManageOrganization Action = "organization:manage"
+	ActionJoinOrganization   Action = "organization:join"
+
+	ActionManageUser    Action = "user:manage"
+	ActionDeleteAccount Action = "user:delete_account"
+	ActionExportData    Action = "user:export_data"
+
+	ActionUseMFA            Action = "user:use_mfa"
+	ActionPasswordlessLogin Action = "user:passwordless_login"
+
+	ActionReadAuditLog Action = "audit_log:read"
+
+	ActionUsePersonalAccessToken Action = "personal_access_token:use"
+	ActionGrantAdminScope        Action = "personal_access_token:grant_admin_scope"
+)
+
+// Relation the relation between the requester and the resource being accessed
+type Relation int
+
+// known relations
+const (
+	// RelationNone the requester has no relation to the resource, or the action does not target any resource
+	RelationNone Relation = iota
+	// RelationOwner the requester owns the resource, e.g. the parent of the child or the creator of the result
+	RelationOwner
+	// RelationCareReader the requester is a therapist assigned to the child with read access
+	RelationCareReader
+	// RelationCareSubmitter the requester is a therapist assigned to the child with submit access, which includes read access
+	RelationCareSubmitter
+	// RelationOrganizationAdmin the requester administers the organization owning the resource
+	RelationOrganizationAdmin
+	// RelationGuardian the requester shares the child with the owner by accepting the owner's invitation
+	RelationGuardian
+	// RelationOrganizationMember the requester is a member of the organization owning the resource
+	RelationOrganizationMember
+)
+
+// ownerRelation return RelationOwner if the requester is the resource owner
+func ownerRelation(requester *model.AuthUser, ownerID uuid.UUID) Relation {
+	if requester != nil && ownerID != uuid.Nil && requester.ID == ownerID {
+		return RelationOwner
+	}
+
+	return RelationNone
+}
+
+// organizationAdminRelation return RelationOrganizationAdmin if the requester administers the organization
+func organizationAdminRelation(requester *model.AuthUser, organizationID uuid.UUID) Relation {
+	if requester != nil && requester.IsOrganizationAdmin && organizationID != uuid.Nil && requester.OrganizationID == organizationID {
+		return RelationOrganizationAdmin
+	}
+
+	return RelationNone
+}
+
+// organizationMemberRelation return RelationOrganizationMember if the requester is a member of the organization
+func organizationMemberRelation(requester *model.AuthUser, organizationID uuid.UUID) Relation {
+	if requester != nil && organizationID != uuid.Nil && requester.OrganizationID == organizationID {
+		return RelationOrganizationMember
+	}
+
+	return RelationNone
+}
+
+var allRoles = []model.Roles{model.RolesAdministrator, model.RolesTherapist, model.RolesParent}
+
+// policyRule list which roles are allowed to perform an action
+type policyRule struct {
+	// roles allowed regardless of their relation to the resource
+	roles []model.Roles
+	// roles allowed only if they own the resource
+	ownerRoles []model.Roles
+	// roles allowed only if they are assigned to the child, regardless of the care scope
+	careReaderRoles []model.Roles
+	// roles allowed only if they are assigned to the child with submit access
+	careSubmitterRoles []model.Roles
+	// roles allowed only if they administer the organization owning the resource
+	organizationAdminRoles []model.Roles
+	// roles allowed only if they are the guardian of the child
+	guardianRoles []model.Roles
+	// roles allowed only if they are a member of the organization owning the resource
+	organizationMemberRoles []model.Roles
+}
+
+// policies the whole authorization matrix. Action not listed here is denied for everyone
+var policies = map[Action]policyRule{
+	ActionRegisterChild: {roles: allRoles},
+	ActionUpdateChild:   {ownerRoles: allRoles},
+	ActionSearchChild:   {roles: []model.Roles{model.RolesTherapist, model.RolesAdministrator}},
+	ActionReadChildStatistic: {
+		ownerRoles:      allRoles,
+		guardianRoles:   allRoles,
+		careReaderRoles: []model.Roles{model.RolesTherapist},
+	},
+	ActionManageCareAccess:   {ownerRoles: allRoles},
+	ActionReceiveCareAccess:  {roles: []model.Roles{model.RolesTherapist}},
+	ActionManageGuardian:     {ownerRoles: allRoles},
+	ActionListGuardian:       {ownerRoles: allRoles, guardianRoles: allRoles},
+	ActionLeaveGuardianship:  {guardianRoles: allRoles},
+	ActionAcceptGuardianship: {roles: allRoles},
+	ActionTransferChild:      {ownerRoles: allRoles},
+	ActionAcceptTransfer:     {roles: allRoles},
+	ActionArchiveChild:       {ownerRoles: allRoles},
+	ActionDeleteChild:        {ownerRoles: allRoles},
+	ActionSearchOrganizationChild: {
+		roles:                  []model.Roles{model.RolesAdministrator},
+		organizationAdminRoles: []model.Roles{model.RolesTherapist},
+	},
+
+	ActionSubmitChildResult: {
+		ownerRoles:         allRoles,
+		guardianRoles:      allRoles,
+		careSubmitterRoles: []model.Roles{model.RolesTherapist},
+	},
+	ActionReadResult: {
+		roles:           []model.Roles{model.RolesAdministrator},
+		ownerRoles:      allRoles,
+		guardianRoles:   allRoles,
+		careReaderRoles: []model.Roles{model.RolesTherapist},
+	},
+	ActionSearchResult: {roles: []model.Roles{model.RolesTherapist, model.RolesAdministrator}},
+	ActionSearchOrganizationResult: {
+		roles:                  []model.Roles{model.RolesAdministrator},
+		organizationAdminRoles: []model.Roles{model.RolesTherapist},
+	},
+
+	ActionManagePackage: {
+		roles:                  []model.Roles{model.RolesAdministrator},
+		organizationAdminRoles: []model.Roles{model.RolesTherapist},
+	},
+	ActionUseOrganizationPackage: {
+		roles:                   []model.Roles{model.RolesAdministrator},
+		organizationMemberRoles: allRoles,
+	},
+
+	ActionManageOrganization: {roles: []model.Roles{model.RolesAdministrator}},
+	ActionJoinOrganization:   {roles: []model.Roles{model.RolesTherapist}},
+
+	ActionManageUser:    {roles: []model.Roles{model.RolesAdministrator}},
+	ActionDeleteAccount: {roles: []model.Roles{model.RolesParent}},
+	ActionExportData:    {roles: []model.Roles{model.RolesParent}},
+
+	ActionUseMFA:            {roles: []model.Roles{model.RolesTherapist, model.RolesAdministrator}},
+	ActionPasswordlessLogin: {roles: []model.Roles{model.RolesParent}},
+
+	ActionReadAuditLog: {roles: []model.Roles{model.RolesAdministrator}},
+
+	ActionUsePersonalAccessToken: {roles: []model.Roles{model.RolesTherapist, model.RolesAdministrator}},
+	ActionGrantAdminScope:        {roles: []model.Roles{model.RolesAdministrator}},
+}
+
+// IsAllowed report whether the role having the relation to the resource is allowed to perform the action
+func IsAllowed(role model.Roles, action Action, relation Relation) bool {
+	rule, ok := policies[action]
+	if !ok {
+		return false
+	}
+
+	if slices.Contains(rule.roles, role) {
+		return true
+	}
+
+	switch relation {
+	default:
+		return false
+	case RelationOwner:
+		return slices.Contains(rule.ownerRoles, role)
+	case RelationCareReader:
+		return slices.Contains(rule.careReaderRoles, role)
+	case RelationCareSubmitter:
+		return slices.Contains(rule.careReaderRoles, role) || slices.Contains(rule.careSubmitterRoles, role)
+	case RelationOrganizationAdmin:
+		return slices.Contains(rule.organizationAdminRoles, role)
+	case RelationGuardian:
+		return slices.Contains(rule.guardianRoles, role)
+	case RelationOrganizationMember:
+		return slices.Contains(rule.organizationMemberRoles, role)
+	}
+}
+
+// Authorize check the requester against the policy matrix. Will return ErrUnauthorized if there is no requester,
+// and ErrForbidden if the requester is not allowed to perform the action
+func Authorize(requester *model.AuthUser, action Action, relation Relation) error {
+	if requester == nil {
+		return UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	if !IsAllowed(requester.Role, action, relation) {
+		return UsecaseError{
+			ErrType: ErrForbidden,
+			Message: "insufficient permission to access this feature",
+		}
+	}
+
+	return nil
+}
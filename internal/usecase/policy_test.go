@@ -0,0 +1,336 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_IsAllowed(t *testing.T) {
+	admin := model.RolesAdministrator
+	therapist := model.RolesTherapist
+	parent := model.RolesParent
+
+	// each action is checked for every role, as the resource owner, as unrelated user,
+	// as the therapist assigned to the child with read or submit access, as the admin or a member of the
+	// organization owning the resource, and as the child's guardian. Role allowed as unrelated user
+	// is also expected to be allowed when related to the resource
+	type expectation struct {
+		role        model.Roles
+		asOwner     bool
+		asOthers    bool
+		asReader    bool
+		asSubmitter bool
+		asOrgAdmin  bool
+		asOrgMember bool
+		asGuardian  bool
+	}
+
+	testCases := []struct {
+		action       usecase.Action
+		expectations []expectation
+	}{
+		{
+			action: usecase.ActionRegisterChild,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: true, asOthers: true},
+				{role: parent, asOwner: true, asOthers: true},
+			},
+		},
+		{
+			action: usecase.ActionUpdateChild,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: false},
+				{role: therapist, asOwner: true, asOthers: false},
+				{role: parent, asOwner: true, asOthers: false},
+			},
+		},
+		{
+			action: usecase.ActionSearchChild,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: true, asOthers: true},
+				{role: parent, asOwner: false, asOthers: false},
+			},
+		},
+		{
+			action: usecase.ActionReadChildStatistic,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: false, asGuardian: true},
+				{role: therapist, asOwner: true, asOthers: false, asReader: true, asSubmitter: true, asGuardian: true},
+				{role: parent, asOwner: true, asOthers: false, asGuardian: true},
+			},
+		},
+		{
+			action: usecase.ActionManageCareAccess,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: false},
+				{role: therapist, asOwner: true, asOthers: false},
+				{role: parent, asOwner: true, asOthers: false},
+			},
+		},
+		{
+			action: usecase.ActionReceiveCareAccess,
+			expectations: []expectation{
+				{role: admin, asOwner: false, asOthers: false},
+				{role: therapist, asOwner: true, asOthers: true},
+				{role: parent, asOwner: false, asOthers: false},
+			},
+		},
+		{
+			action: usecase.ActionManageGuardian,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: false},
+				{role: therapist, asOwner: true, asOthers: false},
+				{role: parent, asOwner: true, asOthers: false},
+			},
+		},
+		{
+			action: usecase.ActionListGuardian,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: false, asGuardian: true},
+				{role: therapist, asOwner: true, asOthers: false, asGuardian: true},
+				{role: parent, asOwner: true, asOthers: false, asGuardian: true},
+			},
+		},
+		{
+			action: usecase.ActionLeaveGuardianship,
+			expectations: []expectation{
+				{role: admin, asOwner: false, asOthers: false, asGuardian: true},
+				{role: therapist, asOwner: false, asOthers: false, asGuardian: true},
+				{role: parent, asOwner: false, asOthers: false, asGuardian: true},
+			},
+		},
+		{
+			action: usecase.ActionAcceptGuardianship,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: true, asOthers: true},
+				{role: parent, asOwner: true, asOthers: true},
+			},
+		},
+		{
+			action: usecase.ActionTransferChild,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: false},
+				{role: therapist, asOwner: true, asOthers: false, asReader: false, asSubmitter: false, asGuardian: false},
+				{role: parent, asOwner: true, asOthers: false, asGuardian: false},
+			},
+		},
+		{
+			action: usecase.ActionAcceptTransfer,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: true, asOthers: true},
+				{role: parent, asOwner: true, asOthers: true},
+			},
+		},
+		{
+			action: usecase.ActionArchiveChild,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: false},
+				{role: therapist, asOwner: true, asOthers: false, asReader: false, asSubmitter: false, asGuardian: false},
+				{role: parent, asOwner: true, asOthers: false, asGuardian: false},
+			},
+		},
+		{
+			action: usecase.ActionDeleteChild,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: false},
+				{role: therapist, asOwner: true, asOthers: false, asReader: false, asSubmitter: false, asGuardian: false},
+				{role: parent, asOwner: true, asOthers: false, asGuardian: false},
+			},
+		},
+		{
+			action: usecase.ActionSearchOrganizationChild,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: false, asOthers: false, asOrgAdmin: true},
+				{role: parent, asOwner: false, asOthers: false},
+			},
+		},
+		{
+			action: usecase.ActionSubmitChildResult,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: false, asGuardian: true},
+				{role: therapist, asOwner: true, asOthers: false, asReader: false, asSubmitter: true, asGuardian: true},
+				{role: parent, asOwner: true, asOthers: false, asGuardian: true},
+			},
+		},
+		{
+			action: usecase.ActionReadResult,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: true, asOthers: false, asReader: true, asSubmitter: true, asGuardian: true},
+				{role: parent, asOwner: true, asOthers: false, asGuardian: true},
+			},
+		},
+		{
+			action: usecase.ActionSearchResult,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: true, asOthers: true},
+				{role: parent, asOwner: false, asOthers: false},
+			},
+		},
+		{
+			action: usecase.ActionSearchOrganizationResult,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: false, asOthers: false, asOrgAdmin: true},
+				{role: parent, asOwner: false, asOthers: false},
+			},
+		},
+		{
+			action: usecase.ActionManagePackage,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: false, asOthers: false, asOrgAdmin: true},
+				{role: parent, asOwner: false, asOthers: false},
+			},
+		},
+		{
+			action: usecase.ActionUseOrganizationPackage,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: false, asOthers: false, asOrgMember: true},
+				{role: parent, asOwner: false, asOthers: false, asOrgMember: true},
+			},
+		},
+		{
+			action: usecase.ActionManageOrganization,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: false, asOthers: false},
+				{role: parent, asOwner: false, asOthers: false},
+			},
+		},
+		{
+			action: usecase.ActionJoinOrganization,
+			expectations: []expectation{
+				{role: admin, asOwner: false, asOthers: false},
+				{role: therapist, asOwner: true, asOthers: true},
+				{role: parent, asOwner: false, asOthers: false},
+			},
+		},
+		{
+			action: usecase.ActionManageUser,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: false, asOthers: false},
+				{role: parent, asOwner: false, asOthers: false},
+			},
+		},
+		{
+			action: usecase.ActionDeleteAccount,
+			expectations: []expectation{
+				{role: admin, asOwner: false, asOthers: false},
+				{role: therapist, asOwner: false, asOthers: false},
+				{role: parent, asOwner: true, asOthers: true},
+			},
+		},
+		{
+			action: usecase.ActionExportData,
+			expectations: []expectation{
+				{role: admin, asOwner: false, asOthers: false},
+				{role: therapist, asOwner: false, asOthers: false},
+				{role: parent, asOwner: true, asOthers: true},
+			},
+		},
+		{
+			action: usecase.ActionUseMFA,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: true, asOthers: true},
+				{role: parent, asOwner: false, asOthers: false},
+			},
+		},
+		{
+			action: usecase.ActionPasswordlessLogin,
+			expectations: []expectation{
+				{role: admin, asOwner: false, asOthers: false},
+				{role: therapist, asOwner: false, asOthers: false},
+				{role: parent, asOwner: true, asOthers: true},
+			},
+		},
+		{
+			action: usecase.ActionReadAuditLog,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: false, asOthers: false},
+				{role: parent, asOwner: false, asOthers: false},
+			},
+		},
+		{
+			action: usecase.ActionUsePersonalAccessToken,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: true, asOthers: true},
+				{role: parent, asOwner: false, asOthers: false},
+			},
+		},
+		{
+			action: usecase.ActionGrantAdminScope,
+			expectations: []expectation{
+				{role: admin, asOwner: true, asOthers: true},
+				{role: therapist, asOwner: false, asOthers: false},
+				{role: parent, asOwner: false, asOthers: false},
+			},
+		},
+		{
+			action: usecase.Action("unknown"),
+			expectations: []expectation{
+				{role: admin, asOwner: false, asOthers: false},
+				{role: therapist, asOwner: false, asOthers: false},
+				{role: parent, asOwner: false, asOthers: false},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, exp := range tc.expectations {
+			t.Run(string(tc.action)+"/"+string(exp.role), func(t *testing.T) {
+				assert.Equal(t, exp.asOwner, usecase.IsAllowed(exp.role, tc.action, usecase.RelationOwner), "as owner")
+				assert.Equal(t, exp.asOthers, usecase.IsAllowed(exp.role, tc.action, usecase.RelationNone), "as others")
+				assert.Equal(t, exp.asOthers || exp.asReader,
+					usecase.IsAllowed(exp.role, tc.action, usecase.RelationCareReader), "as care reader")
+				assert.Equal(t, exp.asOthers || exp.asSubmitter,
+					usecase.IsAllowed(exp.role, tc.action, usecase.RelationCareSubmitter), "as care submitter")
+				assert.Equal(t, exp.asOthers || exp.asOrgAdmin,
+					usecase.IsAllowed(exp.role, tc.action, usecase.RelationOrganizationAdmin), "as organization admin")
+				assert.Equal(t, exp.asOthers || exp.asGuardian,
+					usecase.IsAllowed(exp.role, tc.action, usecase.RelationGuardian), "as guardian")
+				assert.Equal(t, exp.asOthers || exp.asOrgMember,
+					usecase.IsAllowed(exp.role, tc.action, usecase.RelationOrganizationMember), "as organization member")
+			})
+		}
+	}
+}
+
+func TestPolicy_Authorize(t *testing.T) {
+	t.Run("no requester", func(t *testing.T) {
+		err := usecase.Authorize(nil, usecase.ActionRegisterChild, usecase.RelationNone)
+		require.Error(t, err)
+		assertUsecaseErrType(t, usecase.ErrUnauthorized, err)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		requester := &model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+
+		err := usecase.Authorize(requester, usecase.ActionManagePackage, usecase.RelationNone)
+		require.Error(t, err)
+		assertUsecaseErrType(t, usecase.ErrForbidden, err)
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		requester := &model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
+
+		err := usecase.Authorize(requester, usecase.ActionUpdateChild, usecase.RelationOwner)
+		require.NoError(t, err)
+	})
+}
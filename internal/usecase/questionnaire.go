@@ -25,10 +25,13 @@ import (
 
 // QuestionnaireUsecase usecase for questionnaire
 type QuestionnaireUsecase struct {
-	packageRepo PackageRepo
-	childRepo   ChildRepository
-	resultRepo  ResultRepository
-	font        *truetype.Font
+	packageRepo          PackageRepo
+	childRepo            ChildRepository
+	resultRepo           ResultRepository
+	auditLogRepo         AuditLogRepository
+	careRelationshipRepo CareRelationshipRepository
+	childGuardianRepo    ChildGuardianRepository
+	font                 *truetype.Font
 }
 
 // QuestionnaireUsecaseIface interface
@@ -43,13 +46,18 @@ type QuestionnaireUsecaseIface interface {
 // NewQuestionnaireUsecase create new QuestionnaireUsecase instance
 func NewQuestionnaireUsecase(
 	packageRepo PackageRepo, childRepo ChildRepository,
-	resultRepo ResultRepository, font *truetype.Font,
+	resultRepo ResultRepository, auditLogRepo AuditLogRepository,
+	careRelationshipRepo CareRelationshipRepository, childGuardianRepo ChildGuardianRepository,
+	font *truetype.Font,
 ) *QuestionnaireUsecase {
 	return &QuestionnaireUsecase{
-		packageRepo: packageRepo,
-		childRepo:   childRepo,
-		resultRepo:  resultRepo,
-		font:        font,
+		packageRepo:          packageRepo,
+		childRepo:            childRepo,
+		resultRepo:           resultRepo,
+		auditLogRepo:         auditLogRepo,
+		careRelationshipRepo: careRelationshipRepo,
+		childGuardianRepo:    childGuardianRepo,
+		font:                 font,
 	}
 }
 
@@ -146,6 +154,8 @@ func (u *QuestionnaireUsecase) HandleSubmitQuestionnaire(ctx context.Context, in
 		}
 	}
 
+	requester := model.GetUserFromCtx(ctx)
+
 	pack, err := u.packageRepo.FindByID(ctx, input.PackageID)
 	switch err {
 	default:
@@ -164,6 +174,14 @@ func (u *QuestionnaireUsecase) HandleSubmitQuestionnaire(ctx context.Context, in
 		break
 	}
 
+	// the organization scoped package is treated as not exist for those outside of the organization
+	if !isPackageUsable(requester, *pack) {
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	}
+
 	if !pack.IsActive {
 		return nil, UsecaseError{
 			ErrType: ErrBadRequest,
@@ -189,8 +207,6 @@ func (u *QuestionnaireUsecase) HandleSubmitQuestionnaire(ctx context.Context, in
 	// to start
 	indication := pack.IndicationCategories.GetIndicationCategoryByScore(grade.CountTotalScore())
 
-	requester := model.GetUserFromCtx(ctx)
-
 	if input.ChildID == uuid.Nil {
 		createInput := RepoCreateResultInput{
 			PackageID: input.PackageID,
@@ -255,11 +271,13 @@ func (u *QuestionnaireUsecase) HandleSubmitQuestionnaire(ctx context.Context, in
 		break
 	}
 
-	if requester.Role != model.RolesTherapist && requester.ID != child.ParentUserID {
-		return nil, UsecaseError{
-			ErrType: ErrForbidden,
-			Message: "filling questionnaire for a child must be done by either the parents or therapist",
-		}
+	relation, err := childRelation(ctx, u.careRelationshipRepo, u.childGuardianRepo, requester, child)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Authorize(requester, ActionSubmitChildResult, relation); err != nil {
+		return nil, err
 	}
 
 	result, err := u.resultRepo.Create(ctx, RepoCreateResultInput{
@@ -319,12 +337,13 @@ func (u *QuestionnaireUsecase) lockPackageIfNecessary(ctx context.Context, packI
 
 // SearchQuestionnaireResultInput search questionnaire result
 type SearchQuestionnaireResultInput struct {
-	ID        uuid.UUID
-	PackageID uuid.UUID
-	ChildID   uuid.UUID
-	CreatedBy uuid.UUID
-	Limit     int `validate:"min=1,max=100"`
-	Offset    int `validate:"min=0"`
+	ID             uuid.UUID
+	PackageID      uuid.UUID
+	ChildID        uuid.UUID
+	CreatedBy      uuid.UUID
+	OrganizationID uuid.UUID
+	Limit          int `validate:"min=1,max=100"`
+	Offset         int `validate:"min=0"`
 }
 
 func (sqi SearchQuestionnaireResultInput) validate() error {
@@ -345,23 +364,16 @@ type SearchQuestionnaireResultOutput struct {
 }
 
 // HandleSearchQuestionnaireResult search questionnaire results from database based on given search param
-// this function will return a list of questionnaire result based on the search param
+// this function will return a list of questionnaire result based on the search param.
+// Therapist will only find the results of the children assigned to them, while the organization admin will find
+// the results of the children assigned to any therapist of the organization. Only the administrator can search across
+// the organizations
 func (u *QuestionnaireUsecase) HandleSearchQuestionnaireResult(
 	ctx context.Context, input SearchQuestionnaireResultInput,
 ) ([]SearchQuestionnaireResultOutput, error) {
 	requester := model.GetUserFromCtx(ctx)
-	if requester == nil {
-		return nil, UsecaseError{
-			ErrType: ErrUnauthorized,
-			Message: ErrUnauthorized.Error(),
-		}
-	}
-
-	if requester.Role != model.RolesTherapist {
-		return nil, UsecaseError{
-			ErrType: ErrForbidden,
-			Message: "insufficient permission to access this feature",
-		}
+	if err := Authorize(requester, ActionSearchResult, RelationNone); err != nil {
+		return nil, err
 	}
 
 	logger := logrus.WithContext(ctx).WithField("input", helper.Dump(input))
@@ -373,14 +385,25 @@ func (u *QuestionnaireUsecase) HandleSearchQuestionnaireResult(
 		}
 	}
 
-	results, err := u.resultRepo.Search(ctx, RepoSearchResultInput{
+	searchInput := RepoSearchResultInput{
 		ID:        input.ID,
 		PackageID: input.PackageID,
 		ChildID:   input.ChildID,
 		CreatedBy: input.CreatedBy,
 		Limit:     input.Limit,
 		Offset:    input.Offset,
-	})
+	}
+
+	switch {
+	case IsAllowed(requester.Role, ActionSearchOrganizationResult, RelationNone):
+		searchInput.OrganizationID = input.OrganizationID
+	case IsAllowed(requester.Role, ActionSearchOrganizationResult, organizationAdminRelation(requester, requester.OrganizationID)):
+		searchInput.OrganizationID = requester.OrganizationID
+	default:
+		searchInput.TherapistID = requester.ID
+	}
+
+	results, err := u.resultRepo.Search(ctx, searchInput)
 
 	switch err {
 	default:
@@ -400,8 +423,10 @@ func (u *QuestionnaireUsecase) HandleSearchQuestionnaireResult(
 	}
 
 	output := []SearchQuestionnaireResultOutput{}
+	resultIDs := []string{}
 
 	for _, res := range results {
+		resultIDs = append(resultIDs, res.ID.String())
 		output = append(output, SearchQuestionnaireResultOutput{
 			ID:        res.ID,
 			PackageID: res.PackageID,
@@ -411,10 +436,24 @@ func (u *QuestionnaireUsecase) HandleSearchQuestionnaireResult(
 			Result:    res.Result,
 			CreatedAt: res.CreatedAt,
 			UpdatedAt: res.UpdatedAt,
-			DeletedAt: res.DeletedAt,
+			DeletedAt: sql.NullTime(res.DeletedAt),
 		})
 	}
 
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionSearchResult,
+		TargetType: model.AuditTargetResult,
+		Metadata: model.AuditMetadata{
+			"id":         input.ID,
+			"package_id": input.PackageID,
+			"child_id":   input.ChildID,
+			"created_by": input.CreatedBy,
+			"limit":      input.Limit,
+			"offset":     input.Offset,
+			"result_ids": resultIDs,
+		},
+	})
+
 	return output, nil
 }
 
@@ -496,7 +535,7 @@ func (u *QuestionnaireUsecase) HandleGetUserHistory(ctx context.Context, input G
 			Result:    res.Result,
 			CreatedAt: res.CreatedAt,
 			UpdatedAt: res.UpdatedAt,
-			DeletedAt: res.DeletedAt,
+			DeletedAt: sql.NullTime(res.DeletedAt),
 		})
 	}
 
@@ -550,7 +589,17 @@ func (u *QuestionnaireUsecase) HandleDownloadQuestionnaireResult(
 		break
 	}
 
-	if result.CreatedBy != uuid.Nil {
+	// the anonymized results are only kept for the package analysis, thus must not be downloaded by anyone.
+	// Unlike the results submitted without logging in, the missing creator doesn't make them public
+	if result.IsAnonymized() {
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	}
+
+	// the results of a child are never public, even when their creator has been deleted
+	if result.CreatedBy != uuid.Nil || result.ChildID != uuid.Nil {
 		requester := model.GetUserFromCtx(ctx)
 
 		if requester == nil {
@@ -560,12 +609,24 @@ func (u *QuestionnaireUsecase) HandleDownloadQuestionnaireResult(
 			}
 		}
 
-		if requester.Role != model.RolesTherapist && requester.ID != result.CreatedBy {
-			return nil, UsecaseError{
-				ErrType: ErrUnauthorized,
-				Message: "only owner and admin can access this result",
+		relation := ownerRelation(requester, result.CreatedBy)
+		if relation == RelationNone && result.ChildID != uuid.Nil {
+			relation, err = guardianRelation(ctx, u.childGuardianRepo, requester.ID, result.ChildID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if relation == RelationNone && result.ChildID != uuid.Nil && IsAllowed(requester.Role, ActionReceiveCareAccess, RelationNone) {
+			relation, err = careRelation(ctx, u.careRelationshipRepo, requester.ID, result.ChildID)
+			if err != nil {
+				return nil, err
 			}
 		}
+
+		if err := Authorize(requester, ActionReadResult, relation); err != nil {
+			return nil, err
+		}
 	}
 
 	pack, err := u.packageRepo.FindByID(ctx, result.PackageID)
@@ -595,6 +656,16 @@ func (u *QuestionnaireUsecase) HandleDownloadQuestionnaireResult(
 
 	imageResult := imgGenerator.GenerateJPEG()
 
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionDownloadResult,
+		TargetType: model.AuditTargetResult,
+		TargetID:   result.ID.String(),
+		Metadata: model.AuditMetadata{
+			"child_id":   result.ChildID,
+			"created_by": result.CreatedBy,
+		},
+	})
+
 	return &DownloadQuestionnaireResultOutput{
 		ContentType: imageResult.ContentType,
 		Buffer:      imageResult.Buffer,
@@ -867,7 +938,7 @@ type InitializeATECQuestionnaireOutput struct {
 }
 
 // HandleInitializeATECQuestionnaire get an atec questionaire based on provided input.PackageID
-// if not, default to the oldest active and locked package
+// if not, default to the oldest active and locked global package
 func (u *QuestionnaireUsecase) HandleInitializeATECQuestionnaire(ctx context.Context, input InitializeATECQuestionnaireInput) (
 	*InitializeATECQuestionnaireOutput, error,
 ) {
@@ -891,12 +962,21 @@ func (u *QuestionnaireUsecase) HandleInitializeATECQuestionnaire(ctx context.Con
 			Message: ErrNotFound.Error(),
 		}
 	case nil:
-		return &InitializeATECQuestionnaireOutput{
-			ID:            pack.ID,
-			Questionnaire: pack.Questionnaire,
-			Name:          pack.Name,
-		}, nil
+		break
 	}
+
+	if !isPackageUsable(model.GetUserFromCtx(ctx), *pack) {
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	}
+
+	return &InitializeATECQuestionnaireOutput{
+		ID:            pack.ID,
+		Questionnaire: pack.Questionnaire,
+		Name:          pack.Name,
+	}, nil
 }
 
 func (u *QuestionnaireUsecase) getDefaultATECQuestionnaire(ctx context.Context) (*InitializeATECQuestionnaireOutput, error) {
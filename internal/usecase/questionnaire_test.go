@@ -2,8 +2,10 @@ package usecase_test
 
 import (
 	"context"
+	"database/sql"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/freetype/truetype"
 	"github.com/google/uuid"
@@ -11,6 +13,7 @@ import (
 	"github.com/luckyAkbar/atec/internal/usecase"
 	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -555,8 +558,10 @@ func TestQuestionnaireUsecase_HandleSubmitQuestionnaire(t *testing.T) {
 	mockPackageRepo := mockUsecase.NewPackageRepo(t)
 	mockResultRepo := mockUsecase.NewResultRepository(t)
 	mockChildRepo := mockUsecase.NewChildRepository(t)
+	mockCareRelationshipRepo := mockUsecase.NewCareRelationshipRepository(t)
+	mockChildGuardianRepo := mockUsecase.NewChildGuardianRepository(t)
 
-	uc := usecase.NewQuestionnaireUsecase(mockPackageRepo, mockChildRepo, mockResultRepo, nil)
+	uc := usecase.NewQuestionnaireUsecase(mockPackageRepo, mockChildRepo, mockResultRepo, nil, mockCareRelationshipRepo, mockChildGuardianRepo, nil)
 
 	testCases := []struct {
 		name                 string
@@ -844,6 +849,7 @@ func TestQuestionnaireUsecase_HandleSubmitQuestionnaire(t *testing.T) {
 			expectedFunctionCall: func() {
 				mockPackageRepo.EXPECT().FindByID(randomParentCtx, packageID).Return(selectedLockedPackage, nil).Once()
 				mockChildRepo.EXPECT().FindByID(randomParentCtx, childID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(randomParentCtx, childID, randomParent.ID).Return(nil, usecase.ErrRepoNotFound).Once()
 			},
 		},
 		{
@@ -876,7 +882,74 @@ func TestQuestionnaireUsecase_HandleSubmitQuestionnaire(t *testing.T) {
 			},
 		},
 		{
-			name: "therapist should be able to submit to any child",
+			name: "guardian success submiting to the shared child",
+			input: usecase.SubmitQuestionnaireInput{
+				PackageID: packageID,
+				Answers:   validAnswersZeroed,
+				ChildID:   childID,
+			},
+			ctx:     randomParentCtx,
+			wantErr: false,
+			expectedOutput: &usecase.SubmitQuestionnaireOutput{
+				ResultID:   resultZeroedSubmittedByParent.ID,
+				PackageID:  packageID,
+				Answers:    validAnswersZeroed,
+				Result:     zeroedResultDetail,
+				Indication: selectedLockedPackage.IndicationCategories.GetIndicationCategoryByScore(0),
+				CreatedBy:  parentID,
+			},
+			expectedFunctionCall: func() {
+				mockPackageRepo.EXPECT().FindByID(randomParentCtx, packageID).Return(selectedLockedPackage, nil).Once()
+				mockChildRepo.EXPECT().FindByID(randomParentCtx, childID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(randomParentCtx, childID, randomParent.ID).
+					Return(&model.ChildGuardian{ChildID: childID, UserID: randomParent.ID, Role: model.GuardianRoleGuardian}, nil).Once()
+				mockResultRepo.EXPECT().Create(randomParentCtx, usecase.RepoCreateResultInput{
+					PackageID: packageID,
+					Answer:    validAnswersZeroed,
+					Result:    zeroedResultDetail,
+					CreatedBy: randomParent.ID,
+					ChildID:   childID,
+				}).Return(resultZeroedSubmittedByParent, nil).Once()
+			},
+		},
+		{
+			name: "therapist not assigned to the child",
+			input: usecase.SubmitQuestionnaireInput{
+				PackageID: packageID,
+				Answers:   validAnswersZeroed,
+				ChildID:   childID,
+			},
+			ctx:         therapistCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockPackageRepo.EXPECT().FindByID(therapistCtx, packageID).Return(selectedLockedPackage, nil).Once()
+				mockChildRepo.EXPECT().FindByID(therapistCtx, childID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(therapistCtx, childID, therapist.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockCareRelationshipRepo.EXPECT().FindByChildIDAndTherapistID(therapistCtx, childID, therapist.ID).
+					Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name: "therapist only granted read access to the child",
+			input: usecase.SubmitQuestionnaireInput{
+				PackageID: packageID,
+				Answers:   validAnswersZeroed,
+				ChildID:   childID,
+			},
+			ctx:         therapistCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockPackageRepo.EXPECT().FindByID(therapistCtx, packageID).Return(selectedLockedPackage, nil).Once()
+				mockChildRepo.EXPECT().FindByID(therapistCtx, childID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(therapistCtx, childID, therapist.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockCareRelationshipRepo.EXPECT().FindByChildIDAndTherapistID(therapistCtx, childID, therapist.ID).
+					Return(&model.CareRelationship{Scope: model.CareScopeRead}, nil).Once()
+			},
+		},
+		{
+			name: "therapist granted submit access should be able to submit to the child",
 			input: usecase.SubmitQuestionnaireInput{
 				PackageID: packageID,
 				Answers:   validAnswersZeroed,
@@ -895,6 +968,9 @@ func TestQuestionnaireUsecase_HandleSubmitQuestionnaire(t *testing.T) {
 			expectedFunctionCall: func() {
 				mockPackageRepo.EXPECT().FindByID(therapistCtx, packageID).Return(selectedLockedPackage, nil).Once()
 				mockChildRepo.EXPECT().FindByID(therapistCtx, childID).Return(child, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(therapistCtx, childID, therapist.ID).Return(nil, usecase.ErrRepoNotFound).Once()
+				mockCareRelationshipRepo.EXPECT().FindByChildIDAndTherapistID(therapistCtx, childID, therapist.ID).
+					Return(&model.CareRelationship{Scope: model.CareScopeSubmit}, nil).Once()
 				mockResultRepo.EXPECT().Create(therapistCtx, usecase.RepoCreateResultInput{
 					PackageID: packageID,
 					Answer:    validAnswersZeroed,
@@ -1048,8 +1124,17 @@ func TestQuestionnaireUsecase_HandleDownloadQuestionnaireResult(t *testing.T) {
 	}
 	therapistCtx := model.SetUserToCtx(ctx, therapistUser)
 
+	adminUser := model.AuthUser{
+		ID:   uuid.New(),
+		Role: model.RolesAdministrator,
+	}
+	adminCtx := model.SetUserToCtx(ctx, adminUser)
+
 	mockPackageRepo := mockUsecase.NewPackageRepo(t)
 	mockResultRepo := mockUsecase.NewResultRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
+	mockCareRelationshipRepo := mockUsecase.NewCareRelationshipRepository(t)
+	mockChildGuardianRepo := mockUsecase.NewChildGuardianRepository(t)
 
 	fontBytes, err := os.ReadFile("../../assets/font.ttf")
 	if err != nil {
@@ -1061,7 +1146,7 @@ func TestQuestionnaireUsecase_HandleDownloadQuestionnaireResult(t *testing.T) {
 		panic(err)
 	}
 
-	uc := usecase.NewQuestionnaireUsecase(mockPackageRepo, nil, mockResultRepo, font)
+	uc := usecase.NewQuestionnaireUsecase(mockPackageRepo, nil, mockResultRepo, mockAuditLogRepo, mockCareRelationshipRepo, mockChildGuardianRepo, font)
 
 	pack := &model.Package{
 		ID:                      uuid.New(),
@@ -1073,6 +1158,7 @@ func TestQuestionnaireUsecase_HandleDownloadQuestionnaireResult(t *testing.T) {
 		ID:        resultID,
 		CreatedBy: user.ID,
 		PackageID: pack.ID,
+		ChildID:   uuid.New(),
 	}
 
 	resultWithoutOwner := &model.Result{
@@ -1080,6 +1166,26 @@ func TestQuestionnaireUsecase_HandleDownloadQuestionnaireResult(t *testing.T) {
 		PackageID: pack.ID,
 	}
 
+	// the result kept for the child after its creator was permanently deleted
+	resultWithDeletedCreator := &model.Result{
+		ID:        resultID,
+		PackageID: pack.ID,
+		ChildID:   resultWithOwner.ChildID,
+	}
+
+	anonymizedResult := &model.Result{
+		ID:           resultID,
+		PackageID:    pack.ID,
+		ChildAgeBand: sql.NullString{String: "3-5", Valid: true},
+		AnonymizedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}
+
+	expectDownloadRecorded := func(ctx context.Context) {
+		mockAuditLogRepo.EXPECT().Create(ctx, mock.MatchedBy(func(input usecase.RepoCreateAuditLogInput) bool {
+			return input.Action == model.AuditActionDownloadResult && input.TargetID == resultID.String()
+		})).Return(nil).Once()
+	}
+
 	testCases := []struct {
 		name                 string
 		input                usecase.DownloadQuestionnaireResultInput
@@ -1129,6 +1235,31 @@ func TestQuestionnaireUsecase_HandleDownloadQuestionnaireResult(t *testing.T) {
 			expectedFunctionCall: func() {
 				mockResultRepo.EXPECT().FindByID(ctx, resultID).Return(resultWithoutOwner, nil).Once()
 				mockPackageRepo.EXPECT().FindByID(ctx, resultWithoutOwner.PackageID).Return(pack, nil).Once()
+				expectDownloadRecorded(ctx)
+			},
+		},
+		{
+			name: "anonymized result can't be downloaded by anyone",
+			input: usecase.DownloadQuestionnaireResultInput{
+				ResultID: resultID,
+			},
+			ctx:         ctx,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockResultRepo.EXPECT().FindByID(ctx, resultID).Return(anonymizedResult, nil).Once()
+			},
+		},
+		{
+			name: "anonymized result can't be downloaded even by the administrator",
+			input: usecase.DownloadQuestionnaireResultInput{
+				ResultID: resultID,
+			},
+			ctx:         adminCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockResultRepo.EXPECT().FindByID(adminCtx, resultID).Return(anonymizedResult, nil).Once()
 			},
 		},
 		{
@@ -1176,9 +1307,11 @@ func TestQuestionnaireUsecase_HandleDownloadQuestionnaireResult(t *testing.T) {
 			},
 			ctx:         randomUserCtx,
 			wantErr:     true,
-			expectedErr: usecase.ErrUnauthorized,
+			expectedErr: usecase.ErrForbidden,
 			expectedFunctionCall: func() {
 				mockResultRepo.EXPECT().FindByID(randomUserCtx, resultID).Return(resultWithOwner, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(randomUserCtx, resultWithOwner.ChildID, randomUser.ID).
+					Return(nil, usecase.ErrRepoNotFound).Once()
 			},
 		},
 		{
@@ -1191,10 +1324,99 @@ func TestQuestionnaireUsecase_HandleDownloadQuestionnaireResult(t *testing.T) {
 			expectedFunctionCall: func() {
 				mockResultRepo.EXPECT().FindByID(userCtx, resultID).Return(resultWithOwner, nil).Once()
 				mockPackageRepo.EXPECT().FindByID(userCtx, resultWithOwner.PackageID).Return(pack, nil).Once()
+				expectDownloadRecorded(userCtx)
+			},
+		},
+		{
+			name: "the child's result is not public after its creator was deleted",
+			input: usecase.DownloadQuestionnaireResultInput{
+				ResultID: resultID,
+			},
+			ctx:         ctx,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+			expectedFunctionCall: func() {
+				mockResultRepo.EXPECT().FindByID(ctx, resultID).Return(resultWithDeletedCreator, nil).Once()
+			},
+		},
+		{
+			name: "the child's result can still be downloaded by the child's owner after its creator was deleted",
+			input: usecase.DownloadQuestionnaireResultInput{
+				ResultID: resultID,
+			},
+			ctx:     randomUserCtx,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockResultRepo.EXPECT().FindByID(randomUserCtx, resultID).Return(resultWithDeletedCreator, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(randomUserCtx, resultWithDeletedCreator.ChildID, randomUser.ID).
+					Return(&model.ChildGuardian{Role: model.GuardianRoleOwner}, nil).Once()
+				mockPackageRepo.EXPECT().FindByID(randomUserCtx, resultWithDeletedCreator.PackageID).Return(pack, nil).Once()
+				expectDownloadRecorded(randomUserCtx)
+			},
+		},
+		{
+			name: "guardian of the child should be able to download the result",
+			input: usecase.DownloadQuestionnaireResultInput{
+				ResultID: resultID,
+			},
+			ctx:     randomUserCtx,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockResultRepo.EXPECT().FindByID(randomUserCtx, resultID).Return(resultWithOwner, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(randomUserCtx, resultWithOwner.ChildID, randomUser.ID).
+					Return(&model.ChildGuardian{Role: model.GuardianRoleGuardian}, nil).Once()
+				mockPackageRepo.EXPECT().FindByID(randomUserCtx, resultWithOwner.PackageID).Return(pack, nil).Once()
+				expectDownloadRecorded(randomUserCtx)
+			},
+		},
+		{
+			name: "failed to find the guardian membership",
+			input: usecase.DownloadQuestionnaireResultInput{
+				ResultID: resultID,
+			},
+			ctx:         randomUserCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockResultRepo.EXPECT().FindByID(randomUserCtx, resultID).Return(resultWithOwner, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(randomUserCtx, resultWithOwner.ChildID, randomUser.ID).
+					Return(nil, assert.AnError).Once()
 			},
 		},
 		{
-			name: "therapist should be able to download any result",
+			name: "therapist not assigned to the child should not be able to download the result",
+			input: usecase.DownloadQuestionnaireResultInput{
+				ResultID: resultID,
+			},
+			ctx:         therapistCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+			expectedFunctionCall: func() {
+				mockResultRepo.EXPECT().FindByID(therapistCtx, resultID).Return(resultWithOwner, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(therapistCtx, resultWithOwner.ChildID, therapistUser.ID).
+					Return(nil, usecase.ErrRepoNotFound).Once()
+				mockCareRelationshipRepo.EXPECT().FindByChildIDAndTherapistID(therapistCtx, resultWithOwner.ChildID, therapistUser.ID).
+					Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name: "failed to find the therapist care relationship",
+			input: usecase.DownloadQuestionnaireResultInput{
+				ResultID: resultID,
+			},
+			ctx:         therapistCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockResultRepo.EXPECT().FindByID(therapistCtx, resultID).Return(resultWithOwner, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(therapistCtx, resultWithOwner.ChildID, therapistUser.ID).
+					Return(nil, usecase.ErrRepoNotFound).Once()
+				mockCareRelationshipRepo.EXPECT().FindByChildIDAndTherapistID(therapistCtx, resultWithOwner.ChildID, therapistUser.ID).
+					Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name: "therapist assigned to the child should be able to download the result",
 			input: usecase.DownloadQuestionnaireResultInput{
 				ResultID: resultID,
 			},
@@ -1202,7 +1424,27 @@ func TestQuestionnaireUsecase_HandleDownloadQuestionnaireResult(t *testing.T) {
 			wantErr: false,
 			expectedFunctionCall: func() {
 				mockResultRepo.EXPECT().FindByID(therapistCtx, resultID).Return(resultWithOwner, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(therapistCtx, resultWithOwner.ChildID, therapistUser.ID).
+					Return(nil, usecase.ErrRepoNotFound).Once()
+				mockCareRelationshipRepo.EXPECT().FindByChildIDAndTherapistID(therapistCtx, resultWithOwner.ChildID, therapistUser.ID).
+					Return(&model.CareRelationship{Scope: model.CareScopeRead}, nil).Once()
 				mockPackageRepo.EXPECT().FindByID(therapistCtx, resultWithOwner.PackageID).Return(pack, nil).Once()
+				expectDownloadRecorded(therapistCtx)
+			},
+		},
+		{
+			name: "admin should be able to download any result",
+			input: usecase.DownloadQuestionnaireResultInput{
+				ResultID: resultID,
+			},
+			ctx:     adminCtx,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockResultRepo.EXPECT().FindByID(adminCtx, resultID).Return(resultWithOwner, nil).Once()
+				mockChildGuardianRepo.EXPECT().FindByChildIDAndUserID(adminCtx, resultWithOwner.ChildID, adminUser.ID).
+					Return(nil, usecase.ErrRepoNotFound).Once()
+				mockPackageRepo.EXPECT().FindByID(adminCtx, resultWithOwner.PackageID).Return(pack, nil).Once()
+				expectDownloadRecorded(adminCtx)
 			},
 		},
 	}
@@ -1240,10 +1482,21 @@ func TestQuestionnaireUsecae_HandleSearchQuestionnaireResult(t *testing.T) {
 	}
 
 	ctx := model.SetUserToCtx(context.Background(), therapist)
+	adminCtx := model.SetUserToCtx(context.Background(), model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator})
+
+	organizationAdmin := model.AuthUser{
+		ID:                  uuid.New(),
+		Role:                model.RolesTherapist,
+		OrganizationID:      uuid.New(),
+		IsOrganizationAdmin: true,
+	}
+	organizationAdminCtx := model.SetUserToCtx(context.Background(), organizationAdmin)
+	otherOrganizationID := uuid.New()
 
 	mockResultRepo := mockUsecase.NewResultRepository(t)
+	mockAuditLogRepo := mockUsecase.NewAuditLogRepository(t)
 
-	uc := usecase.NewQuestionnaireUsecase(nil, nil, mockResultRepo, nil)
+	uc := usecase.NewQuestionnaireUsecase(nil, nil, mockResultRepo, mockAuditLogRepo, nil, nil, nil)
 
 	validInput := usecase.SearchQuestionnaireResultInput{
 		Limit:     10,
@@ -1270,22 +1523,54 @@ func TestQuestionnaireUsecae_HandleSearchQuestionnaireResult(t *testing.T) {
 			input:       usecase.SearchQuestionnaireResultInput{},
 			wantErr:     true,
 			ctx:         context.Background(),
-			expectedErr: usecase.ErrBadRequest,
+			expectedErr: usecase.ErrUnauthorized,
 		},
 		{
-			name:        "should only allow therapist - parent",
+			name:        "should only allow therapist or administrator - parent",
 			input:       usecase.SearchQuestionnaireResultInput{},
 			wantErr:     true,
 			ctx:         model.SetUserToCtx(context.Background(), model.AuthUser{Role: model.RolesParent}),
-			expectedErr: usecase.ErrBadRequest,
+			expectedErr: usecase.ErrForbidden,
 		},
 		{
-			name:        "should only allow therapist - administrator",
+			name:        "administrator input is validated",
 			input:       usecase.SearchQuestionnaireResultInput{},
 			wantErr:     true,
-			ctx:         model.SetUserToCtx(context.Background(), model.AuthUser{Role: model.RolesAdministrator}),
+			ctx:         adminCtx,
 			expectedErr: usecase.ErrBadRequest,
 		},
+		{
+			name: "administrator is able to search across the organizations",
+			input: usecase.SearchQuestionnaireResultInput{
+				Limit:          10,
+				OrganizationID: otherOrganizationID,
+			},
+			wantErr:     true,
+			ctx:         adminCtx,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockResultRepo.EXPECT().Search(adminCtx, usecase.RepoSearchResultInput{
+					Limit:          10,
+					OrganizationID: otherOrganizationID,
+				}).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name: "organization admin is isolated to the own organization",
+			input: usecase.SearchQuestionnaireResultInput{
+				Limit:          10,
+				OrganizationID: otherOrganizationID,
+			},
+			wantErr:     true,
+			ctx:         organizationAdminCtx,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockResultRepo.EXPECT().Search(organizationAdminCtx, usecase.RepoSearchResultInput{
+					Limit:          10,
+					OrganizationID: organizationAdmin.OrganizationID,
+				}).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
 		{
 			name:        "limit is missing from input",
 			input:       usecase.SearchQuestionnaireResultInput{},
@@ -1311,12 +1596,13 @@ func TestQuestionnaireUsecae_HandleSearchQuestionnaireResult(t *testing.T) {
 			expectedErr: usecase.ErrInternal,
 			expectedFunctionCall: func() {
 				mockResultRepo.EXPECT().Search(ctx, usecase.RepoSearchResultInput{
-					Limit:     validInput.Limit,
-					Offset:    validInput.Offset,
-					ID:        validInput.ID,
-					PackageID: validInput.PackageID,
-					ChildID:   validInput.ChildID,
-					CreatedBy: validInput.CreatedBy,
+					Limit:       validInput.Limit,
+					Offset:      validInput.Offset,
+					ID:          validInput.ID,
+					PackageID:   validInput.PackageID,
+					ChildID:     validInput.ChildID,
+					CreatedBy:   validInput.CreatedBy,
+					TherapistID: therapist.ID,
 				}).Return(nil, assert.AnError).Once()
 			},
 		},
@@ -1328,12 +1614,13 @@ func TestQuestionnaireUsecae_HandleSearchQuestionnaireResult(t *testing.T) {
 			expectedErr: usecase.ErrNotFound,
 			expectedFunctionCall: func() {
 				mockResultRepo.EXPECT().Search(ctx, usecase.RepoSearchResultInput{
-					Limit:     validInput.Limit,
-					Offset:    validInput.Offset,
-					ID:        validInput.ID,
-					PackageID: validInput.PackageID,
-					ChildID:   validInput.ChildID,
-					CreatedBy: validInput.CreatedBy,
+					Limit:       validInput.Limit,
+					Offset:      validInput.Offset,
+					ID:          validInput.ID,
+					PackageID:   validInput.PackageID,
+					ChildID:     validInput.ChildID,
+					CreatedBy:   validInput.CreatedBy,
+					TherapistID: therapist.ID,
 				}).Return(nil, usecase.ErrRepoNotFound).Once()
 			},
 		},
@@ -1345,13 +1632,20 @@ func TestQuestionnaireUsecae_HandleSearchQuestionnaireResult(t *testing.T) {
 			expectedOutputLen: expectedResultLen,
 			expectedFunctionCall: func() {
 				mockResultRepo.EXPECT().Search(ctx, usecase.RepoSearchResultInput{
-					Limit:     validInput.Limit,
-					Offset:    validInput.Offset,
-					ID:        validInput.ID,
-					PackageID: validInput.PackageID,
-					ChildID:   validInput.ChildID,
-					CreatedBy: validInput.CreatedBy,
+					Limit:       validInput.Limit,
+					Offset:      validInput.Offset,
+					ID:          validInput.ID,
+					PackageID:   validInput.PackageID,
+					ChildID:     validInput.ChildID,
+					CreatedBy:   validInput.CreatedBy,
+					TherapistID: therapist.ID,
 				}).Return(make([]model.Result, expectedResultLen), nil).Once()
+				mockAuditLogRepo.EXPECT().Create(ctx, mock.MatchedBy(func(input usecase.RepoCreateAuditLogInput) bool {
+					return input.Action == model.AuditActionSearchResult &&
+						input.ActorID == therapist.ID &&
+						input.Metadata["id"] == validInput.ID &&
+						input.Metadata["child_id"] == validInput.ChildID
+				})).Return(nil).Once()
 			},
 		},
 	}
@@ -1362,7 +1656,7 @@ func TestQuestionnaireUsecae_HandleSearchQuestionnaireResult(t *testing.T) {
 				tc.expectedFunctionCall()
 			}
 
-			res, err := uc.HandleSearchQuestionnaireResult(ctx, tc.input)
+			res, err := uc.HandleSearchQuestionnaireResult(tc.ctx, tc.input)
 
 			if !tc.wantErr {
 				require.NoError(t, err)
@@ -1395,7 +1689,7 @@ func TestQuestionnaireUsecase_HandleGetUserHistory(t *testing.T) {
 
 	mockResultRepo := mockUsecase.NewResultRepository(t)
 
-	uc := usecase.NewQuestionnaireUsecase(nil, nil, mockResultRepo, nil)
+	uc := usecase.NewQuestionnaireUsecase(nil, nil, mockResultRepo, nil, nil, nil, nil)
 
 	expectedOutputLen := 78
 
@@ -1513,7 +1807,7 @@ func TestQuestionnaireUsecase_HandleInitializeATECQuestionnaire(t *testing.T) {
 
 	mockPackageRepo := mockUsecase.NewPackageRepo(t)
 
-	uc := usecase.NewQuestionnaireUsecase(mockPackageRepo, nil, nil, nil)
+	uc := usecase.NewQuestionnaireUsecase(mockPackageRepo, nil, nil, nil, nil, nil, nil)
 
 	targetPackageID := uuid.New()
 	input := usecase.InitializeATECQuestionnaireInput{
@@ -25,6 +25,9 @@ var (
 
 	// ErrRepoTimeout represent timeout or process took too long
 	ErrRepoTimeout RepositoryError = errors.New("timeout")
+
+	// ErrRepoDuplicate represent the data violating a unique constraint on the database
+	ErrRepoDuplicate RepositoryError = errors.New("duplicate data")
 )
 
 // TransactionControllerFactory transaction controller factory
@@ -81,13 +84,21 @@ type RepoCreateChildInput struct {
 	GuardianName sql.NullString
 }
 
-// RepoSearchChildInput input to search child data. everything marked as pointer to a datatype means it is optional
+// RepoSearchChildInput input to search child data. everything marked as pointer to a datatype means it is optional.
+// TherapistID limit the search to the children the therapist is assigned to, while OrganizationID limit the search
+// to the children assigned to any of the organization's therapists. GuardianUserID limit the search to the children
+// the user is the owner or a guardian of. Archived limit the search to either the archived or the active children,
+// leave it nil to search both
 type RepoSearchChildInput struct {
-	ParentUserID *uuid.UUID
-	Name         *string
-	Gender       *bool
-	Limit        int
-	Offset       int
+	ParentUserID   *uuid.UUID
+	GuardianUserID *uuid.UUID
+	TherapistID    *uuid.UUID
+	OrganizationID *uuid.UUID
+	Name           *string
+	Gender         *bool
+	Archived       *bool
+	Limit          int
+	Offset         int
 }
 
 // ChildRepository interface
@@ -97,6 +108,9 @@ type ChildRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*model.Child, error)
 	Search(ctx context.Context, input RepoSearchChildInput) ([]model.Child, error)
 	DeleteAllUserChildren(ctx context.Context, input RepoDeleteAllUserChildrenInput, txController ...any) error
+	RestoreAllUserChildren(ctx context.Context, input RepoRestoreAllUserChildrenInput, txController ...any) error
+	TransferOwnership(ctx context.Context, input RepoTransferChildInput, txController ...any) error
+	DeleteByID(ctx context.Context, id uuid.UUID, txController ...any) error
 }
 
 // RepoRegisterChildInput input
@@ -106,12 +120,13 @@ type RepoRegisterChildInput struct {
 	Name        string `validate:"required"`
 }
 
-// RepoUpdateChildInput input
+// RepoUpdateChildInput input. Set ArchivedAt to an invalid sql.NullTime to restore the archived child
 type RepoUpdateChildInput struct {
 	DateOfBirth  *time.Time
 	Gender       *bool
 	Name         *string
 	GuardianName *sql.NullString
+	ArchivedAt   *sql.NullTime
 }
 
 // RepoUpdateUserInput options to update user record
@@ -120,6 +135,37 @@ type RepoUpdateUserInput struct {
 	Password string `json:"-"`
 	Username string
 	IsActive *bool
+	Roles    model.Roles
+
+	// MFASecret leave nil to keep the current value. Set with invalid sql.NullString to remove the secret
+	MFASecret   *sql.NullString `json:"-"`
+	MFAEnabled  *bool
+	MFARequired *bool
+
+	// FailedLoginAttempts and LockedUntil leave nil to keep the current value
+	FailedLoginAttempts *int
+	LockedUntil         *sql.NullTime
+
+	// OrganizationID leave nil to keep the current value. Set with invalid uuid.NullUUID to remove the membership
+	OrganizationID      *uuid.NullUUID
+	IsOrganizationAdmin *bool
+}
+
+// RepoUserEncryptedFields the encrypted fields of a user, along with the blind index of the email
+type RepoUserEncryptedFields struct {
+	Email           string
+	EmailBlindIndex sql.NullString
+	PhoneNumber     sql.NullString
+	Address         sql.NullString
+	MFASecret       sql.NullString `json:"-"`
+}
+
+// RepoReencryptUserInput input to replace the encrypted fields of a user. The update only happens when the stored
+// values are still equal to Current, so a value changed after it was read will not be overwritten
+type RepoReencryptUserInput struct {
+	UserID      uuid.UUID
+	Current     RepoUserEncryptedFields
+	Reencrypted RepoUserEncryptedFields
 }
 
 // RepoUpdateUserProfileInput input to update user's own profile
@@ -131,33 +177,50 @@ type RepoUpdateUserProfileInput struct {
 
 // RepoCreateUserInput input to create a new user data
 type RepoCreateUserInput struct {
-	Email       string
-	Password    string
-	Username    string
-	IsActive    bool
-	Roles       model.Roles
-	PhoneNumber sql.NullString
-	Address     sql.NullString
+	Email           string
+	EmailBlindIndex string
+	Password        string
+	Username        string
+	IsActive        bool
+	Roles           model.Roles
+	PhoneNumber     sql.NullString
+	Address         sql.NullString
+}
+
+// RepoEmailLookup identify a user by the email. The users created before the email blind index was introduced
+// don't have one until backfilled by the reencrypt command, thus are matched using LegacyEncryptedEmails instead,
+// which hold the email encrypted using every configured key
+type RepoEmailLookup struct {
+	BlindIndex            string
+	LegacyEncryptedEmails []string
 }
 
-// RepoSearchUserInput options to search users
+// RepoSearchUserInput options to search users. Zero value OrganizationID is not used as filter
 type RepoSearchUserInput struct {
-	Role   model.Roles
-	Limit  int
-	Offset int
+	Role           model.Roles
+	Email          *RepoEmailLookup
+	Username       string
+	IsActive       *bool
+	OrganizationID uuid.UUID
+	Limit          int
+	Offset         int
 }
 
 // UserRepository interface exported by UserRepository to help ease mocking
 type UserRepository interface {
-	FindByEmail(ctx context.Context, email string) (*model.User, error)
+	FindByEmail(ctx context.Context, lookup RepoEmailLookup) (*model.User, error)
 	Create(ctx context.Context, input RepoCreateUserInput, txController ...any) (*model.User, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*model.User, error)
-	Update(ctx context.Context, userID uuid.UUID, input RepoUpdateUserInput) (*model.User, error)
+	Update(ctx context.Context, userID uuid.UUID, input RepoUpdateUserInput, txController ...any) (*model.User, error)
 	UpdateProfile(ctx context.Context, userID uuid.UUID, input RepoUpdateUserProfileInput) (*model.User, error)
 	Search(ctx context.Context, input RepoSearchUserInput) ([]model.User, error)
 	GetUsersByRoles(ctx context.Context, roles model.Roles) ([]model.User, error)
 	IsAdminAccountExists(ctx context.Context) (bool, error)
 	DeleteByID(ctx context.Context, input RepoDeleteUserByIDInput, txController ...any) error
+	IncrementFailedLoginAttempts(ctx context.Context, userID uuid.UUID) (*model.User, error)
+	UpdateEmail(ctx context.Context, userID uuid.UUID, email, emailBlindIndex string, txController ...any) error
+	UpdateDeletionRequestedAt(ctx context.Context, userID uuid.UUID, requestedAt sql.NullTime, txController ...any) error
+	FindDeletionRequestedBefore(ctx context.Context, before time.Time) ([]model.User, error)
 }
 
 // RepoCreateResultInput create result input
@@ -169,14 +232,18 @@ type RepoCreateResultInput struct {
 	Result    model.ResultDetail
 }
 
-// RepoSearchResultInput search result input
+// RepoSearchResultInput search result input. TherapistID limit the search to the results of the children
+// the therapist is assigned to, while OrganizationID limit the search to the results of the children
+// assigned to any of the organization's therapists
 type RepoSearchResultInput struct {
-	ID        uuid.UUID
-	PackageID uuid.UUID
-	ChildID   uuid.UUID
-	CreatedBy uuid.UUID
-	Limit     int
-	Offset    int
+	ID             uuid.UUID
+	PackageID      uuid.UUID
+	ChildID        uuid.UUID
+	CreatedBy      uuid.UUID
+	TherapistID    uuid.UUID
+	OrganizationID uuid.UUID
+	Limit          int
+	Offset         int
 }
 
 // RepoFindAllUserHistoryInput input
@@ -193,11 +260,15 @@ type ResultRepository interface {
 	Search(ctx context.Context, input RepoSearchResultInput) ([]model.Result, error)
 	FindAllUserHistory(ctx context.Context, input RepoFindAllUserHistoryInput) ([]model.Result, error)
 	DeleteAllUserResults(ctx context.Context, input RepoDeleteAllUserResultsInput, txController ...any) error
+	RestoreAllUserResults(ctx context.Context, input RepoRestoreAllUserResultsInput, txController ...any) error
+	TransferChildResults(ctx context.Context, input RepoTransferChildInput, txController ...any) error
+	DeleteAllChildResults(ctx context.Context, input RepoDeleteAllChildResultsInput, txController ...any) error
 }
 
-// RepoCreatePackageInput input
+// RepoCreatePackageInput input. Leave OrganizationID invalid to create a global package
 type RepoCreatePackageInput struct {
 	UserID                  uuid.UUID
+	OrganizationID          uuid.NullUUID
 	PackageName             string
 	Questionnaire           model.Questionnaire
 	IndicationCategories    model.IndicationCategories
@@ -219,10 +290,22 @@ type RepoSearchPackageInput struct {
 	Limit    int
 }
 
-// RepoDeleteAllUserResultsInput input
+// RepoDeleteAllUserResultsInput input. When Anonymize is true, the results are kept but detached from the user and
+// the child instead of being deleted, only keeping the child's age band at assessment and gender. The results soft
+// deleted before DeletedSince were deleted by the user, thus are permanently deleted instead of anonymized.
+// HardDelete is used when the user is permanently deleted, thus the user is also removed as the creator of the kept results
 type RepoDeleteAllUserResultsInput struct {
-	UserID     uuid.UUID
-	HardDelete bool
+	UserID       uuid.UUID
+	HardDelete   bool
+	Anonymize    bool
+	DeletedSince time.Time
+}
+
+// RepoDeleteAllChildResultsInput input to permanently delete the child's results. When Anonymize is true, the results
+// are kept but detached from the child and the creator, the same way as RepoDeleteAllUserResultsInput.Anonymize
+type RepoDeleteAllChildResultsInput struct {
+	ChildID   uuid.UUID
+	Anonymize bool
 }
 
 // RepoDeleteAllUserChildrenInput input
@@ -237,6 +320,27 @@ type RepoDeleteUserByIDInput struct {
 	HardDelete bool
 }
 
+// RepoRestoreAllUserResultsInput input to restore the user's soft deleted results.
+// Only the results deleted at or after DeletedSince will be restored
+type RepoRestoreAllUserResultsInput struct {
+	UserID       uuid.UUID
+	DeletedSince time.Time
+}
+
+// RepoTransferChildInput input to move the child and its data from FromUserID to ToUserID
+type RepoTransferChildInput struct {
+	ChildID    uuid.UUID
+	FromUserID uuid.UUID
+	ToUserID   uuid.UUID
+}
+
+// RepoRestoreAllUserChildrenInput input to restore the user's soft deleted children.
+// Only the children deleted at or after DeletedSince will be restored
+type RepoRestoreAllUserChildrenInput struct {
+	UserID       uuid.UUID
+	DeletedSince time.Time
+}
+
 // PackageRepo interface for PackageRepo
 type PackageRepo interface {
 	Create(ctx context.Context, input RepoCreatePackageInput, txControllers ...any) (*model.Package, error)
@@ -247,3 +351,190 @@ type PackageRepo interface {
 	FindOldestActiveAndLockedPackage(ctx context.Context) (*model.Package, error)
 	FindAllActivePackages(ctx context.Context) ([]model.Package, error)
 }
+
+// RepoCreateSessionInput input to create a new login session
+type RepoCreateSessionInput struct {
+	ID               uuid.UUID
+	UserID           uuid.UUID
+	RefreshTokenHash string
+	IPAddress        string
+	UserAgent        string
+	ExpiresAt        time.Time
+}
+
+// RepoRotateRefreshTokenInput input to replace the refresh token of a session
+type RepoRotateRefreshTokenInput struct {
+	SessionID           uuid.UUID
+	OldRefreshTokenHash string
+	NewRefreshTokenHash string
+	ExpiresAt           time.Time
+}
+
+// SessionRepository session repository interface
+type SessionRepository interface {
+	Create(ctx context.Context, input RepoCreateSessionInput) (*model.Session, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Session, error)
+	RotateRefreshToken(ctx context.Context, input RepoRotateRefreshTokenInput) (*model.Session, error)
+	RevokeByID(ctx context.Context, id uuid.UUID) error
+	RevokeAllUserSessions(ctx context.Context, userID uuid.UUID) error
+	RevokeOtherUserSessions(ctx context.Context, userID, exceptSessionID uuid.UUID) error
+	FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]model.Session, error)
+	RevokeUserSession(ctx context.Context, id, userID uuid.UUID) error
+	UpdateLastSeenAt(ctx context.Context, id uuid.UUID) error
+	HasAnySession(ctx context.Context, userID uuid.UUID) (bool, error)
+	HasSessionFromClient(ctx context.Context, userID uuid.UUID, client model.ClientInfo) (bool, error)
+}
+
+// RepoCreateEmailTokenInput input to record a newly issued email token.
+// Leave UserID as uuid.Nil if the token is not bound to any existing user
+type RepoCreateEmailTokenInput struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Purpose   string
+	ExpiresAt time.Time
+}
+
+// RepoConsumeEmailTokenInput input to redeem an email token.
+// Use uuid.Nil as UserID to redeem token not bound to any existing user
+type RepoConsumeEmailTokenInput struct {
+	ID      uuid.UUID
+	UserID  uuid.UUID
+	Purpose string
+}
+
+// EmailTokenRepository email token ledger repository interface
+type EmailTokenRepository interface {
+	Create(ctx context.Context, input RepoCreateEmailTokenInput, txController ...any) (*model.EmailToken, error)
+	Consume(ctx context.Context, input RepoConsumeEmailTokenInput, txController ...any) error
+	RevokeAllUserTokens(ctx context.Context, userID uuid.UUID, purpose string, txController ...any) error
+}
+
+// RepoCreatePasswordlessLoginInput input to store a pending passwordless login which will be discarded after ExpiresIn
+type RepoCreatePasswordlessLoginInput struct {
+	UserID        uuid.UUID
+	OTPHash       string
+	MagicLinkHash string
+	ExpiresIn     time.Duration
+}
+
+// PasswordlessLoginRepository pending passwordless login repository interface. Each user can only have one pending
+// passwordless login, creating a new one will replace the previous
+type PasswordlessLoginRepository interface {
+	Create(ctx context.Context, input RepoCreatePasswordlessLoginInput) error
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*model.PasswordlessLogin, error)
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+// MFARecoveryCodeRepository mfa recovery code repository interface
+type MFARecoveryCodeRepository interface {
+	ReplaceAll(ctx context.Context, userID uuid.UUID, codeHashes []string, txController ...any) error
+	Consume(ctx context.Context, userID uuid.UUID, codeHash string) error
+	DeleteAll(ctx context.Context, userID uuid.UUID, txController ...any) error
+}
+
+// RepoCreatePersonalAccessTokenInput input to store a newly created personal access token
+type RepoCreatePersonalAccessTokenInput struct {
+	UserID    uuid.UUID
+	Name      string
+	TokenHash string
+	Scopes    model.PersonalAccessTokenScopes
+	ExpiresAt time.Time
+}
+
+// PersonalAccessTokenRepository personal access token repository interface
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, input RepoCreatePersonalAccessTokenInput) (*model.PersonalAccessToken, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]model.PersonalAccessToken, error)
+	Revoke(ctx context.Context, id, userID uuid.UUID) error
+	UpdateLastUsedAt(ctx context.Context, id uuid.UUID) error
+}
+
+// RepoCreateAuditLogInput input to append a new entry to the audit trail
+type RepoCreateAuditLogInput struct {
+	ActorID    uuid.UUID
+	ActorRole  model.Roles
+	Action     model.AuditAction
+	TargetType model.AuditTargetType
+	TargetID   string
+	Metadata   model.AuditMetadata
+	IPAddress  string
+	UserAgent  string
+}
+
+// RepoSearchAuditLogInput search audit log input. Zero value fields are not used as filter
+type RepoSearchAuditLogInput struct {
+	ActorID       uuid.UUID
+	Action        model.AuditAction
+	TargetType    model.AuditTargetType
+	TargetID      string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Limit         int
+	Offset        int
+}
+
+// AuditLogRepository audit log repository interface
+type AuditLogRepository interface {
+	Create(ctx context.Context, input RepoCreateAuditLogInput) error
+	Search(ctx context.Context, input RepoSearchAuditLogInput) ([]model.AuditLog, error)
+	DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// RepoCompleteDataExportInput input to store the finished data export archive
+type RepoCompleteDataExportInput struct {
+	ID                uuid.UUID
+	Archive           []byte
+	DownloadTokenHash string
+	ExpiresAt         time.Time
+}
+
+// DataExportRepository data export repository interface
+type DataExportRepository interface {
+	Create(ctx context.Context, userID uuid.UUID) (*model.DataExport, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]model.DataExport, error)
+	FindByDownloadTokenHash(ctx context.Context, tokenHash string) (*model.DataExport, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status model.DataExportStatus) error
+	FailStaleByUserID(ctx context.Context, userID uuid.UUID, updatedBefore time.Time) error
+	Complete(ctx context.Context, input RepoCompleteDataExportInput) error
+}
+
+// RepoUpsertCareRelationshipInput input to assign a therapist to a child
+type RepoUpsertCareRelationshipInput struct {
+	ChildID     uuid.UUID
+	TherapistID uuid.UUID
+	GrantedBy   uuid.UUID
+	Scope       model.CareScope
+}
+
+// CareRelationshipRepository therapist to child care relationship repository interface
+type CareRelationshipRepository interface {
+	Upsert(ctx context.Context, input RepoUpsertCareRelationshipInput) (*model.CareRelationship, error)
+	FindByChildIDAndTherapistID(ctx context.Context, childID, therapistID uuid.UUID) (*model.CareRelationship, error)
+	FindByParentUserID(ctx context.Context, parentUserID uuid.UUID) ([]model.CareRelationship, error)
+	Delete(ctx context.Context, childID, therapistID uuid.UUID) error
+	DeleteByUser(ctx context.Context, childID, userID uuid.UUID, txController ...any) error
+}
+
+// RepoCreateChildGuardianInput input to add a user to the child's guardians
+type RepoCreateChildGuardianInput struct {
+	ChildID uuid.UUID
+	UserID  uuid.UUID
+	Role    model.GuardianRole
+}
+
+// ChildGuardianRepository child guardian membership repository interface
+type ChildGuardianRepository interface {
+	Create(ctx context.Context, input RepoCreateChildGuardianInput, txController ...any) (*model.ChildGuardian, error)
+	FindByChildIDAndUserID(ctx context.Context, childID, userID uuid.UUID) (*model.ChildGuardian, error)
+	FindByChildID(ctx context.Context, childID uuid.UUID) ([]model.ChildGuardian, error)
+	DeleteGuardian(ctx context.Context, childID, userID uuid.UUID) error
+	FindSuccessors(ctx context.Context, ownerUserID uuid.UUID, txController ...any) ([]model.ChildGuardian, error)
+}
+
+// OrganizationRepository organization repository interface
+type OrganizationRepository interface {
+	Create(ctx context.Context, name string) (*model.Organization, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Organization, error)
+	FindAll(ctx context.Context) ([]model.Organization, error)
+}
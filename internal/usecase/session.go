@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// SessionOutput the active login session detail
+type SessionOutput struct {
+	ID         uuid.UUID
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time
+	LastSeenAt *time.Time
+	ExpiresAt  time.Time
+	Current    bool
+}
+
+// HandleListMySessions list all the requester's active login sessions, marking the one used to make the request
+func (u *AuthUsecase) HandleListMySessions(ctx context.Context) ([]SessionOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	sessions, err := u.sessionRepo.FindActiveByUserID(ctx, requester.ID)
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).WithField("user-id", requester.ID).Error("failed to find active sessions")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	output := make([]SessionOutput, 0, len(sessions))
+	for _, session := range sessions {
+		item := SessionOutput{
+			ID:        session.ID,
+			IPAddress: session.IPAddress,
+			UserAgent: session.UserAgent,
+			CreatedAt: session.CreatedAt,
+			ExpiresAt: session.ExpiresAt,
+			Current:   session.ID == requester.SessionID,
+		}
+
+		if session.LastSeenAt.Valid {
+			item.LastSeenAt = &session.LastSeenAt.Time
+		}
+
+		output = append(output, item)
+	}
+
+	return output, nil
+}
+
+// RevokeMySessionInput input
+type RevokeMySessionInput struct {
+	SessionID uuid.UUID `validate:"required"`
+}
+
+func (rmsi RevokeMySessionInput) validate() error {
+	return common.Validator.Struct(rmsi)
+}
+
+// RevokeMySessionOutput output
+type RevokeMySessionOutput struct {
+	Message string
+}
+
+// HandleRevokeMySession revoke one of the requester's sessions, logging out the device using it.
+// Both the access token and refresh token from that session will no longer be usable
+func (u *AuthUsecase) HandleRevokeMySession(ctx context.Context, input RevokeMySessionInput) (*RevokeMySessionOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return nil, UsecaseError{
+			ErrType: ErrUnauthorized,
+			Message: ErrUnauthorized.Error(),
+		}
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	err := u.sessionRepo.RevokeUserSession(ctx, input.SessionID, requester.ID)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).WithField("session-id", input.SessionID).Error("failed to revoke session")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: "session not found or already revoked",
+		}
+	case nil:
+		break
+	}
+
+	return &RevokeMySessionOutput{
+		Message: "session revoked",
+	}, nil
+}
+
+// isNewLoginClient report whether the user never logged in using the client before. The very first login and
+// the login with unknown client are not considered new, because there is nothing to compare against.
+// Failing to check is also treated as not new, to avoid failing the login only because of the alert
+func (u *AuthUsecase) isNewLoginClient(ctx context.Context, userID uuid.UUID, client model.ClientInfo) bool {
+	if client.IsEmpty() {
+		return false
+	}
+
+	logger := logrus.WithContext(ctx).WithField("user-id", userID)
+
+	usedClient, err := u.sessionRepo.HasSessionFromClient(ctx, userID, client)
+	if err != nil {
+		logger.WithError(err).Error("failed to check the session from the same client")
+
+		return false
+	}
+
+	if usedClient {
+		return false
+	}
+
+	hasSession, err := u.sessionRepo.HasAnySession(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to check whether the user ever logged in")
+
+		return false
+	}
+
+	return hasSession
+}
+
+func (u *AuthUsecase) sendNewLoginAlertEmail(ctx context.Context, user *model.User, client model.ClientInfo) error {
+	email, err := u.sharedCryptor.Decrypt(user.Email)
+	if err != nil {
+		return err
+	}
+
+	_, err = u.mailer.SendEmail(ctx, common.SendEmailInput{
+		ReceiverName:  user.Username,
+		ReceiverEmail: email,
+		Subject:       "Login dari Perangkat Baru",
+		HTMLContent:   newLoginAlertEmailTemplate(client.IPAddress, client.UserAgent, time.Now()),
+	})
+
+	return err
+}
@@ -0,0 +1,312 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis_rate/v10"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec/internal/common"
+	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/luckyAkbar/atec/internal/usecase"
+	mockCommon "github.com/luckyAkbar/atec/mocks/internal_/common"
+	mockUsecase "github.com/luckyAkbar/atec/mocks/internal_/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthUsecase_HandleListMySessions(t *testing.T) {
+	ctx := context.Background()
+
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+
+	uc := usecase.NewAuthUsecase(nil, nil, nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil, nil)
+
+	requester := model.AuthUser{ID: uuid.New(), Role: model.RolesParent, SessionID: uuid.New()}
+	requesterCtx := model.SetUserToCtx(ctx, requester)
+	lastSeenAt := time.Now().Add(-time.Minute)
+
+	sessions := []model.Session{
+		{
+			ID:         requester.SessionID,
+			UserID:     requester.ID,
+			IPAddress:  "203.0.113.10",
+			UserAgent:  "Mozilla/5.0",
+			LastSeenAt: sql.NullTime{Time: lastSeenAt, Valid: true},
+		},
+		{
+			ID:        uuid.New(),
+			UserID:    requester.ID,
+			IPAddress: "198.51.100.20",
+			UserAgent: "okhttp/4.12.0",
+		},
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "repo error",
+			ctx:         requesterCtx,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSessionRepo.EXPECT().FindActiveByUserID(requesterCtx, requester.ID).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name: "ok",
+			ctx:  requesterCtx,
+			expectedFunctionCall: func() {
+				mockSessionRepo.EXPECT().FindActiveByUserID(requesterCtx, requester.ID).Return(sessions, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleListMySessions(tc.ctx)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assertUsecaseErrType(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, res, 2)
+
+			assert.Equal(t, requester.SessionID, res[0].ID)
+			assert.Equal(t, "203.0.113.10", res[0].IPAddress)
+			assert.Equal(t, "Mozilla/5.0", res[0].UserAgent)
+			assert.True(t, res[0].Current)
+			require.NotNil(t, res[0].LastSeenAt)
+			assert.True(t, lastSeenAt.Equal(*res[0].LastSeenAt))
+
+			assert.Equal(t, sessions[1].ID, res[1].ID)
+			assert.False(t, res[1].Current)
+			assert.Nil(t, res[1].LastSeenAt)
+		})
+	}
+}
+
+func TestAuthUsecase_HandleRevokeMySession(t *testing.T) {
+	ctx := context.Background()
+
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+
+	uc := usecase.NewAuthUsecase(nil, nil, nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil, nil)
+
+	requester := model.AuthUser{ID: uuid.New(), Role: model.RolesParent, SessionID: uuid.New()}
+	requesterCtx := model.SetUserToCtx(ctx, requester)
+	sessionID := uuid.New()
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.RevokeMySessionInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized",
+			ctx:         ctx,
+			input:       usecase.RevokeMySessionInput{SessionID: sessionID},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "missing session id",
+			ctx:         requesterCtx,
+			input:       usecase.RevokeMySessionInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "not found, owned by other user or already revoked",
+			ctx:         requesterCtx,
+			input:       usecase.RevokeMySessionInput{SessionID: sessionID},
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockSessionRepo.EXPECT().RevokeUserSession(requesterCtx, sessionID, requester.ID).Return(usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "repo error",
+			ctx:         requesterCtx,
+			input:       usecase.RevokeMySessionInput{SessionID: sessionID},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockSessionRepo.EXPECT().RevokeUserSession(requesterCtx, sessionID, requester.ID).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:  "ok",
+			ctx:   requesterCtx,
+			input: usecase.RevokeMySessionInput{SessionID: sessionID},
+			expectedFunctionCall: func() {
+				mockSessionRepo.EXPECT().RevokeUserSession(requesterCtx, sessionID, requester.ID).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := uc.HandleRevokeMySession(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+				assertUsecaseErrType(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "session revoked", res.Message)
+		})
+	}
+}
+
+func TestAuthUsecase_HandleLogin_NewLoginClientAlert(t *testing.T) {
+	mockUserRepo := mockUsecase.NewUserRepository(t)
+	mockSharedCryptor := mockCommon.NewSharedCryptorIface(t)
+	mockSessionRepo := mockUsecase.NewSessionRepository(t)
+	mockRateLimiter := mockUsecase.NewRateLimiter(t)
+	mockMailer := mockCommon.NewMailerIface(t)
+
+	uc := usecase.NewAuthUsecase(
+		mockSharedCryptor, mockUserRepo, nil, nil, nil, mockMailer, mockRateLimiter, mockSessionRepo, nil, nil, nil, nil, nil, nil,
+	)
+
+	client := model.ClientInfo{
+		IPAddress: "203.0.113.10",
+		UserAgent: "Mozilla/5.0",
+	}
+	ctx := model.SetClientInfoToCtx(context.Background(), client)
+
+	input := usecase.LoginInput{
+		Email:    "valid@sample.email",
+		Password: "validPass!!",
+	}
+	emailLookup := usecase.RepoEmailLookup{BlindIndex: "email-blind-index", LegacyEncryptedEmails: []string{"legacyEncryptedEmail"}}
+	user := model.User{
+		ID:       uuid.New(),
+		Email:    "encryptedEmail",
+		Username: "username",
+		IsActive: true,
+	}
+	session := &model.Session{
+		ID:     uuid.New(),
+		UserID: user.ID,
+	}
+
+	expectCredentialsChecked := func() {
+		mockSharedCryptor.EXPECT().LegacyEncrypt(input.Email).Return(emailLookup.LegacyEncryptedEmails, nil).Once()
+		mockSharedCryptor.EXPECT().BlindIndex(input.Email).Return(emailLookup.BlindIndex).Once()
+		mockRateLimiter.EXPECT().Allow(ctx, "login-account:"+emailLookup.BlindIndex, mock.Anything).
+			Return(&redis_rate.Result{Allowed: 1}, nil).Once()
+		mockUserRepo.EXPECT().FindByEmail(ctx, emailLookup).Return(&user, nil).Once()
+		mockSharedCryptor.EXPECT().CompareHash(mock.Anything, []byte(input.Password)).Return(nil).Once()
+	}
+
+	expectSessionIssued := func() {
+		mockSessionRepo.EXPECT().Create(ctx, mock.MatchedBy(func(input usecase.RepoCreateSessionInput) bool {
+			return input.UserID == user.ID && input.IPAddress == client.IPAddress && input.UserAgent == client.UserAgent
+		})).Return(session, nil).Once()
+		mockSharedCryptor.EXPECT().CreateJWT(mock.Anything).Return("jwtToken", nil).Once()
+	}
+
+	expectAlertSent := func(sendErr error) {
+		mockSharedCryptor.EXPECT().Decrypt(user.Email).Return(input.Email, nil).Once()
+		mockMailer.EXPECT().SendEmail(ctx, mock.MatchedBy(func(input common.SendEmailInput) bool {
+			return input.ReceiverEmail == "valid@sample.email" && input.Subject == "Login dari Perangkat Baru"
+		})).Return(nil, sendErr).Once()
+	}
+
+	testCases := []struct {
+		name                 string
+		expectedFunctionCall func()
+	}{
+		{
+			name: "login from previously used client does not send the alert",
+			expectedFunctionCall: func() {
+				expectCredentialsChecked()
+				mockSessionRepo.EXPECT().HasSessionFromClient(ctx, user.ID, client).Return(true, nil).Once()
+				expectSessionIssued()
+			},
+		},
+		{
+			name: "the very first login does not send the alert",
+			expectedFunctionCall: func() {
+				expectCredentialsChecked()
+				mockSessionRepo.EXPECT().HasSessionFromClient(ctx, user.ID, client).Return(false, nil).Once()
+				mockSessionRepo.EXPECT().HasAnySession(ctx, user.ID).Return(false, nil).Once()
+				expectSessionIssued()
+			},
+		},
+		{
+			name: "failing to check the client does not fail the login",
+			expectedFunctionCall: func() {
+				expectCredentialsChecked()
+				mockSessionRepo.EXPECT().HasSessionFromClient(ctx, user.ID, client).Return(false, assert.AnError).Once()
+				expectSessionIssued()
+			},
+		},
+		{
+			name: "login from new client sends the alert",
+			expectedFunctionCall: func() {
+				expectCredentialsChecked()
+				mockSessionRepo.EXPECT().HasSessionFromClient(ctx, user.ID, client).Return(false, nil).Once()
+				mockSessionRepo.EXPECT().HasAnySession(ctx, user.ID).Return(true, nil).Once()
+				expectSessionIssued()
+				expectAlertSent(nil)
+			},
+		},
+		{
+			name: "failing to send the alert does not fail the login",
+			expectedFunctionCall: func() {
+				expectCredentialsChecked()
+				mockSessionRepo.EXPECT().HasSessionFromClient(ctx, user.ID, client).Return(false, nil).Once()
+				mockSessionRepo.EXPECT().HasAnySession(ctx, user.ID).Return(true, nil).Once()
+				expectSessionIssued()
+				expectAlertSent(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.expectedFunctionCall()
+
+			res, err := uc.HandleLogin(ctx, input)
+			require.NoError(t, err)
+			assert.Equal(t, "jwtToken", res.Token)
+		})
+	}
+}
@@ -2,18 +2,32 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"strings"
 	"time"
 
+	"github.com/golang/freetype/truetype"
 	"github.com/google/uuid"
 	"github.com/luckyAkbar/atec/internal/common"
 	"github.com/luckyAkbar/atec/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/sweet-go/stdlib/helper"
 )
 
 // UsersUsecase contains business logic related to user entity
 type UsersUsecase struct {
-	userRepo      UserRepository
-	sharedCryptor common.SharedCryptorIface
+	userRepo             UserRepository
+	sharedCryptor        common.SharedCryptorIface
+	sessionRepo          SessionRepository
+	auditLogRepo         AuditLogRepository
+	childRepo            ChildRepository
+	resultRepo           ResultRepository
+	packageRepo          PackageRepo
+	dataExportRepo       DataExportRepository
+	careRelationshipRepo CareRelationshipRepository
+	organizationRepo     OrganizationRepository
+	mailer               common.MailerIface
+	font                 *truetype.Font
 }
 
 // UsersUsecaseIface exported interface for UsersUsecase
@@ -21,11 +35,52 @@ type UsersUsecaseIface interface {
 	GetMyProfile(ctx context.Context) (*GetMyProfileOutput, error)
 	GetTherapistData(ctx context.Context) ([]GetTherapistDataOutput, error)
 	UpdateMyProfile(ctx context.Context, input UpdateMyProfileInput) (*UpdateMyProfileOutput, error)
+	AdminSearchUsers(ctx context.Context, input AdminSearchUsersInput) ([]AdminUserOutput, error)
+	AdminChangeUserRole(ctx context.Context, input AdminChangeUserRoleInput) (*AdminUpdateUserOutput, error)
+	AdminChangeUserActivation(ctx context.Context, input AdminChangeUserActivationInput) (*AdminUpdateUserOutput, error)
+	AdminSetMFARequirement(ctx context.Context, input AdminSetMFARequirementInput) (*AdminUpdateUserOutput, error)
+	AdminUnlockUser(ctx context.Context, input AdminUnlockUserInput) (*AdminUpdateUserOutput, error)
+	AdminSearchAuditLogs(ctx context.Context, input AdminSearchAuditLogsInput) ([]AuditLogOutput, error)
+	HandleRequestDataExport(ctx context.Context) (*DataExportOutput, error)
+	HandleListMyDataExports(ctx context.Context) ([]DataExportOutput, error)
+	HandleDownloadDataExport(ctx context.Context, input DownloadDataExportInput) (*DownloadDataExportOutput, error)
+	HandleGrantTherapistAccess(ctx context.Context, input GrantTherapistAccessInput) (*TherapistAccessOutput, error)
+	HandleRevokeTherapistAccess(ctx context.Context, input RevokeTherapistAccessInput) error
+	HandleListGrantedTherapistAccess(ctx context.Context) ([]TherapistAccessOutput, error)
+	AdminCreateOrganization(ctx context.Context, input AdminCreateOrganizationInput) (*OrganizationOutput, error)
+	AdminListOrganizations(ctx context.Context) ([]OrganizationOutput, error)
+	AdminSetUserOrganization(ctx context.Context, input AdminSetUserOrganizationInput) (*AdminUpdateUserOutput, error)
 }
 
 // NewUsersUsecase create new UsersUsecase instance
-func NewUsersUsecase(userRepo UserRepository, sharedCryptor common.SharedCryptorIface) *UsersUsecase {
-	return &UsersUsecase{userRepo: userRepo, sharedCryptor: sharedCryptor}
+func NewUsersUsecase(
+	userRepo UserRepository,
+	sharedCryptor common.SharedCryptorIface,
+	sessionRepo SessionRepository,
+	auditLogRepo AuditLogRepository,
+	childRepo ChildRepository,
+	resultRepo ResultRepository,
+	packageRepo PackageRepo,
+	dataExportRepo DataExportRepository,
+	careRelationshipRepo CareRelationshipRepository,
+	organizationRepo OrganizationRepository,
+	mailer common.MailerIface,
+	font *truetype.Font,
+) *UsersUsecase {
+	return &UsersUsecase{
+		userRepo:             userRepo,
+		sharedCryptor:        sharedCryptor,
+		sessionRepo:          sessionRepo,
+		auditLogRepo:         auditLogRepo,
+		childRepo:            childRepo,
+		resultRepo:           resultRepo,
+		packageRepo:          packageRepo,
+		dataExportRepo:       dataExportRepo,
+		careRelationshipRepo: careRelationshipRepo,
+		organizationRepo:     organizationRepo,
+		mailer:               mailer,
+		font:                 font,
+	}
 }
 
 // decryptUserData decrypts sensitive fields on user and returns plain values.
@@ -136,7 +191,8 @@ type GetTherapistDataOutput struct {
 	UpdatedAt time.Time
 }
 
-// GetTherapistData returns all users that have therapist role
+// GetTherapistData returns all users that have therapist role. The organization members will only find
+// the therapists of their own organization
 func (u *UsersUsecase) GetTherapistData(ctx context.Context) ([]GetTherapistDataOutput, error) {
 	requester := model.GetUserFromCtx(ctx)
 	if requester == nil {
@@ -146,7 +202,20 @@ func (u *UsersUsecase) GetTherapistData(ctx context.Context) ([]GetTherapistData
 		}
 	}
 
-	users, err := u.userRepo.GetUsersByRoles(ctx, model.RolesTherapist)
+	var (
+		users []model.User
+		err   error
+	)
+
+	if requester.OrganizationID != uuid.Nil {
+		users, err = u.userRepo.Search(ctx, RepoSearchUserInput{
+			Role:           model.RolesTherapist,
+			OrganizationID: requester.OrganizationID,
+		})
+	} else {
+		users, err = u.userRepo.GetUsersByRoles(ctx, model.RolesTherapist)
+	}
+
 	if err != nil {
 		switch err {
 		default:
@@ -265,3 +334,460 @@ func (u *UsersUsecase) UpdateMyProfile(ctx context.Context, input UpdateMyProfil
 		Message: "ok",
 	}, nil
 }
+
+// AdminSearchUsersInput input
+type AdminSearchUsersInput struct {
+	Email          string      `validate:"omitempty,email"`
+	Username       string      `validate:"max=255"`
+	Role           model.Roles `validate:"omitempty,oneof=administrator therapist parent"`
+	IsActive       *bool
+	OrganizationID uuid.UUID
+	Limit          int `validate:"required,min=1,max=100"`
+	Offset         int `validate:"min=0"`
+}
+
+func (i AdminSearchUsersInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// AdminUserOutput user data as seen by the administrator. LockedUntil will be nil if the account is not locked,
+// and OrganizationID will be nil if the user does not belong to any organization
+type AdminUserOutput struct {
+	ID                  uuid.UUID
+	Username            string
+	Email               string
+	PhoneNumber         *string
+	IsActive            bool
+	Roles               model.Roles
+	FailedLoginAttempts int
+	LockedUntil         *time.Time
+	OrganizationID      *uuid.UUID
+	IsOrganizationAdmin bool
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// AdminSearchUsers allow administrator to search and paginate all registered users.
+// Email and phone number will be returned decrypted
+func (u *UsersUsecase) AdminSearchUsers(ctx context.Context, input AdminSearchUsersInput) ([]AdminUserOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if err := Authorize(requester, ActionManageUser, RelationNone); err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithField("input", helper.Dump(input))
+
+	// email is stored encrypted, so it is only searchable by its blind index
+	var emailLookup *RepoEmailLookup
+
+	if input.Email != "" {
+		lookup, err := newEmailLookup(u.sharedCryptor, input.Email)
+		if err != nil {
+			logger.WithError(err).Error("failed to encrypt email search parameter")
+
+			return nil, UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		}
+
+		emailLookup = &lookup
+	}
+
+	users, err := u.userRepo.Search(ctx, RepoSearchUserInput{
+		Role:           input.Role,
+		Email:          emailLookup,
+		Username:       input.Username,
+		IsActive:       input.IsActive,
+		OrganizationID: input.OrganizationID,
+		Limit:          input.Limit,
+		Offset:         input.Offset,
+	})
+
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to search users data")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	output := make([]AdminUserOutput, 0, len(users))
+
+	for i := range users {
+		decryptedEmail, phonePtr, _, decErr := u.decryptUserData(&users[i])
+		if decErr != nil {
+			logger.WithError(decErr).WithField("user-id", users[i].ID).Error("failed to decrypt user data")
+
+			return nil, UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		}
+
+		var lockedUntil *time.Time
+		if users[i].IsLocked() {
+			lockedUntil = &users[i].LockedUntil.Time
+		}
+
+		var organizationID *uuid.UUID
+		if users[i].OrganizationID.Valid {
+			organizationID = &users[i].OrganizationID.UUID
+		}
+
+		output = append(output, AdminUserOutput{
+			ID:                  users[i].ID,
+			Username:            users[i].Username,
+			Email:               decryptedEmail,
+			PhoneNumber:         phonePtr,
+			IsActive:            users[i].IsActive,
+			Roles:               users[i].Roles,
+			FailedLoginAttempts: users[i].FailedLoginAttempts,
+			LockedUntil:         lockedUntil,
+			OrganizationID:      organizationID,
+			IsOrganizationAdmin: users[i].IsOrganizationAdmin,
+			CreatedAt:           users[i].CreatedAt,
+			UpdatedAt:           users[i].UpdatedAt,
+		})
+	}
+
+	return output, nil
+}
+
+// AdminUpdateUserOutput output
+type AdminUpdateUserOutput struct {
+	Message string
+}
+
+// AdminChangeUserRoleInput input
+type AdminChangeUserRoleInput struct {
+	UserID uuid.UUID   `validate:"required"`
+	Role   model.Roles `validate:"required,oneof=administrator therapist parent"`
+}
+
+func (i AdminChangeUserRoleInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// AdminChangeUserRole allow administrator to change other user's role. Because the role is
+// embedded in the access token, all the target user's sessions will be revoked to make the
+// new role effective immediately. Only therapist can belong to an organization, thus the
+// organization membership is removed when the role is changed to other than therapist
+func (u *UsersUsecase) AdminChangeUserRole(ctx context.Context, input AdminChangeUserRoleInput) (*AdminUpdateUserOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if err := Authorize(requester, ActionManageUser, RelationNone); err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	if input.UserID == requester.ID {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "administrator can not change their own role",
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithField("input", helper.Dump(input))
+
+	user, err := u.userRepo.FindByID(ctx, input.UserID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by id")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	updateInput := RepoUpdateUserInput{Roles: input.Role}
+	if !IsAllowed(input.Role, ActionJoinOrganization, RelationNone) && user.OrganizationID.Valid {
+		isOrganizationAdmin := false
+		updateInput.OrganizationID = &uuid.NullUUID{}
+		updateInput.IsOrganizationAdmin = &isOrganizationAdmin
+	}
+
+	if _, err := u.userRepo.Update(ctx, input.UserID, updateInput); err != nil {
+		logger.WithError(err).Error("failed to update user role")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	recordAuditLog(ctx, u.auditLogRepo, auditEntry{
+		Action:     model.AuditActionChangeUserRole,
+		TargetType: model.AuditTargetUser,
+		TargetID:   input.UserID.String(),
+		Metadata: model.AuditMetadata{
+			"previous_role": user.Roles,
+			"new_role":      input.Role,
+		},
+	})
+
+	if err := u.sessionRepo.RevokeAllUserSessions(ctx, input.UserID); err != nil {
+		logger.WithError(err).Error("failed to revoke user sessions after role change")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	return &AdminUpdateUserOutput{
+		Message: "ok",
+	}, nil
+}
+
+// AdminChangeUserActivationInput input
+type AdminChangeUserActivationInput struct {
+	UserID   uuid.UUID `validate:"required"`
+	IsActive *bool     `validate:"required"`
+}
+
+func (i AdminChangeUserActivationInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// AdminChangeUserActivation allow administrator to deactivate or reactivate other user's account.
+// Deactivating an account will also revoke all of its sessions
+func (u *UsersUsecase) AdminChangeUserActivation(
+	ctx context.Context,
+	input AdminChangeUserActivationInput,
+) (*AdminUpdateUserOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if err := Authorize(requester, ActionManageUser, RelationNone); err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	if input.UserID == requester.ID {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "administrator can not change their own active status",
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithField("input", helper.Dump(input))
+
+	user, err := u.userRepo.FindByID(ctx, input.UserID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by id")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	// activating it directly would leave the deleted results and children behind
+	if *input.IsActive && user.IsDeletionScheduled() {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "this account is scheduled for deletion, cancel the deletion instead",
+		}
+	}
+
+	if _, err := u.userRepo.Update(ctx, input.UserID, RepoUpdateUserInput{IsActive: input.IsActive}); err != nil {
+		logger.WithError(err).Error("failed to update user active status")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if !*input.IsActive {
+		if err := u.sessionRepo.RevokeAllUserSessions(ctx, input.UserID); err != nil {
+			logger.WithError(err).Error("failed to revoke user sessions after deactivation")
+
+			return nil, UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		}
+	}
+
+	return &AdminUpdateUserOutput{
+		Message: "ok",
+	}, nil
+}
+
+// AdminSetMFARequirementInput input
+type AdminSetMFARequirementInput struct {
+	UserID   uuid.UUID `validate:"required"`
+	Required *bool     `validate:"required"`
+}
+
+func (i AdminSetMFARequirementInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// AdminSetMFARequirement allow administrator to require therapist or other administrator account
+// to use two-factor authentication. When required but the user still not enrolled, all of the user's
+// sessions will be revoked so the enrollment is enforced on the next login
+func (u *UsersUsecase) AdminSetMFARequirement(
+	ctx context.Context,
+	input AdminSetMFARequirementInput,
+) (*AdminUpdateUserOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if err := Authorize(requester, ActionManageUser, RelationNone); err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithField("input", helper.Dump(input))
+
+	user, err := u.userRepo.FindByID(ctx, input.UserID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find user by id")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	if !IsAllowed(user.Roles, ActionUseMFA, RelationNone) {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: "two-factor authentication is only available for therapist and administrator",
+		}
+	}
+
+	if _, err := u.userRepo.Update(ctx, input.UserID, RepoUpdateUserInput{MFARequired: input.Required}); err != nil {
+		logger.WithError(err).Error("failed to update user mfa requirement")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	}
+
+	if *input.Required && !user.MFAEnabled {
+		if err := u.sessionRepo.RevokeAllUserSessions(ctx, input.UserID); err != nil {
+			logger.WithError(err).Error("failed to revoke user sessions after requiring mfa")
+
+			return nil, UsecaseError{
+				ErrType: ErrInternal,
+				Message: ErrInternal.Error(),
+			}
+		}
+	}
+
+	return &AdminUpdateUserOutput{
+		Message: "ok",
+	}, nil
+}
+
+// AdminUnlockUserInput input
+type AdminUnlockUserInput struct {
+	UserID uuid.UUID `validate:"required"`
+}
+
+func (i AdminUnlockUserInput) validate() error {
+	return common.Validator.Struct(i)
+}
+
+// AdminUnlockUser allow administrator to unlock account locked due to too many failed login attempts
+// and reset the failed login counter
+func (u *UsersUsecase) AdminUnlockUser(ctx context.Context, input AdminUnlockUserInput) (*AdminUpdateUserOutput, error) {
+	requester := model.GetUserFromCtx(ctx)
+	if err := Authorize(requester, ActionManageUser, RelationNone); err != nil {
+		return nil, err
+	}
+
+	if err := input.validate(); err != nil {
+		return nil, UsecaseError{
+			ErrType: ErrBadRequest,
+			Message: err.Error(),
+		}
+	}
+
+	logger := logrus.WithContext(ctx).WithField("input", helper.Dump(input))
+
+	noFailure := 0
+	_, err := u.userRepo.Update(ctx, input.UserID, RepoUpdateUserInput{
+		FailedLoginAttempts: &noFailure,
+		LockedUntil:         &sql.NullTime{Valid: false},
+	})
+
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to unlock user account")
+
+		return nil, UsecaseError{
+			ErrType: ErrInternal,
+			Message: ErrInternal.Error(),
+		}
+	case ErrRepoNotFound:
+		return nil, UsecaseError{
+			ErrType: ErrNotFound,
+			Message: ErrNotFound.Error(),
+		}
+	case nil:
+		break
+	}
+
+	return &AdminUpdateUserOutput{
+		Message: "ok",
+	}, nil
+}
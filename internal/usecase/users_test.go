@@ -21,7 +21,7 @@ func TestUsersUsecase_GetMyProfile(t *testing.T) {
 
 	mockUserRepo := mock_usecase.NewUserRepository(t)
 	mockCryptor := mockCommon.NewSharedCryptorIface(t)
-	usersUsecase := usecase.NewUsersUsecase(mockUserRepo, mockCryptor)
+	usersUsecase := usecase.NewUsersUsecase(mockUserRepo, mockCryptor, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	now := time.Now()
 
@@ -269,7 +269,7 @@ func TestUsersUsecase_GetTherapistData(t *testing.T) {
 	ctx := context.Background()
 
 	mockUserRepo := mock_usecase.NewUserRepository(t)
-	usersUsecase := usecase.NewUsersUsecase(mockUserRepo, mockCommon.NewSharedCryptorIface(t))
+	usersUsecase := usecase.NewUsersUsecase(mockUserRepo, mockCommon.NewSharedCryptorIface(t), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	now := time.Now()
 
@@ -288,6 +288,12 @@ func TestUsersUsecase_GetTherapistData(t *testing.T) {
 
 		return model.SetUserToCtx(ctx, requester)
 	}()
+	organizationID := uuid.New()
+	ctxOrganizationMember := model.SetUserToCtx(ctx, model.AuthUser{
+		ID:             uuid.New(),
+		Role:           model.RolesTherapist,
+		OrganizationID: organizationID,
+	})
 
 	testCases := []struct {
 		name                 string
@@ -365,6 +371,35 @@ func TestUsersUsecase_GetTherapistData(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "success - organization member only sees the organization's therapists",
+			ctx:     ctxOrganizationMember,
+			wantErr: false,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().Search(ctxOrganizationMember, usecase.RepoSearchUserInput{
+					Role:           model.RolesTherapist,
+					OrganizationID: organizationID,
+				}).Return([]model.User{
+					{
+						Username:       "t3",
+						IsActive:       true,
+						Roles:          model.RolesTherapist,
+						OrganizationID: uuid.NullUUID{UUID: organizationID, Valid: true},
+						CreatedAt:      now,
+						UpdatedAt:      now,
+					},
+				}, nil).Once()
+			},
+			expectedOutput: []usecase.GetTherapistDataOutput{
+				{
+					Username:  "t3",
+					IsActive:  true,
+					Roles:     model.RolesTherapist,
+					CreatedAt: now,
+					UpdatedAt: now,
+				},
+			},
+		},
 	}
 
 	for idx := range testCases {
@@ -414,7 +449,7 @@ func TestUsersUsecase_UpdateMyProfile(t *testing.T) {
 
 	mockUserRepo := mock_usecase.NewUserRepository(t)
 	mockCryptor := mockCommon.NewSharedCryptorIface(t)
-	usersUsecase := usecase.NewUsersUsecase(mockUserRepo, mockCryptor)
+	usersUsecase := usecase.NewUsersUsecase(mockUserRepo, mockCryptor, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	user := model.AuthUser{ID: uuid.New(), Role: model.RolesParent}
 	userCtx := model.SetUserToCtx(ctx, user)
@@ -574,3 +609,725 @@ func TestUsersUsecase_UpdateMyProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestUsersUsecase_AdminSearchUsers(t *testing.T) {
+	ctx := context.Background()
+
+	mockUserRepo := mock_usecase.NewUserRepository(t)
+	mockCryptor := mockCommon.NewSharedCryptorIface(t)
+	usersUsecase := usecase.NewUsersUsecase(mockUserRepo, mockCryptor, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	adminCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator})
+	parentCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesParent})
+
+	isActive := true
+	email := "user@example.com"
+	phone := "+6281234567890"
+
+	user := model.User{
+		ID:                  uuid.New(),
+		Email:               "enc-email",
+		Username:            "user",
+		IsActive:            true,
+		Roles:               model.RolesParent,
+		PhoneNumber:         sql.NullString{String: "enc-phone", Valid: true},
+		FailedLoginAttempts: 5,
+		LockedUntil:         sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	validInput := usecase.AdminSearchUsersInput{
+		Email:    email,
+		Username: "us",
+		Role:     model.RolesParent,
+		IsActive: &isActive,
+		Limit:    10,
+		Offset:   0,
+	}
+
+	repoInput := usecase.RepoSearchUserInput{
+		Role:     model.RolesParent,
+		Email:    &usecase.RepoEmailLookup{BlindIndex: "email-blind-index", LegacyEncryptedEmails: []string{"enc-email"}},
+		Username: "us",
+		IsActive: &isActive,
+		Limit:    10,
+		Offset:   0,
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.AdminSearchUsersInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized when requester not in context",
+			ctx:         ctx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "forbidden for non administrator",
+			ctx:         parentCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:        "validation error - missing limit",
+			ctx:         adminCtx,
+			input:       usecase.AdminSearchUsersInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "validation error - unknown role",
+			ctx:         adminCtx,
+			input:       usecase.AdminSearchUsersInput{Role: model.Roles("superuser"), Limit: 10},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "failed to encrypt email",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockCryptor.EXPECT().LegacyEncrypt(email).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "repository error",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockCryptor.EXPECT().LegacyEncrypt(email).Return([]string{"enc-email"}, nil).Once()
+				mockCryptor.EXPECT().BlindIndex(email).Return("email-blind-index").Once()
+				mockUserRepo.EXPECT().Search(adminCtx, repoInput).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "no users found",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockCryptor.EXPECT().LegacyEncrypt(email).Return([]string{"enc-email"}, nil).Once()
+				mockCryptor.EXPECT().BlindIndex(email).Return("email-blind-index").Once()
+				mockUserRepo.EXPECT().Search(adminCtx, repoInput).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "failed to decrypt user data",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockCryptor.EXPECT().LegacyEncrypt(email).Return([]string{"enc-email"}, nil).Once()
+				mockCryptor.EXPECT().BlindIndex(email).Return("email-blind-index").Once()
+				mockUserRepo.EXPECT().Search(adminCtx, repoInput).Return([]model.User{user}, nil).Once()
+				mockCryptor.EXPECT().Decrypt("enc-email").Return("", assert.AnError).Once()
+			},
+		},
+		{
+			name:  "success",
+			ctx:   adminCtx,
+			input: validInput,
+			expectedFunctionCall: func() {
+				mockCryptor.EXPECT().LegacyEncrypt(email).Return([]string{"enc-email"}, nil).Once()
+				mockCryptor.EXPECT().BlindIndex(email).Return("email-blind-index").Once()
+				mockUserRepo.EXPECT().Search(adminCtx, repoInput).Return([]model.User{user}, nil).Once()
+				mockCryptor.EXPECT().Decrypt("enc-email").Return(email, nil).Once()
+				mockCryptor.EXPECT().Decrypt("enc-phone").Return(phone, nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := usersUsecase.AdminSearchUsers(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+
+				if tc.expectedErr != nil {
+					ucErr, ok := err.(usecase.UsecaseError)
+					require.True(t, ok)
+					assert.Equal(t, tc.expectedErr, ucErr.ErrType)
+				}
+
+				assert.Nil(t, res)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, user.ID, res[0].ID)
+			assert.Equal(t, email, res[0].Email)
+			require.NotNil(t, res[0].PhoneNumber)
+			assert.Equal(t, phone, *res[0].PhoneNumber)
+			assert.Equal(t, user.FailedLoginAttempts, res[0].FailedLoginAttempts)
+			require.NotNil(t, res[0].LockedUntil)
+			assert.Equal(t, user.LockedUntil.Time, *res[0].LockedUntil)
+		})
+	}
+}
+
+func TestUsersUsecase_AdminChangeUserRole(t *testing.T) {
+	ctx := context.Background()
+
+	mockUserRepo := mock_usecase.NewUserRepository(t)
+	mockSessionRepo := mock_usecase.NewSessionRepository(t)
+	mockAuditLogRepo := mock_usecase.NewAuditLogRepository(t)
+	usersUsecase := usecase.NewUsersUsecase(mockUserRepo, nil, mockSessionRepo, mockAuditLogRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	admin := model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator}
+	adminCtx := model.SetUserToCtx(ctx, admin)
+	parentCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesParent})
+
+	targetID := uuid.New()
+	validInput := usecase.AdminChangeUserRoleInput{
+		UserID: targetID,
+		Role:   model.RolesTherapist,
+	}
+	roleChangeAuditLog := usecase.RepoCreateAuditLogInput{
+		ActorID:    admin.ID,
+		ActorRole:  model.RolesAdministrator,
+		Action:     model.AuditActionChangeUserRole,
+		TargetType: model.AuditTargetUser,
+		TargetID:   targetID.String(),
+		Metadata: model.AuditMetadata{
+			"previous_role": model.RolesParent,
+			"new_role":      model.RolesTherapist,
+		},
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.AdminChangeUserRoleInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized when requester not in context",
+			ctx:         ctx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "forbidden for non administrator",
+			ctx:         parentCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:        "validation error - unknown role",
+			ctx:         adminCtx,
+			input:       usecase.AdminChangeUserRoleInput{UserID: targetID, Role: model.Roles("superuser")},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "administrator can not change their own role",
+			ctx:         adminCtx,
+			input:       usecase.AdminChangeUserRoleInput{UserID: admin.ID, Role: model.RolesParent},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "user not found",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "failed to find user",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to update role",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).Return(&model.User{ID: targetID, Roles: model.RolesParent}, nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, targetID, usecase.RepoUpdateUserInput{Roles: model.RolesTherapist}).
+					Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to revoke sessions",
+			ctx:         adminCtx,
+			input:       validInput,
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).Return(&model.User{ID: targetID, Roles: model.RolesParent}, nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, targetID, usecase.RepoUpdateUserInput{Roles: model.RolesTherapist}).
+					Return(&model.User{}, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(adminCtx, roleChangeAuditLog).Return(nil).Once()
+				mockSessionRepo.EXPECT().RevokeAllUserSessions(adminCtx, targetID).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:  "success",
+			ctx:   adminCtx,
+			input: validInput,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).Return(&model.User{ID: targetID, Roles: model.RolesParent}, nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, targetID, usecase.RepoUpdateUserInput{Roles: model.RolesTherapist}).
+					Return(&model.User{}, nil).Once()
+				mockAuditLogRepo.EXPECT().Create(adminCtx, roleChangeAuditLog).Return(nil).Once()
+				mockSessionRepo.EXPECT().RevokeAllUserSessions(adminCtx, targetID).Return(nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := usersUsecase.AdminChangeUserRole(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+
+				if tc.expectedErr != nil {
+					ucErr, ok := err.(usecase.UsecaseError)
+					require.True(t, ok)
+					assert.Equal(t, tc.expectedErr, ucErr.ErrType)
+				}
+
+				assert.Nil(t, res)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, res)
+			assert.Equal(t, "ok", res.Message)
+		})
+	}
+}
+
+func TestUsersUsecase_AdminChangeUserActivation(t *testing.T) {
+	ctx := context.Background()
+
+	mockUserRepo := mock_usecase.NewUserRepository(t)
+	mockSessionRepo := mock_usecase.NewSessionRepository(t)
+	usersUsecase := usecase.NewUsersUsecase(mockUserRepo, nil, mockSessionRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	admin := model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator}
+	adminCtx := model.SetUserToCtx(ctx, admin)
+	therapistCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesTherapist})
+
+	targetID := uuid.New()
+	activeTrue := true
+	activeFalse := false
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.AdminChangeUserActivationInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized when requester not in context",
+			ctx:         ctx,
+			input:       usecase.AdminChangeUserActivationInput{UserID: targetID, IsActive: &activeFalse},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "forbidden for non administrator",
+			ctx:         therapistCtx,
+			input:       usecase.AdminChangeUserActivationInput{UserID: targetID, IsActive: &activeFalse},
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:        "validation error - missing active status",
+			ctx:         adminCtx,
+			input:       usecase.AdminChangeUserActivationInput{UserID: targetID},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "administrator can not deactivate themselves",
+			ctx:         adminCtx,
+			input:       usecase.AdminChangeUserActivationInput{UserID: admin.ID, IsActive: &activeFalse},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "user not found",
+			ctx:         adminCtx,
+			input:       usecase.AdminChangeUserActivationInput{UserID: targetID, IsActive: &activeFalse},
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "account scheduled for deletion can not be activated directly",
+			ctx:         adminCtx,
+			input:       usecase.AdminChangeUserActivationInput{UserID: targetID, IsActive: &activeTrue},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).Return(&model.User{
+					ID:                  targetID,
+					DeletionRequestedAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil).Once()
+			},
+		},
+		{
+			name:        "failed to update active status",
+			ctx:         adminCtx,
+			input:       usecase.AdminChangeUserActivationInput{UserID: targetID, IsActive: &activeFalse},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).Return(&model.User{ID: targetID}, nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, targetID, usecase.RepoUpdateUserInput{IsActive: &activeFalse}).
+					Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:        "failed to revoke sessions on deactivation",
+			ctx:         adminCtx,
+			input:       usecase.AdminChangeUserActivationInput{UserID: targetID, IsActive: &activeFalse},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).Return(&model.User{ID: targetID}, nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, targetID, usecase.RepoUpdateUserInput{IsActive: &activeFalse}).
+					Return(&model.User{}, nil).Once()
+				mockSessionRepo.EXPECT().RevokeAllUserSessions(adminCtx, targetID).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:  "success deactivating revokes all sessions",
+			ctx:   adminCtx,
+			input: usecase.AdminChangeUserActivationInput{UserID: targetID, IsActive: &activeFalse},
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).Return(&model.User{ID: targetID}, nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, targetID, usecase.RepoUpdateUserInput{IsActive: &activeFalse}).
+					Return(&model.User{}, nil).Once()
+				mockSessionRepo.EXPECT().RevokeAllUserSessions(adminCtx, targetID).Return(nil).Once()
+			},
+		},
+		{
+			name:  "success reactivating",
+			ctx:   adminCtx,
+			input: usecase.AdminChangeUserActivationInput{UserID: targetID, IsActive: &activeTrue},
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).Return(&model.User{ID: targetID}, nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, targetID, usecase.RepoUpdateUserInput{IsActive: &activeTrue}).
+					Return(&model.User{}, nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := usersUsecase.AdminChangeUserActivation(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+
+				if tc.expectedErr != nil {
+					ucErr, ok := err.(usecase.UsecaseError)
+					require.True(t, ok)
+					assert.Equal(t, tc.expectedErr, ucErr.ErrType)
+				}
+
+				assert.Nil(t, res)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, res)
+			assert.Equal(t, "ok", res.Message)
+		})
+	}
+}
+
+func TestUsersUsecase_AdminSetMFARequirement(t *testing.T) {
+	ctx := context.Background()
+
+	mockUserRepo := mock_usecase.NewUserRepository(t)
+	mockSessionRepo := mock_usecase.NewSessionRepository(t)
+	usersUsecase := usecase.NewUsersUsecase(mockUserRepo, nil, mockSessionRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	adminCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator})
+	therapistCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesTherapist})
+
+	targetID := uuid.New()
+	required := true
+	notRequired := false
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.AdminSetMFARequirementInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized when requester not in context",
+			ctx:         ctx,
+			input:       usecase.AdminSetMFARequirementInput{UserID: targetID, Required: &required},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "forbidden for non administrator",
+			ctx:         therapistCtx,
+			input:       usecase.AdminSetMFARequirementInput{UserID: targetID, Required: &required},
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:        "validation error - missing requirement",
+			ctx:         adminCtx,
+			input:       usecase.AdminSetMFARequirementInput{UserID: targetID},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "parent account can not be required to use mfa",
+			ctx:         adminCtx,
+			input:       usecase.AdminSetMFARequirementInput{UserID: targetID, Required: &required},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).Return(&model.User{ID: targetID, Roles: model.RolesParent}, nil).Once()
+			},
+		},
+		{
+			name:        "failed to update mfa requirement",
+			ctx:         adminCtx,
+			input:       usecase.AdminSetMFARequirementInput{UserID: targetID, Required: &required},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).Return(&model.User{ID: targetID, Roles: model.RolesTherapist}, nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, targetID, usecase.RepoUpdateUserInput{MFARequired: &required}).
+					Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:  "success requiring not yet enrolled user revokes all sessions",
+			ctx:   adminCtx,
+			input: usecase.AdminSetMFARequirementInput{UserID: targetID, Required: &required},
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).Return(&model.User{ID: targetID, Roles: model.RolesTherapist}, nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, targetID, usecase.RepoUpdateUserInput{MFARequired: &required}).
+					Return(&model.User{}, nil).Once()
+				mockSessionRepo.EXPECT().RevokeAllUserSessions(adminCtx, targetID).Return(nil).Once()
+			},
+		},
+		{
+			name:  "success requiring already enrolled user",
+			ctx:   adminCtx,
+			input: usecase.AdminSetMFARequirementInput{UserID: targetID, Required: &required},
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).
+					Return(&model.User{ID: targetID, Roles: model.RolesTherapist, MFAEnabled: true}, nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, targetID, usecase.RepoUpdateUserInput{MFARequired: &required}).
+					Return(&model.User{}, nil).Once()
+			},
+		},
+		{
+			name:  "success unrequiring",
+			ctx:   adminCtx,
+			input: usecase.AdminSetMFARequirementInput{UserID: targetID, Required: &notRequired},
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().FindByID(adminCtx, targetID).Return(&model.User{ID: targetID, Roles: model.RolesAdministrator}, nil).Once()
+				mockUserRepo.EXPECT().Update(adminCtx, targetID, usecase.RepoUpdateUserInput{MFARequired: &notRequired}).
+					Return(&model.User{}, nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := usersUsecase.AdminSetMFARequirement(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+
+				if tc.expectedErr != nil {
+					ucErr, ok := err.(usecase.UsecaseError)
+					require.True(t, ok)
+					assert.Equal(t, tc.expectedErr, ucErr.ErrType)
+				}
+
+				assert.Nil(t, res)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, res)
+			assert.Equal(t, "ok", res.Message)
+		})
+	}
+}
+
+func TestUsersUsecase_AdminUnlockUser(t *testing.T) {
+	ctx := context.Background()
+
+	mockUserRepo := mock_usecase.NewUserRepository(t)
+	usersUsecase := usecase.NewUsersUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	adminCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesAdministrator})
+	therapistCtx := model.SetUserToCtx(ctx, model.AuthUser{ID: uuid.New(), Role: model.RolesTherapist})
+
+	targetID := uuid.New()
+	noFailure := 0
+	resetInput := usecase.RepoUpdateUserInput{
+		FailedLoginAttempts: &noFailure,
+		LockedUntil:         &sql.NullTime{Valid: false},
+	}
+
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		input                usecase.AdminUnlockUserInput
+		wantErr              bool
+		expectedErr          error
+		expectedFunctionCall func()
+	}{
+		{
+			name:        "unauthorized when requester not in context",
+			ctx:         ctx,
+			input:       usecase.AdminUnlockUserInput{UserID: targetID},
+			wantErr:     true,
+			expectedErr: usecase.ErrUnauthorized,
+		},
+		{
+			name:        "forbidden for non administrator",
+			ctx:         therapistCtx,
+			input:       usecase.AdminUnlockUserInput{UserID: targetID},
+			wantErr:     true,
+			expectedErr: usecase.ErrForbidden,
+		},
+		{
+			name:        "validation error - missing user id",
+			ctx:         adminCtx,
+			input:       usecase.AdminUnlockUserInput{},
+			wantErr:     true,
+			expectedErr: usecase.ErrBadRequest,
+		},
+		{
+			name:        "user not found",
+			ctx:         adminCtx,
+			input:       usecase.AdminUnlockUserInput{UserID: targetID},
+			wantErr:     true,
+			expectedErr: usecase.ErrNotFound,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().Update(adminCtx, targetID, resetInput).Return(nil, usecase.ErrRepoNotFound).Once()
+			},
+		},
+		{
+			name:        "repository error",
+			ctx:         adminCtx,
+			input:       usecase.AdminUnlockUserInput{UserID: targetID},
+			wantErr:     true,
+			expectedErr: usecase.ErrInternal,
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().Update(adminCtx, targetID, resetInput).Return(nil, assert.AnError).Once()
+			},
+		},
+		{
+			name:  "success",
+			ctx:   adminCtx,
+			input: usecase.AdminUnlockUserInput{UserID: targetID},
+			expectedFunctionCall: func() {
+				mockUserRepo.EXPECT().Update(adminCtx, targetID, resetInput).Return(&model.User{}, nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedFunctionCall != nil {
+				tc.expectedFunctionCall()
+			}
+
+			res, err := usersUsecase.AdminUnlockUser(tc.ctx, tc.input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+
+				if tc.expectedErr != nil {
+					ucErr, ok := err.(usecase.UsecaseError)
+					require.True(t, ok)
+					assert.Equal(t, tc.expectedErr, ucErr.ErrType)
+				}
+
+				assert.Nil(t, res)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, res)
+			assert.Equal(t, "ok", res.Message)
+		})
+	}
+}
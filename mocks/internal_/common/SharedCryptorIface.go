@@ -5,7 +5,6 @@ package common
 import (
 	jwt "github.com/golang-jwt/jwt/v5"
 	common "github.com/luckyAkbar/atec/internal/common"
-
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -22,6 +21,52 @@ func (_m *SharedCryptorIface) EXPECT() *SharedCryptorIface_Expecter {
 	return &SharedCryptorIface_Expecter{mock: &_m.Mock}
 }
 
+// BlindIndex provides a mock function with given fields: value
+func (_m *SharedCryptorIface) BlindIndex(value string) string {
+	ret := _m.Called(value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlindIndex")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(value)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// SharedCryptorIface_BlindIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlindIndex'
+type SharedCryptorIface_BlindIndex_Call struct {
+	*mock.Call
+}
+
+// BlindIndex is a helper method to define mock.On call
+//   - value string
+func (_e *SharedCryptorIface_Expecter) BlindIndex(value interface{}) *SharedCryptorIface_BlindIndex_Call {
+	return &SharedCryptorIface_BlindIndex_Call{Call: _e.mock.On("BlindIndex", value)}
+}
+
+func (_c *SharedCryptorIface_BlindIndex_Call) Run(run func(value string)) *SharedCryptorIface_BlindIndex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *SharedCryptorIface_BlindIndex_Call) Return(_a0 string) *SharedCryptorIface_BlindIndex_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SharedCryptorIface_BlindIndex_Call) RunAndReturn(run func(string) string) *SharedCryptorIface_BlindIndex_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CompareHash provides a mock function with given fields: hashed, plain
 func (_m *SharedCryptorIface) CompareHash(hashed []byte, plain []byte) error {
 	ret := _m.Called(hashed, plain)
@@ -293,6 +338,64 @@ func (_c *SharedCryptorIface_Hash_Call) RunAndReturn(run func([]byte) (string, e
 	return _c
 }
 
+// LegacyEncrypt provides a mock function with given fields: plainText
+func (_m *SharedCryptorIface) LegacyEncrypt(plainText string) ([]string, error) {
+	ret := _m.Called(plainText)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LegacyEncrypt")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(plainText)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(plainText)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(plainText)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SharedCryptorIface_LegacyEncrypt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LegacyEncrypt'
+type SharedCryptorIface_LegacyEncrypt_Call struct {
+	*mock.Call
+}
+
+// LegacyEncrypt is a helper method to define mock.On call
+//   - plainText string
+func (_e *SharedCryptorIface_Expecter) LegacyEncrypt(plainText interface{}) *SharedCryptorIface_LegacyEncrypt_Call {
+	return &SharedCryptorIface_LegacyEncrypt_Call{Call: _e.mock.On("LegacyEncrypt", plainText)}
+}
+
+func (_c *SharedCryptorIface_LegacyEncrypt_Call) Run(run func(plainText string)) *SharedCryptorIface_LegacyEncrypt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *SharedCryptorIface_LegacyEncrypt_Call) Return(_a0 []string, _a1 error) *SharedCryptorIface_LegacyEncrypt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SharedCryptorIface_LegacyEncrypt_Call) RunAndReturn(run func(string) ([]string, error)) *SharedCryptorIface_LegacyEncrypt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ValidateJWT provides a mock function with given fields: token, opts
 func (_m *SharedCryptorIface) ValidateJWT(token string, opts common.ValidateJWTOpts) (*jwt.Token, error) {
 	ret := _m.Called(token, opts)
@@ -0,0 +1,202 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+	time "time"
+
+	model "github.com/luckyAkbar/atec/internal/model"
+	usecase "github.com/luckyAkbar/atec/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditLogRepository is an autogenerated mock type for the AuditLogRepository type
+type AuditLogRepository struct {
+	mock.Mock
+}
+
+type AuditLogRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditLogRepository) EXPECT() *AuditLogRepository_Expecter {
+	return &AuditLogRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, input
+func (_m *AuditLogRepository) Create(ctx context.Context, input usecase.RepoCreateAuditLogInput) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoCreateAuditLogInput) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditLogRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type AuditLogRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoCreateAuditLogInput
+func (_e *AuditLogRepository_Expecter) Create(ctx interface{}, input interface{}) *AuditLogRepository_Create_Call {
+	return &AuditLogRepository_Create_Call{Call: _e.mock.On("Create", ctx, input)}
+}
+
+func (_c *AuditLogRepository_Create_Call) Run(run func(ctx context.Context, input usecase.RepoCreateAuditLogInput)) *AuditLogRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.RepoCreateAuditLogInput))
+	})
+	return _c
+}
+
+func (_c *AuditLogRepository_Create_Call) Return(_a0 error) *AuditLogRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuditLogRepository_Create_Call) RunAndReturn(run func(context.Context, usecase.RepoCreateAuditLogInput) error) *AuditLogRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCreatedBefore provides a mock function with given fields: ctx, before
+func (_m *AuditLogRepository) DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCreatedBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditLogRepository_DeleteCreatedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCreatedBefore'
+type AuditLogRepository_DeleteCreatedBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteCreatedBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *AuditLogRepository_Expecter) DeleteCreatedBefore(ctx interface{}, before interface{}) *AuditLogRepository_DeleteCreatedBefore_Call {
+	return &AuditLogRepository_DeleteCreatedBefore_Call{Call: _e.mock.On("DeleteCreatedBefore", ctx, before)}
+}
+
+func (_c *AuditLogRepository_DeleteCreatedBefore_Call) Run(run func(ctx context.Context, before time.Time)) *AuditLogRepository_DeleteCreatedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AuditLogRepository_DeleteCreatedBefore_Call) Return(_a0 int64, _a1 error) *AuditLogRepository_DeleteCreatedBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditLogRepository_DeleteCreatedBefore_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *AuditLogRepository_DeleteCreatedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: ctx, input
+func (_m *AuditLogRepository) Search(ctx context.Context, input usecase.RepoSearchAuditLogInput) ([]model.AuditLog, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []model.AuditLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoSearchAuditLogInput) ([]model.AuditLog, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoSearchAuditLogInput) []model.AuditLog); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.RepoSearchAuditLogInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditLogRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type AuditLogRepository_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoSearchAuditLogInput
+func (_e *AuditLogRepository_Expecter) Search(ctx interface{}, input interface{}) *AuditLogRepository_Search_Call {
+	return &AuditLogRepository_Search_Call{Call: _e.mock.On("Search", ctx, input)}
+}
+
+func (_c *AuditLogRepository_Search_Call) Run(run func(ctx context.Context, input usecase.RepoSearchAuditLogInput)) *AuditLogRepository_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.RepoSearchAuditLogInput))
+	})
+	return _c
+}
+
+func (_c *AuditLogRepository_Search_Call) Return(_a0 []model.AuditLog, _a1 error) *AuditLogRepository_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditLogRepository_Search_Call) RunAndReturn(run func(context.Context, usecase.RepoSearchAuditLogInput) ([]model.AuditLog, error)) *AuditLogRepository_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditLogRepository creates a new instance of AuditLogRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditLogRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditLogRepository {
+	mock := &AuditLogRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
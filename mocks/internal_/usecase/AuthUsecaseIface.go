@@ -140,6 +140,301 @@ func (_c *AuthUsecaseIface_HandleAccountVerification_Call) RunAndReturn(run func
 	return _c
 }
 
+// HandleAdminCancelAccountDeletion provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleAdminCancelAccountDeletion(ctx context.Context, input usecase.AdminCancelAccountDeletionInput) (*usecase.AdminCancelAccountDeletionOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleAdminCancelAccountDeletion")
+	}
+
+	var r0 *usecase.AdminCancelAccountDeletionOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminCancelAccountDeletionInput) (*usecase.AdminCancelAccountDeletionOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminCancelAccountDeletionInput) *usecase.AdminCancelAccountDeletionOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.AdminCancelAccountDeletionOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.AdminCancelAccountDeletionInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleAdminCancelAccountDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleAdminCancelAccountDeletion'
+type AuthUsecaseIface_HandleAdminCancelAccountDeletion_Call struct {
+	*mock.Call
+}
+
+// HandleAdminCancelAccountDeletion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.AdminCancelAccountDeletionInput
+func (_e *AuthUsecaseIface_Expecter) HandleAdminCancelAccountDeletion(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleAdminCancelAccountDeletion_Call {
+	return &AuthUsecaseIface_HandleAdminCancelAccountDeletion_Call{Call: _e.mock.On("HandleAdminCancelAccountDeletion", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleAdminCancelAccountDeletion_Call) Run(run func(ctx context.Context, input usecase.AdminCancelAccountDeletionInput)) *AuthUsecaseIface_HandleAdminCancelAccountDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.AdminCancelAccountDeletionInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleAdminCancelAccountDeletion_Call) Return(_a0 *usecase.AdminCancelAccountDeletionOutput, _a1 error) *AuthUsecaseIface_HandleAdminCancelAccountDeletion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleAdminCancelAccountDeletion_Call) RunAndReturn(run func(context.Context, usecase.AdminCancelAccountDeletionInput) (*usecase.AdminCancelAccountDeletionOutput, error)) *AuthUsecaseIface_HandleAdminCancelAccountDeletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleAdminForceResetPassword provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleAdminForceResetPassword(ctx context.Context, input usecase.AdminForceResetPasswordInput) (*usecase.AdminForceResetPasswordOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleAdminForceResetPassword")
+	}
+
+	var r0 *usecase.AdminForceResetPasswordOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminForceResetPasswordInput) (*usecase.AdminForceResetPasswordOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminForceResetPasswordInput) *usecase.AdminForceResetPasswordOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.AdminForceResetPasswordOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.AdminForceResetPasswordInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleAdminForceResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleAdminForceResetPassword'
+type AuthUsecaseIface_HandleAdminForceResetPassword_Call struct {
+	*mock.Call
+}
+
+// HandleAdminForceResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.AdminForceResetPasswordInput
+func (_e *AuthUsecaseIface_Expecter) HandleAdminForceResetPassword(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleAdminForceResetPassword_Call {
+	return &AuthUsecaseIface_HandleAdminForceResetPassword_Call{Call: _e.mock.On("HandleAdminForceResetPassword", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleAdminForceResetPassword_Call) Run(run func(ctx context.Context, input usecase.AdminForceResetPasswordInput)) *AuthUsecaseIface_HandleAdminForceResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.AdminForceResetPasswordInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleAdminForceResetPassword_Call) Return(_a0 *usecase.AdminForceResetPasswordOutput, _a1 error) *AuthUsecaseIface_HandleAdminForceResetPassword_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleAdminForceResetPassword_Call) RunAndReturn(run func(context.Context, usecase.AdminForceResetPasswordInput) (*usecase.AdminForceResetPasswordOutput, error)) *AuthUsecaseIface_HandleAdminForceResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleChangePassword provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleChangePassword(ctx context.Context, input usecase.ChangePasswordInput) (*usecase.ChangePasswordOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleChangePassword")
+	}
+
+	var r0 *usecase.ChangePasswordOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.ChangePasswordInput) (*usecase.ChangePasswordOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.ChangePasswordInput) *usecase.ChangePasswordOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.ChangePasswordOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.ChangePasswordInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleChangePassword'
+type AuthUsecaseIface_HandleChangePassword_Call struct {
+	*mock.Call
+}
+
+// HandleChangePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.ChangePasswordInput
+func (_e *AuthUsecaseIface_Expecter) HandleChangePassword(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleChangePassword_Call {
+	return &AuthUsecaseIface_HandleChangePassword_Call{Call: _e.mock.On("HandleChangePassword", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleChangePassword_Call) Run(run func(ctx context.Context, input usecase.ChangePasswordInput)) *AuthUsecaseIface_HandleChangePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.ChangePasswordInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleChangePassword_Call) Return(_a0 *usecase.ChangePasswordOutput, _a1 error) *AuthUsecaseIface_HandleChangePassword_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleChangePassword_Call) RunAndReturn(run func(context.Context, usecase.ChangePasswordInput) (*usecase.ChangePasswordOutput, error)) *AuthUsecaseIface_HandleChangePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleConfirmChangeEmail provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleConfirmChangeEmail(ctx context.Context, input usecase.ConfirmChangeEmailInput) (*usecase.ConfirmChangeEmailOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleConfirmChangeEmail")
+	}
+
+	var r0 *usecase.ConfirmChangeEmailOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.ConfirmChangeEmailInput) (*usecase.ConfirmChangeEmailOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.ConfirmChangeEmailInput) *usecase.ConfirmChangeEmailOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.ConfirmChangeEmailOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.ConfirmChangeEmailInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleConfirmChangeEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleConfirmChangeEmail'
+type AuthUsecaseIface_HandleConfirmChangeEmail_Call struct {
+	*mock.Call
+}
+
+// HandleConfirmChangeEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.ConfirmChangeEmailInput
+func (_e *AuthUsecaseIface_Expecter) HandleConfirmChangeEmail(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleConfirmChangeEmail_Call {
+	return &AuthUsecaseIface_HandleConfirmChangeEmail_Call{Call: _e.mock.On("HandleConfirmChangeEmail", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleConfirmChangeEmail_Call) Run(run func(ctx context.Context, input usecase.ConfirmChangeEmailInput)) *AuthUsecaseIface_HandleConfirmChangeEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.ConfirmChangeEmailInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleConfirmChangeEmail_Call) Return(_a0 *usecase.ConfirmChangeEmailOutput, _a1 error) *AuthUsecaseIface_HandleConfirmChangeEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleConfirmChangeEmail_Call) RunAndReturn(run func(context.Context, usecase.ConfirmChangeEmailInput) (*usecase.ConfirmChangeEmailOutput, error)) *AuthUsecaseIface_HandleConfirmChangeEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleCreatePersonalAccessToken provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleCreatePersonalAccessToken(ctx context.Context, input usecase.CreatePersonalAccessTokenInput) (*usecase.CreatePersonalAccessTokenOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleCreatePersonalAccessToken")
+	}
+
+	var r0 *usecase.CreatePersonalAccessTokenOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.CreatePersonalAccessTokenInput) (*usecase.CreatePersonalAccessTokenOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.CreatePersonalAccessTokenInput) *usecase.CreatePersonalAccessTokenOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.CreatePersonalAccessTokenOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.CreatePersonalAccessTokenInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleCreatePersonalAccessToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleCreatePersonalAccessToken'
+type AuthUsecaseIface_HandleCreatePersonalAccessToken_Call struct {
+	*mock.Call
+}
+
+// HandleCreatePersonalAccessToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.CreatePersonalAccessTokenInput
+func (_e *AuthUsecaseIface_Expecter) HandleCreatePersonalAccessToken(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleCreatePersonalAccessToken_Call {
+	return &AuthUsecaseIface_HandleCreatePersonalAccessToken_Call{Call: _e.mock.On("HandleCreatePersonalAccessToken", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleCreatePersonalAccessToken_Call) Run(run func(ctx context.Context, input usecase.CreatePersonalAccessTokenInput)) *AuthUsecaseIface_HandleCreatePersonalAccessToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.CreatePersonalAccessTokenInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleCreatePersonalAccessToken_Call) Return(_a0 *usecase.CreatePersonalAccessTokenOutput, _a1 error) *AuthUsecaseIface_HandleCreatePersonalAccessToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleCreatePersonalAccessToken_Call) RunAndReturn(run func(context.Context, usecase.CreatePersonalAccessTokenInput) (*usecase.CreatePersonalAccessTokenOutput, error)) *AuthUsecaseIface_HandleCreatePersonalAccessToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // HandleDeleteUserData provides a mock function with given fields: ctx, input
 func (_m *AuthUsecaseIface) HandleDeleteUserData(ctx context.Context, input usecase.DeleteUserDataInput) error {
 	ret := _m.Called(ctx, input)
@@ -158,57 +453,855 @@ func (_m *AuthUsecaseIface) HandleDeleteUserData(ctx context.Context, input usec
 	return r0
 }
 
-// AuthUsecaseIface_HandleDeleteUserData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleDeleteUserData'
-type AuthUsecaseIface_HandleDeleteUserData_Call struct {
+// AuthUsecaseIface_HandleDeleteUserData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleDeleteUserData'
+type AuthUsecaseIface_HandleDeleteUserData_Call struct {
+	*mock.Call
+}
+
+// HandleDeleteUserData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.DeleteUserDataInput
+func (_e *AuthUsecaseIface_Expecter) HandleDeleteUserData(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleDeleteUserData_Call {
+	return &AuthUsecaseIface_HandleDeleteUserData_Call{Call: _e.mock.On("HandleDeleteUserData", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleDeleteUserData_Call) Run(run func(ctx context.Context, input usecase.DeleteUserDataInput)) *AuthUsecaseIface_HandleDeleteUserData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.DeleteUserDataInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleDeleteUserData_Call) Return(_a0 error) *AuthUsecaseIface_HandleDeleteUserData_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleDeleteUserData_Call) RunAndReturn(run func(context.Context, usecase.DeleteUserDataInput) error) *AuthUsecaseIface_HandleDeleteUserData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleInitChangeEmail provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleInitChangeEmail(ctx context.Context, input usecase.InitChangeEmailInput) (*usecase.InitChangeEmailOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleInitChangeEmail")
+	}
+
+	var r0 *usecase.InitChangeEmailOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.InitChangeEmailInput) (*usecase.InitChangeEmailOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.InitChangeEmailInput) *usecase.InitChangeEmailOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.InitChangeEmailOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.InitChangeEmailInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleInitChangeEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleInitChangeEmail'
+type AuthUsecaseIface_HandleInitChangeEmail_Call struct {
+	*mock.Call
+}
+
+// HandleInitChangeEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.InitChangeEmailInput
+func (_e *AuthUsecaseIface_Expecter) HandleInitChangeEmail(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleInitChangeEmail_Call {
+	return &AuthUsecaseIface_HandleInitChangeEmail_Call{Call: _e.mock.On("HandleInitChangeEmail", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleInitChangeEmail_Call) Run(run func(ctx context.Context, input usecase.InitChangeEmailInput)) *AuthUsecaseIface_HandleInitChangeEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.InitChangeEmailInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleInitChangeEmail_Call) Return(_a0 *usecase.InitChangeEmailOutput, _a1 error) *AuthUsecaseIface_HandleInitChangeEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleInitChangeEmail_Call) RunAndReturn(run func(context.Context, usecase.InitChangeEmailInput) (*usecase.InitChangeEmailOutput, error)) *AuthUsecaseIface_HandleInitChangeEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleInitPasswordlessLogin provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleInitPasswordlessLogin(ctx context.Context, input usecase.InitPasswordlessLoginInput) (*usecase.InitPasswordlessLoginOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleInitPasswordlessLogin")
+	}
+
+	var r0 *usecase.InitPasswordlessLoginOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.InitPasswordlessLoginInput) (*usecase.InitPasswordlessLoginOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.InitPasswordlessLoginInput) *usecase.InitPasswordlessLoginOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.InitPasswordlessLoginOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.InitPasswordlessLoginInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleInitPasswordlessLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleInitPasswordlessLogin'
+type AuthUsecaseIface_HandleInitPasswordlessLogin_Call struct {
+	*mock.Call
+}
+
+// HandleInitPasswordlessLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.InitPasswordlessLoginInput
+func (_e *AuthUsecaseIface_Expecter) HandleInitPasswordlessLogin(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleInitPasswordlessLogin_Call {
+	return &AuthUsecaseIface_HandleInitPasswordlessLogin_Call{Call: _e.mock.On("HandleInitPasswordlessLogin", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleInitPasswordlessLogin_Call) Run(run func(ctx context.Context, input usecase.InitPasswordlessLoginInput)) *AuthUsecaseIface_HandleInitPasswordlessLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.InitPasswordlessLoginInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleInitPasswordlessLogin_Call) Return(_a0 *usecase.InitPasswordlessLoginOutput, _a1 error) *AuthUsecaseIface_HandleInitPasswordlessLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleInitPasswordlessLogin_Call) RunAndReturn(run func(context.Context, usecase.InitPasswordlessLoginInput) (*usecase.InitPasswordlessLoginOutput, error)) *AuthUsecaseIface_HandleInitPasswordlessLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleInitesetPassword provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleInitesetPassword(ctx context.Context, input usecase.InitResetPasswordInput) (*usecase.InitResetPasswordOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleInitesetPassword")
+	}
+
+	var r0 *usecase.InitResetPasswordOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.InitResetPasswordInput) (*usecase.InitResetPasswordOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.InitResetPasswordInput) *usecase.InitResetPasswordOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.InitResetPasswordOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.InitResetPasswordInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleInitesetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleInitesetPassword'
+type AuthUsecaseIface_HandleInitesetPassword_Call struct {
+	*mock.Call
+}
+
+// HandleInitesetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.InitResetPasswordInput
+func (_e *AuthUsecaseIface_Expecter) HandleInitesetPassword(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleInitesetPassword_Call {
+	return &AuthUsecaseIface_HandleInitesetPassword_Call{Call: _e.mock.On("HandleInitesetPassword", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleInitesetPassword_Call) Run(run func(ctx context.Context, input usecase.InitResetPasswordInput)) *AuthUsecaseIface_HandleInitesetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.InitResetPasswordInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleInitesetPassword_Call) Return(_a0 *usecase.InitResetPasswordOutput, _a1 error) *AuthUsecaseIface_HandleInitesetPassword_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleInitesetPassword_Call) RunAndReturn(run func(context.Context, usecase.InitResetPasswordInput) (*usecase.InitResetPasswordOutput, error)) *AuthUsecaseIface_HandleInitesetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleInviteTherapist provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleInviteTherapist(ctx context.Context, input usecase.InviteTherapistInput) (*usecase.InviteTherapistOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleInviteTherapist")
+	}
+
+	var r0 *usecase.InviteTherapistOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.InviteTherapistInput) (*usecase.InviteTherapistOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.InviteTherapistInput) *usecase.InviteTherapistOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.InviteTherapistOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.InviteTherapistInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleInviteTherapist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleInviteTherapist'
+type AuthUsecaseIface_HandleInviteTherapist_Call struct {
+	*mock.Call
+}
+
+// HandleInviteTherapist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.InviteTherapistInput
+func (_e *AuthUsecaseIface_Expecter) HandleInviteTherapist(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleInviteTherapist_Call {
+	return &AuthUsecaseIface_HandleInviteTherapist_Call{Call: _e.mock.On("HandleInviteTherapist", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleInviteTherapist_Call) Run(run func(ctx context.Context, input usecase.InviteTherapistInput)) *AuthUsecaseIface_HandleInviteTherapist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.InviteTherapistInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleInviteTherapist_Call) Return(_a0 *usecase.InviteTherapistOutput, _a1 error) *AuthUsecaseIface_HandleInviteTherapist_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleInviteTherapist_Call) RunAndReturn(run func(context.Context, usecase.InviteTherapistInput) (*usecase.InviteTherapistOutput, error)) *AuthUsecaseIface_HandleInviteTherapist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleListMySessions provides a mock function with given fields: ctx
+func (_m *AuthUsecaseIface) HandleListMySessions(ctx context.Context) ([]usecase.SessionOutput, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleListMySessions")
+	}
+
+	var r0 []usecase.SessionOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]usecase.SessionOutput, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []usecase.SessionOutput); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]usecase.SessionOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleListMySessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleListMySessions'
+type AuthUsecaseIface_HandleListMySessions_Call struct {
+	*mock.Call
+}
+
+// HandleListMySessions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *AuthUsecaseIface_Expecter) HandleListMySessions(ctx interface{}) *AuthUsecaseIface_HandleListMySessions_Call {
+	return &AuthUsecaseIface_HandleListMySessions_Call{Call: _e.mock.On("HandleListMySessions", ctx)}
+}
+
+func (_c *AuthUsecaseIface_HandleListMySessions_Call) Run(run func(ctx context.Context)) *AuthUsecaseIface_HandleListMySessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleListMySessions_Call) Return(_a0 []usecase.SessionOutput, _a1 error) *AuthUsecaseIface_HandleListMySessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleListMySessions_Call) RunAndReturn(run func(context.Context) ([]usecase.SessionOutput, error)) *AuthUsecaseIface_HandleListMySessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleListPersonalAccessTokens provides a mock function with given fields: ctx
+func (_m *AuthUsecaseIface) HandleListPersonalAccessTokens(ctx context.Context) ([]usecase.PersonalAccessTokenOutput, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleListPersonalAccessTokens")
+	}
+
+	var r0 []usecase.PersonalAccessTokenOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]usecase.PersonalAccessTokenOutput, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []usecase.PersonalAccessTokenOutput); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]usecase.PersonalAccessTokenOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleListPersonalAccessTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleListPersonalAccessTokens'
+type AuthUsecaseIface_HandleListPersonalAccessTokens_Call struct {
+	*mock.Call
+}
+
+// HandleListPersonalAccessTokens is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *AuthUsecaseIface_Expecter) HandleListPersonalAccessTokens(ctx interface{}) *AuthUsecaseIface_HandleListPersonalAccessTokens_Call {
+	return &AuthUsecaseIface_HandleListPersonalAccessTokens_Call{Call: _e.mock.On("HandleListPersonalAccessTokens", ctx)}
+}
+
+func (_c *AuthUsecaseIface_HandleListPersonalAccessTokens_Call) Run(run func(ctx context.Context)) *AuthUsecaseIface_HandleListPersonalAccessTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleListPersonalAccessTokens_Call) Return(_a0 []usecase.PersonalAccessTokenOutput, _a1 error) *AuthUsecaseIface_HandleListPersonalAccessTokens_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleListPersonalAccessTokens_Call) RunAndReturn(run func(context.Context) ([]usecase.PersonalAccessTokenOutput, error)) *AuthUsecaseIface_HandleListPersonalAccessTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleLogin provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleLogin(ctx context.Context, input usecase.LoginInput) (*usecase.LoginOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleLogin")
+	}
+
+	var r0 *usecase.LoginOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.LoginInput) (*usecase.LoginOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.LoginInput) *usecase.LoginOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.LoginOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.LoginInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleLogin'
+type AuthUsecaseIface_HandleLogin_Call struct {
+	*mock.Call
+}
+
+// HandleLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.LoginInput
+func (_e *AuthUsecaseIface_Expecter) HandleLogin(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleLogin_Call {
+	return &AuthUsecaseIface_HandleLogin_Call{Call: _e.mock.On("HandleLogin", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleLogin_Call) Run(run func(ctx context.Context, input usecase.LoginInput)) *AuthUsecaseIface_HandleLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.LoginInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleLogin_Call) Return(_a0 *usecase.LoginOutput, _a1 error) *AuthUsecaseIface_HandleLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleLogin_Call) RunAndReturn(run func(context.Context, usecase.LoginInput) (*usecase.LoginOutput, error)) *AuthUsecaseIface_HandleLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleLogout provides a mock function with given fields: ctx
+func (_m *AuthUsecaseIface) HandleLogout(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleLogout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthUsecaseIface_HandleLogout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleLogout'
+type AuthUsecaseIface_HandleLogout_Call struct {
+	*mock.Call
+}
+
+// HandleLogout is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *AuthUsecaseIface_Expecter) HandleLogout(ctx interface{}) *AuthUsecaseIface_HandleLogout_Call {
+	return &AuthUsecaseIface_HandleLogout_Call{Call: _e.mock.On("HandleLogout", ctx)}
+}
+
+func (_c *AuthUsecaseIface_HandleLogout_Call) Run(run func(ctx context.Context)) *AuthUsecaseIface_HandleLogout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleLogout_Call) Return(_a0 error) *AuthUsecaseIface_HandleLogout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleLogout_Call) RunAndReturn(run func(context.Context) error) *AuthUsecaseIface_HandleLogout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleLogoutAll provides a mock function with given fields: ctx
+func (_m *AuthUsecaseIface) HandleLogoutAll(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleLogoutAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthUsecaseIface_HandleLogoutAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleLogoutAll'
+type AuthUsecaseIface_HandleLogoutAll_Call struct {
+	*mock.Call
+}
+
+// HandleLogoutAll is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *AuthUsecaseIface_Expecter) HandleLogoutAll(ctx interface{}) *AuthUsecaseIface_HandleLogoutAll_Call {
+	return &AuthUsecaseIface_HandleLogoutAll_Call{Call: _e.mock.On("HandleLogoutAll", ctx)}
+}
+
+func (_c *AuthUsecaseIface_HandleLogoutAll_Call) Run(run func(ctx context.Context)) *AuthUsecaseIface_HandleLogoutAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleLogoutAll_Call) Return(_a0 error) *AuthUsecaseIface_HandleLogoutAll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleLogoutAll_Call) RunAndReturn(run func(context.Context) error) *AuthUsecaseIface_HandleLogoutAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleMFAConfirmEnrollment provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleMFAConfirmEnrollment(ctx context.Context, input usecase.MFAConfirmEnrollmentInput) (*usecase.MFAConfirmEnrollmentOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleMFAConfirmEnrollment")
+	}
+
+	var r0 *usecase.MFAConfirmEnrollmentOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.MFAConfirmEnrollmentInput) (*usecase.MFAConfirmEnrollmentOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.MFAConfirmEnrollmentInput) *usecase.MFAConfirmEnrollmentOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.MFAConfirmEnrollmentOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.MFAConfirmEnrollmentInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleMFAConfirmEnrollment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleMFAConfirmEnrollment'
+type AuthUsecaseIface_HandleMFAConfirmEnrollment_Call struct {
+	*mock.Call
+}
+
+// HandleMFAConfirmEnrollment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.MFAConfirmEnrollmentInput
+func (_e *AuthUsecaseIface_Expecter) HandleMFAConfirmEnrollment(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleMFAConfirmEnrollment_Call {
+	return &AuthUsecaseIface_HandleMFAConfirmEnrollment_Call{Call: _e.mock.On("HandleMFAConfirmEnrollment", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleMFAConfirmEnrollment_Call) Run(run func(ctx context.Context, input usecase.MFAConfirmEnrollmentInput)) *AuthUsecaseIface_HandleMFAConfirmEnrollment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.MFAConfirmEnrollmentInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleMFAConfirmEnrollment_Call) Return(_a0 *usecase.MFAConfirmEnrollmentOutput, _a1 error) *AuthUsecaseIface_HandleMFAConfirmEnrollment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleMFAConfirmEnrollment_Call) RunAndReturn(run func(context.Context, usecase.MFAConfirmEnrollmentInput) (*usecase.MFAConfirmEnrollmentOutput, error)) *AuthUsecaseIface_HandleMFAConfirmEnrollment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleMFADisable provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleMFADisable(ctx context.Context, input usecase.MFADisableInput) (*usecase.MFADisableOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleMFADisable")
+	}
+
+	var r0 *usecase.MFADisableOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.MFADisableInput) (*usecase.MFADisableOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.MFADisableInput) *usecase.MFADisableOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.MFADisableOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.MFADisableInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleMFADisable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleMFADisable'
+type AuthUsecaseIface_HandleMFADisable_Call struct {
+	*mock.Call
+}
+
+// HandleMFADisable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.MFADisableInput
+func (_e *AuthUsecaseIface_Expecter) HandleMFADisable(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleMFADisable_Call {
+	return &AuthUsecaseIface_HandleMFADisable_Call{Call: _e.mock.On("HandleMFADisable", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleMFADisable_Call) Run(run func(ctx context.Context, input usecase.MFADisableInput)) *AuthUsecaseIface_HandleMFADisable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.MFADisableInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleMFADisable_Call) Return(_a0 *usecase.MFADisableOutput, _a1 error) *AuthUsecaseIface_HandleMFADisable_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleMFADisable_Call) RunAndReturn(run func(context.Context, usecase.MFADisableInput) (*usecase.MFADisableOutput, error)) *AuthUsecaseIface_HandleMFADisable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleMFAEnroll provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleMFAEnroll(ctx context.Context, input usecase.MFAEnrollInput) (*usecase.MFAEnrollOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleMFAEnroll")
+	}
+
+	var r0 *usecase.MFAEnrollOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.MFAEnrollInput) (*usecase.MFAEnrollOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.MFAEnrollInput) *usecase.MFAEnrollOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.MFAEnrollOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.MFAEnrollInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleMFAEnroll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleMFAEnroll'
+type AuthUsecaseIface_HandleMFAEnroll_Call struct {
+	*mock.Call
+}
+
+// HandleMFAEnroll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.MFAEnrollInput
+func (_e *AuthUsecaseIface_Expecter) HandleMFAEnroll(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleMFAEnroll_Call {
+	return &AuthUsecaseIface_HandleMFAEnroll_Call{Call: _e.mock.On("HandleMFAEnroll", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleMFAEnroll_Call) Run(run func(ctx context.Context, input usecase.MFAEnrollInput)) *AuthUsecaseIface_HandleMFAEnroll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.MFAEnrollInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleMFAEnroll_Call) Return(_a0 *usecase.MFAEnrollOutput, _a1 error) *AuthUsecaseIface_HandleMFAEnroll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleMFAEnroll_Call) RunAndReturn(run func(context.Context, usecase.MFAEnrollInput) (*usecase.MFAEnrollOutput, error)) *AuthUsecaseIface_HandleMFAEnroll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleMFAVerifyLogin provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleMFAVerifyLogin(ctx context.Context, input usecase.MFAVerifyLoginInput) (*usecase.LoginOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleMFAVerifyLogin")
+	}
+
+	var r0 *usecase.LoginOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.MFAVerifyLoginInput) (*usecase.LoginOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.MFAVerifyLoginInput) *usecase.LoginOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.LoginOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.MFAVerifyLoginInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleMFAVerifyLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleMFAVerifyLogin'
+type AuthUsecaseIface_HandleMFAVerifyLogin_Call struct {
+	*mock.Call
+}
+
+// HandleMFAVerifyLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.MFAVerifyLoginInput
+func (_e *AuthUsecaseIface_Expecter) HandleMFAVerifyLogin(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleMFAVerifyLogin_Call {
+	return &AuthUsecaseIface_HandleMFAVerifyLogin_Call{Call: _e.mock.On("HandleMFAVerifyLogin", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleMFAVerifyLogin_Call) Run(run func(ctx context.Context, input usecase.MFAVerifyLoginInput)) *AuthUsecaseIface_HandleMFAVerifyLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.MFAVerifyLoginInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleMFAVerifyLogin_Call) Return(_a0 *usecase.LoginOutput, _a1 error) *AuthUsecaseIface_HandleMFAVerifyLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleMFAVerifyLogin_Call) RunAndReturn(run func(context.Context, usecase.MFAVerifyLoginInput) (*usecase.LoginOutput, error)) *AuthUsecaseIface_HandleMFAVerifyLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleMagicLinkLogin provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleMagicLinkLogin(ctx context.Context, input usecase.MagicLinkLoginInput) (*usecase.LoginOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleMagicLinkLogin")
+	}
+
+	var r0 *usecase.LoginOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.MagicLinkLoginInput) (*usecase.LoginOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.MagicLinkLoginInput) *usecase.LoginOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.LoginOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.MagicLinkLoginInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleMagicLinkLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleMagicLinkLogin'
+type AuthUsecaseIface_HandleMagicLinkLogin_Call struct {
 	*mock.Call
 }
 
-// HandleDeleteUserData is a helper method to define mock.On call
+// HandleMagicLinkLogin is a helper method to define mock.On call
 //   - ctx context.Context
-//   - input usecase.DeleteUserDataInput
-func (_e *AuthUsecaseIface_Expecter) HandleDeleteUserData(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleDeleteUserData_Call {
-	return &AuthUsecaseIface_HandleDeleteUserData_Call{Call: _e.mock.On("HandleDeleteUserData", ctx, input)}
+//   - input usecase.MagicLinkLoginInput
+func (_e *AuthUsecaseIface_Expecter) HandleMagicLinkLogin(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleMagicLinkLogin_Call {
+	return &AuthUsecaseIface_HandleMagicLinkLogin_Call{Call: _e.mock.On("HandleMagicLinkLogin", ctx, input)}
 }
 
-func (_c *AuthUsecaseIface_HandleDeleteUserData_Call) Run(run func(ctx context.Context, input usecase.DeleteUserDataInput)) *AuthUsecaseIface_HandleDeleteUserData_Call {
+func (_c *AuthUsecaseIface_HandleMagicLinkLogin_Call) Run(run func(ctx context.Context, input usecase.MagicLinkLoginInput)) *AuthUsecaseIface_HandleMagicLinkLogin_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(usecase.DeleteUserDataInput))
+		run(args[0].(context.Context), args[1].(usecase.MagicLinkLoginInput))
 	})
 	return _c
 }
 
-func (_c *AuthUsecaseIface_HandleDeleteUserData_Call) Return(_a0 error) *AuthUsecaseIface_HandleDeleteUserData_Call {
-	_c.Call.Return(_a0)
+func (_c *AuthUsecaseIface_HandleMagicLinkLogin_Call) Return(_a0 *usecase.LoginOutput, _a1 error) *AuthUsecaseIface_HandleMagicLinkLogin_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *AuthUsecaseIface_HandleDeleteUserData_Call) RunAndReturn(run func(context.Context, usecase.DeleteUserDataInput) error) *AuthUsecaseIface_HandleDeleteUserData_Call {
+func (_c *AuthUsecaseIface_HandleMagicLinkLogin_Call) RunAndReturn(run func(context.Context, usecase.MagicLinkLoginInput) (*usecase.LoginOutput, error)) *AuthUsecaseIface_HandleMagicLinkLogin_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// HandleInitesetPassword provides a mock function with given fields: ctx, input
-func (_m *AuthUsecaseIface) HandleInitesetPassword(ctx context.Context, input usecase.InitResetPasswordInput) (*usecase.InitResetPasswordOutput, error) {
+// HandleRedeemTherapistInvitation provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleRedeemTherapistInvitation(ctx context.Context, input usecase.RedeemTherapistInvitationInput) (*usecase.RedeemTherapistInvitationOutput, error) {
 	ret := _m.Called(ctx, input)
 
 	if len(ret) == 0 {
-		panic("no return value specified for HandleInitesetPassword")
+		panic("no return value specified for HandleRedeemTherapistInvitation")
 	}
 
-	var r0 *usecase.InitResetPasswordOutput
+	var r0 *usecase.RedeemTherapistInvitationOutput
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, usecase.InitResetPasswordInput) (*usecase.InitResetPasswordOutput, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RedeemTherapistInvitationInput) (*usecase.RedeemTherapistInvitationOutput, error)); ok {
 		return rf(ctx, input)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, usecase.InitResetPasswordInput) *usecase.InitResetPasswordOutput); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RedeemTherapistInvitationInput) *usecase.RedeemTherapistInvitationOutput); ok {
 		r0 = rf(ctx, input)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*usecase.InitResetPasswordOutput)
+			r0 = ret.Get(0).(*usecase.RedeemTherapistInvitationOutput)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, usecase.InitResetPasswordInput) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.RedeemTherapistInvitationInput) error); ok {
 		r1 = rf(ctx, input)
 	} else {
 		r1 = ret.Error(1)
@@ -217,57 +1310,57 @@ func (_m *AuthUsecaseIface) HandleInitesetPassword(ctx context.Context, input us
 	return r0, r1
 }
 
-// AuthUsecaseIface_HandleInitesetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleInitesetPassword'
-type AuthUsecaseIface_HandleInitesetPassword_Call struct {
+// AuthUsecaseIface_HandleRedeemTherapistInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleRedeemTherapistInvitation'
+type AuthUsecaseIface_HandleRedeemTherapistInvitation_Call struct {
 	*mock.Call
 }
 
-// HandleInitesetPassword is a helper method to define mock.On call
+// HandleRedeemTherapistInvitation is a helper method to define mock.On call
 //   - ctx context.Context
-//   - input usecase.InitResetPasswordInput
-func (_e *AuthUsecaseIface_Expecter) HandleInitesetPassword(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleInitesetPassword_Call {
-	return &AuthUsecaseIface_HandleInitesetPassword_Call{Call: _e.mock.On("HandleInitesetPassword", ctx, input)}
+//   - input usecase.RedeemTherapistInvitationInput
+func (_e *AuthUsecaseIface_Expecter) HandleRedeemTherapistInvitation(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleRedeemTherapistInvitation_Call {
+	return &AuthUsecaseIface_HandleRedeemTherapistInvitation_Call{Call: _e.mock.On("HandleRedeemTherapistInvitation", ctx, input)}
 }
 
-func (_c *AuthUsecaseIface_HandleInitesetPassword_Call) Run(run func(ctx context.Context, input usecase.InitResetPasswordInput)) *AuthUsecaseIface_HandleInitesetPassword_Call {
+func (_c *AuthUsecaseIface_HandleRedeemTherapistInvitation_Call) Run(run func(ctx context.Context, input usecase.RedeemTherapistInvitationInput)) *AuthUsecaseIface_HandleRedeemTherapistInvitation_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(usecase.InitResetPasswordInput))
+		run(args[0].(context.Context), args[1].(usecase.RedeemTherapistInvitationInput))
 	})
 	return _c
 }
 
-func (_c *AuthUsecaseIface_HandleInitesetPassword_Call) Return(_a0 *usecase.InitResetPasswordOutput, _a1 error) *AuthUsecaseIface_HandleInitesetPassword_Call {
+func (_c *AuthUsecaseIface_HandleRedeemTherapistInvitation_Call) Return(_a0 *usecase.RedeemTherapistInvitationOutput, _a1 error) *AuthUsecaseIface_HandleRedeemTherapistInvitation_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *AuthUsecaseIface_HandleInitesetPassword_Call) RunAndReturn(run func(context.Context, usecase.InitResetPasswordInput) (*usecase.InitResetPasswordOutput, error)) *AuthUsecaseIface_HandleInitesetPassword_Call {
+func (_c *AuthUsecaseIface_HandleRedeemTherapistInvitation_Call) RunAndReturn(run func(context.Context, usecase.RedeemTherapistInvitationInput) (*usecase.RedeemTherapistInvitationOutput, error)) *AuthUsecaseIface_HandleRedeemTherapistInvitation_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// HandleLogin provides a mock function with given fields: ctx, input
-func (_m *AuthUsecaseIface) HandleLogin(ctx context.Context, input usecase.LoginInput) (*usecase.LoginOutput, error) {
+// HandleRefreshToken provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleRefreshToken(ctx context.Context, input usecase.RefreshTokenInput) (*usecase.RefreshTokenOutput, error) {
 	ret := _m.Called(ctx, input)
 
 	if len(ret) == 0 {
-		panic("no return value specified for HandleLogin")
+		panic("no return value specified for HandleRefreshToken")
 	}
 
-	var r0 *usecase.LoginOutput
+	var r0 *usecase.RefreshTokenOutput
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, usecase.LoginInput) (*usecase.LoginOutput, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RefreshTokenInput) (*usecase.RefreshTokenOutput, error)); ok {
 		return rf(ctx, input)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, usecase.LoginInput) *usecase.LoginOutput); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RefreshTokenInput) *usecase.RefreshTokenOutput); ok {
 		r0 = rf(ctx, input)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*usecase.LoginOutput)
+			r0 = ret.Get(0).(*usecase.RefreshTokenOutput)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, usecase.LoginInput) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.RefreshTokenInput) error); ok {
 		r1 = rf(ctx, input)
 	} else {
 		r1 = ret.Error(1)
@@ -276,31 +1369,31 @@ func (_m *AuthUsecaseIface) HandleLogin(ctx context.Context, input usecase.Login
 	return r0, r1
 }
 
-// AuthUsecaseIface_HandleLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleLogin'
-type AuthUsecaseIface_HandleLogin_Call struct {
+// AuthUsecaseIface_HandleRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleRefreshToken'
+type AuthUsecaseIface_HandleRefreshToken_Call struct {
 	*mock.Call
 }
 
-// HandleLogin is a helper method to define mock.On call
+// HandleRefreshToken is a helper method to define mock.On call
 //   - ctx context.Context
-//   - input usecase.LoginInput
-func (_e *AuthUsecaseIface_Expecter) HandleLogin(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleLogin_Call {
-	return &AuthUsecaseIface_HandleLogin_Call{Call: _e.mock.On("HandleLogin", ctx, input)}
+//   - input usecase.RefreshTokenInput
+func (_e *AuthUsecaseIface_Expecter) HandleRefreshToken(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleRefreshToken_Call {
+	return &AuthUsecaseIface_HandleRefreshToken_Call{Call: _e.mock.On("HandleRefreshToken", ctx, input)}
 }
 
-func (_c *AuthUsecaseIface_HandleLogin_Call) Run(run func(ctx context.Context, input usecase.LoginInput)) *AuthUsecaseIface_HandleLogin_Call {
+func (_c *AuthUsecaseIface_HandleRefreshToken_Call) Run(run func(ctx context.Context, input usecase.RefreshTokenInput)) *AuthUsecaseIface_HandleRefreshToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(usecase.LoginInput))
+		run(args[0].(context.Context), args[1].(usecase.RefreshTokenInput))
 	})
 	return _c
 }
 
-func (_c *AuthUsecaseIface_HandleLogin_Call) Return(_a0 *usecase.LoginOutput, _a1 error) *AuthUsecaseIface_HandleLogin_Call {
+func (_c *AuthUsecaseIface_HandleRefreshToken_Call) Return(_a0 *usecase.RefreshTokenOutput, _a1 error) *AuthUsecaseIface_HandleRefreshToken_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *AuthUsecaseIface_HandleLogin_Call) RunAndReturn(run func(context.Context, usecase.LoginInput) (*usecase.LoginOutput, error)) *AuthUsecaseIface_HandleLogin_Call {
+func (_c *AuthUsecaseIface_HandleRefreshToken_Call) RunAndReturn(run func(context.Context, usecase.RefreshTokenInput) (*usecase.RefreshTokenOutput, error)) *AuthUsecaseIface_HandleRefreshToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -423,6 +1516,183 @@ func (_c *AuthUsecaseIface_HandleResetPassword_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// HandleRestoreAccount provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleRestoreAccount(ctx context.Context, input usecase.RestoreAccountInput) (*usecase.RestoreAccountOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleRestoreAccount")
+	}
+
+	var r0 *usecase.RestoreAccountOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RestoreAccountInput) (*usecase.RestoreAccountOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RestoreAccountInput) *usecase.RestoreAccountOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.RestoreAccountOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.RestoreAccountInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleRestoreAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleRestoreAccount'
+type AuthUsecaseIface_HandleRestoreAccount_Call struct {
+	*mock.Call
+}
+
+// HandleRestoreAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RestoreAccountInput
+func (_e *AuthUsecaseIface_Expecter) HandleRestoreAccount(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleRestoreAccount_Call {
+	return &AuthUsecaseIface_HandleRestoreAccount_Call{Call: _e.mock.On("HandleRestoreAccount", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleRestoreAccount_Call) Run(run func(ctx context.Context, input usecase.RestoreAccountInput)) *AuthUsecaseIface_HandleRestoreAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.RestoreAccountInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleRestoreAccount_Call) Return(_a0 *usecase.RestoreAccountOutput, _a1 error) *AuthUsecaseIface_HandleRestoreAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleRestoreAccount_Call) RunAndReturn(run func(context.Context, usecase.RestoreAccountInput) (*usecase.RestoreAccountOutput, error)) *AuthUsecaseIface_HandleRestoreAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleRevokeMySession provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleRevokeMySession(ctx context.Context, input usecase.RevokeMySessionInput) (*usecase.RevokeMySessionOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleRevokeMySession")
+	}
+
+	var r0 *usecase.RevokeMySessionOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RevokeMySessionInput) (*usecase.RevokeMySessionOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RevokeMySessionInput) *usecase.RevokeMySessionOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.RevokeMySessionOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.RevokeMySessionInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleRevokeMySession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleRevokeMySession'
+type AuthUsecaseIface_HandleRevokeMySession_Call struct {
+	*mock.Call
+}
+
+// HandleRevokeMySession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RevokeMySessionInput
+func (_e *AuthUsecaseIface_Expecter) HandleRevokeMySession(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleRevokeMySession_Call {
+	return &AuthUsecaseIface_HandleRevokeMySession_Call{Call: _e.mock.On("HandleRevokeMySession", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleRevokeMySession_Call) Run(run func(ctx context.Context, input usecase.RevokeMySessionInput)) *AuthUsecaseIface_HandleRevokeMySession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.RevokeMySessionInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleRevokeMySession_Call) Return(_a0 *usecase.RevokeMySessionOutput, _a1 error) *AuthUsecaseIface_HandleRevokeMySession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleRevokeMySession_Call) RunAndReturn(run func(context.Context, usecase.RevokeMySessionInput) (*usecase.RevokeMySessionOutput, error)) *AuthUsecaseIface_HandleRevokeMySession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleRevokePersonalAccessToken provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleRevokePersonalAccessToken(ctx context.Context, input usecase.RevokePersonalAccessTokenInput) (*usecase.RevokePersonalAccessTokenOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleRevokePersonalAccessToken")
+	}
+
+	var r0 *usecase.RevokePersonalAccessTokenOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RevokePersonalAccessTokenInput) (*usecase.RevokePersonalAccessTokenOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RevokePersonalAccessTokenInput) *usecase.RevokePersonalAccessTokenOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.RevokePersonalAccessTokenOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.RevokePersonalAccessTokenInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleRevokePersonalAccessToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleRevokePersonalAccessToken'
+type AuthUsecaseIface_HandleRevokePersonalAccessToken_Call struct {
+	*mock.Call
+}
+
+// HandleRevokePersonalAccessToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RevokePersonalAccessTokenInput
+func (_e *AuthUsecaseIface_Expecter) HandleRevokePersonalAccessToken(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleRevokePersonalAccessToken_Call {
+	return &AuthUsecaseIface_HandleRevokePersonalAccessToken_Call{Call: _e.mock.On("HandleRevokePersonalAccessToken", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleRevokePersonalAccessToken_Call) Run(run func(ctx context.Context, input usecase.RevokePersonalAccessTokenInput)) *AuthUsecaseIface_HandleRevokePersonalAccessToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.RevokePersonalAccessTokenInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleRevokePersonalAccessToken_Call) Return(_a0 *usecase.RevokePersonalAccessTokenOutput, _a1 error) *AuthUsecaseIface_HandleRevokePersonalAccessToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleRevokePersonalAccessToken_Call) RunAndReturn(run func(context.Context, usecase.RevokePersonalAccessTokenInput) (*usecase.RevokePersonalAccessTokenOutput, error)) *AuthUsecaseIface_HandleRevokePersonalAccessToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // HandleSignup provides a mock function with given fields: ctx, input
 func (_m *AuthUsecaseIface) HandleSignup(ctx context.Context, input usecase.SignupInput) (*usecase.SignupOutput, error) {
 	ret := _m.Called(ctx, input)
@@ -482,6 +1752,124 @@ func (_c *AuthUsecaseIface_HandleSignup_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// HandleUnlockAccount provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleUnlockAccount(ctx context.Context, input usecase.UnlockAccountInput) (*usecase.UnlockAccountOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleUnlockAccount")
+	}
+
+	var r0 *usecase.UnlockAccountOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.UnlockAccountInput) (*usecase.UnlockAccountOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.UnlockAccountInput) *usecase.UnlockAccountOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.UnlockAccountOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.UnlockAccountInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleUnlockAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleUnlockAccount'
+type AuthUsecaseIface_HandleUnlockAccount_Call struct {
+	*mock.Call
+}
+
+// HandleUnlockAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.UnlockAccountInput
+func (_e *AuthUsecaseIface_Expecter) HandleUnlockAccount(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleUnlockAccount_Call {
+	return &AuthUsecaseIface_HandleUnlockAccount_Call{Call: _e.mock.On("HandleUnlockAccount", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleUnlockAccount_Call) Run(run func(ctx context.Context, input usecase.UnlockAccountInput)) *AuthUsecaseIface_HandleUnlockAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.UnlockAccountInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleUnlockAccount_Call) Return(_a0 *usecase.UnlockAccountOutput, _a1 error) *AuthUsecaseIface_HandleUnlockAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleUnlockAccount_Call) RunAndReturn(run func(context.Context, usecase.UnlockAccountInput) (*usecase.UnlockAccountOutput, error)) *AuthUsecaseIface_HandleUnlockAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleVerifyPasswordlessLogin provides a mock function with given fields: ctx, input
+func (_m *AuthUsecaseIface) HandleVerifyPasswordlessLogin(ctx context.Context, input usecase.VerifyPasswordlessLoginInput) (*usecase.LoginOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleVerifyPasswordlessLogin")
+	}
+
+	var r0 *usecase.LoginOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.VerifyPasswordlessLoginInput) (*usecase.LoginOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.VerifyPasswordlessLoginInput) *usecase.LoginOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.LoginOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.VerifyPasswordlessLoginInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecaseIface_HandleVerifyPasswordlessLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleVerifyPasswordlessLogin'
+type AuthUsecaseIface_HandleVerifyPasswordlessLogin_Call struct {
+	*mock.Call
+}
+
+// HandleVerifyPasswordlessLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.VerifyPasswordlessLoginInput
+func (_e *AuthUsecaseIface_Expecter) HandleVerifyPasswordlessLogin(ctx interface{}, input interface{}) *AuthUsecaseIface_HandleVerifyPasswordlessLogin_Call {
+	return &AuthUsecaseIface_HandleVerifyPasswordlessLogin_Call{Call: _e.mock.On("HandleVerifyPasswordlessLogin", ctx, input)}
+}
+
+func (_c *AuthUsecaseIface_HandleVerifyPasswordlessLogin_Call) Run(run func(ctx context.Context, input usecase.VerifyPasswordlessLoginInput)) *AuthUsecaseIface_HandleVerifyPasswordlessLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.VerifyPasswordlessLoginInput))
+	})
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleVerifyPasswordlessLogin_Call) Return(_a0 *usecase.LoginOutput, _a1 error) *AuthUsecaseIface_HandleVerifyPasswordlessLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecaseIface_HandleVerifyPasswordlessLogin_Call) RunAndReturn(run func(context.Context, usecase.VerifyPasswordlessLoginInput) (*usecase.LoginOutput, error)) *AuthUsecaseIface_HandleVerifyPasswordlessLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewAuthUsecaseIface creates a new instance of AuthUsecaseIface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAuthUsecaseIface(t interface {
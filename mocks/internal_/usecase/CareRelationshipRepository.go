@@ -0,0 +1,324 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/luckyAkbar/atec/internal/model"
+	usecase "github.com/luckyAkbar/atec/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CareRelationshipRepository is an autogenerated mock type for the CareRelationshipRepository type
+type CareRelationshipRepository struct {
+	mock.Mock
+}
+
+type CareRelationshipRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CareRelationshipRepository) EXPECT() *CareRelationshipRepository_Expecter {
+	return &CareRelationshipRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, childID, therapistID
+func (_m *CareRelationshipRepository) Delete(ctx context.Context, childID uuid.UUID, therapistID uuid.UUID) error {
+	ret := _m.Called(ctx, childID, therapistID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, childID, therapistID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CareRelationshipRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type CareRelationshipRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - childID uuid.UUID
+//   - therapistID uuid.UUID
+func (_e *CareRelationshipRepository_Expecter) Delete(ctx interface{}, childID interface{}, therapistID interface{}) *CareRelationshipRepository_Delete_Call {
+	return &CareRelationshipRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, childID, therapistID)}
+}
+
+func (_c *CareRelationshipRepository_Delete_Call) Run(run func(ctx context.Context, childID uuid.UUID, therapistID uuid.UUID)) *CareRelationshipRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *CareRelationshipRepository_Delete_Call) Return(_a0 error) *CareRelationshipRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CareRelationshipRepository_Delete_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *CareRelationshipRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByUser provides a mock function with given fields: ctx, childID, userID, txController
+func (_m *CareRelationshipRepository) DeleteByUser(ctx context.Context, childID uuid.UUID, userID uuid.UUID, txController ...any) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, childID, userID)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, ...any) error); ok {
+		r0 = rf(ctx, childID, userID, txController...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CareRelationshipRepository_DeleteByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByUser'
+type CareRelationshipRepository_DeleteByUser_Call struct {
+	*mock.Call
+}
+
+// DeleteByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - childID uuid.UUID
+//   - userID uuid.UUID
+//   - txController ...any
+func (_e *CareRelationshipRepository_Expecter) DeleteByUser(ctx interface{}, childID interface{}, userID interface{}, txController ...interface{}) *CareRelationshipRepository_DeleteByUser_Call {
+	return &CareRelationshipRepository_DeleteByUser_Call{Call: _e.mock.On("DeleteByUser",
+		append([]interface{}{ctx, childID, userID}, txController...)...)}
+}
+
+func (_c *CareRelationshipRepository_DeleteByUser_Call) Run(run func(ctx context.Context, childID uuid.UUID, userID uuid.UUID, txController ...any)) *CareRelationshipRepository_DeleteByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *CareRelationshipRepository_DeleteByUser_Call) Return(_a0 error) *CareRelationshipRepository_DeleteByUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CareRelationshipRepository_DeleteByUser_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, ...any) error) *CareRelationshipRepository_DeleteByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByChildIDAndTherapistID provides a mock function with given fields: ctx, childID, therapistID
+func (_m *CareRelationshipRepository) FindByChildIDAndTherapistID(ctx context.Context, childID uuid.UUID, therapistID uuid.UUID) (*model.CareRelationship, error) {
+	ret := _m.Called(ctx, childID, therapistID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByChildIDAndTherapistID")
+	}
+
+	var r0 *model.CareRelationship
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.CareRelationship, error)); ok {
+		return rf(ctx, childID, therapistID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.CareRelationship); ok {
+		r0 = rf(ctx, childID, therapistID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CareRelationship)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, childID, therapistID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CareRelationshipRepository_FindByChildIDAndTherapistID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByChildIDAndTherapistID'
+type CareRelationshipRepository_FindByChildIDAndTherapistID_Call struct {
+	*mock.Call
+}
+
+// FindByChildIDAndTherapistID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - childID uuid.UUID
+//   - therapistID uuid.UUID
+func (_e *CareRelationshipRepository_Expecter) FindByChildIDAndTherapistID(ctx interface{}, childID interface{}, therapistID interface{}) *CareRelationshipRepository_FindByChildIDAndTherapistID_Call {
+	return &CareRelationshipRepository_FindByChildIDAndTherapistID_Call{Call: _e.mock.On("FindByChildIDAndTherapistID", ctx, childID, therapistID)}
+}
+
+func (_c *CareRelationshipRepository_FindByChildIDAndTherapistID_Call) Run(run func(ctx context.Context, childID uuid.UUID, therapistID uuid.UUID)) *CareRelationshipRepository_FindByChildIDAndTherapistID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *CareRelationshipRepository_FindByChildIDAndTherapistID_Call) Return(_a0 *model.CareRelationship, _a1 error) *CareRelationshipRepository_FindByChildIDAndTherapistID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CareRelationshipRepository_FindByChildIDAndTherapistID_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.CareRelationship, error)) *CareRelationshipRepository_FindByChildIDAndTherapistID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByParentUserID provides a mock function with given fields: ctx, parentUserID
+func (_m *CareRelationshipRepository) FindByParentUserID(ctx context.Context, parentUserID uuid.UUID) ([]model.CareRelationship, error) {
+	ret := _m.Called(ctx, parentUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByParentUserID")
+	}
+
+	var r0 []model.CareRelationship
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]model.CareRelationship, error)); ok {
+		return rf(ctx, parentUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.CareRelationship); ok {
+		r0 = rf(ctx, parentUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CareRelationship)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, parentUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CareRelationshipRepository_FindByParentUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByParentUserID'
+type CareRelationshipRepository_FindByParentUserID_Call struct {
+	*mock.Call
+}
+
+// FindByParentUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - parentUserID uuid.UUID
+func (_e *CareRelationshipRepository_Expecter) FindByParentUserID(ctx interface{}, parentUserID interface{}) *CareRelationshipRepository_FindByParentUserID_Call {
+	return &CareRelationshipRepository_FindByParentUserID_Call{Call: _e.mock.On("FindByParentUserID", ctx, parentUserID)}
+}
+
+func (_c *CareRelationshipRepository_FindByParentUserID_Call) Run(run func(ctx context.Context, parentUserID uuid.UUID)) *CareRelationshipRepository_FindByParentUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *CareRelationshipRepository_FindByParentUserID_Call) Return(_a0 []model.CareRelationship, _a1 error) *CareRelationshipRepository_FindByParentUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CareRelationshipRepository_FindByParentUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]model.CareRelationship, error)) *CareRelationshipRepository_FindByParentUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function with given fields: ctx, input
+func (_m *CareRelationshipRepository) Upsert(ctx context.Context, input usecase.RepoUpsertCareRelationshipInput) (*model.CareRelationship, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 *model.CareRelationship
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoUpsertCareRelationshipInput) (*model.CareRelationship, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoUpsertCareRelationshipInput) *model.CareRelationship); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CareRelationship)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.RepoUpsertCareRelationshipInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CareRelationshipRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type CareRelationshipRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoUpsertCareRelationshipInput
+func (_e *CareRelationshipRepository_Expecter) Upsert(ctx interface{}, input interface{}) *CareRelationshipRepository_Upsert_Call {
+	return &CareRelationshipRepository_Upsert_Call{Call: _e.mock.On("Upsert", ctx, input)}
+}
+
+func (_c *CareRelationshipRepository_Upsert_Call) Run(run func(ctx context.Context, input usecase.RepoUpsertCareRelationshipInput)) *CareRelationshipRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.RepoUpsertCareRelationshipInput))
+	})
+	return _c
+}
+
+func (_c *CareRelationshipRepository_Upsert_Call) Return(_a0 *model.CareRelationship, _a1 error) *CareRelationshipRepository_Upsert_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CareRelationshipRepository_Upsert_Call) RunAndReturn(run func(context.Context, usecase.RepoUpsertCareRelationshipInput) (*model.CareRelationship, error)) *CareRelationshipRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCareRelationshipRepository creates a new instance of CareRelationshipRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCareRelationshipRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CareRelationshipRepository {
+	mock := &CareRelationshipRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
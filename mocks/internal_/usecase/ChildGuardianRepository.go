@@ -0,0 +1,346 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/luckyAkbar/atec/internal/model"
+	usecase "github.com/luckyAkbar/atec/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ChildGuardianRepository is an autogenerated mock type for the ChildGuardianRepository type
+type ChildGuardianRepository struct {
+	mock.Mock
+}
+
+type ChildGuardianRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ChildGuardianRepository) EXPECT() *ChildGuardianRepository_Expecter {
+	return &ChildGuardianRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, input, txController
+func (_m *ChildGuardianRepository) Create(ctx context.Context, input usecase.RepoCreateChildGuardianInput, txController ...any) (*model.ChildGuardian, error) {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, input)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.ChildGuardian
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoCreateChildGuardianInput, ...any) (*model.ChildGuardian, error)); ok {
+		return rf(ctx, input, txController...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoCreateChildGuardianInput, ...any) *model.ChildGuardian); ok {
+		r0 = rf(ctx, input, txController...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChildGuardian)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.RepoCreateChildGuardianInput, ...any) error); ok {
+		r1 = rf(ctx, input, txController...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChildGuardianRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type ChildGuardianRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoCreateChildGuardianInput
+//   - txController ...any
+func (_e *ChildGuardianRepository_Expecter) Create(ctx interface{}, input interface{}, txController ...interface{}) *ChildGuardianRepository_Create_Call {
+	return &ChildGuardianRepository_Create_Call{Call: _e.mock.On("Create",
+		append([]interface{}{ctx, input}, txController...)...)}
+}
+
+func (_c *ChildGuardianRepository_Create_Call) Run(run func(ctx context.Context, input usecase.RepoCreateChildGuardianInput, txController ...any)) *ChildGuardianRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(usecase.RepoCreateChildGuardianInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ChildGuardianRepository_Create_Call) Return(_a0 *model.ChildGuardian, _a1 error) *ChildGuardianRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ChildGuardianRepository_Create_Call) RunAndReturn(run func(context.Context, usecase.RepoCreateChildGuardianInput, ...any) (*model.ChildGuardian, error)) *ChildGuardianRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteGuardian provides a mock function with given fields: ctx, childID, userID
+func (_m *ChildGuardianRepository) DeleteGuardian(ctx context.Context, childID uuid.UUID, userID uuid.UUID) error {
+	ret := _m.Called(ctx, childID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGuardian")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, childID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChildGuardianRepository_DeleteGuardian_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteGuardian'
+type ChildGuardianRepository_DeleteGuardian_Call struct {
+	*mock.Call
+}
+
+// DeleteGuardian is a helper method to define mock.On call
+//   - ctx context.Context
+//   - childID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChildGuardianRepository_Expecter) DeleteGuardian(ctx interface{}, childID interface{}, userID interface{}) *ChildGuardianRepository_DeleteGuardian_Call {
+	return &ChildGuardianRepository_DeleteGuardian_Call{Call: _e.mock.On("DeleteGuardian", ctx, childID, userID)}
+}
+
+func (_c *ChildGuardianRepository_DeleteGuardian_Call) Run(run func(ctx context.Context, childID uuid.UUID, userID uuid.UUID)) *ChildGuardianRepository_DeleteGuardian_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ChildGuardianRepository_DeleteGuardian_Call) Return(_a0 error) *ChildGuardianRepository_DeleteGuardian_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ChildGuardianRepository_DeleteGuardian_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *ChildGuardianRepository_DeleteGuardian_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByChildID provides a mock function with given fields: ctx, childID
+func (_m *ChildGuardianRepository) FindByChildID(ctx context.Context, childID uuid.UUID) ([]model.ChildGuardian, error) {
+	ret := _m.Called(ctx, childID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByChildID")
+	}
+
+	var r0 []model.ChildGuardian
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]model.ChildGuardian, error)); ok {
+		return rf(ctx, childID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.ChildGuardian); ok {
+		r0 = rf(ctx, childID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ChildGuardian)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, childID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChildGuardianRepository_FindByChildID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByChildID'
+type ChildGuardianRepository_FindByChildID_Call struct {
+	*mock.Call
+}
+
+// FindByChildID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - childID uuid.UUID
+func (_e *ChildGuardianRepository_Expecter) FindByChildID(ctx interface{}, childID interface{}) *ChildGuardianRepository_FindByChildID_Call {
+	return &ChildGuardianRepository_FindByChildID_Call{Call: _e.mock.On("FindByChildID", ctx, childID)}
+}
+
+func (_c *ChildGuardianRepository_FindByChildID_Call) Run(run func(ctx context.Context, childID uuid.UUID)) *ChildGuardianRepository_FindByChildID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ChildGuardianRepository_FindByChildID_Call) Return(_a0 []model.ChildGuardian, _a1 error) *ChildGuardianRepository_FindByChildID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ChildGuardianRepository_FindByChildID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]model.ChildGuardian, error)) *ChildGuardianRepository_FindByChildID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByChildIDAndUserID provides a mock function with given fields: ctx, childID, userID
+func (_m *ChildGuardianRepository) FindByChildIDAndUserID(ctx context.Context, childID uuid.UUID, userID uuid.UUID) (*model.ChildGuardian, error) {
+	ret := _m.Called(ctx, childID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByChildIDAndUserID")
+	}
+
+	var r0 *model.ChildGuardian
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.ChildGuardian, error)); ok {
+		return rf(ctx, childID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.ChildGuardian); ok {
+		r0 = rf(ctx, childID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChildGuardian)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, childID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChildGuardianRepository_FindByChildIDAndUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByChildIDAndUserID'
+type ChildGuardianRepository_FindByChildIDAndUserID_Call struct {
+	*mock.Call
+}
+
+// FindByChildIDAndUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - childID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChildGuardianRepository_Expecter) FindByChildIDAndUserID(ctx interface{}, childID interface{}, userID interface{}) *ChildGuardianRepository_FindByChildIDAndUserID_Call {
+	return &ChildGuardianRepository_FindByChildIDAndUserID_Call{Call: _e.mock.On("FindByChildIDAndUserID", ctx, childID, userID)}
+}
+
+func (_c *ChildGuardianRepository_FindByChildIDAndUserID_Call) Run(run func(ctx context.Context, childID uuid.UUID, userID uuid.UUID)) *ChildGuardianRepository_FindByChildIDAndUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ChildGuardianRepository_FindByChildIDAndUserID_Call) Return(_a0 *model.ChildGuardian, _a1 error) *ChildGuardianRepository_FindByChildIDAndUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ChildGuardianRepository_FindByChildIDAndUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.ChildGuardian, error)) *ChildGuardianRepository_FindByChildIDAndUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindSuccessors provides a mock function with given fields: ctx, ownerUserID, txController
+func (_m *ChildGuardianRepository) FindSuccessors(ctx context.Context, ownerUserID uuid.UUID, txController ...any) ([]model.ChildGuardian, error) {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, ownerUserID)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindSuccessors")
+	}
+
+	var r0 []model.ChildGuardian
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, ...any) ([]model.ChildGuardian, error)); ok {
+		return rf(ctx, ownerUserID, txController...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, ...any) []model.ChildGuardian); ok {
+		r0 = rf(ctx, ownerUserID, txController...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ChildGuardian)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, ...any) error); ok {
+		r1 = rf(ctx, ownerUserID, txController...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChildGuardianRepository_FindSuccessors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindSuccessors'
+type ChildGuardianRepository_FindSuccessors_Call struct {
+	*mock.Call
+}
+
+// FindSuccessors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerUserID uuid.UUID
+//   - txController ...any
+func (_e *ChildGuardianRepository_Expecter) FindSuccessors(ctx interface{}, ownerUserID interface{}, txController ...interface{}) *ChildGuardianRepository_FindSuccessors_Call {
+	return &ChildGuardianRepository_FindSuccessors_Call{Call: _e.mock.On("FindSuccessors",
+		append([]interface{}{ctx, ownerUserID}, txController...)...)}
+}
+
+func (_c *ChildGuardianRepository_FindSuccessors_Call) Run(run func(ctx context.Context, ownerUserID uuid.UUID, txController ...any)) *ChildGuardianRepository_FindSuccessors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(uuid.UUID), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ChildGuardianRepository_FindSuccessors_Call) Return(_a0 []model.ChildGuardian, _a1 error) *ChildGuardianRepository_FindSuccessors_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ChildGuardianRepository_FindSuccessors_Call) RunAndReturn(run func(context.Context, uuid.UUID, ...any) ([]model.ChildGuardian, error)) *ChildGuardianRepository_FindSuccessors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewChildGuardianRepository creates a new instance of ChildGuardianRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewChildGuardianRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ChildGuardianRepository {
+	mock := &ChildGuardianRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
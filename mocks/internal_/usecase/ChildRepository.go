@@ -5,12 +5,10 @@ package usecase
 import (
 	context "context"
 
+	uuid "github.com/google/uuid"
 	model "github.com/luckyAkbar/atec/internal/model"
-	mock "github.com/stretchr/testify/mock"
-
 	usecase "github.com/luckyAkbar/atec/internal/usecase"
-
-	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // ChildRepository is an autogenerated mock type for the ChildRepository type
@@ -143,6 +141,64 @@ func (_c *ChildRepository_DeleteAllUserChildren_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// DeleteByID provides a mock function with given fields: ctx, id, txController
+func (_m *ChildRepository) DeleteByID(ctx context.Context, id uuid.UUID, txController ...any) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, ...any) error); ok {
+		r0 = rf(ctx, id, txController...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChildRepository_DeleteByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByID'
+type ChildRepository_DeleteByID_Call struct {
+	*mock.Call
+}
+
+// DeleteByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - txController ...any
+func (_e *ChildRepository_Expecter) DeleteByID(ctx interface{}, id interface{}, txController ...interface{}) *ChildRepository_DeleteByID_Call {
+	return &ChildRepository_DeleteByID_Call{Call: _e.mock.On("DeleteByID",
+		append([]interface{}{ctx, id}, txController...)...)}
+}
+
+func (_c *ChildRepository_DeleteByID_Call) Run(run func(ctx context.Context, id uuid.UUID, txController ...any)) *ChildRepository_DeleteByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(uuid.UUID), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ChildRepository_DeleteByID_Call) Return(_a0 error) *ChildRepository_DeleteByID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ChildRepository_DeleteByID_Call) RunAndReturn(run func(context.Context, uuid.UUID, ...any) error) *ChildRepository_DeleteByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindByID provides a mock function with given fields: ctx, id
 func (_m *ChildRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Child, error) {
 	ret := _m.Called(ctx, id)
@@ -202,6 +258,64 @@ func (_c *ChildRepository_FindByID_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// RestoreAllUserChildren provides a mock function with given fields: ctx, input, txController
+func (_m *ChildRepository) RestoreAllUserChildren(ctx context.Context, input usecase.RepoRestoreAllUserChildrenInput, txController ...any) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, input)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreAllUserChildren")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoRestoreAllUserChildrenInput, ...any) error); ok {
+		r0 = rf(ctx, input, txController...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChildRepository_RestoreAllUserChildren_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreAllUserChildren'
+type ChildRepository_RestoreAllUserChildren_Call struct {
+	*mock.Call
+}
+
+// RestoreAllUserChildren is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoRestoreAllUserChildrenInput
+//   - txController ...any
+func (_e *ChildRepository_Expecter) RestoreAllUserChildren(ctx interface{}, input interface{}, txController ...interface{}) *ChildRepository_RestoreAllUserChildren_Call {
+	return &ChildRepository_RestoreAllUserChildren_Call{Call: _e.mock.On("RestoreAllUserChildren",
+		append([]interface{}{ctx, input}, txController...)...)}
+}
+
+func (_c *ChildRepository_RestoreAllUserChildren_Call) Run(run func(ctx context.Context, input usecase.RepoRestoreAllUserChildrenInput, txController ...any)) *ChildRepository_RestoreAllUserChildren_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(usecase.RepoRestoreAllUserChildrenInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ChildRepository_RestoreAllUserChildren_Call) Return(_a0 error) *ChildRepository_RestoreAllUserChildren_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ChildRepository_RestoreAllUserChildren_Call) RunAndReturn(run func(context.Context, usecase.RepoRestoreAllUserChildrenInput, ...any) error) *ChildRepository_RestoreAllUserChildren_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Search provides a mock function with given fields: ctx, input
 func (_m *ChildRepository) Search(ctx context.Context, input usecase.RepoSearchChildInput) ([]model.Child, error) {
 	ret := _m.Called(ctx, input)
@@ -261,6 +375,64 @@ func (_c *ChildRepository_Search_Call) RunAndReturn(run func(context.Context, us
 	return _c
 }
 
+// TransferOwnership provides a mock function with given fields: ctx, input, txController
+func (_m *ChildRepository) TransferOwnership(ctx context.Context, input usecase.RepoTransferChildInput, txController ...any) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, input)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferOwnership")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoTransferChildInput, ...any) error); ok {
+		r0 = rf(ctx, input, txController...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChildRepository_TransferOwnership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferOwnership'
+type ChildRepository_TransferOwnership_Call struct {
+	*mock.Call
+}
+
+// TransferOwnership is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoTransferChildInput
+//   - txController ...any
+func (_e *ChildRepository_Expecter) TransferOwnership(ctx interface{}, input interface{}, txController ...interface{}) *ChildRepository_TransferOwnership_Call {
+	return &ChildRepository_TransferOwnership_Call{Call: _e.mock.On("TransferOwnership",
+		append([]interface{}{ctx, input}, txController...)...)}
+}
+
+func (_c *ChildRepository_TransferOwnership_Call) Run(run func(ctx context.Context, input usecase.RepoTransferChildInput, txController ...any)) *ChildRepository_TransferOwnership_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(usecase.RepoTransferChildInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ChildRepository_TransferOwnership_Call) Return(_a0 error) *ChildRepository_TransferOwnership_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ChildRepository_TransferOwnership_Call) RunAndReturn(run func(context.Context, usecase.RepoTransferChildInput, ...any) error) *ChildRepository_TransferOwnership_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, id, input
 func (_m *ChildRepository) Update(ctx context.Context, id uuid.UUID, input usecase.RepoUpdateChildInput) (*model.Child, error) {
 	ret := _m.Called(ctx, id, input)
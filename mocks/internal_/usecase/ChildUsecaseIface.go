@@ -81,6 +81,218 @@ func (_c *ChildUsecaseIface_GetRegisteredChildren_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// HandleAcceptChildTransfer provides a mock function with given fields: ctx, input
+func (_m *ChildUsecaseIface) HandleAcceptChildTransfer(ctx context.Context, input usecase.AcceptChildTransferInput) (*usecase.AcceptChildTransferOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleAcceptChildTransfer")
+	}
+
+	var r0 *usecase.AcceptChildTransferOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AcceptChildTransferInput) (*usecase.AcceptChildTransferOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AcceptChildTransferInput) *usecase.AcceptChildTransferOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.AcceptChildTransferOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.AcceptChildTransferInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChildUsecaseIface_HandleAcceptChildTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleAcceptChildTransfer'
+type ChildUsecaseIface_HandleAcceptChildTransfer_Call struct {
+	*mock.Call
+}
+
+// HandleAcceptChildTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.AcceptChildTransferInput
+func (_e *ChildUsecaseIface_Expecter) HandleAcceptChildTransfer(ctx interface{}, input interface{}) *ChildUsecaseIface_HandleAcceptChildTransfer_Call {
+	return &ChildUsecaseIface_HandleAcceptChildTransfer_Call{Call: _e.mock.On("HandleAcceptChildTransfer", ctx, input)}
+}
+
+func (_c *ChildUsecaseIface_HandleAcceptChildTransfer_Call) Run(run func(ctx context.Context, input usecase.AcceptChildTransferInput)) *ChildUsecaseIface_HandleAcceptChildTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.AcceptChildTransferInput))
+	})
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleAcceptChildTransfer_Call) Return(_a0 *usecase.AcceptChildTransferOutput, _a1 error) *ChildUsecaseIface_HandleAcceptChildTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleAcceptChildTransfer_Call) RunAndReturn(run func(context.Context, usecase.AcceptChildTransferInput) (*usecase.AcceptChildTransferOutput, error)) *ChildUsecaseIface_HandleAcceptChildTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleAcceptGuardianInvitation provides a mock function with given fields: ctx, input
+func (_m *ChildUsecaseIface) HandleAcceptGuardianInvitation(ctx context.Context, input usecase.AcceptGuardianInvitationInput) (*usecase.GuardianOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleAcceptGuardianInvitation")
+	}
+
+	var r0 *usecase.GuardianOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AcceptGuardianInvitationInput) (*usecase.GuardianOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AcceptGuardianInvitationInput) *usecase.GuardianOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.GuardianOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.AcceptGuardianInvitationInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChildUsecaseIface_HandleAcceptGuardianInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleAcceptGuardianInvitation'
+type ChildUsecaseIface_HandleAcceptGuardianInvitation_Call struct {
+	*mock.Call
+}
+
+// HandleAcceptGuardianInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.AcceptGuardianInvitationInput
+func (_e *ChildUsecaseIface_Expecter) HandleAcceptGuardianInvitation(ctx interface{}, input interface{}) *ChildUsecaseIface_HandleAcceptGuardianInvitation_Call {
+	return &ChildUsecaseIface_HandleAcceptGuardianInvitation_Call{Call: _e.mock.On("HandleAcceptGuardianInvitation", ctx, input)}
+}
+
+func (_c *ChildUsecaseIface_HandleAcceptGuardianInvitation_Call) Run(run func(ctx context.Context, input usecase.AcceptGuardianInvitationInput)) *ChildUsecaseIface_HandleAcceptGuardianInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.AcceptGuardianInvitationInput))
+	})
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleAcceptGuardianInvitation_Call) Return(_a0 *usecase.GuardianOutput, _a1 error) *ChildUsecaseIface_HandleAcceptGuardianInvitation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleAcceptGuardianInvitation_Call) RunAndReturn(run func(context.Context, usecase.AcceptGuardianInvitationInput) (*usecase.GuardianOutput, error)) *ChildUsecaseIface_HandleAcceptGuardianInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleArchiveChild provides a mock function with given fields: ctx, input
+func (_m *ChildUsecaseIface) HandleArchiveChild(ctx context.Context, input usecase.ArchiveChildInput) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleArchiveChild")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.ArchiveChildInput) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChildUsecaseIface_HandleArchiveChild_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleArchiveChild'
+type ChildUsecaseIface_HandleArchiveChild_Call struct {
+	*mock.Call
+}
+
+// HandleArchiveChild is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.ArchiveChildInput
+func (_e *ChildUsecaseIface_Expecter) HandleArchiveChild(ctx interface{}, input interface{}) *ChildUsecaseIface_HandleArchiveChild_Call {
+	return &ChildUsecaseIface_HandleArchiveChild_Call{Call: _e.mock.On("HandleArchiveChild", ctx, input)}
+}
+
+func (_c *ChildUsecaseIface_HandleArchiveChild_Call) Run(run func(ctx context.Context, input usecase.ArchiveChildInput)) *ChildUsecaseIface_HandleArchiveChild_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.ArchiveChildInput))
+	})
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleArchiveChild_Call) Return(_a0 error) *ChildUsecaseIface_HandleArchiveChild_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleArchiveChild_Call) RunAndReturn(run func(context.Context, usecase.ArchiveChildInput) error) *ChildUsecaseIface_HandleArchiveChild_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleDeleteChild provides a mock function with given fields: ctx, input
+func (_m *ChildUsecaseIface) HandleDeleteChild(ctx context.Context, input usecase.DeleteChildInput) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleDeleteChild")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.DeleteChildInput) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChildUsecaseIface_HandleDeleteChild_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleDeleteChild'
+type ChildUsecaseIface_HandleDeleteChild_Call struct {
+	*mock.Call
+}
+
+// HandleDeleteChild is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.DeleteChildInput
+func (_e *ChildUsecaseIface_Expecter) HandleDeleteChild(ctx interface{}, input interface{}) *ChildUsecaseIface_HandleDeleteChild_Call {
+	return &ChildUsecaseIface_HandleDeleteChild_Call{Call: _e.mock.On("HandleDeleteChild", ctx, input)}
+}
+
+func (_c *ChildUsecaseIface_HandleDeleteChild_Call) Run(run func(ctx context.Context, input usecase.DeleteChildInput)) *ChildUsecaseIface_HandleDeleteChild_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.DeleteChildInput))
+	})
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleDeleteChild_Call) Return(_a0 error) *ChildUsecaseIface_HandleDeleteChild_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleDeleteChild_Call) RunAndReturn(run func(context.Context, usecase.DeleteChildInput) error) *ChildUsecaseIface_HandleDeleteChild_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // HandleGetStatistic provides a mock function with given fields: ctx, input
 func (_m *ChildUsecaseIface) HandleGetStatistic(ctx context.Context, input usecase.GetStatisticInput) (*usecase.GetStatisticOutput, error) {
 	ret := _m.Called(ctx, input)
@@ -140,6 +352,277 @@ func (_c *ChildUsecaseIface_HandleGetStatistic_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// HandleInitiateChildTransfer provides a mock function with given fields: ctx, input
+func (_m *ChildUsecaseIface) HandleInitiateChildTransfer(ctx context.Context, input usecase.InitiateChildTransferInput) (*usecase.InitiateChildTransferOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleInitiateChildTransfer")
+	}
+
+	var r0 *usecase.InitiateChildTransferOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.InitiateChildTransferInput) (*usecase.InitiateChildTransferOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.InitiateChildTransferInput) *usecase.InitiateChildTransferOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.InitiateChildTransferOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.InitiateChildTransferInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChildUsecaseIface_HandleInitiateChildTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleInitiateChildTransfer'
+type ChildUsecaseIface_HandleInitiateChildTransfer_Call struct {
+	*mock.Call
+}
+
+// HandleInitiateChildTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.InitiateChildTransferInput
+func (_e *ChildUsecaseIface_Expecter) HandleInitiateChildTransfer(ctx interface{}, input interface{}) *ChildUsecaseIface_HandleInitiateChildTransfer_Call {
+	return &ChildUsecaseIface_HandleInitiateChildTransfer_Call{Call: _e.mock.On("HandleInitiateChildTransfer", ctx, input)}
+}
+
+func (_c *ChildUsecaseIface_HandleInitiateChildTransfer_Call) Run(run func(ctx context.Context, input usecase.InitiateChildTransferInput)) *ChildUsecaseIface_HandleInitiateChildTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.InitiateChildTransferInput))
+	})
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleInitiateChildTransfer_Call) Return(_a0 *usecase.InitiateChildTransferOutput, _a1 error) *ChildUsecaseIface_HandleInitiateChildTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleInitiateChildTransfer_Call) RunAndReturn(run func(context.Context, usecase.InitiateChildTransferInput) (*usecase.InitiateChildTransferOutput, error)) *ChildUsecaseIface_HandleInitiateChildTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleInviteGuardian provides a mock function with given fields: ctx, input
+func (_m *ChildUsecaseIface) HandleInviteGuardian(ctx context.Context, input usecase.InviteGuardianInput) (*usecase.InviteGuardianOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleInviteGuardian")
+	}
+
+	var r0 *usecase.InviteGuardianOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.InviteGuardianInput) (*usecase.InviteGuardianOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.InviteGuardianInput) *usecase.InviteGuardianOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.InviteGuardianOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.InviteGuardianInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChildUsecaseIface_HandleInviteGuardian_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleInviteGuardian'
+type ChildUsecaseIface_HandleInviteGuardian_Call struct {
+	*mock.Call
+}
+
+// HandleInviteGuardian is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.InviteGuardianInput
+func (_e *ChildUsecaseIface_Expecter) HandleInviteGuardian(ctx interface{}, input interface{}) *ChildUsecaseIface_HandleInviteGuardian_Call {
+	return &ChildUsecaseIface_HandleInviteGuardian_Call{Call: _e.mock.On("HandleInviteGuardian", ctx, input)}
+}
+
+func (_c *ChildUsecaseIface_HandleInviteGuardian_Call) Run(run func(ctx context.Context, input usecase.InviteGuardianInput)) *ChildUsecaseIface_HandleInviteGuardian_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.InviteGuardianInput))
+	})
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleInviteGuardian_Call) Return(_a0 *usecase.InviteGuardianOutput, _a1 error) *ChildUsecaseIface_HandleInviteGuardian_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleInviteGuardian_Call) RunAndReturn(run func(context.Context, usecase.InviteGuardianInput) (*usecase.InviteGuardianOutput, error)) *ChildUsecaseIface_HandleInviteGuardian_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleListGuardians provides a mock function with given fields: ctx, input
+func (_m *ChildUsecaseIface) HandleListGuardians(ctx context.Context, input usecase.ListGuardiansInput) ([]usecase.GuardianOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleListGuardians")
+	}
+
+	var r0 []usecase.GuardianOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.ListGuardiansInput) ([]usecase.GuardianOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.ListGuardiansInput) []usecase.GuardianOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]usecase.GuardianOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.ListGuardiansInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChildUsecaseIface_HandleListGuardians_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleListGuardians'
+type ChildUsecaseIface_HandleListGuardians_Call struct {
+	*mock.Call
+}
+
+// HandleListGuardians is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.ListGuardiansInput
+func (_e *ChildUsecaseIface_Expecter) HandleListGuardians(ctx interface{}, input interface{}) *ChildUsecaseIface_HandleListGuardians_Call {
+	return &ChildUsecaseIface_HandleListGuardians_Call{Call: _e.mock.On("HandleListGuardians", ctx, input)}
+}
+
+func (_c *ChildUsecaseIface_HandleListGuardians_Call) Run(run func(ctx context.Context, input usecase.ListGuardiansInput)) *ChildUsecaseIface_HandleListGuardians_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.ListGuardiansInput))
+	})
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleListGuardians_Call) Return(_a0 []usecase.GuardianOutput, _a1 error) *ChildUsecaseIface_HandleListGuardians_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleListGuardians_Call) RunAndReturn(run func(context.Context, usecase.ListGuardiansInput) ([]usecase.GuardianOutput, error)) *ChildUsecaseIface_HandleListGuardians_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleRemoveGuardian provides a mock function with given fields: ctx, input
+func (_m *ChildUsecaseIface) HandleRemoveGuardian(ctx context.Context, input usecase.RemoveGuardianInput) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleRemoveGuardian")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RemoveGuardianInput) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChildUsecaseIface_HandleRemoveGuardian_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleRemoveGuardian'
+type ChildUsecaseIface_HandleRemoveGuardian_Call struct {
+	*mock.Call
+}
+
+// HandleRemoveGuardian is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RemoveGuardianInput
+func (_e *ChildUsecaseIface_Expecter) HandleRemoveGuardian(ctx interface{}, input interface{}) *ChildUsecaseIface_HandleRemoveGuardian_Call {
+	return &ChildUsecaseIface_HandleRemoveGuardian_Call{Call: _e.mock.On("HandleRemoveGuardian", ctx, input)}
+}
+
+func (_c *ChildUsecaseIface_HandleRemoveGuardian_Call) Run(run func(ctx context.Context, input usecase.RemoveGuardianInput)) *ChildUsecaseIface_HandleRemoveGuardian_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.RemoveGuardianInput))
+	})
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleRemoveGuardian_Call) Return(_a0 error) *ChildUsecaseIface_HandleRemoveGuardian_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleRemoveGuardian_Call) RunAndReturn(run func(context.Context, usecase.RemoveGuardianInput) error) *ChildUsecaseIface_HandleRemoveGuardian_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleRestoreChild provides a mock function with given fields: ctx, input
+func (_m *ChildUsecaseIface) HandleRestoreChild(ctx context.Context, input usecase.RestoreChildInput) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleRestoreChild")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RestoreChildInput) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChildUsecaseIface_HandleRestoreChild_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleRestoreChild'
+type ChildUsecaseIface_HandleRestoreChild_Call struct {
+	*mock.Call
+}
+
+// HandleRestoreChild is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RestoreChildInput
+func (_e *ChildUsecaseIface_Expecter) HandleRestoreChild(ctx interface{}, input interface{}) *ChildUsecaseIface_HandleRestoreChild_Call {
+	return &ChildUsecaseIface_HandleRestoreChild_Call{Call: _e.mock.On("HandleRestoreChild", ctx, input)}
+}
+
+func (_c *ChildUsecaseIface_HandleRestoreChild_Call) Run(run func(ctx context.Context, input usecase.RestoreChildInput)) *ChildUsecaseIface_HandleRestoreChild_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.RestoreChildInput))
+	})
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleRestoreChild_Call) Return(_a0 error) *ChildUsecaseIface_HandleRestoreChild_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ChildUsecaseIface_HandleRestoreChild_Call) RunAndReturn(run func(context.Context, usecase.RestoreChildInput) error) *ChildUsecaseIface_HandleRestoreChild_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Register provides a mock function with given fields: ctx, input
 func (_m *ChildUsecaseIface) Register(ctx context.Context, input usecase.RegisterChildInput) (*usecase.RegisterChildOutput, error) {
 	ret := _m.Called(ctx, input)
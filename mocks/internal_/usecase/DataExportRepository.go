@@ -0,0 +1,360 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	model "github.com/luckyAkbar/atec/internal/model"
+	usecase "github.com/luckyAkbar/atec/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DataExportRepository is an autogenerated mock type for the DataExportRepository type
+type DataExportRepository struct {
+	mock.Mock
+}
+
+type DataExportRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DataExportRepository) EXPECT() *DataExportRepository_Expecter {
+	return &DataExportRepository_Expecter{mock: &_m.Mock}
+}
+
+// Complete provides a mock function with given fields: ctx, input
+func (_m *DataExportRepository) Complete(ctx context.Context, input usecase.RepoCompleteDataExportInput) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoCompleteDataExportInput) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataExportRepository_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type DataExportRepository_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoCompleteDataExportInput
+func (_e *DataExportRepository_Expecter) Complete(ctx interface{}, input interface{}) *DataExportRepository_Complete_Call {
+	return &DataExportRepository_Complete_Call{Call: _e.mock.On("Complete", ctx, input)}
+}
+
+func (_c *DataExportRepository_Complete_Call) Run(run func(ctx context.Context, input usecase.RepoCompleteDataExportInput)) *DataExportRepository_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.RepoCompleteDataExportInput))
+	})
+	return _c
+}
+
+func (_c *DataExportRepository_Complete_Call) Return(_a0 error) *DataExportRepository_Complete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataExportRepository_Complete_Call) RunAndReturn(run func(context.Context, usecase.RepoCompleteDataExportInput) error) *DataExportRepository_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, userID
+func (_m *DataExportRepository) Create(ctx context.Context, userID uuid.UUID) (*model.DataExport, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.DataExport, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.DataExport); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataExportRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type DataExportRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *DataExportRepository_Expecter) Create(ctx interface{}, userID interface{}) *DataExportRepository_Create_Call {
+	return &DataExportRepository_Create_Call{Call: _e.mock.On("Create", ctx, userID)}
+}
+
+func (_c *DataExportRepository_Create_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *DataExportRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataExportRepository_Create_Call) Return(_a0 *model.DataExport, _a1 error) *DataExportRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataExportRepository_Create_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.DataExport, error)) *DataExportRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailStaleByUserID provides a mock function with given fields: ctx, userID, updatedBefore
+func (_m *DataExportRepository) FailStaleByUserID(ctx context.Context, userID uuid.UUID, updatedBefore time.Time) error {
+	ret := _m.Called(ctx, userID, updatedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailStaleByUserID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r0 = rf(ctx, userID, updatedBefore)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataExportRepository_FailStaleByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailStaleByUserID'
+type DataExportRepository_FailStaleByUserID_Call struct {
+	*mock.Call
+}
+
+// FailStaleByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - updatedBefore time.Time
+func (_e *DataExportRepository_Expecter) FailStaleByUserID(ctx interface{}, userID interface{}, updatedBefore interface{}) *DataExportRepository_FailStaleByUserID_Call {
+	return &DataExportRepository_FailStaleByUserID_Call{Call: _e.mock.On("FailStaleByUserID", ctx, userID, updatedBefore)}
+}
+
+func (_c *DataExportRepository_FailStaleByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID, updatedBefore time.Time)) *DataExportRepository_FailStaleByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *DataExportRepository_FailStaleByUserID_Call) Return(_a0 error) *DataExportRepository_FailStaleByUserID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataExportRepository_FailStaleByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) error) *DataExportRepository_FailStaleByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByDownloadTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *DataExportRepository) FindByDownloadTokenHash(ctx context.Context, tokenHash string) (*model.DataExport, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByDownloadTokenHash")
+	}
+
+	var r0 *model.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.DataExport, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.DataExport); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataExportRepository_FindByDownloadTokenHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByDownloadTokenHash'
+type DataExportRepository_FindByDownloadTokenHash_Call struct {
+	*mock.Call
+}
+
+// FindByDownloadTokenHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *DataExportRepository_Expecter) FindByDownloadTokenHash(ctx interface{}, tokenHash interface{}) *DataExportRepository_FindByDownloadTokenHash_Call {
+	return &DataExportRepository_FindByDownloadTokenHash_Call{Call: _e.mock.On("FindByDownloadTokenHash", ctx, tokenHash)}
+}
+
+func (_c *DataExportRepository_FindByDownloadTokenHash_Call) Run(run func(ctx context.Context, tokenHash string)) *DataExportRepository_FindByDownloadTokenHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *DataExportRepository_FindByDownloadTokenHash_Call) Return(_a0 *model.DataExport, _a1 error) *DataExportRepository_FindByDownloadTokenHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataExportRepository_FindByDownloadTokenHash_Call) RunAndReturn(run func(context.Context, string) (*model.DataExport, error)) *DataExportRepository_FindByDownloadTokenHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByUserID provides a mock function with given fields: ctx, userID
+func (_m *DataExportRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]model.DataExport, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUserID")
+	}
+
+	var r0 []model.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]model.DataExport, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.DataExport); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataExportRepository_FindByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByUserID'
+type DataExportRepository_FindByUserID_Call struct {
+	*mock.Call
+}
+
+// FindByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *DataExportRepository_Expecter) FindByUserID(ctx interface{}, userID interface{}) *DataExportRepository_FindByUserID_Call {
+	return &DataExportRepository_FindByUserID_Call{Call: _e.mock.On("FindByUserID", ctx, userID)}
+}
+
+func (_c *DataExportRepository_FindByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *DataExportRepository_FindByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataExportRepository_FindByUserID_Call) Return(_a0 []model.DataExport, _a1 error) *DataExportRepository_FindByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataExportRepository_FindByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]model.DataExport, error)) *DataExportRepository_FindByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, id, status
+func (_m *DataExportRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status model.DataExportStatus) error {
+	ret := _m.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, model.DataExportStatus) error); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataExportRepository_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
+type DataExportRepository_UpdateStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - status model.DataExportStatus
+func (_e *DataExportRepository_Expecter) UpdateStatus(ctx interface{}, id interface{}, status interface{}) *DataExportRepository_UpdateStatus_Call {
+	return &DataExportRepository_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", ctx, id, status)}
+}
+
+func (_c *DataExportRepository_UpdateStatus_Call) Run(run func(ctx context.Context, id uuid.UUID, status model.DataExportStatus)) *DataExportRepository_UpdateStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(model.DataExportStatus))
+	})
+	return _c
+}
+
+func (_c *DataExportRepository_UpdateStatus_Call) Return(_a0 error) *DataExportRepository_UpdateStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataExportRepository_UpdateStatus_Call) RunAndReturn(run func(context.Context, uuid.UUID, model.DataExportStatus) error) *DataExportRepository_UpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDataExportRepository creates a new instance of DataExportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDataExportRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DataExportRepository {
+	mock := &DataExportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
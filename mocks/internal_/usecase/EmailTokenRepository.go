@@ -0,0 +1,226 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/luckyAkbar/atec/internal/model"
+	usecase "github.com/luckyAkbar/atec/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EmailTokenRepository is an autogenerated mock type for the EmailTokenRepository type
+type EmailTokenRepository struct {
+	mock.Mock
+}
+
+type EmailTokenRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *EmailTokenRepository) EXPECT() *EmailTokenRepository_Expecter {
+	return &EmailTokenRepository_Expecter{mock: &_m.Mock}
+}
+
+// Consume provides a mock function with given fields: ctx, input, txController
+func (_m *EmailTokenRepository) Consume(ctx context.Context, input usecase.RepoConsumeEmailTokenInput, txController ...any) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, input)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Consume")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoConsumeEmailTokenInput, ...any) error); ok {
+		r0 = rf(ctx, input, txController...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EmailTokenRepository_Consume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Consume'
+type EmailTokenRepository_Consume_Call struct {
+	*mock.Call
+}
+
+// Consume is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoConsumeEmailTokenInput
+//   - txController ...any
+func (_e *EmailTokenRepository_Expecter) Consume(ctx interface{}, input interface{}, txController ...interface{}) *EmailTokenRepository_Consume_Call {
+	return &EmailTokenRepository_Consume_Call{Call: _e.mock.On("Consume",
+		append([]interface{}{ctx, input}, txController...)...)}
+}
+
+func (_c *EmailTokenRepository_Consume_Call) Run(run func(ctx context.Context, input usecase.RepoConsumeEmailTokenInput, txController ...any)) *EmailTokenRepository_Consume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(usecase.RepoConsumeEmailTokenInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *EmailTokenRepository_Consume_Call) Return(_a0 error) *EmailTokenRepository_Consume_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EmailTokenRepository_Consume_Call) RunAndReturn(run func(context.Context, usecase.RepoConsumeEmailTokenInput, ...any) error) *EmailTokenRepository_Consume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, input, txController
+func (_m *EmailTokenRepository) Create(ctx context.Context, input usecase.RepoCreateEmailTokenInput, txController ...any) (*model.EmailToken, error) {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, input)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.EmailToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoCreateEmailTokenInput, ...any) (*model.EmailToken, error)); ok {
+		return rf(ctx, input, txController...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoCreateEmailTokenInput, ...any) *model.EmailToken); ok {
+		r0 = rf(ctx, input, txController...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EmailToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.RepoCreateEmailTokenInput, ...any) error); ok {
+		r1 = rf(ctx, input, txController...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EmailTokenRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type EmailTokenRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoCreateEmailTokenInput
+//   - txController ...any
+func (_e *EmailTokenRepository_Expecter) Create(ctx interface{}, input interface{}, txController ...interface{}) *EmailTokenRepository_Create_Call {
+	return &EmailTokenRepository_Create_Call{Call: _e.mock.On("Create",
+		append([]interface{}{ctx, input}, txController...)...)}
+}
+
+func (_c *EmailTokenRepository_Create_Call) Run(run func(ctx context.Context, input usecase.RepoCreateEmailTokenInput, txController ...any)) *EmailTokenRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(usecase.RepoCreateEmailTokenInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *EmailTokenRepository_Create_Call) Return(_a0 *model.EmailToken, _a1 error) *EmailTokenRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EmailTokenRepository_Create_Call) RunAndReturn(run func(context.Context, usecase.RepoCreateEmailTokenInput, ...any) (*model.EmailToken, error)) *EmailTokenRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAllUserTokens provides a mock function with given fields: ctx, userID, purpose, txController
+func (_m *EmailTokenRepository) RevokeAllUserTokens(ctx context.Context, userID uuid.UUID, purpose string, txController ...any) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, userID, purpose)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAllUserTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, ...any) error); ok {
+		r0 = rf(ctx, userID, purpose, txController...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EmailTokenRepository_RevokeAllUserTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAllUserTokens'
+type EmailTokenRepository_RevokeAllUserTokens_Call struct {
+	*mock.Call
+}
+
+// RevokeAllUserTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - purpose string
+//   - txController ...any
+func (_e *EmailTokenRepository_Expecter) RevokeAllUserTokens(ctx interface{}, userID interface{}, purpose interface{}, txController ...interface{}) *EmailTokenRepository_RevokeAllUserTokens_Call {
+	return &EmailTokenRepository_RevokeAllUserTokens_Call{Call: _e.mock.On("RevokeAllUserTokens",
+		append([]interface{}{ctx, userID, purpose}, txController...)...)}
+}
+
+func (_c *EmailTokenRepository_RevokeAllUserTokens_Call) Run(run func(ctx context.Context, userID uuid.UUID, purpose string, txController ...any)) *EmailTokenRepository_RevokeAllUserTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *EmailTokenRepository_RevokeAllUserTokens_Call) Return(_a0 error) *EmailTokenRepository_RevokeAllUserTokens_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EmailTokenRepository_RevokeAllUserTokens_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, ...any) error) *EmailTokenRepository_RevokeAllUserTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewEmailTokenRepository creates a new instance of EmailTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEmailTokenRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EmailTokenRepository {
+	mock := &EmailTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
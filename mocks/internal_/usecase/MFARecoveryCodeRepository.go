@@ -0,0 +1,203 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MFARecoveryCodeRepository is an autogenerated mock type for the MFARecoveryCodeRepository type
+type MFARecoveryCodeRepository struct {
+	mock.Mock
+}
+
+type MFARecoveryCodeRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MFARecoveryCodeRepository) EXPECT() *MFARecoveryCodeRepository_Expecter {
+	return &MFARecoveryCodeRepository_Expecter{mock: &_m.Mock}
+}
+
+// Consume provides a mock function with given fields: ctx, userID, codeHash
+func (_m *MFARecoveryCodeRepository) Consume(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	ret := _m.Called(ctx, userID, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Consume")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, userID, codeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MFARecoveryCodeRepository_Consume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Consume'
+type MFARecoveryCodeRepository_Consume_Call struct {
+	*mock.Call
+}
+
+// Consume is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - codeHash string
+func (_e *MFARecoveryCodeRepository_Expecter) Consume(ctx interface{}, userID interface{}, codeHash interface{}) *MFARecoveryCodeRepository_Consume_Call {
+	return &MFARecoveryCodeRepository_Consume_Call{Call: _e.mock.On("Consume", ctx, userID, codeHash)}
+}
+
+func (_c *MFARecoveryCodeRepository_Consume_Call) Run(run func(ctx context.Context, userID uuid.UUID, codeHash string)) *MFARecoveryCodeRepository_Consume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MFARecoveryCodeRepository_Consume_Call) Return(_a0 error) *MFARecoveryCodeRepository_Consume_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MFARecoveryCodeRepository_Consume_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *MFARecoveryCodeRepository_Consume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAll provides a mock function with given fields: ctx, userID, txController
+func (_m *MFARecoveryCodeRepository) DeleteAll(ctx context.Context, userID uuid.UUID, txController ...any) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, userID)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, ...any) error); ok {
+		r0 = rf(ctx, userID, txController...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MFARecoveryCodeRepository_DeleteAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAll'
+type MFARecoveryCodeRepository_DeleteAll_Call struct {
+	*mock.Call
+}
+
+// DeleteAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - txController ...any
+func (_e *MFARecoveryCodeRepository_Expecter) DeleteAll(ctx interface{}, userID interface{}, txController ...interface{}) *MFARecoveryCodeRepository_DeleteAll_Call {
+	return &MFARecoveryCodeRepository_DeleteAll_Call{Call: _e.mock.On("DeleteAll",
+		append([]interface{}{ctx, userID}, txController...)...)}
+}
+
+func (_c *MFARecoveryCodeRepository_DeleteAll_Call) Run(run func(ctx context.Context, userID uuid.UUID, txController ...any)) *MFARecoveryCodeRepository_DeleteAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(uuid.UUID), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MFARecoveryCodeRepository_DeleteAll_Call) Return(_a0 error) *MFARecoveryCodeRepository_DeleteAll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MFARecoveryCodeRepository_DeleteAll_Call) RunAndReturn(run func(context.Context, uuid.UUID, ...any) error) *MFARecoveryCodeRepository_DeleteAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceAll provides a mock function with given fields: ctx, userID, codeHashes, txController
+func (_m *MFARecoveryCodeRepository) ReplaceAll(ctx context.Context, userID uuid.UUID, codeHashes []string, txController ...any) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, userID, codeHashes)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []string, ...any) error); ok {
+		r0 = rf(ctx, userID, codeHashes, txController...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MFARecoveryCodeRepository_ReplaceAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceAll'
+type MFARecoveryCodeRepository_ReplaceAll_Call struct {
+	*mock.Call
+}
+
+// ReplaceAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - codeHashes []string
+//   - txController ...any
+func (_e *MFARecoveryCodeRepository_Expecter) ReplaceAll(ctx interface{}, userID interface{}, codeHashes interface{}, txController ...interface{}) *MFARecoveryCodeRepository_ReplaceAll_Call {
+	return &MFARecoveryCodeRepository_ReplaceAll_Call{Call: _e.mock.On("ReplaceAll",
+		append([]interface{}{ctx, userID, codeHashes}, txController...)...)}
+}
+
+func (_c *MFARecoveryCodeRepository_ReplaceAll_Call) Run(run func(ctx context.Context, userID uuid.UUID, codeHashes []string, txController ...any)) *MFARecoveryCodeRepository_ReplaceAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].([]string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MFARecoveryCodeRepository_ReplaceAll_Call) Return(_a0 error) *MFARecoveryCodeRepository_ReplaceAll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MFARecoveryCodeRepository_ReplaceAll_Call) RunAndReturn(run func(context.Context, uuid.UUID, []string, ...any) error) *MFARecoveryCodeRepository_ReplaceAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMFARecoveryCodeRepository creates a new instance of MFARecoveryCodeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMFARecoveryCodeRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MFARecoveryCodeRepository {
+	mock := &MFARecoveryCodeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
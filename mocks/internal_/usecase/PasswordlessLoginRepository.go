@@ -0,0 +1,192 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/luckyAkbar/atec/internal/model"
+	usecase "github.com/luckyAkbar/atec/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasswordlessLoginRepository is an autogenerated mock type for the PasswordlessLoginRepository type
+type PasswordlessLoginRepository struct {
+	mock.Mock
+}
+
+type PasswordlessLoginRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PasswordlessLoginRepository) EXPECT() *PasswordlessLoginRepository_Expecter {
+	return &PasswordlessLoginRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, input
+func (_m *PasswordlessLoginRepository) Create(ctx context.Context, input usecase.RepoCreatePasswordlessLoginInput) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoCreatePasswordlessLoginInput) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PasswordlessLoginRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type PasswordlessLoginRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoCreatePasswordlessLoginInput
+func (_e *PasswordlessLoginRepository_Expecter) Create(ctx interface{}, input interface{}) *PasswordlessLoginRepository_Create_Call {
+	return &PasswordlessLoginRepository_Create_Call{Call: _e.mock.On("Create", ctx, input)}
+}
+
+func (_c *PasswordlessLoginRepository_Create_Call) Run(run func(ctx context.Context, input usecase.RepoCreatePasswordlessLoginInput)) *PasswordlessLoginRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.RepoCreatePasswordlessLoginInput))
+	})
+	return _c
+}
+
+func (_c *PasswordlessLoginRepository_Create_Call) Return(_a0 error) *PasswordlessLoginRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PasswordlessLoginRepository_Create_Call) RunAndReturn(run func(context.Context, usecase.RepoCreatePasswordlessLoginInput) error) *PasswordlessLoginRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByUserID provides a mock function with given fields: ctx, userID
+func (_m *PasswordlessLoginRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUserID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PasswordlessLoginRepository_DeleteByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByUserID'
+type PasswordlessLoginRepository_DeleteByUserID_Call struct {
+	*mock.Call
+}
+
+// DeleteByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *PasswordlessLoginRepository_Expecter) DeleteByUserID(ctx interface{}, userID interface{}) *PasswordlessLoginRepository_DeleteByUserID_Call {
+	return &PasswordlessLoginRepository_DeleteByUserID_Call{Call: _e.mock.On("DeleteByUserID", ctx, userID)}
+}
+
+func (_c *PasswordlessLoginRepository_DeleteByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *PasswordlessLoginRepository_DeleteByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PasswordlessLoginRepository_DeleteByUserID_Call) Return(_a0 error) *PasswordlessLoginRepository_DeleteByUserID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PasswordlessLoginRepository_DeleteByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *PasswordlessLoginRepository_DeleteByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByUserID provides a mock function with given fields: ctx, userID
+func (_m *PasswordlessLoginRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*model.PasswordlessLogin, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUserID")
+	}
+
+	var r0 *model.PasswordlessLogin
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.PasswordlessLogin, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.PasswordlessLogin); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PasswordlessLogin)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PasswordlessLoginRepository_FindByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByUserID'
+type PasswordlessLoginRepository_FindByUserID_Call struct {
+	*mock.Call
+}
+
+// FindByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *PasswordlessLoginRepository_Expecter) FindByUserID(ctx interface{}, userID interface{}) *PasswordlessLoginRepository_FindByUserID_Call {
+	return &PasswordlessLoginRepository_FindByUserID_Call{Call: _e.mock.On("FindByUserID", ctx, userID)}
+}
+
+func (_c *PasswordlessLoginRepository_FindByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *PasswordlessLoginRepository_FindByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PasswordlessLoginRepository_FindByUserID_Call) Return(_a0 *model.PasswordlessLogin, _a1 error) *PasswordlessLoginRepository_FindByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PasswordlessLoginRepository_FindByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.PasswordlessLogin, error)) *PasswordlessLoginRepository_FindByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPasswordlessLoginRepository creates a new instance of PasswordlessLoginRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasswordlessLoginRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasswordlessLoginRepository {
+	mock := &PasswordlessLoginRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,311 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/luckyAkbar/atec/internal/model"
+	usecase "github.com/luckyAkbar/atec/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PersonalAccessTokenRepository is an autogenerated mock type for the PersonalAccessTokenRepository type
+type PersonalAccessTokenRepository struct {
+	mock.Mock
+}
+
+type PersonalAccessTokenRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PersonalAccessTokenRepository) EXPECT() *PersonalAccessTokenRepository_Expecter {
+	return &PersonalAccessTokenRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, input
+func (_m *PersonalAccessTokenRepository) Create(ctx context.Context, input usecase.RepoCreatePersonalAccessTokenInput) (*model.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoCreatePersonalAccessTokenInput) (*model.PersonalAccessToken, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoCreatePersonalAccessTokenInput) *model.PersonalAccessToken); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.RepoCreatePersonalAccessTokenInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalAccessTokenRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type PersonalAccessTokenRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoCreatePersonalAccessTokenInput
+func (_e *PersonalAccessTokenRepository_Expecter) Create(ctx interface{}, input interface{}) *PersonalAccessTokenRepository_Create_Call {
+	return &PersonalAccessTokenRepository_Create_Call{Call: _e.mock.On("Create", ctx, input)}
+}
+
+func (_c *PersonalAccessTokenRepository_Create_Call) Run(run func(ctx context.Context, input usecase.RepoCreatePersonalAccessTokenInput)) *PersonalAccessTokenRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.RepoCreatePersonalAccessTokenInput))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepository_Create_Call) Return(_a0 *model.PersonalAccessToken, _a1 error) *PersonalAccessTokenRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepository_Create_Call) RunAndReturn(run func(context.Context, usecase.RepoCreatePersonalAccessTokenInput) (*model.PersonalAccessToken, error)) *PersonalAccessTokenRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *PersonalAccessTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByTokenHash")
+	}
+
+	var r0 *model.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.PersonalAccessToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.PersonalAccessToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalAccessTokenRepository_FindByTokenHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByTokenHash'
+type PersonalAccessTokenRepository_FindByTokenHash_Call struct {
+	*mock.Call
+}
+
+// FindByTokenHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *PersonalAccessTokenRepository_Expecter) FindByTokenHash(ctx interface{}, tokenHash interface{}) *PersonalAccessTokenRepository_FindByTokenHash_Call {
+	return &PersonalAccessTokenRepository_FindByTokenHash_Call{Call: _e.mock.On("FindByTokenHash", ctx, tokenHash)}
+}
+
+func (_c *PersonalAccessTokenRepository_FindByTokenHash_Call) Run(run func(ctx context.Context, tokenHash string)) *PersonalAccessTokenRepository_FindByTokenHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepository_FindByTokenHash_Call) Return(_a0 *model.PersonalAccessToken, _a1 error) *PersonalAccessTokenRepository_FindByTokenHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepository_FindByTokenHash_Call) RunAndReturn(run func(context.Context, string) (*model.PersonalAccessToken, error)) *PersonalAccessTokenRepository_FindByTokenHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByUserID provides a mock function with given fields: ctx, userID
+func (_m *PersonalAccessTokenRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]model.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUserID")
+	}
+
+	var r0 []model.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]model.PersonalAccessToken, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.PersonalAccessToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalAccessTokenRepository_FindByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByUserID'
+type PersonalAccessTokenRepository_FindByUserID_Call struct {
+	*mock.Call
+}
+
+// FindByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *PersonalAccessTokenRepository_Expecter) FindByUserID(ctx interface{}, userID interface{}) *PersonalAccessTokenRepository_FindByUserID_Call {
+	return &PersonalAccessTokenRepository_FindByUserID_Call{Call: _e.mock.On("FindByUserID", ctx, userID)}
+}
+
+func (_c *PersonalAccessTokenRepository_FindByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *PersonalAccessTokenRepository_FindByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepository_FindByUserID_Call) Return(_a0 []model.PersonalAccessToken, _a1 error) *PersonalAccessTokenRepository_FindByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepository_FindByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]model.PersonalAccessToken, error)) *PersonalAccessTokenRepository_FindByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: ctx, id, userID
+func (_m *PersonalAccessTokenRepository) Revoke(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersonalAccessTokenRepository_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type PersonalAccessTokenRepository_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - userID uuid.UUID
+func (_e *PersonalAccessTokenRepository_Expecter) Revoke(ctx interface{}, id interface{}, userID interface{}) *PersonalAccessTokenRepository_Revoke_Call {
+	return &PersonalAccessTokenRepository_Revoke_Call{Call: _e.mock.On("Revoke", ctx, id, userID)}
+}
+
+func (_c *PersonalAccessTokenRepository_Revoke_Call) Run(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID)) *PersonalAccessTokenRepository_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepository_Revoke_Call) Return(_a0 error) *PersonalAccessTokenRepository_Revoke_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepository_Revoke_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *PersonalAccessTokenRepository_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLastUsedAt provides a mock function with given fields: ctx, id
+func (_m *PersonalAccessTokenRepository) UpdateLastUsedAt(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLastUsedAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersonalAccessTokenRepository_UpdateLastUsedAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLastUsedAt'
+type PersonalAccessTokenRepository_UpdateLastUsedAt_Call struct {
+	*mock.Call
+}
+
+// UpdateLastUsedAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *PersonalAccessTokenRepository_Expecter) UpdateLastUsedAt(ctx interface{}, id interface{}) *PersonalAccessTokenRepository_UpdateLastUsedAt_Call {
+	return &PersonalAccessTokenRepository_UpdateLastUsedAt_Call{Call: _e.mock.On("UpdateLastUsedAt", ctx, id)}
+}
+
+func (_c *PersonalAccessTokenRepository_UpdateLastUsedAt_Call) Run(run func(ctx context.Context, id uuid.UUID)) *PersonalAccessTokenRepository_UpdateLastUsedAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepository_UpdateLastUsedAt_Call) Return(_a0 error) *PersonalAccessTokenRepository_UpdateLastUsedAt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepository_UpdateLastUsedAt_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *PersonalAccessTokenRepository_UpdateLastUsedAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPersonalAccessTokenRepository creates a new instance of PersonalAccessTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPersonalAccessTokenRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PersonalAccessTokenRepository {
+	mock := &PersonalAccessTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
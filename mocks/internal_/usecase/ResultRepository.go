@@ -5,12 +5,10 @@ package usecase
 import (
 	context "context"
 
+	uuid "github.com/google/uuid"
 	model "github.com/luckyAkbar/atec/internal/model"
-	mock "github.com/stretchr/testify/mock"
-
 	usecase "github.com/luckyAkbar/atec/internal/usecase"
-
-	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // ResultRepository is an autogenerated mock type for the ResultRepository type
@@ -85,6 +83,64 @@ func (_c *ResultRepository_Create_Call) RunAndReturn(run func(context.Context, u
 	return _c
 }
 
+// DeleteAllChildResults provides a mock function with given fields: ctx, input, txController
+func (_m *ResultRepository) DeleteAllChildResults(ctx context.Context, input usecase.RepoDeleteAllChildResultsInput, txController ...any) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, input)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllChildResults")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoDeleteAllChildResultsInput, ...any) error); ok {
+		r0 = rf(ctx, input, txController...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResultRepository_DeleteAllChildResults_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAllChildResults'
+type ResultRepository_DeleteAllChildResults_Call struct {
+	*mock.Call
+}
+
+// DeleteAllChildResults is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoDeleteAllChildResultsInput
+//   - txController ...any
+func (_e *ResultRepository_Expecter) DeleteAllChildResults(ctx interface{}, input interface{}, txController ...interface{}) *ResultRepository_DeleteAllChildResults_Call {
+	return &ResultRepository_DeleteAllChildResults_Call{Call: _e.mock.On("DeleteAllChildResults",
+		append([]interface{}{ctx, input}, txController...)...)}
+}
+
+func (_c *ResultRepository_DeleteAllChildResults_Call) Run(run func(ctx context.Context, input usecase.RepoDeleteAllChildResultsInput, txController ...any)) *ResultRepository_DeleteAllChildResults_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(usecase.RepoDeleteAllChildResultsInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ResultRepository_DeleteAllChildResults_Call) Return(_a0 error) *ResultRepository_DeleteAllChildResults_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ResultRepository_DeleteAllChildResults_Call) RunAndReturn(run func(context.Context, usecase.RepoDeleteAllChildResultsInput, ...any) error) *ResultRepository_DeleteAllChildResults_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteAllUserResults provides a mock function with given fields: ctx, input, txController
 func (_m *ResultRepository) DeleteAllUserResults(ctx context.Context, input usecase.RepoDeleteAllUserResultsInput, txController ...any) error {
 	var _ca []interface{}
@@ -261,6 +317,64 @@ func (_c *ResultRepository_FindByID_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// RestoreAllUserResults provides a mock function with given fields: ctx, input, txController
+func (_m *ResultRepository) RestoreAllUserResults(ctx context.Context, input usecase.RepoRestoreAllUserResultsInput, txController ...any) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, input)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreAllUserResults")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoRestoreAllUserResultsInput, ...any) error); ok {
+		r0 = rf(ctx, input, txController...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResultRepository_RestoreAllUserResults_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreAllUserResults'
+type ResultRepository_RestoreAllUserResults_Call struct {
+	*mock.Call
+}
+
+// RestoreAllUserResults is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoRestoreAllUserResultsInput
+//   - txController ...any
+func (_e *ResultRepository_Expecter) RestoreAllUserResults(ctx interface{}, input interface{}, txController ...interface{}) *ResultRepository_RestoreAllUserResults_Call {
+	return &ResultRepository_RestoreAllUserResults_Call{Call: _e.mock.On("RestoreAllUserResults",
+		append([]interface{}{ctx, input}, txController...)...)}
+}
+
+func (_c *ResultRepository_RestoreAllUserResults_Call) Run(run func(ctx context.Context, input usecase.RepoRestoreAllUserResultsInput, txController ...any)) *ResultRepository_RestoreAllUserResults_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(usecase.RepoRestoreAllUserResultsInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ResultRepository_RestoreAllUserResults_Call) Return(_a0 error) *ResultRepository_RestoreAllUserResults_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ResultRepository_RestoreAllUserResults_Call) RunAndReturn(run func(context.Context, usecase.RepoRestoreAllUserResultsInput, ...any) error) *ResultRepository_RestoreAllUserResults_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Search provides a mock function with given fields: ctx, input
 func (_m *ResultRepository) Search(ctx context.Context, input usecase.RepoSearchResultInput) ([]model.Result, error) {
 	ret := _m.Called(ctx, input)
@@ -320,6 +434,64 @@ func (_c *ResultRepository_Search_Call) RunAndReturn(run func(context.Context, u
 	return _c
 }
 
+// TransferChildResults provides a mock function with given fields: ctx, input, txController
+func (_m *ResultRepository) TransferChildResults(ctx context.Context, input usecase.RepoTransferChildInput, txController ...any) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, input)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferChildResults")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoTransferChildInput, ...any) error); ok {
+		r0 = rf(ctx, input, txController...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResultRepository_TransferChildResults_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferChildResults'
+type ResultRepository_TransferChildResults_Call struct {
+	*mock.Call
+}
+
+// TransferChildResults is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoTransferChildInput
+//   - txController ...any
+func (_e *ResultRepository_Expecter) TransferChildResults(ctx interface{}, input interface{}, txController ...interface{}) *ResultRepository_TransferChildResults_Call {
+	return &ResultRepository_TransferChildResults_Call{Call: _e.mock.On("TransferChildResults",
+		append([]interface{}{ctx, input}, txController...)...)}
+}
+
+func (_c *ResultRepository_TransferChildResults_Call) Run(run func(ctx context.Context, input usecase.RepoTransferChildInput, txController ...any)) *ResultRepository_TransferChildResults_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(usecase.RepoTransferChildInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ResultRepository_TransferChildResults_Call) Return(_a0 error) *ResultRepository_TransferChildResults_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ResultRepository_TransferChildResults_Call) RunAndReturn(run func(context.Context, usecase.RepoTransferChildInput, ...any) error) *ResultRepository_TransferChildResults_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewResultRepository creates a new instance of ResultRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewResultRepository(t interface {
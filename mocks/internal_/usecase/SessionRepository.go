@@ -0,0 +1,627 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/luckyAkbar/atec/internal/model"
+	usecase "github.com/luckyAkbar/atec/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionRepository is an autogenerated mock type for the SessionRepository type
+type SessionRepository struct {
+	mock.Mock
+}
+
+type SessionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SessionRepository) EXPECT() *SessionRepository_Expecter {
+	return &SessionRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, input
+func (_m *SessionRepository) Create(ctx context.Context, input usecase.RepoCreateSessionInput) (*model.Session, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoCreateSessionInput) (*model.Session, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoCreateSessionInput) *model.Session); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.RepoCreateSessionInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type SessionRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoCreateSessionInput
+func (_e *SessionRepository_Expecter) Create(ctx interface{}, input interface{}) *SessionRepository_Create_Call {
+	return &SessionRepository_Create_Call{Call: _e.mock.On("Create", ctx, input)}
+}
+
+func (_c *SessionRepository_Create_Call) Run(run func(ctx context.Context, input usecase.RepoCreateSessionInput)) *SessionRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.RepoCreateSessionInput))
+	})
+	return _c
+}
+
+func (_c *SessionRepository_Create_Call) Return(_a0 *model.Session, _a1 error) *SessionRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepository_Create_Call) RunAndReturn(run func(context.Context, usecase.RepoCreateSessionInput) (*model.Session, error)) *SessionRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindActiveByUserID provides a mock function with given fields: ctx, userID
+func (_m *SessionRepository) FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]model.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveByUserID")
+	}
+
+	var r0 []model.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]model.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepository_FindActiveByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindActiveByUserID'
+type SessionRepository_FindActiveByUserID_Call struct {
+	*mock.Call
+}
+
+// FindActiveByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *SessionRepository_Expecter) FindActiveByUserID(ctx interface{}, userID interface{}) *SessionRepository_FindActiveByUserID_Call {
+	return &SessionRepository_FindActiveByUserID_Call{Call: _e.mock.On("FindActiveByUserID", ctx, userID)}
+}
+
+func (_c *SessionRepository_FindActiveByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *SessionRepository_FindActiveByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *SessionRepository_FindActiveByUserID_Call) Return(_a0 []model.Session, _a1 error) *SessionRepository_FindActiveByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepository_FindActiveByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]model.Session, error)) *SessionRepository_FindActiveByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *SessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Session, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.Session, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.Session); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type SessionRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *SessionRepository_Expecter) FindByID(ctx interface{}, id interface{}) *SessionRepository_FindByID_Call {
+	return &SessionRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *SessionRepository_FindByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *SessionRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *SessionRepository_FindByID_Call) Return(_a0 *model.Session, _a1 error) *SessionRepository_FindByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepository_FindByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.Session, error)) *SessionRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasAnySession provides a mock function with given fields: ctx, userID
+func (_m *SessionRepository) HasAnySession(ctx context.Context, userID uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasAnySession")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepository_HasAnySession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasAnySession'
+type SessionRepository_HasAnySession_Call struct {
+	*mock.Call
+}
+
+// HasAnySession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *SessionRepository_Expecter) HasAnySession(ctx interface{}, userID interface{}) *SessionRepository_HasAnySession_Call {
+	return &SessionRepository_HasAnySession_Call{Call: _e.mock.On("HasAnySession", ctx, userID)}
+}
+
+func (_c *SessionRepository_HasAnySession_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *SessionRepository_HasAnySession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *SessionRepository_HasAnySession_Call) Return(_a0 bool, _a1 error) *SessionRepository_HasAnySession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepository_HasAnySession_Call) RunAndReturn(run func(context.Context, uuid.UUID) (bool, error)) *SessionRepository_HasAnySession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasSessionFromClient provides a mock function with given fields: ctx, userID, client
+func (_m *SessionRepository) HasSessionFromClient(ctx context.Context, userID uuid.UUID, client model.ClientInfo) (bool, error) {
+	ret := _m.Called(ctx, userID, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasSessionFromClient")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, model.ClientInfo) (bool, error)); ok {
+		return rf(ctx, userID, client)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, model.ClientInfo) bool); ok {
+		r0 = rf(ctx, userID, client)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, model.ClientInfo) error); ok {
+		r1 = rf(ctx, userID, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepository_HasSessionFromClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasSessionFromClient'
+type SessionRepository_HasSessionFromClient_Call struct {
+	*mock.Call
+}
+
+// HasSessionFromClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - client model.ClientInfo
+func (_e *SessionRepository_Expecter) HasSessionFromClient(ctx interface{}, userID interface{}, client interface{}) *SessionRepository_HasSessionFromClient_Call {
+	return &SessionRepository_HasSessionFromClient_Call{Call: _e.mock.On("HasSessionFromClient", ctx, userID, client)}
+}
+
+func (_c *SessionRepository_HasSessionFromClient_Call) Run(run func(ctx context.Context, userID uuid.UUID, client model.ClientInfo)) *SessionRepository_HasSessionFromClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(model.ClientInfo))
+	})
+	return _c
+}
+
+func (_c *SessionRepository_HasSessionFromClient_Call) Return(_a0 bool, _a1 error) *SessionRepository_HasSessionFromClient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepository_HasSessionFromClient_Call) RunAndReturn(run func(context.Context, uuid.UUID, model.ClientInfo) (bool, error)) *SessionRepository_HasSessionFromClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAllUserSessions provides a mock function with given fields: ctx, userID
+func (_m *SessionRepository) RevokeAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAllUserSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionRepository_RevokeAllUserSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAllUserSessions'
+type SessionRepository_RevokeAllUserSessions_Call struct {
+	*mock.Call
+}
+
+// RevokeAllUserSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *SessionRepository_Expecter) RevokeAllUserSessions(ctx interface{}, userID interface{}) *SessionRepository_RevokeAllUserSessions_Call {
+	return &SessionRepository_RevokeAllUserSessions_Call{Call: _e.mock.On("RevokeAllUserSessions", ctx, userID)}
+}
+
+func (_c *SessionRepository_RevokeAllUserSessions_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *SessionRepository_RevokeAllUserSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *SessionRepository_RevokeAllUserSessions_Call) Return(_a0 error) *SessionRepository_RevokeAllUserSessions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionRepository_RevokeAllUserSessions_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *SessionRepository_RevokeAllUserSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeByID provides a mock function with given fields: ctx, id
+func (_m *SessionRepository) RevokeByID(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionRepository_RevokeByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeByID'
+type SessionRepository_RevokeByID_Call struct {
+	*mock.Call
+}
+
+// RevokeByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *SessionRepository_Expecter) RevokeByID(ctx interface{}, id interface{}) *SessionRepository_RevokeByID_Call {
+	return &SessionRepository_RevokeByID_Call{Call: _e.mock.On("RevokeByID", ctx, id)}
+}
+
+func (_c *SessionRepository_RevokeByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *SessionRepository_RevokeByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *SessionRepository_RevokeByID_Call) Return(_a0 error) *SessionRepository_RevokeByID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionRepository_RevokeByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *SessionRepository_RevokeByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeOtherUserSessions provides a mock function with given fields: ctx, userID, exceptSessionID
+func (_m *SessionRepository) RevokeOtherUserSessions(ctx context.Context, userID uuid.UUID, exceptSessionID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, exceptSessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOtherUserSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, exceptSessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionRepository_RevokeOtherUserSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeOtherUserSessions'
+type SessionRepository_RevokeOtherUserSessions_Call struct {
+	*mock.Call
+}
+
+// RevokeOtherUserSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - exceptSessionID uuid.UUID
+func (_e *SessionRepository_Expecter) RevokeOtherUserSessions(ctx interface{}, userID interface{}, exceptSessionID interface{}) *SessionRepository_RevokeOtherUserSessions_Call {
+	return &SessionRepository_RevokeOtherUserSessions_Call{Call: _e.mock.On("RevokeOtherUserSessions", ctx, userID, exceptSessionID)}
+}
+
+func (_c *SessionRepository_RevokeOtherUserSessions_Call) Run(run func(ctx context.Context, userID uuid.UUID, exceptSessionID uuid.UUID)) *SessionRepository_RevokeOtherUserSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *SessionRepository_RevokeOtherUserSessions_Call) Return(_a0 error) *SessionRepository_RevokeOtherUserSessions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionRepository_RevokeOtherUserSessions_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *SessionRepository_RevokeOtherUserSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeUserSession provides a mock function with given fields: ctx, id, userID
+func (_m *SessionRepository) RevokeUserSession(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeUserSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionRepository_RevokeUserSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUserSession'
+type SessionRepository_RevokeUserSession_Call struct {
+	*mock.Call
+}
+
+// RevokeUserSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - userID uuid.UUID
+func (_e *SessionRepository_Expecter) RevokeUserSession(ctx interface{}, id interface{}, userID interface{}) *SessionRepository_RevokeUserSession_Call {
+	return &SessionRepository_RevokeUserSession_Call{Call: _e.mock.On("RevokeUserSession", ctx, id, userID)}
+}
+
+func (_c *SessionRepository_RevokeUserSession_Call) Run(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID)) *SessionRepository_RevokeUserSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *SessionRepository_RevokeUserSession_Call) Return(_a0 error) *SessionRepository_RevokeUserSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionRepository_RevokeUserSession_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *SessionRepository_RevokeUserSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotateRefreshToken provides a mock function with given fields: ctx, input
+func (_m *SessionRepository) RotateRefreshToken(ctx context.Context, input usecase.RepoRotateRefreshTokenInput) (*model.Session, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateRefreshToken")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoRotateRefreshTokenInput) (*model.Session, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoRotateRefreshTokenInput) *model.Session); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.RepoRotateRefreshTokenInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepository_RotateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateRefreshToken'
+type SessionRepository_RotateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// RotateRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RepoRotateRefreshTokenInput
+func (_e *SessionRepository_Expecter) RotateRefreshToken(ctx interface{}, input interface{}) *SessionRepository_RotateRefreshToken_Call {
+	return &SessionRepository_RotateRefreshToken_Call{Call: _e.mock.On("RotateRefreshToken", ctx, input)}
+}
+
+func (_c *SessionRepository_RotateRefreshToken_Call) Run(run func(ctx context.Context, input usecase.RepoRotateRefreshTokenInput)) *SessionRepository_RotateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.RepoRotateRefreshTokenInput))
+	})
+	return _c
+}
+
+func (_c *SessionRepository_RotateRefreshToken_Call) Return(_a0 *model.Session, _a1 error) *SessionRepository_RotateRefreshToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepository_RotateRefreshToken_Call) RunAndReturn(run func(context.Context, usecase.RepoRotateRefreshTokenInput) (*model.Session, error)) *SessionRepository_RotateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLastSeenAt provides a mock function with given fields: ctx, id
+func (_m *SessionRepository) UpdateLastSeenAt(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLastSeenAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionRepository_UpdateLastSeenAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLastSeenAt'
+type SessionRepository_UpdateLastSeenAt_Call struct {
+	*mock.Call
+}
+
+// UpdateLastSeenAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *SessionRepository_Expecter) UpdateLastSeenAt(ctx interface{}, id interface{}) *SessionRepository_UpdateLastSeenAt_Call {
+	return &SessionRepository_UpdateLastSeenAt_Call{Call: _e.mock.On("UpdateLastSeenAt", ctx, id)}
+}
+
+func (_c *SessionRepository_UpdateLastSeenAt_Call) Run(run func(ctx context.Context, id uuid.UUID)) *SessionRepository_UpdateLastSeenAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *SessionRepository_UpdateLastSeenAt_Call) Return(_a0 error) *SessionRepository_UpdateLastSeenAt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionRepository_UpdateLastSeenAt_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *SessionRepository_UpdateLastSeenAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSessionRepository creates a new instance of SessionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSessionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SessionRepository {
+	mock := &SessionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,13 +4,13 @@ package usecase
 
 import (
 	context "context"
+	sql "database/sql"
+	time "time"
 
+	uuid "github.com/google/uuid"
 	model "github.com/luckyAkbar/atec/internal/model"
-	mock "github.com/stretchr/testify/mock"
-
 	usecase "github.com/luckyAkbar/atec/internal/usecase"
-
-	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // UserRepository is an autogenerated mock type for the UserRepository type
@@ -154,9 +154,9 @@ func (_c *UserRepository_DeleteByID_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
-// FindByEmail provides a mock function with given fields: ctx, email
-func (_m *UserRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
-	ret := _m.Called(ctx, email)
+// FindByEmail provides a mock function with given fields: ctx, lookup
+func (_m *UserRepository) FindByEmail(ctx context.Context, lookup usecase.RepoEmailLookup) (*model.User, error) {
+	ret := _m.Called(ctx, lookup)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByEmail")
@@ -164,19 +164,19 @@ func (_m *UserRepository) FindByEmail(ctx context.Context, email string) (*model
 
 	var r0 *model.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
-		return rf(ctx, email)
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoEmailLookup) (*model.User, error)); ok {
+		return rf(ctx, lookup)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
-		r0 = rf(ctx, email)
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RepoEmailLookup) *model.User); ok {
+		r0 = rf(ctx, lookup)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, email)
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.RepoEmailLookup) error); ok {
+		r1 = rf(ctx, lookup)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -191,14 +191,14 @@ type UserRepository_FindByEmail_Call struct {
 
 // FindByEmail is a helper method to define mock.On call
 //   - ctx context.Context
-//   - email string
-func (_e *UserRepository_Expecter) FindByEmail(ctx interface{}, email interface{}) *UserRepository_FindByEmail_Call {
-	return &UserRepository_FindByEmail_Call{Call: _e.mock.On("FindByEmail", ctx, email)}
+//   - lookup usecase.RepoEmailLookup
+func (_e *UserRepository_Expecter) FindByEmail(ctx interface{}, lookup interface{}) *UserRepository_FindByEmail_Call {
+	return &UserRepository_FindByEmail_Call{Call: _e.mock.On("FindByEmail", ctx, lookup)}
 }
 
-func (_c *UserRepository_FindByEmail_Call) Run(run func(ctx context.Context, email string)) *UserRepository_FindByEmail_Call {
+func (_c *UserRepository_FindByEmail_Call) Run(run func(ctx context.Context, lookup usecase.RepoEmailLookup)) *UserRepository_FindByEmail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(usecase.RepoEmailLookup))
 	})
 	return _c
 }
@@ -208,7 +208,7 @@ func (_c *UserRepository_FindByEmail_Call) Return(_a0 *model.User, _a1 error) *U
 	return _c
 }
 
-func (_c *UserRepository_FindByEmail_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *UserRepository_FindByEmail_Call {
+func (_c *UserRepository_FindByEmail_Call) RunAndReturn(run func(context.Context, usecase.RepoEmailLookup) (*model.User, error)) *UserRepository_FindByEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -272,6 +272,65 @@ func (_c *UserRepository_FindByID_Call) RunAndReturn(run func(context.Context, u
 	return _c
 }
 
+// FindDeletionRequestedBefore provides a mock function with given fields: ctx, before
+func (_m *UserRepository) FindDeletionRequestedBefore(ctx context.Context, before time.Time) ([]model.User, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDeletionRequestedBefore")
+	}
+
+	var r0 []model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]model.User, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []model.User); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_FindDeletionRequestedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDeletionRequestedBefore'
+type UserRepository_FindDeletionRequestedBefore_Call struct {
+	*mock.Call
+}
+
+// FindDeletionRequestedBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *UserRepository_Expecter) FindDeletionRequestedBefore(ctx interface{}, before interface{}) *UserRepository_FindDeletionRequestedBefore_Call {
+	return &UserRepository_FindDeletionRequestedBefore_Call{Call: _e.mock.On("FindDeletionRequestedBefore", ctx, before)}
+}
+
+func (_c *UserRepository_FindDeletionRequestedBefore_Call) Run(run func(ctx context.Context, before time.Time)) *UserRepository_FindDeletionRequestedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *UserRepository_FindDeletionRequestedBefore_Call) Return(_a0 []model.User, _a1 error) *UserRepository_FindDeletionRequestedBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_FindDeletionRequestedBefore_Call) RunAndReturn(run func(context.Context, time.Time) ([]model.User, error)) *UserRepository_FindDeletionRequestedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUsersByRoles provides a mock function with given fields: ctx, roles
 func (_m *UserRepository) GetUsersByRoles(ctx context.Context, roles model.Roles) ([]model.User, error) {
 	ret := _m.Called(ctx, roles)
@@ -331,6 +390,65 @@ func (_c *UserRepository_GetUsersByRoles_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// IncrementFailedLoginAttempts provides a mock function with given fields: ctx, userID
+func (_m *UserRepository) IncrementFailedLoginAttempts(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementFailedLoginAttempts")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_IncrementFailedLoginAttempts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementFailedLoginAttempts'
+type UserRepository_IncrementFailedLoginAttempts_Call struct {
+	*mock.Call
+}
+
+// IncrementFailedLoginAttempts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserRepository_Expecter) IncrementFailedLoginAttempts(ctx interface{}, userID interface{}) *UserRepository_IncrementFailedLoginAttempts_Call {
+	return &UserRepository_IncrementFailedLoginAttempts_Call{Call: _e.mock.On("IncrementFailedLoginAttempts", ctx, userID)}
+}
+
+func (_c *UserRepository_IncrementFailedLoginAttempts_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserRepository_IncrementFailedLoginAttempts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *UserRepository_IncrementFailedLoginAttempts_Call) Return(_a0 *model.User, _a1 error) *UserRepository_IncrementFailedLoginAttempts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_IncrementFailedLoginAttempts_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.User, error)) *UserRepository_IncrementFailedLoginAttempts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsAdminAccountExists provides a mock function with given fields: ctx
 func (_m *UserRepository) IsAdminAccountExists(ctx context.Context) (bool, error) {
 	ret := _m.Called(ctx)
@@ -446,9 +564,12 @@ func (_c *UserRepository_Search_Call) RunAndReturn(run func(context.Context, use
 	return _c
 }
 
-// Update provides a mock function with given fields: ctx, userID, input
-func (_m *UserRepository) Update(ctx context.Context, userID uuid.UUID, input usecase.RepoUpdateUserInput) (*model.User, error) {
-	ret := _m.Called(ctx, userID, input)
+// Update provides a mock function with given fields: ctx, userID, input, txController
+func (_m *UserRepository) Update(ctx context.Context, userID uuid.UUID, input usecase.RepoUpdateUserInput, txController ...any) (*model.User, error) {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, userID, input)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
@@ -456,19 +577,19 @@ func (_m *UserRepository) Update(ctx context.Context, userID uuid.UUID, input us
 
 	var r0 *model.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, usecase.RepoUpdateUserInput) (*model.User, error)); ok {
-		return rf(ctx, userID, input)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, usecase.RepoUpdateUserInput, ...any) (*model.User, error)); ok {
+		return rf(ctx, userID, input, txController...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, usecase.RepoUpdateUserInput) *model.User); ok {
-		r0 = rf(ctx, userID, input)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, usecase.RepoUpdateUserInput, ...any) *model.User); ok {
+		r0 = rf(ctx, userID, input, txController...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, usecase.RepoUpdateUserInput) error); ok {
-		r1 = rf(ctx, userID, input)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, usecase.RepoUpdateUserInput, ...any) error); ok {
+		r1 = rf(ctx, userID, input, txController...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -485,13 +606,21 @@ type UserRepository_Update_Call struct {
 //   - ctx context.Context
 //   - userID uuid.UUID
 //   - input usecase.RepoUpdateUserInput
-func (_e *UserRepository_Expecter) Update(ctx interface{}, userID interface{}, input interface{}) *UserRepository_Update_Call {
-	return &UserRepository_Update_Call{Call: _e.mock.On("Update", ctx, userID, input)}
+//   - txController ...any
+func (_e *UserRepository_Expecter) Update(ctx interface{}, userID interface{}, input interface{}, txController ...interface{}) *UserRepository_Update_Call {
+	return &UserRepository_Update_Call{Call: _e.mock.On("Update",
+		append([]interface{}{ctx, userID, input}, txController...)...)}
 }
 
-func (_c *UserRepository_Update_Call) Run(run func(ctx context.Context, userID uuid.UUID, input usecase.RepoUpdateUserInput)) *UserRepository_Update_Call {
+func (_c *UserRepository_Update_Call) Run(run func(ctx context.Context, userID uuid.UUID, input usecase.RepoUpdateUserInput, txController ...any)) *UserRepository_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(usecase.RepoUpdateUserInput))
+		variadicArgs := make([]any, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(usecase.RepoUpdateUserInput), variadicArgs...)
 	})
 	return _c
 }
@@ -501,7 +630,126 @@ func (_c *UserRepository_Update_Call) Return(_a0 *model.User, _a1 error) *UserRe
 	return _c
 }
 
-func (_c *UserRepository_Update_Call) RunAndReturn(run func(context.Context, uuid.UUID, usecase.RepoUpdateUserInput) (*model.User, error)) *UserRepository_Update_Call {
+func (_c *UserRepository_Update_Call) RunAndReturn(run func(context.Context, uuid.UUID, usecase.RepoUpdateUserInput, ...any) (*model.User, error)) *UserRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateDeletionRequestedAt provides a mock function with given fields: ctx, userID, requestedAt, txController
+func (_m *UserRepository) UpdateDeletionRequestedAt(ctx context.Context, userID uuid.UUID, requestedAt sql.NullTime, txController ...any) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, userID, requestedAt)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeletionRequestedAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, sql.NullTime, ...any) error); ok {
+		r0 = rf(ctx, userID, requestedAt, txController...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_UpdateDeletionRequestedAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateDeletionRequestedAt'
+type UserRepository_UpdateDeletionRequestedAt_Call struct {
+	*mock.Call
+}
+
+// UpdateDeletionRequestedAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - requestedAt sql.NullTime
+//   - txController ...any
+func (_e *UserRepository_Expecter) UpdateDeletionRequestedAt(ctx interface{}, userID interface{}, requestedAt interface{}, txController ...interface{}) *UserRepository_UpdateDeletionRequestedAt_Call {
+	return &UserRepository_UpdateDeletionRequestedAt_Call{Call: _e.mock.On("UpdateDeletionRequestedAt",
+		append([]interface{}{ctx, userID, requestedAt}, txController...)...)}
+}
+
+func (_c *UserRepository_UpdateDeletionRequestedAt_Call) Run(run func(ctx context.Context, userID uuid.UUID, requestedAt sql.NullTime, txController ...any)) *UserRepository_UpdateDeletionRequestedAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(sql.NullTime), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdateDeletionRequestedAt_Call) Return(_a0 error) *UserRepository_UpdateDeletionRequestedAt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_UpdateDeletionRequestedAt_Call) RunAndReturn(run func(context.Context, uuid.UUID, sql.NullTime, ...any) error) *UserRepository_UpdateDeletionRequestedAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEmail provides a mock function with given fields: ctx, userID, email, emailBlindIndex, txController
+func (_m *UserRepository) UpdateEmail(ctx context.Context, userID uuid.UUID, email string, emailBlindIndex string, txController ...any) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, userID, email, emailBlindIndex)
+	_ca = append(_ca, txController...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, ...any) error); ok {
+		r0 = rf(ctx, userID, email, emailBlindIndex, txController...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_UpdateEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEmail'
+type UserRepository_UpdateEmail_Call struct {
+	*mock.Call
+}
+
+// UpdateEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - email string
+//   - emailBlindIndex string
+//   - txController ...any
+func (_e *UserRepository_Expecter) UpdateEmail(ctx interface{}, userID interface{}, email interface{}, emailBlindIndex interface{}, txController ...interface{}) *UserRepository_UpdateEmail_Call {
+	return &UserRepository_UpdateEmail_Call{Call: _e.mock.On("UpdateEmail",
+		append([]interface{}{ctx, userID, email, emailBlindIndex}, txController...)...)}
+}
+
+func (_c *UserRepository_UpdateEmail_Call) Run(run func(ctx context.Context, userID uuid.UUID, email string, emailBlindIndex string, txController ...any)) *UserRepository_UpdateEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]any, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdateEmail_Call) Return(_a0 error) *UserRepository_UpdateEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_UpdateEmail_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, string, ...any) error) *UserRepository_UpdateEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
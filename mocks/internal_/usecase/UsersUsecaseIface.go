@@ -22,6 +22,536 @@ func (_m *UsersUsecaseIface) EXPECT() *UsersUsecaseIface_Expecter {
 	return &UsersUsecaseIface_Expecter{mock: &_m.Mock}
 }
 
+// AdminChangeUserActivation provides a mock function with given fields: ctx, input
+func (_m *UsersUsecaseIface) AdminChangeUserActivation(ctx context.Context, input usecase.AdminChangeUserActivationInput) (*usecase.AdminUpdateUserOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdminChangeUserActivation")
+	}
+
+	var r0 *usecase.AdminUpdateUserOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminChangeUserActivationInput) (*usecase.AdminUpdateUserOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminChangeUserActivationInput) *usecase.AdminUpdateUserOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.AdminUpdateUserOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.AdminChangeUserActivationInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersUsecaseIface_AdminChangeUserActivation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdminChangeUserActivation'
+type UsersUsecaseIface_AdminChangeUserActivation_Call struct {
+	*mock.Call
+}
+
+// AdminChangeUserActivation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.AdminChangeUserActivationInput
+func (_e *UsersUsecaseIface_Expecter) AdminChangeUserActivation(ctx interface{}, input interface{}) *UsersUsecaseIface_AdminChangeUserActivation_Call {
+	return &UsersUsecaseIface_AdminChangeUserActivation_Call{Call: _e.mock.On("AdminChangeUserActivation", ctx, input)}
+}
+
+func (_c *UsersUsecaseIface_AdminChangeUserActivation_Call) Run(run func(ctx context.Context, input usecase.AdminChangeUserActivationInput)) *UsersUsecaseIface_AdminChangeUserActivation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.AdminChangeUserActivationInput))
+	})
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminChangeUserActivation_Call) Return(_a0 *usecase.AdminUpdateUserOutput, _a1 error) *UsersUsecaseIface_AdminChangeUserActivation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminChangeUserActivation_Call) RunAndReturn(run func(context.Context, usecase.AdminChangeUserActivationInput) (*usecase.AdminUpdateUserOutput, error)) *UsersUsecaseIface_AdminChangeUserActivation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AdminChangeUserRole provides a mock function with given fields: ctx, input
+func (_m *UsersUsecaseIface) AdminChangeUserRole(ctx context.Context, input usecase.AdminChangeUserRoleInput) (*usecase.AdminUpdateUserOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdminChangeUserRole")
+	}
+
+	var r0 *usecase.AdminUpdateUserOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminChangeUserRoleInput) (*usecase.AdminUpdateUserOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminChangeUserRoleInput) *usecase.AdminUpdateUserOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.AdminUpdateUserOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.AdminChangeUserRoleInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersUsecaseIface_AdminChangeUserRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdminChangeUserRole'
+type UsersUsecaseIface_AdminChangeUserRole_Call struct {
+	*mock.Call
+}
+
+// AdminChangeUserRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.AdminChangeUserRoleInput
+func (_e *UsersUsecaseIface_Expecter) AdminChangeUserRole(ctx interface{}, input interface{}) *UsersUsecaseIface_AdminChangeUserRole_Call {
+	return &UsersUsecaseIface_AdminChangeUserRole_Call{Call: _e.mock.On("AdminChangeUserRole", ctx, input)}
+}
+
+func (_c *UsersUsecaseIface_AdminChangeUserRole_Call) Run(run func(ctx context.Context, input usecase.AdminChangeUserRoleInput)) *UsersUsecaseIface_AdminChangeUserRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.AdminChangeUserRoleInput))
+	})
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminChangeUserRole_Call) Return(_a0 *usecase.AdminUpdateUserOutput, _a1 error) *UsersUsecaseIface_AdminChangeUserRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminChangeUserRole_Call) RunAndReturn(run func(context.Context, usecase.AdminChangeUserRoleInput) (*usecase.AdminUpdateUserOutput, error)) *UsersUsecaseIface_AdminChangeUserRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AdminCreateOrganization provides a mock function with given fields: ctx, input
+func (_m *UsersUsecaseIface) AdminCreateOrganization(ctx context.Context, input usecase.AdminCreateOrganizationInput) (*usecase.OrganizationOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdminCreateOrganization")
+	}
+
+	var r0 *usecase.OrganizationOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminCreateOrganizationInput) (*usecase.OrganizationOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminCreateOrganizationInput) *usecase.OrganizationOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.OrganizationOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.AdminCreateOrganizationInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersUsecaseIface_AdminCreateOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdminCreateOrganization'
+type UsersUsecaseIface_AdminCreateOrganization_Call struct {
+	*mock.Call
+}
+
+// AdminCreateOrganization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.AdminCreateOrganizationInput
+func (_e *UsersUsecaseIface_Expecter) AdminCreateOrganization(ctx interface{}, input interface{}) *UsersUsecaseIface_AdminCreateOrganization_Call {
+	return &UsersUsecaseIface_AdminCreateOrganization_Call{Call: _e.mock.On("AdminCreateOrganization", ctx, input)}
+}
+
+func (_c *UsersUsecaseIface_AdminCreateOrganization_Call) Run(run func(ctx context.Context, input usecase.AdminCreateOrganizationInput)) *UsersUsecaseIface_AdminCreateOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.AdminCreateOrganizationInput))
+	})
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminCreateOrganization_Call) Return(_a0 *usecase.OrganizationOutput, _a1 error) *UsersUsecaseIface_AdminCreateOrganization_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminCreateOrganization_Call) RunAndReturn(run func(context.Context, usecase.AdminCreateOrganizationInput) (*usecase.OrganizationOutput, error)) *UsersUsecaseIface_AdminCreateOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AdminListOrganizations provides a mock function with given fields: ctx
+func (_m *UsersUsecaseIface) AdminListOrganizations(ctx context.Context) ([]usecase.OrganizationOutput, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdminListOrganizations")
+	}
+
+	var r0 []usecase.OrganizationOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]usecase.OrganizationOutput, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []usecase.OrganizationOutput); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]usecase.OrganizationOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersUsecaseIface_AdminListOrganizations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdminListOrganizations'
+type UsersUsecaseIface_AdminListOrganizations_Call struct {
+	*mock.Call
+}
+
+// AdminListOrganizations is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *UsersUsecaseIface_Expecter) AdminListOrganizations(ctx interface{}) *UsersUsecaseIface_AdminListOrganizations_Call {
+	return &UsersUsecaseIface_AdminListOrganizations_Call{Call: _e.mock.On("AdminListOrganizations", ctx)}
+}
+
+func (_c *UsersUsecaseIface_AdminListOrganizations_Call) Run(run func(ctx context.Context)) *UsersUsecaseIface_AdminListOrganizations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminListOrganizations_Call) Return(_a0 []usecase.OrganizationOutput, _a1 error) *UsersUsecaseIface_AdminListOrganizations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminListOrganizations_Call) RunAndReturn(run func(context.Context) ([]usecase.OrganizationOutput, error)) *UsersUsecaseIface_AdminListOrganizations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AdminSearchAuditLogs provides a mock function with given fields: ctx, input
+func (_m *UsersUsecaseIface) AdminSearchAuditLogs(ctx context.Context, input usecase.AdminSearchAuditLogsInput) ([]usecase.AuditLogOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdminSearchAuditLogs")
+	}
+
+	var r0 []usecase.AuditLogOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminSearchAuditLogsInput) ([]usecase.AuditLogOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminSearchAuditLogsInput) []usecase.AuditLogOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]usecase.AuditLogOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.AdminSearchAuditLogsInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersUsecaseIface_AdminSearchAuditLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdminSearchAuditLogs'
+type UsersUsecaseIface_AdminSearchAuditLogs_Call struct {
+	*mock.Call
+}
+
+// AdminSearchAuditLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.AdminSearchAuditLogsInput
+func (_e *UsersUsecaseIface_Expecter) AdminSearchAuditLogs(ctx interface{}, input interface{}) *UsersUsecaseIface_AdminSearchAuditLogs_Call {
+	return &UsersUsecaseIface_AdminSearchAuditLogs_Call{Call: _e.mock.On("AdminSearchAuditLogs", ctx, input)}
+}
+
+func (_c *UsersUsecaseIface_AdminSearchAuditLogs_Call) Run(run func(ctx context.Context, input usecase.AdminSearchAuditLogsInput)) *UsersUsecaseIface_AdminSearchAuditLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.AdminSearchAuditLogsInput))
+	})
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminSearchAuditLogs_Call) Return(_a0 []usecase.AuditLogOutput, _a1 error) *UsersUsecaseIface_AdminSearchAuditLogs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminSearchAuditLogs_Call) RunAndReturn(run func(context.Context, usecase.AdminSearchAuditLogsInput) ([]usecase.AuditLogOutput, error)) *UsersUsecaseIface_AdminSearchAuditLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AdminSearchUsers provides a mock function with given fields: ctx, input
+func (_m *UsersUsecaseIface) AdminSearchUsers(ctx context.Context, input usecase.AdminSearchUsersInput) ([]usecase.AdminUserOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdminSearchUsers")
+	}
+
+	var r0 []usecase.AdminUserOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminSearchUsersInput) ([]usecase.AdminUserOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminSearchUsersInput) []usecase.AdminUserOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]usecase.AdminUserOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.AdminSearchUsersInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersUsecaseIface_AdminSearchUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdminSearchUsers'
+type UsersUsecaseIface_AdminSearchUsers_Call struct {
+	*mock.Call
+}
+
+// AdminSearchUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.AdminSearchUsersInput
+func (_e *UsersUsecaseIface_Expecter) AdminSearchUsers(ctx interface{}, input interface{}) *UsersUsecaseIface_AdminSearchUsers_Call {
+	return &UsersUsecaseIface_AdminSearchUsers_Call{Call: _e.mock.On("AdminSearchUsers", ctx, input)}
+}
+
+func (_c *UsersUsecaseIface_AdminSearchUsers_Call) Run(run func(ctx context.Context, input usecase.AdminSearchUsersInput)) *UsersUsecaseIface_AdminSearchUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.AdminSearchUsersInput))
+	})
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminSearchUsers_Call) Return(_a0 []usecase.AdminUserOutput, _a1 error) *UsersUsecaseIface_AdminSearchUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminSearchUsers_Call) RunAndReturn(run func(context.Context, usecase.AdminSearchUsersInput) ([]usecase.AdminUserOutput, error)) *UsersUsecaseIface_AdminSearchUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AdminSetMFARequirement provides a mock function with given fields: ctx, input
+func (_m *UsersUsecaseIface) AdminSetMFARequirement(ctx context.Context, input usecase.AdminSetMFARequirementInput) (*usecase.AdminUpdateUserOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdminSetMFARequirement")
+	}
+
+	var r0 *usecase.AdminUpdateUserOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminSetMFARequirementInput) (*usecase.AdminUpdateUserOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminSetMFARequirementInput) *usecase.AdminUpdateUserOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.AdminUpdateUserOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.AdminSetMFARequirementInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersUsecaseIface_AdminSetMFARequirement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdminSetMFARequirement'
+type UsersUsecaseIface_AdminSetMFARequirement_Call struct {
+	*mock.Call
+}
+
+// AdminSetMFARequirement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.AdminSetMFARequirementInput
+func (_e *UsersUsecaseIface_Expecter) AdminSetMFARequirement(ctx interface{}, input interface{}) *UsersUsecaseIface_AdminSetMFARequirement_Call {
+	return &UsersUsecaseIface_AdminSetMFARequirement_Call{Call: _e.mock.On("AdminSetMFARequirement", ctx, input)}
+}
+
+func (_c *UsersUsecaseIface_AdminSetMFARequirement_Call) Run(run func(ctx context.Context, input usecase.AdminSetMFARequirementInput)) *UsersUsecaseIface_AdminSetMFARequirement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.AdminSetMFARequirementInput))
+	})
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminSetMFARequirement_Call) Return(_a0 *usecase.AdminUpdateUserOutput, _a1 error) *UsersUsecaseIface_AdminSetMFARequirement_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminSetMFARequirement_Call) RunAndReturn(run func(context.Context, usecase.AdminSetMFARequirementInput) (*usecase.AdminUpdateUserOutput, error)) *UsersUsecaseIface_AdminSetMFARequirement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AdminSetUserOrganization provides a mock function with given fields: ctx, input
+func (_m *UsersUsecaseIface) AdminSetUserOrganization(ctx context.Context, input usecase.AdminSetUserOrganizationInput) (*usecase.AdminUpdateUserOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdminSetUserOrganization")
+	}
+
+	var r0 *usecase.AdminUpdateUserOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminSetUserOrganizationInput) (*usecase.AdminUpdateUserOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminSetUserOrganizationInput) *usecase.AdminUpdateUserOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.AdminUpdateUserOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.AdminSetUserOrganizationInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersUsecaseIface_AdminSetUserOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdminSetUserOrganization'
+type UsersUsecaseIface_AdminSetUserOrganization_Call struct {
+	*mock.Call
+}
+
+// AdminSetUserOrganization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.AdminSetUserOrganizationInput
+func (_e *UsersUsecaseIface_Expecter) AdminSetUserOrganization(ctx interface{}, input interface{}) *UsersUsecaseIface_AdminSetUserOrganization_Call {
+	return &UsersUsecaseIface_AdminSetUserOrganization_Call{Call: _e.mock.On("AdminSetUserOrganization", ctx, input)}
+}
+
+func (_c *UsersUsecaseIface_AdminSetUserOrganization_Call) Run(run func(ctx context.Context, input usecase.AdminSetUserOrganizationInput)) *UsersUsecaseIface_AdminSetUserOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.AdminSetUserOrganizationInput))
+	})
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminSetUserOrganization_Call) Return(_a0 *usecase.AdminUpdateUserOutput, _a1 error) *UsersUsecaseIface_AdminSetUserOrganization_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminSetUserOrganization_Call) RunAndReturn(run func(context.Context, usecase.AdminSetUserOrganizationInput) (*usecase.AdminUpdateUserOutput, error)) *UsersUsecaseIface_AdminSetUserOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AdminUnlockUser provides a mock function with given fields: ctx, input
+func (_m *UsersUsecaseIface) AdminUnlockUser(ctx context.Context, input usecase.AdminUnlockUserInput) (*usecase.AdminUpdateUserOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdminUnlockUser")
+	}
+
+	var r0 *usecase.AdminUpdateUserOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminUnlockUserInput) (*usecase.AdminUpdateUserOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.AdminUnlockUserInput) *usecase.AdminUpdateUserOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.AdminUpdateUserOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.AdminUnlockUserInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersUsecaseIface_AdminUnlockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdminUnlockUser'
+type UsersUsecaseIface_AdminUnlockUser_Call struct {
+	*mock.Call
+}
+
+// AdminUnlockUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.AdminUnlockUserInput
+func (_e *UsersUsecaseIface_Expecter) AdminUnlockUser(ctx interface{}, input interface{}) *UsersUsecaseIface_AdminUnlockUser_Call {
+	return &UsersUsecaseIface_AdminUnlockUser_Call{Call: _e.mock.On("AdminUnlockUser", ctx, input)}
+}
+
+func (_c *UsersUsecaseIface_AdminUnlockUser_Call) Run(run func(ctx context.Context, input usecase.AdminUnlockUserInput)) *UsersUsecaseIface_AdminUnlockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.AdminUnlockUserInput))
+	})
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminUnlockUser_Call) Return(_a0 *usecase.AdminUpdateUserOutput, _a1 error) *UsersUsecaseIface_AdminUnlockUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsersUsecaseIface_AdminUnlockUser_Call) RunAndReturn(run func(context.Context, usecase.AdminUnlockUserInput) (*usecase.AdminUpdateUserOutput, error)) *UsersUsecaseIface_AdminUnlockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetMyProfile provides a mock function with given fields: ctx
 func (_m *UsersUsecaseIface) GetMyProfile(ctx context.Context) (*usecase.GetMyProfileOutput, error) {
 	ret := _m.Called(ctx)
@@ -138,6 +668,345 @@ func (_c *UsersUsecaseIface_GetTherapistData_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// HandleDownloadDataExport provides a mock function with given fields: ctx, input
+func (_m *UsersUsecaseIface) HandleDownloadDataExport(ctx context.Context, input usecase.DownloadDataExportInput) (*usecase.DownloadDataExportOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleDownloadDataExport")
+	}
+
+	var r0 *usecase.DownloadDataExportOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.DownloadDataExportInput) (*usecase.DownloadDataExportOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.DownloadDataExportInput) *usecase.DownloadDataExportOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.DownloadDataExportOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.DownloadDataExportInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersUsecaseIface_HandleDownloadDataExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleDownloadDataExport'
+type UsersUsecaseIface_HandleDownloadDataExport_Call struct {
+	*mock.Call
+}
+
+// HandleDownloadDataExport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.DownloadDataExportInput
+func (_e *UsersUsecaseIface_Expecter) HandleDownloadDataExport(ctx interface{}, input interface{}) *UsersUsecaseIface_HandleDownloadDataExport_Call {
+	return &UsersUsecaseIface_HandleDownloadDataExport_Call{Call: _e.mock.On("HandleDownloadDataExport", ctx, input)}
+}
+
+func (_c *UsersUsecaseIface_HandleDownloadDataExport_Call) Run(run func(ctx context.Context, input usecase.DownloadDataExportInput)) *UsersUsecaseIface_HandleDownloadDataExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.DownloadDataExportInput))
+	})
+	return _c
+}
+
+func (_c *UsersUsecaseIface_HandleDownloadDataExport_Call) Return(_a0 *usecase.DownloadDataExportOutput, _a1 error) *UsersUsecaseIface_HandleDownloadDataExport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsersUsecaseIface_HandleDownloadDataExport_Call) RunAndReturn(run func(context.Context, usecase.DownloadDataExportInput) (*usecase.DownloadDataExportOutput, error)) *UsersUsecaseIface_HandleDownloadDataExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleGrantTherapistAccess provides a mock function with given fields: ctx, input
+func (_m *UsersUsecaseIface) HandleGrantTherapistAccess(ctx context.Context, input usecase.GrantTherapistAccessInput) (*usecase.TherapistAccessOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleGrantTherapistAccess")
+	}
+
+	var r0 *usecase.TherapistAccessOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.GrantTherapistAccessInput) (*usecase.TherapistAccessOutput, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.GrantTherapistAccessInput) *usecase.TherapistAccessOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.TherapistAccessOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.GrantTherapistAccessInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersUsecaseIface_HandleGrantTherapistAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleGrantTherapistAccess'
+type UsersUsecaseIface_HandleGrantTherapistAccess_Call struct {
+	*mock.Call
+}
+
+// HandleGrantTherapistAccess is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.GrantTherapistAccessInput
+func (_e *UsersUsecaseIface_Expecter) HandleGrantTherapistAccess(ctx interface{}, input interface{}) *UsersUsecaseIface_HandleGrantTherapistAccess_Call {
+	return &UsersUsecaseIface_HandleGrantTherapistAccess_Call{Call: _e.mock.On("HandleGrantTherapistAccess", ctx, input)}
+}
+
+func (_c *UsersUsecaseIface_HandleGrantTherapistAccess_Call) Run(run func(ctx context.Context, input usecase.GrantTherapistAccessInput)) *UsersUsecaseIface_HandleGrantTherapistAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.GrantTherapistAccessInput))
+	})
+	return _c
+}
+
+func (_c *UsersUsecaseIface_HandleGrantTherapistAccess_Call) Return(_a0 *usecase.TherapistAccessOutput, _a1 error) *UsersUsecaseIface_HandleGrantTherapistAccess_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsersUsecaseIface_HandleGrantTherapistAccess_Call) RunAndReturn(run func(context.Context, usecase.GrantTherapistAccessInput) (*usecase.TherapistAccessOutput, error)) *UsersUsecaseIface_HandleGrantTherapistAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleListGrantedTherapistAccess provides a mock function with given fields: ctx
+func (_m *UsersUsecaseIface) HandleListGrantedTherapistAccess(ctx context.Context) ([]usecase.TherapistAccessOutput, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleListGrantedTherapistAccess")
+	}
+
+	var r0 []usecase.TherapistAccessOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]usecase.TherapistAccessOutput, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []usecase.TherapistAccessOutput); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]usecase.TherapistAccessOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersUsecaseIface_HandleListGrantedTherapistAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleListGrantedTherapistAccess'
+type UsersUsecaseIface_HandleListGrantedTherapistAccess_Call struct {
+	*mock.Call
+}
+
+// HandleListGrantedTherapistAccess is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *UsersUsecaseIface_Expecter) HandleListGrantedTherapistAccess(ctx interface{}) *UsersUsecaseIface_HandleListGrantedTherapistAccess_Call {
+	return &UsersUsecaseIface_HandleListGrantedTherapistAccess_Call{Call: _e.mock.On("HandleListGrantedTherapistAccess", ctx)}
+}
+
+func (_c *UsersUsecaseIface_HandleListGrantedTherapistAccess_Call) Run(run func(ctx context.Context)) *UsersUsecaseIface_HandleListGrantedTherapistAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *UsersUsecaseIface_HandleListGrantedTherapistAccess_Call) Return(_a0 []usecase.TherapistAccessOutput, _a1 error) *UsersUsecaseIface_HandleListGrantedTherapistAccess_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsersUsecaseIface_HandleListGrantedTherapistAccess_Call) RunAndReturn(run func(context.Context) ([]usecase.TherapistAccessOutput, error)) *UsersUsecaseIface_HandleListGrantedTherapistAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleListMyDataExports provides a mock function with given fields: ctx
+func (_m *UsersUsecaseIface) HandleListMyDataExports(ctx context.Context) ([]usecase.DataExportOutput, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleListMyDataExports")
+	}
+
+	var r0 []usecase.DataExportOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]usecase.DataExportOutput, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []usecase.DataExportOutput); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]usecase.DataExportOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersUsecaseIface_HandleListMyDataExports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleListMyDataExports'
+type UsersUsecaseIface_HandleListMyDataExports_Call struct {
+	*mock.Call
+}
+
+// HandleListMyDataExports is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *UsersUsecaseIface_Expecter) HandleListMyDataExports(ctx interface{}) *UsersUsecaseIface_HandleListMyDataExports_Call {
+	return &UsersUsecaseIface_HandleListMyDataExports_Call{Call: _e.mock.On("HandleListMyDataExports", ctx)}
+}
+
+func (_c *UsersUsecaseIface_HandleListMyDataExports_Call) Run(run func(ctx context.Context)) *UsersUsecaseIface_HandleListMyDataExports_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *UsersUsecaseIface_HandleListMyDataExports_Call) Return(_a0 []usecase.DataExportOutput, _a1 error) *UsersUsecaseIface_HandleListMyDataExports_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsersUsecaseIface_HandleListMyDataExports_Call) RunAndReturn(run func(context.Context) ([]usecase.DataExportOutput, error)) *UsersUsecaseIface_HandleListMyDataExports_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleRequestDataExport provides a mock function with given fields: ctx
+func (_m *UsersUsecaseIface) HandleRequestDataExport(ctx context.Context) (*usecase.DataExportOutput, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleRequestDataExport")
+	}
+
+	var r0 *usecase.DataExportOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*usecase.DataExportOutput, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *usecase.DataExportOutput); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.DataExportOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersUsecaseIface_HandleRequestDataExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleRequestDataExport'
+type UsersUsecaseIface_HandleRequestDataExport_Call struct {
+	*mock.Call
+}
+
+// HandleRequestDataExport is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *UsersUsecaseIface_Expecter) HandleRequestDataExport(ctx interface{}) *UsersUsecaseIface_HandleRequestDataExport_Call {
+	return &UsersUsecaseIface_HandleRequestDataExport_Call{Call: _e.mock.On("HandleRequestDataExport", ctx)}
+}
+
+func (_c *UsersUsecaseIface_HandleRequestDataExport_Call) Run(run func(ctx context.Context)) *UsersUsecaseIface_HandleRequestDataExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *UsersUsecaseIface_HandleRequestDataExport_Call) Return(_a0 *usecase.DataExportOutput, _a1 error) *UsersUsecaseIface_HandleRequestDataExport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsersUsecaseIface_HandleRequestDataExport_Call) RunAndReturn(run func(context.Context) (*usecase.DataExportOutput, error)) *UsersUsecaseIface_HandleRequestDataExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleRevokeTherapistAccess provides a mock function with given fields: ctx, input
+func (_m *UsersUsecaseIface) HandleRevokeTherapistAccess(ctx context.Context, input usecase.RevokeTherapistAccessInput) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleRevokeTherapistAccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.RevokeTherapistAccessInput) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UsersUsecaseIface_HandleRevokeTherapistAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleRevokeTherapistAccess'
+type UsersUsecaseIface_HandleRevokeTherapistAccess_Call struct {
+	*mock.Call
+}
+
+// HandleRevokeTherapistAccess is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input usecase.RevokeTherapistAccessInput
+func (_e *UsersUsecaseIface_Expecter) HandleRevokeTherapistAccess(ctx interface{}, input interface{}) *UsersUsecaseIface_HandleRevokeTherapistAccess_Call {
+	return &UsersUsecaseIface_HandleRevokeTherapistAccess_Call{Call: _e.mock.On("HandleRevokeTherapistAccess", ctx, input)}
+}
+
+func (_c *UsersUsecaseIface_HandleRevokeTherapistAccess_Call) Run(run func(ctx context.Context, input usecase.RevokeTherapistAccessInput)) *UsersUsecaseIface_HandleRevokeTherapistAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.RevokeTherapistAccessInput))
+	})
+	return _c
+}
+
+func (_c *UsersUsecaseIface_HandleRevokeTherapistAccess_Call) Return(_a0 error) *UsersUsecaseIface_HandleRevokeTherapistAccess_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UsersUsecaseIface_HandleRevokeTherapistAccess_Call) RunAndReturn(run func(context.Context, usecase.RevokeTherapistAccessInput) error) *UsersUsecaseIface_HandleRevokeTherapistAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateMyProfile provides a mock function with given fields: ctx, input
 func (_m *UsersUsecaseIface) UpdateMyProfile(ctx context.Context, input usecase.UpdateMyProfileInput) (*usecase.UpdateMyProfileOutput, error) {
 	ret := _m.Called(ctx, input)